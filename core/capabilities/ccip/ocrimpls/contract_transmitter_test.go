@@ -546,6 +546,11 @@ func (t *TestHeadTrackerConfig) PersistenceEnabled() bool {
 	return true
 }
 
+// FinalityProvider implements config.HeadTracker.
+func (t *TestHeadTrackerConfig) FinalityProvider() evmconfig.FinalityProvider {
+	return nil
+}
+
 var _ evmconfig.HeadTracker = (*TestHeadTrackerConfig)(nil)
 
 type TestEvmConfig struct {
@@ -624,6 +629,14 @@ func (g *TestGasEstimatorConfig) FeeHistory() evmconfig.FeeHistory {
 	return &TestFeeHistoryConfig{}
 }
 
+func (g *TestGasEstimatorConfig) Composite() evmconfig.Composite {
+	return &TestCompositeConfig{}
+}
+
+func (g *TestGasEstimatorConfig) FeeAPI() evmconfig.FeeAPI {
+	return &TestFeeAPIConfig{}
+}
+
 func (g *TestGasEstimatorConfig) EIP1559DynamicFees() bool   { return false }
 func (g *TestGasEstimatorConfig) LimitDefault() uint64       { return 1e6 }
 func (g *TestGasEstimatorConfig) BumpPercent() uint16        { return 2 }
@@ -676,6 +689,14 @@ type TestFeeHistoryConfig struct {
 	evmconfig.FeeHistory
 }
 
+type TestCompositeConfig struct {
+	evmconfig.Composite
+}
+
+type TestFeeAPIConfig struct {
+	evmconfig.FeeAPI
+}
+
 type transactionsConfig struct {
 	evmconfig.Transactions
 	e         *TestEvmConfig
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"errors"
+	"fmt"
 	"math/big"
 	"slices"
 
@@ -77,7 +78,10 @@ func (e *ServiceWrapper) Start(ctx context.Context) error {
 		if idx == -1 {
 			return errors.New("key for configured node address not found")
 		}
-		e.signerKey = enabledKeys[idx].ToEcdsaPrivKey()
+		e.signerKey, err = enabledKeys[idx].ToEcdsaPrivKey()
+		if err != nil {
+			return fmt.Errorf("key for configured node address: %w", err)
+		}
 		if enabledKeys[idx].ID() != nodeAddress {
 			return errors.New("node address mismatch")
 		}
@@ -200,7 +200,12 @@ func (f *FwdMgr) getContractSenders(ctx context.Context, addr common.Address) ([
 }
 
 func (f *FwdMgr) getAuthorizedSenders(ctx context.Context, addr common.Address) ([]common.Address, error) {
-	c, err := authorized_receiver.NewAuthorizedReceiverCaller(addr, f.evmClient)
+	return AuthorizedSenders(ctx, f.evmClient, addr)
+}
+
+// AuthorizedSenders calls getAuthorizedSenders on the forwarder at addr.
+func AuthorizedSenders(ctx context.Context, caller bind.ContractCaller, addr common.Address) ([]common.Address, error) {
+	c, err := authorized_receiver.NewAuthorizedReceiverCaller(addr, caller)
 	if err != nil {
 		return nil, pkgerrors.Wrap(err, "Failed to init forwarder caller")
 	}
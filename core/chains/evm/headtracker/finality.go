@@ -0,0 +1,483 @@
+package headtracker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	commontypes "github.com/smartcontractkit/chainlink-framework/chains/headtracker/types"
+
+	httypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/headtracker/types"
+	evmconfig "github.com/smartcontractkit/chainlink/v2/evm/config"
+	"github.com/smartcontractkit/chainlink/v2/evm/config/toml"
+	evmtypes "github.com/smartcontractkit/chainlink/v2/evm/types"
+)
+
+// FinalityProvider determines the latest finalized block of a chain.
+type FinalityProvider interface {
+	// Type returns the kind of finality determined by the provider.
+	Type() toml.FinalityProviderType
+	// LatestFinalizedBlock returns the latest finalized block.
+	LatestFinalizedBlock(ctx context.Context) (*evmtypes.Head, error)
+	// Close releases the connections of the provider to other chains, if any.
+	Close() error
+}
+
+// FinalityClient is the client of the chain the FinalityProvider determines the finality of.
+type FinalityClient interface {
+	HeadByNumber(ctx context.Context, n *big.Int) (*evmtypes.Head, error)
+	LatestFinalizedBlock(ctx context.Context) (*evmtypes.Head, error)
+	CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+}
+
+// FinalityConfig is the chain config determining finality when no finality provider type is configured.
+type FinalityConfig interface {
+	FinalityDepth() uint32
+	FinalityTagEnabled() bool
+}
+
+// NewFinalityProvider returns the FinalityProvider of the configured type. When no type is configured, finality is
+// determined by the finalized tag if FinalityTagEnabled, and by FinalityDepth otherwise.
+func NewFinalityProvider(lggr logger.Logger, client FinalityClient, cfg FinalityConfig, fpCfg evmconfig.FinalityProvider) (FinalityProvider, error) {
+	typ := fpCfg.Type()
+	if typ == "" {
+		typ = toml.FinalityProviderDepth
+		if cfg.FinalityTagEnabled() {
+			typ = toml.FinalityProviderTag
+		}
+	}
+	switch typ {
+	case toml.FinalityProviderDepth:
+		return NewDepthFinality(client, cfg.FinalityDepth()), nil
+	case toml.FinalityProviderTag:
+		return NewTagFinality(client), nil
+	case toml.FinalityProviderOPStack:
+		if fpCfg.L1URL() == nil || fpCfg.ContractAddress() == nil {
+			return nil, errors.New("opstack finality provider requires L1URL and ContractAddress")
+		}
+		return NewOPStackFinality(lggr, client, fpCfg.L1URL(), fpCfg.ContractAddress().Address()), nil
+	case toml.FinalityProviderArbitrum:
+		return NewArbitrumFinality(lggr, client, fpCfg.L1Confirmations()), nil
+	default:
+		return nil, fmt.Errorf("unsupported finality provider type: %s", typ)
+	}
+}
+
+type depthFinality struct {
+	client FinalityClient
+	depth  int64
+}
+
+// NewDepthFinality returns a FinalityProvider considering the block depth blocks below the latest head finalized.
+func NewDepthFinality(client FinalityClient, depth uint32) FinalityProvider {
+	return &depthFinality{client: client, depth: int64(depth)}
+}
+
+func (f *depthFinality) Type() toml.FinalityProviderType { return toml.FinalityProviderDepth }
+
+func (f *depthFinality) Close() error { return nil }
+
+func (f *depthFinality) LatestFinalizedBlock(ctx context.Context) (*evmtypes.Head, error) {
+	latest, err := f.client.HeadByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest block: %w", err)
+	}
+	if latest == nil {
+		return nil, errors.New("expected latest block to be valid")
+	}
+	if f.depth == 0 {
+		return latest, nil
+	}
+	return f.client.HeadByNumber(ctx, big.NewInt(max(latest.Number-f.depth, 0)))
+}
+
+type tagFinality struct {
+	client FinalityClient
+}
+
+// NewTagFinality returns a FinalityProvider using the block returned by the RPC for the finalized tag.
+func NewTagFinality(client FinalityClient) FinalityProvider {
+	return &tagFinality{client: client}
+}
+
+func (f *tagFinality) Type() toml.FinalityProviderType { return toml.FinalityProviderTag }
+
+func (f *tagFinality) Close() error { return nil }
+
+func (f *tagFinality) LatestFinalizedBlock(ctx context.Context) (*evmtypes.Head, error) {
+	return f.client.LatestFinalizedBlock(ctx)
+}
+
+var (
+	// optimismPortalDisputeGameFactory is the selector of OptimismPortal2.disputeGameFactory(), the address of the
+	// DisputeGameFactory creating the games of the portal.
+	optimismPortalDisputeGameFactory = crypto.Keccak256([]byte("disputeGameFactory()"))[:4]
+	// optimismPortalRespectedGameType is the selector of OptimismPortal2.respectedGameType(), the type of the games
+	// withdrawals may be proven against.
+	optimismPortalRespectedGameType = crypto.Keccak256([]byte("respectedGameType()"))[:4]
+	// optimismPortalRespectedGameTypeUpdatedAt is the selector of OptimismPortal2.respectedGameTypeUpdatedAt(), the
+	// timestamp of the last update of the respected game type. Games created before are not respected.
+	optimismPortalRespectedGameTypeUpdatedAt = crypto.Keccak256([]byte("respectedGameTypeUpdatedAt()"))[:4]
+	// optimismPortalDisputeGameFinalityDelaySeconds is the selector of
+	// OptimismPortal2.disputeGameFinalityDelaySeconds(), the delay after its resolution before a game is finalized.
+	optimismPortalDisputeGameFinalityDelaySeconds = crypto.Keccak256([]byte("disputeGameFinalityDelaySeconds()"))[:4]
+	// disputeGameFactoryGameCount is the selector of DisputeGameFactory.gameCount(), the number of dispute games created.
+	disputeGameFactoryGameCount = crypto.Keccak256([]byte("gameCount()"))[:4]
+	// disputeGameFactoryGameAtIndex is the selector of DisputeGameFactory.gameAtIndex(uint256), the type, creation
+	// timestamp and proxy address of a dispute game.
+	disputeGameFactoryGameAtIndex = crypto.Keccak256([]byte("gameAtIndex(uint256)"))[:4]
+	// disputeGameStatus is the selector of IDisputeGame.status(), whether the game is in progress or which side won.
+	disputeGameStatus = crypto.Keccak256([]byte("status()"))[:4]
+	// disputeGameResolvedAt is the selector of IDisputeGame.resolvedAt(), the timestamp of the resolution of the game.
+	disputeGameResolvedAt = crypto.Keccak256([]byte("resolvedAt()"))[:4]
+	// disputeGameL2BlockNumber is the selector of IDisputeGame.l2BlockNumber(), the L2 block of the output proposed by
+	// a dispute game.
+	disputeGameL2BlockNumber = crypto.Keccak256([]byte("l2BlockNumber()"))[:4]
+)
+
+// disputeGameStatusDefenderWins is the GameStatus of a game whose proposed output was not successfully challenged.
+const disputeGameStatusDefenderWins = 2
+
+type opStackFinality struct {
+	lggr   logger.Logger
+	client FinalityClient
+	l1URL  *url.URL
+	portal common.Address
+
+	l1Mu     sync.Mutex
+	l1       *ethclient.Client
+	l1Closed bool
+
+	mu sync.Mutex
+	// finalized is the latest game found finalized, which bounds the search of the next one as finality only advances
+	finalized *opStackGame
+}
+
+// opStackGame is a dispute game finalized by the OptimismPortal.
+type opStackGame struct {
+	index   int64
+	proxy   common.Address
+	l2Block *big.Int
+}
+
+// NewOPStackFinality returns a FinalityProvider considering the L2 block of the latest dispute game finalized by the
+// OptimismPortal2 contract at portal, as of the finalized L1 block: a game of the respected game type, resolved in
+// favor of the defender for longer than the finality delay of the portal. The L1 RPC at l1URL is dialed on first use,
+// and closed by Close.
+func NewOPStackFinality(lggr logger.Logger, client FinalityClient, l1URL *url.URL, portal common.Address) FinalityProvider {
+	return &opStackFinality{lggr: logger.Named(lggr, "OPStackFinality"), client: client, l1URL: l1URL, portal: portal}
+}
+
+func (f *opStackFinality) Type() toml.FinalityProviderType { return toml.FinalityProviderOPStack }
+
+// LatestFinalizedBlock walks the games of the DisputeGameFactory back from the newest one, until the latest finalized
+// game or the one found by the previous call. The L2 block of the game is capped at the latest L2 block.
+func (f *opStackFinality) LatestFinalizedBlock(ctx context.Context) (*evmtypes.Head, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	l1, err := f.dialL1(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// The portal and the games are read at a fixed finalized block, so that they are consistent with each other
+	var finalized struct {
+		Number    hexutil.Big    `json:"number"`
+		Timestamp hexutil.Uint64 `json:"timestamp"`
+	}
+	if err = l1.Client().CallContext(ctx, &finalized, "eth_getBlockByNumber", "finalized", false); err != nil {
+		return nil, fmt.Errorf("failed to get finalized L1 block: %w", err)
+	}
+	l1Block := finalized.Number.ToInt()
+
+	factory, err := f.callUint(ctx, l1, f.portal, optimismPortalDisputeGameFactory, l1Block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read DisputeGameFactory of OptimismPortal %s: %w", f.portal, err)
+	}
+	factoryAddr := common.BigToAddress(factory)
+	gameType, err := f.callUint(ctx, l1, f.portal, optimismPortalRespectedGameType, l1Block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read respected game type of OptimismPortal %s: %w", f.portal, err)
+	}
+	gameTypeUpdatedAt, err := f.callUint(ctx, l1, f.portal, optimismPortalRespectedGameTypeUpdatedAt, l1Block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read respected game type update of OptimismPortal %s: %w", f.portal, err)
+	}
+	delay, err := f.callUint(ctx, l1, f.portal, optimismPortalDisputeGameFinalityDelaySeconds, l1Block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read finality delay of OptimismPortal %s: %w", f.portal, err)
+	}
+	// games resolved before finalizedAt are past the finality delay
+	finalizedAt := new(big.Int).Sub(new(big.Int).SetUint64(uint64(finalized.Timestamp)), delay)
+
+	count, err := f.callUint(ctx, l1, factoryAddr, disputeGameFactoryGameCount, l1Block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read game count from DisputeGameFactory %s: %w", factoryAddr, err)
+	}
+	if !count.IsInt64() {
+		return nil, fmt.Errorf("unexpected game count %s of DisputeGameFactory %s", count, factoryAddr)
+	}
+	game := f.finalized
+	for index := count.Int64() - 1; game == nil || index > game.index; index-- {
+		if index < 0 {
+			return nil, fmt.Errorf("no finalized dispute game created by DisputeGameFactory %s at L1 block %s", factoryAddr, l1Block)
+		}
+		var ok bool
+		var g opStackGame
+		g, ok, err = f.gameAt(ctx, l1, factoryAddr, index, l1Block, gameType, gameTypeUpdatedAt, finalizedAt)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			game = &g
+			break
+		}
+	}
+	if f.finalized == nil || game.index != f.finalized.index {
+		f.lggr.Debugw("Found latest finalized dispute game", "index", game.index, "game", game.proxy, "l2BlockNumber", game.l2Block, "l1BlockNumber", l1Block)
+	}
+	f.finalized = game
+
+	latest, err := f.client.HeadByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest block: %w", err)
+	}
+	if latest == nil {
+		return nil, errors.New("expected latest block to be valid")
+	}
+	if game.l2Block.Cmp(big.NewInt(latest.Number)) >= 0 {
+		return latest, nil
+	}
+	return f.client.HeadByNumber(ctx, game.l2Block)
+}
+
+// gameAt returns the game at index of the factory, and whether it is finalized: of the respected game type, created
+// after the respected game type was set, and resolved in favor of the defender before finalizedAt.
+func (f *opStackFinality) gameAt(ctx context.Context, l1 *ethclient.Client, factory common.Address, index int64, l1Block *big.Int,
+	gameType, gameTypeUpdatedAt, finalizedAt *big.Int) (opStackGame, bool, error) {
+	data := append(append([]byte{}, disputeGameFactoryGameAtIndex...), common.BigToHash(big.NewInt(index)).Bytes()...)
+	result, err := l1.CallContract(ctx, ethereum.CallMsg{To: &factory, Data: data}, l1Block)
+	if err != nil {
+		return opStackGame{}, false, fmt.Errorf("failed to read game %d from DisputeGameFactory %s: %w", index, factory, err)
+	}
+	if len(result) != 96 {
+		return opStackGame{}, false, fmt.Errorf("unexpected DisputeGameFactory gameAtIndex result: %x", result)
+	}
+	game := opStackGame{index: index, proxy: common.BytesToAddress(result[64:])}
+	if new(big.Int).SetBytes(result[:32]).Cmp(gameType) != 0 || new(big.Int).SetBytes(result[32:64]).Cmp(gameTypeUpdatedAt) < 0 {
+		return game, false, nil
+	}
+	status, err := f.callUint(ctx, l1, game.proxy, disputeGameStatus, l1Block)
+	if err != nil {
+		return game, false, fmt.Errorf("failed to read status of dispute game %s: %w", game.proxy, err)
+	}
+	if status.Cmp(big.NewInt(disputeGameStatusDefenderWins)) != 0 {
+		return game, false, nil
+	}
+	resolvedAt, err := f.callUint(ctx, l1, game.proxy, disputeGameResolvedAt, l1Block)
+	if err != nil {
+		return game, false, fmt.Errorf("failed to read resolution of dispute game %s: %w", game.proxy, err)
+	}
+	if resolvedAt.Cmp(finalizedAt) >= 0 {
+		return game, false, nil
+	}
+	if game.l2Block, err = f.callUint(ctx, l1, game.proxy, disputeGameL2BlockNumber, l1Block); err != nil {
+		return game, false, fmt.Errorf("failed to read L2 block number of dispute game %s: %w", game.proxy, err)
+	}
+	return game, true, nil
+}
+
+func (f *opStackFinality) callUint(ctx context.Context, l1 *ethclient.Client, to common.Address, selector []byte, l1Block *big.Int) (*big.Int, error) {
+	result, err := l1.CallContract(ctx, ethereum.CallMsg{To: &to, Data: selector}, l1Block)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) != 32 {
+		return nil, fmt.Errorf("unexpected result: %x", result)
+	}
+	return new(big.Int).SetBytes(result), nil
+}
+
+func (f *opStackFinality) dialL1(ctx context.Context) (*ethclient.Client, error) {
+	f.l1Mu.Lock()
+	defer f.l1Mu.Unlock()
+	if f.l1Closed {
+		return nil, errors.New("finality provider is closed")
+	}
+	if f.l1 != nil {
+		return f.l1, nil
+	}
+	c, err := rpc.DialContext(ctx, f.l1URL.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial L1 RPC %s: %w", f.l1URL.Redacted(), err)
+	}
+	f.lggr.Debugw("Dialed L1 RPC", "l1URL", f.l1URL.Redacted())
+	f.l1 = ethclient.NewClient(c)
+	return f.l1, nil
+}
+
+func (f *opStackFinality) Close() error {
+	f.l1Mu.Lock()
+	defer f.l1Mu.Unlock()
+	f.l1Closed = true
+	if f.l1 != nil {
+		f.l1.Close()
+		f.l1 = nil
+	}
+	return nil
+}
+
+// arbitrumNodeInterface is the address of the virtual NodeInterface contract of Arbitrum chains, only callable by
+// eth_call.
+var arbitrumNodeInterface = common.HexToAddress("0x00000000000000000000000000000000000000C8")
+
+// nodeInterfaceGetL1Confirmations is the selector of NodeInterface.getL1Confirmations(bytes32), the number of L1
+// confirmations of the batch of the L2 block with the given hash.
+var nodeInterfaceGetL1Confirmations = crypto.Keccak256([]byte("getL1Confirmations(bytes32)"))[:4]
+
+type arbitrumFinality struct {
+	lggr          logger.Logger
+	client        FinalityClient
+	confirmations uint64
+
+	mu sync.Mutex
+	// settled is the latest block found finalized, which bounds the search of the next one as finality only advances
+	settled *evmtypes.Head
+}
+
+// NewArbitrumFinality returns a FinalityProvider considering the L2 blocks whose batch has at least confirmations L1
+// confirmations finalized.
+func NewArbitrumFinality(lggr logger.Logger, client FinalityClient, confirmations uint32) FinalityProvider {
+	return &arbitrumFinality{lggr: logger.Named(lggr, "ArbitrumFinality"), client: client, confirmations: uint64(confirmations)}
+}
+
+func (f *arbitrumFinality) Type() toml.FinalityProviderType { return toml.FinalityProviderArbitrum }
+
+func (f *arbitrumFinality) Close() error { return nil }
+
+// LatestFinalizedBlock searches the latest settled block forward from the previous one, as finality only advances by a
+// few blocks between heads, or backward from the latest block when there is none. The search doubles its step until it
+// overshoots, then bisects the last step.
+func (f *arbitrumFinality) LatestFinalizedBlock(ctx context.Context) (*evmtypes.Head, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	latest, err := f.client.HeadByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest block: %w", err)
+	}
+	if latest == nil {
+		return nil, errors.New("expected latest block to be valid")
+	}
+
+	// lo is settled and hi is not, or is past the latest block
+	lo, hi := int64(0), latest.Number+1
+	var settled *evmtypes.Head
+	if f.settled != nil && f.settled.Number <= latest.Number {
+		lo, settled = f.settled.Number, f.settled
+		for base, step := lo, int64(1); base+step < hi; step *= 2 {
+			head, ok, err := f.probe(ctx, base+step, latest)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				hi = base + step
+				break
+			}
+			lo, settled = base+step, head
+		}
+	} else {
+		for step := int64(0); latest.Number-step > 0; step = max(1, 2*step) {
+			head, ok, err := f.probe(ctx, latest.Number-step, latest)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				lo, settled = latest.Number-step, head
+				break
+			}
+			hi = latest.Number - step
+		}
+	}
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		head, ok, err := f.probe(ctx, mid, latest)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			lo, settled = mid, head
+		} else {
+			hi = mid
+		}
+	}
+	if settled == nil {
+		if settled, err = f.client.HeadByNumber(ctx, big.NewInt(lo)); err != nil {
+			return nil, fmt.Errorf("failed to get block %d: %w", lo, err)
+		}
+	}
+	if f.settled == nil || settled.Number != f.settled.Number {
+		f.lggr.Debugw("Found latest settled block", "blockNumber", settled.Number, "latestBlockNumber", latest.Number)
+	}
+	f.settled = settled
+	return settled, nil
+}
+
+// probe returns block n and whether it is settled.
+func (f *arbitrumFinality) probe(ctx context.Context, n int64, latest *evmtypes.Head) (*evmtypes.Head, bool, error) {
+	head := latest
+	if n != latest.Number {
+		var err error
+		if head, err = f.client.HeadByNumber(ctx, big.NewInt(n)); err != nil {
+			return nil, false, fmt.Errorf("failed to get block %d: %w", n, err)
+		}
+		if head == nil {
+			return nil, false, fmt.Errorf("expected block %d to be valid", n)
+		}
+	}
+	ok, err := f.isSettled(ctx, head)
+	return head, ok, err
+}
+
+func (f *arbitrumFinality) isSettled(ctx context.Context, head *evmtypes.Head) (bool, error) {
+	data := append(append([]byte{}, nodeInterfaceGetL1Confirmations...), head.Hash.Bytes()...)
+	result, err := f.client.CallContract(ctx, ethereum.CallMsg{To: &arbitrumNodeInterface, Data: data}, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to get L1 confirmations of block %d: %w", head.Number, err)
+	}
+	if len(result) != 32 {
+		return false, fmt.Errorf("unexpected NodeInterface getL1Confirmations result: %x", result)
+	}
+	confirmations := new(big.Int).SetBytes(result)
+	return confirmations.IsUint64() && confirmations.Uint64() >= f.confirmations, nil
+}
+
+// finalityClient is the client of the head tracker returning the latest finalized block of a FinalityProvider.
+type finalityClient struct {
+	httypes.Client
+	finality FinalityProvider
+}
+
+func (c *finalityClient) LatestFinalizedBlock(ctx context.Context) (*evmtypes.Head, error) {
+	return c.finality.LatestFinalizedBlock(ctx)
+}
+
+// finalityConfig is the config of the head tracker making it use the finality of the client rather than FinalityDepth.
+type finalityConfig struct {
+	commontypes.Config
+	tagEnabled bool
+}
+
+func (c *finalityConfig) FinalityTagEnabled() bool { return c.tagEnabled }
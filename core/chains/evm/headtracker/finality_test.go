@@ -0,0 +1,240 @@
+package headtracker_test
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	commonconfig "github.com/smartcontractkit/chainlink-common/pkg/config"
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/headtracker"
+	"github.com/smartcontractkit/chainlink/v2/evm/client/clienttest"
+	"github.com/smartcontractkit/chainlink/v2/evm/config/toml"
+	"github.com/smartcontractkit/chainlink/v2/evm/testutils"
+	evmtypes "github.com/smartcontractkit/chainlink/v2/evm/types"
+)
+
+func TestNewFinalityProvider(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		name        string
+		finalityTag bool
+		typ         *toml.FinalityProviderType
+		expected    toml.FinalityProviderType
+	}{
+		{name: "depth by default", expected: toml.FinalityProviderDepth},
+		{name: "tag with FinalityTagEnabled", finalityTag: true, expected: toml.FinalityProviderTag},
+		{name: "configured type", finalityTag: true, typ: ptr(toml.FinalityProviderArbitrum), expected: toml.FinalityProviderArbitrum},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testutils.NewTestChainScopedConfig(t, func(c *toml.EVMConfig) {
+				c.FinalityTagEnabled = ptr(tt.finalityTag)
+				c.HeadTracker.FinalityProvider.Type = tt.typ
+			})
+			finality, err := headtracker.NewFinalityProvider(logger.Test(t), clienttest.NewClient(t), cfg.EVM(), cfg.EVM().HeadTracker().FinalityProvider())
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, finality.Type())
+		})
+	}
+}
+
+func TestDepthFinality(t *testing.T) {
+	t.Parallel()
+
+	client := clienttest.NewClient(t)
+	client.On("HeadByNumber", mock.Anything, (*big.Int)(nil)).Return(testutils.Head(100), nil).Once()
+	finalized := testutils.Head(90)
+	client.On("HeadByNumber", mock.Anything, big.NewInt(90)).Return(finalized, nil).Once()
+
+	head, err := headtracker.NewDepthFinality(client, 10).LatestFinalizedBlock(tests.Context(t))
+	require.NoError(t, err)
+	assert.Equal(t, finalized, head)
+}
+
+func TestArbitrumFinality(t *testing.T) {
+	t.Parallel()
+
+	settled := int64(37)
+	heads := map[int64]*evmtypes.Head{}
+	hashes := map[common.Hash]int64{}
+	for i := int64(0); i <= 100; i++ {
+		heads[i] = testutils.Head(i)
+		hashes[heads[i].Hash] = i
+	}
+	client := clienttest.NewClient(t)
+	client.On("HeadByNumber", mock.Anything, mock.Anything).Return(func(_ context.Context, n *big.Int) (*evmtypes.Head, error) {
+		if n == nil {
+			return heads[100], nil
+		}
+		return heads[n.Int64()], nil
+	})
+	var calls atomic.Int32
+	client.On("CallContract", mock.Anything, mock.Anything, (*big.Int)(nil)).Return(func(_ context.Context, msg ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+		calls.Add(1)
+		assert.Equal(t, common.HexToAddress("0xC8"), *msg.To)
+		require.Len(t, msg.Data, 36)
+		confirmations := uint64(100)
+		if hashes[common.BytesToHash(msg.Data[4:])] > settled {
+			confirmations = 10
+		}
+		return common.BigToHash(new(big.Int).SetUint64(confirmations)).Bytes(), nil
+	})
+
+	finality := headtracker.NewArbitrumFinality(logger.Test(t), client, 64)
+	for _, tt := range []struct {
+		name    string
+		settled int64
+		calls   int32
+	}{
+		{name: "searches backward from the latest block", settled: 37},
+		{name: "probes the next block when finality did not advance", settled: 37, calls: 1},
+		{name: "searches forward from the previous settled block", settled: 40, calls: 4},
+		{name: "reaches the latest block", settled: 100},
+	} {
+		settled = tt.settled
+		calls.Store(0)
+		head, err := finality.LatestFinalizedBlock(tests.Context(t))
+		require.NoError(t, err, tt.name)
+		assert.Equal(t, heads[settled], head, tt.name)
+		if tt.calls > 0 {
+			assert.Equal(t, tt.calls, calls.Load(), tt.name)
+		}
+	}
+}
+
+func TestOPStackFinality(t *testing.T) {
+	t.Parallel()
+
+	const respectedGameType, delay, l1Timestamp = 1, 100, 1700001000
+	type game struct {
+		gameType   int64
+		status     int64
+		resolvedAt int64
+		l2Block    int64
+	}
+	// the newest games are not finalized: unresolved, challenged, of another type, or resolved too recently
+	games := []game{
+		{gameType: respectedGameType, status: 2, resolvedAt: l1Timestamp - 2*delay, l2Block: 30},
+		{gameType: respectedGameType, status: 2, resolvedAt: l1Timestamp - 2*delay, l2Block: 42},
+		{gameType: respectedGameType, status: 2, resolvedAt: l1Timestamp - delay/2, l2Block: 50},
+		{gameType: 0, status: 2, resolvedAt: l1Timestamp - 2*delay, l2Block: 60},
+		{gameType: respectedGameType, status: 1, resolvedAt: l1Timestamp - 2*delay, l2Block: 70},
+		{gameType: respectedGameType, status: 0, l2Block: 80},
+		{gameType: respectedGameType, status: 0, l2Block: 90},
+	}
+	// the last game is created after the first call
+	var gameCount atomic.Int64
+	gameCount.Store(int64(len(games) - 1))
+	var gameCalls atomic.Int32
+	portal := testutils.NewAddress()
+	factory := testutils.NewAddress()
+	proxies := map[common.Address]game{}
+	for i, g := range games {
+		proxies[common.BigToAddress(big.NewInt(int64(1000+i)))] = g
+	}
+	word := func(v *big.Int) string {
+		return `"` + hexutil.Encode(common.BigToHash(v).Bytes()) + `"`
+	}
+	selector := func(signature string) string {
+		return hexutil.Encode(crypto.Keccak256([]byte(signature))[:4])
+	}
+	l1URL := testutils.NewWSServer(t, testutils.FixtureChainID, func(method string, params gjson.Result) (resp testutils.JSONRPCResponse) {
+		switch method {
+		case "eth_getBlockByNumber":
+			assert.Equal(t, "finalized", params.Array()[0].String())
+			resp.Result = fmt.Sprintf(`{"number":"0x10","timestamp":"%s"}`, hexutil.EncodeUint64(l1Timestamp))
+		case "eth_call":
+			assert.Equal(t, "0x10", params.Array()[1].String(), "games are read at the finalized L1 block")
+			to := common.HexToAddress(params.Array()[0].Get("to").String())
+			data := params.Array()[0].Get("input").String()
+			if data == "" {
+				data = params.Array()[0].Get("data").String()
+			}
+			g, isGame := proxies[to]
+			switch {
+			case to == portal && data == selector("disputeGameFactory()"):
+				resp.Result = word(new(big.Int).SetBytes(factory.Bytes()))
+			case to == portal && data == selector("respectedGameType()"):
+				resp.Result = word(big.NewInt(respectedGameType))
+			case to == portal && data == selector("respectedGameTypeUpdatedAt()"):
+				resp.Result = word(big.NewInt(1600000000))
+			case to == portal && data == selector("disputeGameFinalityDelaySeconds()"):
+				resp.Result = word(big.NewInt(delay))
+			case to == factory && data == selector("gameCount()"):
+				resp.Result = word(big.NewInt(gameCount.Load()))
+			case to == factory:
+				gameCalls.Add(1)
+				require.True(t, strings.HasPrefix(data, selector("gameAtIndex(uint256)")))
+				index := new(big.Int).SetBytes(hexutil.MustDecode(data)[4:]).Int64()
+				result := append(common.BigToHash(big.NewInt(games[index].gameType)).Bytes(), common.BigToHash(big.NewInt(1700000000)).Bytes()...)
+				resp.Result = `"` + hexutil.Encode(append(result, common.BigToHash(big.NewInt(1000+index)).Bytes()...)) + `"`
+			case isGame && data == selector("status()"):
+				resp.Result = word(big.NewInt(g.status))
+			case isGame && data == selector("resolvedAt()"):
+				resp.Result = word(big.NewInt(g.resolvedAt))
+			case isGame && data == selector("l2BlockNumber()"):
+				resp.Result = word(big.NewInt(g.l2Block))
+			default:
+				t.Errorf("unexpected call %s to %s", data, to)
+			}
+		default:
+			t.Errorf("unexpected method %s", method)
+		}
+		return
+	}).WSURL()
+
+	client := clienttest.NewClient(t)
+	var latest atomic.Pointer[evmtypes.Head]
+	latest.Store(testutils.Head(100))
+	client.On("HeadByNumber", mock.Anything, (*big.Int)(nil)).Return(func(context.Context, *big.Int) (*evmtypes.Head, error) {
+		return latest.Load(), nil
+	})
+	finalized := testutils.Head(42)
+	client.On("HeadByNumber", mock.Anything, big.NewInt(42)).Return(finalized, nil)
+
+	cfg := testutils.NewTestChainScopedConfig(t, func(c *toml.EVMConfig) {
+		c.HeadTracker.FinalityProvider = toml.FinalityProvider{
+			Type:            ptr(toml.FinalityProviderOPStack),
+			L1URL:           (*commonconfig.URL)(l1URL),
+			ContractAddress: ptr(evmtypes.EIP55AddressFromAddress(portal)),
+		}
+	})
+	finality, err := headtracker.NewFinalityProvider(logger.Test(t), client, cfg.EVM(), cfg.EVM().HeadTracker().FinalityProvider())
+	require.NoError(t, err)
+	head, err := finality.LatestFinalizedBlock(tests.Context(t))
+	require.NoError(t, err)
+	assert.Equal(t, finalized, head, "the latest finalized game is the newest respected game won by the defender past the finality delay")
+	assert.Equal(t, int32(5), gameCalls.Load())
+
+	// the search stops at the previous finalized game
+	gameCount.Store(int64(len(games)))
+	head, err = finality.LatestFinalizedBlock(tests.Context(t))
+	require.NoError(t, err)
+	assert.Equal(t, finalized, head)
+	assert.Equal(t, int32(5+5), gameCalls.Load())
+
+	// the finalized block is capped at the latest L2 block
+	latest.Store(testutils.Head(40))
+	head, err = finality.LatestFinalizedBlock(tests.Context(t))
+	require.NoError(t, err)
+	assert.Equal(t, latest.Load(), head)
+
+	require.NoError(t, finality.Close())
+	_, err = finality.LatestFinalizedBlock(tests.Context(t))
+	require.ErrorContains(t, err, "closed")
+}
@@ -2,6 +2,7 @@ package headtracker
 
 import (
 	"context"
+	"errors"
 
 	"github.com/ethereum/go-ethereum"
 	"go.uber.org/zap/zapcore"
@@ -12,6 +13,7 @@ import (
 	"github.com/smartcontractkit/chainlink-framework/chains/headtracker"
 	commontypes "github.com/smartcontractkit/chainlink-framework/chains/headtracker/types"
 	httypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/headtracker/types"
+	"github.com/smartcontractkit/chainlink/v2/evm/config/toml"
 	evmtypes "github.com/smartcontractkit/chainlink/v2/evm/types"
 )
 
@@ -36,6 +38,44 @@ func NewHeadTracker(
 	)
 }
 
+// NewHeadTrackerWithFinality returns a HeadTracker determining the latest finalized block with finality. Depth and tag
+// finality are computed by the head tracker itself from FinalityDepth and the finalized tag, which spares RPC calls
+// for the heads it already tracks.
+func NewHeadTrackerWithFinality(
+	lggr logger.Logger,
+	ethClient httypes.Client,
+	config commontypes.Config,
+	htConfig commontypes.HeadTrackerConfig,
+	headBroadcaster httypes.HeadBroadcaster,
+	headSaver httypes.HeadSaver,
+	mailMon *mailbox.Monitor,
+	finality FinalityProvider,
+) httypes.HeadTracker {
+	switch finality.Type() {
+	case toml.FinalityProviderDepth:
+		config = &finalityConfig{Config: config, tagEnabled: false}
+	case toml.FinalityProviderTag:
+		config = &finalityConfig{Config: config, tagEnabled: true}
+	default:
+		ethClient = &finalityClient{Client: ethClient, finality: finality}
+		config = &finalityConfig{Config: config, tagEnabled: true}
+	}
+	return &finalityHeadTracker{
+		HeadTracker: NewHeadTracker(lggr, ethClient, config, htConfig, headBroadcaster, headSaver, mailMon),
+		finality:    finality,
+	}
+}
+
+// finalityHeadTracker is a HeadTracker closing its FinalityProvider when closed.
+type finalityHeadTracker struct {
+	httypes.HeadTracker
+	finality FinalityProvider
+}
+
+func (t *finalityHeadTracker) Close() error {
+	return errors.Join(t.HeadTracker.Close(), t.finality.Close())
+}
+
 var NullTracker httypes.HeadTracker = &nullTracker{}
 
 type nullTracker struct{}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"math/big"
 
 	evmclient "github.com/smartcontractkit/chainlink/v2/evm/client"
 	evmtypes "github.com/smartcontractkit/chainlink/v2/evm/types"
@@ -12,16 +11,18 @@ import (
 
 // simulatedHeadTracker - simplified version of HeadTracker that works with simulated backed
 type simulatedHeadTracker struct {
-	ec             evmclient.Client
-	useFinalityTag bool
-	finalityDepth  int64
+	ec       evmclient.Client
+	finality FinalityProvider
 }
 
 func NewSimulatedHeadTracker(ec evmclient.Client, useFinalityTag bool, finalityDepth int64) *simulatedHeadTracker {
+	finality := NewDepthFinality(ec, uint32(finalityDepth)) //nolint:gosec // this won't overflow
+	if useFinalityTag {
+		finality = NewTagFinality(ec)
+	}
 	return &simulatedHeadTracker{
-		ec:             ec,
-		useFinalityTag: useFinalityTag,
-		finalityDepth:  finalityDepth,
+		ec:       ec,
+		finality: finality,
 	}
 }
 
@@ -35,13 +36,7 @@ func (ht *simulatedHeadTracker) LatestAndFinalizedBlock(ctx context.Context) (*e
 		return nil, nil, fmt.Errorf("expected latest block to be valid")
 	}
 
-	var finalizedBlock *evmtypes.Head
-	if ht.useFinalityTag {
-		finalizedBlock, err = ht.ec.LatestFinalizedBlock(ctx)
-	} else {
-		finalizedBlock, err = ht.ec.HeadByNumber(ctx, big.NewInt(max(latest.Number-ht.finalityDepth, 0)))
-	}
-
+	finalizedBlock, err := ht.finality.LatestFinalizedBlock(ctx)
 	if err != nil {
 		return nil, nil, fmt.Errorf("simulatedHeadTracker failed to get finalized block")
 	}
@@ -0,0 +1,59 @@
+package logpoller
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// DecodeLog returns the name and arguments of the event of log, or nothing if the event is not in contract. Addresses
+// and bytes arguments are hex encoded, so that the arguments can be rendered as JSON.
+func DecodeLog(contract *abi.ABI, log Log) (string, map[string]any, error) {
+	event, err := contract.EventByID(log.EventSig)
+	if err != nil {
+		return "", nil, nil
+	}
+	args := map[string]any{}
+	if err = event.Inputs.UnpackIntoMap(args, log.Data); err != nil {
+		return "", nil, err
+	}
+	var indexed abi.Arguments
+	for _, arg := range event.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	topics := log.GetTopics()
+	if len(topics) != len(indexed)+1 {
+		return "", nil, fmt.Errorf("event %s has %d indexed arguments, but the log has %d topics", event.Name, len(indexed), len(topics))
+	}
+	if err = abi.ParseTopicsIntoMap(args, indexed, topics[1:]); err != nil {
+		return "", nil, err
+	}
+	for name, value := range args {
+		args[name] = hexBytes(value)
+	}
+	return event.Name, args, nil
+}
+
+// hexBytes hex encodes byte arrays and slices, which would otherwise be rendered as lists of numbers.
+func hexBytes(value any) any {
+	if address, ok := value.(common.Address); ok {
+		return address.Hex()
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Array, reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.Uint8 {
+			return value
+		}
+		b := make([]byte, v.Len())
+		reflect.Copy(reflect.ValueOf(b), v)
+		return hexutil.Encode(b)
+	default:
+		return value
+	}
+}
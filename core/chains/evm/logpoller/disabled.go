@@ -2,6 +2,7 @@ package logpoller
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -129,3 +130,15 @@ func (d disabled) FindLCA(ctx context.Context) (*LogPollerBlock, error) {
 func (d disabled) DeleteLogsAndBlocksAfter(ctx context.Context, start int64) error {
 	return ErrDisabled
 }
+
+func (d disabled) Subscribe(ctx context.Context, filterName string, confs evmtypes.Confirmations) (Subscription, error) {
+	return nil, ErrDisabled
+}
+
+func (d disabled) ExportSnapshot(ctx context.Context, w io.Writer, from, to int64, filterName string) (SnapshotSummary, error) {
+	return SnapshotSummary{}, ErrDisabled
+}
+
+func (d disabled) ImportSnapshot(ctx context.Context, r io.ReadSeeker) (SnapshotSummary, error) {
+	return SnapshotSummary{}, ErrDisabled
+}
@@ -19,4 +19,7 @@
 //   - After calling Replay(fromBlock), all blocks including that one to the latest chain tip will be polled
 //     with the current filter. This can be used on first time job add to specify a start block from which you wish to capture
 //     existing logs.
+//   - Subscribe(filterName, confs) delivers the logs of a filter at least once, in order, once they have enough
+//     confirmations, followed by retractions of delivered logs removed by reorgs. Acknowledged positions are persisted,
+//     so subscribing again after a restart resumes where the subscriber left off.
 package logpoller
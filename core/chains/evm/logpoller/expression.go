@@ -0,0 +1,285 @@
+package logpoller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/types/query"
+	"github.com/smartcontractkit/chainlink-common/pkg/types/query/primitives"
+
+	evmtypes "github.com/smartcontractkit/chainlink/v2/evm/types"
+)
+
+// MaxQueryExpressions is the maximum number of expressions, including nested ones, of a query built from QueryExpressions.
+const MaxQueryExpressions = 100
+
+// QueryExpression is the JSON representation of the expressions accepted by FilteredLogs, as used by the logs query API.
+// Exactly one field must be set.
+type QueryExpression struct {
+	And           []QueryExpression   `json:"and,omitempty"`
+	Or            []QueryExpression   `json:"or,omitempty"`
+	Address       *common.Address     `json:"address,omitempty"`
+	EventSig      *common.Hash        `json:"eventSig,omitempty"`
+	Topic         *QueryHashFilter    `json:"topic,omitempty"`
+	Word          *QueryHashFilter    `json:"word,omitempty"`
+	Confirmations *QueryConfirmations `json:"confirmations,omitempty"`
+	Block         *QueryComparison    `json:"block,omitempty"`
+	Timestamp     *QueryComparison    `json:"timestamp,omitempty"`
+	TxHash        *common.Hash        `json:"txHash,omitempty"`
+}
+
+// QueryHashFilter compares an indexed topic (1 to 3) or a data word of logs with a list of values. The condition holds
+// if the comparison holds for any of the values.
+type QueryHashFilter struct {
+	Index    uint64        `json:"index"`
+	Values   []common.Hash `json:"values"`
+	Operator string        `json:"operator,omitempty"`
+}
+
+// QueryComparison compares the block number, or the block timestamp in unix seconds, of logs with a value.
+type QueryComparison struct {
+	Value    uint64 `json:"value"`
+	Operator string `json:"operator,omitempty"`
+}
+
+// QueryConfirmations is either a number of confirmations, or "finalized".
+type QueryConfirmations evmtypes.Confirmations
+
+func (c QueryConfirmations) MarshalJSON() ([]byte, error) {
+	if evmtypes.Confirmations(c) == evmtypes.Finalized {
+		return json.Marshal("finalized")
+	}
+	return json.Marshal(int64(c))
+}
+
+func (c *QueryConfirmations) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		s = string(b)
+	}
+	confs, err := ParseConfirmations(s)
+	if err != nil {
+		return err
+	}
+	*c = QueryConfirmations(confs)
+	return nil
+}
+
+// ParseConfirmations parses a number of confirmations, or "finalized".
+func ParseConfirmations(s string) (evmtypes.Confirmations, error) {
+	if s == "finalized" {
+		return evmtypes.Finalized, nil
+	}
+	n, err := strconv.ParseUint(s, 10, 31)
+	if err != nil {
+		return 0, fmt.Errorf("invalid confirmations %q: must be a number or \"finalized\"", s)
+	}
+	return evmtypes.Confirmations(n), nil
+}
+
+// ParseComparisonOperator parses one of ==, !=, >, <, >= and <=. The empty string is ==.
+func ParseComparisonOperator(s string) (primitives.ComparisonOperator, error) {
+	switch s {
+	case "", "==":
+		return primitives.Eq, nil
+	case "!=":
+		return primitives.Neq, nil
+	case ">":
+		return primitives.Gt, nil
+	case "<":
+		return primitives.Lt, nil
+	case ">=":
+		return primitives.Gte, nil
+	case "<=":
+		return primitives.Lte, nil
+	default:
+		return 0, fmt.Errorf("invalid comparison operator %q", s)
+	}
+}
+
+// QueryExpressions converts exprs to the expressions accepted by FilteredLogs.
+func QueryExpressions(exprs []QueryExpression) ([]query.Expression, error) {
+	count := 0
+	return queryExpressions(exprs, &count)
+}
+
+func queryExpressions(exprs []QueryExpression, count *int) ([]query.Expression, error) {
+	converted := make([]query.Expression, len(exprs))
+	for i, e := range exprs {
+		if *count++; *count > MaxQueryExpressions {
+			return nil, fmt.Errorf("too many expressions: at most %d are allowed", MaxQueryExpressions)
+		}
+		var err error
+		if converted[i], err = e.expression(count); err != nil {
+			return nil, err
+		}
+	}
+	return converted, nil
+}
+
+func (e QueryExpression) expression(count *int) (query.Expression, error) {
+	var exprs []query.Expression
+	add := func(expr query.Expression) {
+		exprs = append(exprs, expr)
+	}
+	if e.And != nil || e.Or != nil {
+		op, nested := query.AND, e.And
+		if e.Or != nil {
+			op, nested = query.OR, e.Or
+		}
+		if len(nested) < 2 {
+			return query.Expression{}, fmt.Errorf("%s requires at least two expressions", op)
+		}
+		converted, err := queryExpressions(nested, count)
+		if err != nil {
+			return query.Expression{}, err
+		}
+		add(query.Expression{BoolExpression: query.BoolExpression{Expressions: converted, BoolOperator: op}})
+	}
+	if e.And != nil && e.Or != nil {
+		add(query.Expression{})
+	}
+	if e.Address != nil {
+		add(NewAddressFilter(*e.Address))
+	}
+	if e.EventSig != nil {
+		add(NewEventSigFilter(*e.EventSig))
+	}
+	if e.Topic != nil {
+		op, err := e.Topic.operator()
+		if err != nil {
+			return query.Expression{}, err
+		}
+		if e.Topic.Index < 1 || e.Topic.Index > 3 {
+			return query.Expression{}, fmt.Errorf("invalid topic index %d: must be between 1 and 3", e.Topic.Index)
+		}
+		add(NewEventByTopicFilter(e.Topic.Index, []HashedValueComparator{{Values: e.Topic.Values, Operator: op}}))
+	}
+	if e.Word != nil {
+		op, err := e.Word.operator()
+		if err != nil {
+			return query.Expression{}, err
+		}
+		add(NewEventByWordFilter(int(e.Word.Index), []HashedValueComparator{{Values: e.Word.Values, Operator: op}})) //nolint:gosec // G115
+	}
+	if e.Confirmations != nil {
+		add(NewConfirmationsFilter(evmtypes.Confirmations(*e.Confirmations)))
+	}
+	if e.Block != nil {
+		op, err := ParseComparisonOperator(e.Block.Operator)
+		if err != nil {
+			return query.Expression{}, err
+		}
+		add(query.Block(strconv.FormatUint(e.Block.Value, 10), op))
+	}
+	if e.Timestamp != nil {
+		op, err := ParseComparisonOperator(e.Timestamp.Operator)
+		if err != nil {
+			return query.Expression{}, err
+		}
+		add(query.Timestamp(e.Timestamp.Value, op))
+	}
+	if e.TxHash != nil {
+		add(query.TxHash(e.TxHash.Hex()))
+	}
+	switch len(exprs) {
+	case 0:
+		return query.Expression{}, errors.New("empty expression")
+	case 1:
+		return exprs[0], nil
+	default:
+		return query.Expression{}, errors.New("expression must have exactly one field set")
+	}
+}
+
+func (f *QueryHashFilter) operator() (primitives.ComparisonOperator, error) {
+	if len(f.Values) == 0 {
+		return 0, errors.New("at least one value must be specified")
+	}
+	return ParseComparisonOperator(f.Operator)
+}
+
+const (
+	// DefaultLogsQueryLimit is the number of logs returned when a LogsQuery has no limit.
+	DefaultLogsQueryLimit = 100
+	// MaxLogsQueryLimit is the maximum number of logs returned by a LogsQuery.
+	MaxLogsQueryLimit = 1000
+)
+
+// LogsQuery is a query of the logs saved by the log poller, as used by the logs query API. Logs are sorted by block
+// number and log index.
+//
+// Cursor is the ID of a log previously returned: only the logs after it (before it when sorting in descending order)
+// are returned. Cursors require the query to only match finalized logs, with a top level
+// {"confirmations": "finalized"} expression.
+type LogsQuery struct {
+	Expressions []QueryExpression `json:"expressions"`
+	Limit       uint64            `json:"limit,omitempty"`
+	Cursor      string            `json:"cursor,omitempty"`
+	Sort        string            `json:"sort,omitempty"`
+}
+
+// Parse returns the expressions and limit accepted by FilteredLogs. A top level expression must restrict the addresses
+// or the events of the logs, so that queries are always served by an index.
+func (q *LogsQuery) Parse() ([]query.Expression, query.LimitAndSort, error) {
+	var indexed, finalized bool
+	for _, e := range q.Expressions {
+		indexed = indexed || e.isIndexed()
+		finalized = finalized || (e.Confirmations != nil && evmtypes.Confirmations(*e.Confirmations) == evmtypes.Finalized)
+	}
+	if !indexed {
+		return nil, query.LimitAndSort{}, errors.New("query must have an address or eventSig expression")
+	}
+	expressions, err := QueryExpressions(q.Expressions)
+	if err != nil {
+		return nil, query.LimitAndSort{}, err
+	}
+
+	limit := q.Limit
+	if limit == 0 {
+		limit = DefaultLogsQueryLimit
+	} else if limit > MaxLogsQueryLimit {
+		return nil, query.LimitAndSort{}, fmt.Errorf("limit must be at most %d", MaxLogsQueryLimit)
+	}
+	dir, cursorDir := query.Asc, query.CursorFollowing
+	switch q.Sort {
+	case "", "asc":
+	case "desc":
+		dir, cursorDir = query.Desc, query.CursorPrevious
+	default:
+		return nil, query.LimitAndSort{}, fmt.Errorf("invalid sort %q: must be asc or desc", q.Sort)
+	}
+	if q.Cursor == "" {
+		return expressions, query.NewLimitAndSort(query.CountLimit(limit), query.NewSortBySequence(dir)), nil
+	}
+	if !finalized {
+		return nil, query.LimitAndSort{}, errors.New(`cursor requires a top level {"confirmations": "finalized"} expression`)
+	}
+	return expressions, query.NewLimitAndSort(query.CursorLimit(q.Cursor, cursorDir, limit), query.NewSortBySequence(dir)), nil
+}
+
+// isIndexed returns whether e only matches logs of given addresses or events.
+func (e QueryExpression) isIndexed() bool {
+	switch {
+	case e.Address != nil || e.EventSig != nil:
+		return true
+	case len(e.Or) > 0:
+		for _, alternative := range e.Or {
+			if !alternative.isIndexed() {
+				return false
+			}
+		}
+		return true
+	default:
+		for _, nested := range e.And {
+			if nested.isIndexed() {
+				return true
+			}
+		}
+		return false
+	}
+}
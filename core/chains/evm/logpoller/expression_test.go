@@ -0,0 +1,86 @@
+package logpoller
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/types/query"
+
+	"github.com/smartcontractkit/chainlink/v2/evm/types"
+)
+
+func TestQueryExpressions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("builds the query of the JSON expressions", func(t *testing.T) {
+		var exprs []QueryExpression
+		require.NoError(t, json.Unmarshal([]byte(`[
+			{"or": [{"address": "0x0000000000000000000000000000000000000042"}, {"address": "0x0000000000000000000000000000000000000043"}]},
+			{"eventSig": "0x0000000000000000000000000000000000000000000000000000000000000021"},
+			{"topic": {"index": 2, "values": ["0x0000000000000000000000000000000000000000000000000000000000000001"], "operator": ">="}},
+			{"word": {"index": 1, "values": ["0x0000000000000000000000000000000000000000000000000000000000000002"]}},
+			{"block": {"value": 10, "operator": ">"}},
+			{"confirmations": "finalized"}
+		]`), &exprs))
+
+		expressions, err := QueryExpressions(exprs)
+		require.NoError(t, err)
+		result, args, err := (&pgDSLParser{}).buildQuery(big.NewInt(1), expressions, query.NewLimitAndSort(query.CountLimit(5)))
+		require.NoError(t, err)
+		assert.Equal(t, logsQuery(
+			" WHERE evm_chain_id = :evm_chain_id "+
+				"AND ((address = :address_0 OR address = :address_1) "+
+				"AND event_sig = :event_sig_0 "+
+				"AND topics[3] >= :topic_value_0 "+
+				"AND substring(data from 32*1+1 for 32) = :word_value_0 "+
+				"AND block_number > :block_number_0 "+
+				"AND block_number <= "+
+				"(SELECT finalized_block_number FROM evm.log_poller_blocks WHERE evm_chain_id = :evm_chain_id ORDER BY block_number DESC LIMIT 1)) "+
+				"ORDER BY "+defaultSort+" "+
+				"LIMIT 5"), result)
+		assertArgs(t, args, 7)
+	})
+
+	t.Run("confirmations", func(t *testing.T) {
+		var e QueryExpression
+		require.NoError(t, json.Unmarshal([]byte(`{"confirmations": 12}`), &e))
+		assert.Equal(t, types.Confirmations(12), types.Confirmations(*e.Confirmations))
+		b, err := json.Marshal(e)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"confirmations": 12}`, string(b))
+
+		finalized := QueryConfirmations(types.Finalized)
+		b, err = json.Marshal(QueryExpression{Confirmations: &finalized})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"confirmations": "finalized"}`, string(b))
+
+		require.ErrorContains(t, json.Unmarshal([]byte(`{"confirmations": "safe"}`), &e), "invalid confirmations")
+	})
+
+	t.Run("rejects invalid expressions", func(t *testing.T) {
+		for expr, expected := range map[string]string{
+			`{}`: "empty expression",
+			`{"address": "0x0000000000000000000000000000000000000042", "eventSig": "0x0000000000000000000000000000000000000000000000000000000000000021"}`: "exactly one field",
+			`{"or": [{"address": "0x0000000000000000000000000000000000000042"}]}`:                                                                         "at least two expressions",
+			`{"topic": {"index": 4, "values": ["0x0000000000000000000000000000000000000000000000000000000000000001"]}}`:                                   "invalid topic index",
+			`{"topic": {"index": 1, "values": []}}`:    "at least one value",
+			`{"block": {"value": 1, "operator": "~"}}`: "invalid comparison operator",
+		} {
+			var e QueryExpression
+			require.NoError(t, json.Unmarshal([]byte(expr), &e))
+			_, err := QueryExpressions([]QueryExpression{e})
+			assert.ErrorContains(t, err, expected, expr)
+		}
+
+		address := `{"address": "0x0000000000000000000000000000000000000042"}`
+		var exprs []QueryExpression
+		require.NoError(t, json.Unmarshal([]byte(`[{"or": [`+strings.Repeat(address+",", MaxQueryExpressions)+address+`]}]`), &exprs))
+		_, err := QueryExpressions(exprs)
+		assert.ErrorContains(t, err, "too many expressions")
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"io"
 	"math/big"
 	"math/rand/v2"
 	"sort"
@@ -72,6 +73,17 @@ type LogPoller interface {
 
 	// chainlink-common query filtering
 	FilteredLogs(ctx context.Context, filter []query.Expression, limitAndSort query.LimitAndSort, queryName string) ([]Log, error)
+
+	// Subscribe delivers the logs matching the registered filter once they have confs confirmations, and retractions
+	// of delivered logs removed by reorgs. Delivery resumes after the last acknowledged event, or starts at the oldest
+	// log retained in the db on the first subscription.
+	Subscribe(ctx context.Context, filterName string, confs evmtypes.Confirmations) (Subscription, error)
+
+	// ExportSnapshot writes the finalized blocks and logs of a block range, optionally only the logs of a filter, to w.
+	ExportSnapshot(ctx context.Context, w io.Writer, from, to int64, filterName string) (SnapshotSummary, error)
+	// ImportSnapshot verifies a snapshot written by ExportSnapshot against the finalized blocks of the chain, and inserts its blocks and logs
+	// in a single transaction, so that the snapshot is imported entirely or not at all.
+	ImportSnapshot(ctx context.Context, r io.ReadSeeker) (SnapshotSummary, error)
 }
 
 type LogPollerTest interface {
@@ -120,6 +132,8 @@ type logPoller struct {
 	backupPollerNextBlock    int64 // next block to be processed by Backup LogPoller
 	backupPollerBlockDelay   int64 // how far behind regular LogPoller should BackupLogPoller run. 0 = disabled
 
+	subs *subscriptions
+
 	filterMu        sync.RWMutex
 	filters         map[string]Filter
 	filterDirty     bool
@@ -162,7 +176,7 @@ type Opts struct {
 // How fast that can be done depends largely on network speed and DB, but even for the fastest
 // support chain, polygon, which has 2s block times, we need RPCs roughly with <= 500ms latency
 func NewLogPoller(orm ORM, ec Client, lggr logger.Logger, headTracker HeadTracker, opts Opts) *logPoller {
-	return &logPoller{
+	lp := &logPoller{
 		stopCh:                   make(chan struct{}),
 		ec:                       ec,
 		orm:                      orm,
@@ -182,6 +196,8 @@ func NewLogPoller(orm ORM, ec Client, lggr logger.Logger, headTracker HeadTracke
 		filters:                  make(map[string]Filter),
 		filterDirty:              true, // Always build Filter on first call to cache an empty filter if nothing registered yet.
 	}
+	lp.subs = newSubscriptions(lp.lggr, orm, lp.getFilter, lp.stopCh)
+	return lp
 }
 
 type Filter struct {
@@ -333,6 +349,14 @@ func (lp *logPoller) HasFilter(name string) bool {
 	return ok
 }
 
+// getFilter returns the registered filter with the given name, if any.
+func (lp *logPoller) getFilter(name string) (Filter, bool) {
+	lp.filterMu.RLock()
+	defer lp.filterMu.RUnlock()
+	filter, ok := lp.filters[name]
+	return filter, ok
+}
+
 // GetFilters returns a deep copy of the filters map.
 func (lp *logPoller) GetFilters() map[string]Filter {
 	lp.filterMu.RLock()
@@ -526,6 +550,7 @@ func (lp *logPoller) Close() error {
 		}
 		close(lp.stopCh)
 		lp.wg.Wait()
+		lp.subs.close()
 		return nil
 	})
 }
@@ -930,11 +955,14 @@ func (lp *logPoller) backfill(ctx context.Context, start, end int64) error {
 		}
 
 		lp.lggr.Debugw("Backfill found logs", "from", from, "to", to, "logs", len(gethLogs), "blocks", blocks)
-		err = lp.orm.InsertLogsWithBlock(ctx, convertLogs(gethLogs, blocks, lp.lggr, lp.ec.ConfiguredChainID()), endblock)
+		logs := convertLogs(gethLogs, blocks, lp.lggr, lp.ec.ConfiguredChainID())
+		err = lp.orm.InsertLogsWithBlock(ctx, logs, endblock)
 		if err != nil {
 			lp.lggr.Warnw("Unable to insert logs, retrying", "err", err, "from", from, "to", to)
 			return err
 		}
+		// backfilled blocks are finalized
+		lp.subs.onLogsSaved(logs, endblock.BlockNumber, endblock.BlockNumber)
 	}
 	return nil
 }
@@ -999,7 +1027,7 @@ func (lp *logPoller) getCurrentBlockMaybeHandleReorg(ctx context.Context, curren
 		// the canonical set per read. Typically, if an application took action on a log
 		// it would be saved elsewhere e.g. evm.txes, so it seems better to just support the fast reads.
 		// Its also nicely analogous to reading from the chain itself.
-		err2 = lp.orm.DeleteLogsAndBlocksAfter(ctx, blockAfterLCA.Number)
+		err2 = lp.DeleteLogsAndBlocksAfter(ctx, blockAfterLCA.Number)
 		if err2 != nil {
 			// If we error on db commit, we can't know if the tx went through or not.
 			// We return an error here which will cause us to restart polling from lastBlockSaved + 1
@@ -1106,15 +1134,13 @@ func (lp *logPoller) pollAndSaveLogs(ctx context.Context, currentBlockNumber int
 			BlockTimestamp:       currentBlock.Timestamp,
 			FinalizedBlockNumber: latestFinalizedBlockNumber,
 		}
-		err = lp.orm.InsertLogsWithBlock(
-			ctx,
-			convertLogs(logs, []LogPollerBlock{block}, lp.lggr, lp.ec.ConfiguredChainID()),
-			block,
-		)
+		lgs := convertLogs(logs, []LogPollerBlock{block}, lp.lggr, lp.ec.ConfiguredChainID())
+		err = lp.orm.InsertLogsWithBlock(ctx, lgs, block)
 		if err != nil {
 			lp.lggr.Warnw("Unable to save logs resuming from last saved block + 1", "err", err, "block", currentBlockNumber)
 			return nil
 		}
+		lp.subs.onLogsSaved(lgs, block.BlockNumber, block.FinalizedBlockNumber)
 		// Update current block.
 		// Same reorg detection on unfinalized blocks.
 		currentBlockNumber++
@@ -1615,9 +1641,30 @@ func (lp *logPoller) IndexedLogsWithSigsExcluding(ctx context.Context, address c
 	return lp.orm.SelectIndexedLogsWithSigsExcluding(ctx, eventSigA, eventSigB, topicIndex, address, fromBlock, toBlock, confs)
 }
 
-// DeleteLogsAndBlocksAfter - removes blocks and logs starting from the specified block
+// DeleteLogsAndBlocksAfter removes the logs and blocks from start, and retracts the removed logs from subscriptions.
 func (lp *logPoller) DeleteLogsAndBlocksAfter(ctx context.Context, start int64) error {
-	return lp.orm.DeleteLogsAndBlocksAfter(ctx, start)
+	var removed []Log
+	if lp.subs.active() {
+		latest, err := lp.orm.SelectLatestBlock(ctx)
+		if err == nil {
+			removed, err = lp.orm.SelectLogsByBlockRange(ctx, start, latest.BlockNumber)
+		}
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("failed to select logs to retract: %w", err)
+		}
+	}
+	if err := lp.orm.DeleteLogsAndBlocksAfter(ctx, start); err != nil {
+		return err
+	}
+	lp.subs.retract(start, removed)
+	return nil
+}
+
+func (lp *logPoller) Subscribe(ctx context.Context, filterName string, confs evmtypes.Confirmations) (Subscription, error) {
+	if !lp.HasFilter(filterName) {
+		return nil, fmt.Errorf("filter %s is not registered", filterName)
+	}
+	return lp.subs.subscribe(ctx, filterName, confs)
 }
 
 func (lp *logPoller) FindLCA(ctx context.Context) (*LogPollerBlock, error) {
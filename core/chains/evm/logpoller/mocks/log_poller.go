@@ -5,6 +5,8 @@ package mocks
 import (
 	context "context"
 
+	io "io"
+
 	common "github.com/ethereum/go-ethereum/common"
 
 	logpoller "github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller"
@@ -12,7 +14,6 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	query "github.com/smartcontractkit/chainlink-common/pkg/types/query"
-
 	time "time"
 
 	types "github.com/smartcontractkit/chainlink/v2/evm/types"
@@ -123,6 +124,66 @@ func (_c *LogPoller_DeleteLogsAndBlocksAfter_Call) RunAndReturn(run func(context
 	return _c
 }
 
+// ExportSnapshot provides a mock function with given fields: ctx, w, from, to, filterName
+func (_m *LogPoller) ExportSnapshot(ctx context.Context, w io.Writer, from int64, to int64, filterName string) (logpoller.SnapshotSummary, error) {
+	ret := _m.Called(ctx, w, from, to, filterName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportSnapshot")
+	}
+
+	var r0 logpoller.SnapshotSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, io.Writer, int64, int64, string) (logpoller.SnapshotSummary, error)); ok {
+		return rf(ctx, w, from, to, filterName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, io.Writer, int64, int64, string) logpoller.SnapshotSummary); ok {
+		r0 = rf(ctx, w, from, to, filterName)
+	} else {
+		r0 = ret.Get(0).(logpoller.SnapshotSummary)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, io.Writer, int64, int64, string) error); ok {
+		r1 = rf(ctx, w, from, to, filterName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LogPoller_ExportSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportSnapshot'
+type LogPoller_ExportSnapshot_Call struct {
+	*mock.Call
+}
+
+// ExportSnapshot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - w io.Writer
+//   - from int64
+//   - to int64
+//   - filterName string
+func (_e *LogPoller_Expecter) ExportSnapshot(ctx interface{}, w interface{}, from interface{}, to interface{}, filterName interface{}) *LogPoller_ExportSnapshot_Call {
+	return &LogPoller_ExportSnapshot_Call{Call: _e.mock.On("ExportSnapshot", ctx, w, from, to, filterName)}
+}
+
+func (_c *LogPoller_ExportSnapshot_Call) Run(run func(ctx context.Context, w io.Writer, from int64, to int64, filterName string)) *LogPoller_ExportSnapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(io.Writer), args[2].(int64), args[3].(int64), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *LogPoller_ExportSnapshot_Call) Return(_a0 logpoller.SnapshotSummary, _a1 error) *LogPoller_ExportSnapshot_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LogPoller_ExportSnapshot_Call) RunAndReturn(run func(context.Context, io.Writer, int64, int64, string) (logpoller.SnapshotSummary, error)) *LogPoller_ExportSnapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FilteredLogs provides a mock function with given fields: ctx, filter, limitAndSort, queryName
 func (_m *LogPoller) FilteredLogs(ctx context.Context, filter []query.Expression, limitAndSort query.LimitAndSort, queryName string) ([]logpoller.Log, error) {
 	ret := _m.Called(ctx, filter, limitAndSort, queryName)
@@ -486,6 +547,63 @@ func (_c *LogPoller_Healthy_Call) RunAndReturn(run func() error) *LogPoller_Heal
 	return _c
 }
 
+// ImportSnapshot provides a mock function with given fields: ctx, r
+func (_m *LogPoller) ImportSnapshot(ctx context.Context, r io.ReadSeeker) (logpoller.SnapshotSummary, error) {
+	ret := _m.Called(ctx, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportSnapshot")
+	}
+
+	var r0 logpoller.SnapshotSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, io.ReadSeeker) (logpoller.SnapshotSummary, error)); ok {
+		return rf(ctx, r)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, io.ReadSeeker) logpoller.SnapshotSummary); ok {
+		r0 = rf(ctx, r)
+	} else {
+		r0 = ret.Get(0).(logpoller.SnapshotSummary)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, io.ReadSeeker) error); ok {
+		r1 = rf(ctx, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LogPoller_ImportSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportSnapshot'
+type LogPoller_ImportSnapshot_Call struct {
+	*mock.Call
+}
+
+// ImportSnapshot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - r io.ReadSeeker
+func (_e *LogPoller_Expecter) ImportSnapshot(ctx interface{}, r interface{}) *LogPoller_ImportSnapshot_Call {
+	return &LogPoller_ImportSnapshot_Call{Call: _e.mock.On("ImportSnapshot", ctx, r)}
+}
+
+func (_c *LogPoller_ImportSnapshot_Call) Run(run func(ctx context.Context, r io.ReadSeeker)) *LogPoller_ImportSnapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(io.ReadSeeker))
+	})
+	return _c
+}
+
+func (_c *LogPoller_ImportSnapshot_Call) Return(_a0 logpoller.SnapshotSummary, _a1 error) *LogPoller_ImportSnapshot_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LogPoller_ImportSnapshot_Call) RunAndReturn(run func(context.Context, io.ReadSeeker) (logpoller.SnapshotSummary, error)) *LogPoller_ImportSnapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // IndexedLogs provides a mock function with given fields: ctx, eventSig, address, topicIndex, topicValues, confs
 func (_m *LogPoller) IndexedLogs(ctx context.Context, eventSig common.Hash, address common.Address, topicIndex int, topicValues []common.Hash, confs types.Confirmations) ([]logpoller.Log, error) {
 	ret := _m.Called(ctx, eventSig, address, topicIndex, topicValues, confs)
@@ -1809,6 +1927,66 @@ func (_c *LogPoller_Start_Call) RunAndReturn(run func(context.Context) error) *L
 	return _c
 }
 
+// Subscribe provides a mock function with given fields: ctx, filterName, confs
+func (_m *LogPoller) Subscribe(ctx context.Context, filterName string, confs types.Confirmations) (logpoller.Subscription, error) {
+	ret := _m.Called(ctx, filterName, confs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Subscribe")
+	}
+
+	var r0 logpoller.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, types.Confirmations) (logpoller.Subscription, error)); ok {
+		return rf(ctx, filterName, confs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, types.Confirmations) logpoller.Subscription); ok {
+		r0 = rf(ctx, filterName, confs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(logpoller.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, types.Confirmations) error); ok {
+		r1 = rf(ctx, filterName, confs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LogPoller_Subscribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Subscribe'
+type LogPoller_Subscribe_Call struct {
+	*mock.Call
+}
+
+// Subscribe is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filterName string
+//   - confs types.Confirmations
+func (_e *LogPoller_Expecter) Subscribe(ctx interface{}, filterName interface{}, confs interface{}) *LogPoller_Subscribe_Call {
+	return &LogPoller_Subscribe_Call{Call: _e.mock.On("Subscribe", ctx, filterName, confs)}
+}
+
+func (_c *LogPoller_Subscribe_Call) Run(run func(ctx context.Context, filterName string, confs types.Confirmations)) *LogPoller_Subscribe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(types.Confirmations))
+	})
+	return _c
+}
+
+func (_c *LogPoller_Subscribe_Call) Return(_a0 logpoller.Subscription, _a1 error) *LogPoller_Subscribe_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LogPoller_Subscribe_Call) RunAndReturn(run func(context.Context, string, types.Confirmations) (logpoller.Subscription, error)) *LogPoller_Subscribe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UnregisterFilter provides a mock function with given fields: ctx, name
 func (_m *LogPoller) UnregisterFilter(ctx context.Context, name string) error {
 	ret := _m.Called(ctx, name)
@@ -7,6 +7,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/lib/pq"
 
+	evmtypes "github.com/smartcontractkit/chainlink/v2/evm/types"
 	"github.com/smartcontractkit/chainlink/v2/evm/utils/big"
 )
 
@@ -22,6 +23,15 @@ type LogPollerBlock struct {
 	CreatedAt            time.Time
 }
 
+// SubscriptionCursor is the position of the last log acknowledged by the subscriber of a filter, see LogPoller.Subscribe.
+type SubscriptionCursor struct {
+	FilterName  string
+	Confs       evmtypes.Confirmations
+	BlockNumber int64
+	LogIndex    int64
+	UpdatedAt   time.Time
+}
+
 // Log represents an EVM log.
 type Log struct {
 	EvmChainId     *big.Big
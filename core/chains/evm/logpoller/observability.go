@@ -106,6 +106,17 @@ func (o *ObservedORM) InsertLogsWithBlock(ctx context.Context, logs []Log, block
 	return err
 }
 
+func (o *ObservedORM) InsertBlocksAndLogs(ctx context.Context, blocks []LogPollerBlock, logs []Log) error {
+	err := withObservedExec(o, "InsertBlocksAndLogs", create, func() error {
+		return o.ORM.InsertBlocksAndLogs(ctx, blocks, logs)
+	})
+	if err == nil {
+		o.logsInserted.WithLabelValues(o.chainId).Add(float64(len(logs)))
+		o.blocksInserted.WithLabelValues(o.chainId).Add(float64(len(blocks)))
+	}
+	return err
+}
+
 func (o *ObservedORM) InsertFilter(ctx context.Context, filter Filter) error {
 	return withObservedExec(o, "InsertFilter", create, func() error {
 		return o.ORM.InsertFilter(ctx, filter)
@@ -166,6 +177,24 @@ func (o *ObservedORM) SelectBlockByNumber(ctx context.Context, n int64) (*LogPol
 	})
 }
 
+func (o *ObservedORM) SelectFilterLogsAfter(ctx context.Context, filterName string, afterBlock, afterLogIndex int64, limit int) ([]Log, error) {
+	return withObservedQueryAndResults(o, "SelectFilterLogsAfter", func() ([]Log, error) {
+		return o.ORM.SelectFilterLogsAfter(ctx, filterName, afterBlock, afterLogIndex, limit)
+	})
+}
+
+func (o *ObservedORM) SelectSubscriptionCursor(ctx context.Context, filterName string, confs evmtypes.Confirmations) (*SubscriptionCursor, error) {
+	return withObservedQuery(o, "SelectSubscriptionCursor", func() (*SubscriptionCursor, error) {
+		return o.ORM.SelectSubscriptionCursor(ctx, filterName, confs)
+	})
+}
+
+func (o *ObservedORM) UpsertSubscriptionCursor(ctx context.Context, cursor SubscriptionCursor) error {
+	return withObservedExec(o, "UpsertSubscriptionCursor", create, func() error {
+		return o.ORM.UpsertSubscriptionCursor(ctx, cursor)
+	})
+}
+
 func (o *ObservedORM) SelectLatestBlock(ctx context.Context) (*LogPollerBlock, error) {
 	return withObservedQuery(o, "SelectLatestBlock", func() (*LogPollerBlock, error) {
 		return o.ORM.SelectLatestBlock(ctx)
@@ -26,6 +26,7 @@ import (
 type ORM interface {
 	InsertLogs(ctx context.Context, logs []Log) error
 	InsertLogsWithBlock(ctx context.Context, logs []Log, block LogPollerBlock) error
+	InsertBlocksAndLogs(ctx context.Context, blocks []LogPollerBlock, logs []Log) error
 	InsertFilter(ctx context.Context, filter Filter) error
 
 	LoadFilters(ctx context.Context) (map[string]Filter, error)
@@ -53,6 +54,10 @@ type ORM interface {
 	SelectLatestLogEventSigsAddrsWithConfs(ctx context.Context, fromBlock int64, addresses []common.Address, eventSigs []common.Hash, confs evmtypes.Confirmations) ([]Log, error)
 	SelectLatestBlockByEventSigsAddrsWithConfs(ctx context.Context, fromBlock int64, eventSigs []common.Hash, addresses []common.Address, confs evmtypes.Confirmations) (int64, error)
 	SelectLogsByBlockRange(ctx context.Context, start, end int64) ([]Log, error)
+	SelectFilterLogsAfter(ctx context.Context, filterName string, afterBlock, afterLogIndex int64, limit int) ([]Log, error)
+
+	SelectSubscriptionCursor(ctx context.Context, filterName string, confs evmtypes.Confirmations) (*SubscriptionCursor, error)
+	UpsertSubscriptionCursor(ctx context.Context, cursor SubscriptionCursor) error
 
 	SelectIndexedLogs(ctx context.Context, address common.Address, eventSig common.Hash, topicIndex int, topicValues []common.Hash, confs evmtypes.Confirmations) ([]Log, error)
 	SelectIndexedLogsByBlockRange(ctx context.Context, start, end int64, address common.Address, eventSig common.Hash, topicIndex int, topicValues []common.Hash) ([]Log, error)
@@ -415,6 +420,17 @@ func (o *DSORM) DeleteLogsAndBlocksAfter(ctx context.Context, start int64) error
 			o.lggr.Warnw("Unable to clear reorged logs, retrying", "err", err)
 			return err
 		}
+
+		// Subscribers must receive the logs of the new canonical blocks, so their cursors are moved before them.
+		_, err = o.ds.ExecContext(ctx, `UPDATE evm.log_poller_subscriptions
+							SET block_number = $2, log_index = -1, updated_at = NOW()
+							WHERE evm_chain_id = $1
+							AND block_number >= $2`,
+			ubig.New(o.chainID), start)
+		if err != nil {
+			o.lggr.Warnw("Unable to rewind subscription cursors, retrying", "err", err)
+			return err
+		}
 		return nil
 	})
 }
@@ -562,6 +578,21 @@ func (o *DSORM) InsertLogsWithBlock(ctx context.Context, logs []Log, block LogPo
 	})
 }
 
+// InsertBlocksAndLogs inserts the blocks and logs in a single transaction, e.g. when importing a snapshot.
+func (o *DSORM) InsertBlocksAndLogs(ctx context.Context, blocks []LogPollerBlock, logs []Log) error {
+	if err := o.validateLogs(logs); err != nil {
+		return err
+	}
+	return o.Transact(ctx, func(orm *DSORM) error {
+		for _, b := range blocks {
+			if err := orm.InsertBlock(ctx, b.BlockHash, b.BlockNumber, b.BlockTimestamp, b.FinalizedBlockNumber); err != nil {
+				return fmt.Errorf("failed to insert block %d: %w", b.BlockNumber, err)
+			}
+		}
+		return orm.insertLogsWithinTx(ctx, logs, orm.ds)
+	})
+}
+
 func (o *DSORM) insertLogsWithinTx(ctx context.Context, logs []Log, tx sqlutil.DataSource) error {
 	batchInsertSize := 4000
 	for i := 0; i < len(logs); i += batchInsertSize {
@@ -627,6 +658,53 @@ func (o *DSORM) SelectLogsByBlockRange(ctx context.Context, start, end int64) ([
 	return logs, nil
 }
 
+// SelectFilterLogsAfter finds the logs matching the named filter after the position (afterBlock, afterLogIndex),
+// ordered by block number and log index.
+func (o *DSORM) SelectFilterLogsAfter(ctx context.Context, filterName string, afterBlock, afterLogIndex int64, limit int) ([]Log, error) {
+	query := logsQueryWithTablePrefix("l", `
+		WHERE l.evm_chain_id = $1
+		AND (l.block_number, l.log_index) > ($2, $3)
+		AND EXISTS (
+			SELECT 1 FROM evm.log_poller_filters f
+			WHERE f.evm_chain_id = l.evm_chain_id
+			AND f.name = $4
+			AND f.address = l.address
+			AND f.event = l.event_sig
+			AND (f.topic2 IS NULL OR f.topic2 = l.topics[2])
+			AND (f.topic3 IS NULL OR f.topic3 = l.topics[3])
+			AND (f.topic4 IS NULL OR f.topic4 = l.topics[4]))
+		ORDER BY l.block_number, l.log_index
+		LIMIT $5`)
+	var logs []Log
+	if err := o.ds.SelectContext(ctx, &logs, query, ubig.New(o.chainID), afterBlock, afterLogIndex, filterName, limit); err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+// SelectSubscriptionCursor returns the cursor of the subscriber of filterName with confs, or sql.ErrNoRows.
+func (o *DSORM) SelectSubscriptionCursor(ctx context.Context, filterName string, confs evmtypes.Confirmations) (*SubscriptionCursor, error) {
+	var c SubscriptionCursor
+	if err := o.ds.GetContext(ctx, &c, `SELECT filter_name, confs, block_number, log_index, updated_at
+		FROM evm.log_poller_subscriptions
+		WHERE evm_chain_id = $1 AND filter_name = $2 AND confs = $3`,
+		ubig.New(o.chainID), filterName, confs); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// UpsertSubscriptionCursor persists the cursor of a subscriber.
+func (o *DSORM) UpsertSubscriptionCursor(ctx context.Context, cursor SubscriptionCursor) error {
+	_, err := o.ds.ExecContext(ctx, `INSERT INTO evm.log_poller_subscriptions
+			(evm_chain_id, filter_name, confs, block_number, log_index, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		ON CONFLICT (evm_chain_id, filter_name, confs)
+		DO UPDATE SET block_number = EXCLUDED.block_number, log_index = EXCLUDED.log_index, updated_at = EXCLUDED.updated_at`,
+		ubig.New(o.chainID), cursor.FilterName, cursor.Confs, cursor.BlockNumber, cursor.LogIndex)
+	return err
+}
+
 // SelectLogs finds the logs in a given block range.
 func (o *DSORM) SelectLogs(ctx context.Context, start, end int64, address common.Address, eventSig common.Hash) ([]Log, error) {
 	args, err := newQueryArgsForEvent(o.chainID, address, eventSig).
@@ -1400,6 +1400,58 @@ func TestORM_DeleteBlocksBefore(t *testing.T) {
 	require.Equal(t, err, sql.ErrNoRows)
 }
 
+func TestORM_SubscriptionCursors(t *testing.T) {
+	th := SetupTH(t, lpOpts)
+	o1 := th.ORM
+	ctx := testutils.Context(t)
+	event1 := EmitterABI.Events["Log1"].ID
+	address1 := common.HexToAddress("0x2ab9a2Dc53736b361b72d900CdF9F78F9406fbbb")
+	address2 := common.HexToAddress("0x6E225058950f237371261C985Db6bDe26df2200E")
+	require.NoError(t, o1.InsertFilter(ctx, logpoller.Filter{Name: "sub", Addresses: []common.Address{address1}, EventSigs: []common.Hash{event1}}))
+	require.NoError(t, o1.InsertLogs(ctx, []logpoller.Log{
+		GenLog(th.ChainID, 1, 1, "0x1", event1[:], address1),
+		GenLog(th.ChainID, 2, 1, "0x1", event1[:], address2),
+		GenLog(th.ChainID, 3, 1, "0x1", event1[:], address1),
+		GenLog(th.ChainID, 1, 2, "0x2", event1[:], address1),
+		GenLog(th.ChainID, 1, 3, "0x3", event1[:], address1),
+	}))
+
+	logs, err := o1.SelectFilterLogsAfter(ctx, "sub", 1, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, logs, 3)
+	assert.Equal(t, int64(1), logs[0].BlockNumber)
+	assert.Equal(t, int64(3), logs[0].LogIndex)
+	assert.Equal(t, int64(3), logs[2].BlockNumber)
+	logs, err = o1.SelectFilterLogsAfter(ctx, "sub", -1, -1, 2)
+	require.NoError(t, err)
+	require.Len(t, logs, 2)
+	logs, err = o1.SelectFilterLogsAfter(ctx, "other", -1, -1, 10)
+	require.NoError(t, err)
+	require.Empty(t, logs)
+
+	_, err = o1.SelectSubscriptionCursor(ctx, "sub", types.Finalized)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	require.NoError(t, o1.UpsertSubscriptionCursor(ctx, logpoller.SubscriptionCursor{FilterName: "sub", Confs: types.Finalized, BlockNumber: 1, LogIndex: 3}))
+	require.NoError(t, o1.UpsertSubscriptionCursor(ctx, logpoller.SubscriptionCursor{FilterName: "sub", Confs: types.Finalized, BlockNumber: 3, LogIndex: 1}))
+	require.NoError(t, o1.UpsertSubscriptionCursor(ctx, logpoller.SubscriptionCursor{FilterName: "sub", Confs: 0, BlockNumber: 1, LogIndex: 1}))
+	cursor, err := o1.SelectSubscriptionCursor(ctx, "sub", types.Finalized)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), cursor.BlockNumber)
+	assert.Equal(t, int64(1), cursor.LogIndex)
+
+	// reorgs rewind the cursors after the removed blocks
+	require.NoError(t, o1.InsertBlock(ctx, common.HexToHash("0x3"), 3, time.Now(), 0))
+	require.NoError(t, o1.DeleteLogsAndBlocksAfter(ctx, 2))
+	cursor, err = o1.SelectSubscriptionCursor(ctx, "sub", types.Finalized)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), cursor.BlockNumber)
+	assert.Equal(t, int64(-1), cursor.LogIndex)
+	cursor, err = o1.SelectSubscriptionCursor(ctx, "sub", 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), cursor.BlockNumber)
+	assert.Equal(t, int64(1), cursor.LogIndex)
+}
+
 func TestLogPoller_Logs(t *testing.T) {
 	t.Parallel()
 	ctx := testutils.Context(t)
@@ -2126,6 +2178,48 @@ func TestInsertLogsWithBlock(t *testing.T) {
 	}
 }
 
+func TestInsertBlocksAndLogs(t *testing.T) {
+	chainID := testutils.NewRandomEVMChainID()
+	event := utils.RandomBytes32()
+	address := utils.RandomAddress()
+	ctx := testutils.Context(t)
+
+	// We need full db here, because we want to test transaction rollbacks.
+	_, db := heavyweight.FullTestDBV2(t, nil)
+	o := logpoller.NewORM(chainID, db, logger.Test(t))
+
+	blocks := []logpoller.LogPollerBlock{
+		{BlockHash: utils.RandomBytes32(), BlockNumber: 1, BlockTimestamp: time.Now(), FinalizedBlockNumber: 1},
+		{BlockHash: utils.RandomBytes32(), BlockNumber: 2, BlockTimestamp: time.Now(), FinalizedBlockNumber: 2},
+	}
+	logs := []logpoller.Log{
+		GenLog(chainID, 1, 1, utils.RandomAddress().String(), event[:], address),
+		GenLog(chainID, 1, 2, utils.RandomAddress().String(), event[:], address),
+	}
+	invalidBlock := logpoller.LogPollerBlock{BlockHash: utils.RandomBytes32(), BlockNumber: -10, BlockTimestamp: time.Now(), FinalizedBlockNumber: -10}
+
+	t.Run("rollbacks transaction when a block is invalid", func(t *testing.T) {
+		require.Error(t, o.InsertBlocksAndLogs(ctx, append([]logpoller.LogPollerBlock{blocks[0]}, invalidBlock), logs))
+
+		_, err := o.SelectLatestBlock(ctx)
+		require.Error(t, err)
+		dbLogs, err := o.SelectLogs(ctx, 0, math.MaxInt, address, event)
+		require.NoError(t, err)
+		assert.Empty(t, dbLogs)
+	})
+
+	t.Run("persists all blocks and logs", func(t *testing.T) {
+		require.NoError(t, o.InsertBlocksAndLogs(ctx, blocks, logs))
+
+		latest, err := o.SelectLatestBlock(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), latest.BlockNumber)
+		dbLogs, err := o.SelectLogs(ctx, 0, math.MaxInt, address, event)
+		require.NoError(t, err)
+		assert.Len(t, dbLogs, len(logs))
+	})
+}
+
 func TestInsertLogsInTx(t *testing.T) {
 	chainID := testutils.NewRandomEVMChainID()
 	event := utils.RandomBytes32()
@@ -0,0 +1,452 @@
+package logpoller
+
+import (
+	"bufio"
+	"cmp"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/lib/pq"
+	"golang.org/x/exp/maps"
+
+	ubig "github.com/smartcontractkit/chainlink/v2/evm/utils/big"
+)
+
+// SnapshotVersion is the version of the snapshot format written by ExportSnapshot.
+const SnapshotVersion = 1
+
+// snapshotPageSize is the number of blocks read from the db at a time when exporting.
+const snapshotPageSize = 1000
+
+// A snapshot is a gzipped stream of newline delimited JSON records: a SnapshotHeader, followed by the blocks and logs
+// of the range in block order, and a trailer holding the hex encoded SHA-256 checksum of all the preceding lines.
+//
+// Blocks are only exported for full snapshots. A snapshot scoped to a filter holds only the logs of that filter, so
+// importing it does not move the latest block of the log poller past logs of the other filters.
+
+// SnapshotHeader describes the contents of a snapshot.
+type SnapshotHeader struct {
+	Version    int       `json:"version"`
+	EvmChainID *ubig.Big `json:"evmChainId"`
+	FromBlock  int64     `json:"fromBlock"`
+	ToBlock    int64     `json:"toBlock"`
+	Filter     string    `json:"filter,omitempty"`
+}
+
+// SnapshotSummary is the result of exporting or importing a snapshot.
+type SnapshotSummary struct {
+	SnapshotHeader
+	Blocks   int64
+	Logs     int64
+	Checksum string
+}
+
+type snapshotRecord struct {
+	Block    *snapshotBlock   `json:"block,omitempty"`
+	Log      *snapshotLog     `json:"log,omitempty"`
+	Trailer  *snapshotTrailer `json:"trailer,omitempty"`
+	position int64
+}
+
+type snapshotBlock struct {
+	Hash                 common.Hash `json:"hash"`
+	Number               int64       `json:"number"`
+	Timestamp            time.Time   `json:"timestamp"`
+	FinalizedBlockNumber int64       `json:"finalizedBlockNumber"`
+}
+
+type snapshotLog struct {
+	BlockHash      common.Hash     `json:"blockHash"`
+	BlockNumber    int64           `json:"blockNumber"`
+	BlockTimestamp time.Time       `json:"blockTimestamp"`
+	LogIndex       int64           `json:"logIndex"`
+	Address        common.Address  `json:"address"`
+	EventSig       common.Hash     `json:"eventSig"`
+	Topics         []hexutil.Bytes `json:"topics"`
+	TxHash         common.Hash     `json:"txHash"`
+	Data           hexutil.Bytes   `json:"data"`
+}
+
+type snapshotTrailer struct {
+	Blocks   int64  `json:"blocks"`
+	Logs     int64  `json:"logs"`
+	Checksum string `json:"checksum"`
+}
+
+func newSnapshotLog(l Log) *snapshotLog {
+	topics := make([]hexutil.Bytes, len(l.Topics))
+	for i, t := range l.Topics {
+		topics[i] = t
+	}
+	return &snapshotLog{
+		BlockHash:      l.BlockHash,
+		BlockNumber:    l.BlockNumber,
+		BlockTimestamp: l.BlockTimestamp,
+		LogIndex:       l.LogIndex,
+		Address:        l.Address,
+		EventSig:       l.EventSig,
+		Topics:         topics,
+		TxHash:         l.TxHash,
+		Data:           l.Data,
+	}
+}
+
+func (l *snapshotLog) toLog(chainID *ubig.Big) Log {
+	topics := make(pq.ByteaArray, len(l.Topics))
+	for i, t := range l.Topics {
+		topics[i] = t
+	}
+	return Log{
+		EvmChainId:     chainID,
+		LogIndex:       l.LogIndex,
+		BlockHash:      l.BlockHash,
+		BlockNumber:    l.BlockNumber,
+		BlockTimestamp: l.BlockTimestamp,
+		Topics:         topics,
+		EventSig:       l.EventSig,
+		Address:        l.Address,
+		TxHash:         l.TxHash,
+		Data:           l.Data,
+	}
+}
+
+// snapshotWriter writes the lines of a snapshot, keeping the checksum of everything written before the trailer.
+type snapshotWriter struct {
+	gz     *gzip.Writer
+	digest hash.Hash
+	out    io.Writer
+}
+
+func newSnapshotWriter(w io.Writer) *snapshotWriter {
+	gz := gzip.NewWriter(w)
+	digest := sha256.New()
+	return &snapshotWriter{gz: gz, digest: digest, out: io.MultiWriter(gz, digest)}
+}
+
+func (w *snapshotWriter) write(v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = w.out.Write(append(b, '\n'))
+	return err
+}
+
+func (w *snapshotWriter) close(blocks, logs int64) (string, error) {
+	checksum := hex.EncodeToString(w.digest.Sum(nil))
+	b, err := json.Marshal(snapshotRecord{Trailer: &snapshotTrailer{Blocks: blocks, Logs: logs, Checksum: checksum}})
+	if err != nil {
+		return "", err
+	}
+	if _, err = w.gz.Write(append(b, '\n')); err != nil {
+		return "", err
+	}
+	return checksum, w.gz.Close()
+}
+
+// snapshotReader reads the lines of a snapshot and verifies the trailer against the lines read.
+type snapshotReader struct {
+	scanner *bufio.Scanner
+	digest  hash.Hash
+	header  SnapshotHeader
+	line    int64
+	blocks  int64
+	logs    int64
+	trailer *snapshotTrailer
+}
+
+func newSnapshotReader(r io.Reader) (*snapshotReader, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("snapshot is not gzipped: %w", err)
+	}
+	scanner := bufio.NewScanner(gz)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	sr := &snapshotReader{scanner: scanner, digest: sha256.New()}
+	line, err := sr.readLine()
+	if err != nil {
+		return nil, err
+	}
+	if line == nil {
+		return nil, errors.New("snapshot is empty")
+	}
+	sr.digest.Write(line)
+	sr.digest.Write([]byte{'\n'})
+	if err = json.Unmarshal(line, &sr.header); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot header: %w", err)
+	}
+	if sr.header.Version != SnapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d", sr.header.Version)
+	}
+	if sr.header.EvmChainID == nil {
+		return nil, errors.New("snapshot header is missing the chain id")
+	}
+	return sr, nil
+}
+
+func (r *snapshotReader) readLine() ([]byte, error) {
+	if !r.scanner.Scan() {
+		return nil, r.scanner.Err()
+	}
+	r.line++
+	return r.scanner.Bytes(), nil
+}
+
+// next returns the next block or log record, or io.EOF once the trailer has been read and verified.
+func (r *snapshotReader) next() (snapshotRecord, error) {
+	line, err := r.readLine()
+	if err != nil {
+		return snapshotRecord{}, fmt.Errorf("failed to read snapshot line %d: %w", r.line, err)
+	}
+	if line == nil {
+		if r.trailer == nil {
+			return snapshotRecord{}, errors.New("snapshot is truncated: missing trailer")
+		}
+		return snapshotRecord{}, io.EOF
+	}
+	if r.trailer != nil {
+		return snapshotRecord{}, fmt.Errorf("unexpected line %d after the snapshot trailer", r.line)
+	}
+	var rec snapshotRecord
+	if err = json.Unmarshal(line, &rec); err != nil {
+		return snapshotRecord{}, fmt.Errorf("failed to decode snapshot line %d: %w", r.line, err)
+	}
+	switch {
+	case rec.Trailer != nil:
+		if err = r.verify(rec.Trailer); err != nil {
+			return snapshotRecord{}, err
+		}
+		r.trailer = rec.Trailer
+		return r.next()
+	case rec.Block != nil:
+		r.blocks++
+	case rec.Log != nil:
+		r.logs++
+	default:
+		return snapshotRecord{}, fmt.Errorf("unknown record at snapshot line %d", r.line)
+	}
+	r.digest.Write(line)
+	r.digest.Write([]byte{'\n'})
+	rec.position = r.line
+	return rec, nil
+}
+
+func (r *snapshotReader) verify(t *snapshotTrailer) error {
+	checksum := hex.EncodeToString(r.digest.Sum(nil))
+	if t.Checksum != checksum {
+		return fmt.Errorf("snapshot checksum mismatch: expected %s but got %s", t.Checksum, checksum)
+	}
+	if t.Blocks != r.blocks || t.Logs != r.logs {
+		return fmt.Errorf("snapshot is corrupted: expected %d blocks and %d logs but got %d and %d", t.Blocks, t.Logs, r.blocks, r.logs)
+	}
+	return nil
+}
+
+// ExportSnapshot writes the finalized blocks and logs between from and to (inclusive) to w. If filterName is set, only
+// the logs matching the registered filter are exported, and no blocks.
+func (lp *logPoller) ExportSnapshot(ctx context.Context, w io.Writer, from, to int64, filterName string) (SnapshotSummary, error) {
+	if from < 0 || from > to {
+		return SnapshotSummary{}, fmt.Errorf("invalid block range [%d, %d]", from, to)
+	}
+	latest, err := lp.orm.SelectLatestBlock(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return SnapshotSummary{}, errors.New("no blocks have been saved by the log poller yet")
+		}
+		return SnapshotSummary{}, fmt.Errorf("failed to select latest block: %w", err)
+	}
+	if to > latest.FinalizedBlockNumber {
+		return SnapshotSummary{}, fmt.Errorf("block %d is not finalized, latest finalized block is %d", to, latest.FinalizedBlockNumber)
+	}
+
+	var filter *Filter
+	if filterName != "" {
+		filters, err2 := lp.orm.LoadFilters(ctx)
+		if err2 != nil {
+			return SnapshotSummary{}, fmt.Errorf("failed to load filters: %w", err2)
+		}
+		f, ok := filters[filterName]
+		if !ok {
+			return SnapshotSummary{}, fmt.Errorf("filter %s is not registered", filterName)
+		}
+		filter = &f
+	}
+
+	summary := SnapshotSummary{SnapshotHeader: SnapshotHeader{
+		Version:    SnapshotVersion,
+		EvmChainID: ubig.New(lp.ec.ConfiguredChainID()),
+		FromBlock:  from,
+		ToBlock:    to,
+		Filter:     filterName,
+	}}
+	sw := newSnapshotWriter(w)
+	if err = sw.write(summary.SnapshotHeader); err != nil {
+		return SnapshotSummary{}, err
+	}
+	for start := from; start <= to; start += snapshotPageSize {
+		end := min(start+snapshotPageSize-1, to)
+		var blocks []LogPollerBlock
+		if filter == nil {
+			if blocks, err = lp.orm.GetBlocksRange(ctx, start, end); err != nil {
+				return SnapshotSummary{}, fmt.Errorf("failed to select blocks [%d, %d]: %w", start, end, err)
+			}
+		}
+		logs, err2 := lp.snapshotLogs(ctx, start, end, filter)
+		if err2 != nil {
+			return SnapshotSummary{}, fmt.Errorf("failed to select logs [%d, %d]: %w", start, end, err2)
+		}
+		// blocks precede their logs, so that import can check logs against the blocks of the snapshot
+		var i int
+		for _, b := range blocks {
+			for ; i < len(logs) && logs[i].BlockNumber < b.BlockNumber; i++ {
+				if err = sw.write(snapshotRecord{Log: newSnapshotLog(logs[i])}); err != nil {
+					return SnapshotSummary{}, err
+				}
+			}
+			if err = sw.write(snapshotRecord{Block: &snapshotBlock{
+				Hash:                 b.BlockHash,
+				Number:               b.BlockNumber,
+				Timestamp:            b.BlockTimestamp,
+				FinalizedBlockNumber: b.FinalizedBlockNumber,
+			}}); err != nil {
+				return SnapshotSummary{}, err
+			}
+		}
+		for ; i < len(logs); i++ {
+			if err = sw.write(snapshotRecord{Log: newSnapshotLog(logs[i])}); err != nil {
+				return SnapshotSummary{}, err
+			}
+		}
+		summary.Blocks += int64(len(blocks))
+		summary.Logs += int64(len(logs))
+	}
+	if summary.Checksum, err = sw.close(summary.Blocks, summary.Logs); err != nil {
+		return SnapshotSummary{}, err
+	}
+	return summary, nil
+}
+
+// snapshotLogs returns the logs between start and end matching filter, or all logs if filter is nil, ordered by
+// block number and log index.
+func (lp *logPoller) snapshotLogs(ctx context.Context, start, end int64, filter *Filter) ([]Log, error) {
+	if filter == nil {
+		return lp.orm.SelectLogsByBlockRange(ctx, start, end)
+	}
+	var logs []Log
+	for _, addr := range filter.Addresses {
+		addrLogs, err := lp.orm.SelectLogsWithSigs(ctx, start, end, addr, filter.EventSigs)
+		if err != nil {
+			return nil, err
+		}
+		for i := range addrLogs {
+			if filter.matches(&addrLogs[i]) {
+				logs = append(logs, addrLogs[i])
+			}
+		}
+	}
+	slices.SortFunc(logs, func(a, b Log) int {
+		return cmp.Or(cmp.Compare(a.BlockNumber, b.BlockNumber), cmp.Compare(a.LogIndex, b.LogIndex))
+	})
+	return logs, nil
+}
+
+// ImportSnapshot inserts the blocks and logs of a snapshot written by ExportSnapshot. The snapshot is read twice:
+// first to verify its checksum and that all of its blocks are finalized blocks of the chain, by comparing their
+// hashes with the RPC, and then to load its blocks and logs, which are inserted in a single transaction once the
+// snapshot is fully read. Nothing is inserted if the verification or the insertion fails.
+func (lp *logPoller) ImportSnapshot(ctx context.Context, r io.ReadSeeker) (SnapshotSummary, error) {
+	sr, err := newSnapshotReader(r)
+	if err != nil {
+		return SnapshotSummary{}, err
+	}
+	if chainID := lp.ec.ConfiguredChainID(); sr.header.EvmChainID.Cmp(ubig.New(chainID)) != 0 {
+		return SnapshotSummary{}, fmt.Errorf("snapshot is for chain %s, but the log poller is for chain %s", sr.header.EvmChainID, chainID)
+	}
+
+	hashes := make(map[uint64]common.Hash)
+	for {
+		rec, err2 := sr.next()
+		if errors.Is(err2, io.EOF) {
+			break
+		} else if err2 != nil {
+			return SnapshotSummary{}, err2
+		}
+		num, blockHash := rec.block()
+		if num < sr.header.FromBlock || num > sr.header.ToBlock {
+			return SnapshotSummary{}, fmt.Errorf("block %d at snapshot line %d is outside of the snapshot range [%d, %d]", num, rec.position, sr.header.FromBlock, sr.header.ToBlock)
+		}
+		if h, ok := hashes[uint64(num)]; ok && h != blockHash { //nolint:gosec // G115
+			return SnapshotSummary{}, fmt.Errorf("conflicting hashes %s and %s for block %d at snapshot line %d", h, blockHash, num, rec.position)
+		}
+		hashes[uint64(num)] = blockHash //nolint:gosec // G115
+	}
+	summary := SnapshotSummary{SnapshotHeader: sr.header, Blocks: sr.blocks, Logs: sr.logs, Checksum: sr.trailer.Checksum}
+
+	numbers := maps.Keys(hashes)
+	slices.Sort(numbers)
+	fetched, err := lp.batchFetchBlocks(ctx, numbers, lp.rpcBatchSize)
+	if err != nil {
+		return SnapshotSummary{}, fmt.Errorf("failed to fetch finalized blocks of the snapshot: %w", err)
+	}
+	for _, num := range numbers {
+		b, ok := fetched[num]
+		if !ok {
+			return SnapshotSummary{}, fmt.Errorf("block %d was not returned by the RPC", num)
+		}
+		if b.BlockHash != hashes[num] {
+			return SnapshotSummary{}, fmt.Errorf("hash of block %d in the snapshot is %s, but the finalized block has hash %s", num, hashes[num], b.BlockHash)
+		}
+	}
+
+	if _, err = r.Seek(0, io.SeekStart); err != nil {
+		return SnapshotSummary{}, err
+	}
+	if sr, err = newSnapshotReader(r); err != nil {
+		return SnapshotSummary{}, err
+	}
+	var blocks []LogPollerBlock
+	var logs []Log
+	for {
+		rec, err2 := sr.next()
+		if errors.Is(err2, io.EOF) {
+			break
+		} else if err2 != nil {
+			return SnapshotSummary{}, err2
+		}
+		// guards against the file changing between the two reads
+		if num, blockHash := rec.block(); hashes[uint64(num)] != blockHash { //nolint:gosec // G115
+			return SnapshotSummary{}, fmt.Errorf("block %d at snapshot line %d was not verified", num, rec.position)
+		}
+		if b := rec.Block; b != nil {
+			blocks = append(blocks, LogPollerBlock{BlockHash: b.Hash, BlockNumber: b.Number, BlockTimestamp: b.Timestamp, FinalizedBlockNumber: b.FinalizedBlockNumber})
+			continue
+		}
+		logs = append(logs, rec.Log.toLog(sr.header.EvmChainID))
+	}
+	if err = lp.orm.InsertBlocksAndLogs(ctx, blocks, logs); err != nil {
+		return SnapshotSummary{}, fmt.Errorf("failed to insert snapshot: %w", err)
+	}
+	lp.lggr.Infow("Imported snapshot", "fromBlock", summary.FromBlock, "toBlock", summary.ToBlock, "filter", summary.Filter,
+		"blocks", summary.Blocks, "logs", summary.Logs, "checksum", summary.Checksum)
+	return summary, nil
+}
+
+// block returns the number and hash of the block of a block or log record.
+func (rec *snapshotRecord) block() (int64, common.Hash) {
+	if rec.Block != nil {
+		return rec.Block.Number, rec.Block.Hash
+	}
+	return rec.Log.BlockNumber, rec.Log.BlockHash
+}
@@ -0,0 +1,225 @@
+package logpoller
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"io"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/evm/client/clienttest"
+	ubig "github.com/smartcontractkit/chainlink/v2/evm/utils/big"
+)
+
+// snapshotORM is an in-memory ORM for the queries of snapshots.
+type snapshotORM struct {
+	ORM
+	filters map[string]Filter
+	blocks  []LogPollerBlock
+	logs    []Log
+	inserts int
+}
+
+func (o *snapshotORM) SelectLatestBlock(context.Context) (*LogPollerBlock, error) {
+	if len(o.blocks) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return &o.blocks[len(o.blocks)-1], nil
+}
+
+func (o *snapshotORM) SelectLatestFinalizedBlock(context.Context) (*LogPollerBlock, error) {
+	return nil, sql.ErrNoRows
+}
+
+func (o *snapshotORM) LoadFilters(context.Context) (map[string]Filter, error) {
+	return o.filters, nil
+}
+
+func (o *snapshotORM) GetBlocksRange(_ context.Context, start, end int64) ([]LogPollerBlock, error) {
+	var blocks []LogPollerBlock
+	for _, b := range o.blocks {
+		if b.BlockNumber >= start && b.BlockNumber <= end {
+			blocks = append(blocks, b)
+		}
+	}
+	return blocks, nil
+}
+
+func (o *snapshotORM) SelectLogsByBlockRange(_ context.Context, start, end int64) ([]Log, error) {
+	var logs []Log
+	for _, l := range o.logs {
+		if l.BlockNumber >= start && l.BlockNumber <= end {
+			logs = append(logs, l)
+		}
+	}
+	return logs, nil
+}
+
+func (o *snapshotORM) SelectLogsWithSigs(_ context.Context, start, end int64, address common.Address, eventSigs []common.Hash) ([]Log, error) {
+	var logs []Log
+	for _, l := range o.logs {
+		if l.BlockNumber >= start && l.BlockNumber <= end && l.Address == address {
+			for _, sig := range eventSigs {
+				if l.EventSig == sig {
+					logs = append(logs, l)
+				}
+			}
+		}
+	}
+	return logs, nil
+}
+
+func (o *snapshotORM) InsertBlocksAndLogs(_ context.Context, blocks []LogPollerBlock, logs []Log) error {
+	o.inserts++
+	o.blocks = append(o.blocks, blocks...)
+	o.logs = append(o.logs, logs...)
+	return nil
+}
+
+func newSnapshotLogPoller(t *testing.T, orm ORM, chainID *big.Int) *logPoller {
+	ec := clienttest.NewClient(t)
+	mockBatchCallContext(t, ec) // finalized block is 5, and the hash of each block is its number
+	ec.On("ConfiguredChainID").Return(chainID)
+	for _, c := range ec.ExpectedCalls {
+		c.Maybe()
+	}
+	return NewLogPoller(orm, ec, logger.Test(t), nil, Opts{UseFinalityTag: true, RpcBatchSize: 2})
+}
+
+// rewriteSnapshot applies fn to the uncompressed lines of a snapshot.
+func rewriteSnapshot(t *testing.T, snapshot []byte, fn func(lines []string) []string) []byte {
+	gz, err := gzip.NewReader(bytes.NewReader(snapshot))
+	require.NoError(t, err)
+	b, err := io.ReadAll(gz)
+	require.NoError(t, err)
+	lines := fn(strings.Split(strings.TrimSuffix(string(b), "\n"), "\n"))
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err = w.Write([]byte(strings.Join(lines, "\n") + "\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestLogPoller_Snapshot(t *testing.T) {
+	t.Parallel()
+	chainID := testutils.FixtureChainID
+	addr := testutils.NewAddress()
+	sig := common.HexToHash("0x01")
+	filter := Filter{Name: "test", Addresses: []common.Address{addr}, EventSigs: []common.Hash{sig}}
+
+	src := &snapshotORM{filters: map[string]Filter{filter.Name: filter}}
+	for i := int64(1); i <= 6; i++ {
+		src.blocks = append(src.blocks, LogPollerBlock{
+			BlockHash:            common.BigToHash(big.NewInt(i)),
+			BlockNumber:          i,
+			BlockTimestamp:       time.Unix(i, 0).UTC(),
+			FinalizedBlockNumber: 5,
+		})
+	}
+	for _, l := range []Log{
+		subscriptionLog(addr, sig, 2, 0),
+		subscriptionLog(testutils.NewAddress(), sig, 2, 1),
+		subscriptionLog(addr, sig, 4, 3),
+	} {
+		l.EvmChainId = ubig.New(chainID)
+		l.BlockHash = common.BigToHash(big.NewInt(l.BlockNumber))
+		l.BlockTimestamp = time.Unix(l.BlockNumber, 0).UTC()
+		l.Data = []byte{1, 2, 3}
+		src.logs = append(src.logs, l)
+	}
+	exporter := newSnapshotLogPoller(t, src, chainID)
+
+	export := func(t *testing.T, from, to int64, filterName string) []byte {
+		var buf bytes.Buffer
+		_, err := exporter.ExportSnapshot(tests.Context(t), &buf, from, to, filterName)
+		require.NoError(t, err)
+		return buf.Bytes()
+	}
+
+	t.Run("exports finalized blocks only", func(t *testing.T) {
+		_, err := exporter.ExportSnapshot(tests.Context(t), io.Discard, 1, 6, "")
+		require.ErrorContains(t, err, "block 6 is not finalized")
+		_, err = exporter.ExportSnapshot(tests.Context(t), io.Discard, 1, 5, "missing")
+		require.ErrorContains(t, err, "filter missing is not registered")
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		dst := &snapshotORM{}
+		summary, err := newSnapshotLogPoller(t, dst, chainID).ImportSnapshot(tests.Context(t), bytes.NewReader(export(t, 1, 5, "")))
+		require.NoError(t, err)
+		// the blocks and logs are inserted at once
+		assert.Equal(t, 1, dst.inserts)
+		assert.Equal(t, int64(5), summary.Blocks)
+		assert.Equal(t, int64(3), summary.Logs)
+		assert.Equal(t, src.blocks[:5], dst.blocks)
+		for i := range dst.logs {
+			assert.Equal(t, src.logs[i].Topics, dst.logs[i].Topics)
+			dst.logs[i].Topics = src.logs[i].Topics
+		}
+		assert.Equal(t, src.logs, dst.logs)
+	})
+
+	t.Run("filter snapshot holds no blocks", func(t *testing.T) {
+		dst := &snapshotORM{}
+		summary, err := newSnapshotLogPoller(t, dst, chainID).ImportSnapshot(tests.Context(t), bytes.NewReader(export(t, 1, 5, filter.Name)))
+		require.NoError(t, err)
+		assert.Equal(t, filter.Name, summary.Filter)
+		assert.Empty(t, dst.blocks)
+		assert.Equal(t, []logPosition{{2, 0}, {4, 3}}, positions(dst.logs))
+	})
+
+	t.Run("rejects tampered snapshots", func(t *testing.T) {
+		snapshot := export(t, 1, 5, "")
+		for name, tc := range map[string]struct {
+			snapshot []byte
+			err      string
+		}{
+			"checksum": {rewriteSnapshot(t, snapshot, func(lines []string) []string {
+				lines[2] = strings.Replace(lines[2], `"finalizedBlockNumber":5`, `"finalizedBlockNumber":4`, 1)
+				return lines
+			}), "snapshot checksum mismatch"},
+			"truncated": {rewriteSnapshot(t, snapshot, func(lines []string) []string {
+				return lines[:len(lines)-1]
+			}), "missing trailer"},
+			"other chain": {rewriteSnapshot(t, snapshot, func(lines []string) []string {
+				lines[0] = strings.Replace(lines[0], `"evmChainId":"`+chainID.String()+`"`, `"evmChainId":"1"`, 1)
+				return lines
+			}), "snapshot is for chain 1"},
+		} {
+			t.Run(name, func(t *testing.T) {
+				dst := &snapshotORM{}
+				_, err := newSnapshotLogPoller(t, dst, chainID).ImportSnapshot(tests.Context(t), bytes.NewReader(tc.snapshot))
+				require.ErrorContains(t, err, tc.err)
+				assert.Zero(t, dst.inserts)
+				assert.Empty(t, dst.blocks)
+				assert.Empty(t, dst.logs)
+			})
+		}
+	})
+
+	t.Run("rejects blocks not on the chain", func(t *testing.T) {
+		forked := &snapshotORM{blocks: append([]LogPollerBlock(nil), src.blocks...)}
+		forked.blocks[2].BlockHash = common.HexToHash("0xbad")
+		var buf bytes.Buffer
+		_, err := newSnapshotLogPoller(t, forked, chainID).ExportSnapshot(tests.Context(t), &buf, 1, 5, "")
+		require.NoError(t, err)
+
+		dst := &snapshotORM{}
+		_, err = newSnapshotLogPoller(t, dst, chainID).ImportSnapshot(tests.Context(t), bytes.NewReader(buf.Bytes()))
+		require.ErrorContains(t, err, "hash of block 3 in the snapshot is")
+		assert.Empty(t, dst.blocks)
+	})
+}
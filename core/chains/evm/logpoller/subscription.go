@@ -0,0 +1,481 @@
+package logpoller
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+
+	evmtypes "github.com/smartcontractkit/chainlink/v2/evm/types"
+)
+
+const (
+	// subscriptionSyncPageSize is the number of logs loaded per query while a subscription catches up from the db.
+	subscriptionSyncPageSize = 1000
+	// subscriptionMaxQueuedLogs is the number of undelivered logs after which a subscription drops them, and catches up
+	// from its acknowledged cursor once the subscriber has drained its events.
+	subscriptionMaxQueuedLogs = 10_000
+	// subscriptionRetryPeriod is how long a subscription waits before retrying a failed query.
+	subscriptionRetryPeriod = 5 * time.Second
+)
+
+var ErrSubscriptionExists = errors.New("subscription already exists")
+
+// Subscription delivers the logs of a filter as they are persisted by the LogPoller, see LogPoller.Subscribe.
+type Subscription interface {
+	// Events delivers new logs and reorg retractions, in order. It is closed when the subscription is closed.
+	Events() <-chan SubscriptionEvent
+	// Ack persists the position of the event, so that subscribing again resumes after it.
+	Ack(ctx context.Context, event SubscriptionEvent) error
+	// Close stops the subscription.
+	Close()
+}
+
+// SubscriptionEvent is either a batch of newly confirmed logs, or a retraction of delivered logs removed by a reorg.
+type SubscriptionEvent struct {
+	// Logs are confirmed logs matching the filter, ordered by block number and log index.
+	Logs []Log
+	// Retracted are delivered logs which were removed by a reorg of the blocks from RetractedFromBlock.
+	Retracted          []Log
+	RetractedFromBlock int64
+
+	epoch uint64
+}
+
+// logPosition orders logs by block number and log index.
+type logPosition struct {
+	blockNumber int64
+	logIndex    int64
+}
+
+func positionOf(l *Log) logPosition {
+	return logPosition{blockNumber: l.BlockNumber, logIndex: l.LogIndex}
+}
+
+func (p logPosition) cmp(o logPosition) int {
+	if p.blockNumber != o.blockNumber {
+		if p.blockNumber < o.blockNumber {
+			return -1
+		}
+		return 1
+	}
+	if p.logIndex < o.logIndex {
+		return -1
+	} else if p.logIndex > o.logIndex {
+		return 1
+	}
+	return 0
+}
+
+// matches returns true if the log matches the addresses, event signatures and topics of the filter.
+func (filter *Filter) matches(l *Log) bool {
+	if !slices.Contains(filter.Addresses, l.Address) || !slices.Contains(filter.EventSigs, l.EventSig) {
+		return false
+	}
+	for i, values := range []evmtypes.HashArray{filter.Topic2, filter.Topic3, filter.Topic4} {
+		if len(values) == 0 {
+			continue
+		}
+		if len(l.Topics) <= i+1 || !slices.Contains(values, common.BytesToHash(l.Topics[i+1])) {
+			return false
+		}
+	}
+	return true
+}
+
+type subscriptionKey struct {
+	filterName string
+	confs      evmtypes.Confirmations
+}
+
+// subscriptions pushes the logs saved by the LogPoller to the subscriptions of their filters.
+type subscriptions struct {
+	lggr   logger.SugaredLogger
+	orm    ORM
+	filter func(name string) (Filter, bool)
+	stopCh services.StopChan
+	wg     sync.WaitGroup
+
+	mu   sync.RWMutex
+	subs map[subscriptionKey]*subscription
+}
+
+func newSubscriptions(lggr logger.SugaredLogger, orm ORM, filter func(name string) (Filter, bool), stopCh services.StopChan) *subscriptions {
+	return &subscriptions{
+		lggr:   lggr,
+		orm:    orm,
+		filter: filter,
+		stopCh: stopCh,
+		subs:   make(map[subscriptionKey]*subscription),
+	}
+}
+
+func (s *subscriptions) subscribe(ctx context.Context, filterName string, confs evmtypes.Confirmations) (*subscription, error) {
+	start := logPosition{blockNumber: -1, logIndex: -1}
+	cursor, err := s.orm.SelectSubscriptionCursor(ctx, filterName, confs)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to load subscription cursor: %w", err)
+	} else if err == nil {
+		start = logPosition{blockNumber: cursor.BlockNumber, logIndex: cursor.LogIndex}
+	}
+
+	key := subscriptionKey{filterName: filterName, confs: confs}
+	sub := &subscription{
+		key:      key,
+		subs:     s,
+		lggr:     logger.Sugared(logger.With(s.lggr, "filterName", filterName, "confs", confs)),
+		events:   make(chan SubscriptionEvent),
+		wake:     make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+		acked:    start,
+		queued:   start,
+		syncing:  true,
+		syncedTo: start,
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.subs[key]; ok {
+		return nil, fmt.Errorf("%w: filter %s with %d confirmations", ErrSubscriptionExists, filterName, confs)
+	}
+	s.subs[key] = sub
+	s.wg.Add(1)
+	go sub.run()
+	return sub, nil
+}
+
+func (s *subscriptions) remove(sub *subscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.subs[sub.key] == sub {
+		delete(s.subs, sub.key)
+	}
+}
+
+func (s *subscriptions) active() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.subs) > 0
+}
+
+func (s *subscriptions) all() []*subscription {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	subs := make([]*subscription, 0, len(s.subs))
+	for _, sub := range s.subs {
+		subs = append(subs, sub)
+	}
+	return subs
+}
+
+// onLogsSaved is called after the logs and the latest block were committed to the db.
+func (s *subscriptions) onLogsSaved(logs []Log, latestBlock, finalizedBlock int64) {
+	for _, sub := range s.all() {
+		filter, ok := s.filter(sub.key.filterName)
+		var matched []Log
+		if ok {
+			for i := range logs {
+				if filter.matches(&logs[i]) {
+					matched = append(matched, logs[i])
+				}
+			}
+		}
+		sub.add(matched, latestBlock, finalizedBlock)
+	}
+}
+
+// retract is called after the logs and blocks from block start were removed by a reorg. removed are the logs which
+// were saved in the db before.
+func (s *subscriptions) retract(start int64, removed []Log) {
+	for _, sub := range s.all() {
+		filter, ok := s.filter(sub.key.filterName)
+		var matched []Log
+		if ok {
+			for i := range removed {
+				if filter.matches(&removed[i]) {
+					matched = append(matched, removed[i])
+				}
+			}
+		}
+		sub.retract(start, matched)
+	}
+}
+
+func (s *subscriptions) close() {
+	for _, sub := range s.all() {
+		sub.Close()
+	}
+	s.wg.Wait()
+}
+
+type subscription struct {
+	key    subscriptionKey
+	subs   *subscriptions
+	lggr   logger.SugaredLogger
+	events chan SubscriptionEvent
+	wake   chan struct{}
+
+	stop     chan struct{}
+	stopOnce sync.Once
+
+	mu       sync.Mutex
+	acked    logPosition // last position acknowledged by the subscriber
+	queued   logPosition // last position of the logs queued for delivery
+	syncing  bool        // whether logs after syncedTo may be missing and must be loaded from the db
+	syncedTo logPosition
+	syncGen  uint64 // incremented whenever syncedTo is reset, to discard concurrent loads
+	pending  []Log  // unconfirmed logs after queued
+	queue    []SubscriptionEvent
+	queueLen int // number of logs in queue
+	latest   int64
+	final    int64
+	epoch    uint64 // incremented by every reorg
+}
+
+var _ Subscription = (*subscription)(nil)
+
+func (s *subscription) Events() <-chan SubscriptionEvent { return s.events }
+
+func (s *subscription) Ack(ctx context.Context, event SubscriptionEvent) error {
+	if len(event.Logs) == 0 {
+		return nil
+	}
+	pos := positionOf(&event.Logs[len(event.Logs)-1])
+
+	s.mu.Lock()
+	// Logs delivered before a reorg may have been retracted since, so their position must not be persisted.
+	stale := event.epoch != s.epoch || pos.cmp(s.acked) <= 0
+	s.mu.Unlock()
+	if stale {
+		return nil
+	}
+
+	if err := s.subs.orm.UpsertSubscriptionCursor(ctx, SubscriptionCursor{
+		FilterName:  s.key.filterName,
+		Confs:       s.key.confs,
+		BlockNumber: pos.blockNumber,
+		LogIndex:    pos.logIndex,
+	}); err != nil {
+		return fmt.Errorf("failed to persist subscription cursor: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if event.epoch == s.epoch && pos.cmp(s.acked) > 0 {
+		s.acked = pos
+	}
+	return nil
+}
+
+func (s *subscription) Close() {
+	s.stopOnce.Do(func() {
+		s.subs.remove(s)
+		close(s.stop)
+	})
+}
+
+// confirmedBlock returns the latest block whose logs have enough confirmations. Must be called with mu held.
+func (s *subscription) confirmedBlock() int64 {
+	if s.key.confs == evmtypes.Finalized {
+		return s.final
+	}
+	return s.latest - int64(s.key.confs)
+}
+
+func (s *subscription) add(logs []Log, latestBlock, finalizedBlock int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latest = max(s.latest, latestBlock)
+	s.final = max(s.final, finalizedBlock)
+	s.addPending(logs)
+	s.release()
+}
+
+// addPending adds logs after the queued position to pending. Must be called with mu held.
+func (s *subscription) addPending(logs []Log) {
+	added := false
+	for _, l := range logs {
+		if positionOf(&l).cmp(s.queued) > 0 {
+			s.pending = append(s.pending, l)
+			added = true
+		}
+	}
+	if !added {
+		return
+	}
+	slices.SortFunc(s.pending, func(a, b Log) int { return positionOf(&a).cmp(positionOf(&b)) })
+	s.pending = slices.CompactFunc(s.pending, func(a, b Log) bool { return positionOf(&a) == positionOf(&b) })
+}
+
+// release queues the confirmed pending logs for delivery. Must be called with mu held.
+func (s *subscription) release() {
+	confirmed := s.confirmedBlock()
+	n := 0
+	for n < len(s.pending) && s.pending[n].BlockNumber <= confirmed &&
+		(!s.syncing || positionOf(&s.pending[n]).cmp(s.syncedTo) <= 0) {
+		n++
+	}
+	if n == 0 {
+		return
+	}
+	logs := slices.Clone(s.pending[:n])
+	s.pending = slices.Delete(s.pending, 0, n)
+	s.queued = positionOf(&logs[len(logs)-1])
+	s.queue = append(s.queue, SubscriptionEvent{Logs: logs, epoch: s.epoch})
+	s.queueLen += len(logs)
+
+	if s.queueLen > subscriptionMaxQueuedLogs {
+		s.lggr.Warnw("Subscriber is falling behind, dropping queued logs to reload them later", "queued", s.queueLen)
+		s.queue, s.queueLen, s.pending = nil, 0, nil
+		s.queued = s.acked
+		s.resync(s.acked)
+	}
+	s.notify()
+}
+
+// resync loads the logs after pos from the db. Must be called with mu held.
+func (s *subscription) resync(pos logPosition) {
+	s.syncing = true
+	s.syncedTo = pos
+	s.syncGen++
+}
+
+func (s *subscription) retract(start int64, removed []Log) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cut := logPosition{blockNumber: start, logIndex: -1}
+	var retracted []Log
+	for _, l := range removed {
+		if pos := positionOf(&l); pos.cmp(cut) > 0 && pos.cmp(s.queued) <= 0 {
+			retracted = append(retracted, l)
+		}
+	}
+	s.pending = slices.DeleteFunc(s.pending, func(l Log) bool { return l.BlockNumber >= start })
+	if s.queued.cmp(cut) > 0 {
+		s.queued = cut
+	}
+	if s.acked.cmp(cut) > 0 {
+		// the persisted cursor was rewound with the removal of the blocks
+		s.acked = cut
+	}
+	if s.syncing && s.syncedTo.cmp(cut) > 0 {
+		s.resync(cut)
+	}
+	s.latest = min(s.latest, start-1)
+	s.final = min(s.final, start-1)
+	s.epoch++
+	if len(retracted) > 0 {
+		s.queue = append(s.queue, SubscriptionEvent{Retracted: retracted, RetractedFromBlock: start, epoch: s.epoch})
+		s.notify()
+	}
+}
+
+// notify wakes up run. Must be called with mu held.
+func (s *subscription) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// next returns the next event to deliver, or whether the subscription needs to load logs from the db.
+func (s *subscription) next() (event SubscriptionEvent, ok bool, syncing bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.queue) > 0 {
+		event = s.queue[0]
+		s.queue[0] = SubscriptionEvent{}
+		s.queue = s.queue[1:]
+		s.queueLen -= len(event.Logs)
+		return event, true, false
+	}
+	return event, false, s.syncing
+}
+
+func (s *subscription) run() {
+	defer s.subs.wg.Done()
+	defer close(s.events)
+	ctx, cancel := s.subs.stopCh.NewCtx()
+	defer cancel()
+
+	for {
+		event, ok, syncing := s.next()
+		switch {
+		case ok:
+			select {
+			case s.events <- event:
+			case <-s.stop:
+				return
+			case <-ctx.Done():
+				return
+			}
+		case syncing:
+			if err := s.sync(ctx); err != nil {
+				s.lggr.Errorw("Failed to load logs for subscription, retrying later", "err", err)
+				select {
+				case <-time.After(subscriptionRetryPeriod):
+				case <-s.stop:
+					return
+				case <-ctx.Done():
+					return
+				}
+			}
+		default:
+			select {
+			case <-s.wake:
+			case <-s.stop:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// sync loads the next page of logs after syncedTo from the db.
+func (s *subscription) sync(ctx context.Context) error {
+	s.mu.Lock()
+	from, gen, needLatest := s.syncedTo, s.syncGen, s.latest == 0
+	s.mu.Unlock()
+
+	var latest *LogPollerBlock
+	if needLatest {
+		var err error
+		latest, err = s.subs.orm.SelectLatestBlock(ctx)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+	}
+	logs, err := s.subs.orm.SelectFilterLogsAfter(ctx, s.key.filterName, from.blockNumber, from.logIndex, subscriptionSyncPageSize)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if gen != s.syncGen {
+		// syncedTo was reset by a reorg or an overflow in the meantime
+		return nil
+	}
+	if latest != nil {
+		s.latest = max(s.latest, latest.BlockNumber)
+		s.final = max(s.final, latest.FinalizedBlockNumber)
+	}
+	s.addPending(logs)
+	if len(logs) < subscriptionSyncPageSize {
+		s.syncing = false
+	} else {
+		s.syncedTo = positionOf(&logs[len(logs)-1])
+	}
+	s.release()
+	return nil
+}
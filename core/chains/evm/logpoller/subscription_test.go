@@ -0,0 +1,239 @@
+package logpoller
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	evmtypes "github.com/smartcontractkit/chainlink/v2/evm/types"
+)
+
+// subscriptionORM is an in-memory ORM for the queries of subscriptions.
+type subscriptionORM struct {
+	ORM
+	mu      sync.Mutex
+	filter  Filter
+	logs    []Log
+	latest  *LogPollerBlock
+	cursors map[subscriptionKey]SubscriptionCursor
+}
+
+func (o *subscriptionORM) SelectSubscriptionCursor(_ context.Context, filterName string, confs evmtypes.Confirmations) (*SubscriptionCursor, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	c, ok := o.cursors[subscriptionKey{filterName, confs}]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return &c, nil
+}
+
+func (o *subscriptionORM) UpsertSubscriptionCursor(_ context.Context, cursor SubscriptionCursor) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.cursors[subscriptionKey{cursor.FilterName, cursor.Confs}] = cursor
+	return nil
+}
+
+func (o *subscriptionORM) SelectLatestBlock(context.Context) (*LogPollerBlock, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.latest == nil {
+		return nil, sql.ErrNoRows
+	}
+	return o.latest, nil
+}
+
+func (o *subscriptionORM) SelectFilterLogsAfter(_ context.Context, filterName string, afterBlock, afterLogIndex int64, limit int) ([]Log, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	after := logPosition{afterBlock, afterLogIndex}
+	var logs []Log
+	for i := range o.logs {
+		if o.filter.Name == filterName && o.filter.matches(&o.logs[i]) && positionOf(&o.logs[i]).cmp(after) > 0 && len(logs) < limit {
+			logs = append(logs, o.logs[i])
+		}
+	}
+	return logs, nil
+}
+
+func newTestSubscriptions(t *testing.T, orm *subscriptionORM) *subscriptions {
+	stopCh := make(services.StopChan)
+	subs := newSubscriptions(logger.Sugared(logger.Test(t)), orm, func(name string) (Filter, bool) {
+		return orm.filter, name == orm.filter.Name
+	}, stopCh)
+	t.Cleanup(func() {
+		close(stopCh)
+		subs.close()
+	})
+	return subs
+}
+
+func subscriptionLog(address common.Address, eventSig common.Hash, block, index int64) Log {
+	return Log{
+		Address:     address,
+		EventSig:    eventSig,
+		Topics:      pq.ByteaArray{eventSig.Bytes()},
+		BlockNumber: block,
+		LogIndex:    index,
+	}
+}
+
+func requireEvent(t *testing.T, sub Subscription) SubscriptionEvent {
+	select {
+	case ev, ok := <-sub.Events():
+		require.True(t, ok, "subscription closed")
+		return ev
+	case <-time.After(tests.WaitTimeout(t)):
+		t.Fatal("timed out waiting for subscription event")
+	}
+	return SubscriptionEvent{}
+}
+
+func requireNoEvent(t *testing.T, sub Subscription) {
+	select {
+	case ev := <-sub.Events():
+		t.Fatalf("unexpected event: %v", ev)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func positions(logs []Log) []logPosition {
+	var ps []logPosition
+	for i := range logs {
+		ps = append(ps, positionOf(&logs[i]))
+	}
+	return ps
+}
+
+func TestFilter_Matches(t *testing.T) {
+	t.Parallel()
+	addr := testutils.NewAddress()
+	sig := common.HexToHash("0x01")
+	topic := common.HexToHash("0x02")
+	filter := Filter{Addresses: []common.Address{addr}, EventSigs: []common.Hash{sig}, Topic3: []common.Hash{topic}}
+
+	l := subscriptionLog(addr, sig, 1, 0)
+	assert.False(t, filter.matches(&l), "missing topic3")
+	l.Topics = pq.ByteaArray{sig.Bytes(), common.HexToHash("0x05").Bytes(), topic.Bytes()}
+	assert.True(t, filter.matches(&l))
+	l.Address = testutils.NewAddress()
+	assert.False(t, filter.matches(&l), "other address")
+	l = subscriptionLog(addr, common.HexToHash("0x03"), 1, 0)
+	assert.False(t, filter.matches(&l), "other event")
+}
+
+func TestSubscriptions(t *testing.T) {
+	t.Parallel()
+	addr := testutils.NewAddress()
+	sig := common.HexToHash("0x01")
+	filter := Filter{Name: "test", Addresses: []common.Address{addr}, EventSigs: []common.Hash{sig}}
+
+	t.Run("delivers confirmed logs of the filter", func(t *testing.T) {
+		orm := &subscriptionORM{filter: filter, cursors: map[subscriptionKey]SubscriptionCursor{}}
+		subs := newTestSubscriptions(t, orm)
+		sub, err := subs.subscribe(tests.Context(t), filter.Name, 2)
+		require.NoError(t, err)
+
+		_, err = subs.subscribe(tests.Context(t), filter.Name, 2)
+		require.ErrorIs(t, err, ErrSubscriptionExists)
+
+		subs.onLogsSaved([]Log{
+			subscriptionLog(addr, sig, 10, 1),
+			subscriptionLog(testutils.NewAddress(), sig, 10, 2),
+			subscriptionLog(addr, sig, 10, 3),
+		}, 10, 5)
+		requireNoEvent(t, sub)
+
+		subs.onLogsSaved(nil, 11, 6)
+		requireNoEvent(t, sub)
+
+		subs.onLogsSaved([]Log{subscriptionLog(addr, sig, 12, 0)}, 12, 7)
+		ev := requireEvent(t, sub)
+		assert.Equal(t, []logPosition{{10, 1}, {10, 3}}, positions(ev.Logs))
+		assert.Empty(t, ev.Retracted)
+
+		sub.Close()
+		_, ok := <-sub.Events()
+		assert.False(t, ok)
+		_, err = subs.subscribe(tests.Context(t), filter.Name, 2)
+		require.NoError(t, err)
+	})
+
+	t.Run("resumes from the acknowledged cursor", func(t *testing.T) {
+		orm := &subscriptionORM{
+			filter:  filter,
+			latest:  &LogPollerBlock{BlockNumber: 20, FinalizedBlockNumber: 15},
+			cursors: map[subscriptionKey]SubscriptionCursor{},
+			logs: []Log{
+				subscriptionLog(addr, sig, 3, 0),
+				subscriptionLog(addr, sig, 5, 1),
+				subscriptionLog(addr, sig, 5, 2),
+				subscriptionLog(addr, sig, 16, 0),
+			},
+		}
+		orm.cursors[subscriptionKey{filter.Name, evmtypes.Finalized}] = SubscriptionCursor{FilterName: filter.Name, Confs: evmtypes.Finalized, BlockNumber: 5, LogIndex: 1}
+		subs := newTestSubscriptions(t, orm)
+		sub, err := subs.subscribe(tests.Context(t), filter.Name, evmtypes.Finalized)
+		require.NoError(t, err)
+
+		ev := requireEvent(t, sub)
+		assert.Equal(t, []logPosition{{5, 2}}, positions(ev.Logs))
+
+		// duplicates of logs loaded from the db are dropped
+		subs.onLogsSaved([]Log{subscriptionLog(addr, sig, 16, 0), subscriptionLog(addr, sig, 21, 0)}, 21, 16)
+		ev = requireEvent(t, sub)
+		assert.Equal(t, []logPosition{{16, 0}}, positions(ev.Logs))
+
+		require.NoError(t, sub.Ack(tests.Context(t), ev))
+		cursor, err := orm.SelectSubscriptionCursor(tests.Context(t), filter.Name, evmtypes.Finalized)
+		require.NoError(t, err)
+		assert.Equal(t, int64(16), cursor.BlockNumber)
+		assert.Equal(t, int64(0), cursor.LogIndex)
+	})
+
+	t.Run("retracts delivered logs on reorg", func(t *testing.T) {
+		orm := &subscriptionORM{filter: filter, cursors: map[subscriptionKey]SubscriptionCursor{}}
+		subs := newTestSubscriptions(t, orm)
+		sub, err := subs.subscribe(tests.Context(t), filter.Name, 0)
+		require.NoError(t, err)
+
+		logs := []Log{subscriptionLog(addr, sig, 10, 0), subscriptionLog(addr, sig, 11, 0)}
+		subs.onLogsSaved(logs[:1], 10, 5)
+		delivered := requireEvent(t, sub)
+		subs.onLogsSaved(logs[1:], 11, 5)
+		requireEvent(t, sub)
+
+		subs.retract(11, logs[1:])
+		ev := requireEvent(t, sub)
+		assert.Empty(t, ev.Logs)
+		assert.Equal(t, int64(11), ev.RetractedFromBlock)
+		assert.Equal(t, []logPosition{{11, 0}}, positions(ev.Retracted))
+
+		// events delivered before the reorg are not persisted
+		require.NoError(t, sub.Ack(tests.Context(t), delivered))
+		_, err = orm.SelectSubscriptionCursor(tests.Context(t), filter.Name, 0)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+
+		// the new canonical block is delivered again
+		subs.onLogsSaved([]Log{subscriptionLog(addr, sig, 11, 3)}, 11, 5)
+		ev = requireEvent(t, sub)
+		assert.Equal(t, []logPosition{{11, 3}}, positions(ev.Logs))
+		require.NoError(t, sub.Ack(tests.Context(t), ev))
+		cursor, err := orm.SelectSubscriptionCursor(tests.Context(t), filter.Name, 0)
+		require.NoError(t, err)
+		assert.Equal(t, int64(11), cursor.BlockNumber)
+	})
+}
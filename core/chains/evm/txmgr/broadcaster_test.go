@@ -460,7 +460,7 @@ func TestEthBroadcaster_ProcessUnstartedEthTxs_Success(t *testing.T) {
 					return true
 				}
 				return false
-			}), "latest").Return(nil).Once()
+			}), "pending").Return(nil).Once()
 
 			ethTx := mustCreateUnstartedTxFromEvmTxRequest(t, txStore, txRequest, testutils.FixtureChainID)
 
@@ -482,7 +482,7 @@ func TestEthBroadcaster_ProcessUnstartedEthTxs_Success(t *testing.T) {
 			}), fromAddress).Return(multinode.Successful, nil).Once()
 			ethClient.On("CallContext", mock.Anything, mock.AnythingOfType("*hexutil.Bytes"), "eth_call", mock.MatchedBy(func(callarg map[string]interface{}) bool {
 				return fmt.Sprintf("%s", callarg["value"]) == "0x21e" // 542
-			}), "latest").Return(errors.New("this is not a revert, something unexpected went wrong")).Once()
+			}), mock.Anything).Return(errors.New("this is not a revert, something unexpected went wrong")).Twice()
 
 			ethTx := mustCreateUnstartedGeneratedTx(t, txStore, fromAddress, testutils.FixtureChainID,
 				txRequestWithChecker(checker),
@@ -507,7 +507,7 @@ func TestEthBroadcaster_ProcessUnstartedEthTxs_Success(t *testing.T) {
 			}
 			ethClient.On("CallContext", mock.Anything, mock.AnythingOfType("*hexutil.Bytes"), "eth_call", mock.MatchedBy(func(callarg map[string]interface{}) bool {
 				return fmt.Sprintf("%s", callarg["value"]) == "0x282" // 642
-			}), "latest").Return(&jerr).Once()
+			}), mock.Anything).Return(&jerr).Twice()
 
 			ethTx := mustCreateUnstartedGeneratedTx(t, txStore, fromAddress, testutils.FixtureChainID,
 				txRequestWithChecker(checker),
@@ -29,7 +29,8 @@ type latestAndFinalizedBlockHeadTracker interface {
 	LatestAndFinalizedBlock(ctx context.Context) (latest, finalized *types.Head, err error)
 }
 
-// NewTxm constructs the necessary dependencies for the EvmTxm (broadcaster, confirmer, etc) and returns a new EvmTxManager
+// NewTxm constructs the necessary dependencies for the EvmTxm (broadcaster, confirmer, etc) and returns a new EvmTxManager,
+// which also implements TxBundleManager
 func NewTxm(
 	ds sqlutil.DataSource,
 	chainConfig ChainConfig,
@@ -55,24 +56,33 @@ func NewTxm(
 	} else {
 		lggr.Info("EvmForwarderManager: Disabled")
 	}
-	checker := &CheckerFactory{Client: client}
+	simulationPolicy, err := NewSimulationPolicyFromConfig(txConfig.Simulation())
+	if err != nil {
+		return nil, err
+	}
+	checker := &CheckerFactory{Client: client, SimulationPolicy: simulationPolicy}
 	// create tx attempt builder
 	txAttemptBuilder := NewEvmTxAttemptBuilder(*client.ConfiguredChainID(), fCfg, keyStore, estimator)
 	txStore := NewTxStore(ds, lggr)
-	txmCfg := NewEvmTxmConfig(chainConfig)             // wrap Evm specific config
-	feeCfg := NewEvmTxmFeeConfig(fCfg)                 // wrap Evm specific config
-	txmClient := NewEvmTxmClient(client, clientErrors) // wrap Evm specific client
+	txmCfg := NewEvmTxmConfig(chainConfig)                // wrap Evm specific config
+	feeCfg := NewEvmTxmFeeConfig(fCfg)                    // wrap Evm specific config
+	evmTxmClient := NewEvmTxmClient(client, clientErrors) // wrap Evm specific client
+	var txmClient TxmClient = evmTxmClient
+	if txConfig.PrivateRelay().URL() != nil {
+		txmClient = NewPrivateRelayTxmClient(evmTxmClient, client, clientErrors, txConfig.PrivateRelay(), keyStore)
+	}
 	chainID := txmClient.ConfiguredChainID()
 	evmBroadcaster := NewEvmBroadcaster(txStore, txmClient, txmCfg, feeCfg, txConfig, listenerConfig, keyStore, txAttemptBuilder, lggr, checker, chainConfig.NonceAutoSync(), chainConfig.ChainType())
 	evmTracker := NewEvmTracker(txStore, keyStore, chainID, lggr)
 	stuckTxDetector := NewStuckTxDetector(lggr, client.ConfiguredChainID(), chainConfig.ChainType(), fCfg.PriceMax(), txConfig.AutoPurge(), estimator, txStore, client)
 	evmConfirmer := NewEvmConfirmer(txStore, txmClient, feeCfg, txConfig, dbConfig, keyStore, txAttemptBuilder, lggr, stuckTxDetector, headTracker)
-	evmFinalizer := NewEvmFinalizer(lggr, client.ConfiguredChainID(), chainConfig.RPCDefaultBatchSize(), txConfig.ForwardersEnabled(), txStore, txmClient, headTracker)
+	evmFinalizer := NewEvmFinalizer(lggr, client.ConfiguredChainID(), chainConfig.RPCDefaultBatchSize(), txConfig.ForwardersEnabled(), txStore, evmTxmClient, headTracker)
 	var evmResender *Resender
 	if txConfig.ResendAfterThreshold() > 0 {
 		evmResender = NewEvmResender(lggr, txStore, txmClient, evmTracker, keyStore, txmgr.DefaultResenderPollInterval, chainConfig, txConfig)
 	}
-	txm = NewEvmTxm(chainID, txmCfg, txConfig, keyStore, lggr, checker, fwdMgr, txAttemptBuilder, txStore, evmBroadcaster, evmConfirmer, evmResender, evmTracker, evmFinalizer, txmv2wrapper)
+	evmTxm := NewEvmTxm(chainID, txmCfg, txConfig, keyStore, lggr, checker, fwdMgr, txAttemptBuilder, txStore, evmBroadcaster, evmConfirmer, evmResender, evmTracker, evmFinalizer, txmv2wrapper)
+	txm = &bundleTxm{Txm: evmTxm, chainID: chainID, txStore: txStore, keyStore: keyStore, txConfig: txConfig}
 	return txm, nil
 }
 
@@ -0,0 +1,163 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	nullv4 "gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink-framework/chains/txmgr"
+)
+
+// ErrTxBundleMemberFailed is the error saved for the unstarted members of a TxBundle when one of its members failed
+var ErrTxBundleMemberFailed = errors.New("bundle member failed")
+
+// TxBundleState is the state of a TxBundle, derived from the states of its members
+type TxBundleState string
+
+const (
+	// TxBundleUnstarted bundles have no member sent yet
+	TxBundleUnstarted = TxBundleState("unstarted")
+	// TxBundlePending bundles have members sent but not yet confirmed
+	TxBundlePending = TxBundleState("pending")
+	// TxBundleConfirmed bundles have every member confirmed, but not every member finalized
+	TxBundleConfirmed = TxBundleState("confirmed")
+	// TxBundleFinalized bundles have every member finalized
+	TxBundleFinalized = TxBundleState("finalized")
+	// TxBundleFailed bundles have a member which fatally errored or reverted on-chain, so that its unstarted members are
+	// never sent
+	TxBundleFailed = TxBundleState("failed")
+)
+
+// TxBundle is an ordered sequence of transactions from a single address, sent with consecutive nonces. Bundles are
+// all-or-nothing: when a member fatally errors or reverts on-chain, the members after it which are not sent yet are
+// never sent, and the pipeline run awaiting the bundle is resumed with an error rather than a receipt.
+type TxBundle struct {
+	ID uuid.UUID
+	// Txs are the members of the bundle in nonce order
+	Txs []Tx
+}
+
+// State returns the state of the bundle. Reverted members are only detected if the receipts of the attempts of the
+// members are loaded.
+func (b TxBundle) State() TxBundleState {
+	unstarted, confirmed, finalized := 0, 0, 0
+	for _, tx := range b.Txs {
+		if txReverted(tx) {
+			return TxBundleFailed
+		}
+		switch tx.State {
+		case txmgr.TxFatalError:
+			return TxBundleFailed
+		case txmgr.TxUnstarted:
+			unstarted++
+		case txmgr.TxConfirmed:
+			confirmed++
+		case txmgr.TxFinalized:
+			finalized++
+		}
+	}
+	switch len(b.Txs) {
+	case unstarted:
+		return TxBundleUnstarted
+	case finalized:
+		return TxBundleFinalized
+	case confirmed + finalized:
+		return TxBundleConfirmed
+	default:
+		return TxBundlePending
+	}
+}
+
+func txReverted(tx Tx) bool {
+	for _, attempt := range tx.TxAttempts {
+		for _, receipt := range attempt.Receipts {
+			if receipt.GetStatus() == 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// FailedTxBundleCallback is a pipeline task run awaiting the callback of a failed TxBundle
+type FailedTxBundleCallback struct {
+	PipelineTaskRunID uuid.UUID `db:"pipeline_task_run_id"`
+	// Error is the error of the first member of the bundle which fatally errored or reverted on-chain
+	Error nullv4.String `db:"error"`
+}
+
+// TxBundleManager is implemented by transaction managers supporting TxBundles.
+type TxBundleManager interface {
+	// CreateTransactionBundle creates a TxBundle of txRequests, in order. Every request must be from the same address,
+	// and only the last one may signal a pipeline task run callback, which is resumed once every member is finalized.
+	CreateTransactionBundle(ctx context.Context, txRequests []TxRequest) (TxBundle, error)
+	// FindTxBundle returns the TxBundle with the given ID.
+	FindTxBundle(ctx context.Context, bundleID uuid.UUID) (*TxBundle, error)
+}
+
+type bundleTxStore interface {
+	CheckTxQueueCapacity(ctx context.Context, fromAddress common.Address, maxQueuedTransactions uint64, chainID *big.Int) (err error)
+	CreateTransactionBundle(ctx context.Context, txRequests []TxRequest, chainID *big.Int) (TxBundle, error)
+	FindTxBundle(ctx context.Context, bundleID uuid.UUID) (*TxBundle, error)
+}
+
+type bundleTxmConfig interface {
+	MaxQueued() uint64
+}
+
+// bundleTxm is the Txm extended with TxBundles
+type bundleTxm struct {
+	*Txm
+	chainID  *big.Int
+	txStore  bundleTxStore
+	keyStore KeyStore
+	txConfig bundleTxmConfig
+}
+
+var _ TxBundleManager = (*bundleTxm)(nil)
+
+func (b *bundleTxm) CreateTransactionBundle(ctx context.Context, txRequests []TxRequest) (bundle TxBundle, err error) {
+	if err = validateTxBundle(txRequests); err != nil {
+		return bundle, err
+	}
+	fromAddress := txRequests[0].FromAddress
+	if err = b.keyStore.CheckEnabled(ctx, fromAddress, b.chainID); err != nil {
+		return bundle, fmt.Errorf("cannot send transaction bundle from %s on chain ID %s: %w", fromAddress, b.chainID.String(), err)
+	}
+	if err = b.txStore.CheckTxQueueCapacity(ctx, fromAddress, b.txConfig.MaxQueued(), b.chainID); err != nil {
+		return bundle, fmt.Errorf("Txm#CreateTransactionBundle: %w", err)
+	}
+	bundle, err = b.txStore.CreateTransactionBundle(ctx, txRequests, b.chainID)
+	if err != nil {
+		return bundle, err
+	}
+	b.Trigger(fromAddress)
+	return bundle, nil
+}
+
+func (b *bundleTxm) FindTxBundle(ctx context.Context, bundleID uuid.UUID) (*TxBundle, error) {
+	return b.txStore.FindTxBundle(ctx, bundleID)
+}
+
+func validateTxBundle(txRequests []TxRequest) error {
+	if len(txRequests) == 0 {
+		return errors.New("transaction bundle must have at least one transaction")
+	}
+	for i, txRequest := range txRequests {
+		if txRequest.FromAddress != txRequests[0].FromAddress {
+			return fmt.Errorf("transaction %d of bundle is from %s, expected %s", i, txRequest.FromAddress, txRequests[0].FromAddress)
+		}
+		if txRequest.ForwarderAddress != (common.Address{}) {
+			return fmt.Errorf("transaction %d of bundle is forwarded, which is not supported", i)
+		}
+		if i < len(txRequests)-1 && (txRequest.PipelineTaskRunID != nil || txRequest.SignalCallback) {
+			return fmt.Errorf("transaction %d of bundle has a pipeline task run, which only the last transaction may have", i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package txmgr_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	txmgrcommon "github.com/smartcontractkit/chainlink-framework/chains/txmgr"
+	txmgrtypes "github.com/smartcontractkit/chainlink-framework/chains/txmgr/types"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/txmgr"
+	evmtypes "github.com/smartcontractkit/chainlink/v2/evm/types"
+)
+
+func TestTxBundle_State(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		name     string
+		states   []txmgrtypes.TxState
+		expected txmgr.TxBundleState
+	}{
+		{"unstarted", []txmgrtypes.TxState{txmgrcommon.TxUnstarted, txmgrcommon.TxUnstarted}, txmgr.TxBundleUnstarted},
+		{"in progress", []txmgrtypes.TxState{txmgrcommon.TxInProgress, txmgrcommon.TxUnstarted}, txmgr.TxBundlePending},
+		{"partially confirmed", []txmgrtypes.TxState{txmgrcommon.TxConfirmed, txmgrcommon.TxUnconfirmed}, txmgr.TxBundlePending},
+		{"confirmed", []txmgrtypes.TxState{txmgrcommon.TxFinalized, txmgrcommon.TxConfirmed}, txmgr.TxBundleConfirmed},
+		{"finalized", []txmgrtypes.TxState{txmgrcommon.TxFinalized, txmgrcommon.TxFinalized}, txmgr.TxBundleFinalized},
+		{"failed", []txmgrtypes.TxState{txmgrcommon.TxConfirmed, txmgrcommon.TxFatalError}, txmgr.TxBundleFailed},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			bundle := txmgr.TxBundle{Txs: make([]txmgr.Tx, len(tt.states))}
+			for i, state := range tt.states {
+				bundle.Txs[i].State = state
+			}
+			assert.Equal(t, tt.expected, bundle.State())
+		})
+	}
+}
+
+func TestTxBundle_State_Reverted(t *testing.T) {
+	t.Parallel()
+
+	bundle := txmgr.TxBundle{Txs: []txmgr.Tx{
+		{State: txmgrcommon.TxFinalized, TxAttempts: []txmgr.TxAttempt{{Receipts: []txmgr.ChainReceipt{&evmtypes.Receipt{Status: 1}}}}},
+		{State: txmgrcommon.TxConfirmed, TxAttempts: []txmgr.TxAttempt{{Receipts: []txmgr.ChainReceipt{&evmtypes.Receipt{Status: 0}}}}},
+	}}
+	assert.Equal(t, txmgr.TxBundleFailed, bundle.State())
+
+	bundle.Txs[1].TxAttempts[0].Receipts[0] = &evmtypes.Receipt{Status: 1}
+	assert.Equal(t, txmgr.TxBundleConfirmed, bundle.State())
+}
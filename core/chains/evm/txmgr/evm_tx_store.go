@@ -44,9 +44,11 @@ type EvmTxStore interface {
 	TxStoreWebApi
 
 	// methods used solely in EVM components
+	CreateTransactionBundle(ctx context.Context, txRequests []TxRequest, chainID *big.Int) (bundle TxBundle, err error)
 	DeleteReceiptByTxHash(ctx context.Context, txHash common.Hash) error
 	FindAttemptsRequiringReceiptFetch(ctx context.Context, chainID *big.Int) (hashes []TxAttempt, err error)
 	FindConfirmedTxesReceipts(ctx context.Context, finalizedBlockNum int64, chainID *big.Int) (receipts []*types.Receipt, err error)
+	FindFailedTxBundlesPendingCallback(ctx context.Context, chainID *big.Int) (callbacks []FailedTxBundleCallback, err error)
 	FindTxesPendingCallback(ctx context.Context, latest, finalized int64, chainID *big.Int) (receiptsPlus []ReceiptPlus, err error)
 	FindTxesByIDs(ctx context.Context, etxIDs []int64, chainID *big.Int) (etxs []*Tx, err error)
 	SaveFetchedReceipts(ctx context.Context, r []*types.Receipt) (err error)
@@ -57,6 +59,7 @@ type EvmTxStore interface {
 type TxStoreWebApi interface {
 	FindTxAttemptConfirmedByTxIDs(ctx context.Context, ids []int64) ([]TxAttempt, error)
 	FindTxByHash(ctx context.Context, hash common.Hash) (*Tx, error)
+	FindTxBundle(ctx context.Context, bundleID uuid.UUID) (*TxBundle, error)
 	Transactions(ctx context.Context, offset, limit int) ([]Tx, int, error)
 	TxAttempts(ctx context.Context, offset, limit int) ([]TxAttempt, int, error)
 	TransactionsWithAttempts(ctx context.Context, offset, limit int) ([]Tx, int, error)
@@ -202,6 +205,10 @@ type DbEthTx struct {
 	SignalCallback bool
 	// Marks tx callback as signaled
 	CallbackCompleted bool
+	// BundleID is the TxBundle the tx is a member of, if any
+	BundleID uuid.NullUUID
+	// BundleIndex is the position of the tx in its TxBundle
+	BundleIndex nullv4.Int
 }
 
 func (db *DbEthTx) FromTx(tx *Tx) {
@@ -950,8 +957,26 @@ func (o *evmTxStore) SaveFetchedReceipts(ctx context.Context, r []*types.Receipt
 
 	stmt = sqlx.Rebind(sqlx.DOLLAR, stmt)
 
-	_, err = o.q.ExecContext(ctx, stmt, valueArgs...)
-	return pkgerrors.Wrap(err, "SaveFetchedReceipts failed to save receipts")
+	var revertedHashes [][]byte
+	for _, r := range receipts {
+		if r.Status == 0 {
+			revertedHashes = append(revertedHashes, r.TxHash.Bytes())
+		}
+	}
+	return o.Transact(ctx, false, func(orm *evmTxStore) error {
+		if _, err = orm.q.ExecContext(ctx, stmt, valueArgs...); err != nil {
+			return pkgerrors.Wrap(err, "SaveFetchedReceipts failed to save receipts")
+		}
+		if len(revertedHashes) == 0 {
+			return nil
+		}
+		// A reverted member fails its TxBundle, so the members after it which are not sent yet must never be sent
+		var etxIDs []int64
+		if err = orm.q.SelectContext(ctx, &etxIDs, `SELECT DISTINCT eth_tx_id FROM evm.tx_attempts WHERE hash = ANY($1)`, pq.Array(revertedHashes)); err != nil {
+			return pkgerrors.Wrap(err, "SaveFetchedReceipts failed to find reverted evm.txes")
+		}
+		return orm.failUnstartedTxBundleMembers(ctx, etxIDs)
+	})
 }
 
 func (o *evmTxStore) GetInProgressTxAttempts(ctx context.Context, address common.Address, chainID *big.Int) (attempts []TxAttempt, err error) {
@@ -975,7 +1000,8 @@ WHERE evm.tx_attempts.state = 'in_progress' AND evm.txes.from_address = $1 AND e
 	return attempts, pkgerrors.Wrap(err, "getInProgressEthTxAttempts failed")
 }
 
-// Find confirmed txes requiring callback but have not yet been signaled
+// Find confirmed txes requiring callback but have not yet been signaled. Txes of a TxBundle are only returned once every
+// member of the bundle is finalized, and never if a member reverted on-chain.
 func (o *evmTxStore) FindTxesPendingCallback(ctx context.Context, latest, finalized int64, chainID *big.Int) (receiptsPlus []ReceiptPlus, err error) {
 	var rs []dbReceiptPlus
 
@@ -991,6 +1017,15 @@ func (o *evmTxStore) FindTxesPendingCallback(ctx context.Context, latest, finali
 	    (evm.txes.min_confirmations IS NOT NULL AND evm.receipts.block_number <= ($1 - evm.txes.min_confirmations)) 
 		OR (evm.txes.min_confirmations IS NULL AND evm.receipts.block_number <= $2)
 	) 
+	AND NOT EXISTS (
+		SELECT 1 FROM evm.txes bundled WHERE bundled.bundle_id = evm.txes.bundle_id AND bundled.state <> 'finalized'
+	)
+	AND NOT EXISTS (
+		SELECT 1 FROM evm.txes bundled
+		INNER JOIN evm.tx_attempts bundled_attempts ON bundled_attempts.eth_tx_id = bundled.id
+		INNER JOIN evm.receipts bundled_receipts ON bundled_receipts.tx_hash = bundled_attempts.hash
+		WHERE bundled.bundle_id = evm.txes.bundle_id AND bundled_receipts.receipt->>'status' = '0x0'
+	)
   	AND evm.txes.evm_chain_id = $3
 	`, latest, finalized, chainID.String())
 	if err != nil {
@@ -1000,6 +1035,35 @@ func (o *evmTxStore) FindTxesPendingCallback(ctx context.Context, latest, finali
 	return
 }
 
+// FindFailedTxBundlesPendingCallback returns the pipeline task runs awaiting the callback of a TxBundle with a member
+// which fatally errored or reverted on-chain, which are never resumed with a receipt.
+func (o *evmTxStore) FindFailedTxBundlesPendingCallback(ctx context.Context, chainID *big.Int) (callbacks []FailedTxBundleCallback, err error) {
+	var cancel context.CancelFunc
+	ctx, cancel = o.stopCh.Ctx(ctx)
+	defer cancel()
+	err = o.q.SelectContext(ctx, &callbacks, `
+	SELECT evm.txes.pipeline_task_run_id, failed.error FROM evm.txes
+	INNER JOIN LATERAL (
+		SELECT failures.error FROM (
+			SELECT bundled.bundle_index, bundled.error FROM evm.txes bundled
+			WHERE bundled.bundle_id = evm.txes.bundle_id AND bundled.state = 'fatal_error'
+			UNION ALL
+			SELECT bundled.bundle_index, 'transaction 0x' || encode(bundled_receipts.tx_hash, 'hex') || ' reverted on-chain' FROM evm.txes bundled
+			INNER JOIN evm.tx_attempts bundled_attempts ON bundled_attempts.eth_tx_id = bundled.id
+			INNER JOIN evm.receipts bundled_receipts ON bundled_receipts.tx_hash = bundled_attempts.hash
+			WHERE bundled.bundle_id = evm.txes.bundle_id AND bundled_receipts.receipt->>'status' = '0x0'
+		) failures
+		ORDER BY failures.bundle_index ASC LIMIT 1
+	) failed ON TRUE
+	WHERE evm.txes.bundle_id IS NOT NULL AND evm.txes.pipeline_task_run_id IS NOT NULL AND evm.txes.signal_callback = TRUE AND evm.txes.callback_completed = FALSE
+	AND evm.txes.evm_chain_id = $1
+	`, chainID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve failed transaction bundles pending pipeline resume callback: %w", err)
+	}
+	return
+}
+
 // Update tx to mark that its callback has been signaled
 func (o *evmTxStore) UpdateTxCallbackCompleted(ctx context.Context, pipelineTaskRunID uuid.UUID, chainID *big.Int) error {
 	var cancel context.CancelFunc
@@ -1398,7 +1462,15 @@ func (o *evmTxStore) FindNextUnstartedTransactionFromAddress(ctx context.Context
 	ctx, cancel = o.stopCh.Ctx(ctx)
 	defer cancel()
 	var dbEtx DbEthTx
-	err := o.q.GetContext(ctx, &dbEtx, `SELECT * FROM evm.txes WHERE from_address = $1 AND state = 'unstarted' AND evm_chain_id = $2 ORDER BY value ASC, created_at ASC, id ASC`, fromAddress, chainID.String())
+	// Members of a TxBundle are picked in order, and a bundle with a member already started is picked before any other
+	// tx, so that the members of a bundle get consecutive nonces
+	err := o.q.GetContext(ctx, &dbEtx, `SELECT * FROM evm.txes WHERE from_address = $1 AND state = 'unstarted' AND evm_chain_id = $2
+AND NOT EXISTS (
+	SELECT 1 FROM evm.txes bundled WHERE bundled.bundle_id = evm.txes.bundle_id AND bundled.state = 'unstarted' AND bundled.bundle_index < evm.txes.bundle_index
+)
+ORDER BY EXISTS (
+	SELECT 1 FROM evm.txes bundled WHERE bundled.bundle_id = evm.txes.bundle_id AND bundled.state <> 'unstarted'
+) DESC, value ASC, created_at ASC, id ASC`, fromAddress, chainID.String())
 	etx := new(Tx)
 	dbEtx.ToTx(etx)
 	if err != nil {
@@ -1427,7 +1499,10 @@ func (o *evmTxStore) UpdateTxFatalErrorAndDeleteAttempts(ctx context.Context, et
 		dbEtx.FromTx(etx)
 		err := pkgerrors.Wrap(orm.q.GetContext(ctx, &dbEtx, `UPDATE evm.txes SET state=$1, error=$2, broadcast_at=NULL, initial_broadcast_at=NULL, nonce=NULL WHERE id=$3 RETURNING *`, etx.State, etx.Error, etx.ID), "saveFatallyErroredTransaction failed to save eth_tx")
 		dbEtx.ToTx(etx)
-		return err
+		if err != nil {
+			return err
+		}
+		return orm.failUnstartedTxBundleMembers(ctx, []int64{etx.ID})
 	})
 }
 
@@ -1665,6 +1740,97 @@ RETURNING "txes".*
 	return etx, err
 }
 
+// CreateTransactionBundle inserts a TxBundle of txRequests in a single database transaction. If a bundle was already
+// created for the pipeline task run of the last request, it is returned instead. Bundle members have no subject, so
+// they are never pruned from the queue.
+func (o *evmTxStore) CreateTransactionBundle(ctx context.Context, txRequests []TxRequest, chainID *big.Int) (bundle TxBundle, err error) {
+	var cancel context.CancelFunc
+	ctx, cancel = o.stopCh.Ctx(ctx)
+	defer cancel()
+	if len(txRequests) == 0 {
+		return bundle, errors.New("CreateTransactionBundle: expected at least one transaction")
+	}
+	var dbEtxs []DbEthTx
+	err = o.Transact(ctx, false, func(orm *evmTxStore) error {
+		if last := txRequests[len(txRequests)-1]; last.PipelineTaskRunID != nil {
+			var bundleID uuid.NullUUID
+			err = orm.q.GetContext(ctx, &bundleID, `SELECT bundle_id FROM evm.txes WHERE pipeline_task_run_id = $1 AND evm_chain_id = $2`, last.PipelineTaskRunID, chainID.String())
+			// If no eth_tx matches (the common case) then continue
+			if !errors.Is(err, sql.ErrNoRows) {
+				if err != nil {
+					return pkgerrors.Wrap(err, "CreateTransactionBundle")
+				}
+				if !bundleID.Valid {
+					return pkgerrors.Errorf("CreateTransactionBundle: a transaction outside of a bundle already exists for pipeline task run %s", last.PipelineTaskRunID)
+				}
+				// if a previous bundle for this task run exists, immediately return it
+				return pkgerrors.Wrap(orm.q.SelectContext(ctx, &dbEtxs, `SELECT * FROM evm.txes WHERE bundle_id = $1 ORDER BY bundle_index ASC`, bundleID), "CreateTransactionBundle failed to load existing bundle")
+			}
+		}
+		bundleID := uuid.New()
+		dbEtxs = make([]DbEthTx, len(txRequests))
+		for i, txRequest := range txRequests {
+			err = orm.q.GetContext(ctx, &dbEtxs[i], `
+INSERT INTO evm.txes (from_address, to_address, encoded_payload, value, gas_limit, state, created_at, meta, evm_chain_id, min_confirmations, pipeline_task_run_id, transmit_checker, idempotency_key, signal_callback, bundle_id, bundle_index)
+VALUES (
+$1,$2,$3,$4,$5,'unstarted',NOW(),$6,$7,$8,$9,$10,$11,$12,$13,$14
+)
+RETURNING "txes".*
+`, txRequest.FromAddress, txRequest.ToAddress, txRequest.EncodedPayload, assets.Eth(txRequest.Value), txRequest.FeeLimit, txRequest.Meta, chainID.String(), txRequest.MinConfirmations, txRequest.PipelineTaskRunID, txRequest.Checker, txRequest.IdempotencyKey, txRequest.SignalCallback, bundleID, i)
+			if err != nil {
+				return pkgerrors.Wrapf(err, "CreateTransactionBundle failed to insert evm tx %d", i)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return bundle, err
+	}
+	return dbEthTxsToTxBundle(dbEtxs), nil
+}
+
+// FindTxBundle returns the TxBundle with the given ID, with the attempts and receipts of its members.
+func (o *evmTxStore) FindTxBundle(ctx context.Context, bundleID uuid.UUID) (*TxBundle, error) {
+	var cancel context.CancelFunc
+	ctx, cancel = o.stopCh.Ctx(ctx)
+	defer cancel()
+	var bundle TxBundle
+	err := o.Transact(ctx, true, func(orm *evmTxStore) error {
+		var dbEtxs []DbEthTx
+		if err := orm.q.SelectContext(ctx, &dbEtxs, `SELECT * FROM evm.txes WHERE bundle_id = $1 ORDER BY bundle_index ASC`, bundleID); err != nil {
+			return pkgerrors.Wrapf(err, "failed to find evm.txes of bundle %s", bundleID)
+		}
+		if len(dbEtxs) == 0 {
+			return pkgerrors.Wrapf(sql.ErrNoRows, "failed to find evm.txes of bundle %s", bundleID)
+		}
+		bundle = dbEthTxsToTxBundle(dbEtxs)
+		etxs := make([]*Tx, len(bundle.Txs))
+		for i := range bundle.Txs {
+			etxs[i] = &bundle.Txs[i]
+		}
+		if err := orm.LoadTxesAttempts(ctx, etxs); err != nil {
+			return err
+		}
+		return orm.loadEthTxesAttemptsReceipts(ctx, etxs)
+	})
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "FindTxBundle failed")
+	}
+	return &bundle, nil
+}
+
+func dbEthTxsToTxBundle(dbEtxs []DbEthTx) (bundle TxBundle) {
+	if len(dbEtxs) == 0 {
+		return
+	}
+	bundle.ID = dbEtxs[0].BundleID.UUID
+	bundle.Txs = make([]Tx, len(dbEtxs))
+	for i := range dbEtxs {
+		dbEtxs[i].ToTx(&bundle.Txs[i])
+	}
+	return
+}
+
 func (o *evmTxStore) PruneUnstartedTxQueue(ctx context.Context, queueSize uint32, subject uuid.UUID) (ids []int64, err error) {
 	var cancel context.CancelFunc
 	ctx, cancel = o.stopCh.Ctx(ctx)
@@ -2043,9 +2209,22 @@ func (o *evmTxStore) UpdateTxFatalError(ctx context.Context, etxIDs []int64, err
 	var cancel context.CancelFunc
 	ctx, cancel = o.stopCh.Ctx(ctx)
 	defer cancel()
-	sql := `UPDATE evm.txes SET state = 'fatal_error', error = $1 WHERE id = ANY($2)`
-	_, err := o.q.ExecContext(ctx, sql, errMsg, pq.Array(etxIDs))
-	return err
+	return o.Transact(ctx, false, func(orm *evmTxStore) error {
+		sql := `UPDATE evm.txes SET state = 'fatal_error', error = $1 WHERE id = ANY($2)`
+		if _, err := orm.q.ExecContext(ctx, sql, errMsg, pq.Array(etxIDs)); err != nil {
+			return err
+		}
+		return orm.failUnstartedTxBundleMembers(ctx, etxIDs)
+	})
+}
+
+// failUnstartedTxBundleMembers marks the unstarted members of the TxBundles of the given txes as fatally errored, as the
+// rest of a bundle must not be sent once one of its members failed.
+func (o *evmTxStore) failUnstartedTxBundleMembers(ctx context.Context, etxIDs []int64) error {
+	_, err := o.q.ExecContext(ctx, `UPDATE evm.txes SET state = 'fatal_error', error = $1 WHERE state = 'unstarted' AND bundle_id IN (
+	SELECT bundle_id FROM evm.txes WHERE id = ANY($2) AND bundle_id IS NOT NULL
+)`, ErrTxBundleMemberFailed.Error(), pq.Array(etxIDs))
+	return pkgerrors.Wrap(err, "failed to mark unstarted transaction bundle members as fatally errored")
 }
 
 func (o *evmTxStore) FindTxesByIDs(ctx context.Context, etxIDs []int64, chainID *big.Int) (etxs []*Tx, err error) {
@@ -655,6 +655,22 @@ func TestORM_FindTxesPendingCallback(t *testing.T) {
 	if assert.Len(t, receiptsPlus, 1) {
 		assert.Equal(t, tr1.ID, receiptsPlus[0].ID)
 	}
+
+	// Member of a bundle with a member not yet finalized. Should be ignored
+	bundleID := uuid.New()
+	pgtest.MustExec(t, db, `UPDATE evm.txes SET bundle_id = $1, bundle_index = 1 WHERE id = $2`, bundleID, etx1.ID)
+	pgtest.MustExec(t, db, `UPDATE evm.txes SET bundle_id = $1, bundle_index = 0 WHERE id = $2`, bundleID, etx5.ID)
+	receiptsPlus, err = txStore.FindTxesPendingCallback(tests.Context(t), head.Number, etxBlockNum, ethClient.ConfiguredChainID())
+	require.NoError(t, err)
+	assert.Empty(t, receiptsPlus)
+
+	// Every member of the bundle finalized
+	pgtest.MustExec(t, db, `UPDATE evm.txes SET state = 'finalized' WHERE bundle_id = $1`, bundleID)
+	receiptsPlus, err = txStore.FindTxesPendingCallback(tests.Context(t), head.Number, etxBlockNum, ethClient.ConfiguredChainID())
+	require.NoError(t, err)
+	if assert.Len(t, receiptsPlus, 1) {
+		assert.Equal(t, tr1.ID, receiptsPlus[0].ID)
+	}
 }
 
 func Test_FindTxWithIdempotencyKey(t *testing.T) {
@@ -1720,6 +1736,151 @@ func TestORM_CreateTransaction(t *testing.T) {
 	})
 }
 
+func TestORM_CreateTransactionBundle(t *testing.T) {
+	t.Parallel()
+
+	db := pgtest.NewSqlxDB(t)
+	txStore := cltest.NewTestTxStore(t, db)
+	kst := cltest.NewKeyStore(t, db)
+	ethClient := evmtest.NewEthClientMockWithDefaultChain(t)
+
+	pgtest.MustExec(t, db, `SET CONSTRAINTS fk_pipeline_runs_pruning_key DEFERRED`)
+	pgtest.MustExec(t, db, `SET CONSTRAINTS pipeline_runs_pipeline_spec_id_fkey DEFERRED`)
+
+	newBundle := func(t *testing.T, fromAddress common.Address, n int, taskRunID *uuid.UUID) []txmgr.TxRequest {
+		txRequests := make([]txmgr.TxRequest, n)
+		for i := range txRequests {
+			txRequests[i] = txmgr.TxRequest{FromAddress: fromAddress, ToAddress: testutils.NewAddress(), EncodedPayload: []byte{byte(i)}, FeeLimit: 1000}
+		}
+		if taskRunID != nil {
+			txRequests[n-1].PipelineTaskRunID = taskRunID
+			txRequests[n-1].SignalCallback = true
+		}
+		return txRequests
+	}
+
+	t.Run("inserts members in order", func(t *testing.T) {
+		_, fromAddress := cltest.MustInsertRandomKey(t, kst.Eth())
+		txRequests := newBundle(t, fromAddress, 3, nil)
+		bundle, err := txStore.CreateTransactionBundle(tests.Context(t), txRequests, ethClient.ConfiguredChainID())
+		require.NoError(t, err)
+
+		assert.NotEqual(t, uuid.Nil, bundle.ID)
+		require.Len(t, bundle.Txs, 3)
+		for i, tx := range bundle.Txs {
+			assert.Equal(t, txRequests[i].ToAddress, tx.ToAddress)
+			assert.Equal(t, txmgrcommon.TxUnstarted, tx.State)
+			assert.False(t, tx.Subject.Valid)
+		}
+		assert.Equal(t, txmgr.TxBundleUnstarted, bundle.State())
+
+		found, err := txStore.FindTxBundle(tests.Context(t), bundle.ID)
+		require.NoError(t, err)
+		assert.Equal(t, bundle.ID, found.ID)
+		require.Len(t, found.Txs, 3)
+		assert.Equal(t, bundle.Txs[2].ID, found.Txs[2].ID)
+
+		_, err = txStore.FindTxBundle(tests.Context(t), uuid.New())
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("returns the existing bundle of a pipeline task run", func(t *testing.T) {
+		_, fromAddress := cltest.MustInsertRandomKey(t, kst.Eth())
+		run := cltest.MustInsertPipelineRun(t, db)
+		tr := cltest.MustInsertUnfinishedPipelineTaskRun(t, db, run.ID)
+
+		bundle, err := txStore.CreateTransactionBundle(tests.Context(t), newBundle(t, fromAddress, 2, &tr.ID), ethClient.ConfiguredChainID())
+		require.NoError(t, err)
+		again, err := txStore.CreateTransactionBundle(tests.Context(t), newBundle(t, fromAddress, 2, &tr.ID), ethClient.ConfiguredChainID())
+		require.NoError(t, err)
+		assert.Equal(t, bundle.ID, again.ID)
+		assert.Equal(t, bundle.Txs[0].ID, again.Txs[0].ID)
+	})
+
+	t.Run("members are picked in order before other transactions once started", func(t *testing.T) {
+		_, fromAddress := cltest.MustInsertRandomKey(t, kst.Eth())
+		bundle, err := txStore.CreateTransactionBundle(tests.Context(t), newBundle(t, fromAddress, 2, nil), ethClient.ConfiguredChainID())
+		require.NoError(t, err)
+		mustCreateUnstartedGeneratedTx(t, txStore, fromAddress, ethClient.ConfiguredChainID())
+
+		etx, err := txStore.FindNextUnstartedTransactionFromAddress(tests.Context(t), fromAddress, ethClient.ConfiguredChainID())
+		require.NoError(t, err)
+		assert.Equal(t, bundle.Txs[0].ID, etx.ID)
+
+		pgtest.MustExec(t, db, `UPDATE evm.txes SET state = 'unconfirmed', nonce = 0, broadcast_at = NOW(), initial_broadcast_at = NOW() WHERE id = $1`, bundle.Txs[0].ID)
+		etx, err = txStore.FindNextUnstartedTransactionFromAddress(tests.Context(t), fromAddress, ethClient.ConfiguredChainID())
+		require.NoError(t, err)
+		assert.Equal(t, bundle.Txs[1].ID, etx.ID)
+	})
+
+	t.Run("failed member fails the unstarted members and resumes the pipeline run with an error", func(t *testing.T) {
+		_, fromAddress := cltest.MustInsertRandomKey(t, kst.Eth())
+		run := cltest.MustInsertPipelineRun(t, db)
+		tr := cltest.MustInsertUnfinishedPipelineTaskRun(t, db, run.ID)
+		bundle, err := txStore.CreateTransactionBundle(tests.Context(t), newBundle(t, fromAddress, 3, &tr.ID), ethClient.ConfiguredChainID())
+		require.NoError(t, err)
+
+		etx := bundle.Txs[0]
+		etx.Error = null.StringFrom("insufficient funds")
+		require.NoError(t, txStore.UpdateTxFatalErrorAndDeleteAttempts(tests.Context(t), &etx))
+
+		found, err := txStore.FindTxBundle(tests.Context(t), bundle.ID)
+		require.NoError(t, err)
+		assert.Equal(t, txmgr.TxBundleFailed, found.State())
+		for _, tx := range found.Txs[1:] {
+			assert.Equal(t, txmgrcommon.TxFatalError, tx.State)
+			assert.Equal(t, txmgr.ErrTxBundleMemberFailed.Error(), tx.Error.String)
+		}
+
+		callbacks, err := txStore.FindFailedTxBundlesPendingCallback(tests.Context(t), ethClient.ConfiguredChainID())
+		require.NoError(t, err)
+		require.Len(t, callbacks, 1)
+		assert.Equal(t, tr.ID, callbacks[0].PipelineTaskRunID)
+		assert.Equal(t, "insufficient funds", callbacks[0].Error.String)
+
+		require.NoError(t, txStore.UpdateTxCallbackCompleted(tests.Context(t), tr.ID, ethClient.ConfiguredChainID()))
+		callbacks, err = txStore.FindFailedTxBundlesPendingCallback(tests.Context(t), ethClient.ConfiguredChainID())
+		require.NoError(t, err)
+		assert.Empty(t, callbacks)
+	})
+
+	t.Run("reverted member fails the unstarted members and resumes the pipeline run with an error", func(t *testing.T) {
+		_, fromAddress := cltest.MustInsertRandomKey(t, kst.Eth())
+		run := cltest.MustInsertPipelineRun(t, db)
+		tr := cltest.MustInsertUnfinishedPipelineTaskRun(t, db, run.ID)
+		bundle, err := txStore.CreateTransactionBundle(tests.Context(t), newBundle(t, fromAddress, 3, &tr.ID), ethClient.ConfiguredChainID())
+		require.NoError(t, err)
+
+		pgtest.MustExec(t, db, `UPDATE evm.txes SET state = 'unconfirmed', nonce = 0, broadcast_at = NOW(), initial_broadcast_at = NOW() WHERE id = $1`, bundle.Txs[0].ID)
+		attempt := cltest.NewLegacyEthTxAttempt(t, bundle.Txs[0].ID)
+		attempt.State = txmgrtypes.TxAttemptBroadcast
+		require.NoError(t, txStore.InsertTxAttempt(tests.Context(t), &attempt))
+		receipt := types.Receipt{
+			TxHash:           attempt.Hash,
+			BlockHash:        utils.NewHash(),
+			BlockNumber:      big.NewInt(42),
+			TransactionIndex: uint(1),
+			Status:           0,
+		}
+		require.NoError(t, txStore.SaveFetchedReceipts(tests.Context(t), []*types.Receipt{&receipt}))
+		pgtest.MustExec(t, db, `UPDATE evm.txes SET state = 'finalized' WHERE id = $1`, bundle.Txs[0].ID)
+
+		found, err := txStore.FindTxBundle(tests.Context(t), bundle.ID)
+		require.NoError(t, err)
+		assert.Equal(t, txmgr.TxBundleFailed, found.State())
+		for _, tx := range found.Txs[1:] {
+			assert.Equal(t, txmgrcommon.TxFatalError, tx.State)
+			assert.Equal(t, txmgr.ErrTxBundleMemberFailed.Error(), tx.Error.String)
+		}
+
+		callbacks, err := txStore.FindFailedTxBundlesPendingCallback(tests.Context(t), ethClient.ConfiguredChainID())
+		require.NoError(t, err)
+		require.Len(t, callbacks, 1)
+		assert.Equal(t, tr.ID, callbacks[0].PipelineTaskRunID)
+		assert.Equal(t, fmt.Sprintf("transaction %s reverted on-chain", attempt.Hash.Hex()), callbacks[0].Error.String)
+	})
+}
+
 func TestORM_PruneUnstartedTxQueue(t *testing.T) {
 	t.Parallel()
 
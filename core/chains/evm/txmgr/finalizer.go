@@ -64,6 +64,7 @@ type finalizerTxStore interface {
 	DeleteReceiptByTxHash(ctx context.Context, txHash common.Hash) error
 	FindAttemptsRequiringReceiptFetch(ctx context.Context, chainID *big.Int) (hashes []TxAttempt, err error)
 	FindConfirmedTxesReceipts(ctx context.Context, finalizedBlockNum int64, chainID *big.Int) (receipts []*types.Receipt, err error)
+	FindFailedTxBundlesPendingCallback(ctx context.Context, chainID *big.Int) (callbacks []FailedTxBundleCallback, err error)
 	FindTxesPendingCallback(ctx context.Context, latest, finalized int64, chainID *big.Int) (receiptsPlus []ReceiptPlus, err error)
 	FindTxesByIDs(ctx context.Context, etxIDs []int64, chainID *big.Int) (etxs []*Tx, err error)
 	PreloadTxes(ctx context.Context, attempts []TxAttempt) error
@@ -560,6 +561,25 @@ func (f *evmFinalizer) ResumePendingTaskRuns(ctx context.Context, latest, finali
 		}
 	}
 
+	return f.resumeFailedTxBundleTaskRuns(ctx)
+}
+
+// resumeFailedTxBundleTaskRuns resumes the task runs awaiting a failed TxBundle with the error of its failed member
+func (f *evmFinalizer) resumeFailedTxBundleTaskRuns(ctx context.Context) error {
+	callbacks, err := f.txStore.FindFailedTxBundlesPendingCallback(ctx, f.chainID)
+	if err != nil {
+		return err
+	}
+	for _, data := range callbacks {
+		taskErr := fmt.Errorf("transaction bundle failed: %s", data.Error.String)
+		f.lggr.Debugw("Callback: resuming failed tx bundle", "taskErr", taskErr, "pipelineTaskRunID", data.PipelineTaskRunID)
+		if err := f.resumeCallback(ctx, data.PipelineTaskRunID, nil, taskErr); err != nil {
+			return fmt.Errorf("failed to resume suspended pipeline run: %w", err)
+		}
+		if err := f.txStore.UpdateTxCallbackCompleted(ctx, data.PipelineTaskRunID, f.chainID); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
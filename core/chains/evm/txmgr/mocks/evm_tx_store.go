@@ -18,6 +18,8 @@ import (
 
 	time "time"
 
+	txmgr "github.com/smartcontractkit/chainlink/v2/core/chains/evm/txmgr"
+
 	types "github.com/smartcontractkit/chainlink-framework/chains/txmgr/types"
 
 	uuid "github.com/google/uuid"
@@ -397,6 +399,66 @@ func (_c *EvmTxStore_CreateTransaction_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// CreateTransactionBundle provides a mock function with given fields: ctx, txRequests, chainID
+func (_m *EvmTxStore) CreateTransactionBundle(ctx context.Context, txRequests []types.TxRequest[common.Address, common.Hash], chainID *big.Int) (txmgr.TxBundle, error) {
+	ret := _m.Called(ctx, txRequests, chainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTransactionBundle")
+	}
+
+	var r0 txmgr.TxBundle
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []types.TxRequest[common.Address, common.Hash], *big.Int) (txmgr.TxBundle, error)); ok {
+		return rf(ctx, txRequests, chainID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []types.TxRequest[common.Address, common.Hash], *big.Int) txmgr.TxBundle); ok {
+		r0 = rf(ctx, txRequests, chainID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(txmgr.TxBundle)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []types.TxRequest[common.Address, common.Hash], *big.Int) error); ok {
+		r1 = rf(ctx, txRequests, chainID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EvmTxStore_CreateTransactionBundle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTransactionBundle'
+type EvmTxStore_CreateTransactionBundle_Call struct {
+	*mock.Call
+}
+
+// CreateTransactionBundle is a helper method to define mock.On call
+//   - ctx context.Context
+//   - txRequests []types.TxRequest[common.Address, common.Hash]
+//   - chainID *big.Int
+func (_e *EvmTxStore_Expecter) CreateTransactionBundle(ctx interface{}, txRequests interface{}, chainID interface{}) *EvmTxStore_CreateTransactionBundle_Call {
+	return &EvmTxStore_CreateTransactionBundle_Call{Call: _e.mock.On("CreateTransactionBundle", ctx, txRequests, chainID)}
+}
+
+func (_c *EvmTxStore_CreateTransactionBundle_Call) Run(run func(ctx context.Context, txRequests []types.TxRequest[common.Address, common.Hash], chainID *big.Int)) *EvmTxStore_CreateTransactionBundle_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]types.TxRequest[common.Address, common.Hash]), args[2].(*big.Int))
+	})
+	return _c
+}
+
+func (_c *EvmTxStore_CreateTransactionBundle_Call) Return(_a0 txmgr.TxBundle, _a1 error) *EvmTxStore_CreateTransactionBundle_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *EvmTxStore_CreateTransactionBundle_Call) RunAndReturn(run func(context.Context, []types.TxRequest[common.Address, common.Hash], *big.Int) (txmgr.TxBundle, error)) *EvmTxStore_CreateTransactionBundle_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteInProgressAttempt provides a mock function with given fields: ctx, attempt
 func (_m *EvmTxStore) DeleteInProgressAttempt(ctx context.Context, attempt types.TxAttempt[*big.Int, common.Address, common.Hash, common.Hash, evmtypes.Nonce, gas.EvmFee]) error {
 	ret := _m.Called(ctx, attempt)
@@ -724,6 +786,65 @@ func (_c *EvmTxStore_FindEarliestUnconfirmedTxAttemptBlock_Call) RunAndReturn(ru
 	return _c
 }
 
+// FindFailedTxBundlesPendingCallback provides a mock function with given fields: ctx, chainID
+func (_m *EvmTxStore) FindFailedTxBundlesPendingCallback(ctx context.Context, chainID *big.Int) ([]txmgr.FailedTxBundleCallback, error) {
+	ret := _m.Called(ctx, chainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindFailedTxBundlesPendingCallback")
+	}
+
+	var r0 []txmgr.FailedTxBundleCallback
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *big.Int) ([]txmgr.FailedTxBundleCallback, error)); ok {
+		return rf(ctx, chainID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *big.Int) []txmgr.FailedTxBundleCallback); ok {
+		r0 = rf(ctx, chainID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]txmgr.FailedTxBundleCallback)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *big.Int) error); ok {
+		r1 = rf(ctx, chainID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EvmTxStore_FindFailedTxBundlesPendingCallback_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindFailedTxBundlesPendingCallback'
+type EvmTxStore_FindFailedTxBundlesPendingCallback_Call struct {
+	*mock.Call
+}
+
+// FindFailedTxBundlesPendingCallback is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chainID *big.Int
+func (_e *EvmTxStore_Expecter) FindFailedTxBundlesPendingCallback(ctx interface{}, chainID interface{}) *EvmTxStore_FindFailedTxBundlesPendingCallback_Call {
+	return &EvmTxStore_FindFailedTxBundlesPendingCallback_Call{Call: _e.mock.On("FindFailedTxBundlesPendingCallback", ctx, chainID)}
+}
+
+func (_c *EvmTxStore_FindFailedTxBundlesPendingCallback_Call) Run(run func(ctx context.Context, chainID *big.Int)) *EvmTxStore_FindFailedTxBundlesPendingCallback_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*big.Int))
+	})
+	return _c
+}
+
+func (_c *EvmTxStore_FindFailedTxBundlesPendingCallback_Call) Return(_a0 []txmgr.FailedTxBundleCallback, _a1 error) *EvmTxStore_FindFailedTxBundlesPendingCallback_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *EvmTxStore_FindFailedTxBundlesPendingCallback_Call) RunAndReturn(run func(context.Context, *big.Int) ([]txmgr.FailedTxBundleCallback, error)) *EvmTxStore_FindFailedTxBundlesPendingCallback_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FindLatestSequence provides a mock function with given fields: ctx, fromAddress, chainID
 func (_m *EvmTxStore) FindLatestSequence(ctx context.Context, fromAddress common.Address, chainID *big.Int) (evmtypes.Nonce, error) {
 	ret := _m.Called(ctx, fromAddress, chainID)
@@ -1151,6 +1272,65 @@ func (_c *EvmTxStore_FindTxAttemptsRequiringResend_Call) RunAndReturn(run func(c
 	return _c
 }
 
+// FindTxBundle provides a mock function with given fields: ctx, bundleID
+func (_m *EvmTxStore) FindTxBundle(ctx context.Context, bundleID uuid.UUID) (*txmgr.TxBundle, error) {
+	ret := _m.Called(ctx, bundleID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindTxBundle")
+	}
+
+	var r0 *txmgr.TxBundle
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*txmgr.TxBundle, error)); ok {
+		return rf(ctx, bundleID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *txmgr.TxBundle); ok {
+		r0 = rf(ctx, bundleID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*txmgr.TxBundle)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, bundleID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EvmTxStore_FindTxBundle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindTxBundle'
+type EvmTxStore_FindTxBundle_Call struct {
+	*mock.Call
+}
+
+// FindTxBundle is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bundleID uuid.UUID
+func (_e *EvmTxStore_Expecter) FindTxBundle(ctx interface{}, bundleID interface{}) *EvmTxStore_FindTxBundle_Call {
+	return &EvmTxStore_FindTxBundle_Call{Call: _e.mock.On("FindTxBundle", ctx, bundleID)}
+}
+
+func (_c *EvmTxStore_FindTxBundle_Call) Run(run func(ctx context.Context, bundleID uuid.UUID)) *EvmTxStore_FindTxBundle_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *EvmTxStore_FindTxBundle_Call) Return(_a0 *txmgr.TxBundle, _a1 error) *EvmTxStore_FindTxBundle_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *EvmTxStore_FindTxBundle_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*txmgr.TxBundle, error)) *EvmTxStore_FindTxBundle_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FindTxByHash provides a mock function with given fields: ctx, hash
 func (_m *EvmTxStore) FindTxByHash(ctx context.Context, hash common.Hash) (*types.Tx[*big.Int, common.Address, common.Hash, common.Hash, evmtypes.Nonce, gas.EvmFee], error) {
 	ret := _m.Called(ctx, hash)
@@ -0,0 +1,218 @@
+package txmgr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-framework/multinode"
+
+	"github.com/smartcontractkit/chainlink/v2/evm/client"
+	"github.com/smartcontractkit/chainlink/v2/evm/config"
+	"github.com/smartcontractkit/chainlink/v2/evm/config/toml"
+)
+
+// privateRelayTimeout bounds each request to the private relay
+const privateRelayTimeout = 10 * time.Second
+
+type privateRelayKeystore interface {
+	SignMessage(ctx context.Context, address common.Address, message []byte) ([]byte, error)
+}
+
+var _ TxmClient = (*privateRelayTxmClient)(nil)
+
+// privateRelayTxmClient is a TxmClient which sends the transactions of keys with the private relay enabled to the
+// relay rather than to the public mempool. A transaction which is not included within FallbackBlocks of its first
+// broadcast is sent publicly from then on. The block of the first broadcast is derived from the persisted attempts,
+// so that the fallback survives restarts.
+type privateRelayTxmClient struct {
+	TxmClient
+	client       client.Client
+	clientErrors config.ClientErrors
+	cfg          config.PrivateRelay
+	keystore     privateRelayKeystore
+	httpClient   *http.Client
+}
+
+// NewPrivateRelayTxmClient wraps txmClient to send transactions through the private relay configured by cfg.
+func NewPrivateRelayTxmClient(txmClient TxmClient, c client.Client, clientErrors config.ClientErrors, cfg config.PrivateRelay, keystore privateRelayKeystore) *privateRelayTxmClient {
+	return &privateRelayTxmClient{
+		TxmClient:    txmClient,
+		client:       c,
+		clientErrors: clientErrors,
+		cfg:          cfg,
+		keystore:     keystore,
+		httpClient:   &http.Client{Timeout: privateRelayTimeout},
+	}
+}
+
+func (c *privateRelayTxmClient) SendTransactionReturnCode(ctx context.Context, etx Tx, attempt TxAttempt, lggr logger.SugaredLogger) (multinode.SendTxReturnCode, error) {
+	if !c.cfg.Enabled(etx.FromAddress) {
+		return c.TxmClient.SendTransactionReturnCode(ctx, etx, attempt, lggr)
+	}
+	signedTx, err := GetGethSignedTx(attempt.SignedRawTx)
+	if err != nil {
+		lggr.Criticalw("Fatal error signing transaction", "err", err, "etx", etx)
+		return multinode.Fatal, err
+	}
+	latest, err := c.client.LatestBlockHeight(ctx)
+	if err != nil {
+		return multinode.Retryable, fmt.Errorf("failed to get latest block for private relay: %w", err)
+	}
+
+	fallbackBlocks := int64(c.cfg.FallbackBlocks())
+	maxBlock := latest.Int64() + fallbackBlocks
+	if sentAt := firstBroadcastBlock(etx, attempt); sentAt != nil {
+		if latest.Int64() >= *sentAt+fallbackBlocks {
+			lggr.Warnw("Transaction was not included through the private relay, falling back to public broadcast",
+				"txID", etx.ID, "txHash", attempt.Hash, "firstBroadcastBlock", *sentAt, "fallbackBlocks", fallbackBlocks)
+			return c.TxmClient.SendTransactionReturnCode(ctx, etx, attempt, lggr)
+		}
+		maxBlock = *sentAt + fallbackBlocks
+	}
+
+	err = c.sendPrivateTransaction(ctx, etx.FromAddress, signedTx, latest, big.NewInt(maxBlock))
+	return client.ClassifySendError(err, c.clientErrors, lggr, signedTx, etx.FromAddress, c.client.IsL2()), err
+}
+
+// BatchSendTransactions sends the attempts of keys with the private relay enabled one by one with
+// SendTransactionReturnCode, and batches the rest.
+func (c *privateRelayTxmClient) BatchSendTransactions(
+	ctx context.Context,
+	attempts []TxAttempt,
+	batchSize int,
+	lggr logger.SugaredLogger,
+) (
+	codes []multinode.SendTxReturnCode,
+	txErrs []error,
+	broadcastTime time.Time,
+	successfulTxIDs []int64,
+	err error,
+) {
+	var public []TxAttempt
+	var publicIndexes []int
+	codes = make([]multinode.SendTxReturnCode, len(attempts))
+	txErrs = make([]error, len(attempts))
+	broadcastTime = time.Now()
+	for i, attempt := range attempts {
+		if !c.cfg.Enabled(attempt.Tx.FromAddress) {
+			public = append(public, attempt)
+			publicIndexes = append(publicIndexes, i)
+			continue
+		}
+		codes[i], txErrs[i] = c.SendTransactionReturnCode(ctx, attempt.Tx, attempt, lggr)
+		if codes[i] == multinode.Successful {
+			successfulTxIDs = append(successfulTxIDs, attempt.TxID)
+		}
+	}
+	if len(public) == 0 {
+		return
+	}
+
+	publicCodes, publicErrs, publicBroadcastTime, publicSuccessfulTxIDs, err := c.TxmClient.BatchSendTransactions(ctx, public, batchSize, lggr)
+	for i, index := range publicIndexes {
+		if i < len(publicCodes) {
+			codes[index] = publicCodes[i]
+		}
+		if i < len(publicErrs) {
+			txErrs[index] = publicErrs[i]
+		}
+	}
+	if publicBroadcastTime.Before(broadcastTime) {
+		broadcastTime = publicBroadcastTime
+	}
+	successfulTxIDs = append(successfulTxIDs, publicSuccessfulTxIDs...)
+	return
+}
+
+// firstBroadcastBlock returns the earliest block before which an attempt of the transaction was broadcast, or nil if
+// none is known to have been.
+func firstBroadcastBlock(etx Tx, attempt TxAttempt) (first *int64) {
+	first = attempt.BroadcastBeforeBlockNum
+	for _, a := range etx.TxAttempts {
+		if a.BroadcastBeforeBlockNum != nil && (first == nil || *a.BroadcastBeforeBlockNum < *first) {
+			first = a.BroadcastBeforeBlockNum
+		}
+	}
+	return
+}
+
+func (c *privateRelayTxmClient) sendPrivateTransaction(ctx context.Context, fromAddress common.Address, signedTx *gethtypes.Transaction, latest, maxBlock *big.Int) error {
+	txBytes, err := signedTx.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("failed to marshal tx into canonical encoding: %w", err)
+	}
+	var params any
+	switch c.cfg.Method() {
+	case toml.PrivateRelayMethodSendBundle:
+		params = map[string]any{
+			"txs":         []string{hexutil.Encode(txBytes)},
+			"blockNumber": hexutil.EncodeBig(new(big.Int).Add(latest, big.NewInt(1))),
+		}
+	default:
+		params = map[string]any{
+			"tx":             hexutil.Encode(txBytes),
+			"maxBlockNumber": hexutil.EncodeBig(maxBlock),
+		}
+	}
+	body, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  c.cfg.Method(),
+		"params":  []any{params},
+	})
+	if err != nil {
+		return err
+	}
+	return c.signAndPost(ctx, fromAddress, body)
+}
+
+func (c *privateRelayTxmClient) signAndPost(ctx context.Context, address common.Address, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.URL().String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	hashedBody := crypto.Keccak256Hash(body).Hex()
+	signedMessage, err := c.keystore.SignMessage(ctx, address, []byte(hashedBody))
+	if err != nil {
+		return fmt.Errorf("failed to sign private relay request: %w", err)
+	}
+	req.Header.Add("X-Flashbots-Signature", address.String()+":"+hexutil.Encode(signedMessage))
+	req.Header.Add("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("private relay request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read private relay response: %w", err)
+	}
+
+	var response struct {
+		Error *client.JsonError `json:"error"`
+	}
+	if err = json.Unmarshal(respBody, &response); err != nil {
+		return fmt.Errorf("private relay responded with status %d: %s", resp.StatusCode, string(respBody))
+	}
+	if response.Error != nil {
+		return response.Error
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("private relay responded with status %d: %s", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
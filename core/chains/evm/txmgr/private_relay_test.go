@@ -0,0 +1,224 @@
+package txmgr_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	commonconfig "github.com/smartcontractkit/chainlink-common/pkg/config"
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+	"github.com/smartcontractkit/chainlink-framework/multinode"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/txmgr"
+	"github.com/smartcontractkit/chainlink/v2/evm/client/clienttest"
+	"github.com/smartcontractkit/chainlink/v2/evm/config/configtest"
+	"github.com/smartcontractkit/chainlink/v2/evm/config/toml"
+	evmtypes "github.com/smartcontractkit/chainlink/v2/evm/types"
+)
+
+type stubRelay struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests []stubRelayRequest
+	response string
+}
+
+type stubRelayRequest struct {
+	Method    string                   `json:"method"`
+	Params    []map[string]interface{} `json:"params"`
+	Signature string                   `json:"-"`
+}
+
+func newStubRelay(t *testing.T, response string) *stubRelay {
+	relay := &stubRelay{response: response}
+	relay.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req stubRelayRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		req.Signature = r.Header.Get("X-Flashbots-Signature")
+		relay.mu.Lock()
+		relay.requests = append(relay.requests, req)
+		relay.mu.Unlock()
+		_, err := w.Write([]byte(relay.response))
+		assert.NoError(t, err)
+	}))
+	t.Cleanup(relay.Close)
+	return relay
+}
+
+func (r *stubRelay) Requests() []stubRelayRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.requests
+}
+
+type stubRelayKeystore struct{}
+
+func (stubRelayKeystore) SignMessage(ctx context.Context, address common.Address, message []byte) ([]byte, error) {
+	return []byte{1, 2, 3}, nil
+}
+
+func newPrivateRelayAttempt(t *testing.T, fromAddress common.Address, broadcastBeforeBlockNum *int64) txmgr.TxAttempt {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	tx, err := types.SignTx(types.NewTx(&types.LegacyTx{Nonce: 7, GasPrice: big.NewInt(1), Gas: 21000, Value: big.NewInt(42)}), types.HomesteadSigner{}, key)
+	require.NoError(t, err)
+	signedRawTx, err := rlp.EncodeToBytes(tx)
+	require.NoError(t, err)
+	return txmgr.TxAttempt{
+		TxID:                    1,
+		Tx:                      txmgr.Tx{ID: 1, FromAddress: fromAddress},
+		Hash:                    tx.Hash(),
+		SignedRawTx:             signedRawTx,
+		BroadcastBeforeBlockNum: broadcastBeforeBlockNum,
+	}
+}
+
+func TestPrivateRelayTxmClient(t *testing.T) {
+	privateAddress := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	publicAddress := common.HexToAddress("0x2000000000000000000000000000000000000002")
+	lggr := logger.Sugared(logger.Test(t))
+
+	newClient := func(t *testing.T, relayURL string, method toml.PrivateRelayMethod) (txmgr.TxmClient, *clienttest.Client) {
+		cfg := configtest.NewChainScopedConfig(t, func(c *toml.EVMConfig) {
+			c.Transactions.PrivateRelay = toml.PrivateRelayConfig{
+				URL:            commonconfig.MustParseURL(relayURL),
+				Method:         &method,
+				FallbackBlocks: ptr[uint32](10),
+			}
+			enabled := true
+			key := evmtypes.EIP55AddressFromAddress(privateAddress)
+			c.KeySpecific = toml.KeySpecificConfig{{Key: &key, PrivateRelay: toml.KeySpecificPrivateRelay{Enabled: &enabled}}}
+		})
+		ethClient := clienttest.NewClientWithDefaultChainID(t)
+		ethClient.On("IsL2").Return(false).Maybe()
+		txmClient := txmgr.NewPrivateRelayTxmClient(txmgr.NewEvmTxmClient(ethClient, nil), ethClient, nil, cfg.EVM().Transactions().PrivateRelay(), stubRelayKeystore{})
+		return txmClient, ethClient
+	}
+
+	t.Run("sends transactions of private keys with eth_sendPrivateTransaction", func(t *testing.T) {
+		relay := newStubRelay(t, `{"jsonrpc":"2.0","id":1,"result":"0x01"}`)
+		txmClient, ethClient := newClient(t, relay.URL, toml.PrivateRelayMethodSendPrivateTransaction)
+		ethClient.On("LatestBlockHeight", mock.Anything).Return(big.NewInt(100), nil).Once()
+
+		attempt := newPrivateRelayAttempt(t, privateAddress, nil)
+		code, err := txmClient.SendTransactionReturnCode(tests.Context(t), attempt.Tx, attempt, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, multinode.Successful, code)
+
+		requests := relay.Requests()
+		require.Len(t, requests, 1)
+		assert.Equal(t, "eth_sendPrivateTransaction", requests[0].Method)
+		assert.Equal(t, hexutil.Encode(attempt.SignedRawTx), requests[0].Params[0]["tx"])
+		assert.Equal(t, "0x6e", requests[0].Params[0]["maxBlockNumber"])
+		assert.True(t, strings.HasPrefix(requests[0].Signature, privateAddress.String()+":"))
+	})
+
+	t.Run("sends transactions of private keys with eth_sendBundle targeting the next block", func(t *testing.T) {
+		relay := newStubRelay(t, `{"jsonrpc":"2.0","id":1,"result":{"bundleHash":"0x01"}}`)
+		txmClient, ethClient := newClient(t, relay.URL, toml.PrivateRelayMethodSendBundle)
+		ethClient.On("LatestBlockHeight", mock.Anything).Return(big.NewInt(100), nil).Once()
+
+		attempt := newPrivateRelayAttempt(t, privateAddress, nil)
+		code, err := txmClient.SendTransactionReturnCode(tests.Context(t), attempt.Tx, attempt, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, multinode.Successful, code)
+
+		requests := relay.Requests()
+		require.Len(t, requests, 1)
+		assert.Equal(t, "eth_sendBundle", requests[0].Method)
+		assert.Equal(t, []interface{}{hexutil.Encode(attempt.SignedRawTx)}, requests[0].Params[0]["txs"])
+		assert.Equal(t, "0x65", requests[0].Params[0]["blockNumber"])
+	})
+
+	t.Run("classifies relay errors", func(t *testing.T) {
+		relay := newStubRelay(t, `{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"nonce too low"}}`)
+		txmClient, ethClient := newClient(t, relay.URL, toml.PrivateRelayMethodSendPrivateTransaction)
+		ethClient.On("LatestBlockHeight", mock.Anything).Return(big.NewInt(100), nil).Once()
+
+		attempt := newPrivateRelayAttempt(t, privateAddress, nil)
+		code, err := txmClient.SendTransactionReturnCode(tests.Context(t), attempt.Tx, attempt, lggr)
+		require.ErrorContains(t, err, "nonce too low")
+		assert.Equal(t, multinode.TransactionAlreadyKnown, code)
+	})
+
+	t.Run("keeps the max block of the first broadcast", func(t *testing.T) {
+		relay := newStubRelay(t, `{"jsonrpc":"2.0","id":1,"result":"0x01"}`)
+		txmClient, ethClient := newClient(t, relay.URL, toml.PrivateRelayMethodSendPrivateTransaction)
+		ethClient.On("LatestBlockHeight", mock.Anything).Return(big.NewInt(100), nil).Once()
+
+		attempt := newPrivateRelayAttempt(t, privateAddress, ptr[int64](95))
+		code, err := txmClient.SendTransactionReturnCode(tests.Context(t), attempt.Tx, attempt, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, multinode.Successful, code)
+
+		requests := relay.Requests()
+		require.Len(t, requests, 1)
+		assert.Equal(t, "0x69", requests[0].Params[0]["maxBlockNumber"])
+	})
+
+	t.Run("falls back to public broadcast after FallbackBlocks", func(t *testing.T) {
+		relay := newStubRelay(t, `{"jsonrpc":"2.0","id":1,"result":"0x01"}`)
+		txmClient, ethClient := newClient(t, relay.URL, toml.PrivateRelayMethodSendPrivateTransaction)
+		ethClient.On("LatestBlockHeight", mock.Anything).Return(big.NewInt(100), nil).Once()
+
+		attempt := newPrivateRelayAttempt(t, privateAddress, nil)
+		attempt.Tx.TxAttempts = []txmgr.TxAttempt{newPrivateRelayAttempt(t, privateAddress, ptr[int64](90))}
+		ethClient.On("SendTransactionReturnCode", mock.Anything, mock.MatchedBy(func(tx *types.Transaction) bool {
+			return tx.Hash() == attempt.Hash
+		}), privateAddress).Return(multinode.Successful, nil).Once()
+
+		code, err := txmClient.SendTransactionReturnCode(tests.Context(t), attempt.Tx, attempt, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, multinode.Successful, code)
+		assert.Empty(t, relay.Requests())
+	})
+
+	t.Run("sends transactions of other keys publicly", func(t *testing.T) {
+		relay := newStubRelay(t, `{"jsonrpc":"2.0","id":1,"result":"0x01"}`)
+		txmClient, ethClient := newClient(t, relay.URL, toml.PrivateRelayMethodSendPrivateTransaction)
+
+		attempt := newPrivateRelayAttempt(t, publicAddress, nil)
+		ethClient.On("SendTransactionReturnCode", mock.Anything, mock.Anything, publicAddress).Return(multinode.Successful, nil).Once()
+
+		code, err := txmClient.SendTransactionReturnCode(tests.Context(t), attempt.Tx, attempt, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, multinode.Successful, code)
+		assert.Empty(t, relay.Requests())
+	})
+
+	t.Run("batches only the transactions of other keys", func(t *testing.T) {
+		relay := newStubRelay(t, `{"jsonrpc":"2.0","id":1,"result":"0x01"}`)
+		txmClient, ethClient := newClient(t, relay.URL, toml.PrivateRelayMethodSendPrivateTransaction)
+		ethClient.On("LatestBlockHeight", mock.Anything).Return(big.NewInt(100), nil).Once()
+
+		private := newPrivateRelayAttempt(t, privateAddress, nil)
+		public := newPrivateRelayAttempt(t, publicAddress, nil)
+		public.TxID = 2
+		ethClient.On("BatchCallContextAll", mock.Anything, mock.MatchedBy(func(reqs []rpc.BatchElem) bool {
+			return len(reqs) == 1 && reqs[0].Args[0] == hexutil.Encode(public.SignedRawTx)
+		})).Return(nil).Once()
+
+		codes, txErrs, _, successfulTxIDs, err := txmClient.BatchSendTransactions(tests.Context(t), []txmgr.TxAttempt{private, public}, 0, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, []multinode.SendTxReturnCode{multinode.Successful, multinode.Successful}, codes)
+		assert.Equal(t, []error{nil, nil}, txErrs)
+		assert.ElementsMatch(t, []int64{1, 2}, successfulTxIDs)
+		assert.Len(t, relay.Requests(), 1)
+	})
+}
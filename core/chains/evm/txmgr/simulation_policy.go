@@ -0,0 +1,150 @@
+package txmgr
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	evmabi "github.com/smartcontractkit/chainlink/v2/evm/abi"
+	"github.com/smartcontractkit/chainlink/v2/evm/config"
+)
+
+// RevertClass classifies the revert of a simulated transaction.
+type RevertClass string
+
+const (
+	// RevertPermanent reverts are not expected to succeed on retry, so the transaction is fatally errored.
+	RevertPermanent = RevertClass("permanent")
+	// RevertTransient reverts depend on transient chain state, so the simulation is retried with backoff until its
+	// retry timeout, after which the transaction is fatally errored.
+	RevertTransient = RevertClass("transient")
+)
+
+// Revert is the classified revert of a simulated transaction.
+type Revert struct {
+	// Selector is the hex encoded selector of the error the transaction reverted with, if any.
+	Selector string
+	// Reason is the decoded error the transaction reverted with, if it could be decoded.
+	Reason string
+	Class  RevertClass
+}
+
+// SimulationPolicy decodes the reverts of simulated transactions and classifies them as permanent or transient.
+// Reverts with Error(string) or Panic(uint256), and with custom errors not registered as transient, are permanent.
+type SimulationPolicy struct {
+	mu               sync.RWMutex
+	errors           map[string]abi.Error
+	transientErrors  map[string]bool
+	transientReasons map[string]bool
+}
+
+// NewSimulationPolicy returns a SimulationPolicy without registered errors.
+func NewSimulationPolicy() *SimulationPolicy {
+	return &SimulationPolicy{
+		errors:           map[string]abi.Error{},
+		transientErrors:  map[string]bool{},
+		transientReasons: map[string]bool{},
+	}
+}
+
+// NewSimulationPolicyFromConfig returns a SimulationPolicy with the custom errors and transient revert reasons of cfg.
+func NewSimulationPolicyFromConfig(cfg config.Simulation) (*SimulationPolicy, error) {
+	p := NewSimulationPolicy()
+	permanentErrors, err := evmabi.ParseErrors(cfg.Errors()...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid simulation errors: %w", err)
+	}
+	if err = p.RegisterErrors(permanentErrors); err != nil {
+		return nil, err
+	}
+	transientErrors, err := evmabi.ParseErrors(cfg.TransientErrors()...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transient simulation errors: %w", err)
+	}
+	transient := make([]string, 0, len(transientErrors.Errors))
+	for name := range transientErrors.Errors {
+		transient = append(transient, name)
+	}
+	if err = p.RegisterErrors(transientErrors, transient...); err != nil {
+		return nil, err
+	}
+	p.RegisterTransientReasons(cfg.TransientReasons()...)
+	return p, nil
+}
+
+// RegisterErrors registers the custom errors of contractABI, classifying the errors named in transient as transient.
+func (p *SimulationPolicy) RegisterErrors(contractABI abi.ABI, transient ...string) error {
+	for _, name := range transient {
+		if _, ok := contractABI.Errors[name]; !ok {
+			return fmt.Errorf("transient error %s is not an error of the ABI", name)
+		}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, abiErr := range contractABI.Errors {
+		selector := hexutil.Encode(abiErr.ID[:4])
+		p.errors[selector] = abiErr
+		delete(p.transientErrors, selector)
+	}
+	for _, name := range transient {
+		id := contractABI.Errors[name].ID
+		p.transientErrors[hexutil.Encode(id[:4])] = true
+	}
+	return nil
+}
+
+// RegisterTransientReasons classifies reverts with Error(string) for any of the given reasons as transient.
+func (p *SimulationPolicy) RegisterTransientReasons(reasons ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, reason := range reasons {
+		p.transientReasons[reason] = true
+	}
+}
+
+// selectorLabel returns the selector label of the revert in metrics, which is only the selector of decoded errors so
+// that the number of label values is bounded by the registered errors.
+func (r Revert) selectorLabel() string {
+	if r.Reason == "" {
+		return "unknown"
+	}
+	return r.Selector
+}
+
+// Classify decodes and classifies a revert with the given revert data.
+func (p *SimulationPolicy) Classify(data []byte) Revert {
+	revert := Revert{Class: RevertPermanent}
+	if len(data) < 4 {
+		return revert
+	}
+	revert.Selector = hexutil.Encode(data[:4])
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		revert.Reason = reason
+		if p.transientReasons[reason] {
+			revert.Class = RevertTransient
+		}
+		return revert
+	}
+	abiErr, ok := p.errors[revert.Selector]
+	if !ok {
+		return revert
+	}
+	revert.Reason = abiErr.Name + "()"
+	if args, err := abiErr.Inputs.Unpack(data[4:]); err == nil {
+		formatted := make([]string, len(args))
+		for i, arg := range args {
+			formatted[i] = fmt.Sprintf("%v", arg)
+		}
+		revert.Reason = fmt.Sprintf("%s(%s)", abiErr.Name, strings.Join(formatted, ", "))
+	}
+	if p.transientErrors[revert.Selector] {
+		revert.Class = RevertTransient
+	}
+	return revert
+}
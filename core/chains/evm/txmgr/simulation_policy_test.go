@@ -0,0 +1,76 @@
+package txmgr_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/txmgr"
+	"github.com/smartcontractkit/chainlink/v2/evm/config/configtest"
+	"github.com/smartcontractkit/chainlink/v2/evm/config/toml"
+)
+
+const simulationTestABI = `[
+	{"type":"error","name":"InsufficientBalance","inputs":[{"name":"available","type":"uint256"},{"name":"required","type":"uint256"}]},
+	{"type":"error","name":"StaleReport","inputs":[]}
+]`
+
+func TestSimulationPolicy_Classify(t *testing.T) {
+	t.Parallel()
+
+	contractABI, err := abi.JSON(strings.NewReader(simulationTestABI))
+	require.NoError(t, err)
+	policy := txmgr.NewSimulationPolicy()
+	require.NoError(t, policy.RegisterErrors(contractABI, "StaleReport"))
+	policy.RegisterTransientReasons("price feed stale")
+
+	insufficientBalance, err := contractABI.Errors["InsufficientBalance"].Inputs.Pack(hexutil.MustDecodeBig("0x1"), hexutil.MustDecodeBig("0x2"))
+	require.NoError(t, err)
+	insufficientBalanceID := contractABI.Errors["InsufficientBalance"].ID
+	staleReportID := contractABI.Errors["StaleReport"].ID
+
+	for _, tt := range []struct {
+		name     string
+		data     []byte
+		expected txmgr.Revert
+	}{
+		{"no data", nil, txmgr.Revert{Class: txmgr.RevertPermanent}},
+		{"reason", revertReason(t, "not allowed"), txmgr.Revert{Selector: "0x08c379a0", Reason: "not allowed", Class: txmgr.RevertPermanent}},
+		{"transient reason", revertReason(t, "price feed stale"), txmgr.Revert{Selector: "0x08c379a0", Reason: "price feed stale", Class: txmgr.RevertTransient}},
+		{"custom error", append(insufficientBalanceID[:4:4], insufficientBalance...), txmgr.Revert{Selector: hexutil.Encode(insufficientBalanceID[:4]), Reason: "InsufficientBalance(1, 2)", Class: txmgr.RevertPermanent}},
+		{"transient custom error", staleReportID[:4], txmgr.Revert{Selector: hexutil.Encode(staleReportID[:4]), Reason: "StaleReport()", Class: txmgr.RevertTransient}},
+		{"unknown custom error", []byte{1, 2, 3, 4}, txmgr.Revert{Selector: "0x01020304", Class: txmgr.RevertPermanent}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, policy.Classify(tt.data))
+		})
+	}
+
+	t.Run("unknown transient error", func(t *testing.T) {
+		require.EqualError(t, policy.RegisterErrors(contractABI, "Unknown"), "transient error Unknown is not an error of the ABI")
+	})
+}
+
+func TestNewSimulationPolicyFromConfig(t *testing.T) {
+	t.Parallel()
+
+	cfg := configtest.NewChainScopedConfig(t, func(c *toml.EVMConfig) {
+		c.Transactions.Simulation.Errors = &[]string{"InsufficientBalance(uint256,uint256)"}
+		c.Transactions.Simulation.TransientErrors = &[]string{"StaleReport()"}
+		c.Transactions.Simulation.TransientReasons = &[]string{"price feed stale"}
+	})
+	policy, err := txmgr.NewSimulationPolicyFromConfig(cfg.EVM().Transactions().Simulation())
+	require.NoError(t, err)
+
+	contractABI, err := abi.JSON(strings.NewReader(simulationTestABI))
+	require.NoError(t, err)
+	insufficientBalanceID := contractABI.Errors["InsufficientBalance"].ID
+	staleReportID := contractABI.Errors["StaleReport"].ID
+	assert.Equal(t, txmgr.Revert{Selector: hexutil.Encode(insufficientBalanceID[:4]), Reason: "InsufficientBalance()", Class: txmgr.RevertPermanent}, policy.Classify(insufficientBalanceID[:4]))
+	assert.Equal(t, txmgr.Revert{Selector: hexutil.Encode(staleReportID[:4]), Reason: "StaleReport()", Class: txmgr.RevertTransient}, policy.Classify(staleReportID[:4]))
+	assert.Equal(t, txmgr.RevertTransient, policy.Classify(revertReason(t, "price feed stale")).Class)
+}
@@ -103,6 +103,14 @@ func (g *TestGasEstimatorConfig) FeeHistory() evmconfig.FeeHistory {
 	return &TestFeeHistoryConfig{}
 }
 
+func (g *TestGasEstimatorConfig) Composite() evmconfig.Composite {
+	return &TestCompositeConfig{}
+}
+
+func (g *TestGasEstimatorConfig) FeeAPI() evmconfig.FeeAPI {
+	return &TestFeeAPIConfig{}
+}
+
 func (g *TestGasEstimatorConfig) EIP1559DynamicFees() bool   { return false }
 func (g *TestGasEstimatorConfig) LimitDefault() uint64       { return 42 }
 func (g *TestGasEstimatorConfig) BumpPercent() uint16        { return 42 }
@@ -155,6 +163,14 @@ type TestFeeHistoryConfig struct {
 	evmconfig.FeeHistory
 }
 
+type TestCompositeConfig struct {
+	evmconfig.Composite
+}
+
+type TestFeeAPIConfig struct {
+	evmconfig.FeeAPI
+}
+
 func (b *TestFeeHistoryConfig) CacheTimeout() time.Duration { return 0 * time.Second }
 
 type transactionsConfig struct {
@@ -2,14 +2,20 @@ package txmgr
 
 import (
 	"context"
+	"errors"
 	"math/big"
+	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	gethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/jpillora/backoff"
 	pkgerrors "github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	bigmath "github.com/smartcontractkit/chainlink-common/pkg/utils/big_math"
@@ -30,6 +36,20 @@ type (
 	TransmitCheckerSpec = txmgrtypes.TransmitCheckerSpec[common.Address]
 )
 
+const (
+	// simulationRetryMin and simulationRetryMax bound the backoff between simulations of transactions reverting
+	// transiently, which are retried within the TransmitCheckTimeout.
+	simulationRetryMin = 100 * time.Millisecond
+	simulationRetryMax = time.Second
+)
+
+var (
+	promSimulationReverts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "tx_manager_simulation_reverts",
+		Help: "The number of pre-broadcast transaction simulations that reverted, labeled by the selector of the decoded error (or unknown) and revert class",
+	}, []string{"chainID", "selector", "class"})
+)
+
 var (
 	// NoChecker is a TransmitChecker that always determines a transaction should be submitted.
 	NoChecker TransmitChecker = noChecker{}
@@ -43,13 +63,15 @@ var (
 // CheckerFactory is a real implementation of TransmitCheckerFactory.
 type CheckerFactory struct {
 	Client evmclient.Client
+	// SimulationPolicy is the policy of the SimulateCheckers built. Reverts are not decoded if nil.
+	SimulationPolicy *SimulationPolicy
 }
 
 // BuildChecker satisfies the TransmitCheckerFactory interface.
 func (c *CheckerFactory) BuildChecker(spec TransmitCheckerSpec) (TransmitChecker, error) {
 	switch spec.CheckerType {
 	case TransmitCheckerTypeSimulate:
-		return &SimulateChecker{c.Client}, nil
+		return &SimulateChecker{Client: c.Client, Policy: c.SimulationPolicy}, nil
 	case TransmitCheckerTypeVRFV1:
 		if spec.VRFCoordinatorAddress == nil {
 			return nil, pkgerrors.Errorf("malformed checker, expected non-nil VRFCoordinatorAddress, got: %v", spec)
@@ -116,9 +138,13 @@ func (noChecker) Check(
 	return nil
 }
 
-// SimulateChecker simulates transactions, producing an error if they revert on chain.
+// SimulateChecker simulates transactions against the pending and latest blocks, producing an error if they revert
+// permanently on chain. Transient reverts are retried with backoff until the deadline of the check, so that the
+// broadcaster is not blocked beyond the TransmitCheckTimeout, after which the transaction is sent anyway.
 type SimulateChecker struct {
 	Client evmclient.Client
+	// Policy decodes and classifies reverts. Reverts are not decoded if nil.
+	Policy *SimulationPolicy
 }
 
 // Check satisfies the TransmitChecker interface.
@@ -128,6 +154,92 @@ func (s *SimulateChecker) Check(
 	tx Tx,
 	a TxAttempt,
 ) error {
+	policy := s.Policy
+	if policy == nil {
+		policy = NewSimulationPolicy()
+	}
+	chainID := ""
+	if tx.ChainID != nil {
+		chainID = tx.ChainID.String()
+	}
+
+	retry := backoff.Backoff{Min: simulationRetryMin, Max: simulationRetryMax, Factor: 2}
+	for {
+		revert, jErr, err := s.simulate(ctx, policy, tx, a)
+		if err != nil {
+			if errors.Is(ctx.Err(), context.Canceled) {
+				return ctx.Err()
+			}
+			l.Warnw("Transaction simulation failed, will attempt to send anyway",
+				"ethTxAttemptID", a.ID, "txHash", a.Hash, "err", err)
+			return nil
+		}
+		if revert == nil {
+			l.Debugw("Transaction simulation succeeded",
+				"ethTxAttemptID", a.ID, "txHash", a.Hash)
+			return nil
+		}
+
+		promSimulationReverts.WithLabelValues(chainID, revert.selectorLabel(), string(revert.Class)).Inc()
+		if revert.Class == RevertPermanent {
+			l.Criticalw("Transaction reverted during simulation",
+				"ethTxAttemptID", a.ID, "txHash", a.Hash, "rpcErr", jErr.String(), "selector", revert.Selector, "reason", revert.Reason)
+			if revert.Reason != "" {
+				return pkgerrors.Errorf("transaction reverted during simulation: %s: %s", revert.Reason, jErr.String())
+			}
+			return pkgerrors.Errorf("transaction reverted during simulation: %s", jErr.String())
+		}
+
+		wait := retry.Duration()
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			l.Warnw("Transaction still reverting transiently when the transmit check timed out, will attempt to send anyway",
+				"ethTxAttemptID", a.ID, "txHash", a.Hash, "rpcErr", jErr.String(), "selector", revert.Selector, "reason", revert.Reason)
+			return nil
+		}
+		l.Debugw("Transaction reverted transiently during simulation, retrying",
+			"ethTxAttemptID", a.ID, "txHash", a.Hash, "rpcErr", jErr.String(), "selector", revert.Selector, "reason", revert.Reason, "retryIn", wait)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// simulate calls the transaction against the pending and latest blocks, returning its classified revert if it
+// reverts. A transaction reverting against the pending block only reverts transiently, and the pending block is
+// ignored if the RPC does not support calls against it.
+func (s *SimulateChecker) simulate(ctx context.Context, policy *SimulationPolicy, tx Tx, a TxAttempt) (*Revert, *evmclient.JsonError, error) {
+	pendingErr := s.call(ctx, tx, a, "pending")
+	if pendingErr == nil {
+		return nil, nil, nil
+	}
+	pendingRevert := evmclient.ExtractRPCErrorOrNil(pendingErr)
+	if pendingRevert != nil && !isSimulationRevert(pendingRevert) {
+		pendingRevert = nil
+	}
+
+	latestErr := s.call(ctx, tx, a, "latest")
+	if latestErr == nil {
+		if pendingRevert == nil {
+			return nil, nil, nil
+		}
+		revert := policy.Classify(revertData(pendingRevert))
+		revert.Class = RevertTransient
+		return &revert, pendingRevert, nil
+	}
+	if latestRevert := evmclient.ExtractRPCErrorOrNil(latestErr); latestRevert != nil {
+		revert := policy.Classify(revertData(latestRevert))
+		return &revert, latestRevert, nil
+	}
+	if pendingRevert != nil {
+		revert := policy.Classify(revertData(pendingRevert))
+		return &revert, pendingRevert, nil
+	}
+	return nil, nil, latestErr
+}
+
+func (s *SimulateChecker) call(ctx context.Context, tx Tx, a TxAttempt, block string) error {
 	// See: https://github.com/ethereum/go-ethereum/blob/acdf9238fb03d79c9b1c20c2fa476a7e6f4ac2ac/ethclient/gethclient/gethclient.go#L193
 	callArg := map[string]interface{}{
 		"from": tx.FromAddress,
@@ -143,21 +255,28 @@ func (s *SimulateChecker) Check(
 		"data":                 hexutil.Bytes(tx.EncodedPayload),
 	}
 	var b hexutil.Bytes
-	// always run simulation on "latest" block
-	err := s.Client.CallContext(ctx, &b, "eth_call", callArg, evmclient.ToBlockNumArg(nil))
-	if err != nil {
-		if jErr := evmclient.ExtractRPCErrorOrNil(err); jErr != nil {
-			l.Criticalw("Transaction reverted during simulation",
-				"ethTxAttemptID", a.ID, "txHash", a.Hash, "err", err, "rpcErr", jErr.String(), "returnValue", b.String())
-			return pkgerrors.Errorf("transaction reverted during simulation: %s", jErr.String())
+	return s.Client.CallContext(ctx, &b, "eth_call", callArg, block)
+}
+
+// isSimulationRevert returns whether a JSON-RPC error of a call is a revert, rather than e.g. an unsupported block tag.
+func isSimulationRevert(jErr *evmclient.JsonError) bool {
+	return jErr.Code == 3 || strings.Contains(strings.ToLower(jErr.Message), "revert") || len(revertData(jErr)) >= 4
+}
+
+// revertData returns the revert data of a JSON-RPC error, if any.
+func revertData(jErr *evmclient.JsonError) []byte {
+	switch data := jErr.Data.(type) {
+	case []byte:
+		return data
+	case string:
+		b, err := hexutil.Decode(data)
+		if err != nil {
+			return nil
 		}
-		l.Warnw("Transaction simulation failed, will attempt to send anyway",
-			"ethTxAttemptID", a.ID, "txHash", a.Hash, "err", err, "returnValue", b.String())
-	} else {
-		l.Debugw("Transaction simulation succeeded",
-			"ethTxAttemptID", a.ID, "txHash", a.Hash, "returnValue", b.String())
+		return b
+	default:
+		return nil
 	}
-	return nil
 }
 
 // VRFV1Checker is an implementation of TransmitChecker that checks whether a VRF V1 fulfillment
@@ -8,9 +8,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rpc"
 	pkgerrors "github.com/pkg/errors"
 	"github.com/stretchr/testify/mock"
@@ -132,12 +135,16 @@ func TestTransmitCheckers(t *testing.T) {
 			State:     txmgrtypes.TxAttemptInProgress,
 		}
 
-		t.Run("success", func(t *testing.T) {
+		mockCall := func(block string, err error) {
 			client.On("CallContext", mock.Anything,
 				mock.AnythingOfType("*hexutil.Bytes"), "eth_call",
 				mock.MatchedBy(func(callarg map[string]interface{}) bool {
 					return fmt.Sprintf("%s", callarg["value"]) == "0x282" // 642
-				}), "latest").Return(nil).Once()
+				}), block).Return(err).Once()
+		}
+
+		t.Run("success", func(t *testing.T) {
+			mockCall("pending", nil)
 
 			require.NoError(t, checker.Check(ctx, log, tx, attempt))
 		})
@@ -148,28 +155,89 @@ func TestTransmitCheckers(t *testing.T) {
 				Message: "oh no, it reverted",
 				Data:    []byte{42, 166, 34},
 			}
-			client.On("CallContext", mock.Anything,
-				mock.AnythingOfType("*hexutil.Bytes"), "eth_call",
-				mock.MatchedBy(func(callarg map[string]interface{}) bool {
-					return fmt.Sprintf("%s", callarg["value"]) == "0x282" // 642
-				}), "latest").Return(&jerr).Once()
+			mockCall("pending", &jerr)
+			mockCall("latest", &jerr)
 
 			err := checker.Check(ctx, log, tx, attempt)
 			expErrMsg := "transaction reverted during simulation: json-rpc error { Code = 42, Message = 'oh no, it reverted', Data = 'KqYi' }"
 			require.EqualError(t, err, expErrMsg)
 		})
 
+		t.Run("revert with reason", func(t *testing.T) {
+			jerr := evmclient.JsonError{
+				Code:    3,
+				Message: "execution reverted: insufficient balance",
+				Data:    hexutil.Encode(revertReason(t, "insufficient balance")),
+			}
+			mockCall("pending", &jerr)
+			mockCall("latest", &jerr)
+
+			err := checker.Check(ctx, log, tx, attempt)
+			require.ErrorContains(t, err, "transaction reverted during simulation: insufficient balance: json-rpc error { Code = 3")
+		})
+
+		t.Run("transient revert against pending block is retried", func(t *testing.T) {
+			jerr := evmclient.JsonError{Code: 3, Message: "execution reverted"}
+			mockCall("pending", &jerr)
+			mockCall("latest", nil)
+			mockCall("pending", nil)
+
+			require.NoError(t, checker.Check(ctx, log, tx, attempt))
+		})
+
+		t.Run("pending block not supported", func(t *testing.T) {
+			mockCall("pending", &evmclient.JsonError{Code: -32602, Message: "invalid block tag"})
+			mockCall("latest", nil)
+
+			require.NoError(t, checker.Check(ctx, log, tx, attempt))
+		})
+
 		t.Run("non revert error", func(t *testing.T) {
-			client.On("CallContext", mock.Anything,
-				mock.AnythingOfType("*hexutil.Bytes"), "eth_call",
-				mock.MatchedBy(func(callarg map[string]interface{}) bool {
-					return fmt.Sprintf("%s", callarg["value"]) == "0x282" // 642
-				}), "latest").Return(pkgerrors.New("error")).Once()
+			mockCall("pending", pkgerrors.New("error"))
+			mockCall("latest", pkgerrors.New("error"))
 
 			// Non-revert errors are logged but should not prevent transmission, and do not need
 			// to be passed to the caller
 			require.NoError(t, checker.Check(ctx, log, tx, attempt))
 		})
+
+		newTransientChecker := func(t *testing.T) (txmgr.SimulateChecker, *clienttest.Client, *evmclient.JsonError) {
+			policy := txmgr.NewSimulationPolicy()
+			policy.RegisterTransientReasons("stale price")
+			client := clienttest.NewClientWithDefaultChainID(t)
+			jerr := &evmclient.JsonError{Code: 3, Message: "execution reverted: stale price", Data: hexutil.Encode(revertReason(t, "stale price"))}
+			return txmgr.SimulateChecker{Client: client, Policy: policy}, client, jerr
+		}
+
+		t.Run("transient revert retried within the check timeout", func(t *testing.T) {
+			checker, client, jerr := newTransientChecker(t)
+			client.On("CallContext", mock.Anything, mock.AnythingOfType("*hexutil.Bytes"), "eth_call", mock.Anything, mock.Anything).Return(jerr).Times(2)
+			client.On("CallContext", mock.Anything, mock.AnythingOfType("*hexutil.Bytes"), "eth_call", mock.Anything, "pending").Return(nil).Once()
+
+			checkCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+			defer cancel()
+			require.NoError(t, checker.Check(checkCtx, log, tx, attempt))
+		})
+
+		t.Run("transient revert sent anyway when the check times out", func(t *testing.T) {
+			checker, client, jerr := newTransientChecker(t)
+			client.On("CallContext", mock.Anything, mock.AnythingOfType("*hexutil.Bytes"), "eth_call", mock.Anything, mock.Anything).Return(jerr)
+
+			checkCtx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+			defer cancel()
+			start := time.Now()
+			require.NoError(t, checker.Check(checkCtx, log, tx, attempt))
+			require.Less(t, time.Since(start), 500*time.Millisecond)
+		})
+
+		t.Run("transient revert stops retrying when canceled", func(t *testing.T) {
+			checker, client, jerr := newTransientChecker(t)
+			client.On("CallContext", mock.Anything, mock.AnythingOfType("*hexutil.Bytes"), "eth_call", mock.Anything, mock.Anything).Return(jerr)
+
+			checkCtx, cancel := context.WithCancel(ctx)
+			time.AfterFunc(100*time.Millisecond, cancel)
+			require.ErrorIs(t, checker.Check(checkCtx, log, tx, attempt), context.Canceled)
+		})
 	})
 
 	t.Run("VRF V1", func(t *testing.T) {
@@ -372,3 +440,11 @@ func TestTransmitCheckers(t *testing.T) {
 		})
 	})
 }
+
+func revertReason(t *testing.T, reason string) []byte {
+	stringType, err := abi.NewType("string", "", nil)
+	require.NoError(t, err)
+	data, err := abi.Arguments{{Type: stringType}}.Pack(reason)
+	require.NoError(t, err)
+	return append(crypto.Keccak256([]byte("Error(string)"))[:4], data...)
+}
@@ -0,0 +1,86 @@
+package legacyevm
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/txmgr"
+	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
+	"github.com/smartcontractkit/chainlink/v2/evm/assets"
+	"github.com/smartcontractkit/chainlink/v2/evm/config"
+	"github.com/smartcontractkit/chainlink/v2/evm/monitor"
+)
+
+func newBalanceMonitorOpts(chainID *big.Int, cfg config.EVM, ds sqlutil.DataSource, txm txmgr.TxManager, auditLogger audit.AuditLogger, lggr logger.Logger) monitor.Opts {
+	opts := monitor.Opts{Config: cfg.BalanceMonitor()}
+	if linkAddr := cfg.LinkContractAddress(); linkAddr != "" {
+		if common.IsHexAddress(linkAddr) {
+			opts.LinkAddress = common.HexToAddress(linkAddr)
+		} else {
+			lggr.Warnw("BalanceMonitor: ignoring invalid LinkContractAddress", "linkContractAddress", linkAddr)
+		}
+	}
+	if cfg.BalanceMonitor().TopUp().Enabled() && cfg.Transactions().Enabled() {
+		if auditLogger == nil {
+			auditLogger = audit.NoopLogger
+		}
+		opts.TopUpSender = &topUpSender{
+			ds:          ds,
+			txm:         txm,
+			chainID:     chainID,
+			gasLimit:    cfg.GasEstimator().LimitTransfer(),
+			auditLogger: auditLogger,
+		}
+	}
+	return opts
+}
+
+// topUpSender sends balance monitor top-ups through the TxManager and records them in the audit log.
+type topUpSender struct {
+	ds          sqlutil.DataSource
+	txm         txmgr.TxManager
+	chainID     *big.Int
+	gasLimit    uint64
+	auditLogger audit.AuditLogger
+}
+
+func (s *topUpSender) SendTopUp(ctx context.Context, from, to common.Address, amount *assets.Wei) error {
+	etx, err := s.txm.SendNativeToken(ctx, s.chainID, from, to, *amount.ToInt(), s.gasLimit)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue top-up: %w", err)
+	}
+	s.auditLogger.Audit(audit.BalanceMonitorTopUp, map[string]interface{}{
+		"ethTX":  etx,
+		"from":   from,
+		"to":     to,
+		"amount": amount,
+	})
+	return nil
+}
+
+// TopUps returns the native token transfers from the funding key since the given time, which did not fail.
+func (s *topUpSender) TopUps(ctx context.Context, from common.Address, since time.Time) ([]monitor.TopUp, error) {
+	var rows []struct {
+		ToAddress common.Address `db:"to_address"`
+		CreatedAt time.Time      `db:"created_at"`
+		Pending   bool           `db:"pending"`
+	}
+	err := s.ds.SelectContext(ctx, &rows, `SELECT to_address, created_at, state IN ('unstarted', 'in_progress', 'unconfirmed') AS pending FROM evm.txes
+WHERE evm_chain_id = $1 AND from_address = $2 AND created_at > $3 AND value > 0 AND length(encoded_payload) = 0 AND state <> 'fatal_error'
+ORDER BY created_at`, s.chainID.String(), from, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get top-ups: %w", err)
+	}
+	topUps := make([]monitor.TopUp, len(rows))
+	for i, r := range rows {
+		topUps[i] = monitor.TopUp{To: r.ToAddress, CreatedAt: r.CreatedAt, Pending: r.Pending}
+	}
+	return topUps, nil
+}
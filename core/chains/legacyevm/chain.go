@@ -23,6 +23,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/txmgr"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
 	"github.com/smartcontractkit/chainlink/v2/evm/client"
 	"github.com/smartcontractkit/chainlink/v2/evm/config"
 	"github.com/smartcontractkit/chainlink/v2/evm/config/toml"
@@ -152,6 +153,9 @@ type ChainOpts struct {
 
 	DS sqlutil.DataSource
 
+	// AuditLogger records automated actions like balance monitor top-ups. Optional.
+	AuditLogger audit.AuditLogger
+
 	// TODO BCF-2513 remove test code from the API
 	// Gen-functions are useful for dependency injection by tests
 	GenEthClient      func(*big.Int) client.Client
@@ -220,6 +224,11 @@ func newChain(ctx context.Context, cfg *config.ChainScoped, nodes []*toml.Node,
 		cl = opts.GenEthClient(chainID)
 	}
 
+	finality, err := headtracker.NewFinalityProvider(l, cl, cfg.EVM(), cfg.EVM().HeadTracker().FinalityProvider())
+	if err != nil {
+		return nil, fmt.Errorf("failed to instantiate finality provider for chain with ID %s: %w", chainID, err)
+	}
+
 	headBroadcaster := headtracker.NewHeadBroadcaster(l)
 	headSaver := headtracker.NullSaver
 	var headTracker httypes.HeadTracker
@@ -233,7 +242,7 @@ func newChain(ctx context.Context, cfg *config.ChainScoped, nodes []*toml.Node,
 			orm = headtracker.NewNullORM()
 		}
 		headSaver = headtracker.NewHeadSaver(l, orm, cfg.EVM(), cfg.EVM().HeadTracker())
-		headTracker = headtracker.NewHeadTracker(l, cl, cfg.EVM(), cfg.EVM().HeadTracker(), headBroadcaster, headSaver, opts.MailMon)
+		headTracker = headtracker.NewHeadTrackerWithFinality(l, cl, cfg.EVM(), cfg.EVM().HeadTracker(), headBroadcaster, headSaver, opts.MailMon, finality)
 	} else {
 		headTracker = opts.GenHeadTracker(chainID, headBroadcaster)
 	}
@@ -288,7 +297,7 @@ func newChain(ctx context.Context, cfg *config.ChainScoped, nodes []*toml.Node,
 
 	var balanceMonitor monitor.BalanceMonitor
 	if opts.AppConfig.EVMRPCEnabled() && cfg.EVM().BalanceMonitor().Enabled() {
-		balanceMonitor = monitor.NewBalanceMonitor(cl, opts.KeyStore, l)
+		balanceMonitor = monitor.NewBalanceMonitor(cl, opts.KeyStore, l, newBalanceMonitorOpts(chainID, cfg.EVM(), opts.DS, txm, opts.AuditLogger, l))
 		headBroadcaster.Subscribe(balanceMonitor)
 	}
 
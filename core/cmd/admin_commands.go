@@ -7,8 +7,10 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -68,6 +70,56 @@ func initAdminSubCmds(s *Shell) []cli.Command {
 				},
 			},
 		},
+		initCustomRolesSubCmd(s),
+		initAPITokensSubCmd(s),
+		{
+			Name:  "s4",
+			Usage: "Commands for administering S4 storage",
+			Subcommands: cli.Commands{
+				{
+					Name:  "quotas",
+					Usage: "Inspect or reset the per-address S4 quotas",
+					Subcommands: cli.Commands{
+						{
+							Name:   "list",
+							Usage:  "Lists the quota usage of all addresses",
+							Action: s.ListS4Quotas,
+							Flags: []cli.Flag{
+								cli.StringFlag{
+									Name:  "namespace",
+									Usage: "S4 namespace of the quotas",
+									Value: "functions",
+								},
+							},
+						},
+						{
+							Name:   "reset",
+							Usage:  "Resets the quota usage of an address, or of all addresses",
+							Action: s.ResetS4Quotas,
+							Flags: []cli.Flag{
+								cli.StringFlag{
+									Name:  "namespace",
+									Usage: "S4 namespace of the quotas",
+									Value: "functions",
+								},
+								cli.StringFlag{
+									Name:  "address",
+									Usage: "address whose quota is reset",
+								},
+								cli.BoolFlag{
+									Name:  "all",
+									Usage: "reset the quotas of all addresses",
+								},
+								cli.BoolFlag{
+									Name:  "yes, y",
+									Usage: "skip the confirmation prompt",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			Name:   "status",
 			Usage:  "Displays the health of various services running inside the node.",
@@ -95,7 +147,7 @@ func initAdminSubCmds(s *Shell) []cli.Command {
 						},
 						cli.StringFlag{
 							Name:     "role",
-							Usage:    "Permission level of new user. Options: 'admin', 'edit', 'run', 'view'.",
+							Usage:    "Permission level of new user. Options: 'admin', 'edit', 'run', 'view', or the name of a custom role.",
 							Required: true,
 						},
 					},
@@ -112,7 +164,7 @@ func initAdminSubCmds(s *Shell) []cli.Command {
 						},
 						cli.StringFlag{
 							Name:     "new-role, newrole",
-							Usage:    "new permission level role to set for user. Options: 'admin', 'edit', 'run', 'view'.",
+							Usage:    "new permission level role to set for user. Options: 'admin', 'edit', 'run', 'view', or the name of a custom role.",
 							Required: true,
 						},
 					},
@@ -139,12 +191,13 @@ type AdminUsersPresenter struct {
 	presenters.UserResource
 }
 
-var adminUsersTableHeaders = []string{"Email", "Role", "Has API token", "Created at", "Updated at"}
+var adminUsersTableHeaders = []string{"Email", "Role", "Custom role", "Has API token", "Created at", "Updated at"}
 
 func (p *AdminUsersPresenter) ToRow() []string {
 	row := []string{
 		p.ID,
 		string(p.Role),
+		p.CustomRole,
 		p.HasActiveApiToken,
 		p.CreatedAt.String(),
 		p.UpdatedAt.String(),
@@ -302,6 +355,92 @@ func (s *Shell) DeleteUser(c *cli.Context) (err error) {
 	return s.renderAPIResponse(response, &AdminUsersPresenter{}, "Successfully deleted API user")
 }
 
+type S4QuotaPresenter struct {
+	JAID
+	presenters.S4QuotaResource
+}
+
+var s4QuotasTableHeaders = []string{"Address", "Namespace", "Period start", "Bytes", "Writes"}
+
+func (p *S4QuotaPresenter) ToRow() []string {
+	return []string{
+		p.ID,
+		p.Namespace,
+		p.PeriodStart.String(),
+		strconv.FormatUint(p.Bytes, 10),
+		strconv.FormatUint(p.Writes, 10),
+	}
+}
+
+type S4QuotaPresenters []S4QuotaPresenter
+
+// RenderTable implements TableRenderer
+func (ps S4QuotaPresenters) RenderTable(rt RendererTable) error {
+	rows := [][]string{}
+
+	for _, p := range ps {
+		rows = append(rows, p.ToRow())
+	}
+
+	if _, err := rt.Write([]byte("S4 quotas\n")); err != nil {
+		return err
+	}
+	renderList(s4QuotasTableHeaders, rows, rt.Writer)
+
+	return cutils.JustError(rt.Write([]byte("\n")))
+}
+
+// ListS4Quotas renders the S4 quota usage of all addresses in a namespace
+func (s *Shell) ListS4Quotas(c *cli.Context) (err error) {
+	v := url.Values{}
+	v.Add("namespace", c.String("namespace"))
+	resp, err := s.HTTP.Get(s.ctx(), "/v2/s4/quotas?"+v.Encode())
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &S4QuotaPresenters{})
+}
+
+// ResetS4Quotas resets the S4 quota usage of an address, or of all addresses in a namespace
+func (s *Shell) ResetS4Quotas(c *cli.Context) (err error) {
+	address, all := c.String("address"), c.Bool("all")
+	if (address == "") == !all {
+		return s.errorOut(errors.New("must specify exactly one of --address or --all"))
+	}
+
+	if !confirmAction(c) {
+		return nil
+	}
+
+	v := url.Values{}
+	v.Add("namespace", c.String("namespace"))
+	path := "/v2/s4/quotas"
+	if address != "" {
+		path += "/" + address
+	}
+	resp, err := s.HTTP.Delete(s.ctx(), path+"?"+v.Encode())
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+	if _, err = s.parseResponse(resp); err != nil {
+		return err
+	}
+
+	fmt.Println("Successfully reset S4 quotas")
+	return nil
+}
+
 // Status will display the health of various services
 func (s *Shell) Status(c *cli.Context) error {
 	resp, err := s.HTTP.Get(s.ctx(), "/health?full=1", nil)
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+	"go.uber.org/multierr"
+
+	cutils "github.com/smartcontractkit/chainlink-common/pkg/utils"
+	"github.com/smartcontractkit/chainlink/v2/core/web"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+func initAPITokensSubCmd(s *Shell) cli.Command {
+	return cli.Command{
+		Name:  "tokens",
+		Usage: "Create, list, or revoke scoped API tokens for automation",
+		Subcommands: cli.Commands{
+			{
+				Name:   "list",
+				Usage:  "Lists your API tokens, or the API tokens of all users for admins",
+				Action: s.ListAPITokens,
+			},
+			{
+				Name:  "create",
+				Usage: "Create an API token with the given name, to be sent as a bearer token in the Authorization header",
+				Flags: []cli.Flag{
+					cli.StringSliceFlag{
+						Name:  "scope, s",
+						Usage: "permission granted to the token in the format resource:action[:scope], like jobs:*:webhook or bridges:read. Requests are also restricted by your role. Can be repeated",
+					},
+					cli.DurationFlag{
+						Name:  "expires-in",
+						Usage: "duration after which the token expires",
+						Value: 30 * 24 * time.Hour,
+					},
+				},
+				Action: s.CreateAPIToken,
+			},
+			{
+				Name:  "revoke",
+				Usage: "Revoke the API token with the given ID",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "skip the confirmation prompt",
+					},
+				},
+				Action: s.RevokeAPIToken,
+			},
+		},
+	}
+}
+
+type APITokenPresenter struct {
+	JAID
+	presenters.APITokenResource
+}
+
+var apiTokenHeaders = []string{"ID", "Name", "User", "Scopes", "Expires at", "Last used at", "Revoked at"}
+
+func (p *APITokenPresenter) ToRow() []string {
+	var lastUsedAt, revokedAt string
+	if p.LastUsedAt != nil {
+		lastUsedAt = p.LastUsedAt.String()
+	}
+	if p.RevokedAt != nil {
+		revokedAt = p.RevokedAt.String()
+	}
+	return []string{
+		p.ID,
+		p.Name,
+		p.UserEmail,
+		strings.Join(p.Scopes, "\n"),
+		p.ExpiresAt.String(),
+		lastUsedAt,
+		revokedAt,
+	}
+}
+
+// RenderTable implements TableRenderer
+func (p *APITokenPresenter) RenderTable(rt RendererTable) error {
+	renderList(apiTokenHeaders, [][]string{p.ToRow()}, rt.Writer)
+	if p.Token != "" {
+		msg := fmt.Sprintf("\nToken (will not be shown again): %s\n", p.Token)
+		if _, err := rt.Write([]byte(msg)); err != nil {
+			return err
+		}
+	}
+	return cutils.JustError(rt.Write([]byte("\n")))
+}
+
+type APITokenPresenters []APITokenPresenter
+
+// RenderTable implements TableRenderer
+func (ps APITokenPresenters) RenderTable(rt RendererTable) error {
+	rows := [][]string{}
+	for _, p := range ps {
+		rows = append(rows, p.ToRow())
+	}
+
+	if _, err := rt.Write([]byte("API tokens\n")); err != nil {
+		return err
+	}
+	renderList(apiTokenHeaders, rows, rt.Writer)
+
+	return cutils.JustError(rt.Write([]byte("\n")))
+}
+
+// ListAPITokens renders the API tokens of the user, or of all users for admins
+func (s *Shell) ListAPITokens(_ *cli.Context) (err error) {
+	resp, err := s.HTTP.Get(s.ctx(), "/v2/tokens", nil)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &APITokenPresenters{})
+}
+
+// CreateAPIToken creates an API token of the user, and renders its bearer token
+func (s *Shell) CreateAPIToken(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("Must pass the name of the API token"))
+	}
+	b, err := json.Marshal(web.APITokenRequest{
+		Name:      c.Args().Get(0),
+		Scopes:    c.StringSlice("scope"),
+		ExpiresIn: c.Duration("expires-in").String(),
+	})
+	if err != nil {
+		return s.errorOut(err)
+	}
+	resp, err := s.HTTP.Post(s.ctx(), "/v2/tokens", bytes.NewBuffer(b))
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &APITokenPresenter{}, "Successfully created API token")
+}
+
+// RevokeAPIToken revokes an API token
+func (s *Shell) RevokeAPIToken(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("Must pass the ID of the API token"))
+	}
+	if !confirmAction(c) {
+		return nil
+	}
+	resp, err := s.HTTP.Delete(s.ctx(), "/v2/tokens/"+url.PathEscape(c.Args().Get(0)))
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	if _, err = s.parseResponse(resp); err != nil {
+		return err
+	}
+
+	fmt.Printf("Successfully revoked API token %s\n", c.Args().Get(0))
+	return nil
+}
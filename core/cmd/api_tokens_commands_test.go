@@ -0,0 +1,47 @@
+package cmd_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/cmd"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+func TestAPITokenPresenter_RenderTable(t *testing.T) {
+	t.Parallel()
+
+	lastUsedAt := time.Now()
+	p := cmd.APITokenPresenter{
+		JAID: cmd.JAID{ID: "0123abcd"},
+		APITokenResource: presenters.APITokenResource{
+			JAID:       presenters.JAID{ID: "0123abcd"},
+			Name:       "ci-deploy",
+			UserEmail:  "ci@example.com",
+			Scopes:     []string{"jobs:*:webhook", "bridges:read"},
+			ExpiresAt:  time.Now().Add(time.Hour),
+			LastUsedAt: &lastUsedAt,
+		},
+	}
+
+	buffer := bytes.NewBufferString("")
+	r := cmd.RendererTable{Writer: buffer}
+	require.NoError(t, cmd.APITokenPresenters{p}.RenderTable(r))
+
+	output := buffer.String()
+	assert.Contains(t, output, "0123abcd")
+	assert.Contains(t, output, "ci-deploy")
+	assert.Contains(t, output, "ci@example.com")
+	assert.Contains(t, output, "jobs:*:webhook")
+	assert.Contains(t, output, "bridges:read")
+	assert.NotContains(t, output, "will not be shown again")
+
+	p.Token = "clt_0123abcd_secret"
+	buffer.Reset()
+	require.NoError(t, p.RenderTable(r))
+	assert.Contains(t, buffer.String(), "Token (will not be shown again): clt_0123abcd_secret")
+}
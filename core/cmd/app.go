@@ -196,6 +196,7 @@ func NewApp(s *Shell) *cli.App {
 				initCSAKeysSubCmd(s),
 				initOCRKeysSubCmd(s),
 				initOCR2KeysSubCmd(s),
+				initKeyRotationsSubCmd(s),
 
 				keysCommand("Cosmos", NewCosmosKeysClient(s)),
 				keysCommand("Solana", NewSolanaKeysClient(s)),
@@ -301,6 +302,11 @@ func NewApp(s *Shell) *cli.App {
 			Usage:       "Commands for handling node configuration",
 			Subcommands: initNodeSubCmds(s),
 		},
+		{
+			Name:        "logs",
+			Usage:       "Commands for querying the logs saved by the log poller",
+			Subcommands: initLogsSubCmds(s),
+		},
 		{
 			Name:        "forwarders",
 			Usage:       "Commands for managing forwarder addresses.",
@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+	"go.uber.org/multierr"
+
+	cutils "github.com/smartcontractkit/chainlink-common/pkg/utils"
+	"github.com/smartcontractkit/chainlink/v2/core/web"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+func initCustomRolesSubCmd(s *Shell) cli.Command {
+	roleFlags := []cli.Flag{
+		cli.StringFlag{
+			Name:  "description",
+			Usage: "description of the role",
+		},
+		cli.StringFlag{
+			Name:  "group",
+			Usage: "LDAP group CN or OIDC group whose members are assigned the role, unless they are members of the admin group",
+		},
+		cli.StringSliceFlag{
+			Name:  "permission, p",
+			Usage: "permission granted by the role in the format resource:action[:scope], like jobs:*:offchainreporting2 or keys:export:csa. Can be repeated",
+		},
+	}
+	return cli.Command{
+		Name:  "roles",
+		Usage: "Create, edit, or delete custom roles of API users",
+		Subcommands: cli.Commands{
+			{
+				Name:   "list",
+				Usage:  "Lists all custom roles",
+				Action: s.ListCustomRoles,
+			},
+			{
+				Name:   "show",
+				Usage:  "Show the custom role with the given name",
+				Action: s.ShowCustomRole,
+			},
+			{
+				Name:   "create",
+				Usage:  "Create a custom role with the given name",
+				Flags:  roleFlags,
+				Action: s.CreateCustomRole,
+			},
+			{
+				Name:   "update",
+				Usage:  "Replace the description, group and permissions of the custom role with the given name",
+				Flags:  roleFlags,
+				Action: s.UpdateCustomRole,
+			},
+			{
+				Name:  "delete",
+				Usage: "Delete the custom role with the given name, which must not be assigned to any local user",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "skip the confirmation prompt",
+					},
+				},
+				Action: s.DeleteCustomRole,
+			},
+		},
+	}
+}
+
+type CustomRolePresenter struct {
+	JAID
+	presenters.CustomRoleResource
+}
+
+var customRoleHeaders = []string{"Name", "Description", "Group", "Permissions", "Updated at"}
+
+func (p *CustomRolePresenter) ToRow() []string {
+	var group string
+	if p.Group != nil {
+		group = *p.Group
+	}
+	return []string{
+		p.ID,
+		p.Description,
+		group,
+		strings.Join(p.Permissions, "\n"),
+		p.UpdatedAt.String(),
+	}
+}
+
+// RenderTable implements TableRenderer
+func (p *CustomRolePresenter) RenderTable(rt RendererTable) error {
+	renderList(customRoleHeaders, [][]string{p.ToRow()}, rt.Writer)
+	return cutils.JustError(rt.Write([]byte("\n")))
+}
+
+type CustomRolePresenters []CustomRolePresenter
+
+// RenderTable implements TableRenderer
+func (ps CustomRolePresenters) RenderTable(rt RendererTable) error {
+	rows := [][]string{}
+	for _, p := range ps {
+		rows = append(rows, p.ToRow())
+	}
+
+	if _, err := rt.Write([]byte("Custom roles\n")); err != nil {
+		return err
+	}
+	renderList(customRoleHeaders, rows, rt.Writer)
+
+	return cutils.JustError(rt.Write([]byte("\n")))
+}
+
+// ListCustomRoles renders all custom roles
+func (s *Shell) ListCustomRoles(_ *cli.Context) (err error) {
+	resp, err := s.HTTP.Get(s.ctx(), "/v2/roles", nil)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &CustomRolePresenters{})
+}
+
+// ShowCustomRole renders a custom role
+func (s *Shell) ShowCustomRole(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("Must pass the name of the custom role"))
+	}
+	resp, err := s.HTTP.Get(s.ctx(), "/v2/roles/"+url.PathEscape(c.Args().Get(0)), nil)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &CustomRolePresenter{})
+}
+
+// CreateCustomRole creates a custom role
+func (s *Shell) CreateCustomRole(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("Must pass the name of the custom role"))
+	}
+	buf, err := customRoleRequestBody(c)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	resp, err := s.HTTP.Post(s.ctx(), "/v2/roles", buf)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &CustomRolePresenter{}, "Successfully created custom role")
+}
+
+// UpdateCustomRole replaces the description, group and permissions of a custom role
+func (s *Shell) UpdateCustomRole(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("Must pass the name of the custom role"))
+	}
+	buf, err := customRoleRequestBody(c)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	resp, err := s.HTTP.Put(s.ctx(), "/v2/roles/"+url.PathEscape(c.Args().Get(0)), buf)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &CustomRolePresenter{}, "Successfully updated custom role")
+}
+
+// DeleteCustomRole deletes a custom role
+func (s *Shell) DeleteCustomRole(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("Must pass the name of the custom role"))
+	}
+	if !confirmAction(c) {
+		return nil
+	}
+	resp, err := s.HTTP.Delete(s.ctx(), "/v2/roles/"+url.PathEscape(c.Args().Get(0)))
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	if _, err = s.parseResponse(resp); err != nil {
+		return err
+	}
+
+	fmt.Printf("Successfully deleted custom role %s\n", c.Args().Get(0))
+	return nil
+}
+
+func customRoleRequestBody(c *cli.Context) (*bytes.Buffer, error) {
+	request := web.CustomRoleRequest{
+		Name:        c.Args().Get(0),
+		Description: c.String("description"),
+		Permissions: c.StringSlice("permission"),
+	}
+	if c.IsSet("group") {
+		group := c.String("group")
+		request.Group = &group
+	}
+	b, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewBuffer(b), nil
+}
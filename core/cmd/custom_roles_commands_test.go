@@ -0,0 +1,40 @@
+package cmd_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/cmd"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+func TestCustomRolePresenter_RenderTable(t *testing.T) {
+	t.Parallel()
+
+	group := "NodeOCROperators"
+	p := cmd.CustomRolePresenter{
+		JAID: cmd.JAID{ID: "ocr-operator"},
+		CustomRoleResource: presenters.CustomRoleResource{
+			JAID:        presenters.JAID{ID: "ocr-operator"},
+			Description: "manages OCR2 jobs",
+			Group:       &group,
+			Permissions: []string{"jobs:*:offchainreporting2", "keys:read:ocr2"},
+			UpdatedAt:   time.Now(),
+		},
+	}
+
+	buffer := bytes.NewBufferString("")
+	r := cmd.RendererTable{Writer: buffer}
+	require.NoError(t, cmd.CustomRolePresenters{p}.RenderTable(r))
+
+	output := buffer.String()
+	assert.Contains(t, output, "ocr-operator")
+	assert.Contains(t, output, "manages OCR2 jobs")
+	assert.Contains(t, output, group)
+	assert.Contains(t, output, "jobs:*:offchainreporting2")
+	assert.Contains(t, output, "keys:read:ocr2")
+}
@@ -71,6 +71,22 @@ func initEthKeysSubCmd(s *Shell) cli.Command {
 				},
 				Action: s.ImportETHKey,
 			},
+			{
+				Name:   "register-remote",
+				Usage:  "Register a key held by the remote signer by its address; the private key never enters the node's keystore",
+				Action: s.RegisterRemoteETHKey,
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:     "address",
+						Usage:    "address of the key held by the remote signer",
+						Required: true,
+					},
+					cli.StringFlag{
+						Name:  "evm-chain-id, evmChainID",
+						Usage: "Chain ID for the key. If left blank, default chain will be used.",
+					},
+				},
+			},
 			{
 				Name:  "export",
 				Usage: format(`Exports an ETH key to a JSON file`),
@@ -303,6 +319,33 @@ func (s *Shell) ImportETHKey(c *cli.Context) (err error) {
 	return s.renderAPIResponse(resp, &EthKeyPresenter{}, "🔑 Imported ETH key")
 }
 
+// RegisterRemoteETHKey registers an Ethereum key held by the remote signer,
+// address of key must be passed
+func (s *Shell) RegisterRemoteETHKey(c *cli.Context) (err error) {
+	registerURL := url.URL{
+		Path: "/v2/keys/evm/remote",
+	}
+	query := registerURL.Query()
+
+	query.Set("address", c.String("address"))
+	if c.IsSet("evm-chain-id") {
+		query.Set("evmChainID", c.String("evm-chain-id"))
+	}
+
+	registerURL.RawQuery = query.Encode()
+	resp, err := s.HTTP.Post(s.ctx(), registerURL.String(), nil)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &EthKeyPresenter{}, "🔑 Registered remote ETH key")
+}
+
 // ExportETHKey exports an ETH key,
 // address must be passed
 func (s *Shell) ExportETHKey(c *cli.Context) (err error) {
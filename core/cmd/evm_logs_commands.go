@@ -0,0 +1,260 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+	"go.uber.org/multierr"
+
+	cutils "github.com/smartcontractkit/chainlink-common/pkg/utils"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller"
+	"github.com/smartcontractkit/chainlink/v2/core/web"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+func initLogsSubCmds(s *Shell) []cli.Command {
+	return []cli.Command{
+		{
+			Name:   "query",
+			Usage:  "Query the logs saved by the log poller. Flags are combined with the expressions of --query, and all must match.",
+			Action: s.QueryLogs,
+			Flags: []cli.Flag{
+				cli.Int64Flag{
+					Name:  "chain-id, evm-chain-id",
+					Usage: "Chain ID of the EVM-based blockchain",
+				},
+				cli.StringSliceFlag{
+					Name:  "address",
+					Usage: "address of the contract emitting the logs, may be repeated to match any of them",
+				},
+				cli.StringSliceFlag{
+					Name:  "event",
+					Usage: "event signature hash, or event signature such as Transfer(address,address,uint256), may be repeated to match any of them",
+				},
+				cli.StringSliceFlag{
+					Name:  "topic1",
+					Usage: "value of the first indexed topic, may be repeated to match any of them",
+				},
+				cli.StringSliceFlag{
+					Name:  "topic2",
+					Usage: "value of the second indexed topic, may be repeated to match any of them",
+				},
+				cli.StringSliceFlag{
+					Name:  "topic3",
+					Usage: "value of the third indexed topic, may be repeated to match any of them",
+				},
+				cli.StringFlag{
+					Name:  "confs",
+					Usage: "minimum number of confirmations of the logs, or `finalized`",
+				},
+				cli.Uint64Flag{
+					Name:  "from-block",
+					Usage: "first block of the logs",
+				},
+				cli.Uint64Flag{
+					Name:  "to-block",
+					Usage: "last block of the logs",
+				},
+				cli.StringFlag{
+					Name:  "query",
+					Usage: "file holding a JSON list of query expressions",
+				},
+				cli.StringFlag{
+					Name:  "abi",
+					Usage: "file holding the ABI of the contract, used to decode the logs",
+				},
+				cli.Uint64Flag{
+					Name:  "limit",
+					Usage: "maximum number of logs returned",
+					Value: 100,
+				},
+				cli.StringFlag{
+					Name:  "cursor",
+					Usage: "ID of the last log of the previous page; requires --confs finalized",
+				},
+				cli.StringFlag{
+					Name:  "sort",
+					Usage: "sort order of the logs, `asc` or `desc`",
+					Value: "asc",
+				},
+			},
+		},
+	}
+}
+
+// EVMLogPresenter implements TableRenderer for an EVMLogResource.
+type EVMLogPresenter struct {
+	JAID
+	presenters.EVMLogResource
+}
+
+var evmLogsTableHeaders = []string{"ID", "Block", "Address", "Event", "Topics", "Data"}
+
+// ToRow presents the EVMLogResource as a slice of strings.
+func (p *EVMLogPresenter) ToRow() []string {
+	event, topics, data := p.EventSig, strings.Join(p.Topics[min(1, len(p.Topics)):], "\n"), p.Data
+	if p.Event != "" {
+		args, _ := json.Marshal(p.Args)
+		event, topics, data = p.Event, "", string(args)
+	}
+	return []string{p.ID, strconv.FormatInt(p.BlockNumber, 10), p.Address, event, topics, data}
+}
+
+type EVMLogPresenters []EVMLogPresenter
+
+// RenderTable implements TableRenderer
+func (ps EVMLogPresenters) RenderTable(rt RendererTable) error {
+	rows := [][]string{}
+
+	for _, p := range ps {
+		rows = append(rows, p.ToRow())
+	}
+
+	if _, err := rt.Write([]byte("Logs\n")); err != nil {
+		return err
+	}
+	renderList(evmLogsTableHeaders, rows, rt.Writer)
+
+	return cutils.JustError(rt.Write([]byte("\n")))
+}
+
+// QueryLogs renders the logs saved by the log poller matching a query
+func (s *Shell) QueryLogs(c *cli.Context) (err error) {
+	request := web.LogsQueryRequest{LogsQuery: logpoller.LogsQuery{
+		Limit:  c.Uint64("limit"),
+		Cursor: c.String("cursor"),
+		Sort:   c.String("sort"),
+	}}
+	if request.Expressions, err = logsQueryExpressions(c); err != nil {
+		return s.errorOut(err)
+	}
+	if path := c.String("abi"); path != "" {
+		b, err2 := os.ReadFile(path)
+		if err2 != nil {
+			return s.errorOut(errors.Wrap(err2, "failed to read abi"))
+		}
+		request.ABI = string(b)
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	v := url.Values{}
+	if c.IsSet("chain-id") {
+		v.Add("evmChainID", fmt.Sprintf("%d", c.Int64("chain-id")))
+	}
+	resp, err := s.HTTP.Post(s.ctx(), "/v2/logs/query?"+v.Encode(), bytes.NewReader(body))
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &EVMLogPresenters{})
+}
+
+// logsQueryExpressions returns the expressions of the --query file and of the flags of c.
+func logsQueryExpressions(c *cli.Context) ([]logpoller.QueryExpression, error) {
+	var exprs []logpoller.QueryExpression
+	if path := c.String("query"); path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read query")
+		}
+		if err = json.Unmarshal(b, &exprs); err != nil {
+			return nil, errors.Wrap(err, "failed to decode query")
+		}
+	}
+
+	// anyOf matches any of the expressions
+	anyOf := func(alternatives []logpoller.QueryExpression) {
+		switch len(alternatives) {
+		case 0:
+		case 1:
+			exprs = append(exprs, alternatives[0])
+		default:
+			exprs = append(exprs, logpoller.QueryExpression{Or: alternatives})
+		}
+	}
+
+	var addresses []logpoller.QueryExpression
+	for _, a := range c.StringSlice("address") {
+		if !common.IsHexAddress(a) {
+			return nil, errors.Errorf("invalid address: %s", a)
+		}
+		address := common.HexToAddress(a)
+		addresses = append(addresses, logpoller.QueryExpression{Address: &address})
+	}
+	anyOf(addresses)
+
+	var events []logpoller.QueryExpression
+	for _, e := range c.StringSlice("event") {
+		sig := crypto.Keccak256Hash([]byte(e))
+		if strings.HasPrefix(e, "0x") {
+			var err error
+			if sig, err = parseHash(e); err != nil {
+				return nil, errors.Wrap(err, "invalid event")
+			}
+		}
+		events = append(events, logpoller.QueryExpression{EventSig: &sig})
+	}
+	anyOf(events)
+
+	for i, name := range []string{"topic1", "topic2", "topic3"} {
+		if !c.IsSet(name) {
+			continue
+		}
+		var values []common.Hash
+		for _, t := range c.StringSlice(name) {
+			h, err := parseHash(t)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid %s", name)
+			}
+			values = append(values, h)
+		}
+		exprs = append(exprs, logpoller.QueryExpression{Topic: &logpoller.QueryHashFilter{Index: uint64(i + 1), Values: values}}) //nolint:gosec // G115
+	}
+
+	if c.IsSet("confs") {
+		confs, err := logpoller.ParseConfirmations(c.String("confs"))
+		if err != nil {
+			return nil, err
+		}
+		qc := logpoller.QueryConfirmations(confs)
+		exprs = append(exprs, logpoller.QueryExpression{Confirmations: &qc})
+	}
+	if c.IsSet("from-block") {
+		exprs = append(exprs, logpoller.QueryExpression{Block: &logpoller.QueryComparison{Value: c.Uint64("from-block"), Operator: ">="}})
+	}
+	if c.IsSet("to-block") {
+		exprs = append(exprs, logpoller.QueryExpression{Block: &logpoller.QueryComparison{Value: c.Uint64("to-block"), Operator: "<="}})
+	}
+	return exprs, nil
+}
+
+// parseHash parses a 32 bytes hex value. Shorter values, such as addresses, are left padded.
+func parseHash(s string) (common.Hash, error) {
+	b, err := hexutil.Decode(s)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if len(b) > common.HashLength {
+		return common.Hash{}, errors.Errorf("%s is longer than 32 bytes", s)
+	}
+	return common.BytesToHash(b), nil
+}
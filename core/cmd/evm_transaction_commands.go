@@ -62,6 +62,11 @@ func initEVMTxSubCmd(s *Shell) cli.Command {
 				Usage:  "get information on a specific Ethereum Transaction",
 				Action: s.ShowTransaction,
 			},
+			{
+				Name:   "bundle",
+				Usage:  "get information on a specific bundle of Ethereum Transactions",
+				Action: s.ShowTransactionBundle,
+			},
 		},
 	}
 }
@@ -105,6 +110,28 @@ func (ps EthTxPresenters) RenderTable(rt RendererTable) error {
 	return nil
 }
 
+type EthTxBundlePresenter struct {
+	JAID
+	presenters.EthTxBundleResource
+}
+
+// RenderTable implements TableRenderer
+func (p *EthTxBundlePresenter) RenderTable(rt RendererTable) error {
+	table := rt.newTable([]string{"Hash", "Nonce", "From", "To", "State"})
+	for _, tx := range p.Transactions {
+		table.Append([]string{
+			tx.Hash.Hex(),
+			tx.Nonce,
+			tx.From.Hex(),
+			tx.To.Hex(),
+			fmt.Sprint(tx.State),
+		})
+	}
+
+	render(fmt.Sprintf("Ethereum Transaction Bundle %v (%v)", p.ID, p.State), table)
+	return nil
+}
+
 // IndexTransactions returns the list of transactions in descending order,
 // taking an optional page parameter
 func (s *Shell) IndexTransactions(c *cli.Context) error {
@@ -131,6 +158,26 @@ func (s *Shell) ShowTransaction(c *cli.Context) (err error) {
 	return err
 }
 
+// ShowTransactionBundle returns the info for the given transaction bundle ID
+func (s *Shell) ShowTransactionBundle(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("must pass the ID of the transaction bundle"))
+	}
+	id := c.Args().First()
+	resp, err := s.HTTP.Get(s.ctx(), "/v2/transactions/evm/bundles/"+id)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	err = s.renderAPIResponse(resp, &EthTxBundlePresenter{})
+	return err
+}
+
 // SendEther transfers ETH from the node's account to a specified address.
 func (s *Shell) SendEther(c *cli.Context) (err error) {
 	if c.NArg() < 3 {
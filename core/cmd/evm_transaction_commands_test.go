@@ -89,6 +89,36 @@ func TestShell_ShowTransaction(t *testing.T) {
 	assert.Equal(t, &tx.FromAddress, renderedTx.From)
 }
 
+func TestShell_ShowTransactionBundle(t *testing.T) {
+	t.Parallel()
+
+	app := startNewApplicationV2(t, nil)
+	client, r := app.NewShellAndRenderer()
+
+	db := app.GetDB()
+	_, from := cltest.MustInsertRandomKey(t, app.KeyStore.Eth())
+
+	txStore := cltest.NewTestTxStore(t, db)
+	bundle, err := txStore.CreateTransactionBundle(testutils.Context(t), []txmgr.TxRequest{
+		{FromAddress: from, ToAddress: testutils.NewAddress(), FeeLimit: 21000},
+		{FromAddress: from, ToAddress: testutils.NewAddress(), FeeLimit: 21000},
+	}, testutils.FixtureChainID)
+	require.NoError(t, err)
+
+	set := flag.NewFlagSet("test get tx bundle", 0)
+	flagSetApplyFromAction(client.ShowTransactionBundle, set, "")
+
+	require.NoError(t, set.Parse([]string{bundle.ID.String()}))
+
+	c := cli.NewContext(nil, set, nil)
+	require.NoError(t, client.ShowTransactionBundle(c))
+
+	renderedBundle := *r.Renders[0].(*cmd.EthTxBundlePresenter)
+	assert.Equal(t, string(txmgr.TxBundleUnstarted), renderedBundle.State)
+	require.Len(t, renderedBundle.Transactions, 2)
+	assert.Equal(t, &from, renderedBundle.Transactions[0].From)
+}
+
 func TestShell_IndexTxAttempts(t *testing.T) {
 	t.Parallel()
 
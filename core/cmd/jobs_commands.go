@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 	"time"
 
@@ -50,6 +51,21 @@ func initJobsSubCmds(s *Shell) []cli.Command {
 			Usage:  "Trigger a job run",
 			Action: s.TriggerPipelineRun,
 		},
+		{
+			Name:   "replay",
+			Usage:  "Replay a finished pipeline run, substituting recorded task results, and diff it against the original",
+			Action: s.ReplayPipelineRun,
+			Flags: []cli.Flag{
+				cli.StringSliceFlag{
+					Name:  "task",
+					Usage: "DOT ID of a task whose recorded result is substituted (repeatable)",
+				},
+				cli.StringSliceFlag{
+					Name:  "type",
+					Usage: "task type whose recorded results are substituted (repeatable); defaults to bridge, http, ethcall and estimategaslimit",
+				},
+			},
+		},
 	}
 }
 
@@ -313,3 +329,71 @@ func (s *Shell) TriggerPipelineRun(c *cli.Context) error {
 	err = s.renderAPIResponse(resp, &run, "Pipeline run successfully triggered")
 	return err
 }
+
+// PipelineRunReplayPresenter wraps the JSONAPI PipelineRunReplay Resource and adds rendering functionality
+type PipelineRunReplayPresenter struct {
+	JAID
+	presenters.PipelineRunReplayResource
+}
+
+// RenderTable implements TableRenderer
+func (p *PipelineRunReplayPresenter) RenderTable(rt RendererTable) error {
+	table := rt.newTable([]string{"Task", "Type", "Replayed", "Changed", "Original Output", "Output", "Original Error", "Error"})
+	for _, d := range p.TaskRunDiffs {
+		changed := strconv.FormatBool(d.Changed)
+		if !d.Recorded {
+			changed = "N/A"
+		}
+		table.Append([]string{
+			d.DotID,
+			string(d.Type),
+			strconv.FormatBool(d.Replayed),
+			changed,
+			stringOrEmpty(d.OriginalOutput),
+			stringOrEmpty(d.Output),
+			stringOrEmpty(d.OriginalError),
+			stringOrEmpty(d.Error),
+		})
+	}
+
+	render("Pipeline Run Replay", table)
+	return nil
+}
+
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// ReplayPipelineRun replays a finished pipeline run based on a run ID
+func (s *Shell) ReplayPipelineRun(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("must pass the id of the pipeline run to replay"))
+	}
+
+	var taskTypes []pipeline.TaskType
+	for _, t := range c.StringSlice("type") {
+		taskTypes = append(taskTypes, pipeline.TaskType(t))
+	}
+	request, err := json.Marshal(pipeline.ReplayOptions{
+		DotIDs:    c.StringSlice("task"),
+		TaskTypes: taskTypes,
+	})
+	if err != nil {
+		return s.errorOut(err)
+	}
+
+	resp, err := s.HTTP.Post(s.ctx(), "/v2/pipeline/runs/"+c.Args().First()+"/replay", bytes.NewReader(request))
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &PipelineRunReplayPresenter{})
+}
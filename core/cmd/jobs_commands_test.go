@@ -88,6 +88,38 @@ func TestJobPresenter_RenderTable(t *testing.T) {
 	assert.Contains(t, output, createdAt.Format(time.RFC3339))
 }
 
+func TestPipelineRunReplayPresenter_RenderTable(t *testing.T) {
+	t.Parallel()
+
+	var (
+		original = `"10"`
+		replayed = `"21"`
+		errMsg   = "uh oh"
+		buffer   = bytes.NewBufferString("")
+		r        = cmd.RendererTable{Writer: buffer}
+	)
+
+	p := cmd.PipelineRunReplayPresenter{
+		PipelineRunReplayResource: presenters.PipelineRunReplayResource{
+			JAID: presenters.NewJAID("1"),
+			TaskRunDiffs: []presenters.PipelineTaskRunDiffResource{
+				{DotID: "ds1", Type: "http", Replayed: true, Recorded: true, Changed: true, OriginalOutput: &original, Output: &replayed},
+				{DotID: "ds1_parse", Type: "jsonparse", Recorded: false, Error: &errMsg},
+			},
+		},
+	}
+
+	require.NoError(t, p.RenderTable(r))
+
+	output := buffer.String()
+	assert.Contains(t, output, "ds1_parse")
+	assert.Contains(t, output, "jsonparse")
+	assert.Contains(t, output, original)
+	assert.Contains(t, output, replayed)
+	assert.Contains(t, output, "N/A")
+	assert.Contains(t, output, errMsg)
+}
+
 func TestJobRenderer_GetTasks(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+	"go.uber.org/multierr"
+
+	cutils "github.com/smartcontractkit/chainlink-common/pkg/utils"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+func initKeyRotationsSubCmd(s *Shell) cli.Command {
+	return cli.Command{
+		Name:  "rotations",
+		Usage: "Remote commands for rotating the node's ETH and OCR2 keys",
+		Subcommands: cli.Commands{
+			{
+				Name: "create",
+				Usage: format(`Rotates the ETH key with the given address, or the OCR2 key bundle with the given ID, by creating its successor.
+				The old key keeps working until the rotation is retired.`),
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "type",
+						Usage: "type of the key to rotate, eth or ocr2 (required)",
+					},
+					cli.DurationFlag{
+						Name:  "retire-after",
+						Usage: "retire the old key automatically after this duration, such as 72h, once it is no longer referenced and has drained",
+					},
+				},
+				Action: s.CreateKeyRotation,
+			},
+			{
+				Name:  "list",
+				Usage: format(`List key rotations`),
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "key-id",
+						Usage: "only list the rotations of the key with this address or ID",
+					},
+				},
+				Action: s.ListKeyRotations,
+			},
+			{
+				Name:   "show",
+				Usage:  format(`Show the key rotation with the given ID, with the references and in-flight transactions of its old key`),
+				Action: s.ShowKeyRotation,
+			},
+			{
+				Name: "retire",
+				Usage: format(`Retires the old key of the key rotation with the given ID, once it is no longer referenced
+				and has no in-flight transactions. An ETH key is disabled for its chains.`),
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "delete",
+						Usage: "also delete the old key",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "skip the confirmation prompt",
+					},
+				},
+				Action: s.RetireKeyRotation,
+			},
+		},
+	}
+}
+
+type KeyRotationPresenter struct {
+	JAID // Include this to overwrite the presenter JAID so it can correctly render the ID in JSON
+	presenters.KeyRotationResource
+}
+
+var keyRotationHeaders = []string{"ID", "Type", "Old key", "New key", "State", "Created", "Retire after", "Retired"}
+
+// RenderTable implements TableRenderer
+func (p *KeyRotationPresenter) RenderTable(rt RendererTable) error {
+	if _, err := rt.Write([]byte("🔑 Key Rotations\n")); err != nil {
+		return err
+	}
+	renderList(keyRotationHeaders, [][]string{p.ToRow()}, rt.Writer)
+
+	if len(p.References) > 0 {
+		rows := [][]string{}
+		for _, ref := range p.References {
+			rows = append(rows, []string{string(ref.Kind), strconv.FormatInt(ref.ID, 10), ref.Name, strconv.FormatBool(ref.BlocksRetirement)})
+		}
+		if _, err := rt.Write([]byte("\nReferences of the old key\n")); err != nil {
+			return err
+		}
+		renderList([]string{"Kind", "ID", "Name", "Blocks retirement"}, rows, rt.Writer)
+	}
+	if p.InFlightTxs > 0 {
+		if _, err := rt.Write([]byte(fmt.Sprintf("\nIn-flight transactions of the old key: %d\n", p.InFlightTxs))); err != nil {
+			return err
+		}
+	}
+
+	return cutils.JustError(rt.Write([]byte("\n")))
+}
+
+func (p *KeyRotationPresenter) ToRow() []string {
+	var retireAfter, retiredAt string
+	if p.RetireAfter != nil {
+		retireAfter = p.RetireAfter.String()
+	}
+	if p.RetiredAt != nil {
+		retiredAt = p.RetiredAt.String()
+	}
+	return []string{
+		p.ID,
+		string(p.KeyType),
+		p.OldKeyID,
+		p.NewKeyID,
+		string(p.State),
+		p.CreatedAt.String(),
+		retireAfter,
+		retiredAt,
+	}
+}
+
+type KeyRotationPresenters []KeyRotationPresenter
+
+// RenderTable implements TableRenderer
+func (ps KeyRotationPresenters) RenderTable(rt RendererTable) error {
+	rows := [][]string{}
+	for _, p := range ps {
+		rows = append(rows, p.ToRow())
+	}
+
+	if _, err := rt.Write([]byte("🔑 Key Rotations\n")); err != nil {
+		return err
+	}
+	renderList(keyRotationHeaders, rows, rt.Writer)
+
+	return cutils.JustError(rt.Write([]byte("\n")))
+}
+
+// CreateKeyRotation rotates an ETH key or an OCR2 key bundle
+func (s *Shell) CreateKeyRotation(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("Must pass the address or ID of the key to rotate"))
+	}
+	keyType := strings.ToLower(c.String("type"))
+	if keyType == "" {
+		return s.errorOut(errors.New("Must specify --type flag"))
+	}
+
+	query := url.Values{}
+	query.Set("keyType", keyType)
+	query.Set("keyID", c.Args().Get(0))
+	if retireAfter := c.Duration("retire-after"); retireAfter != 0 {
+		query.Set("retireAfter", retireAfter.String())
+	}
+	resp, err := s.HTTP.Post(s.ctx(), "/v2/keys/rotations?"+query.Encode(), nil)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	var presenter KeyRotationPresenter
+	return s.renderAPIResponse(resp, &presenter, "Created key rotation")
+}
+
+// ListKeyRotations lists the key rotations
+func (s *Shell) ListKeyRotations(c *cli.Context) (err error) {
+	path := "/v2/keys/rotations"
+	if keyID := c.String("key-id"); keyID != "" {
+		path += "?keyID=" + url.QueryEscape(keyID)
+	}
+	resp, err := s.HTTP.Get(s.ctx(), path, nil)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	var presenters KeyRotationPresenters
+	return s.renderAPIResponse(resp, &presenters)
+}
+
+// ShowKeyRotation shows a key rotation
+func (s *Shell) ShowKeyRotation(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("Must pass the ID of the key rotation"))
+	}
+	resp, err := s.HTTP.Get(s.ctx(), "/v2/keys/rotations/"+c.Args().Get(0), nil)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	var presenter KeyRotationPresenter
+	return s.renderAPIResponse(resp, &presenter)
+}
+
+// RetireKeyRotation retires the old key of a key rotation
+func (s *Shell) RetireKeyRotation(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("Must pass the ID of the key rotation"))
+	}
+	if c.Bool("delete") && !confirmAction(c) {
+		return nil
+	}
+
+	path := "/v2/keys/rotations/" + c.Args().Get(0) + "/retire"
+	if c.Bool("delete") {
+		path += "?delete=true"
+	}
+	resp, err := s.HTTP.Post(s.ctx(), path, nil)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	var presenter KeyRotationPresenter
+	return s.renderAPIResponse(resp, &presenter, "Retired key rotation")
+}
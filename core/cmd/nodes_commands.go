@@ -47,7 +47,7 @@ type NodePresenter struct {
 
 // ToRow presents the EVMNodeResource as a slice of strings.
 func (p *NodePresenter) ToRow() []string {
-	return []string{p.Name, p.ChainID, p.State, p.Config}
+	return []string{p.Name, p.ChainID, p.State, p.Archive, p.Config}
 }
 
 // RenderTable implements TableRenderer
@@ -100,4 +100,4 @@ func (cli *nodeClient[P2]) IndexNodes(c *cli.Context) (err error) {
 	return cli.getPage(cli.path, c.Int("page"), &p)
 }
 
-var nodeHeaders = []string{"Name", "Chain ID", "State", "Config"}
+var nodeHeaders = []string{"Name", "Chain ID", "State", "Archive", "Config"}
@@ -37,8 +37,10 @@ import (
 
 	"github.com/smartcontractkit/chainlink/v2/core/build"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/txmgr"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/legacyevm"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/chaintype"
+	"github.com/smartcontractkit/chainlink/v2/core/services/periodicbackup"
 	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
 	"github.com/smartcontractkit/chainlink/v2/core/sessions"
 	"github.com/smartcontractkit/chainlink/v2/core/shutdown"
@@ -216,6 +218,26 @@ func initLocalSubCmds(s *Shell, safe bool) []cli.Command {
 					Before: s.validateDB,
 					Flags:  []cli.Flag{},
 				},
+				{
+					Name:   "restore",
+					Usage:  "Restore the database from a backup taken by Database.Backup. WARNING: This will REPLACE ALL DATA of the database referred to by CL_DATABASE_URL env variable or by the Database.URL field in a secrets TOML config. The node must not be running.",
+					Action: s.RestoreDatabase,
+					Before: s.validateDB,
+					Flags: []cli.Flag{
+						cli.StringFlag{
+							Name:  "from",
+							Usage: "name of the backup to restore, or `latest` for the most recent backup",
+						},
+						cli.StringFlag{
+							Name:  "password, p",
+							Usage: "text file holding the keystore password, required to restore encrypted backups",
+						},
+						cli.BoolFlag{
+							Name:  "yes, y",
+							Usage: "skip the confirmation prompt",
+						},
+					},
+				},
 				{
 					Name:   "rollback",
 					Usage:  "Roll back the database to a previous <version>. Rolls back a single migration if no version specified.",
@@ -278,6 +300,60 @@ func initLocalSubCmds(s *Shell, safe bool) []cli.Command {
 				},
 			},
 		},
+		{
+			Name:  "logpoller",
+			Usage: "Commands for seeding the log poller from the data of another node.",
+			Subcommands: []cli.Command{
+				{
+					Name:   "export",
+					Usage:  "Export the finalized blocks and logs of a block range to a checksummed snapshot file",
+					Action: s.ExportLogPollerData,
+					Flags: []cli.Flag{
+						cli.StringFlag{
+							Name:     "chain-id",
+							Usage:    "Chain ID of the EVM-based blockchain",
+							Required: true,
+						},
+						cli.Int64Flag{
+							Name:     "from",
+							Usage:    "Beginning of the block range to export",
+							Required: true,
+						},
+						cli.Int64Flag{
+							Name:     "to",
+							Usage:    "End of the block range to export (inclusive)",
+							Required: true,
+						},
+						cli.StringFlag{
+							Name:  "filter",
+							Usage: "OPTIONAL: only export the logs of the log poller filter with this name",
+						},
+						cli.StringFlag{
+							Name:     "output, o",
+							Usage:    "path of the snapshot file to create",
+							Required: true,
+						},
+					},
+				},
+				{
+					Name:   "import",
+					Usage:  "Import a snapshot file created by `logpoller export`, after verifying its checksum and its block hashes against the finalized blocks of the chain. The node must not be running.",
+					Action: s.ImportLogPollerData,
+					Flags: []cli.Flag{
+						cli.StringFlag{
+							Name:     "chain-id",
+							Usage:    "Chain ID of the EVM-based blockchain",
+							Required: true,
+						},
+						cli.StringFlag{
+							Name:     "file, f",
+							Usage:    "path of the snapshot file to import",
+							Required: true,
+						},
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -955,6 +1031,39 @@ func (s *Shell) MigrateDatabase(_ *cli.Context) error {
 	return nil
 }
 
+// RestoreDatabase restores the database from a backup taken by Database.Backup.
+func (s *Shell) RestoreDatabase(c *cli.Context) error {
+	ctx := s.ctx()
+	cfg := s.Config.Database()
+	parsed := cfg.URL()
+	if parsed.String() == "" {
+		return s.errorOut(errDBURLMissing)
+	}
+	from := c.String("from")
+	if from == "" {
+		return s.errorOut(errors.New("must pass the name of the backup to restore with --from, or --from=latest for the most recent backup"))
+	}
+
+	password := s.Config.Password().Keystore()
+	if passwordFile := c.String("password"); passwordFile != "" {
+		p, err := utils.PasswordFromFile(passwordFile)
+		if err != nil {
+			return s.errorOut(errors.Wrap(err, "error reading password from file"))
+		}
+		password = p
+	}
+
+	if !confirmAction(c) {
+		return nil
+	}
+
+	s.Logger.Infof("Restoring database: %#v", parsed.Redacted())
+	if err := periodicbackup.RestoreDatabase(ctx, parsed, s.Config.RootDir(), cfg.Backup(), password, from, s.Logger); err != nil {
+		return s.errorOut(err)
+	}
+	return nil
+}
+
 // RollbackDatabase rolls back the database via down migrations.
 func (s *Shell) RollbackDatabase(c *cli.Context) error {
 	ctx := s.ctx()
@@ -1294,3 +1403,115 @@ func (s *Shell) RemoveBlocks(c *cli.Context) error {
 
 	return nil
 }
+
+// ExportLogPollerData writes a snapshot of the log poller blocks and logs of a block range to a file. It only reads
+// from the database, so it may be run alongside the node.
+func (s *Shell) ExportLogPollerData(c *cli.Context) (err error) {
+	chainID, ok := new(big.Int).SetString(c.String("chain-id"), 10)
+	if !ok {
+		return s.errorOut(errors.New("invalid chain-id"))
+	}
+	from, to := c.Int64("from"), c.Int64("to")
+	if from < 0 || from > to {
+		return s.errorOut(errors.Errorf("invalid block range [%d, %d]", from, to))
+	}
+
+	if err = s.Config.Validate(); err != nil {
+		return s.errorOut(fmt.Errorf("error validating configuration: %+v", err))
+	}
+
+	ctx := s.ctx()
+	lggr := logger.Sugared(s.Logger.Named("ExportLogPollerData"))
+	db, err := pg.OpenUnlockedDB(ctx, s.Config.AppID(), s.Config.Database())
+	if err != nil {
+		return s.errorOut(errors.Wrap(err, "opening DB"))
+	}
+	defer lggr.ErrorIfFn(db.Close, "Error closing db")
+
+	chain, err := s.logPollerChain(ctx, db, chainID)
+	if err != nil {
+		return s.errorOut(err)
+	}
+
+	output := c.String("output")
+	f, err := os.OpenFile(output, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return s.errorOut(errors.Wrap(err, "failed to create snapshot file"))
+	}
+	defer func() {
+		err = multierr.Append(err, f.Close())
+		if err != nil {
+			err = multierr.Append(err, os.Remove(output))
+		}
+	}()
+
+	summary, err := chain.LogPoller().ExportSnapshot(ctx, f, from, to, c.String("filter"))
+	if err != nil {
+		return s.errorOut(errors.Wrap(err, "failed to export snapshot"))
+	}
+	lggr.Infow("Exported log poller snapshot", "file", output, "fromBlock", summary.FromBlock, "toBlock", summary.ToBlock,
+		"filter", summary.Filter, "blocks", summary.Blocks, "logs", summary.Logs, "checksum", summary.Checksum)
+	return nil
+}
+
+// ImportLogPollerData inserts the blocks and logs of a snapshot file created by ExportLogPollerData.
+func (s *Shell) ImportLogPollerData(c *cli.Context) error {
+	chainID, ok := new(big.Int).SetString(c.String("chain-id"), 10)
+	if !ok {
+		return s.errorOut(errors.New("invalid chain-id"))
+	}
+
+	if err := s.Config.Validate(); err != nil {
+		return s.errorOut(fmt.Errorf("error validating configuration: %+v", err))
+	}
+
+	f, err := os.Open(c.String("file"))
+	if err != nil {
+		return s.errorOut(errors.Wrap(err, "failed to open snapshot file"))
+	}
+	defer f.Close()
+
+	lggr := logger.Sugared(s.Logger.Named("ImportLogPollerData"))
+	ldb := pg.NewLockedDB(s.Config.AppID(), s.Config.Database(), s.Config.Database().Lock(), lggr)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go shutdown.HandleShutdown(func(sig string) {
+		cancel()
+		lggr.Info("received signal to stop - closing the database and releasing lock")
+	})
+
+	if err = ldb.Open(ctx); err != nil {
+		return s.errorOut(errors.Wrap(err, "opening db"))
+	}
+	defer lggr.ErrorIfFn(ldb.Close, "Error closing db")
+
+	chain, err := s.logPollerChain(ctx, ldb.DB(), chainID)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	// local commands do not start the application, so the client has to be dialed to verify the snapshot
+	if err = chain.Client().Dial(ctx); err != nil {
+		return s.errorOut(errors.Wrap(err, "failed to dial the chain client"))
+	}
+	defer chain.Client().Close()
+
+	summary, err := chain.LogPoller().ImportSnapshot(ctx, f)
+	if err != nil {
+		return s.errorOut(errors.Wrap(err, "failed to import snapshot"))
+	}
+	lggr.Infow("Imported log poller snapshot", "file", c.String("file"), "fromBlock", summary.FromBlock, "toBlock", summary.ToBlock,
+		"filter", summary.Filter, "blocks", summary.Blocks, "logs", summary.Logs, "checksum", summary.Checksum)
+	return nil
+}
+
+// logPollerChain returns the EVM chain with chainID of an application instantiated on db, if its log poller is enabled.
+func (s *Shell) logPollerChain(ctx context.Context, db *sqlx.DB, chainID *big.Int) (legacyevm.Chain, error) {
+	if !s.Config.Feature().LogPoller() {
+		return nil, errors.New("the log poller is not enabled")
+	}
+	app, err := s.AppFactory.NewApplication(ctx, s.Config, s.Logger, s.Registerer, db, s.KeyStoreAuthenticator)
+	if err != nil {
+		return nil, errors.Wrap(err, "fatal error instantiating application")
+	}
+	return app.GetRelayers().LegacyEVMChains().Get(chainID.String())
+}
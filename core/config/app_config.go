@@ -51,6 +51,7 @@ type AppConfig interface {
 	Password() Password
 	Prometheus() Prometheus
 	Pyroscope() Pyroscope
+	RemoteSigner() RemoteSigner
 	Sentry() Sentry
 	TelemetryIngress() TelemetryIngress
 	Threshold() Threshold
@@ -66,3 +67,18 @@ var (
 	DatabaseBackupModeLite DatabaseBackupMode = "lite"
 	DatabaseBackupModeFull DatabaseBackupMode = "full"
 )
+
+type DatabaseBackupSink string
+
+var (
+	DatabaseBackupSinkFilesystem DatabaseBackupSink = "filesystem"
+	DatabaseBackupSinkS3         DatabaseBackupSink = "s3"
+)
+
+type DatabaseBackupCompression string
+
+var (
+	DatabaseBackupCompressionNone DatabaseBackupCompression = "none"
+	DatabaseBackupCompressionGzip DatabaseBackupCompression = "gzip"
+	DatabaseBackupCompressionZstd DatabaseBackupCompression = "zstd"
+)
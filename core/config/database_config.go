@@ -6,13 +6,33 @@ import (
 )
 
 type Backup interface {
+	Compression() DatabaseBackupCompression
 	Dir() string
+	Encrypt() bool
 	Frequency() time.Duration
 	Mode() DatabaseBackupMode
 	OnVersionUpgrade() bool
+	Retention() BackupRetention
+	S3() BackupS3
+	Sink() DatabaseBackupSink
 	URL() *url.URL
 }
 
+type BackupRetention interface {
+	Daily() uint32
+	Weekly() uint32
+}
+
+type BackupS3 interface {
+	AccessKeyID() string
+	Bucket() string
+	Endpoint() *url.URL
+	Prefix() string
+	Region() string
+	SecretAccessKey() string
+	UsePathStyle() bool
+}
+
 type Lock interface {
 	LockingMode() string
 	LeaseDuration() time.Duration
@@ -14,6 +14,7 @@ import (
 	solcfg "github.com/smartcontractkit/chainlink-solana/pkg/solana/config"
 	stkcfg "github.com/smartcontractkit/chainlink-starknet/relayer/pkg/chainlink/config"
 
+	commonassets "github.com/smartcontractkit/chainlink-common/pkg/assets"
 	"github.com/smartcontractkit/chainlink-common/pkg/config"
 
 	"github.com/smartcontractkit/chainlink/v2/core/config/docs"
@@ -54,7 +55,9 @@ func TestDoc(t *testing.T) {
 		// clean up KeySpecific as a special case
 		require.Equal(t, 1, len(docDefaults.KeySpecific))
 		ks := toml.KeySpecific{Key: new(types.EIP55Address),
-			GasEstimator: toml.KeySpecificGasEstimator{PriceMax: new(assets.Wei)}}
+			GasEstimator:   toml.KeySpecificGasEstimator{PriceMax: new(assets.Wei)},
+			BalanceMonitor: toml.KeySpecificBalanceMonitor{EthFloor: new(assets.Wei), LinkFloor: new(commonassets.Link)},
+			PrivateRelay:   toml.KeySpecificPrivateRelay{Enabled: new(bool)}}
 		require.Equal(t, ks, docDefaults.KeySpecific[0])
 		docDefaults.KeySpecific = nil
 
@@ -102,9 +105,27 @@ func TestDoc(t *testing.T) {
 		docDefaults.Transactions.TransactionManagerV2.CustomURL = nil
 		docDefaults.Transactions.TransactionManagerV2.DualBroadcast = nil
 
+		// PrivateRelay has no fallback values
+		docDefaults.Transactions.PrivateRelay = toml.PrivateRelayConfig{}
+
+		// Simulation has no fallback values
+		docDefaults.Transactions.Simulation = toml.SimulationConfig{}
+
 		// Fallback DA oracle is not set
 		docDefaults.GasEstimator.DAOracle = toml.DAOracle{}
 
+		// Fallback finality provider is not set
+		docDefaults.HeadTracker.FinalityProvider = toml.FinalityProvider{}
+
+		// Composite estimator is only configured with Composite Mode
+		docDefaults.GasEstimator.Composite = toml.CompositeEstimator{}
+
+		// FeeAPI estimator is only configured with FeeAPI Mode
+		docDefaults.GasEstimator.FeeAPI = toml.FeeAPIEstimator{}
+
+		// BalanceMonitor alerts and top-ups have no global values
+		docDefaults.BalanceMonitor = toml.BalanceMonitor{Enabled: docDefaults.BalanceMonitor.Enabled}
+
 		assertTOML(t, fallbackDefaults, docDefaults)
 	})
 
@@ -0,0 +1,7 @@
+package config
+
+import "net/url"
+
+type RemoteSigner interface {
+	URL() *url.URL
+}
@@ -7,6 +7,7 @@ import (
 	"net/url"
 	"reflect"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/google/uuid"
@@ -116,13 +117,14 @@ func (c *Core) ValidateConfig() (err error) {
 }
 
 type Secrets struct {
-	Database   DatabaseSecrets          `toml:",omitempty"`
-	Password   Passwords                `toml:",omitempty"`
-	WebServer  WebServerSecrets         `toml:",omitempty"`
-	Pyroscope  PyroscopeSecrets         `toml:",omitempty"`
-	Prometheus PrometheusSecrets        `toml:",omitempty"`
-	Mercury    MercurySecrets           `toml:",omitempty"`
-	Threshold  ThresholdKeyShareSecrets `toml:",omitempty"`
+	Database     DatabaseSecrets          `toml:",omitempty"`
+	Password     Passwords                `toml:",omitempty"`
+	WebServer    WebServerSecrets         `toml:",omitempty"`
+	Pyroscope    PyroscopeSecrets         `toml:",omitempty"`
+	Prometheus   PrometheusSecrets        `toml:",omitempty"`
+	Mercury      MercurySecrets           `toml:",omitempty"`
+	Threshold    ThresholdKeyShareSecrets `toml:",omitempty"`
+	RemoteSigner RemoteSignerSecrets      `toml:",omitempty"`
 }
 
 func dbURLPasswordComplexity(err error) string {
@@ -133,6 +135,22 @@ type DatabaseSecrets struct {
 	URL                  *models.SecretURL
 	BackupURL            *models.SecretURL
 	AllowSimplePasswords *bool
+
+	BackupS3 DatabaseBackupS3Secrets `toml:",omitempty"`
+}
+
+type DatabaseBackupS3Secrets struct {
+	AccessKeyID     *models.Secret
+	SecretAccessKey *models.Secret
+}
+
+func (d *DatabaseBackupS3Secrets) setFrom(f *DatabaseBackupS3Secrets) {
+	if v := f.AccessKeyID; v != nil {
+		d.AccessKeyID = v
+	}
+	if v := f.SecretAccessKey; v != nil {
+		d.SecretAccessKey = v
+	}
 }
 
 func validateDBURL(dbURI url.URL) error {
@@ -197,6 +215,7 @@ func (d *DatabaseSecrets) SetFrom(f *DatabaseSecrets) (err error) {
 	if v := f.URL; v != nil {
 		d.URL = v
 	}
+	d.BackupS3.setFrom(&f.BackupS3)
 	return nil
 }
 
@@ -434,6 +453,12 @@ type DatabaseBackup struct {
 	Frequency        *commonconfig.Duration
 	Mode             *config.DatabaseBackupMode
 	OnVersionUpgrade *bool
+	Sink             *config.DatabaseBackupSink
+	Compression      *config.DatabaseBackupCompression
+	Encrypt          *bool
+
+	Retention DatabaseBackupRetention `toml:",omitempty"`
+	S3        DatabaseBackupS3        `toml:",omitempty"`
 }
 
 func (d *DatabaseBackup) setFrom(f *DatabaseBackup) {
@@ -449,6 +474,85 @@ func (d *DatabaseBackup) setFrom(f *DatabaseBackup) {
 	if v := f.OnVersionUpgrade; v != nil {
 		d.OnVersionUpgrade = v
 	}
+	if v := f.Sink; v != nil {
+		d.Sink = v
+	}
+	if v := f.Compression; v != nil {
+		d.Compression = v
+	}
+	if v := f.Encrypt; v != nil {
+		d.Encrypt = v
+	}
+	d.Retention.setFrom(&f.Retention)
+	d.S3.setFrom(&f.S3)
+}
+
+func (d *DatabaseBackup) ValidateConfig() (err error) {
+	if d.Compression != nil {
+		switch *d.Compression {
+		case config.DatabaseBackupCompressionNone, config.DatabaseBackupCompressionGzip, config.DatabaseBackupCompressionZstd:
+		default:
+			err = multierr.Append(err, configutils.ErrInvalid{Name: "Compression", Value: *d.Compression, Msg: "must be one of: none, gzip, zstd"})
+		}
+	}
+	if d.Sink != nil {
+		switch *d.Sink {
+		case config.DatabaseBackupSinkFilesystem:
+		case config.DatabaseBackupSinkS3:
+			if d.S3.Bucket == nil || *d.S3.Bucket == "" {
+				err = multierr.Append(err, configutils.ErrMissing{Name: "S3.Bucket", Msg: "must be set when Sink is s3"})
+			}
+			if d.S3.Region == nil || *d.S3.Region == "" {
+				err = multierr.Append(err, configutils.ErrMissing{Name: "S3.Region", Msg: "must be set when Sink is s3"})
+			}
+		default:
+			err = multierr.Append(err, configutils.ErrInvalid{Name: "Sink", Value: *d.Sink, Msg: "must be one of: filesystem, s3"})
+		}
+	}
+	return
+}
+
+type DatabaseBackupRetention struct {
+	Daily  *uint32
+	Weekly *uint32
+}
+
+func (r *DatabaseBackupRetention) setFrom(f *DatabaseBackupRetention) {
+	if v := f.Daily; v != nil {
+		r.Daily = v
+	}
+	if v := f.Weekly; v != nil {
+		r.Weekly = v
+	}
+}
+
+// DatabaseBackupS3
+//
+// Note: credentials are stored in Secrets.Database.BackupS3
+type DatabaseBackupS3 struct {
+	Endpoint     *commonconfig.URL
+	Bucket       *string
+	Prefix       *string
+	Region       *string
+	UsePathStyle *bool
+}
+
+func (s *DatabaseBackupS3) setFrom(f *DatabaseBackupS3) {
+	if v := f.Endpoint; v != nil {
+		s.Endpoint = v
+	}
+	if v := f.Bucket; v != nil {
+		s.Bucket = v
+	}
+	if v := f.Prefix; v != nil {
+		s.Prefix = v
+	}
+	if v := f.Region; v != nil {
+		s.Region = v
+	}
+	if v := f.UsePathStyle; v != nil {
+		s.UsePathStyle = v
+	}
 }
 
 type TelemetryIngress struct {
@@ -608,7 +712,9 @@ type WebServer struct {
 	ListenIP                *net.IP
 
 	LDAP      WebServerLDAP      `toml:",omitempty"`
+	OIDC      WebServerOIDC      `toml:",omitempty"`
 	MFA       WebServerMFA       `toml:",omitempty"`
+	APITokens WebServerAPITokens `toml:",omitempty"`
 	RateLimit WebServerRateLimit `toml:",omitempty"`
 	TLS       WebServerTLS       `toml:",omitempty"`
 }
@@ -652,12 +758,19 @@ func (w *WebServer) setFrom(f *WebServer) {
 	}
 
 	w.LDAP.setFrom(&f.LDAP)
+	w.OIDC.setFrom(&f.OIDC)
 	w.MFA.setFrom(&f.MFA)
+	w.APITokens.setFrom(&f.APITokens)
 	w.RateLimit.setFrom(&f.RateLimit)
 	w.TLS.setFrom(&f.TLS)
 }
 
 func (w *WebServer) ValidateConfig() (err error) {
+	// Validate OIDC fields when authentication method is OIDCAuth
+	if *w.AuthenticationMethod == string(sessions.OIDCAuth) {
+		return w.OIDC.validateConfig()
+	}
+
 	// Validate LDAP fields when authentication method is LDAPAuth
 	if *w.AuthenticationMethod != string(sessions.LDAPAuth) {
 		return
@@ -705,6 +818,23 @@ func (w *WebServerMFA) setFrom(f *WebServerMFA) {
 	}
 }
 
+type WebServerAPITokens struct {
+	MaxExpiry *commonconfig.Duration
+}
+
+func (w *WebServerAPITokens) setFrom(f *WebServerAPITokens) {
+	if v := f.MaxExpiry; v != nil {
+		w.MaxExpiry = v
+	}
+}
+
+func (w *WebServerAPITokens) ValidateConfig() (err error) {
+	if w.MaxExpiry != nil && w.MaxExpiry.Duration() <= 0 {
+		err = multierr.Append(err, configutils.ErrInvalid{Name: "MaxExpiry", Value: w.MaxExpiry.Duration(), Msg: "must be greater than 0"})
+	}
+	return
+}
+
 type WebServerRateLimit struct {
 	Authenticated         *int64
 	AuthenticatedPeriod   *commonconfig.Duration
@@ -834,6 +964,102 @@ func (w *WebServerLDAP) setFrom(f *WebServerLDAP) {
 	}
 }
 
+type WebServerOIDC struct {
+	IssuerURL       *string
+	ClientID        *string
+	RedirectURL     *string
+	Scopes          *[]string
+	Audience        *string
+	EmailClaim      *string
+	GroupsClaim     *string
+	AdminUserGroup  *string
+	EditUserGroup   *string
+	RunUserGroup    *string
+	ReadUserGroup   *string
+	SessionTimeout  *commonconfig.Duration
+	ProviderTimeout *commonconfig.Duration
+}
+
+func (w *WebServerOIDC) setFrom(f *WebServerOIDC) {
+	if v := f.IssuerURL; v != nil {
+		w.IssuerURL = v
+	}
+	if v := f.ClientID; v != nil {
+		w.ClientID = v
+	}
+	if v := f.RedirectURL; v != nil {
+		w.RedirectURL = v
+	}
+	if v := f.Scopes; v != nil {
+		w.Scopes = v
+	}
+	if v := f.Audience; v != nil {
+		w.Audience = v
+	}
+	if v := f.EmailClaim; v != nil {
+		w.EmailClaim = v
+	}
+	if v := f.GroupsClaim; v != nil {
+		w.GroupsClaim = v
+	}
+	if v := f.AdminUserGroup; v != nil {
+		w.AdminUserGroup = v
+	}
+	if v := f.EditUserGroup; v != nil {
+		w.EditUserGroup = v
+	}
+	if v := f.RunUserGroup; v != nil {
+		w.RunUserGroup = v
+	}
+	if v := f.ReadUserGroup; v != nil {
+		w.ReadUserGroup = v
+	}
+	if v := f.SessionTimeout; v != nil {
+		w.SessionTimeout = v
+	}
+	if v := f.ProviderTimeout; v != nil {
+		w.ProviderTimeout = v
+	}
+}
+
+func (w *WebServerOIDC) validateConfig() (err error) {
+	required := []struct {
+		name  string
+		value *string
+		url   bool
+	}{
+		{"IssuerURL", w.IssuerURL, true},
+		{"ClientID", w.ClientID, false},
+		{"RedirectURL", w.RedirectURL, true},
+		{"EmailClaim", w.EmailClaim, false},
+		{"GroupsClaim", w.GroupsClaim, false},
+		{"AdminUserGroup", w.AdminUserGroup, false},
+		{"EditUserGroup", w.EditUserGroup, false},
+		{"RunUserGroup", w.RunUserGroup, false},
+		{"ReadUserGroup", w.ReadUserGroup, false},
+	}
+	for _, f := range required {
+		if f.value == nil || *f.value == "" {
+			err = multierr.Append(err, configutils.ErrEmpty{Name: "OIDC." + f.name, Msg: "must be provided and non-empty"})
+			continue
+		}
+		if f.url {
+			if u, perr := url.Parse(*f.value); perr != nil || u.Scheme == "" || u.Host == "" {
+				err = multierr.Append(err, configutils.ErrInvalid{Name: "OIDC." + f.name, Value: *f.value, Msg: "must be an absolute URL"})
+			}
+		}
+	}
+	if w.Scopes == nil {
+		err = multierr.Append(err, configutils.ErrMissing{Name: "OIDC.Scopes", Msg: "must include 'openid'"})
+	} else if !slices.Contains(*w.Scopes, "openid") {
+		err = multierr.Append(err, configutils.ErrInvalid{Name: "OIDC.Scopes", Value: *w.Scopes, Msg: "must include 'openid'"})
+	}
+	if w.Audience != nil && *w.Audience != "" && w.ClientID != nil && *w.Audience == *w.ClientID {
+		err = multierr.Append(err, configutils.ErrInvalid{Name: "OIDC.Audience", Value: *w.Audience, Msg: "must differ from OIDC.ClientID, so that ID tokens are not accepted as bearer tokens"})
+	}
+	return err
+}
+
 type WebServerLDAPSecrets struct {
 	ServerAddress     *models.SecretURL
 	ReadOnlyUserLogin *models.Secret
@@ -852,12 +1078,24 @@ func (w *WebServerLDAPSecrets) setFrom(f *WebServerLDAPSecrets) {
 	}
 }
 
+type WebServerOIDCSecrets struct {
+	ClientSecret *models.Secret
+}
+
+func (w *WebServerOIDCSecrets) setFrom(f *WebServerOIDCSecrets) {
+	if v := f.ClientSecret; v != nil {
+		w.ClientSecret = v
+	}
+}
+
 type WebServerSecrets struct {
 	LDAP WebServerLDAPSecrets `toml:",omitempty"`
+	OIDC WebServerOIDCSecrets `toml:",omitempty"`
 }
 
 func (w *WebServerSecrets) SetFrom(f *WebServerSecrets) error {
 	w.LDAP.setFrom(&f.LDAP)
+	w.OIDC.setFrom(&f.OIDC)
 	return nil
 }
 
@@ -1613,6 +1851,43 @@ func (c *Capabilities) setFrom(f *Capabilities) {
 	c.GatewayConnector.setFrom(&f.GatewayConnector)
 }
 
+type RemoteSignerSecrets struct {
+	URL *models.SecretURL
+}
+
+func (r *RemoteSignerSecrets) SetFrom(f *RemoteSignerSecrets) (err error) {
+	err = r.validateMerge(f)
+	if err != nil {
+		return err
+	}
+
+	if v := f.URL; v != nil {
+		r.URL = v
+	}
+
+	return nil
+}
+
+func (r *RemoteSignerSecrets) validateMerge(f *RemoteSignerSecrets) (err error) {
+	if r.URL != nil && f.URL != nil {
+		err = multierr.Append(err, configutils.ErrOverride{Name: "URL"})
+	}
+
+	return err
+}
+
+func (r *RemoteSignerSecrets) ValidateConfig() (err error) {
+	if r.URL == nil {
+		return
+	}
+	switch r.URL.URL().Scheme {
+	case "http", "https":
+	default:
+		err = multierr.Append(err, configutils.ErrInvalid{Name: "URL", Value: r.URL.URL().Scheme, Msg: "must be http or https"})
+	}
+	return
+}
+
 type ThresholdKeyShareSecrets struct {
 	ThresholdKeyShare *models.Secret
 }
@@ -532,6 +532,57 @@ func TestTracing_ValidateMode(t *testing.T) {
 	}
 }
 
+func TestWebServer_ValidateOIDC(t *testing.T) {
+	valid := func() WebServerOIDC {
+		return WebServerOIDC{
+			IssuerURL:      ptr("https://idp.example.com"),
+			ClientID:       ptr("chainlink-node"),
+			RedirectURL:    ptr("https://node.example.com/oidc/callback"),
+			Scopes:         &[]string{"openid", "email"},
+			EmailClaim:     ptr("email"),
+			GroupsClaim:    ptr("groups"),
+			AdminUserGroup: ptr("NodeAdmins"),
+			EditUserGroup:  ptr("NodeEditors"),
+			RunUserGroup:   ptr("NodeRunners"),
+			ReadUserGroup:  ptr("NodeReadOnly"),
+		}
+	}
+	tests := []struct {
+		name   string
+		modify func(*WebServerOIDC)
+		errMsg string
+	}{
+		{name: "valid", modify: func(*WebServerOIDC) {}},
+		{name: "missing issuer", modify: func(o *WebServerOIDC) { o.IssuerURL = ptr("") },
+			errMsg: "OIDC.IssuerURL: empty: must be provided and non-empty"},
+		{name: "relative redirect", modify: func(o *WebServerOIDC) { o.RedirectURL = ptr("/oidc/callback") },
+			errMsg: "OIDC.RedirectURL: invalid value (/oidc/callback): must be an absolute URL"},
+		{name: "missing openid scope", modify: func(o *WebServerOIDC) { o.Scopes = &[]string{"email"} },
+			errMsg: "OIDC.Scopes: invalid value ([email]): must include 'openid'"},
+		{name: "distinct audience", modify: func(o *WebServerOIDC) { o.Audience = ptr("chainlink-api") }},
+		{name: "audience of client", modify: func(o *WebServerOIDC) { o.Audience = ptr("chainlink-node") },
+			errMsg: "OIDC.Audience: invalid value (chainlink-node): must differ from OIDC.ClientID, so that ID tokens are not accepted as bearer tokens"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oidc := valid()
+			tt.modify(&oidc)
+			ws := WebServer{AuthenticationMethod: ptr("oidc"), OIDC: oidc}
+			err := ws.ValidateConfig()
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.errMsg)
+		})
+	}
+
+	// OIDC fields are not validated unless OIDC is the authentication method
+	ws := WebServer{AuthenticationMethod: ptr("local")}
+	assert.NoError(t, ws.ValidateConfig())
+}
+
 func TestMercuryTLS_ValidateTLSCertPath(t *testing.T) {
 	tests := []struct {
 		name        string
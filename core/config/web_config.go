@@ -32,6 +32,10 @@ type MFA interface {
 	RPOrigin() string
 }
 
+type APITokens interface {
+	MaxExpiry() time.Duration
+}
+
 type LDAP interface {
 	ServerAddress() string
 	ReadOnlyUserLogin() string
@@ -55,6 +59,23 @@ type LDAP interface {
 	UpstreamSyncRateLimit() commonconfig.Duration
 }
 
+type OIDC interface {
+	IssuerURL() string
+	ClientID() string
+	ClientSecret() string
+	RedirectURL() string
+	Scopes() []string
+	Audience() string
+	EmailClaim() string
+	GroupsClaim() string
+	AdminUserGroup() string
+	EditUserGroup() string
+	RunUserGroup() string
+	ReadUserGroup() string
+	SessionTimeout() commonconfig.Duration
+	ProviderTimeout() time.Duration
+}
+
 type WebServer interface {
 	AuthenticationMethod() string
 	AllowOrigins() string
@@ -73,5 +94,7 @@ type WebServer interface {
 	TLS() TLS
 	RateLimit() RateLimit
 	MFA() MFA
+	APITokens() APITokens
 	LDAP() LDAP
+	OIDC() OIDC
 }
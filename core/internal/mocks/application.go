@@ -61,6 +61,51 @@ func (_m *Application) EXPECT() *Application_Expecter {
 	return &Application_Expecter{mock: &_m.Mock}
 }
 
+// APITokensORM provides a mock function with no fields
+func (_m *Application) APITokensORM() sessions.APITokensORM {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for APITokensORM")
+	}
+
+	var r0 sessions.APITokensORM
+	if rf, ok := ret.Get(0).(func() sessions.APITokensORM); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(sessions.APITokensORM)
+	}
+
+	return r0
+}
+
+// Application_APITokensORM_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'APITokensORM'
+type Application_APITokensORM_Call struct {
+	*mock.Call
+}
+
+// APITokensORM is a helper method to define mock.On call
+func (_e *Application_Expecter) APITokensORM() *Application_APITokensORM_Call {
+	return &Application_APITokensORM_Call{Call: _e.mock.On("APITokensORM")}
+}
+
+func (_c *Application_APITokensORM_Call) Run(run func()) *Application_APITokensORM_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Application_APITokensORM_Call) Return(_a0 sessions.APITokensORM) *Application_APITokensORM_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Application_APITokensORM_Call) RunAndReturn(run func() sessions.APITokensORM) *Application_APITokensORM_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AddJobV2 provides a mock function with given fields: ctx, _a1
 func (_m *Application) AddJobV2(ctx context.Context, _a1 *job.Job) error {
 	ret := _m.Called(ctx, _a1)
@@ -249,6 +294,51 @@ func (_c *Application_BridgeORM_Call) RunAndReturn(run func() bridges.ORM) *Appl
 	return _c
 }
 
+// CustomRolesORM provides a mock function with no fields
+func (_m *Application) CustomRolesORM() sessions.CustomRolesORM {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for CustomRolesORM")
+	}
+
+	var r0 sessions.CustomRolesORM
+	if rf, ok := ret.Get(0).(func() sessions.CustomRolesORM); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(sessions.CustomRolesORM)
+	}
+
+	return r0
+}
+
+// Application_CustomRolesORM_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CustomRolesORM'
+type Application_CustomRolesORM_Call struct {
+	*mock.Call
+}
+
+// CustomRolesORM is a helper method to define mock.On call
+func (_e *Application_Expecter) CustomRolesORM() *Application_CustomRolesORM_Call {
+	return &Application_CustomRolesORM_Call{Call: _e.mock.On("CustomRolesORM")}
+}
+
+func (_c *Application_CustomRolesORM_Call) Run(run func()) *Application_CustomRolesORM_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Application_CustomRolesORM_Call) Return(_a0 sessions.CustomRolesORM) *Application_CustomRolesORM_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Application_CustomRolesORM_Call) RunAndReturn(run func() sessions.CustomRolesORM) *Application_CustomRolesORM_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteJob provides a mock function with given fields: ctx, jobID
 func (_m *Application) DeleteJob(ctx context.Context, jobID int32) error {
 	ret := _m.Called(ctx, jobID)
@@ -1248,6 +1338,66 @@ func (_c *Application_ReplayFromBlock_Call) RunAndReturn(run func(*big.Int, uint
 	return _c
 }
 
+// ReplayPipelineRun provides a mock function with given fields: ctx, runID, opts
+func (_m *Application) ReplayPipelineRun(ctx context.Context, runID int64, opts pipeline.ReplayOptions) (*pipeline.RunReplay, error) {
+	ret := _m.Called(ctx, runID, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplayPipelineRun")
+	}
+
+	var r0 *pipeline.RunReplay
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, pipeline.ReplayOptions) (*pipeline.RunReplay, error)); ok {
+		return rf(ctx, runID, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, pipeline.ReplayOptions) *pipeline.RunReplay); ok {
+		r0 = rf(ctx, runID, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pipeline.RunReplay)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, pipeline.ReplayOptions) error); ok {
+		r1 = rf(ctx, runID, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Application_ReplayPipelineRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplayPipelineRun'
+type Application_ReplayPipelineRun_Call struct {
+	*mock.Call
+}
+
+// ReplayPipelineRun is a helper method to define mock.On call
+//   - ctx context.Context
+//   - runID int64
+//   - opts pipeline.ReplayOptions
+func (_e *Application_Expecter) ReplayPipelineRun(ctx interface{}, runID interface{}, opts interface{}) *Application_ReplayPipelineRun_Call {
+	return &Application_ReplayPipelineRun_Call{Call: _e.mock.On("ReplayPipelineRun", ctx, runID, opts)}
+}
+
+func (_c *Application_ReplayPipelineRun_Call) Run(run func(ctx context.Context, runID int64, opts pipeline.ReplayOptions)) *Application_ReplayPipelineRun_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(pipeline.ReplayOptions))
+	})
+	return _c
+}
+
+func (_c *Application_ReplayPipelineRun_Call) Return(_a0 *pipeline.RunReplay, _a1 error) *Application_ReplayPipelineRun_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Application_ReplayPipelineRun_Call) RunAndReturn(run func(context.Context, int64, pipeline.ReplayOptions) (*pipeline.RunReplay, error)) *Application_ReplayPipelineRun_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ResumeJobV2 provides a mock function with given fields: ctx, taskID, result
 func (_m *Application) ResumeJobV2(ctx context.Context, taskID uuid.UUID, result pipeline.Result) error {
 	ret := _m.Called(ctx, taskID, result)
@@ -20,6 +20,15 @@ const (
 	APITokenDeleteAttemptPasswordMismatch EventID = "API_TOKEN_DELETE_ATTEMPT_PASSWORD_MISMATCH"
 	APITokenDeleted                       EventID = "API_TOKEN_DELETED"
 
+	ScopedAPITokenCreated  EventID = "SCOPED_API_TOKEN_CREATED"
+	ScopedAPITokenRevoked  EventID = "SCOPED_API_TOKEN_REVOKED"
+	ScopedAPITokenUsed     EventID = "SCOPED_API_TOKEN_USED"
+	ScopedAPITokenRejected EventID = "SCOPED_API_TOKEN_REJECTED"
+
+	CustomRoleCreated EventID = "CUSTOM_ROLE_CREATED"
+	CustomRoleUpdated EventID = "CUSTOM_ROLE_UPDATED"
+	CustomRoleDeleted EventID = "CUSTOM_ROLE_DELETED"
+
 	FeedsManCreated EventID = "FEEDS_MAN_CREATED"
 	FeedsManUpdated EventID = "FEEDS_MAN_UPDATED"
 
@@ -51,6 +60,9 @@ const (
 	KeyExported EventID = "KEY_EXPORTED"
 	KeyDeleted  EventID = "KEY_DELETED"
 
+	KeyRotationStarted EventID = "KEY_ROTATION_STARTED"
+	KeyRotationRetired EventID = "KEY_ROTATION_RETIRED"
+
 	EthTransactionCreated    EventID = "ETH_TRANSACTION_CREATED"
 	CosmosTransactionCreated EventID = "COSMOS_TRANSACTION_CREATED"
 	SolanaTransactionCreated EventID = "SOLANA_TRANSACTION_CREATED"
@@ -91,4 +103,8 @@ const (
 	EnvNoncriticalEnvDumped EventID = "ENV_NONCRITICAL_ENV_DUMPED"
 
 	UnauthedRunResumed EventID = "UNAUTHED_RUN_RESUMED"
+
+	S4QuotaReset EventID = "S4_QUOTA_RESET"
+
+	BalanceMonitorTopUp EventID = "BALANCE_MONITOR_TOP_UP"
 )
@@ -77,8 +77,11 @@ import (
 	workflowstore "github.com/smartcontractkit/chainlink/v2/core/services/workflows/store"
 	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/syncer"
 	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions/apitokens"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions/customroles"
 	"github.com/smartcontractkit/chainlink/v2/core/sessions/ldapauth"
 	"github.com/smartcontractkit/chainlink/v2/core/sessions/localauth"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions/oidcauth"
 	"github.com/smartcontractkit/chainlink/v2/core/static"
 	evmtypes "github.com/smartcontractkit/chainlink/v2/evm/types"
 	evmutils "github.com/smartcontractkit/chainlink/v2/evm/utils"
@@ -114,11 +117,15 @@ type Application interface {
 	BridgeORM() bridges.ORM
 	BasicAdminUsersORM() sessions.BasicAdminUsersORM
 	AuthenticationProvider() sessions.AuthenticationProvider
+	CustomRolesORM() sessions.CustomRolesORM
+	APITokensORM() sessions.APITokensORM
 	TxmStorageService() txmgr.EvmTxStore
 	AddJobV2(ctx context.Context, job *job.Job) error
 	DeleteJob(ctx context.Context, jobID int32) error
 	RunWebhookJobV2(ctx context.Context, jobUUID uuid.UUID, requestBody string, meta jsonserializable.JSONSerializable) (int64, error)
 	ResumeJobV2(ctx context.Context, taskID uuid.UUID, result pipeline.Result) error
+	// ReplayPipelineRun re-executes a finished pipeline run in-memory and diffs it against the recorded run.
+	ReplayPipelineRun(ctx context.Context, runID int64, opts pipeline.ReplayOptions) (*pipeline.RunReplay, error)
 	// Testing only
 	RunJobV2(ctx context.Context, jobID int32, meta map[string]interface{}) (int64, error)
 
@@ -152,6 +159,8 @@ type ChainlinkApplication struct {
 	bridgeORM                bridges.ORM
 	localAdminUsersORM       sessions.BasicAdminUsersORM
 	authenticationProvider   sessions.AuthenticationProvider
+	customRolesORM           sessions.CustomRolesORM
+	apiTokensORM             sessions.APITokensORM
 	txmStorageService        txmgr.EvmTxStore
 	FeedsService             feeds.Service
 	webhookJobRunner         webhook.JobRunner
@@ -460,11 +469,13 @@ func NewApplication(opts ApplicationOpts) (Application, error) {
 	telemetryManager := telemetry.NewManager(cfg.TelemetryIngress(), keyStore.CSA(), globalLogger)
 	srvcs = append(srvcs, telemetryManager)
 
+	srvcs = append(srvcs, keystore.NewRotationRetirer(keyStore.Rotation(), globalLogger))
+
 	backupCfg := cfg.Database().Backup()
 	if backupCfg.Mode() != config.DatabaseBackupModeNone && backupCfg.Frequency() > 0 {
 		globalLogger.Infow("DatabaseBackup: periodic database backups are enabled", "frequency", backupCfg.Frequency())
 
-		databaseBackup, err := periodicbackup.NewDatabaseBackup(cfg.Database().URL(), cfg.RootDir(), backupCfg, globalLogger)
+		databaseBackup, err := periodicbackup.NewDatabaseBackup(cfg.Database().URL(), cfg.RootDir(), backupCfg, cfg.Password().Keystore(), globalLogger)
 		if err != nil {
 			return nil, errors.Wrap(err, "NewApplication: failed to initialize database backup")
 		}
@@ -497,7 +508,7 @@ func NewApplication(opts ApplicationOpts) (Application, error) {
 	localAdminUsersORM := localauth.NewORM(opts.DS, cfg.WebServer().SessionTimeout().Duration(), globalLogger, auditLogger)
 
 	// Initialize Sessions ORM based on environment configured authenticator
-	// localDB auth or remote LDAP auth
+	// localDB auth, remote LDAP auth or OIDC identity provider auth
 	authMethod := cfg.WebServer().AuthenticationMethod()
 	var authenticationProvider sessions.AuthenticationProvider
 	var sessionReaper *utils.SleeperTask
@@ -514,11 +525,20 @@ func NewApplication(opts ApplicationOpts) (Application, error) {
 		syncer := ldapauth.NewLDAPServerStateSyncer(opts.DS, cfg.WebServer().LDAP(), globalLogger)
 		srvcs = append(srvcs, syncer)
 		sessionReaper = utils.NewSleeperTaskCtx(syncer)
+	case sessions.OIDCAuth:
+		var err error
+		authenticationProvider, err = oidcauth.NewOIDCAuthenticator(
+			opts.DS, cfg.WebServer().OIDC(), cfg.Insecure().DevWebServer(), globalLogger, auditLogger,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "NewApplication: failed to initialize OIDC Authentication module")
+		}
+		sessionReaper = oidcauth.NewSessionReaper(opts.DS, cfg.WebServer(), globalLogger)
 	case sessions.LocalAuth:
 		authenticationProvider = localauth.NewORM(opts.DS, cfg.WebServer().SessionTimeout().Duration(), globalLogger, auditLogger)
 		sessionReaper = localauth.NewSessionReaper(opts.DS, cfg.WebServer(), globalLogger)
 	default:
-		return nil, errors.Errorf("NewApplication: Unexpected 'AuthenticationMethod': %s supported values: %s, %s", authMethod, sessions.LocalAuth, sessions.LDAPAuth)
+		return nil, errors.Errorf("NewApplication: Unexpected 'AuthenticationMethod': %s supported values: %s, %s, %s", authMethod, sessions.LocalAuth, sessions.LDAPAuth, sessions.OIDCAuth)
 	}
 
 	var (
@@ -784,6 +804,8 @@ func NewApplication(opts ApplicationOpts) (Application, error) {
 		bridgeORM:                bridgeORM,
 		localAdminUsersORM:       localAdminUsersORM,
 		authenticationProvider:   authenticationProvider,
+		customRolesORM:           customroles.NewORM(opts.DS),
+		apiTokensORM:             apitokens.NewORM(opts.DS),
 		txmStorageService:        txmORM,
 		FeedsService:             feedsService,
 		Config:                   cfg,
@@ -970,6 +992,14 @@ func (app *ChainlinkApplication) AuthenticationProvider() sessions.Authenticatio
 	return app.authenticationProvider
 }
 
+func (app *ChainlinkApplication) CustomRolesORM() sessions.CustomRolesORM {
+	return app.customRolesORM
+}
+
+func (app *ChainlinkApplication) APITokensORM() sessions.APITokensORM {
+	return app.apiTokensORM
+}
+
 // TODO BCF-2516 remove this all together remove EVM specifics
 func (app *ChainlinkApplication) EVMORM() evmtypes.Configs {
 	return app.GetRelayers().LegacyEVMChains().ChainNodeConfigs()
@@ -1091,6 +1121,18 @@ func (app *ChainlinkApplication) ResumeJobV2(
 	return app.pipelineRunner.ResumeRun(ctx, taskID, result.Value, result.Error)
 }
 
+func (app *ChainlinkApplication) ReplayPipelineRun(
+	ctx context.Context,
+	runID int64,
+	opts pipeline.ReplayOptions,
+) (*pipeline.RunReplay, error) {
+	run, err := app.pipelineORM.FindRun(ctx, runID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "run ID %v", runID)
+	}
+	return app.pipelineRunner.ReplayRun(ctx, run, opts)
+}
+
 func (app *ChainlinkApplication) GetFeedsService() feeds.Service {
 	return app.FeedsService
 }
@@ -397,6 +397,10 @@ func (s *Secrets) SetFrom(f *Secrets) (err error) {
 		err = multierr.Append(err, commonconfig.NamedMultiErrorList(err2, "Threshold"))
 	}
 
+	if err2 := s.RemoteSigner.SetFrom(&f.RemoteSigner); err2 != nil {
+		err = multierr.Append(err, commonconfig.NamedMultiErrorList(err2, "RemoteSigner"))
+	}
+
 	_, err = commonconfig.MultiErrorList(err)
 
 	return err
@@ -33,6 +33,89 @@ func (b *backupConfig) URL() *url.URL {
 	return b.s.BackupURL.URL()
 }
 
+func (b *backupConfig) Sink() config.DatabaseBackupSink {
+	return *b.c.Sink
+}
+
+func (b *backupConfig) Compression() config.DatabaseBackupCompression {
+	return *b.c.Compression
+}
+
+func (b *backupConfig) Encrypt() bool {
+	return *b.c.Encrypt
+}
+
+func (b *backupConfig) Retention() config.BackupRetention {
+	return &backupRetentionConfig{c: b.c.Retention}
+}
+
+func (b *backupConfig) S3() config.BackupS3 {
+	return &backupS3Config{c: b.c.S3, s: b.s.BackupS3}
+}
+
+type backupRetentionConfig struct {
+	c toml.DatabaseBackupRetention
+}
+
+func (r *backupRetentionConfig) Daily() uint32 {
+	return *r.c.Daily
+}
+
+func (r *backupRetentionConfig) Weekly() uint32 {
+	return *r.c.Weekly
+}
+
+type backupS3Config struct {
+	c toml.DatabaseBackupS3
+	s toml.DatabaseBackupS3Secrets
+}
+
+func (b *backupS3Config) Endpoint() *url.URL {
+	if b.c.Endpoint == nil {
+		return nil
+	}
+	return b.c.Endpoint.URL()
+}
+
+func (b *backupS3Config) Bucket() string {
+	if b.c.Bucket == nil {
+		return ""
+	}
+	return *b.c.Bucket
+}
+
+func (b *backupS3Config) Prefix() string {
+	if b.c.Prefix == nil {
+		return ""
+	}
+	return *b.c.Prefix
+}
+
+func (b *backupS3Config) Region() string {
+	if b.c.Region == nil {
+		return ""
+	}
+	return *b.c.Region
+}
+
+func (b *backupS3Config) UsePathStyle() bool {
+	return b.c.UsePathStyle != nil && *b.c.UsePathStyle
+}
+
+func (b *backupS3Config) AccessKeyID() string {
+	if b.s.AccessKeyID == nil {
+		return ""
+	}
+	return string(*b.s.AccessKeyID)
+}
+
+func (b *backupS3Config) SecretAccessKey() string {
+	if b.s.SecretAccessKey == nil {
+		return ""
+	}
+	return string(*b.s.SecretAccessKey)
+}
+
 type lockConfig struct {
 	c toml.DatabaseLock
 }
@@ -535,6 +535,10 @@ func (g *generalConfig) Threshold() coreconfig.Threshold {
 	return &thresholdConfig{s: g.secrets.Threshold}
 }
 
+func (g *generalConfig) RemoteSigner() coreconfig.RemoteSigner {
+	return &remoteSignerConfig{s: g.secrets.RemoteSigner}
+}
+
 func (g *generalConfig) Tracing() coreconfig.Tracing {
 	return &tracingConfig{s: g.c.Tracing}
 }
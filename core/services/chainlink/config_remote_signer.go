@@ -0,0 +1,19 @@
+package chainlink
+
+import (
+	"net/url"
+
+	"github.com/smartcontractkit/chainlink/v2/core/config/toml"
+)
+
+type remoteSignerConfig struct {
+	s toml.RemoteSignerSecrets
+}
+
+// URL returns the URL of the remote signer, or nil if there is none.
+func (r *remoteSignerConfig) URL() *url.URL {
+	if r.s.URL == nil {
+		return nil
+	}
+	return r.s.URL.URL()
+}
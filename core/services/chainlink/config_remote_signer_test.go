@@ -0,0 +1,31 @@
+package chainlink
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	remoteSignerSecrets = `
+[RemoteSigner]
+URL = "https://signer.example.com"
+`
+)
+
+func TestRemoteSignerConfig(t *testing.T) {
+	opts := GeneralConfigOpts{
+		SecretsStrings: []string{remoteSignerSecrets},
+	}
+	cfg, err := opts.New()
+	require.NoError(t, err)
+
+	rs := cfg.RemoteSigner()
+	require.NotNil(t, rs.URL())
+	assert.Equal(t, "https://signer.example.com", rs.URL().String())
+
+	cfg, err = GeneralConfigOpts{}.New()
+	require.NoError(t, err)
+	assert.Nil(t, cfg.RemoteSigner().URL())
+}
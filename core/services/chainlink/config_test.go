@@ -330,6 +330,20 @@ func TestConfig_Marshal(t *testing.T) {
 			Frequency:        &hour,
 			Mode:             &config.DatabaseBackupModeFull,
 			OnVersionUpgrade: ptr(true),
+			Sink:             &config.DatabaseBackupSinkS3,
+			Compression:      &config.DatabaseBackupCompressionZstd,
+			Encrypt:          ptr(true),
+			Retention: toml.DatabaseBackupRetention{
+				Daily:  ptr[uint32](7),
+				Weekly: ptr[uint32](4),
+			},
+			S3: toml.DatabaseBackupS3{
+				Endpoint:     mustURL("https://s3.example.com"),
+				Bucket:       ptr("backups"),
+				Prefix:       ptr("node/"),
+				Region:       ptr("eu-west-1"),
+				UsePathStyle: ptr(true),
+			},
 		},
 	}
 	full.TelemetryIngress = toml.TelemetryIngress{
@@ -376,6 +390,9 @@ func TestConfig_Marshal(t *testing.T) {
 			RPID:     ptr("test-rpid"),
 			RPOrigin: ptr("test-rp-origin"),
 		},
+		APITokens: toml.WebServerAPITokens{
+			MaxExpiry: commoncfg.MustNewDuration(720 * time.Hour),
+		},
 		LDAP: toml.WebServerLDAP{
 			ServerTLS:                   ptr(true),
 			SessionTimeout:              commoncfg.MustNewDuration(15 * time.Minute),
@@ -395,6 +412,21 @@ func TestConfig_Marshal(t *testing.T) {
 			UpstreamSyncInterval:        commoncfg.MustNewDuration(0 * time.Second),
 			UpstreamSyncRateLimit:       commoncfg.MustNewDuration(2 * time.Minute),
 		},
+		OIDC: toml.WebServerOIDC{
+			IssuerURL:       ptr("https://idp.example.com/realms/chainlink"),
+			ClientID:        ptr("chainlink-node"),
+			RedirectURL:     ptr("https://node.example.com/oidc/callback"),
+			Scopes:          &[]string{"openid", "email", "groups", "profile"},
+			Audience:        ptr("chainlink-api"),
+			EmailClaim:      ptr("preferred_username"),
+			GroupsClaim:     ptr("roles"),
+			AdminUserGroup:  ptr("NodeAdmins"),
+			EditUserGroup:   ptr("NodeEditors"),
+			RunUserGroup:    ptr("NodeRunners"),
+			ReadUserGroup:   ptr("NodeReadOnly"),
+			SessionTimeout:  commoncfg.MustNewDuration(8 * time.Hour),
+			ProviderTimeout: commoncfg.MustNewDuration(5 * time.Second),
+		},
 		RateLimit: toml.WebServerRateLimit{
 			Authenticated:         ptr[int64](42),
 			AuthenticatedPeriod:   commoncfg.MustNewDuration(time.Second),
@@ -699,6 +731,7 @@ func TestConfig_Marshal(t *testing.T) {
 					EnforceRepeatableRead:      ptr(true),
 					DeathDeclarationDelay:      &minute,
 					NewHeadsPollInterval:       &zeroSeconds,
+					ArchiveProbeEnabled:        ptr(true),
 					Errors: evmcfg.ClientErrors{
 						NonceTooLow:                       ptr[string]("(: |^)nonce too low"),
 						NonceTooHigh:                      ptr[string]("(: |^)nonce too high"),
@@ -744,6 +777,7 @@ func TestConfig_Marshal(t *testing.T) {
 					Name:    ptr("bar"),
 					HTTPURL: mustURL("https://bar.com"),
 					WSURL:   mustURL("wss://web.socket/test/bar"),
+					Roles:   []evmcfg.NodeRole{evmcfg.NodeRoleLogs, evmcfg.NodeRoleArchive},
 				},
 				{
 					Name:     ptr("broadcast"),
@@ -920,6 +954,20 @@ Dir = 'test/backup/dir'
 Frequency = '1h0m0s'
 Mode = 'full'
 OnVersionUpgrade = true
+Sink = 's3'
+Compression = 'zstd'
+Encrypt = true
+
+[Database.Backup.Retention]
+Daily = 7
+Weekly = 4
+
+[Database.Backup.S3]
+Endpoint = 'https://s3.example.com'
+Bucket = 'backups'
+Prefix = 'node/'
+Region = 'eu-west-1'
+UsePathStyle = true
 
 [Database.Listener]
 MaxReconnectDuration = '1m0s'
@@ -991,10 +1039,28 @@ UserAPITokenDuration = '240h0m0s'
 UpstreamSyncInterval = '0s'
 UpstreamSyncRateLimit = '2m0s'
 
+[WebServer.OIDC]
+IssuerURL = 'https://idp.example.com/realms/chainlink'
+ClientID = 'chainlink-node'
+RedirectURL = 'https://node.example.com/oidc/callback'
+Scopes = ['openid', 'email', 'groups', 'profile']
+Audience = 'chainlink-api'
+EmailClaim = 'preferred_username'
+GroupsClaim = 'roles'
+AdminUserGroup = 'NodeAdmins'
+EditUserGroup = 'NodeEditors'
+RunUserGroup = 'NodeRunners'
+ReadUserGroup = 'NodeReadOnly'
+SessionTimeout = '8h0m0s'
+ProviderTimeout = '5s'
+
 [WebServer.MFA]
 RPID = 'test-rpid'
 RPOrigin = 'test-rp-origin'
 
+[WebServer.APITokens]
+MaxExpiry = '720h0m0s'
+
 [WebServer.RateLimit]
 Authenticated = 42
 AuthenticatedPeriod = '1s'
@@ -1215,6 +1281,7 @@ FinalizedBlockPollInterval = '1s'
 EnforceRepeatableRead = true
 DeathDeclarationDelay = '1m0s'
 NewHeadsPollInterval = '0s'
+ArchiveProbeEnabled = true
 
 [EVM.NodePool.Errors]
 NonceTooLow = '(: |^)nonce too low'
@@ -1257,6 +1324,7 @@ HTTPURL = 'https://foo.web'
 Name = 'bar'
 WSURL = 'wss://web.socket/test/bar'
 HTTPURL = 'https://bar.com'
+Roles = ['logs', 'archive']
 
 [[EVM.Nodes]]
 Name = 'broadcast'
@@ -1455,6 +1523,107 @@ func TestConfig_full(t *testing.T) {
 		if got.EVM[c].GasEstimator.DAOracle.CustomGasPriceCalldata == nil {
 			got.EVM[c].GasEstimator.DAOracle.CustomGasPriceCalldata = new(string)
 		}
+		if got.EVM[c].HeadTracker.FinalityProvider.Type == nil {
+			got.EVM[c].HeadTracker.FinalityProvider.Type = ptr(evmcfg.FinalityProviderOPStack)
+		}
+		if got.EVM[c].HeadTracker.FinalityProvider.L1URL == nil {
+			got.EVM[c].HeadTracker.FinalityProvider.L1URL = new(commoncfg.URL)
+		}
+		if got.EVM[c].HeadTracker.FinalityProvider.ContractAddress == nil {
+			got.EVM[c].HeadTracker.FinalityProvider.ContractAddress = new(types.EIP55Address)
+		}
+		if got.EVM[c].HeadTracker.FinalityProvider.L1Confirmations == nil {
+			got.EVM[c].HeadTracker.FinalityProvider.L1Confirmations = new(uint32)
+		}
+		if got.EVM[c].GasEstimator.Composite.Estimators == nil {
+			got.EVM[c].GasEstimator.Composite.Estimators = &[]string{}
+		}
+		if got.EVM[c].GasEstimator.Composite.Policy == nil {
+			got.EVM[c].GasEstimator.Composite.Policy = ptr(evmcfg.CompositePolicyMedian)
+		}
+		if got.EVM[c].GasEstimator.Composite.MaxStaleness == nil {
+			got.EVM[c].GasEstimator.Composite.MaxStaleness = new(commoncfg.Duration)
+		}
+		if got.EVM[c].GasEstimator.FeeAPI.URL == nil {
+			got.EVM[c].GasEstimator.FeeAPI.URL = new(commoncfg.URL)
+		}
+		if got.EVM[c].GasEstimator.FeeAPI.PollPeriod == nil {
+			got.EVM[c].GasEstimator.FeeAPI.PollPeriod = new(commoncfg.Duration)
+		}
+		if got.EVM[c].GasEstimator.FeeAPI.Unit == nil {
+			got.EVM[c].GasEstimator.FeeAPI.Unit = ptr(evmcfg.FeeAPIUnitWei)
+		}
+		if got.EVM[c].GasEstimator.FeeAPI.GasPricePath == nil {
+			got.EVM[c].GasEstimator.FeeAPI.GasPricePath = new(string)
+		}
+		if got.EVM[c].GasEstimator.FeeAPI.GasFeeCapPath == nil {
+			got.EVM[c].GasEstimator.FeeAPI.GasFeeCapPath = new(string)
+		}
+		if got.EVM[c].GasEstimator.FeeAPI.GasTipCapPath == nil {
+			got.EVM[c].GasEstimator.FeeAPI.GasTipCapPath = new(string)
+		}
+		if got.EVM[c].BalanceMonitor.EthFloor == nil {
+			got.EVM[c].BalanceMonitor.EthFloor = new(assets.Wei)
+		}
+		if got.EVM[c].BalanceMonitor.LinkFloor == nil {
+			got.EVM[c].BalanceMonitor.LinkFloor = new(commonassets.Link)
+		}
+		if got.EVM[c].BalanceMonitor.AlertInterval == nil {
+			got.EVM[c].BalanceMonitor.AlertInterval = new(commoncfg.Duration)
+		}
+		if got.EVM[c].BalanceMonitor.WebhookURL == nil {
+			got.EVM[c].BalanceMonitor.WebhookURL = new(commoncfg.URL)
+		}
+		if got.EVM[c].BalanceMonitor.TopUp.Enabled == nil {
+			got.EVM[c].BalanceMonitor.TopUp.Enabled = ptr(false)
+		}
+		if got.EVM[c].BalanceMonitor.TopUp.FundingAddress == nil {
+			got.EVM[c].BalanceMonitor.TopUp.FundingAddress = new(types.EIP55Address)
+		}
+		if got.EVM[c].BalanceMonitor.TopUp.Floor == nil {
+			got.EVM[c].BalanceMonitor.TopUp.Floor = new(assets.Wei)
+		}
+		if got.EVM[c].BalanceMonitor.TopUp.Amount == nil {
+			got.EVM[c].BalanceMonitor.TopUp.Amount = new(assets.Wei)
+		}
+		if got.EVM[c].BalanceMonitor.TopUp.MinInterval == nil {
+			got.EVM[c].BalanceMonitor.TopUp.MinInterval = new(commoncfg.Duration)
+		}
+		if got.EVM[c].BalanceMonitor.TopUp.MaxPerDay == nil {
+			got.EVM[c].BalanceMonitor.TopUp.MaxPerDay = ptr(uint32(0))
+		}
+		if got.EVM[c].Transactions.PrivateRelay.Enabled == nil {
+			got.EVM[c].Transactions.PrivateRelay.Enabled = ptr(false)
+		}
+		if got.EVM[c].Transactions.PrivateRelay.URL == nil {
+			got.EVM[c].Transactions.PrivateRelay.URL = new(commoncfg.URL)
+		}
+		if got.EVM[c].Transactions.PrivateRelay.Method == nil {
+			got.EVM[c].Transactions.PrivateRelay.Method = ptr(evmcfg.PrivateRelayMethodSendPrivateTransaction)
+		}
+		if got.EVM[c].Transactions.PrivateRelay.FallbackBlocks == nil {
+			got.EVM[c].Transactions.PrivateRelay.FallbackBlocks = ptr(uint32(0))
+		}
+		if got.EVM[c].Transactions.Simulation.Errors == nil {
+			got.EVM[c].Transactions.Simulation.Errors = &[]string{}
+		}
+		if got.EVM[c].Transactions.Simulation.TransientErrors == nil {
+			got.EVM[c].Transactions.Simulation.TransientErrors = &[]string{}
+		}
+		if got.EVM[c].Transactions.Simulation.TransientReasons == nil {
+			got.EVM[c].Transactions.Simulation.TransientReasons = &[]string{}
+		}
+		for i := range got.EVM[c].KeySpecific {
+			if got.EVM[c].KeySpecific[i].PrivateRelay.Enabled == nil {
+				got.EVM[c].KeySpecific[i].PrivateRelay.Enabled = ptr(false)
+			}
+			if got.EVM[c].KeySpecific[i].BalanceMonitor.EthFloor == nil {
+				got.EVM[c].KeySpecific[i].BalanceMonitor.EthFloor = new(assets.Wei)
+			}
+			if got.EVM[c].KeySpecific[i].BalanceMonitor.LinkFloor == nil {
+				got.EVM[c].KeySpecific[i].BalanceMonitor.LinkFloor = new(commonassets.Link)
+			}
+		}
 	}
 
 	cfgtest.AssertFieldsNotNil(t, got)
@@ -1481,7 +1650,7 @@ func TestConfig_Validate(t *testing.T) {
 		- LDAP.RunUserGroupCN: invalid value (<nil>): LDAP ReadUserGroupCN can not be empty
 		- LDAP.RunUserGroupCN: invalid value (<nil>): LDAP RunUserGroupCN can not be empty
 		- LDAP.ReadUserGroupCN: invalid value (<nil>): LDAP ReadUserGroupCN can not be empty
-	- EVM: 10 errors:
+	- EVM: 11 errors:
 		- 1.ChainID: invalid value (1): duplicate - must be unique
 		- 0.Nodes.1.Name: invalid value (foo): duplicate - must be unique
 		- 3.Nodes.4.WSURL: invalid value (ws://dupe.com): duplicate - must be unique
@@ -1537,7 +1706,17 @@ func TestConfig_Validate(t *testing.T) {
 			- ChainID: missing: required for all chains
 			- Nodes: missing: must have at least one node
 		- 5.Transactions.AutoPurge.DetectionApiUrl: invalid value (): must be set for scroll
-		- 6.Nodes: missing: 0th node (primary) must have a valid WSURL when http polling is disabled
+		- 6: 2 errors:
+			- Nodes: missing: 0th node (primary) must have a valid WSURL when http polling is disabled
+			- Transactions.PrivateRelay: 3 errors:
+					- URL: missing: required if private relay is enabled
+					- Method: invalid value (eth_sendRawTransaction): must be eth_sendPrivateTransaction or eth_sendBundle
+					- FallbackBlocks: invalid value (0): must be greater than 0
+		- 7: 2 errors:
+			- Nodes: missing: must have at least one primary node with no roles or the read role
+			- Nodes.0: 2 errors:
+					- Roles: invalid value (logs): must not be duplicated
+					- Roles: invalid value (trace): must be one of read, logs, archive or send
 	- Cosmos: 5 errors:
 		- 1.ChainID: invalid value (Malaga-420): duplicate - must be unique
 		- 0.Nodes.1.Name: invalid value (test): duplicate - must be unique
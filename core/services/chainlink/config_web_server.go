@@ -96,6 +96,14 @@ func (m *mfaConfig) RPOrigin() string {
 	return *m.c.RPOrigin
 }
 
+type apiTokensConfig struct {
+	c toml.WebServerAPITokens
+}
+
+func (a *apiTokensConfig) MaxExpiry() time.Duration {
+	return a.c.MaxExpiry.Duration()
+}
+
 type webServerConfig struct {
 	c       toml.WebServer
 	s       toml.WebServerSecrets
@@ -114,10 +122,18 @@ func (w *webServerConfig) MFA() config.MFA {
 	return &mfaConfig{c: w.c.MFA}
 }
 
+func (w *webServerConfig) APITokens() config.APITokens {
+	return &apiTokensConfig{c: w.c.APITokens}
+}
+
 func (w *webServerConfig) LDAP() config.LDAP {
 	return &ldapConfig{c: w.c.LDAP, s: w.s.LDAP}
 }
 
+func (w *webServerConfig) OIDC() config.OIDC {
+	return &oidcConfig{c: w.c.OIDC, s: w.s.OIDC}
+}
+
 func (w *webServerConfig) AuthenticationMethod() string {
 	return *w.c.AuthenticationMethod
 }
@@ -313,3 +329,104 @@ func (l *ldapConfig) UpstreamSyncRateLimit() commonconfig.Duration {
 	}
 	return *l.c.UpstreamSyncRateLimit
 }
+
+type oidcConfig struct {
+	c toml.WebServerOIDC
+	s toml.WebServerOIDCSecrets
+}
+
+func (o *oidcConfig) IssuerURL() string {
+	if o.c.IssuerURL == nil {
+		return ""
+	}
+	return *o.c.IssuerURL
+}
+
+func (o *oidcConfig) ClientID() string {
+	if o.c.ClientID == nil {
+		return ""
+	}
+	return *o.c.ClientID
+}
+
+func (o *oidcConfig) ClientSecret() string {
+	if o.s.ClientSecret == nil {
+		return ""
+	}
+	return string(*o.s.ClientSecret)
+}
+
+func (o *oidcConfig) RedirectURL() string {
+	if o.c.RedirectURL == nil {
+		return ""
+	}
+	return *o.c.RedirectURL
+}
+
+func (o *oidcConfig) Scopes() []string {
+	if o.c.Scopes == nil {
+		return nil
+	}
+	return *o.c.Scopes
+}
+
+// Audience returns the expected audience of bearer tokens, which are rejected if it is empty.
+func (o *oidcConfig) Audience() string {
+	if o.c.Audience == nil {
+		return ""
+	}
+	return *o.c.Audience
+}
+
+func (o *oidcConfig) EmailClaim() string {
+	if o.c.EmailClaim == nil {
+		return ""
+	}
+	return *o.c.EmailClaim
+}
+
+func (o *oidcConfig) GroupsClaim() string {
+	if o.c.GroupsClaim == nil {
+		return ""
+	}
+	return *o.c.GroupsClaim
+}
+
+func (o *oidcConfig) AdminUserGroup() string {
+	if o.c.AdminUserGroup == nil {
+		return ""
+	}
+	return *o.c.AdminUserGroup
+}
+
+func (o *oidcConfig) EditUserGroup() string {
+	if o.c.EditUserGroup == nil {
+		return ""
+	}
+	return *o.c.EditUserGroup
+}
+
+func (o *oidcConfig) RunUserGroup() string {
+	if o.c.RunUserGroup == nil {
+		return ""
+	}
+	return *o.c.RunUserGroup
+}
+
+func (o *oidcConfig) ReadUserGroup() string {
+	if o.c.ReadUserGroup == nil {
+		return ""
+	}
+	return *o.c.ReadUserGroup
+}
+
+func (o *oidcConfig) SessionTimeout() commonconfig.Duration {
+	if o.c.SessionTimeout == nil {
+		return commonconfig.Duration{}
+	}
+	return *o.c.SessionTimeout
+}
+
+func (o *oidcConfig) ProviderTimeout() time.Duration {
+	return o.c.ProviderTimeout.Duration()
+}
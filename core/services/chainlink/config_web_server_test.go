@@ -45,4 +45,28 @@ func TestWebServerConfig(t *testing.T) {
 	mf := ws.MFA()
 	assert.Equal(t, "test-rpid", mf.RPID())
 	assert.Equal(t, "test-rp-origin", mf.RPOrigin())
+
+	assert.Equal(t, 720*time.Hour, ws.APITokens().MaxExpiry())
+
+	oidc := ws.OIDC()
+	assert.Equal(t, "https://idp.example.com/realms/chainlink", oidc.IssuerURL())
+	assert.Equal(t, "chainlink-node", oidc.ClientID())
+	assert.Equal(t, "https://node.example.com/oidc/callback", oidc.RedirectURL())
+	assert.Equal(t, []string{"openid", "email", "groups", "profile"}, oidc.Scopes())
+	assert.Equal(t, "chainlink-api", oidc.Audience())
+	assert.Equal(t, "preferred_username", oidc.EmailClaim())
+	assert.Equal(t, "roles", oidc.GroupsClaim())
+	assert.Equal(t, "NodeAdmins", oidc.AdminUserGroup())
+	assert.Equal(t, "NodeReadOnly", oidc.ReadUserGroup())
+	assert.Equal(t, *commonconfig.MustNewDuration(8 * time.Hour), oidc.SessionTimeout())
+	assert.Equal(t, 5*time.Second, oidc.ProviderTimeout())
+}
+
+func TestWebServerConfig_OIDCAudience(t *testing.T) {
+	oidc := &oidcConfig{}
+	assert.Empty(t, oidc.Audience())
+	oidc.c.ClientID = ptr("chainlink-node")
+	assert.Empty(t, oidc.Audience())
+	oidc.c.Audience = ptr("chainlink-api")
+	assert.Equal(t, "chainlink-api", oidc.Audience())
 }
@@ -108,7 +108,9 @@ func setupFluxAggregatorUniverse(t *testing.T, configOptions ...func(cfg *fluxAg
 
 	key, err := ethkey.NewV2()
 	require.NoError(t, err)
-	oracleTransactor, err := bind.NewKeyedTransactorWithChainID(key.ToEcdsaPrivKey(), testutils.SimulatedChainID)
+	privKey, err := key.ToEcdsaPrivKey()
+	require.NoError(t, err)
+	oracleTransactor, err := bind.NewKeyedTransactorWithChainID(privKey, testutils.SimulatedChainID)
 	require.NoError(t, err)
 
 	var f fluxAggregatorUniverse
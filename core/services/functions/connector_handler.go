@@ -124,6 +124,8 @@ func (h *functionsConnectorHandler) HandleGatewayMessage(ctx context.Context, ga
 	switch body.Method {
 	case functions.MethodSecretsList:
 		h.handleSecretsList(ctx, gatewayId, body, fromAddr)
+	case functions.MethodSecretsHistory:
+		h.handleSecretsHistory(ctx, gatewayId, body, fromAddr)
 	case functions.MethodSecretsSet:
 		if balance, err := h.subscriptions.GetMaxUserBalance(fromAddr); err != nil || balance.Cmp(h.minimumBalance.ToInt()) < 0 {
 			h.lggr.Errorw("user subscription has insufficient balance", "id", gatewayId, "address", fromAddr, "balance", balance, "minBalance", h.minimumBalance)
@@ -185,6 +187,32 @@ func (h *functionsConnectorHandler) handleSecretsList(ctx context.Context, gatew
 	h.sendResponseAndLog(ctx, gatewayId, body, response)
 }
 
+func (h *functionsConnectorHandler) handleSecretsHistory(ctx context.Context, gatewayId string, body *api.MessageBody, fromAddr ethCommon.Address) {
+	var request functions.SecretsHistoryRequest
+	var response functions.SecretsHistoryResponse
+	err := json.Unmarshal(body.Payload, &request)
+	if err == nil {
+		var history []*s4.VersionedRecord
+		history, err = h.storage.History(ctx, fromAddr, request.SlotID, request.Limit)
+		if err == nil {
+			response.Success = true
+			response.Rows = make([]functions.SecretsHistoryRow, len(history))
+			for i, record := range history {
+				response.Rows[i] = functions.SecretsHistoryRow{
+					Version:    record.Version,
+					Expiration: record.Record.Expiration,
+					Confirmed:  record.Metadata.Confirmed,
+				}
+			}
+		} else {
+			response.ErrorMessage = fmt.Sprintf("Failed to get secrets history: %v", err)
+		}
+	} else {
+		response.ErrorMessage = fmt.Sprintf("Bad request to get secrets history: %v", err)
+	}
+	h.sendResponseAndLog(ctx, gatewayId, body, response)
+}
+
 func (h *functionsConnectorHandler) handleSecretsSet(ctx context.Context, gatewayId string, body *api.MessageBody, fromAddr ethCommon.Address) {
 	var request functions.SecretsSetRequest
 	var response functions.SecretsSetResponse
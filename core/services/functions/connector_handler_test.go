@@ -152,6 +152,46 @@ func TestFunctionsConnectorHandler(t *testing.T) {
 			})
 		})
 
+		t.Run("secrets_history", func(t *testing.T) {
+			msg := api.Message{
+				Body: api.MessageBody{
+					DonId:     "fun4",
+					MessageId: "1",
+					Method:    "secrets_history",
+					Sender:    addr.Hex(),
+					Payload:   json.RawMessage(`{"slot_id":1,"limit":2}`),
+				},
+			}
+			require.NoError(t, msg.Sign(privateKey))
+
+			ctx := testutils.Context(t)
+			history := []*s4.VersionedRecord{
+				{Version: 2, Record: s4.Record{Expiration: 20}},
+				{Version: 1, Record: s4.Record{Expiration: 10}, Metadata: s4.Metadata{Confirmed: true}},
+			}
+			storage.On("History", ctx, addr, uint(1), uint(2)).Return(history, nil).Once()
+			allowlist.On("Allow", addr).Return(true).Once()
+			connector.On("SendToGateway", ctx, "gw1", mock.Anything).Run(func(args mock.Arguments) {
+				msg, ok := args[2].(*api.Message)
+				require.True(t, ok)
+				require.Equal(t, `{"success":true,"rows":[{"version":2,"expiration":20,"confirmed":false},{"version":1,"expiration":10,"confirmed":true}]}`, string(msg.Body.Payload))
+			}).Return(nil).Once()
+
+			handler.HandleGatewayMessage(ctx, "gw1", &msg)
+
+			t.Run("storage error", func(t *testing.T) {
+				storage.On("History", ctx, addr, uint(1), uint(2)).Return(nil, s4.ErrSlotIdTooBig).Once()
+				allowlist.On("Allow", addr).Return(true).Once()
+				connector.On("SendToGateway", ctx, "gw1", mock.Anything).Run(func(args mock.Arguments) {
+					msg, ok := args[2].(*api.Message)
+					require.True(t, ok)
+					require.Equal(t, `{"success":false,"error_message":"Failed to get secrets history: slot id is too big"}`, string(msg.Body.Payload))
+				}).Return(nil).Once()
+
+				handler.HandleGatewayMessage(ctx, "gw1", &msg)
+			})
+		})
+
 		t.Run("secrets_set", func(t *testing.T) {
 			ctx := testutils.Context(t)
 			key := s4.Key{
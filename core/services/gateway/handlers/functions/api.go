@@ -3,9 +3,10 @@ package functions
 import "github.com/smartcontractkit/chainlink/v2/core/services/gateway/api"
 
 const (
-	MethodSecretsSet  = "secrets_set"
-	MethodSecretsList = "secrets_list"
-	MethodHeartbeat   = "heartbeat"
+	MethodSecretsSet     = "secrets_set"
+	MethodSecretsList    = "secrets_list"
+	MethodSecretsHistory = "secrets_history"
+	MethodHeartbeat      = "heartbeat"
 )
 
 type SecretsSetRequest struct {
@@ -18,6 +19,11 @@ type SecretsSetRequest struct {
 
 // SecretsListRequest has empty payload
 
+type SecretsHistoryRequest struct {
+	SlotID uint `json:"slot_id"`
+	Limit  uint `json:"limit"`
+}
+
 type ResponseBase struct {
 	Success      bool   `json:"success"`
 	ErrorMessage string `json:"error_message,omitempty"`
@@ -38,6 +44,17 @@ type SecretsListRow struct {
 	Expiration int64  `json:"expiration"`
 }
 
+type SecretsHistoryResponse struct {
+	ResponseBase
+	Rows []SecretsHistoryRow `json:"rows,omitempty"`
+}
+
+type SecretsHistoryRow struct {
+	Version    uint64 `json:"version"`
+	Expiration int64  `json:"expiration"`
+	Confirmed  bool   `json:"confirmed"`
+}
+
 // Gateway -> User response, which combines responses from several nodes
 type CombinedResponse struct {
 	ResponseBase
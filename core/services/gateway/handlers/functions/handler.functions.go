@@ -56,6 +56,16 @@ var (
 		Name: "gateway_functions_secrets_list_failure",
 		Help: "Metric to track failed secrets_list calls",
 	}, []string{"don_id"})
+
+	promSecretsHistorySuccess = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "gateway_functions_secrets_history_success",
+		Help: "Metric to track successful secrets_history calls",
+	}, []string{"don_id"})
+
+	promSecretsHistoryFailure = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "gateway_functions_secrets_history_failure",
+		Help: "Metric to track failed secrets_history calls",
+	}, []string{"don_id"})
 )
 
 type FunctionsHandlerConfig struct {
@@ -214,7 +224,7 @@ func (h *functionsHandler) HandleUserMessage(ctx context.Context, msg *api.Messa
 		}
 	}
 	switch msg.Body.Method {
-	case MethodSecretsSet, MethodSecretsList:
+	case MethodSecretsSet, MethodSecretsList, MethodSecretsHistory:
 		return h.handleRequest(ctx, msg, callbackCh)
 	case MethodHeartbeat:
 		if _, ok := h.allowedHeartbeatInitiators[msg.Body.Sender]; !ok {
@@ -255,7 +265,7 @@ func (h *functionsHandler) HandleNodeMessage(ctx context.Context, msg *api.Messa
 		return errors.New("rate-limited")
 	}
 	switch msg.Body.Method {
-	case MethodSecretsSet, MethodSecretsList:
+	case MethodSecretsSet, MethodSecretsList, MethodSecretsHistory:
 		return h.pendingRequests.ProcessResponse(msg, h.processSecretsResponse)
 	case MethodHeartbeat:
 		return h.pendingRequests.ProcessResponse(msg, h.processHeartbeatResponse)
@@ -319,6 +329,12 @@ func newSecretsResponse(request *api.Message, success bool, responses []*api.Mes
 		} else {
 			promSecretsListFailure.WithLabelValues(request.Body.DonId).Inc()
 		}
+	} else if request.Body.Method == MethodSecretsHistory {
+		if success {
+			promSecretsHistorySuccess.WithLabelValues(request.Body.DonId).Inc()
+		} else {
+			promSecretsHistoryFailure.WithLabelValues(request.Body.DonId).Inc()
+		}
 	}
 
 	userResponse := *request
@@ -146,6 +146,35 @@ func TestFunctionsHandler_HandleUserMessage_SecretsSet(t *testing.T) {
 	}
 }
 
+func TestFunctionsHandler_HandleUserMessage_SecretsHistory(t *testing.T) {
+	t.Parallel()
+
+	nodes, user := gc.NewTestNodes(t, 4), gc.NewTestNodes(t, 1)[0]
+	handler, don, allowlist, _ := newFunctionsHandlerForATestDON(t, nodes, time.Hour*24, user.Address)
+	userRequestMsg := newSignedMessage(t, "1234", "secrets_history", "don_id", user.PrivateKey)
+
+	callbachCh := make(chan handlers.UserCallbackPayload)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		// wait on a response from Gateway to the user
+		response := <-callbachCh
+		require.Equal(t, api.NoError, response.ErrCode)
+		require.Equal(t, userRequestMsg.Body.MessageId, response.Msg.Body.MessageId)
+		var payload functions.CombinedResponse
+		require.NoError(t, json.Unmarshal(response.Msg.Body.Payload, &payload))
+		require.True(t, payload.Success)
+		require.Len(t, payload.NodeResponses, 2)
+	}()
+
+	// secrets_history does not require a minimum balance
+	allowlist.On("Allow", common.HexToAddress(user.Address)).Return(true, nil)
+	don.On("SendToNode", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	require.NoError(t, handler.HandleUserMessage(testutils.Context(t), &userRequestMsg, callbachCh))
+	sendNodeReponses(t, handler, userRequestMsg, nodes, []bool{true, false, true, true})
+	<-done
+}
+
 func TestFunctionsHandler_HandleUserMessage_Heartbeat(t *testing.T) {
 	t.Parallel()
 
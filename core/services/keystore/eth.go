@@ -27,6 +27,7 @@ type Eth interface {
 	Create(ctx context.Context, chainIDs ...*big.Int) (ethkey.KeyV2, error)
 	Delete(ctx context.Context, id string) (ethkey.KeyV2, error)
 	Import(ctx context.Context, keyJSON []byte, password string, chainIDs ...*big.Int) (ethkey.KeyV2, error)
+	AddRemote(ctx context.Context, address common.Address, chainIDs ...*big.Int) (ethkey.KeyV2, error)
 	Export(ctx context.Context, id string, password string) ([]byte, error)
 
 	Enable(ctx context.Context, address common.Address, chainID *big.Int) error
@@ -60,6 +61,7 @@ type eth struct {
 	ds            sqlutil.DataSource
 	subscribers   [](chan struct{})
 	subscribersMu *sync.RWMutex
+	remoteSigner  RemoteSigner                      // optional, signs with the keys added by AddRemote
 	resourceMutex map[common.Address]*ResourceMutex // ResourceMutex is an internal field and ought not be persisted to the database. Its main usage is to verify that the same key is not used for both TXMv1 and TXMv2 (usage in both TXMs will cause nonce drift and will lead to missing transactions). This functionality should be removed after we completely switch to TXMv2
 }
 
@@ -192,6 +194,30 @@ func (ks *eth) Import(ctx context.Context, keyJSON []byte, password string, chai
 	return key, nil
 }
 
+// AddRemote adds the key of address, whose private key is held by the remote signer, and enables it for the given
+// chain IDs. Only the address is stored in the keystore.
+func (ks *eth) AddRemote(ctx context.Context, address common.Address, chainIDs ...*big.Int) (ethkey.KeyV2, error) {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+	if ks.isLocked() {
+		return ethkey.KeyV2{}, ErrLocked
+	}
+	if ks.remoteSigner == nil {
+		return ethkey.KeyV2{}, ErrNoRemoteSigner
+	}
+	key := ethkey.FromAddress(address)
+	if _, found := ks.keyRing.Eth[key.ID()]; found {
+		return ethkey.KeyV2{}, ErrKeyExists
+	}
+	err := ks.add(ctx, key, chainIDs...)
+	if err != nil {
+		return ethkey.KeyV2{}, errors.Wrap(err, "unable to add eth key")
+	}
+	ks.notify()
+	ks.logger.Infow(fmt.Sprintf("Added remote EVM key with ID %s", key.Address.Hex()), "address", key.Address.Hex(), "evmChainIDs", chainIDs)
+	return key, nil
+}
+
 func (ks *eth) Export(ctx context.Context, id string, password string) ([]byte, error) {
 	ks.lock.RLock()
 	defer ks.lock.RUnlock()
@@ -202,6 +228,9 @@ func (ks *eth) Export(ctx context.Context, id string, password string) ([]byte,
 	if err != nil {
 		return nil, err
 	}
+	if key.IsRemote() {
+		return nil, errors.Errorf("key %s is held by the remote signer and cannot be exported", key.ID())
+	}
 	return key.ToEncryptedJSON(password, ks.scryptParams)
 }
 
@@ -332,17 +361,19 @@ func (ks *eth) SubscribeToKeyChanges(ctx context.Context) (ch chan struct{}, uns
 }
 
 func (ks *eth) SignTx(ctx context.Context, address common.Address, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
-	ks.lock.RLock()
-	defer ks.lock.RUnlock()
-	if ks.isLocked() {
-		return nil, ErrLocked
+	key, remoteSigner, err := ks.getSigningKey(address)
+	if err != nil {
+		return nil, err
+	}
+	if key.IsRemote() {
+		return remoteSigner.SignTx(ctx, address, tx, chainID)
 	}
-	key, err := ks.getByID(address.String())
+	privKey, err := key.ToEcdsaPrivKey()
 	if err != nil {
 		return nil, err
 	}
 	signer := types.LatestSignerForChainID(chainID)
-	return types.SignTx(tx, signer, key.ToEcdsaPrivKey())
+	return types.SignTx(tx, signer, privKey)
 }
 
 // EnabledKeysForChain returns all keys that are enabled for the given chain
@@ -370,7 +401,12 @@ func (ks *eth) GetRoundRobinAddress(ctx context.Context, chainID *big.Int, white
 
 	var keys []ethkey.KeyV2
 	if len(whitelist) == 0 {
-		keys = ks.enabledKeysForChain(chainID)
+		// keys draining in a rotation are only used when whitelisted, so that they stop being picked for new transactions
+		for _, k := range ks.enabledKeysForChain(chainID) {
+			if !ks.keyStates.Draining[k.ID()] {
+				keys = append(keys, k)
+			}
+		}
 	} else if len(whitelist) > 0 {
 		for _, k := range ks.enabledKeysForChain(chainID) {
 			for _, addr := range whitelist {
@@ -559,22 +595,56 @@ func (ks *eth) XXXTestingOnlyAdd(ctx context.Context, key ethkey.KeyV2) {
 // SignMessage signs the provided message using the private key associated with the given address,
 // following the EIP-191 specific identifier (e.g., keccak256("\x19Ethereum Signed Message:\n"${message length}${message}))
 func (ks *eth) SignMessage(ctx context.Context, address common.Address, data []byte) ([]byte, error) {
-	ks.lock.RLock()
-	defer ks.lock.RUnlock()
-	if ks.isLocked() {
-		return nil, ErrLocked
+	key, remoteSigner, err := ks.getSigningKey(address)
+	if err != nil {
+		return nil, err
 	}
-	key, err := ks.getByID(address.Hex())
+	if key.IsRemote() {
+		return remoteSigner.SignMessage(ctx, address, data)
+	}
+	privKey, err := key.ToEcdsaPrivKey()
 	if err != nil {
 		return nil, err
 	}
-	signature, err := crypto.Sign(accounts.TextHash(data), key.ToEcdsaPrivKey())
+	signature, err := crypto.Sign(accounts.TextHash(data), privKey)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to sign data")
 	}
 	return signature, nil
 }
 
+// getSigningKey returns the key of address, and the remote signer if the key is held by it. The lock is released
+// before returning, so that requests to the remote signer do not block the keystore.
+func (ks *eth) getSigningKey(address common.Address) (ethkey.KeyV2, RemoteSigner, error) {
+	ks.lock.RLock()
+	defer ks.lock.RUnlock()
+	if ks.isLocked() {
+		return ethkey.KeyV2{}, nil, ErrLocked
+	}
+	key, err := ks.getByID(address.Hex())
+	if err != nil {
+		return ethkey.KeyV2{}, nil, err
+	}
+	if key.IsRemote() && ks.remoteSigner == nil {
+		return ethkey.KeyV2{}, nil, errors.Wrapf(ErrNoRemoteSigner, "key %s is held by the remote signer", key.ID())
+	}
+	return key, ks.remoteSigner, nil
+}
+
+// setDraining marks the key of address as draining in a rotation, or clears the mark.
+func (ks *eth) setDraining(address common.Address, draining bool) {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+	if ks.isLocked() {
+		return
+	}
+	if draining {
+		ks.keyStates.Draining[address.Hex()] = true
+	} else {
+		delete(ks.keyStates.Draining, address.Hex())
+	}
+}
+
 // caller must hold lock!
 func (ks *eth) getByID(id string) (ethkey.KeyV2, error) {
 	key, found := ks.keyRing.Eth[id]
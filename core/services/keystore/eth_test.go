@@ -11,6 +11,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -20,8 +21,10 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore"
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/ethkey"
+	clutils "github.com/smartcontractkit/chainlink/v2/core/utils"
 	evmclient "github.com/smartcontractkit/chainlink/v2/evm/client"
 	"github.com/smartcontractkit/chainlink/v2/evm/utils"
 	ubig "github.com/smartcontractkit/chainlink/v2/evm/utils/big"
@@ -371,7 +374,9 @@ func Test_EthKeyStore_SignMessage(t *testing.T) {
 
 	k, _ := cltest.MustInsertRandomKey(t, ethKeyStore)
 
-	pubKeyBytes := crypto.FromECDSAPub(&k.ToEcdsaPrivKey().PublicKey)
+	privKey, err := k.ToEcdsaPrivKey()
+	require.NoError(t, err)
+	pubKeyBytes := crypto.FromECDSAPub(&privKey.PublicKey)
 
 	message := []byte("this is a message")
 
@@ -385,6 +390,52 @@ func Test_EthKeyStore_SignMessage(t *testing.T) {
 	require.ErrorContains(t, err, "Key not found")
 }
 
+func Test_EthKeyStore_AddRemote(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	db := pgtest.NewSqlxDB(t)
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	chainID := big.NewInt(evmclient.NullClientChainID)
+
+	t.Run("requires a remote signer", func(t *testing.T) {
+		ethKeyStore := cltest.NewKeyStore(t, db).Eth()
+		_, err := ethKeyStore.AddRemote(ctx, address, chainID)
+		require.ErrorIs(t, err, keystore.ErrNoRemoteSigner)
+	})
+
+	keyStore := keystore.NewInMemoryWithRemoteSigner(db, clutils.FastScryptParams, logger.TestLogger(t), newStubWeb3Signer(t, &stubWeb3Signer{key: key}))
+	require.NoError(t, keyStore.Unlock(ctx, cltest.Password))
+	ethKeyStore := keyStore.Eth()
+
+	k, err := ethKeyStore.AddRemote(ctx, address, chainID)
+	require.NoError(t, err)
+	assert.True(t, k.IsRemote())
+	require.NoError(t, ethKeyStore.CheckEnabled(ctx, address, chainID))
+
+	_, err = ethKeyStore.AddRemote(ctx, address, chainID)
+	require.ErrorIs(t, err, keystore.ErrKeyExists)
+
+	_, err = ethKeyStore.Export(ctx, k.ID(), cltest.Password)
+	require.ErrorContains(t, err, "cannot be exported")
+
+	tx := cltest.NewLegacyTransaction(0, testutils.NewAddress(), big.NewInt(53), 21000, big.NewInt(1000000000), []byte{1, 2, 3, 4})
+	signed, err := ethKeyStore.SignTx(ctx, address, tx, chainID)
+	require.NoError(t, err)
+	from, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+	require.NoError(t, err)
+	assert.Equal(t, address, from)
+
+	message := []byte("this is a message")
+	signature, err := ethKeyStore.SignMessage(ctx, address, message)
+	require.NoError(t, err)
+	pubKey, err := crypto.Ecrecover(accounts.TextHash(message), signature)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.FromECDSAPub(&key.PublicKey), pubKey)
+}
+
 func Test_EthKeyStore_E2E(t *testing.T) {
 	t.Parallel()
 
@@ -16,6 +16,9 @@ type EncryptedEthKeyExport struct {
 }
 
 func (key KeyV2) ToEncryptedJSON(password string, scryptParams utils.ScryptParams) (export []byte, err error) {
+	if key.IsRemote() {
+		return nil, ErrRemoteKey
+	}
 	// DEV: uuid is derived directly from the address, since it is not stored internally
 	id, err := uuid.FromBytes(key.Address.Bytes()[:16])
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"crypto/ecdsa"
 	"crypto/rand"
+	"errors"
 	"fmt"
 	"math/big"
 
@@ -15,6 +16,9 @@ import (
 
 var curve = crypto.S256()
 
+// ErrRemoteKey is returned when the private key of a key held by a remote signer is requested.
+var ErrRemoteKey = errors.New("private key is held by the remote signer")
+
 type Raw []byte
 
 func (raw Raw) Key() KeyV2 {
@@ -66,16 +70,37 @@ func FromPrivateKey(privKey *ecdsa.PrivateKey) (key KeyV2) {
 	}
 }
 
+// FromAddress returns a key for address whose private key is held by a remote signer.
+func FromAddress(address common.Address) KeyV2 {
+	return KeyV2{
+		Address:      address,
+		EIP55Address: types.EIP55AddressFromAddress(address),
+	}
+}
+
 func (key KeyV2) ID() string {
 	return key.Address.Hex()
 }
 
-func (key KeyV2) Raw() Raw {
-	return key.privateKey.D.Bytes()
+// IsRemote returns true if the private key is held by a remote signer rather than by the keystore.
+func (key KeyV2) IsRemote() bool {
+	return key.privateKey == nil
 }
 
-func (key KeyV2) ToEcdsaPrivKey() *ecdsa.PrivateKey {
-	return key.privateKey
+// Raw returns the private key, or ErrRemoteKey if it is held by a remote signer.
+func (key KeyV2) Raw() (Raw, error) {
+	if key.IsRemote() {
+		return nil, ErrRemoteKey
+	}
+	return key.privateKey.D.Bytes(), nil
+}
+
+// ToEcdsaPrivKey returns the private key, or ErrRemoteKey if it is held by a remote signer.
+func (key KeyV2) ToEcdsaPrivKey() (*ecdsa.PrivateKey, error) {
+	if key.IsRemote() {
+		return nil, ErrRemoteKey
+	}
+	return key.privateKey, nil
 }
 
 func (key KeyV2) String() string {
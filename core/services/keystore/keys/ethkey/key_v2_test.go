@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
 	"github.com/smartcontractkit/chainlink/v2/evm/types"
 )
 
@@ -43,3 +44,19 @@ func TestEthKeyV2_NewV2(t *testing.T) {
 	assert.NotNil(t, keyV2.privateKey)
 	assert.Equal(t, keyV2.Address.Hex(), keyV2.ID())
 }
+
+func TestEthKeyV2_FromAddress(t *testing.T) {
+	keyV2, err := NewV2()
+	require.NoError(t, err)
+
+	k := FromAddress(keyV2.Address)
+	assert.True(t, k.IsRemote())
+	assert.Equal(t, keyV2.Address.Hex(), k.ID())
+
+	_, err = k.Raw()
+	require.ErrorIs(t, err, ErrRemoteKey)
+	_, err = k.ToEcdsaPrivKey()
+	require.ErrorIs(t, err, ErrRemoteKey)
+	_, err = k.ToEncryptedJSON("password", utils.FastScryptParams)
+	require.ErrorIs(t, err, ErrRemoteKey)
+}
@@ -63,13 +63,15 @@ func NewInMemory(ds sqlutil.DataSource, scryptParams utils.ScryptParams, lggr lo
 		logger:       lggr.Named("KeyStore"),
 	}
 
+	eth := newEthKeyStore(km, dbORM, ds)
+	ocr2 := newOCR2KeyStore(km)
 	return &master{
 		keyManager: km,
 		cosmos:     newCosmosKeyStore(km),
 		csa:        newCSAKeyStore(km),
-		eth:        newEthKeyStore(km, dbORM, ds),
+		eth:        eth,
 		ocr:        newOCRKeyStore(km),
-		ocr2:       newOCR2KeyStore(km),
+		ocr2:       ocr2,
 		p2p:        newP2PKeyStore(km),
 		solana:     newSolanaKeyStore(km),
 		starknet:   newStarkNetKeyStore(km),
@@ -77,5 +79,14 @@ func NewInMemory(ds sqlutil.DataSource, scryptParams utils.ScryptParams, lggr lo
 		tron:       newTronKeyStore(km),
 		vrf:        newVRFKeyStore(km),
 		workflow:   newWorkflowKeyStore(km),
+		rotation:   newRotation(ds, eth, ocr2),
 	}
 }
+
+// NewInMemoryWithRemoteSigner is NewInMemory with an eth keystore which signs with the keys added by AddRemote
+// through remoteSigner.
+func NewInMemoryWithRemoteSigner(ds sqlutil.DataSource, scryptParams utils.ScryptParams, lggr logger.Logger, remoteSigner RemoteSigner) *master {
+	m := NewInMemory(ds, scryptParams, lggr)
+	m.eth.remoteSigner = remoteSigner
+	return m
+}
@@ -49,6 +49,7 @@ type Master interface {
 	Tron() Tron
 	VRF() VRF
 	Workflow() Workflow
+	Rotation() Rotation
 	Unlock(ctx context.Context, password string) error
 	IsEmpty(ctx context.Context) (bool, error)
 }
@@ -67,12 +68,21 @@ type master struct {
 	tron     *tron
 	vrf      *vrf
 	workflow *workflow
+	rotation *rotation
 }
 
 func New(ds sqlutil.DataSource, scryptParams utils.ScryptParams, lggr logger.Logger) Master {
 	return newMaster(ds, scryptParams, lggr)
 }
 
+// NewWithRemoteSigner returns a Master whose eth keystore signs with the keys added by Eth().AddRemote through
+// remoteSigner.
+func NewWithRemoteSigner(ds sqlutil.DataSource, scryptParams utils.ScryptParams, lggr logger.Logger, remoteSigner RemoteSigner) Master {
+	m := newMaster(ds, scryptParams, lggr)
+	m.eth.remoteSigner = remoteSigner
+	return m
+}
+
 func newMaster(ds sqlutil.DataSource, scryptParams utils.ScryptParams, lggr logger.Logger) *master {
 	orm := NewORM(ds, lggr)
 	km := &keyManager{
@@ -83,13 +93,15 @@ func newMaster(ds sqlutil.DataSource, scryptParams utils.ScryptParams, lggr logg
 		logger:       lggr.Named("KeyStore"),
 	}
 
+	eth := newEthKeyStore(km, orm, orm.ds)
+	ocr2 := newOCR2KeyStore(km)
 	return &master{
 		keyManager: km,
 		cosmos:     newCosmosKeyStore(km),
 		csa:        newCSAKeyStore(km),
-		eth:        newEthKeyStore(km, orm, orm.ds),
+		eth:        eth,
 		ocr:        newOCRKeyStore(km),
-		ocr2:       newOCR2KeyStore(km),
+		ocr2:       ocr2,
 		p2p:        newP2PKeyStore(km),
 		solana:     newSolanaKeyStore(km),
 		starknet:   newStarkNetKeyStore(km),
@@ -97,6 +109,7 @@ func newMaster(ds sqlutil.DataSource, scryptParams utils.ScryptParams, lggr logg
 		tron:       newTronKeyStore(km),
 		vrf:        newVRFKeyStore(km),
 		workflow:   newWorkflowKeyStore(km),
+		rotation:   newRotation(orm.ds, eth, ocr2),
 	}
 }
 
@@ -148,6 +161,10 @@ func (ks *master) Workflow() Workflow {
 	return ks.workflow
 }
 
+func (ks *master) Rotation() Rotation {
+	return ks.rotation
+}
+
 type ORM interface {
 	isEmpty(context.Context) (bool, error)
 	saveEncryptedKeyRing(context.Context, *encryptedKeyRing, ...func(sqlutil.DataSource) error) error
@@ -407,6 +407,53 @@ func (_c *Master_P2P_Call) RunAndReturn(run func() keystore.P2P) *Master_P2P_Cal
 	return _c
 }
 
+// Rotation provides a mock function with no fields
+func (_m *Master) Rotation() keystore.Rotation {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rotation")
+	}
+
+	var r0 keystore.Rotation
+	if rf, ok := ret.Get(0).(func() keystore.Rotation); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(keystore.Rotation)
+		}
+	}
+
+	return r0
+}
+
+// Master_Rotation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Rotation'
+type Master_Rotation_Call struct {
+	*mock.Call
+}
+
+// Rotation is a helper method to define mock.On call
+func (_e *Master_Expecter) Rotation() *Master_Rotation_Call {
+	return &Master_Rotation_Call{Call: _e.mock.On("Rotation")}
+}
+
+func (_c *Master_Rotation_Call) Run(run func()) *Master_Rotation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Master_Rotation_Call) Return(_a0 keystore.Rotation) *Master_Rotation_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Master_Rotation_Call) RunAndReturn(run func() keystore.Rotation) *Master_Rotation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Solana provides a mock function with no fields
 func (_m *Master) Solana() keystore.Solana {
 	ret := _m.Called()
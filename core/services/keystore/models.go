@@ -70,12 +70,15 @@ type keyStates struct {
 	// Chain ID => Key ID => state
 	ChainIDKeyID map[string]map[string]*ethkey.State
 	All          []*ethkey.State
+	// Key ID => true if the key is draining in a rotation
+	Draining map[string]bool
 }
 
 func newKeyStates() *keyStates {
 	return &keyStates{
 		KeyIDChainID: make(map[string]map[string]*ethkey.State),
 		ChainIDKeyID: make(map[string]map[string]*ethkey.State),
+		Draining:     make(map[string]bool),
 	}
 }
 
@@ -217,7 +220,12 @@ func (kr *keyRing) raw() (rawKeys rawKeyRing) {
 		rawKeys.CSA = append(rawKeys.CSA, csaKey.Raw())
 	}
 	for _, ethKey := range kr.Eth {
-		rawKeys.Eth = append(rawKeys.Eth, ethKey.Raw())
+		raw, err := ethKey.Raw()
+		if errors.Is(err, ethkey.ErrRemoteKey) {
+			rawKeys.RemoteEth = append(rawKeys.RemoteEth, ethKey.Address)
+			continue
+		}
+		rawKeys.Eth = append(rawKeys.Eth, raw)
 	}
 	for _, ocrKey := range kr.OCR {
 		rawKeys.OCR = append(rawKeys.OCR, ocrKey.Raw())
@@ -350,6 +358,7 @@ func (kr *keyRing) logPubKeys(lggr logger.Logger) {
 // (like public keys) to the database
 type rawKeyRing struct {
 	Eth        []ethkey.Raw
+	RemoteEth  []common.Address // eth keys held by the remote signer are stored by address only
 	CSA        []csakey.Raw
 	OCR        []ocrkey.Raw
 	OCR2       []ocr2key.Raw
@@ -374,6 +383,10 @@ func (rawKeys rawKeyRing) keys() (*keyRing, error) {
 		ethKey := rawETHKey.Key()
 		keyRing.Eth[ethKey.ID()] = ethKey
 	}
+	for _, address := range rawKeys.RemoteEth {
+		ethKey := ethkey.FromAddress(address)
+		keyRing.Eth[ethKey.ID()] = ethKey
+	}
 	for _, rawOCRKey := range rawKeys.OCR {
 		ocrKey := rawOCRKey.Key()
 		keyRing.OCR[ocrKey.ID()] = ocrKey
@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/chaintype"
@@ -42,16 +43,22 @@ func TestKeyRing_Encrypt_Decrypt(t *testing.T) {
 	vrf1, vrf2 := vrfkey.MustNewV2XXXTestingOnly(big.NewInt(1)), vrfkey.MustNewV2XXXTestingOnly(big.NewInt(2))
 	tk1, tk2 := cosmoskey.MustNewInsecure(rand.Reader), cosmoskey.MustNewInsecure(rand.Reader)
 	uk1, uk2 := tronkey.MustNewInsecure(rand.Reader), tronkey.MustNewInsecure(rand.Reader)
+	eth1Raw, err := eth1.Raw()
+	require.NoError(t, err)
+	eth2Raw, err := eth2.Raw()
+	require.NoError(t, err)
+	remoteEth := ethkey.FromAddress(common.HexToAddress("0x1000000000000000000000000000000000000001"))
 	originalKeyRingRaw := rawKeyRing{
-		CSA:    []csakey.Raw{csa1.Raw(), csa2.Raw()},
-		Eth:    []ethkey.Raw{eth1.Raw(), eth2.Raw()},
-		OCR:    []ocrkey.Raw{ocr[0].Raw(), ocr[1].Raw()},
-		OCR2:   ocr2_raw,
-		P2P:    []p2pkey.Raw{p2p1.Raw(), p2p2.Raw()},
-		Solana: []solkey.Raw{sol1.Raw(), sol2.Raw()},
-		VRF:    []vrfkey.Raw{vrf1.Raw(), vrf2.Raw()},
-		Cosmos: []cosmoskey.Raw{tk1.Raw(), tk2.Raw()},
-		Tron:   []tronkey.Raw{uk1.Raw(), uk2.Raw()},
+		CSA:       []csakey.Raw{csa1.Raw(), csa2.Raw()},
+		Eth:       []ethkey.Raw{eth1Raw, eth2Raw},
+		RemoteEth: []common.Address{remoteEth.Address},
+		OCR:       []ocrkey.Raw{ocr[0].Raw(), ocr[1].Raw()},
+		OCR2:      ocr2_raw,
+		P2P:       []p2pkey.Raw{p2p1.Raw(), p2p2.Raw()},
+		Solana:    []solkey.Raw{sol1.Raw(), sol2.Raw()},
+		VRF:       []vrfkey.Raw{vrf1.Raw(), vrf2.Raw()},
+		Cosmos:    []cosmoskey.Raw{tk1.Raw(), tk2.Raw()},
+		Tron:      []tronkey.Raw{uk1.Raw(), uk2.Raw()},
 	}
 	originalKeyRing, kerr := originalKeyRingRaw.keys()
 	require.NoError(t, kerr)
@@ -74,9 +81,12 @@ func TestKeyRing_Encrypt_Decrypt(t *testing.T) {
 		require.Equal(t, originalKeyRing.CSA[csa1.ID()].PublicKey, decryptedKeyRing.CSA[csa1.ID()].PublicKey)
 		require.Equal(t, originalKeyRing.CSA[csa2.ID()].PublicKey, decryptedKeyRing.CSA[csa2.ID()].PublicKey)
 		// compare eth keys
-		require.Equal(t, 2, len(decryptedKeyRing.Eth))
+		require.Equal(t, 3, len(decryptedKeyRing.Eth))
 		require.Equal(t, originalKeyRing.Eth[eth1.ID()].Address, decryptedKeyRing.Eth[eth1.ID()].Address)
 		require.Equal(t, originalKeyRing.Eth[eth2.ID()].Address, decryptedKeyRing.Eth[eth2.ID()].Address)
+		require.False(t, decryptedKeyRing.Eth[eth1.ID()].IsRemote())
+		require.Equal(t, remoteEth.Address, decryptedKeyRing.Eth[remoteEth.ID()].Address)
+		require.True(t, decryptedKeyRing.Eth[remoteEth.ID()].IsRemote())
 		// compare ocr keys
 		require.Equal(t, 2, len(decryptedKeyRing.OCR))
 		require.Equal(t, originalKeyRing.OCR[ocr[0].ID()].OnChainSigning.X, decryptedKeyRing.OCR[ocr[0].ID()].OnChainSigning.X)
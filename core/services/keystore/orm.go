@@ -76,5 +76,13 @@ func (orm ksORM) loadKeyStates(ctx context.Context) (*keyStates, error) {
 	for _, state := range ethkeystates {
 		ks.add(state)
 	}
+	var draining []string
+	if err := orm.ds.SelectContext(ctx, &draining, `SELECT old_key_id FROM key_rotations WHERE key_type = $1 AND state = $2`,
+		RotationKeyTypeEth, KeyRotationStateDraining); err != nil {
+		return ks, errors.Wrap(err, "error loading draining keys from DB")
+	}
+	for _, keyID := range draining {
+		ks.Draining[keyID] = true
+	}
 	return ks, nil
 }
@@ -0,0 +1,154 @@
+package keystore
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/url"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+)
+
+// remoteSignerTimeout bounds each request to the remote signer
+const remoteSignerTimeout = 10 * time.Second
+
+var ErrNoRemoteSigner = errors.New("no remote signer is configured")
+
+// RemoteSigner signs with eth keys whose private keys never leave the remote signer.
+type RemoteSigner interface {
+	SignTx(ctx context.Context, address common.Address, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+	SignMessage(ctx context.Context, address common.Address, data []byte) ([]byte, error)
+}
+
+var _ RemoteSigner = (*web3Signer)(nil)
+
+// web3Signer is a RemoteSigner speaking the eth_signTransaction and eth_sign JSON-RPC methods of web3signer, and of
+// any other signer compatible with it.
+type web3Signer struct {
+	client *rpc.Client
+}
+
+// NewWeb3Signer returns a RemoteSigner for the web3signer compatible JSON-RPC endpoint at u.
+func NewWeb3Signer(u *url.URL) (RemoteSigner, error) {
+	client, err := rpc.DialContext(context.Background(), u.String())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to dial remote signer")
+	}
+	return &web3Signer{client: client}, nil
+}
+
+type web3SignerTxArgs struct {
+	From                 common.Address    `json:"from"`
+	To                   *common.Address   `json:"to,omitempty"`
+	Gas                  hexutil.Uint64    `json:"gas"`
+	GasPrice             *hexutil.Big      `json:"gasPrice,omitempty"`
+	MaxFeePerGas         *hexutil.Big      `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big      `json:"maxPriorityFeePerGas,omitempty"`
+	Value                *hexutil.Big      `json:"value"`
+	Nonce                hexutil.Uint64    `json:"nonce"`
+	Data                 hexutil.Bytes     `json:"data"`
+	ChainID              *hexutil.Big      `json:"chainId"`
+	AccessList           *types.AccessList `json:"accessList,omitempty"`
+}
+
+func (s *web3Signer) SignTx(ctx context.Context, address common.Address, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	args := web3SignerTxArgs{
+		From:    address,
+		To:      tx.To(),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   (*hexutil.Big)(tx.Value()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		Data:    tx.Data(),
+		ChainID: (*hexutil.Big)(chainID),
+	}
+	switch tx.Type() {
+	case types.LegacyTxType:
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	case types.AccessListTxType:
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+		accessList := tx.AccessList()
+		args.AccessList = &accessList
+	case types.DynamicFeeTxType:
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+		accessList := tx.AccessList()
+		args.AccessList = &accessList
+	default:
+		return nil, errors.Errorf("remote signer does not support transactions of type %d", tx.Type())
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, remoteSignerTimeout)
+	defer cancel()
+	var result json.RawMessage
+	if err := s.client.CallContext(ctx, &result, "eth_signTransaction", args); err != nil {
+		return nil, errors.Wrap(err, "remote signer failed to sign transaction")
+	}
+	raw, err := decodeSignedTransaction(result)
+	if err != nil {
+		return nil, err
+	}
+	signed := new(types.Transaction)
+	if err = signed.UnmarshalBinary(raw); err != nil {
+		return nil, errors.Wrap(err, "remote signer returned an invalid transaction")
+	}
+	// the signing hash covers every field of the transaction other than the signature, including the fees and chain ID
+	signer := types.LatestSignerForChainID(chainID)
+	if signer.Hash(signed) != signer.Hash(tx) {
+		return nil, errors.New("remote signer returned a different transaction")
+	}
+	from, err := types.Sender(signer, signed)
+	if err != nil {
+		return nil, errors.Wrap(err, "remote signer returned an invalid signature")
+	}
+	if from != address {
+		return nil, errors.Errorf("remote signer signed with %s rather than %s", from, address)
+	}
+	return signed, nil
+}
+
+// decodeSignedTransaction accepts both the raw transaction returned by web3signer and the {raw, tx} object returned
+// by geth compatible signers.
+func decodeSignedTransaction(result json.RawMessage) ([]byte, error) {
+	var raw hexutil.Bytes
+	if err := json.Unmarshal(result, &raw); err == nil {
+		return raw, nil
+	}
+	var obj struct {
+		Raw hexutil.Bytes `json:"raw"`
+	}
+	if err := json.Unmarshal(result, &obj); err != nil || len(obj.Raw) == 0 {
+		return nil, errors.Errorf("remote signer returned an unexpected result: %s", string(result))
+	}
+	return obj.Raw, nil
+}
+
+func (s *web3Signer) SignMessage(ctx context.Context, address common.Address, data []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, remoteSignerTimeout)
+	defer cancel()
+	var signature hexutil.Bytes
+	if err := s.client.CallContext(ctx, &signature, "eth_sign", address, hexutil.Bytes(data)); err != nil {
+		return nil, errors.Wrap(err, "remote signer failed to sign data")
+	}
+	if len(signature) != crypto.SignatureLength {
+		return nil, errors.Errorf("remote signer returned a signature of %d bytes", len(signature))
+	}
+	// eth_sign returns the recovery id in the Ethereum convention of 27 or 28, whereas the keystore returns 0 or 1
+	if signature[crypto.RecoveryIDOffset] >= 27 {
+		signature[crypto.RecoveryIDOffset] -= 27
+	}
+	pubKey, err := crypto.SigToPub(accounts.TextHash(data), signature)
+	if err != nil {
+		return nil, errors.Wrap(err, "remote signer returned an invalid signature")
+	}
+	if from := crypto.PubkeyToAddress(*pubKey); from != address {
+		return nil, errors.Errorf("remote signer signed with %s rather than %s", from, address)
+	}
+	return signature, nil
+}
@@ -0,0 +1,164 @@
+package keystore_test
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore"
+)
+
+// stubWeb3Signer serves eth_signTransaction and eth_sign like web3signer, signing with key.
+type stubWeb3Signer struct {
+	key *ecdsa.PrivateKey
+	// object makes eth_signTransaction return a {raw, tx} object rather than the raw transaction
+	object bool
+	// feeBump is added to the fees of the transactions signed, as a misbehaving signer would
+	feeBump int64
+}
+
+type stubWeb3SignerTxArgs struct {
+	To                   *common.Address `json:"to"`
+	Gas                  hexutil.Uint64  `json:"gas"`
+	GasPrice             *hexutil.Big    `json:"gasPrice"`
+	MaxFeePerGas         *hexutil.Big    `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big    `json:"maxPriorityFeePerGas"`
+	Value                *hexutil.Big    `json:"value"`
+	Nonce                hexutil.Uint64  `json:"nonce"`
+	Data                 hexutil.Bytes   `json:"data"`
+	ChainID              *hexutil.Big    `json:"chainId"`
+}
+
+func (s *stubWeb3Signer) SignTransaction(args stubWeb3SignerTxArgs) (interface{}, error) {
+	bump := func(fee *hexutil.Big) *big.Int { return new(big.Int).Add(fee.ToInt(), big.NewInt(s.feeBump)) }
+	var tx *types.Transaction
+	if args.MaxFeePerGas != nil {
+		tx = types.NewTx(&types.DynamicFeeTx{
+			ChainID:   args.ChainID.ToInt(),
+			Nonce:     uint64(args.Nonce),
+			GasTipCap: args.MaxPriorityFeePerGas.ToInt(),
+			GasFeeCap: bump(args.MaxFeePerGas),
+			Gas:       uint64(args.Gas),
+			To:        args.To,
+			Value:     args.Value.ToInt(),
+			Data:      args.Data,
+		})
+	} else {
+		tx = types.NewTx(&types.LegacyTx{
+			Nonce:    uint64(args.Nonce),
+			GasPrice: bump(args.GasPrice),
+			Gas:      uint64(args.Gas),
+			To:       args.To,
+			Value:    args.Value.ToInt(),
+			Data:     args.Data,
+		})
+	}
+	signed, err := types.SignTx(tx, types.LatestSignerForChainID(args.ChainID.ToInt()), s.key)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := signed.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if s.object {
+		return map[string]interface{}{"raw": hexutil.Bytes(raw), "tx": signed}, nil
+	}
+	return hexutil.Bytes(raw), nil
+}
+
+func (s *stubWeb3Signer) Sign(address common.Address, data hexutil.Bytes) (hexutil.Bytes, error) {
+	signature, err := crypto.Sign(accounts.TextHash(data), s.key)
+	if err != nil {
+		return nil, err
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+	return signature, nil
+}
+
+func newStubWeb3Signer(t *testing.T, stub *stubWeb3Signer) keystore.RemoteSigner {
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", stub))
+	ts := httptest.NewServer(server)
+	t.Cleanup(ts.Close)
+	t.Cleanup(server.Stop)
+
+	u, err := url.Parse(ts.URL)
+	require.NoError(t, err)
+	signer, err := keystore.NewWeb3Signer(u)
+	require.NoError(t, err)
+	return signer
+}
+
+func TestWeb3Signer(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	chainID := big.NewInt(1337)
+	to := testutils.NewAddress()
+
+	txs := map[string]*types.Transaction{
+		"legacy":      types.NewTx(&types.LegacyTx{Nonce: 3, GasPrice: big.NewInt(1e9), Gas: 21000, To: &to, Value: big.NewInt(53), Data: []byte{1, 2, 3, 4}}),
+		"dynamic fee": types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Nonce: 3, GasTipCap: big.NewInt(1e9), GasFeeCap: big.NewInt(2e9), Gas: 21000, To: &to, Value: big.NewInt(53)}),
+	}
+	for name, tx := range txs {
+		t.Run("signs "+name+" transactions", func(t *testing.T) {
+			for _, object := range []bool{false, true} {
+				signer := newStubWeb3Signer(t, &stubWeb3Signer{key: key, object: object})
+				signed, err := signer.SignTx(ctx, address, tx, chainID)
+				require.NoError(t, err)
+
+				from, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+				require.NoError(t, err)
+				assert.Equal(t, address, from)
+				assert.Equal(t, tx.Type(), signed.Type())
+				assert.Equal(t, tx.Nonce(), signed.Nonce())
+			}
+		})
+	}
+
+	t.Run("signs messages", func(t *testing.T) {
+		signer := newStubWeb3Signer(t, &stubWeb3Signer{key: key})
+		message := []byte("this is a message")
+
+		signature, err := signer.SignMessage(ctx, address, message)
+		require.NoError(t, err)
+		pubKey, err := crypto.Ecrecover(accounts.TextHash(message), signature)
+		require.NoError(t, err)
+		assert.Equal(t, crypto.FromECDSAPub(&key.PublicKey), pubKey)
+	})
+
+	t.Run("rejects signatures of other keys", func(t *testing.T) {
+		signer := newStubWeb3Signer(t, &stubWeb3Signer{key: key})
+		other := testutils.NewAddress()
+
+		_, err := signer.SignTx(ctx, other, txs["legacy"], chainID)
+		require.ErrorContains(t, err, "rather than "+other.String())
+		_, err = signer.SignMessage(ctx, other, []byte("this is a message"))
+		require.ErrorContains(t, err, "rather than "+other.String())
+	})
+
+	t.Run("rejects transactions with different fees", func(t *testing.T) {
+		signer := newStubWeb3Signer(t, &stubWeb3Signer{key: key, feeBump: 1})
+
+		for _, tx := range txs {
+			_, err := signer.SignTx(ctx, address, tx, chainID)
+			require.ErrorContains(t, err, "remote signer returned a different transaction")
+		}
+	})
+}
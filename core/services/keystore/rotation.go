@@ -0,0 +1,433 @@
+package keystore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/ethkey"
+)
+
+var (
+	ErrKeyRotating       = errors.New("key is already being rotated")
+	ErrKeyReferenced     = errors.New("key is still referenced")
+	ErrKeyDraining       = errors.New("key still has in-flight transactions")
+	ErrRotationNotFound  = errors.New("key rotation not found")
+	ErrRotationNotActive = errors.New("key rotation is not draining")
+)
+
+// RotationKeyType is the type of key which can be rotated.
+type RotationKeyType string
+
+const (
+	RotationKeyTypeEth  RotationKeyType = "eth"
+	RotationKeyTypeOCR2 RotationKeyType = "ocr2"
+)
+
+// KeyRotationState is the state of a key rotation.
+type KeyRotationState string
+
+const (
+	// KeyRotationStateDraining is the state of a rotation whose old key is still in use, and waits for its references
+	// to be moved to the new key and for its in-flight transactions to be confirmed.
+	KeyRotationStateDraining KeyRotationState = "draining"
+	// KeyRotationStateRetired is the state of a rotation whose old key has been disabled, or deleted.
+	KeyRotationStateRetired KeyRotationState = "retired"
+)
+
+// KeyRotation links the old key to its successor, and records when the old key was retired.
+type KeyRotation struct {
+	ID        int64            `db:"id"`
+	KeyType   RotationKeyType  `db:"key_type"`
+	OldKeyID  string           `db:"old_key_id"`
+	NewKeyID  string           `db:"new_key_id"`
+	State     KeyRotationState `db:"state"`
+	CreatedAt time.Time        `db:"created_at"`
+	UpdatedAt time.Time        `db:"updated_at"`
+	// RetireAfter is the time after which the old key is retired automatically, once it is no longer referenced and
+	// has no in-flight transactions. The old key is only retired manually if nil.
+	RetireAfter *time.Time `db:"retire_after"`
+	RetiredAt   *time.Time `db:"retired_at"`
+}
+
+// KeyReferenceKind is the kind of resource which references a key.
+type KeyReferenceKind string
+
+const (
+	KeyReferenceKindJob              KeyReferenceKind = "job"
+	KeyReferenceKindFeedsChainConfig KeyReferenceKind = "feeds_manager_chain_config"
+	// KeyReferenceKindForwarder is a forwarder which authorizes the key on-chain. It may need to authorize the new key,
+	// but does not prevent the old key from being retired. Forwarders are not listed by Rotation.References, as their
+	// authorized senders have to be read from the chain.
+	KeyReferenceKindForwarder KeyReferenceKind = "forwarder"
+)
+
+// KeyReference is a resource which references a key.
+type KeyReference struct {
+	Kind KeyReferenceKind
+	ID   int64
+	Name string
+}
+
+// BlocksRetirement returns true if the old key of a rotation cannot be retired while the reference remains.
+func (r KeyReference) BlocksRetirement() bool {
+	return r.Kind != KeyReferenceKindForwarder
+}
+
+// Rotation rotates eth and OCR2 keys. Rotating a key creates a successor, which is linked to it. The old key keeps
+// working while it drains, until it is retired, but an eth key is no longer picked for new transactions unless they
+// are sent from it explicitly. The rotations are kept as history.
+type Rotation interface {
+	// Rotate creates the successor of the key. The successor of an eth key is enabled for the chains of the key. The
+	// old key is retired automatically after retireAfter if not nil.
+	Rotate(ctx context.Context, keyType RotationKeyType, keyID string, retireAfter *time.Time) (KeyRotation, error)
+	// Get returns the rotation with the given id.
+	Get(ctx context.Context, id int64) (KeyRotation, error)
+	// History returns the rotations of the key, as the old or the new key, or of every key if keyID is empty.
+	History(ctx context.Context, keyID string) ([]KeyRotation, error)
+	// DueRetirements returns the draining rotations whose RetireAfter has passed.
+	DueRetirements(ctx context.Context) ([]KeyRotation, error)
+	// References returns the job specs and feeds manager chain configs which reference the key.
+	References(ctx context.Context, keyType RotationKeyType, keyID string) ([]KeyReference, error)
+	// InFlightTxs returns the number of transactions of the eth key which are not confirmed yet.
+	InFlightTxs(ctx context.Context, keyType RotationKeyType, keyID string) (int64, error)
+	// Retire retires the old key of the rotation once it is no longer referenced, and has no in-flight transactions.
+	// An eth key is disabled for its chains before its in-flight transactions are counted, and enabled again if any
+	// remain. The old key is deleted once retired if deleteKey is true.
+	Retire(ctx context.Context, id int64, deleteKey bool) (KeyRotation, error)
+}
+
+type rotation struct {
+	ds   sqlutil.DataSource
+	eth  *eth
+	ocr2 OCR2
+
+	// retireMu serializes retirements, so that a key is not enabled again by a failed one while another retires it
+	retireMu sync.Mutex
+}
+
+var _ Rotation = (*rotation)(nil)
+
+func newRotation(ds sqlutil.DataSource, eth *eth, ocr2 OCR2) *rotation {
+	return &rotation{ds: ds, eth: eth, ocr2: ocr2}
+}
+
+func (r *rotation) new(ds sqlutil.DataSource) *rotation { return newRotation(ds, r.eth, r.ocr2) }
+
+func (r *rotation) transact(ctx context.Context, fn func(*rotation) error) error {
+	return sqlutil.Transact(ctx, r.new, r.ds, nil, fn)
+}
+
+func (r *rotation) Rotate(ctx context.Context, keyType RotationKeyType, keyID string, retireAfter *time.Time) (KeyRotation, error) {
+	var oldKeyID, newKeyID string
+	var deleteNewKey func() error
+	switch keyType {
+	case RotationKeyTypeEth:
+		if !common.IsHexAddress(keyID) {
+			return KeyRotation{}, errors.Errorf("invalid eth key %s, must be hex address", keyID)
+		}
+		oldKey, err := r.eth.Get(ctx, common.HexToAddress(keyID).Hex())
+		if err != nil {
+			return KeyRotation{}, err
+		}
+		chainIDs, err := r.enabledChainIDs(ctx, oldKey)
+		if err != nil {
+			return KeyRotation{}, err
+		}
+		if len(chainIDs) == 0 {
+			return KeyRotation{}, errors.Errorf("eth key %s is not enabled for any chain", oldKey.ID())
+		}
+		if err = r.checkNotRotating(ctx, keyType, oldKey.ID()); err != nil {
+			return KeyRotation{}, err
+		}
+		newKey, err := r.eth.Create(ctx, chainIDs...)
+		if err != nil {
+			return KeyRotation{}, errors.Wrap(err, "failed to create successor key")
+		}
+		oldKeyID, newKeyID = oldKey.ID(), newKey.ID()
+		deleteNewKey = func() error {
+			_, err2 := r.eth.Delete(ctx, newKey.ID())
+			return err2
+		}
+	case RotationKeyTypeOCR2:
+		oldKey, err := r.ocr2.Get(keyID)
+		if err != nil {
+			return KeyRotation{}, err
+		}
+		if err = r.checkNotRotating(ctx, keyType, oldKey.ID()); err != nil {
+			return KeyRotation{}, err
+		}
+		newKey, err := r.ocr2.Create(ctx, oldKey.ChainType())
+		if err != nil {
+			return KeyRotation{}, errors.Wrap(err, "failed to create successor key")
+		}
+		oldKeyID, newKeyID = oldKey.ID(), newKey.ID()
+		deleteNewKey = func() error {
+			return r.ocr2.Delete(ctx, newKey.ID())
+		}
+	default:
+		return KeyRotation{}, errors.Errorf("unsupported key type for rotation: %s", keyType)
+	}
+
+	var kr KeyRotation
+	err := r.ds.GetContext(ctx, &kr, `INSERT INTO key_rotations (key_type, old_key_id, new_key_id, state, retire_after, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW(), NOW()) RETURNING *`, keyType, oldKeyID, newKeyID, KeyRotationStateDraining, retireAfter)
+	if err != nil {
+		// do not leave behind a successor which is not linked to its predecessor
+		if err2 := deleteNewKey(); err2 != nil {
+			err = errors.Wrapf(err, "failed to delete successor key %s: %v", newKeyID, err2)
+		}
+		return KeyRotation{}, errors.Wrap(err, "failed to insert key rotation")
+	}
+	if keyType == RotationKeyTypeEth {
+		r.eth.setDraining(common.HexToAddress(oldKeyID), true)
+	}
+	return kr, nil
+}
+
+func (r *rotation) checkNotRotating(ctx context.Context, keyType RotationKeyType, keyID string) error {
+	var id int64
+	err := r.ds.GetContext(ctx, &id, `SELECT id FROM key_rotations WHERE key_type = $1 AND old_key_id = $2 AND state = $3`,
+		keyType, keyID, KeyRotationStateDraining)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "failed to check key rotations")
+	}
+	return errors.Wrapf(ErrKeyRotating, "key %s is draining in rotation %d", keyID, id)
+}
+
+func (r *rotation) enabledChainIDs(ctx context.Context, key ethkey.KeyV2) (chainIDs []*big.Int, err error) {
+	states, err := r.eth.GetStatesForKeys(ctx, []ethkey.KeyV2{key})
+	if err != nil {
+		return nil, err
+	}
+	for _, state := range states {
+		if !state.Disabled {
+			chainIDs = append(chainIDs, state.EVMChainID.ToInt())
+		}
+	}
+	return
+}
+
+func (r *rotation) Get(ctx context.Context, id int64) (kr KeyRotation, err error) {
+	err = r.ds.GetContext(ctx, &kr, `SELECT * FROM key_rotations WHERE id = $1`, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return kr, ErrRotationNotFound
+	}
+	return kr, errors.Wrap(err, "failed to get key rotation")
+}
+
+func (r *rotation) History(ctx context.Context, keyID string) (krs []KeyRotation, err error) {
+	if keyID == "" {
+		err = r.ds.SelectContext(ctx, &krs, `SELECT * FROM key_rotations ORDER BY id`)
+	} else {
+		err = r.ds.SelectContext(ctx, &krs, `SELECT * FROM key_rotations WHERE lower(old_key_id) = lower($1) OR lower(new_key_id) = lower($1) ORDER BY id`, keyID)
+	}
+	return krs, errors.Wrap(err, "failed to get key rotations")
+}
+
+func (r *rotation) DueRetirements(ctx context.Context) (krs []KeyRotation, err error) {
+	err = r.ds.SelectContext(ctx, &krs, `SELECT * FROM key_rotations WHERE state = $1 AND retire_after <= NOW() ORDER BY id`,
+		KeyRotationStateDraining)
+	return krs, errors.Wrap(err, "failed to get key rotations due to be retired")
+}
+
+type keyReferenceRow struct {
+	ID   int64          `db:"id"`
+	Name sql.NullString `db:"name"`
+}
+
+func (r *rotation) selectReferences(ctx context.Context, refs []KeyReference, kind KeyReferenceKind, query string, args ...any) ([]KeyReference, error) {
+	var rows []keyReferenceRow
+	if err := r.ds.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to get %s references", kind)
+	}
+	for _, row := range rows {
+		refs = append(refs, KeyReference{Kind: kind, ID: row.ID, Name: row.Name.String})
+	}
+	return refs, nil
+}
+
+func (r *rotation) References(ctx context.Context, keyType RotationKeyType, keyID string) (refs []KeyReference, err error) {
+	switch keyType {
+	case RotationKeyTypeEth:
+		if !common.IsHexAddress(keyID) {
+			return nil, errors.Errorf("invalid eth key %s, must be hex address", keyID)
+		}
+		address := common.HexToAddress(keyID)
+		// pipelines are matched on any mention of the address, such as the from of an ethtx task
+		refs, err = r.selectReferences(ctx, refs, KeyReferenceKindJob, `SELECT id, name FROM jobs
+			WHERE ocr_oracle_spec_id IN (SELECT id FROM ocr_oracle_specs WHERE transmitter_address = $1)
+			OR ocr2_oracle_spec_id IN (SELECT id FROM ocr2_oracle_specs WHERE lower(transmitter_id) = lower($2)
+				OR EXISTS (SELECT 1 FROM jsonb_path_query(relay_config, '$.sendingKeys[*]') k WHERE lower(k #>> '{}') = lower($2)))
+			OR keeper_spec_id IN (SELECT id FROM keeper_specs WHERE from_address = $1)
+			OR vrf_spec_id IN (SELECT id FROM vrf_specs WHERE $1 = ANY(from_addresses))
+			OR blockhash_store_spec_id IN (SELECT id FROM blockhash_store_specs WHERE $1 = ANY(from_addresses))
+			OR block_header_feeder_spec_id IN (SELECT id FROM block_header_feeder_specs WHERE $1 = ANY(from_addresses))
+			OR pipeline_spec_id IN (SELECT id FROM pipeline_specs WHERE strpos(lower(dot_dag_source), lower($2)) > 0)
+			ORDER BY id`, address.Bytes(), address.Hex())
+		if err != nil {
+			return nil, err
+		}
+		return r.selectReferences(ctx, refs, KeyReferenceKindFeedsChainConfig, `SELECT id, chain_id AS name FROM feeds_manager_chain_configs
+			WHERE lower(account_address) = lower($1) ORDER BY id`, address.Hex())
+	case RotationKeyTypeOCR2:
+		refs, err = r.selectReferences(ctx, refs, KeyReferenceKindJob, `SELECT id, name FROM jobs
+			WHERE ocr2_oracle_spec_id IN (SELECT id FROM ocr2_oracle_specs WHERE ocr_key_bundle_id = $1)
+			ORDER BY id`, keyID)
+		if err != nil {
+			return nil, err
+		}
+		return r.selectReferences(ctx, refs, KeyReferenceKindFeedsChainConfig, `SELECT id, chain_id AS name FROM feeds_manager_chain_configs
+			WHERE ocr2_config->>'key_bundle_id' = $1 ORDER BY id`, keyID)
+	default:
+		return nil, errors.Errorf("unsupported key type for rotation: %s", keyType)
+	}
+}
+
+func (r *rotation) InFlightTxs(ctx context.Context, keyType RotationKeyType, keyID string) (count int64, err error) {
+	if keyType != RotationKeyTypeEth {
+		return 0, nil
+	}
+	if !common.IsHexAddress(keyID) {
+		return 0, errors.Errorf("invalid eth key %s, must be hex address", keyID)
+	}
+	err = r.ds.GetContext(ctx, &count, `SELECT count(*) FROM evm.txes
+		WHERE from_address = $1 AND state IN ('unstarted', 'in_progress', 'unconfirmed')`, common.HexToAddress(keyID))
+	return count, errors.Wrap(err, "failed to count in-flight transactions")
+}
+
+func (r *rotation) Retire(ctx context.Context, id int64, deleteKey bool) (kr KeyRotation, err error) {
+	r.retireMu.Lock()
+	defer r.retireMu.Unlock()
+
+	// check before disabling the key, so that it is not disabled needlessly
+	kr, err = r.Get(ctx, id)
+	if err != nil {
+		return KeyRotation{}, err
+	}
+	if err = r.checkRetirable(ctx, kr); err != nil {
+		return KeyRotation{}, err
+	}
+
+	// the key is disabled in memory and in the database before its in-flight transactions are counted, so that no
+	// transaction can be created from it once they are, and it is enabled again if any remain
+	var address common.Address
+	var disabledChainIDs []*big.Int
+	if kr.KeyType == RotationKeyTypeEth {
+		address = common.HexToAddress(kr.OldKeyID)
+		if disabledChainIDs, err = r.disableEthKey(ctx, address); err != nil {
+			return KeyRotation{}, err
+		}
+	}
+
+	err = r.transact(ctx, func(tx *rotation) error {
+		err2 := tx.ds.GetContext(ctx, &kr, `SELECT * FROM key_rotations WHERE id = $1 FOR UPDATE`, id)
+		if errors.Is(err2, sql.ErrNoRows) {
+			return ErrRotationNotFound
+		} else if err2 != nil {
+			return errors.Wrap(err2, "failed to get key rotation")
+		}
+		if err2 = tx.checkRetirable(ctx, kr); err2 != nil {
+			return err2
+		}
+		inFlight, err2 := tx.InFlightTxs(ctx, kr.KeyType, kr.OldKeyID)
+		if err2 != nil {
+			return err2
+		}
+		if inFlight > 0 {
+			return errors.Wrapf(ErrKeyDraining, "key %s has %d in-flight transactions", kr.OldKeyID, inFlight)
+		}
+
+		err2 = tx.ds.GetContext(ctx, &kr, `UPDATE key_rotations SET state = $2, retired_at = NOW(), updated_at = NOW()
+			WHERE id = $1 RETURNING *`, kr.ID, KeyRotationStateRetired)
+		return errors.Wrap(err2, "failed to update key rotation")
+	})
+	if err != nil {
+		if err2 := r.enableEthKey(ctx, address, disabledChainIDs); err2 != nil {
+			err = errors.Wrapf(err, "failed to enable key %s again: %v", kr.OldKeyID, err2)
+		}
+		return KeyRotation{}, err
+	}
+
+	switch kr.KeyType {
+	case RotationKeyTypeEth:
+		// the draining mark is only cleared once the key is disabled, so that it is never picked for new transactions
+		r.eth.setDraining(address, false)
+		if deleteKey {
+			if _, err = r.eth.Delete(ctx, address.Hex()); err != nil {
+				return kr, errors.Wrapf(err, "retired key %s, but failed to delete it", kr.OldKeyID)
+			}
+		}
+	case RotationKeyTypeOCR2:
+		if deleteKey {
+			if err = r.ocr2.Delete(ctx, kr.OldKeyID); err != nil {
+				return kr, errors.Wrapf(err, "retired key %s, but failed to delete it", kr.OldKeyID)
+			}
+		}
+	}
+	return kr, nil
+}
+
+// checkRetirable returns an error if the rotation is not draining, or if its old key is still referenced.
+func (r *rotation) checkRetirable(ctx context.Context, kr KeyRotation) error {
+	if kr.State != KeyRotationStateDraining {
+		return errors.Wrapf(ErrRotationNotActive, "key rotation %d is %s", kr.ID, kr.State)
+	}
+	refs, err := r.References(ctx, kr.KeyType, kr.OldKeyID)
+	if err != nil {
+		return err
+	}
+	var blocking int
+	for _, ref := range refs {
+		if ref.BlocksRetirement() {
+			blocking++
+		}
+	}
+	if blocking > 0 {
+		return errors.Wrapf(ErrKeyReferenced, "key %s is still referenced by %d job specs or feeds manager chain configs", kr.OldKeyID, blocking)
+	}
+	return nil
+}
+
+// disableEthKey disables the key for the chains it is enabled for, and returns them.
+func (r *rotation) disableEthKey(ctx context.Context, address common.Address) (chainIDs []*big.Int, err error) {
+	key, err := r.eth.Get(ctx, address.Hex())
+	if err != nil {
+		return nil, err
+	}
+	enabled, err := r.enabledChainIDs(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	for _, chainID := range enabled {
+		if err = r.eth.Disable(ctx, address, chainID); err != nil {
+			err = fmt.Errorf("failed to disable key %s for chain %s: %w", address, chainID, err)
+			if err2 := r.enableEthKey(ctx, address, chainIDs); err2 != nil {
+				err = errors.Wrapf(err, "failed to enable key %s again: %v", address, err2)
+			}
+			return nil, err
+		}
+		chainIDs = append(chainIDs, chainID)
+	}
+	return chainIDs, nil
+}
+
+// enableEthKey enables the key again for the chains it was disabled for by disableEthKey.
+func (r *rotation) enableEthKey(ctx context.Context, address common.Address, chainIDs []*big.Int) (err error) {
+	for _, chainID := range chainIDs {
+		if err2 := r.eth.Enable(ctx, address, chainID); err2 != nil {
+			err = multierr.Append(err, fmt.Errorf("chain %s: %w", chainID, err2))
+		}
+	}
+	return err
+}
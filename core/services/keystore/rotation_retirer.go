@@ -0,0 +1,61 @@
+package keystore
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+)
+
+// rotationRetirerInterval is how often the rotations due to be retired are checked.
+const rotationRetirerInterval = time.Minute
+
+type rotationRetirer struct {
+	services.Service
+	eng *services.Engine
+
+	rotation Rotation
+}
+
+// NewRotationRetirer returns a service which periodically retires the old keys of the rotations whose RetireAfter has
+// passed, once they are no longer referenced and have no in-flight transactions. The old keys are not deleted.
+func NewRotationRetirer(rotation Rotation, lggr logger.Logger) services.Service {
+	r := &rotationRetirer{rotation: rotation}
+	r.Service, r.eng = services.Config{
+		Name:  "KeyRotationRetirer",
+		Start: r.start,
+	}.NewServiceEngine(lggr)
+	return r
+}
+
+func (r *rotationRetirer) start(context.Context) error {
+	t := services.TickerConfig{
+		JitterPct: services.DefaultJitter,
+	}.NewTicker(rotationRetirerInterval)
+	r.eng.GoTick(t, r.retireDue)
+	return nil
+}
+
+func (r *rotationRetirer) retireDue(ctx context.Context) {
+	krs, err := r.rotation.DueRetirements(ctx)
+	if err != nil {
+		r.eng.Errorw("Failed to get key rotations due to be retired", "err", err)
+		return
+	}
+	for _, kr := range krs {
+		retired, err2 := r.rotation.Retire(ctx, kr.ID, false)
+		switch {
+		case errors.Is(err2, ErrKeyReferenced), errors.Is(err2, ErrKeyDraining), errors.Is(err2, ErrRotationNotActive):
+			// retried until the old key is no longer referenced and has drained
+			r.eng.Debugw("Key rotation is not retirable yet", "rotationID", kr.ID, "oldKeyID", kr.OldKeyID, "err", err2)
+		case err2 != nil:
+			r.eng.Errorw("Failed to retire key rotation", "rotationID", kr.ID, "oldKeyID", kr.OldKeyID, "err", err2)
+		default:
+			r.eng.Infow("Retired key rotation", "rotationID", retired.ID, "keyType", retired.KeyType,
+				"oldKeyID", retired.OldKeyID, "newKeyID", retired.NewKeyID)
+		}
+	}
+}
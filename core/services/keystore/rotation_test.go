@@ -0,0 +1,202 @@
+package keystore_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/services/servicetest"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/chaintype"
+	"github.com/smartcontractkit/chainlink/v2/evm/utils"
+)
+
+func Test_Rotation_Eth(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	db := pgtest.NewSqlxDB(t)
+	keyStore := cltest.NewKeyStore(t, db)
+	ks := keyStore.Eth()
+	rotation := keyStore.Rotation()
+
+	oldKey, oldAddress := cltest.MustInsertRandomKey(t, ks)
+
+	kr, err := rotation.Rotate(ctx, keystore.RotationKeyTypeEth, oldAddress.Hex(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, keystore.RotationKeyTypeEth, kr.KeyType)
+	assert.Equal(t, oldKey.ID(), kr.OldKeyID)
+	assert.Equal(t, keystore.KeyRotationStateDraining, kr.State)
+	assert.Nil(t, kr.RetiredAt)
+
+	// the successor is enabled for the chains of the old key
+	newKey, err := ks.Get(ctx, kr.NewKeyID)
+	require.NoError(t, err)
+	require.NoError(t, ks.CheckEnabled(ctx, newKey.Address, testutils.FixtureChainID))
+
+	t.Run("cannot rotate a key which is draining", func(t *testing.T) {
+		_, err := rotation.Rotate(ctx, keystore.RotationKeyTypeEth, oldAddress.Hex(), nil)
+		require.ErrorIs(t, err, keystore.ErrKeyRotating)
+	})
+
+	t.Run("does not pick the draining key for new transactions unless whitelisted", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			address, err := ks.GetRoundRobinAddress(ctx, testutils.FixtureChainID)
+			require.NoError(t, err)
+			assert.Equal(t, newKey.Address, address)
+		}
+		address, err := ks.GetRoundRobinAddress(ctx, testutils.FixtureChainID, oldAddress)
+		require.NoError(t, err)
+		assert.Equal(t, oldAddress, address)
+	})
+
+	t.Run("does not retire a key sent from by an ethtx task", func(t *testing.T) {
+		jb, _ := cltest.MustInsertWebhookSpec(t, db)
+		dag := fmt.Sprintf(`submit [type=ethtx to="%s" data="0x" from="[\\"%s\\"]"]`, utils.RandomAddress(), strings.ToLower(oldAddress.Hex()))
+		_, err := db.ExecContext(ctx, `UPDATE pipeline_specs SET dot_dag_source = $1 WHERE id = $2`, dag, jb.PipelineSpecID)
+		require.NoError(t, err)
+
+		refs, err := rotation.References(ctx, keystore.RotationKeyTypeEth, oldAddress.Hex())
+		require.NoError(t, err)
+		require.Len(t, refs, 1)
+		assert.Equal(t, keystore.KeyReferenceKindJob, refs[0].Kind)
+		assert.Equal(t, int64(jb.ID), refs[0].ID)
+
+		_, err = rotation.Retire(ctx, kr.ID, false)
+		require.ErrorIs(t, err, keystore.ErrKeyReferenced)
+
+		_, err = db.ExecContext(ctx, `UPDATE pipeline_specs SET dot_dag_source = '' WHERE id = $1`, jb.PipelineSpecID)
+		require.NoError(t, err)
+	})
+
+	t.Run("does not retire a key with in-flight transactions", func(t *testing.T) {
+		txStore := cltest.NewTestTxStore(t, db)
+		cltest.MustInsertUnconfirmedEthTx(t, txStore, 0, oldAddress)
+
+		inFlight, err := rotation.InFlightTxs(ctx, keystore.RotationKeyTypeEth, oldAddress.Hex())
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), inFlight)
+
+		_, err = rotation.Retire(ctx, kr.ID, false)
+		require.ErrorIs(t, err, keystore.ErrKeyDraining)
+		// the key is only disabled once it is retired
+		require.NoError(t, ks.CheckEnabled(ctx, oldAddress, testutils.FixtureChainID))
+
+		_, err = db.ExecContext(ctx, `DELETE FROM evm.txes WHERE from_address = $1`, oldAddress)
+		require.NoError(t, err)
+	})
+
+	t.Run("retires the old key by disabling it", func(t *testing.T) {
+		retired, err := rotation.Retire(ctx, kr.ID, false)
+		require.NoError(t, err)
+		assert.Equal(t, keystore.KeyRotationStateRetired, retired.State)
+		assert.NotNil(t, retired.RetiredAt)
+
+		require.Error(t, ks.CheckEnabled(ctx, oldAddress, testutils.FixtureChainID))
+		require.NoError(t, ks.CheckEnabled(ctx, newKey.Address, testutils.FixtureChainID))
+
+		_, err = rotation.Retire(ctx, kr.ID, false)
+		require.ErrorIs(t, err, keystore.ErrRotationNotActive)
+	})
+
+	t.Run("keeps the rotation as history of both keys", func(t *testing.T) {
+		for _, keyID := range []string{oldKey.ID(), newKey.ID()} {
+			krs, err := rotation.History(ctx, keyID)
+			require.NoError(t, err)
+			require.Len(t, krs, 1)
+			assert.Equal(t, kr.ID, krs[0].ID)
+		}
+	})
+}
+
+func Test_Rotation_OCR2(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	db := pgtest.NewSqlxDB(t)
+	keyStore := cltest.NewKeyStore(t, db)
+	ks := keyStore.OCR2()
+	rotation := keyStore.Rotation()
+
+	oldKey, err := ks.Create(ctx, chaintype.EVM)
+	require.NoError(t, err)
+
+	kr, err := rotation.Rotate(ctx, keystore.RotationKeyTypeOCR2, oldKey.ID(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, oldKey.ID(), kr.OldKeyID)
+
+	newKey, err := ks.Get(kr.NewKeyID)
+	require.NoError(t, err)
+	assert.Equal(t, oldKey.ChainType(), newKey.ChainType())
+
+	refs, err := rotation.References(ctx, keystore.RotationKeyTypeOCR2, oldKey.ID())
+	require.NoError(t, err)
+	assert.Empty(t, refs)
+
+	retired, err := rotation.Retire(ctx, kr.ID, true)
+	require.NoError(t, err)
+	assert.Equal(t, keystore.KeyRotationStateRetired, retired.State)
+
+	_, err = ks.Get(oldKey.ID())
+	require.Error(t, err)
+
+	_, err = rotation.Get(ctx, kr.ID+1)
+	require.ErrorIs(t, err, keystore.ErrRotationNotFound)
+}
+
+func Test_RotationRetirer(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	db := pgtest.NewSqlxDB(t)
+	keyStore := cltest.NewKeyStore(t, db)
+	ks := keyStore.OCR2()
+	rotation := keyStore.Rotation()
+
+	dueKey, err := ks.Create(ctx, chaintype.EVM)
+	require.NoError(t, err)
+	retireAfter := time.Now().Add(-time.Minute)
+	due, err := rotation.Rotate(ctx, keystore.RotationKeyTypeOCR2, dueKey.ID(), &retireAfter)
+	require.NoError(t, err)
+	require.NotNil(t, due.RetireAfter)
+
+	laterKey, err := ks.Create(ctx, chaintype.EVM)
+	require.NoError(t, err)
+	retireAfter = time.Now().Add(time.Hour)
+	later, err := rotation.Rotate(ctx, keystore.RotationKeyTypeOCR2, laterKey.ID(), &retireAfter)
+	require.NoError(t, err)
+
+	manualKey, err := ks.Create(ctx, chaintype.EVM)
+	require.NoError(t, err)
+	_, err = rotation.Rotate(ctx, keystore.RotationKeyTypeOCR2, manualKey.ID(), nil)
+	require.NoError(t, err)
+
+	krs, err := rotation.DueRetirements(ctx)
+	require.NoError(t, err)
+	require.Len(t, krs, 1)
+	assert.Equal(t, due.ID, krs[0].ID)
+
+	servicetest.Run(t, keystore.NewRotationRetirer(rotation, logger.TestLogger(t)))
+
+	require.Eventually(t, func() bool {
+		kr, err := rotation.Get(ctx, due.ID)
+		require.NoError(t, err)
+		return kr.State == keystore.KeyRotationStateRetired
+	}, testutils.WaitTimeout(t), 100*time.Millisecond)
+
+	kr, err := rotation.Get(ctx, later.ID)
+	require.NoError(t, err)
+	assert.Equal(t, keystore.KeyRotationStateDraining, kr.State)
+	// the old key is retired, but not deleted
+	_, err = ks.Get(dueKey.ID())
+	require.NoError(t, err)
+}
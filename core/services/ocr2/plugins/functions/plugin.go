@@ -63,7 +63,6 @@ const (
 // Create all OCR2 plugin Oracles and all extra services needed to run a Functions job.
 func NewFunctionsServices(ctx context.Context, functionsOracleArgs, thresholdOracleArgs, s4OracleArgs *libocr2.OCR2OracleArgs, conf *FunctionsServicesConfig) ([]job.ServiceCtx, error) {
 	pluginORM := functions.NewORM(conf.DS, common.HexToAddress(conf.ContractID))
-	s4ORM := s4.NewCachedORMWrapper(s4.NewPostgresORM(conf.DS, s4.SharedTableName, FunctionsS4Namespace), conf.Logger)
 
 	var pluginConfig config.PluginConfig
 	if err := json.Unmarshal(conf.Job.OCR2OracleSpec.PluginConfig.Bytes(), &pluginConfig); err != nil {
@@ -73,6 +72,13 @@ func NewFunctionsServices(ctx context.Context, functionsOracleArgs, thresholdOra
 		return nil, err
 	}
 
+	var maxHistoryVersions uint
+	if pluginConfig.S4Constraints != nil {
+		maxHistoryVersions = pluginConfig.S4Constraints.MaxHistoryVersions
+	}
+	s4ORM := s4.NewCachedORMWrapper(s4.NewPostgresORM(conf.DS, s4.SharedTableName, FunctionsS4Namespace, maxHistoryVersions), conf.Logger)
+	s4QuotaORM := s4.NewPostgresQuotaORM(conf.DS, s4.SharedTableName, FunctionsS4Namespace)
+
 	allServices := []job.ServiceCtx{}
 
 	var decryptor threshold.Decryptor
@@ -102,7 +108,10 @@ func NewFunctionsServices(ctx context.Context, functionsOracleArgs, thresholdOra
 
 	var s4Storage s4.Storage
 	if pluginConfig.S4Constraints != nil {
-		s4Storage = s4.NewStorage(conf.Logger, *pluginConfig.S4Constraints, s4ORM, clockwork.NewRealClock())
+		s4Storage = s4.NewStorage(conf.Logger, *pluginConfig.S4Constraints, s4ORM, s4QuotaORM, clockwork.NewRealClock())
+		if pluginConfig.S4SweeperConfig != nil {
+			allServices = append(allServices, s4_plugin.NewSweeper(conf.Logger, FunctionsS4Namespace, *pluginConfig.S4SweeperConfig, *pluginConfig.S4Constraints, s4ORM, s4QuotaORM, clockwork.NewRealClock()))
+		}
 	}
 
 	offchainTransmitter := functions.NewOffchainTransmitter(DefaultOffchainTransmitterChannelSize)
@@ -213,7 +222,10 @@ func NewConnector(ctx context.Context, pluginConfig *config.PluginConfig, ethKey
 	if idx == -1 {
 		return nil, nil, errors.New("key for configured node address not found")
 	}
-	signerKey := enabledKeys[idx].ToEcdsaPrivKey()
+	signerKey, err := enabledKeys[idx].ToEcdsaPrivKey()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "key for configured node address")
+	}
 	if enabledKeys[idx].ID() != pluginConfig.GatewayConnectorConfig.NodeAddress {
 		return nil, nil, errors.New("node address mismatch")
 	}
@@ -227,7 +239,7 @@ func NewConnector(ctx context.Context, pluginConfig *config.PluginConfig, ethKey
 	if err != nil {
 		return nil, nil, err
 	}
-	err = connector.AddHandler([]string{hf.MethodSecretsSet, hf.MethodSecretsList, hf.MethodHeartbeat}, handler)
+	err = connector.AddHandler([]string{hf.MethodSecretsSet, hf.MethodSecretsList, hf.MethodSecretsHistory, hf.MethodHeartbeat}, handler)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -52,7 +52,7 @@ func newDON(t *testing.T, size int, config *s4.PluginConfig) *don {
 
 	for i := 0; i < size; i++ {
 		ns := fmt.Sprintf("s4_int_test_%d", i)
-		orm := s4_svc.NewPostgresORM(db, s4_svc.SharedTableName, ns)
+		orm := s4_svc.NewPostgresORM(db, s4_svc.SharedTableName, ns, 0)
 		orms[i] = orm
 
 		ocrLogger := commonlogger.NewOCRWrapper(logger, true, func(msg string) {})
@@ -2,6 +2,8 @@ package s4
 
 import (
 	"context"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -32,6 +34,11 @@ type plugin struct {
 	config       *PluginConfig
 	orm          s4.ORM
 	addressRange *s4.AddressRange
+
+	// ackedVersions are the highest previous versions of every slot included in a finalized report,
+	// which were replicated to all nodes and are not observed again.
+	ackedMu       sync.Mutex
+	ackedVersions map[key]uint64
 }
 
 type key struct {
@@ -58,10 +65,11 @@ func NewReportingPlugin(logger commontypes.Logger, config *PluginConfig, orm s4.
 	}
 
 	return &plugin{
-		logger:       logger,
-		config:       config,
-		orm:          orm,
-		addressRange: addressRange,
+		logger:        logger,
+		config:        config,
+		orm:           orm,
+		addressRange:  addressRange,
+		ackedVersions: make(map[key]uint64),
 	}, nil
 }
 
@@ -187,21 +195,76 @@ func (c *plugin) Observation(ctx context.Context, ts types.ReportTimestamp, quer
 		}
 	}
 
+	rows := append(unconfirmedRows, remainingRows...)
+	historyRows := c.historyRows(ctx, rows, int(c.config.MaxObservationEntries)-len(rows))
+
 	c.logger.Debug("S4StorageReporting Observation", commontypes.LogFields{
 		"epoch":            ts.Epoch,
 		"round":            ts.Round,
 		"nUnconfirmedRows": len(unconfirmedRows),
 		"nRemainingRows":   len(remainingRows),
+		"nHistoryRows":     len(historyRows),
 	})
 
-	return returnObservation(append(unconfirmedRows, remainingRows...))
+	return returnObservation(append(rows, historyRows...))
+}
+
+// historyRows returns up to limit retained previous versions of the observed rows which were not acknowledged yet,
+// so that nodes which missed a version can replicate the history of a slot.
+func (c *plugin) historyRows(ctx context.Context, observed []*s4.Row, limit int) []*s4.Row {
+	if limit <= 0 || len(observed) == 0 {
+		return nil
+	}
+	keys := make([]s4.HistoryKey, len(observed))
+	c.ackedMu.Lock()
+	for i, row := range observed {
+		keys[i] = s4.HistoryKey{
+			Address:      row.Address,
+			SlotId:       row.SlotId,
+			AfterVersion: c.ackedVersions[key{address: row.Address.String(), slotID: row.SlotId}],
+		}
+	}
+	c.ackedMu.Unlock()
+
+	historyRows, err := c.orm.GetHistory(ctx, keys, uint(limit))
+	if err != nil {
+		c.logger.Error("ORM GetHistory error", commontypes.LogFields{"err": err})
+		return nil
+	}
+	return historyRows
+}
+
+// ackVersions records the previous versions of every slot included in a finalized report.
+func (c *plugin) ackVersions(reportRows []*Row) {
+	latestVersions := make(map[key]uint64)
+	for _, row := range reportRows {
+		k := key{address: UnmarshalAddress(row.Address).String(), slotID: uint(row.Slotid)}
+		latestVersions[k] = max(latestVersions[k], row.Version)
+	}
+
+	c.ackedMu.Lock()
+	defer c.ackedMu.Unlock()
+	for _, row := range reportRows {
+		k := key{address: UnmarshalAddress(row.Address).String(), slotID: uint(row.Slotid)}
+		if row.Version < latestVersions[k] && row.Version > c.ackedVersions[k] {
+			c.ackedVersions[k] = row.Version
+		}
+	}
 }
 
 func (c *plugin) Report(_ context.Context, ts types.ReportTimestamp, _ types.Query, aos []types.AttributedObservation) (bool, types.Report, error) {
 	promReportingPluginReport.WithLabelValues(c.config.ProductName).Inc()
 
-	reportMap := make(map[key]*Row)
-	reportKeys := []key{}
+	type vkey struct {
+		key
+		version uint64
+	}
+
+	// Rows are deduplicated by version, the latest version of every slot is reported first,
+	// followed by the previous versions observed from the history of other nodes.
+	reportMap := make(map[vkey]*Row)
+	reportKeys := []vkey{}
+	latestVersions := make(map[key]uint64)
 
 	for _, ao := range aos {
 		observationRows, err := UnmarshalRows(ao.Observation)
@@ -219,22 +282,27 @@ func (c *plugin) Report(_ context.Context, ts types.ReportTimestamp, _ types.Que
 				address: UnmarshalAddress(row.Address).String(),
 				slotID:  uint(row.Slotid),
 			}
-			report, ok := reportMap[mkey]
-			if ok && report.Version >= row.Version {
+			if version, ok := latestVersions[mkey]; !ok || version < row.Version {
+				latestVersions[mkey] = row.Version
+			}
+			vk := vkey{key: mkey, version: row.Version}
+			if _, ok := reportMap[vk]; ok {
 				continue
 			}
-			reportMap[mkey] = row
-			reportKeys = append(reportKeys, mkey)
+			reportMap[vk] = row
+			reportKeys = append(reportKeys, vk)
 		}
 	}
 
 	reportRows := make([]*Row, 0)
-	for _, key := range reportKeys {
-		row := reportMap[key]
-		reportRows = append(reportRows, row)
-
-		if len(reportRows) >= int(c.config.MaxReportEntries) {
-			break
+	for _, latest := range []bool{true, false} {
+		for _, vk := range reportKeys {
+			if len(reportRows) >= int(c.config.MaxReportEntries) {
+				break
+			}
+			if (latestVersions[vk.key] == vk.version) == latest {
+				reportRows = append(reportRows, reportMap[vk])
+			}
 		}
 	}
 
@@ -262,6 +330,11 @@ func (c *plugin) ShouldAcceptFinalizedReport(ctx context.Context, ts types.Repor
 		return false, errors.Wrap(err, "failed to UnmarshalRows in ShouldAcceptFinalizedReport()")
 	}
 
+	// Versions are applied in ascending order, so that the replaced versions are retained as history.
+	sort.SliceStable(reportRows, func(i, j int) bool {
+		return reportRows[i].Version < reportRows[j].Version
+	})
+
 	for _, row := range reportRows {
 		ormRow := &s4.Row{
 			Address:    UnmarshalAddress(row.Address),
@@ -283,13 +356,19 @@ func (c *plugin) ShouldAcceptFinalizedReport(ctx context.Context, ts types.Repor
 		}
 
 		err = c.orm.Update(ctx, ormRow)
-		if err != nil && !errors.Is(err, s4.ErrVersionTooLow) {
+		if errors.Is(err, s4.ErrVersionTooLow) {
+			// A previous version of the row, retained if it is missing from the local history
+			err = c.orm.AddHistory(ctx, ormRow)
+		}
+		if err != nil {
 			c.logger.Error("Failed to Update a row in ShouldAcceptFinalizedReport()", commontypes.LogFields{"err": err})
 			continue
 		}
 		promStoragePluginUpdatesCount.WithLabelValues().Inc()
 	}
 
+	c.ackVersions(reportRows)
+
 	c.logger.Debug("S4StorageReporting ShouldAcceptFinalizedReport", commontypes.LogFields{
 		"epoch":       ts.Epoch,
 		"round":       ts.Round,
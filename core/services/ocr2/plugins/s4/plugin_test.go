@@ -71,6 +71,33 @@ func generateTestOrmRow(t *testing.T, ttl time.Duration, version uint64, confime
 	return row
 }
 
+func generateTestOrmRowVersions(t *testing.T, ttl time.Duration, versions ...uint64) []*s4_svc.Row {
+	priv, addr := testutils.NewPrivateKeyAndAddress(t)
+	rows := make([]*s4_svc.Row, len(versions))
+	for i, version := range versions {
+		row := &s4_svc.Row{
+			Address:    big.New(addr.Big()),
+			SlotId:     0,
+			Version:    version,
+			Confirmed:  true,
+			Expiration: time.Now().Add(ttl).UnixMilli(),
+			Payload:    cltest.MustRandomBytes(t, 64),
+		}
+		env := &s4_svc.Envelope{
+			Address:    addr.Bytes(),
+			SlotID:     row.SlotId,
+			Version:    row.Version,
+			Expiration: row.Expiration,
+			Payload:    row.Payload,
+		}
+		sig, err := env.Sign(priv)
+		assert.NoError(t, err)
+		row.Signature = sig
+		rows[i] = row
+	}
+	return rows
+}
+
 func generateTestOrmRows(t *testing.T, n int, ttl time.Duration) []*s4_svc.Row {
 	rows := make([]*s4_svc.Row, n)
 	for i := 0; i < n; i++ {
@@ -98,6 +125,21 @@ func compareRows(t *testing.T, protoRows []*s4.Row, ormRows []*s4_svc.Row) {
 	}
 }
 
+func convertTestRows(ormRows []*s4_svc.Row) []*s4.Row {
+	rows := make([]*s4.Row, len(ormRows))
+	for i, row := range ormRows {
+		rows[i] = &s4.Row{
+			Address:    row.Address.Bytes(),
+			Slotid:     uint32(row.SlotId),
+			Version:    row.Version,
+			Expiration: row.Expiration,
+			Payload:    row.Payload,
+			Signature:  row.Signature,
+		}
+	}
+	return rows
+}
+
 func compareSnapshotRows(t *testing.T, snapshot []*s4.SnapshotRow, ormVersions []*s4_svc.SnapshotRow) {
 	assert.Equal(t, len(ormVersions), len(snapshot))
 	for i, row := range snapshot {
@@ -236,6 +278,35 @@ func TestPlugin_ShouldAcceptFinalizedReport(t *testing.T) {
 		assert.False(t, should)
 	})
 
+	t.Run("history", func(t *testing.T) {
+		ormRows := generateTestOrmRowVersions(t, time.Minute, 3, 1, 2)
+		rows := convertTestRows(ormRows)
+		updated := make([]uint64, 0)
+		orm.On("Update", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			updated = append(updated, args.Get(1).(*s4_svc.Row).Version)
+		}).Return(nil).Once()
+		orm.On("Update", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			updated = append(updated, args.Get(1).(*s4_svc.Row).Version)
+		}).Return(s4_svc.ErrVersionTooLow).Once()
+		orm.On("Update", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			updated = append(updated, args.Get(1).(*s4_svc.Row).Version)
+		}).Return(nil).Once()
+		orm.On("AddHistory", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			assert.Equal(t, uint64(2), args.Get(1).(*s4_svc.Row).Version)
+		}).Return(nil).Once()
+
+		report, err := proto.Marshal(&s4.Rows{
+			Rows: rows,
+		})
+		assert.NoError(t, err)
+
+		should, err := plugin.ShouldAcceptFinalizedReport(testutils.Context(t), types.ReportTimestamp{}, report)
+		assert.NoError(t, err)
+		assert.False(t, should)
+		// Versions are applied in ascending order, versions already replaced locally are added to the history
+		assert.Equal(t, []uint64{1, 2, 3}, updated)
+	})
+
 	t.Run("don't save expired", func(t *testing.T) {
 		ormRows := make([]*s4_svc.Row, 0)
 		rows := generateTestRows(t, 2, -time.Minute)
@@ -372,6 +443,7 @@ func TestPlugin_Observation(t *testing.T) {
 		orm.On("DeleteExpired", mock.Anything, uint(10), mock.Anything, mock.Anything).Return(int64(10), nil).Once()
 		orm.On("GetUnconfirmedRows", mock.Anything, config.MaxObservationEntries).Return(ormRows[numUnconfirmed:], nil).Once()
 		orm.On("GetSnapshot", mock.Anything, mock.Anything).Return(snapshot, nil).Once()
+		orm.On("GetHistory", mock.Anything, mock.Anything, uint(3)).Return([]*s4_svc.Row{}, nil).Once()
 
 		snapshotRows := rowsToShapshotRows(ormRows)
 		query := &s4.Query{
@@ -451,6 +523,7 @@ func TestPlugin_Observation(t *testing.T) {
 		orm.On("GetSnapshot", mock.Anything, mock.Anything).Return(snapshot, nil).Once()
 		orm.On("Get", mock.Anything, snapshot[1].Address, snapshot[1].SlotId).Return(ormRows[1], nil).Once()
 		orm.On("Get", mock.Anything, snapshot[2].Address, snapshot[2].SlotId).Return(ormRows[2], nil).Once()
+		orm.On("GetHistory", mock.Anything, mock.Anything, uint(8)).Return([]*s4_svc.Row{}, nil).Once()
 
 		observation, err := plugin.Observation(testutils.Context(t), types.ReportTimestamp{}, queryBytes)
 		assert.NoError(t, err)
@@ -460,6 +533,46 @@ func TestPlugin_Observation(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Len(t, rows.Rows, 2)
 	})
+
+	t.Run("with history", func(t *testing.T) {
+		versions := generateTestOrmRowVersions(t, time.Minute, 3, 2, 1)
+		latest := versions[0]
+		latest.Confirmed = false
+		orm.On("DeleteExpired", mock.Anything, uint(10), mock.Anything, mock.Anything).Return(int64(0), nil).Once()
+		orm.On("GetUnconfirmedRows", mock.Anything, config.MaxObservationEntries).Return([]*s4_svc.Row{latest}, nil).Once()
+		orm.On("GetSnapshot", mock.Anything, mock.Anything).Return([]*s4_svc.SnapshotRow{}, nil).Once()
+		historyKeys := []s4_svc.HistoryKey{{Address: latest.Address, SlotId: latest.SlotId}}
+		orm.On("GetHistory", mock.Anything, historyKeys, uint(9)).Return([]*s4_svc.Row{versions[2], versions[1]}, nil).Once()
+
+		observation, err := plugin.Observation(testutils.Context(t), types.ReportTimestamp{}, []byte{})
+		assert.NoError(t, err)
+
+		rows := &s4.Rows{}
+		err = proto.Unmarshal(observation, rows)
+		assert.NoError(t, err)
+		compareRows(t, rows.Rows, []*s4_svc.Row{versions[0], versions[2], versions[1]})
+
+		// Previous versions included in a finalized report are not observed again
+		report, err := proto.Marshal(&s4.Rows{Rows: convertTestRows([]*s4_svc.Row{versions[0], versions[2]})})
+		assert.NoError(t, err)
+		orm.On("Update", mock.Anything, mock.Anything).Return(nil).Twice()
+		_, err = plugin.ShouldAcceptFinalizedReport(testutils.Context(t), types.ReportTimestamp{}, report)
+		assert.NoError(t, err)
+
+		orm.On("DeleteExpired", mock.Anything, uint(10), mock.Anything, mock.Anything).Return(int64(0), nil).Once()
+		orm.On("GetUnconfirmedRows", mock.Anything, config.MaxObservationEntries).Return([]*s4_svc.Row{latest}, nil).Once()
+		orm.On("GetSnapshot", mock.Anything, mock.Anything).Return([]*s4_svc.SnapshotRow{}, nil).Once()
+		historyKeys = []s4_svc.HistoryKey{{Address: latest.Address, SlotId: latest.SlotId, AfterVersion: 1}}
+		orm.On("GetHistory", mock.Anything, historyKeys, uint(9)).Return([]*s4_svc.Row{versions[1]}, nil).Once()
+
+		observation, err = plugin.Observation(testutils.Context(t), types.ReportTimestamp{}, []byte{})
+		assert.NoError(t, err)
+
+		rows = &s4.Rows{}
+		err = proto.Unmarshal(observation, rows)
+		assert.NoError(t, err)
+		compareRows(t, rows.Rows, []*s4_svc.Row{versions[0], versions[1]})
+	})
 }
 
 func TestPlugin_Report(t *testing.T) {
@@ -503,3 +616,46 @@ func TestPlugin_Report(t *testing.T) {
 	// Verify that the same report was produced
 	assert.Equal(t, reportRows, reportRows2)
 }
+
+func TestPlugin_ReportHistory(t *testing.T) {
+	t.Parallel()
+
+	logger := commonlogger.NewOCRWrapper(logger.TestLogger(t), true, func(msg string) {})
+	config := createPluginConfig(3)
+	orm := s4_mocks.NewORM(t)
+	plugin, err := s4.NewReportingPlugin(logger, config, orm)
+	assert.NoError(t, err)
+
+	versions := generateTestOrmRowVersions(t, time.Minute, 1, 2, 3)
+	others := generateConfirmedTestOrmRows(t, 2, time.Minute)
+
+	// The first node is behind, the second node observes the latest version with its history
+	observation1, err := proto.Marshal(&s4.Rows{Rows: convertTestRows(append([]*s4_svc.Row{versions[0]}, others...))})
+	assert.NoError(t, err)
+	observation2, err := proto.Marshal(&s4.Rows{Rows: convertTestRows([]*s4_svc.Row{versions[2], versions[1], versions[0]})})
+	assert.NoError(t, err)
+
+	aos := []types.AttributedObservation{
+		{Observation: observation1},
+		{Observation: observation2},
+	}
+	ok, report, err := plugin.Report(testutils.Context(t), types.ReportTimestamp{}, nil, aos)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	reportRows := &s4.Rows{}
+	err = proto.Unmarshal(report, reportRows)
+	assert.NoError(t, err)
+	// The latest versions of all slots are reported before previous versions
+	compareRows(t, reportRows.Rows, []*s4_svc.Row{others[0], others[1], versions[2]})
+
+	config.MaxReportEntries = 10
+	ok, report, err = plugin.Report(testutils.Context(t), types.ReportTimestamp{}, nil, aos)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	reportRows = &s4.Rows{}
+	err = proto.Unmarshal(report, reportRows)
+	assert.NoError(t, err)
+	compareRows(t, reportRows.Rows, []*s4_svc.Row{others[0], others[1], versions[2], versions[0], versions[1]})
+}
@@ -55,4 +55,49 @@ var (
 		Name: "s4_reporting_plugin_expired_rows",
 		Help: "Metric to track number of expired rows",
 	}, []string{"product"})
+
+	promSweeperRuns = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "s4_sweeper_runs",
+		Help: "Metric to track number of Sweeper runs",
+	}, []string{"product"})
+
+	promSweeperErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "s4_sweeper_errors",
+		Help: "Metric to track number of failed Sweeper runs",
+	}, []string{"product"})
+
+	promSweeperDeletedRows = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "s4_sweeper_deleted_rows",
+		Help: "Metric to track number of expired rows deleted by the Sweeper",
+	}, []string{"product"})
+
+	promSweeperDeletedQuotas = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "s4_sweeper_deleted_quotas",
+		Help: "Metric to track number of quotas of past periods deleted by the Sweeper",
+	}, []string{"product"})
+
+	promSweeperRunDuration = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "s4_sweeper_run_duration_seconds",
+		Help: "Metric to track the duration of the last Sweeper run",
+	}, []string{"product"})
+
+	promQuotaAddresses = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "s4_quota_addresses",
+		Help: "Metric to track number of addresses having writes in the current quota period",
+	}, []string{"product"})
+
+	promQuotaExhaustedAddresses = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "s4_quota_exhausted_addresses",
+		Help: "Metric to track number of addresses having exhausted their quota in the current quota period",
+	}, []string{"product"})
+
+	promQuotaBytes = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "s4_quota_bytes",
+		Help: "Metric to track total payload bytes written in the current quota period",
+	}, []string{"product"})
+
+	promQuotaWrites = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "s4_quota_writes",
+		Help: "Metric to track total number of writes in the current quota period",
+	}, []string{"product"})
 )
@@ -0,0 +1,182 @@
+package s4
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/s4"
+)
+
+const (
+	defaultSweepIntervalSec      = 60
+	defaultSweepBatchSize        = 100
+	defaultSweepMaxBatchesPerRun = 10
+)
+
+// SweeperConfig configures the Sweeper. Zero values are replaced by defaults.
+type SweeperConfig struct {
+	// IntervalSec is the time between two sweeps.
+	IntervalSec uint `json:"intervalSec"`
+	// BatchSize is the maximum number of rows deleted by a single query.
+	BatchSize uint `json:"batchSize"`
+	// MaxBatchesPerRun is the maximum number of batches deleted by a single sweep.
+	MaxBatchesPerRun uint `json:"maxBatchesPerRun"`
+}
+
+// Sweeper is a service which periodically deletes expired rows, including retained versions,
+// and the quotas of past periods, in batches. It also reports the quota usage of the current period.
+type Sweeper struct {
+	services.StateMachine
+
+	lggr        logger.Logger
+	productName string
+	config      SweeperConfig
+	constraints s4.Constraints
+	orm         s4.ORM
+	quotas      s4.QuotaORM
+	clock       clockwork.Clock
+
+	chStop services.StopChan
+	wg     sync.WaitGroup
+}
+
+var _ services.Service = (*Sweeper)(nil)
+
+// NewSweeper returns a Sweeper of the rows of orm and of the quotas, which may be nil.
+func NewSweeper(lggr logger.Logger, productName string, config SweeperConfig, constraints s4.Constraints, orm s4.ORM, quotas s4.QuotaORM, clock clockwork.Clock) *Sweeper {
+	if config.IntervalSec == 0 {
+		config.IntervalSec = defaultSweepIntervalSec
+	}
+	if config.BatchSize == 0 {
+		config.BatchSize = defaultSweepBatchSize
+	}
+	if config.MaxBatchesPerRun == 0 {
+		config.MaxBatchesPerRun = defaultSweepMaxBatchesPerRun
+	}
+	return &Sweeper{
+		lggr:        lggr.Named("S4Sweeper"),
+		productName: productName,
+		config:      config,
+		constraints: constraints,
+		orm:         orm,
+		quotas:      quotas,
+		clock:       clock,
+		chStop:      make(services.StopChan),
+	}
+}
+
+func (s *Sweeper) Start(context.Context) error {
+	return s.StartOnce("S4Sweeper", func() error {
+		s.wg.Add(1)
+		go s.run()
+		return nil
+	})
+}
+
+func (s *Sweeper) Close() error {
+	return s.StopOnce("S4Sweeper", func() error {
+		close(s.chStop)
+		s.wg.Wait()
+		return nil
+	})
+}
+
+func (s *Sweeper) Name() string {
+	return s.lggr.Name()
+}
+
+func (s *Sweeper) HealthReport() map[string]error {
+	return map[string]error{s.Name(): s.Healthy()}
+}
+
+func (s *Sweeper) run() {
+	defer s.wg.Done()
+	ctx, cancel := s.chStop.NewCtx()
+	defer cancel()
+
+	ticker := s.clock.NewTicker(time.Duration(s.config.IntervalSec) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.Chan():
+			if err := s.Sweep(ctx); err != nil && ctx.Err() == nil {
+				s.lggr.Errorw("failed to sweep S4 storage", "err", err)
+			}
+		}
+	}
+}
+
+// Sweep runs a single sweep.
+func (s *Sweeper) Sweep(ctx context.Context) error {
+	start := s.clock.Now()
+	promSweeperRuns.WithLabelValues(s.productName).Inc()
+	defer func() {
+		promSweeperRunDuration.WithLabelValues(s.productName).Set(s.clock.Since(start).Seconds())
+	}()
+
+	var deleted int64
+	for i := uint(0); i < s.config.MaxBatchesPerRun; i++ {
+		count, err := s.orm.DeleteExpired(ctx, s.config.BatchSize, start.UTC())
+		deleted += count
+		promSweeperDeletedRows.WithLabelValues(s.productName).Add(float64(count))
+		if err != nil {
+			promSweeperErrors.WithLabelValues(s.productName).Inc()
+			return errors.Wrap(err, "failed to delete expired rows")
+		}
+		if count < int64(s.config.BatchSize) {
+			break
+		}
+	}
+	if deleted > 0 {
+		s.lggr.Debugw("deleted expired rows", "count", deleted)
+	}
+
+	if s.quotas == nil || s.constraints.QuotaPeriodSec == 0 {
+		return nil
+	}
+	periodStart := s4.QuotaPeriodStart(start, time.Duration(s.constraints.QuotaPeriodSec)*time.Second)
+	count, err := s.quotas.DeleteStaleQuotas(ctx, periodStart)
+	if err != nil {
+		promSweeperErrors.WithLabelValues(s.productName).Inc()
+		return errors.Wrap(err, "failed to delete stale quotas")
+	}
+	promSweeperDeletedQuotas.WithLabelValues(s.productName).Add(float64(count))
+
+	quotas, err := s.quotas.ListQuotas(ctx)
+	if err != nil {
+		promSweeperErrors.WithLabelValues(s.productName).Inc()
+		return errors.Wrap(err, "failed to list quotas")
+	}
+	s.reportQuotas(quotas, periodStart)
+	return nil
+}
+
+func (s *Sweeper) reportQuotas(quotas []*s4.Quota, periodStart int64) {
+	var addresses, exhausted int
+	var bytes, writes uint64
+	for _, quota := range quotas {
+		if quota.PeriodStart < periodStart {
+			continue
+		}
+		addresses++
+		bytes += quota.Bytes
+		writes += quota.Writes
+		if (s.constraints.MaxWritesPerPeriod > 0 && quota.Writes >= s.constraints.MaxWritesPerPeriod) ||
+			(s.constraints.MaxBytesPerPeriod > 0 && quota.Bytes >= s.constraints.MaxBytesPerPeriod) {
+			exhausted++
+		}
+	}
+	promQuotaAddresses.WithLabelValues(s.productName).Set(float64(addresses))
+	promQuotaExhaustedAddresses.WithLabelValues(s.productName).Set(float64(exhausted))
+	promQuotaBytes.WithLabelValues(s.productName).Set(float64(bytes))
+	promQuotaWrites.WithLabelValues(s.productName).Set(float64(writes))
+}
@@ -0,0 +1,88 @@
+package s4_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/s4"
+	s4_svc "github.com/smartcontractkit/chainlink/v2/core/services/s4"
+	"github.com/smartcontractkit/chainlink/v2/evm/utils/big"
+)
+
+func TestSweeper_Sweep(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	clock := clockwork.NewFakeClockAt(time.Now())
+	orm := s4_svc.NewInMemoryORM(0)
+	quotas := s4_svc.NewInMemoryQuotaORM()
+	constraints := s4_svc.Constraints{QuotaPeriodSec: 3600, MaxWritesPerPeriod: 2}
+	config := s4.SweeperConfig{BatchSize: 10, MaxBatchesPerRun: 2}
+	sweeper := s4.NewSweeper(logger.TestLogger(t), "test", config, constraints, orm, quotas, clock)
+
+	rows := generateTestOrmRows(t, 25, -time.Minute)
+	for _, row := range rows {
+		row.Expiration = clock.Now().Add(-time.Minute).UnixMilli()
+		require.NoError(t, orm.Update(ctx, row))
+	}
+	live := generateTestOrmRows(t, 1, time.Hour)[0]
+	live.Expiration = clock.Now().Add(time.Hour).UnixMilli()
+	require.NoError(t, orm.Update(ctx, live))
+
+	periodStart := s4_svc.QuotaPeriodStart(clock.Now(), time.Hour)
+	stale := big.New(testutils.NewAddress().Big())
+	current := big.New(testutils.NewAddress().Big())
+	_, err := quotas.AddUsage(ctx, stale, periodStart-time.Hour.Milliseconds(), 1, 0, 0)
+	require.NoError(t, err)
+	_, err = quotas.AddUsage(ctx, current, periodStart, 1, 0, 0)
+	require.NoError(t, err)
+
+	countRows := func() (count int) {
+		for _, row := range append(rows, live) {
+			if _, err2 := orm.Get(ctx, row.Address, row.SlotId); err2 == nil {
+				count++
+			}
+		}
+		return count
+	}
+
+	// a single run deletes at most BatchSize * MaxBatchesPerRun rows
+	require.NoError(t, sweeper.Sweep(ctx))
+	assert.Equal(t, 6, countRows())
+	require.NoError(t, sweeper.Sweep(ctx))
+	assert.Equal(t, 1, countRows())
+
+	_, err = quotas.GetQuota(ctx, stale)
+	require.ErrorIs(t, err, s4_svc.ErrNotFound)
+	_, err = quotas.GetQuota(ctx, current)
+	require.NoError(t, err)
+}
+
+func TestSweeper_StartClose(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	clock := clockwork.NewFakeClockAt(time.Now())
+	orm := s4_svc.NewInMemoryORM(0)
+	sweeper := s4.NewSweeper(logger.TestLogger(t), "test", s4.SweeperConfig{IntervalSec: 1}, s4_svc.Constraints{}, orm, nil, clock)
+
+	row := generateTestOrmRows(t, 1, time.Hour)[0]
+	row.Expiration = clock.Now().Add(time.Second).UnixMilli()
+	require.NoError(t, orm.Update(ctx, row))
+
+	require.NoError(t, sweeper.Start(ctx))
+	t.Cleanup(func() { assert.NoError(t, sweeper.Close()) })
+	clock.BlockUntil(1)
+
+	clock.Advance(2 * time.Second)
+	require.Eventually(t, func() bool {
+		_, err := orm.Get(ctx, row.Address, row.SlotId)
+		return err != nil
+	}, testutils.WaitTimeout(t), 10*time.Millisecond)
+}
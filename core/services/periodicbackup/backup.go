@@ -2,11 +2,14 @@ package periodicbackup
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
+	"io"
 	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -48,6 +51,11 @@ type (
 		mode            config.DatabaseBackupMode
 		frequency       time.Duration
 		outputParentDir string
+		sink            Sink
+		compression     config.DatabaseBackupCompression
+		password        string
+		retention       config.BackupRetention
+		stopCh          services.StopChan
 		done            chan bool
 	}
 
@@ -56,24 +64,38 @@ type (
 		Dir() string
 		Mode() config.DatabaseBackupMode
 		Frequency() time.Duration
+		Sink() config.DatabaseBackupSink
+		Compression() config.DatabaseBackupCompression
+		Encrypt() bool
+		Retention() config.BackupRetention
+		S3() config.BackupS3
 	}
 )
 
-// NewDatabaseBackup instantiates a *databaseBackup
-func NewDatabaseBackup(dbUrl url.URL, rootDir string, backupConfig BackupConfig, lggr logger.Logger) (DatabaseBackup, error) {
+// NewDatabaseBackup instantiates a *databaseBackup. The keystore password is
+// used to derive the encryption key if Database.Backup.Encrypt is set.
+func NewDatabaseBackup(dbUrl url.URL, rootDir string, backupConfig BackupConfig, keystorePassword string, lggr logger.Logger) (DatabaseBackup, error) {
 	lggr = lggr.Named("DatabaseBackup")
 	dbBackupUrl := backupConfig.URL()
 	if dbBackupUrl != nil {
 		dbUrl = *dbBackupUrl
 	}
 
-	outputParentDir := filepath.Join(rootDir, "backup")
-	if backupConfig.Dir() != "" {
-		dir, err := filepath.Abs(backupConfig.Dir())
-		if err != nil {
-			return nil, errors.Errorf("failed to get path for Database.Backup.Dir (%s) - please set it to a valid directory path", backupConfig.Dir())
+	outputParentDir, err := backupDir(rootDir, backupConfig)
+	if err != nil {
+		return nil, err
+	}
+	sink, err := NewSink(backupConfig, outputParentDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var password string
+	if backupConfig.Encrypt() {
+		if keystorePassword == "" {
+			return nil, errors.New("Database.Backup.Encrypt requires a keystore password")
 		}
-		outputParentDir = dir
+		password = keystorePassword
 	}
 
 	return &databaseBackup{
@@ -83,10 +105,27 @@ func NewDatabaseBackup(dbUrl url.URL, rootDir string, backupConfig BackupConfig,
 		backupConfig.Mode(),
 		backupConfig.Frequency(),
 		outputParentDir,
+		sink,
+		backupConfig.Compression(),
+		password,
+		backupConfig.Retention(),
+		make(services.StopChan),
 		make(chan bool),
 	}, nil
 }
 
+// backupDir returns the directory of the filesystem sink, which also holds temporary files of other sinks.
+func backupDir(rootDir string, backupConfig BackupConfig) (string, error) {
+	if backupConfig.Dir() == "" {
+		return filepath.Join(rootDir, "backup"), nil
+	}
+	dir, err := filepath.Abs(backupConfig.Dir())
+	if err != nil {
+		return "", errors.Errorf("failed to get path for Database.Backup.Dir (%s) - please set it to a valid directory path", backupConfig.Dir())
+	}
+	return dir, nil
+}
+
 // Start starts DatabaseBackup.
 func (backup *databaseBackup) Start(context.Context) error {
 	return backup.StartOnce("DatabaseBackup", func() (err error) {
@@ -119,6 +158,7 @@ func (backup *databaseBackup) Start(context.Context) error {
 
 func (backup *databaseBackup) Close() error {
 	return backup.StopOnce("DatabaseBackup", func() (err error) {
+		close(backup.stopCh)
 		backup.done <- true
 		return nil
 	})
@@ -209,23 +249,102 @@ func (backup *databaseBackup) runBackup(version string) (*backupResult, error) {
 	if version == "" {
 		version = "unknown"
 	}
-	finalFilePath := filepath.Join(backup.outputParentDir, fmt.Sprintf(filePattern, version))
-	_ = os.Remove(finalFilePath)
-	err = os.Rename(tmpFile.Name(), finalFilePath)
-	if err != nil {
-		_ = os.Remove(tmpFile.Name())
-		return nil, errors.Wrap(err, "Failed to rename the temp file to the final backup file")
+	defer os.Remove(tmpFile.Name())
+
+	var timestamp time.Time
+	if backup.retention.Daily() > 0 || backup.retention.Weekly() > 0 {
+		timestamp = time.Now()
 	}
+	name := backupName(version, timestamp, backup.compression, backup.password != "")
 
-	file, err := os.Stat(finalFilePath)
+	// a stuck sink is abandoned when the service stops
+	ctx, cancel := backup.stopCh.NewCtx()
+	defer cancel()
+	location, size, err := backup.store(ctx, tmpFile.Name(), name)
 	if err != nil {
-		return nil, errors.Wrap(err, "Failed to access the final backup file")
+		return nil, err
+	}
+
+	if err = backup.applyRetention(ctx); err != nil {
+		backup.logger.Errorw("Failed to apply backup retention", "err", err)
 	}
 
 	return &backupResult{
-		size:            file.Size(),
-		path:            finalFilePath,
+		size:            size,
+		path:            location,
 		maskedArguments: maskedArgs,
 		pgDumpArguments: args,
 	}, nil
 }
+
+// store encodes the dump at path and stores it and its checksum in the sink as name.
+func (backup *databaseBackup) store(ctx context.Context, path string, name string) (location string, size int64, err error) {
+	dump, err := os.Open(path)
+	if err != nil {
+		return "", 0, errors.Wrap(err, "Failed to open the backup file")
+	}
+	defer dump.Close()
+
+	encoded, err := os.CreateTemp(backup.outputParentDir, "cl_backup_tmp_")
+	if err != nil {
+		return "", 0, errors.Wrap(err, "Failed to create a tmp file")
+	}
+	defer os.Remove(encoded.Name())
+	defer encoded.Close()
+
+	hash := sha256.New()
+	encoder, err := newEncoder(io.MultiWriter(encoded, hash), backup.compression, backup.password)
+	if err != nil {
+		return "", 0, err
+	}
+	if _, err = io.Copy(encoder, dump); err != nil {
+		return "", 0, errors.Wrap(err, "Failed to encode the backup file")
+	}
+	if err = encoder.Close(); err != nil {
+		return "", 0, errors.Wrap(err, "Failed to encode the backup file")
+	}
+
+	size, err = encoded.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return "", 0, err
+	}
+	if _, err = encoded.Seek(0, io.SeekStart); err != nil {
+		return "", 0, err
+	}
+	location, err = backup.sink.Put(ctx, name, encoded, size)
+	if err != nil {
+		return "", 0, errors.Wrap(err, "Failed to store the backup file")
+	}
+
+	checksum := fmt.Sprintf("%x  %s\n", hash.Sum(nil), name)
+	if _, err = backup.sink.Put(ctx, name+checksumExt, strings.NewReader(checksum), int64(len(checksum))); err != nil {
+		return "", 0, errors.Wrap(err, "Failed to store the backup checksum")
+	}
+	return location, size, nil
+}
+
+// applyRetention deletes the backups which are no longer retained.
+func (backup *databaseBackup) applyRetention(ctx context.Context) error {
+	daily, weekly := backup.retention.Daily(), backup.retention.Weekly()
+	if daily == 0 && weekly == 0 {
+		return nil
+	}
+	backups, err := listBackups(ctx, backup.sink)
+	if err != nil {
+		return err
+	}
+	names := make([]string, len(backups))
+	for i, b := range backups {
+		names[i] = b.Name
+	}
+	for _, name := range expiredBackups(names, daily, weekly) {
+		backup.logger.Infow("Deleting expired backup", "name", name)
+		if err = backup.sink.Delete(ctx, name); err != nil {
+			return err
+		}
+		if err = backup.sink.Delete(ctx, name+checksumExt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
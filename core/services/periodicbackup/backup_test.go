@@ -19,7 +19,7 @@ import (
 
 func mustNewDatabaseBackup(t *testing.T, url url.URL, rootDir string, config BackupConfig) *databaseBackup {
 	testutils.SkipShortDB(t)
-	b, err := NewDatabaseBackup(url, rootDir, config, logger.TestLogger(t))
+	b, err := NewDatabaseBackup(url, rootDir, config, "", logger.TestLogger(t))
 	require.NoError(t, err)
 	return b.(*databaseBackup)
 }
@@ -143,6 +143,38 @@ func (t *testConfig) Dir() string {
 	return t.dir
 }
 
+func (t *testConfig) Sink() config.DatabaseBackupSink {
+	return config.DatabaseBackupSinkFilesystem
+}
+
+func (t *testConfig) Compression() config.DatabaseBackupCompression {
+	return config.DatabaseBackupCompressionNone
+}
+
+func (t *testConfig) Encrypt() bool {
+	return false
+}
+
+func (t *testConfig) Retention() config.BackupRetention {
+	return &testRetention{}
+}
+
+func (t *testConfig) S3() config.BackupS3 {
+	return nil
+}
+
+type testRetention struct {
+	daily, weekly uint32
+}
+
+func (r *testRetention) Daily() uint32 {
+	return r.daily
+}
+
+func (r *testRetention) Weekly() uint32 {
+	return r.weekly
+}
+
 func newTestConfig(frequency time.Duration, databaseBackupURL *url.URL, databaseBackupDir string, mode config.DatabaseBackupMode) *testConfig {
 	return &testConfig{
 		frequency: frequency,
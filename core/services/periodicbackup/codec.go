@@ -0,0 +1,314 @@
+package periodicbackup
+
+import (
+	"bufio"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/smartcontractkit/chainlink/v2/core/config"
+)
+
+const (
+	extGzip      = ".gz"
+	extZstd      = ".zst"
+	extEncrypted = ".enc"
+
+	// encryptionMagic starts every encrypted backup, followed by the scrypt salt and the nonce prefix.
+	encryptionMagic = "CLBKENC1"
+	saltSize        = 16
+	noncePrefixSize = 7
+	// chunkSize is the size of the plaintext chunks which are sealed individually,
+	// so that backups can be encrypted and decrypted as streams.
+	chunkSize = 64 * 1024
+
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+var ErrDecrypt = errors.New("failed to decrypt backup: wrong keystore password or corrupted backup")
+
+// encodedExt returns the file extension of backups encoded with the given options.
+func encodedExt(compression config.DatabaseBackupCompression, encrypt bool) string {
+	var ext string
+	switch compression {
+	case config.DatabaseBackupCompressionGzip:
+		ext = extGzip
+	case config.DatabaseBackupCompressionZstd:
+		ext = extZstd
+	}
+	if encrypt {
+		ext += extEncrypted
+	}
+	return ext
+}
+
+// newEncoder returns a writer which compresses and, if password is not empty,
+// encrypts everything written to it into w. It must be closed to flush the
+// remaining data; w is not closed.
+func newEncoder(w io.Writer, compression config.DatabaseBackupCompression, password string) (io.WriteCloser, error) {
+	var closers []io.Closer
+	if password != "" {
+		ew, err := newEncryptWriter(w, password)
+		if err != nil {
+			return nil, err
+		}
+		w = ew
+		closers = append(closers, ew)
+	}
+	switch compression {
+	case config.DatabaseBackupCompressionGzip:
+		gw := gzip.NewWriter(w)
+		w = gw
+		closers = append(closers, gw)
+	case config.DatabaseBackupCompressionZstd:
+		zw, err := zstd.NewWriter(w)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create zstd writer")
+		}
+		w = zw
+		closers = append(closers, zw)
+	case config.DatabaseBackupCompressionNone, "":
+	default:
+		return nil, errors.Errorf("unknown compression: %s", compression)
+	}
+	return &chainWriter{w, closers}, nil
+}
+
+// newDecoder returns a reader of the original contents of the backup name,
+// read from r. The encoding is derived from the extensions of the name.
+func newDecoder(r io.Reader, name string, password string) (io.ReadCloser, error) {
+	var closers []io.Closer
+	if strings.HasSuffix(name, extEncrypted) {
+		if password == "" {
+			return nil, errors.New("backup is encrypted, but no keystore password was provided")
+		}
+		dr, err := newDecryptReader(r, password)
+		if err != nil {
+			return nil, err
+		}
+		r = dr
+		name = strings.TrimSuffix(name, extEncrypted)
+	}
+	switch {
+	case strings.HasSuffix(name, extGzip):
+		gr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read gzip header")
+		}
+		r = gr
+		closers = append(closers, gr)
+	case strings.HasSuffix(name, extZstd):
+		zr, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create zstd reader")
+		}
+		r = zr
+		closers = append(closers, zstdCloser{zr})
+	}
+	return &chainReader{r, closers}, nil
+}
+
+type chainWriter struct {
+	io.Writer
+	closers []io.Closer
+}
+
+// Close closes the writers from the outermost to the innermost, so that each flushes into the next.
+func (c *chainWriter) Close() (err error) {
+	for i := len(c.closers) - 1; i >= 0; i-- {
+		err = multierr.Append(err, c.closers[i].Close())
+	}
+	return
+}
+
+type chainReader struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (c *chainReader) Close() (err error) {
+	for _, closer := range c.closers {
+		err = multierr.Append(err, closer.Close())
+	}
+	return
+}
+
+type zstdCloser struct {
+	*zstd.Decoder
+}
+
+func (z zstdCloser) Close() error {
+	z.Decoder.Close()
+	return nil
+}
+
+func deriveKey(password string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(password), salt, scryptN, scryptR, scryptP, 32)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to derive encryption key")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// chunkNonce returns the nonce of a chunk: the random prefix of the backup,
+// the index of the chunk and whether it is the final one. Binding the index and
+// the final flag to the nonce prevents chunks from being reordered or dropped.
+func chunkNonce(prefix []byte, index uint32, final bool) []byte {
+	nonce := make([]byte, noncePrefixSize+5)
+	copy(nonce, prefix)
+	binary.BigEndian.PutUint32(nonce[noncePrefixSize:], index)
+	if final {
+		nonce[len(nonce)-1] = 1
+	}
+	return nonce
+}
+
+// encryptWriter encrypts a stream in chunks with AES-GCM. Every chunk is
+// written as a flag byte marking the final chunk, the length of the sealed
+// chunk and the sealed chunk.
+type encryptWriter struct {
+	w      io.Writer
+	aead   cipher.AEAD
+	prefix []byte
+	index  uint32
+	buf    []byte
+}
+
+func newEncryptWriter(w io.Writer, password string) (*encryptWriter, error) {
+	header := make([]byte, len(encryptionMagic)+saltSize+noncePrefixSize)
+	copy(header, encryptionMagic)
+	if _, err := rand.Read(header[len(encryptionMagic):]); err != nil {
+		return nil, err
+	}
+	salt := header[len(encryptionMagic) : len(encryptionMagic)+saltSize]
+	aead, err := deriveKey(password, salt)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = w.Write(header); err != nil {
+		return nil, err
+	}
+	return &encryptWriter{w: w, aead: aead, prefix: header[len(encryptionMagic)+saltSize:], buf: make([]byte, 0, chunkSize)}, nil
+}
+
+func (e *encryptWriter) Write(p []byte) (n int, err error) {
+	for len(p) > 0 {
+		if len(e.buf) == chunkSize {
+			if err = e.seal(false); err != nil {
+				return n, err
+			}
+		}
+		c := copy(e.buf[len(e.buf):chunkSize], p)
+		e.buf = e.buf[:len(e.buf)+c]
+		p = p[c:]
+		n += c
+	}
+	return n, nil
+}
+
+func (e *encryptWriter) Close() error {
+	return e.seal(true)
+}
+
+func (e *encryptWriter) seal(final bool) error {
+	sealed := e.aead.Seal(nil, chunkNonce(e.prefix, e.index, final), e.buf, nil)
+	header := make([]byte, 5)
+	if final {
+		header[0] = 1
+	}
+	binary.BigEndian.PutUint32(header[1:], uint32(len(sealed)))
+	if _, err := e.w.Write(header); err != nil {
+		return err
+	}
+	if _, err := e.w.Write(sealed); err != nil {
+		return err
+	}
+	e.index++
+	e.buf = e.buf[:0]
+	return nil
+}
+
+type decryptReader struct {
+	r      *bufio.Reader
+	aead   cipher.AEAD
+	prefix []byte
+	index  uint32
+	buf    []byte
+	final  bool
+}
+
+func newDecryptReader(r io.Reader, password string) (*decryptReader, error) {
+	header := make([]byte, len(encryptionMagic)+saltSize+noncePrefixSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, errors.Wrap(err, "failed to read encryption header")
+	}
+	if string(header[:len(encryptionMagic)]) != encryptionMagic {
+		return nil, errors.New("backup is not encrypted")
+	}
+	aead, err := deriveKey(password, header[len(encryptionMagic):len(encryptionMagic)+saltSize])
+	if err != nil {
+		return nil, err
+	}
+	return &decryptReader{r: bufio.NewReader(r), aead: aead, prefix: header[len(encryptionMagic)+saltSize:]}, nil
+}
+
+func (d *decryptReader) Read(p []byte) (int, error) {
+	for len(d.buf) == 0 {
+		if d.final {
+			return 0, io.EOF
+		}
+		if err := d.open(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, d.buf)
+	d.buf = d.buf[n:]
+	return n, nil
+}
+
+func (d *decryptReader) open() error {
+	header := make([]byte, 5)
+	if _, err := io.ReadFull(d.r, header); err != nil {
+		if errors.Is(err, io.EOF) {
+			return errors.Wrap(ErrDecrypt, "backup is truncated")
+		}
+		return err
+	}
+	size := binary.BigEndian.Uint32(header[1:])
+	if size > chunkSize+uint32(d.aead.Overhead()) {
+		return errors.Wrapf(ErrDecrypt, "chunk %d is too large", d.index)
+	}
+	sealed := make([]byte, size)
+	if _, err := io.ReadFull(d.r, sealed); err != nil {
+		return errors.Wrapf(ErrDecrypt, "chunk %d is truncated", d.index)
+	}
+	final := header[0] == 1
+	plain, err := d.aead.Open(sealed[:0], chunkNonce(d.prefix, d.index, final), sealed, nil)
+	if err != nil {
+		return errors.Wrapf(ErrDecrypt, "chunk %d", d.index)
+	}
+	if final {
+		if _, err = d.r.Peek(1); !errors.Is(err, io.EOF) {
+			return errors.Wrap(ErrDecrypt, "unexpected data after the final chunk")
+		}
+	}
+	d.index++
+	d.buf = plain
+	d.final = final
+	return nil
+}
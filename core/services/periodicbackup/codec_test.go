@@ -0,0 +1,112 @@
+package periodicbackup
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/config"
+)
+
+func encode(t *testing.T, data []byte, compression config.DatabaseBackupCompression, password string) []byte {
+	var buf bytes.Buffer
+	w, err := newEncoder(&buf, compression, password)
+	require.NoError(t, err)
+	_, err = w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func decode(data []byte, name string, password string) ([]byte, error) {
+	r, err := newDecoder(bytes.NewReader(data), name, password)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+func TestCodec_RoundTrip(t *testing.T) {
+	// spans several encryption chunks, with a partial last chunk
+	data := make([]byte, 3*chunkSize+123)
+	_, err := rand.Read(data[:chunkSize])
+	require.NoError(t, err)
+
+	for _, compression := range []config.DatabaseBackupCompression{
+		config.DatabaseBackupCompressionNone,
+		config.DatabaseBackupCompressionGzip,
+		config.DatabaseBackupCompressionZstd,
+	} {
+		for _, password := range []string{"", "p4ssw0rd"} {
+			name := "cl_backup_1.0.0.dump" + encodedExt(compression, password != "")
+			t.Run(name, func(t *testing.T) {
+				encoded := encode(t, data, compression, password)
+				if compression != config.DatabaseBackupCompressionNone {
+					assert.Less(t, len(encoded), len(data))
+				}
+				if password == "" && compression == config.DatabaseBackupCompressionNone {
+					assert.Equal(t, data, encoded)
+				}
+
+				decoded, err := decode(encoded, name, password)
+				require.NoError(t, err)
+				assert.Equal(t, data, decoded)
+			})
+		}
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		encoded := encode(t, nil, config.DatabaseBackupCompressionNone, "p4ssw0rd")
+		decoded, err := decode(encoded, "cl_backup_1.0.0.dump.enc", "p4ssw0rd")
+		require.NoError(t, err)
+		assert.Empty(t, decoded)
+	})
+}
+
+func TestCodec_Encryption(t *testing.T) {
+	data := bytes.Repeat([]byte("chainlink"), chunkSize/4)
+	encoded := encode(t, data, config.DatabaseBackupCompressionNone, "p4ssw0rd")
+	name := "cl_backup_1.0.0.dump.enc"
+
+	assert.NotContains(t, string(encoded), "chainlink")
+	assert.NotEqual(t, encoded, encode(t, data, config.DatabaseBackupCompressionNone, "p4ssw0rd"), "expected a random salt and nonce")
+
+	t.Run("wrong password", func(t *testing.T) {
+		_, err := decode(encoded, name, "wrong")
+		require.ErrorIs(t, err, ErrDecrypt)
+	})
+
+	t.Run("missing password", func(t *testing.T) {
+		_, err := decode(encoded, name, "")
+		require.ErrorContains(t, err, "no keystore password")
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		tampered := bytes.Clone(encoded)
+		tampered[len(tampered)-1] ^= 1
+		_, err := decode(tampered, name, "p4ssw0rd")
+		require.ErrorIs(t, err, ErrDecrypt)
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		// drop the final chunk
+		truncated := encoded[:len(encryptionMagic)+saltSize+noncePrefixSize+5+chunkSize+16]
+		_, err := decode(truncated, name, "p4ssw0rd")
+		require.ErrorIs(t, err, ErrDecrypt)
+	})
+
+	t.Run("trailing data", func(t *testing.T) {
+		_, err := decode(append(bytes.Clone(encoded), 0), name, "p4ssw0rd")
+		require.ErrorIs(t, err, ErrDecrypt)
+	})
+
+	t.Run("not encrypted", func(t *testing.T) {
+		_, err := decode(data, name, "p4ssw0rd")
+		require.ErrorContains(t, err, "not encrypted")
+	})
+}
@@ -0,0 +1,175 @@
+package periodicbackup
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+// LatestBackup is the name which refers to the most recent backup.
+const LatestBackup = "latest"
+
+// listBackups returns the backups stored in sink, from the most recent to the oldest.
+func listBackups(ctx context.Context, sink Sink) ([]Object, error) {
+	objects, err := sink.List(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list backups")
+	}
+	var backups []Object
+	for _, o := range objects {
+		if backupNameRegexp.MatchString(o.Name) {
+			backups = append(backups, o)
+		}
+	}
+	sort.SliceStable(backups, func(i, j int) bool {
+		ti, iok := backupTimestamp(backups[i].Name)
+		if !iok {
+			ti = backups[i].ModifiedAt
+		}
+		tj, jok := backupTimestamp(backups[j].Name)
+		if !jok {
+			tj = backups[j].ModifiedAt
+		}
+		return ti.After(tj)
+	})
+	return backups, nil
+}
+
+// ListBackups returns the backups stored in the configured sink, from the most recent to the oldest.
+func ListBackups(ctx context.Context, rootDir string, backupConfig BackupConfig) ([]Object, error) {
+	dir, err := backupDir(rootDir, backupConfig)
+	if err != nil {
+		return nil, err
+	}
+	sink, err := NewSink(backupConfig, dir)
+	if err != nil {
+		return nil, err
+	}
+	return listBackups(ctx, sink)
+}
+
+// RestoreDatabase restores the backup name, or the most recent backup if name
+// is LatestBackup, from the configured sink into the database at dbURL. The
+// backup is verified against its checksum and decoded before pg_restore
+// replaces the contents of the database.
+func RestoreDatabase(ctx context.Context, dbURL url.URL, rootDir string, backupConfig BackupConfig, keystorePassword string, name string, lggr logger.Logger) error {
+	dir, err := backupDir(rootDir, backupConfig)
+	if err != nil {
+		return err
+	}
+	sink, err := NewSink(backupConfig, dir)
+	if err != nil {
+		return err
+	}
+	if name == LatestBackup {
+		backups, err2 := listBackups(ctx, sink)
+		if err2 != nil {
+			return err2
+		}
+		if len(backups) == 0 {
+			return errors.Wrap(ErrBackupNotFound, "no backups available")
+		}
+		name = backups[0].Name
+	}
+	if !backupNameRegexp.MatchString(name) {
+		return errors.Errorf("invalid backup name %q", name)
+	}
+	lggr.Infow("Restoring database backup", "name", name)
+
+	if err = os.MkdirAll(dir, os.ModePerm); err != nil {
+		return errors.Wrapf(err, "Failed to create directories on the path: %s", dir)
+	}
+	dump, err := os.CreateTemp(dir, "cl_restore_tmp_")
+	if err != nil {
+		return errors.Wrap(err, "Failed to create a tmp file")
+	}
+	defer os.Remove(dump.Name())
+	defer dump.Close()
+
+	if err = fetchBackup(ctx, sink, name, keystorePassword, dir, dump); err != nil {
+		return err
+	}
+	if err = dump.Close(); err != nil {
+		return err
+	}
+
+	// check that the archive is readable before touching the database
+	if out, err2 := exec.CommandContext(ctx, "pg_restore", "--list", dump.Name()).CombinedOutput(); err2 != nil {
+		return errors.Wrapf(err2, "backup %s is not a valid pg_dump archive: %s", name, string(out))
+	}
+
+	args := []string{
+		"--clean", "--if-exists", "--no-owner", "--single-transaction",
+		"-d", dbURL.String(),
+		dump.Name(),
+	}
+	if out, err2 := exec.CommandContext(ctx, "pg_restore", args...).CombinedOutput(); err2 != nil {
+		return errors.Wrapf(err2, "pg_restore failed with output: %s", string(out))
+	}
+	lggr.Infow("Database backup restored", "name", name, "database", dbURL.Redacted())
+	return nil
+}
+
+// fetchBackup downloads the backup name from sink, verifies its checksum and writes its decoded contents to w.
+func fetchBackup(ctx context.Context, sink Sink, name string, password string, tmpDir string, w io.Writer) error {
+	// unverified backups are never restored
+	cr, err := sink.Get(ctx, name+checksumExt)
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch the backup checksum")
+	}
+	b, err := io.ReadAll(io.LimitReader(cr, 1024))
+	cr.Close()
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch the backup checksum")
+	}
+	fields := strings.Fields(string(b))
+	if len(fields) == 0 {
+		return errors.Errorf("invalid checksum file for backup %s", name)
+	}
+	wantChecksum := fields[0]
+
+	r, err := sink.Get(ctx, name)
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch the backup")
+	}
+	defer r.Close()
+
+	// the backup is downloaded and verified before it is decoded
+	encoded, err := os.CreateTemp(tmpDir, "cl_restore_tmp_")
+	if err != nil {
+		return errors.Wrap(err, "Failed to create a tmp file")
+	}
+	defer os.Remove(encoded.Name())
+	defer encoded.Close()
+
+	hash := sha256.New()
+	if _, err = io.Copy(io.MultiWriter(encoded, hash), r); err != nil {
+		return errors.Wrap(err, "failed to fetch the backup")
+	}
+	if got := hex.EncodeToString(hash.Sum(nil)); got != wantChecksum {
+		return errors.Errorf("checksum mismatch for backup %s: expected %s, got %s", name, wantChecksum, got)
+	}
+	if _, err = encoded.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	decoder, err := newDecoder(encoded, name, password)
+	if err != nil {
+		return err
+	}
+	defer decoder.Close()
+	if _, err = io.Copy(w, decoder); err != nil {
+		return errors.Wrapf(err, "failed to decode backup %s", name)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package periodicbackup
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/config"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestPeriodicBackup_StoreAndFetch(t *testing.T) {
+	ctx := testutils.Context(t)
+	lggr := logger.TestLogger(t)
+	dir := t.TempDir()
+	sink := NewFilesystemSink(dir)
+	backup := &databaseBackup{
+		logger:          lggr,
+		outputParentDir: dir,
+		sink:            sink,
+		compression:     config.DatabaseBackupCompressionZstd,
+		password:        "p4ssw0rd",
+		retention:       &testRetention{daily: 2},
+	}
+
+	dump := filepath.Join(t.TempDir(), "dump")
+	content := []byte(strings.Repeat("CREATE TABLE foo (id int);\n", 1000))
+	require.NoError(t, os.WriteFile(dump, content, 0600))
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var names []string
+	for i := 0; i < 3; i++ {
+		name := backupName("1.0.0", start.Add(time.Duration(i)*24*time.Hour), backup.compression, true)
+		location, size, err := backup.store(ctx, dump, name)
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(dir, name), location)
+		assert.Less(t, size, int64(len(content)))
+		assert.FileExists(t, location+checksumExt)
+		names = append(names, name)
+	}
+
+	require.NoError(t, backup.applyRetention(ctx))
+	backups, err := listBackups(ctx, sink)
+	require.NoError(t, err)
+	require.Len(t, backups, 2)
+	assert.Equal(t, names[2], backups[0].Name)
+	assert.Equal(t, names[1], backups[1].Name)
+	assert.NoFileExists(t, filepath.Join(dir, names[0]))
+	assert.NoFileExists(t, filepath.Join(dir, names[0]+checksumExt))
+
+	t.Run("fetch", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, fetchBackup(ctx, sink, names[2], "p4ssw0rd", t.TempDir(), &buf))
+		assert.Equal(t, content, buf.Bytes())
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := fetchBackup(ctx, sink, names[2], "wrong", t.TempDir(), &buf)
+		require.ErrorIs(t, err, ErrDecrypt)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := fetchBackup(ctx, sink, names[0], "p4ssw0rd", t.TempDir(), &buf)
+		require.ErrorIs(t, err, ErrBackupNotFound)
+	})
+
+	t.Run("missing checksum", func(t *testing.T) {
+		require.NoError(t, os.Rename(filepath.Join(dir, names[2]+checksumExt), filepath.Join(dir, "moved")))
+		defer func() { require.NoError(t, os.Rename(filepath.Join(dir, "moved"), filepath.Join(dir, names[2]+checksumExt))) }()
+
+		var buf bytes.Buffer
+		err := fetchBackup(ctx, sink, names[2], "p4ssw0rd", t.TempDir(), &buf)
+		require.ErrorIs(t, err, ErrBackupNotFound)
+		require.ErrorContains(t, err, "failed to fetch the backup checksum")
+		assert.Zero(t, buf.Len())
+	})
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		path := filepath.Join(dir, names[1])
+		b, err := os.ReadFile(path)
+		require.NoError(t, err)
+		b[len(b)/2] ^= 1
+		require.NoError(t, os.WriteFile(path, b, 0600))
+
+		var buf bytes.Buffer
+		err = fetchBackup(ctx, sink, names[1], "p4ssw0rd", t.TempDir(), &buf)
+		require.ErrorContains(t, err, "checksum mismatch")
+		assert.Zero(t, buf.Len())
+	})
+}
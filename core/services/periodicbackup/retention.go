@@ -0,0 +1,83 @@
+package periodicbackup
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/v2/core/config"
+)
+
+const (
+	checksumExt     = ".sha256"
+	timestampFormat = "20060102T150405Z"
+)
+
+var (
+	// backupNameRegexp matches the names of backups, capturing the optional timestamp.
+	backupNameRegexp = regexp.MustCompile(`^cl_backup_.+?(?:_(\d{8}T\d{6}Z))?\.dump(?:\.gz|\.zst)?(?:\.enc)?$`)
+)
+
+// backupName returns the name of a backup of the given version. Backups are
+// timestamped if at is not zero, otherwise they replace the previous backup of
+// the same version.
+func backupName(version string, at time.Time, compression config.DatabaseBackupCompression, encrypt bool) string {
+	name := fmt.Sprintf(filePattern, version)
+	if !at.IsZero() {
+		name = fmt.Sprintf(filePattern, version+"_"+at.UTC().Format(timestampFormat))
+	}
+	return name + encodedExt(compression, encrypt)
+}
+
+// backupTimestamp returns the timestamp of the backup name, if it has one.
+func backupTimestamp(name string) (time.Time, bool) {
+	m := backupNameRegexp.FindStringSubmatch(name)
+	if m == nil || m[1] == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(timestampFormat, m[1])
+	return t, err == nil
+}
+
+// expiredBackups returns the timestamped backups which are not retained: the
+// most recent backup of each of the most recent daily days and weekly ISO
+// weeks is kept. Backups without a timestamp are never expired.
+func expiredBackups(names []string, daily, weekly uint32) []string {
+	type backup struct {
+		name string
+		at   time.Time
+	}
+	var backups []backup
+	for _, name := range names {
+		if at, ok := backupTimestamp(name); ok {
+			backups = append(backups, backup{name, at})
+		}
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].at.After(backups[j].at) })
+
+	keep := make(map[string]bool)
+	days := make(map[string]bool)
+	weeks := make(map[string]bool)
+	for _, b := range backups {
+		day := b.at.Format(time.DateOnly)
+		if !days[day] && uint32(len(days)) < daily {
+			days[day] = true
+			keep[b.name] = true
+		}
+		year, w := b.at.ISOWeek()
+		week := fmt.Sprintf("%d-%d", year, w)
+		if !weeks[week] && uint32(len(weeks)) < weekly {
+			weeks[week] = true
+			keep[b.name] = true
+		}
+	}
+
+	var expired []string
+	for _, b := range backups {
+		if !keep[b.name] {
+			expired = append(expired, b.name)
+		}
+	}
+	return expired
+}
@@ -0,0 +1,84 @@
+package periodicbackup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/config"
+)
+
+func TestBackupName(t *testing.T) {
+	at := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, "cl_backup_1.2.3.dump", backupName("1.2.3", time.Time{}, config.DatabaseBackupCompressionNone, false))
+	assert.Equal(t, "cl_backup_1.2.3_20240301T123000Z.dump.gz", backupName("1.2.3", at, config.DatabaseBackupCompressionGzip, false))
+	assert.Equal(t, "cl_backup_1.2.3_20240301T123000Z.dump.zst.enc", backupName("1.2.3", at, config.DatabaseBackupCompressionZstd, true))
+
+	got, ok := backupTimestamp(backupName("2.0.0-beta_1", at, config.DatabaseBackupCompressionNone, true))
+	require.True(t, ok)
+	assert.Equal(t, at, got)
+
+	_, ok = backupTimestamp("cl_backup_1.2.3.dump")
+	assert.False(t, ok)
+	assert.False(t, backupNameRegexp.MatchString("cl_backup_1.2.3.dump.sha256"))
+	assert.False(t, backupNameRegexp.MatchString("cl_backup_tmp_123"))
+}
+
+func TestExpiredBackups(t *testing.T) {
+	// one backup every 12 hours, from Monday 2024-01-01 to Friday 2024-01-19
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var names []string
+	for at := start; at.Before(start.Add(19 * 24 * time.Hour)); at = at.Add(12 * time.Hour) {
+		names = append(names, backupName("1.0.0", at, config.DatabaseBackupCompressionNone, false))
+	}
+	names = append(names, "cl_backup_0.9.0.dump")
+	name := func(s string) string { return "cl_backup_1.0.0_" + s + ".dump" }
+
+	kept := func(expired []string) []string {
+		isExpired := make(map[string]bool)
+		for _, e := range expired {
+			isExpired[e] = true
+		}
+		var kept []string
+		for _, n := range names {
+			if !isExpired[n] {
+				kept = append(kept, n)
+			}
+		}
+		return kept
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		assert.Len(t, expiredBackups(names, 0, 0), len(names)-1)
+	})
+
+	t.Run("daily", func(t *testing.T) {
+		assert.Equal(t, []string{
+			name("20240117T120000Z"),
+			name("20240118T120000Z"),
+			name("20240119T120000Z"),
+			"cl_backup_0.9.0.dump",
+		}, kept(expiredBackups(names, 3, 0)))
+	})
+
+	t.Run("weekly", func(t *testing.T) {
+		assert.Equal(t, []string{
+			name("20240107T120000Z"),
+			name("20240114T120000Z"),
+			name("20240119T120000Z"),
+			"cl_backup_0.9.0.dump",
+		}, kept(expiredBackups(names, 0, 5)))
+	})
+
+	t.Run("daily and weekly", func(t *testing.T) {
+		assert.Equal(t, []string{
+			name("20240114T120000Z"),
+			name("20240118T120000Z"),
+			name("20240119T120000Z"),
+			"cl_backup_0.9.0.dump",
+		}, kept(expiredBackups(names, 2, 2)))
+	})
+}
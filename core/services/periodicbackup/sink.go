@@ -0,0 +1,44 @@
+package periodicbackup
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/config"
+)
+
+var ErrBackupNotFound = errors.New("backup not found")
+
+// Object is a file stored in a Sink.
+type Object struct {
+	Name       string
+	Size       int64
+	ModifiedAt time.Time
+}
+
+// Sink stores backup files.
+type Sink interface {
+	// Put stores size bytes read from r as the file name, replacing any existing file, and returns its location.
+	Put(ctx context.Context, name string, r io.Reader, size int64) (string, error)
+	// Get opens the file name for reading. It returns ErrBackupNotFound if the file does not exist.
+	Get(ctx context.Context, name string) (io.ReadCloser, error)
+	// List returns all stored files.
+	List(ctx context.Context) ([]Object, error)
+	// Delete removes the file name, if it exists.
+	Delete(ctx context.Context, name string) error
+}
+
+// NewSink returns the Sink configured by cfg. Dir is the directory of the filesystem sink.
+func NewSink(cfg BackupConfig, dir string) (Sink, error) {
+	switch cfg.Sink() {
+	case config.DatabaseBackupSinkFilesystem, "":
+		return NewFilesystemSink(dir), nil
+	case config.DatabaseBackupSinkS3:
+		return NewS3Sink(cfg.S3())
+	default:
+		return nil, errors.Errorf("unknown backup sink: %s", cfg.Sink())
+	}
+}
@@ -0,0 +1,83 @@
+package periodicbackup
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+type filesystemSink struct {
+	dir string
+}
+
+var _ Sink = (*filesystemSink)(nil)
+
+// NewFilesystemSink returns a Sink storing files in dir.
+func NewFilesystemSink(dir string) Sink {
+	return &filesystemSink{dir}
+}
+
+func (s *filesystemSink) Put(_ context.Context, name string, r io.Reader, _ int64) (string, error) {
+	if err := os.MkdirAll(s.dir, os.ModePerm); err != nil {
+		return "", errors.Wrapf(err, "Failed to create directories on the path: %s", s.dir)
+	}
+	tmpFile, err := os.CreateTemp(s.dir, "cl_backup_tmp_")
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to create a tmp file")
+	}
+	defer os.Remove(tmpFile.Name())
+
+	_, err = io.Copy(tmpFile, r)
+	if cerr := tmpFile.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to write the backup file")
+	}
+
+	path := filepath.Join(s.dir, filepath.Base(name))
+	if err = os.Rename(tmpFile.Name(), path); err != nil {
+		return "", errors.Wrap(err, "Failed to rename the temp file to the final backup file")
+	}
+	return path, nil
+}
+
+func (s *filesystemSink) Get(_ context.Context, name string) (io.ReadCloser, error) {
+	f, err := os.Open(filepath.Join(s.dir, filepath.Base(name)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, errors.Wrap(ErrBackupNotFound, name)
+	}
+	return f, err
+}
+
+func (s *filesystemSink) List(context.Context) ([]Object, error) {
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var objects []Object
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, Object{Name: entry.Name(), Size: info.Size(), ModifiedAt: info.ModTime()})
+	}
+	return objects, nil
+}
+
+func (s *filesystemSink) Delete(_ context.Context, name string) error {
+	err := os.Remove(filepath.Join(s.dir, filepath.Base(name)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,431 @@
+package periodicbackup
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/config"
+)
+
+const (
+	s3DateFormat      = "20060102T150405Z"
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+	// s3EmptyPayload is the SHA-256 of an empty payload.
+	s3EmptyPayload = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	// s3RequestTimeout bounds each request, including the upload or download of a backup.
+	s3RequestTimeout = time.Hour
+	// s3ResponseHeaderTimeout bounds the wait for the response of a stuck endpoint once a request is sent.
+	s3ResponseHeaderTimeout = time.Minute
+	// s3PartSize is the minimum size of the parts of multipart uploads. Files up to this size are uploaded with a
+	// single PUT, which S3 limits to 5 GiB.
+	s3PartSize = 256 << 20
+	// s3MaxParts is the maximum number of parts of a multipart upload.
+	s3MaxParts = 10000
+)
+
+// s3Sink stores files in a bucket of an S3-compatible service. It implements
+// the subset of the S3 REST API needed for backups, signed with AWS Signature
+// Version 4.
+type s3Sink struct {
+	client          *http.Client
+	endpoint        url.URL
+	bucket          string
+	prefix          string
+	region          string
+	usePathStyle    bool
+	accessKeyID     string
+	secretAccessKey string
+	partSize        int64
+	now             func() time.Time
+}
+
+var _ Sink = (*s3Sink)(nil)
+
+// NewS3Sink returns a Sink storing files in the S3 bucket configured by cfg.
+func NewS3Sink(cfg config.BackupS3) (Sink, error) {
+	if cfg.Bucket() == "" {
+		return nil, errors.New("Database.Backup.S3.Bucket is required")
+	}
+	if cfg.Region() == "" {
+		return nil, errors.New("Database.Backup.S3.Region is required")
+	}
+	endpoint := cfg.Endpoint()
+	if endpoint == nil || endpoint.Host == "" {
+		endpoint = &url.URL{Scheme: "https", Host: fmt.Sprintf("s3.%s.amazonaws.com", cfg.Region())}
+	}
+	return &s3Sink{
+		client:          newS3Client(),
+		endpoint:        *endpoint,
+		bucket:          cfg.Bucket(),
+		prefix:          cfg.Prefix(),
+		region:          cfg.Region(),
+		usePathStyle:    cfg.UsePathStyle(),
+		accessKeyID:     cfg.AccessKeyID(),
+		secretAccessKey: cfg.SecretAccessKey(),
+		partSize:        s3PartSize,
+		now:             time.Now,
+	}, nil
+}
+
+func newS3Client() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = s3ResponseHeaderTimeout
+	return &http.Client{Transport: transport, Timeout: s3RequestTimeout}
+}
+
+// objectURL returns the URL of the object key, or of the bucket if key is empty.
+func (s *s3Sink) objectURL(key string) *url.URL {
+	u := s.endpoint
+	basePath := strings.TrimSuffix(u.Path, "/")
+	if s.usePathStyle {
+		u.Path = basePath + "/" + s.bucket + "/" + key
+	} else {
+		u.Host = s.bucket + "." + u.Host
+		u.Path = basePath + "/" + key
+	}
+	return &u
+}
+
+func (s *s3Sink) Put(ctx context.Context, name string, r io.Reader, size int64) (string, error) {
+	key := s.prefix + name
+	var err error
+	if size > s.partSize {
+		err = s.putMultipart(ctx, key, r, size)
+	} else {
+		_, err = s.putPart(ctx, s.objectURL(key), r, size)
+	}
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("s3://%s/%s", s.bucket, key), nil
+}
+
+// putPart uploads size bytes read from r to u, and returns the ETag of the response.
+func (s *s3Sink) putPart(ctx context.Context, u *url.URL, r io.Reader, size int64) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u.String(), io.NopCloser(io.LimitReader(r, size)))
+	if err != nil {
+		return "", err
+	}
+	req.ContentLength = size
+	resp, err := s.do(req, s3UnsignedPayload)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	return resp.Header.Get("ETag"), nil
+}
+
+type s3InitiateMultipartUploadResult struct {
+	UploadID string `xml:"UploadId"`
+}
+
+type s3CompletedPart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+type s3CompleteMultipartUpload struct {
+	XMLName xml.Name          `xml:"CompleteMultipartUpload"`
+	Parts   []s3CompletedPart `xml:"Part"`
+}
+
+// putMultipart uploads size bytes read from r as key in parts, as S3 rejects single PUTs of more than 5 GiB. The
+// upload is aborted if any part fails, so that no incomplete parts are left behind.
+func (s *s3Sink) putMultipart(ctx context.Context, key string, r io.Reader, size int64) (err error) {
+	partSize := max(s.partSize, (size+s3MaxParts-1)/s3MaxParts)
+
+	u := s.objectURL(key)
+	u.RawQuery = "uploads="
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(req, s3EmptyPayload)
+	if err != nil {
+		return err
+	}
+	var initiated s3InitiateMultipartUploadResult
+	err = xml.NewDecoder(resp.Body).Decode(&initiated)
+	resp.Body.Close()
+	if err != nil {
+		return errors.Wrap(err, "failed to decode S3 multipart upload")
+	}
+	defer func() {
+		if err != nil {
+			s.abortMultipart(context.WithoutCancel(ctx), key, initiated.UploadID)
+		}
+	}()
+
+	var complete s3CompleteMultipartUpload
+	for offset, number := int64(0), 1; offset < size; offset, number = offset+partSize, number+1 {
+		partURL := s.objectURL(key)
+		partURL.RawQuery = url.Values{"partNumber": {strconv.Itoa(number)}, "uploadId": {initiated.UploadID}}.Encode()
+		etag, err2 := s.putPart(ctx, partURL, r, min(partSize, size-offset))
+		if err2 != nil {
+			return errors.Wrapf(err2, "failed to upload part %d", number)
+		}
+		complete.Parts = append(complete.Parts, s3CompletedPart{PartNumber: number, ETag: etag})
+	}
+
+	body, err := xml.Marshal(complete)
+	if err != nil {
+		return err
+	}
+	u = s.objectURL(key)
+	u.RawQuery = url.Values{"uploadId": {initiated.UploadID}}.Encode()
+	req, err = http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/xml")
+	hash := sha256.Sum256(body)
+	resp, err = s.do(req, hex.EncodeToString(hash[:]))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// S3 may report a failure to complete the upload in the body of a successful response
+	var result struct {
+		XMLName xml.Name
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	if err = xml.NewDecoder(resp.Body).Decode(&result); err == nil && result.XMLName.Local == "Error" {
+		return errors.Errorf("S3 failed to complete multipart upload: %s: %s", result.Code, result.Message)
+	}
+	return nil
+}
+
+func (s *s3Sink) abortMultipart(ctx context.Context, key, uploadID string) {
+	u := s.objectURL(key)
+	u.RawQuery = url.Values{"uploadId": {uploadID}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, u.String(), nil)
+	if err != nil {
+		return
+	}
+	resp, err := s.do(req, s3EmptyPayload)
+	if err != nil {
+		return
+	}
+	resp.Body.Close()
+}
+
+func (s *s3Sink) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.objectURL(s.prefix+name).String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.do(req, s3EmptyPayload)
+	if err != nil {
+		return nil, errors.Wrap(err, name)
+	}
+	return resp.Body, nil
+}
+
+type s3ListBucketResult struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		Size         int64     `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+func (s *s3Sink) List(ctx context.Context) ([]Object, error) {
+	var objects []Object
+	var continuationToken string
+	for {
+		u := s.objectURL("")
+		query := url.Values{"list-type": {"2"}}
+		if s.prefix != "" {
+			query.Set("prefix", s.prefix)
+		}
+		if continuationToken != "" {
+			query.Set("continuation-token", continuationToken)
+		}
+		u.RawQuery = query.Encode()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := s.do(req, s3EmptyPayload)
+		if err != nil {
+			return nil, err
+		}
+		var result s3ListBucketResult
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode S3 object list")
+		}
+		for _, c := range result.Contents {
+			name := strings.TrimPrefix(c.Key, s.prefix)
+			if name == "" || strings.Contains(name, "/") {
+				continue
+			}
+			objects = append(objects, Object{Name: name, Size: c.Size, ModifiedAt: c.LastModified})
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return objects, nil
+		}
+		continuationToken = result.NextContinuationToken
+	}
+}
+
+func (s *s3Sink) Delete(ctx context.Context, name string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.objectURL(s.prefix+name).String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(req, s3EmptyPayload)
+	if errors.Is(err, ErrBackupNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// do signs and sends req, and returns an error for unsuccessful responses.
+func (s *s3Sink) do(req *http.Request, payloadHash string) (*http.Response, error) {
+	s.sign(req, payloadHash, s.now().UTC())
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrBackupNotFound
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return nil, errors.Errorf("S3 %s %s failed with status %d: %s", req.Method, req.URL.Path, resp.StatusCode, string(body))
+}
+
+// sign adds an AWS Signature Version 4 to req. Requests are sent unsigned if no credentials are configured.
+func (s *s3Sink) sign(req *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.Format(s3DateFormat)
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+	if s.accessKeyID == "" {
+		return
+	}
+
+	signedHeaders := []string{"host"}
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		name = strings.ToLower(name)
+		if name == "x-amz-date" || name == "x-amz-content-sha256" || name == "content-type" || name == "range" {
+			headers[name] = strings.TrimSpace(strings.Join(values, ","))
+			signedHeaders = append(signedHeaders, name)
+		}
+	}
+	sort.Strings(signedHeaders)
+	var canonicalHeaders strings.Builder
+	for _, name := range signedHeaders {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		s3EscapePath(req.URL.EscapedPath()),
+		s3CanonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		strings.Join(signedHeaders, ";"),
+		payloadHash,
+	}, "\n")
+
+	date := amzDate[:8]
+	scope := date + "/" + s.region + "/s3/aws4_request"
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hash[:])
+
+	key := hmacSHA256([]byte("AWS4"+s.secretAccessKey), date)
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKeyID, scope, strings.Join(signedHeaders, ";"), signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// s3EscapePath returns the canonical form of an escaped path, in which every
+// byte except for unreserved characters and '/' is percent-encoded.
+func s3EscapePath(escaped string) string {
+	path, err := url.PathUnescape(escaped)
+	if err != nil {
+		path = escaped
+	}
+	if path == "" {
+		return "/"
+	}
+	var sb strings.Builder
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		if c == '/' || s3Unreserved(c) {
+			sb.WriteByte(c)
+		} else {
+			fmt.Fprintf(&sb, "%%%02X", c)
+		}
+	}
+	return sb.String()
+}
+
+func s3CanonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, k := range keys {
+		values := query[k]
+		sort.Strings(values)
+		for _, v := range values {
+			parts = append(parts, s3EscapeQuery(k)+"="+s3EscapeQuery(v))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+func s3EscapeQuery(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if s3Unreserved(c) {
+			sb.WriteByte(c)
+		} else {
+			fmt.Fprintf(&sb, "%%%02X", c)
+		}
+	}
+	return sb.String()
+}
+
+func s3Unreserved(c byte) bool {
+	return c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.' || c == '~'
+}
@@ -310,6 +310,7 @@ const (
 	TaskTypeETHCall          TaskType = "ethcall"
 	TaskTypeETHTx            TaskType = "ethtx"
 	TaskTypeEstimateGasLimit TaskType = "estimategaslimit"
+	TaskTypeExpr             TaskType = "expr"
 	TaskTypeHTTP             TaskType = "http"
 	TaskTypeHexDecode        TaskType = "hexdecode"
 	TaskTypeHexEncode        TaskType = "hexencode"
@@ -318,6 +319,7 @@ const (
 	TaskTypeLessThan         TaskType = "lessthan"
 	TaskTypeLookup           TaskType = "lookup"
 	TaskTypeLowercase        TaskType = "lowercase"
+	TaskTypeMap              TaskType = "map"
 	TaskTypeMean             TaskType = "mean"
 	TaskTypeMedian           TaskType = "median"
 	TaskTypeMerge            TaskType = "merge"
@@ -406,6 +408,8 @@ func UnmarshalTaskFromMap(taskType TaskType, taskMap interface{}, ID int, dotID
 		task = &FailTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
 	case TaskTypeMerge:
 		task = &MergeTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
+	case TaskTypeMap:
+		task = &MapTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
 	case TaskTypeLength:
 		task = &LengthTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
 	case TaskTypeLessThan:
@@ -418,6 +422,8 @@ func UnmarshalTaskFromMap(taskType TaskType, taskMap interface{}, ID int, dotID
 		task = &UppercaseTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
 	case TaskTypeConditional:
 		task = &ConditionalTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
+	case TaskTypeExpr:
+		task = &ExprTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
 	case TaskTypeHexDecode:
 		task = &HexDecodeTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
 	case TaskTypeHexEncode:
@@ -475,6 +481,21 @@ func UnmarshalTaskFromMap(taskType TaskType, taskMap interface{}, ID int, dotID
 		}
 	}
 
+	// tasks with nested sources are parsed upfront so that spec errors surface early
+	switch t := task.(type) {
+	case *MapTask:
+		err = t.parseBody()
+	case *ExprTask:
+		err = t.parseExpr()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = validateResultCache(task); err != nil {
+		return nil, err
+	}
+
 	return task, nil
 }
 
@@ -0,0 +1,774 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+// The expression language of the expr task.
+//
+// Values are big decimals, strings and booleans. Expressions consist of
+//
+//   - literals: 42, 1.5, 1e8, 'text', "text", true, false
+//   - variables: $(foo.bar), resolved through Vars
+//   - arithmetic: + - * / % and unary -
+//   - comparison: == != < <= > >=
+//   - logic: && || ! and the conditional cond ? a : b
+//   - functions: abs, ceil, floor, round, min, max, pow, len, lower, upper, decimal, string
+//
+// Strings are converted to decimals where a decimal is expected, e.g.
+// "1.5" * 2 is 3, except for + which concatenates two strings.
+//
+// Evaluation is deterministic and free of side effects. The length of an
+// expression and the size of the decimals it produces are bounded, and
+// evaluation stops once the context is done.
+
+const (
+	// maxExprLength is the maximum length of an expression in bytes.
+	maxExprLength = 4096
+	// maxExprDigits is the maximum number of digits of a decimal, and the maximum magnitude of its exponent.
+	maxExprDigits = 1024
+	// maxExprPow is the maximum magnitude of the exponent of pow().
+	maxExprPow = 256
+)
+
+var (
+	ErrExprSyntax   = errors.New("expression syntax error")
+	ErrExprType     = errors.New("expression type error")
+	ErrExprOverflow = errors.New("expression overflow")
+)
+
+// expr is a parsed expression.
+type expr interface {
+	eval(ec *exprContext) (interface{}, error)
+}
+
+type exprContext struct {
+	ctx       context.Context
+	vars      Vars
+	precision *int32
+}
+
+// parseExpr parses an expression of the expr task.
+func parseExpr(source string) (expr, error) {
+	if len(source) > maxExprLength {
+		return nil, errors.Wrapf(ErrExprSyntax, "expression longer than %d bytes", maxExprLength)
+	}
+	tokens, err := lexExpr(source)
+	if err != nil {
+		return nil, err
+	}
+	p := exprParser{tokens: tokens}
+	e, err := p.parseConditional()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != exprTokenEOF {
+		return nil, errors.Wrapf(ErrExprSyntax, "unexpected %q at offset %d", tok.text, tok.pos)
+	}
+	return e, nil
+}
+
+// evalExpr evaluates a parsed expression. If precision is not nil, divisions
+// are rounded to that many decimal places.
+func evalExpr(ctx context.Context, e expr, vars Vars, precision *int32) (interface{}, error) {
+	return e.eval(&exprContext{ctx: ctx, vars: vars, precision: precision})
+}
+
+// Lexer
+
+type exprTokenKind int
+
+const (
+	exprTokenEOF exprTokenKind = iota
+	exprTokenNumber
+	exprTokenString
+	exprTokenVar
+	exprTokenIdent
+	exprTokenOp
+)
+
+type exprToken struct {
+	kind exprTokenKind
+	text string
+	pos  int
+}
+
+var exprOps = []string{"==", "!=", "<=", ">=", "&&", "||", "+", "-", "*", "/", "%", "<", ">", "!", "(", ")", ",", "?", ":"}
+
+func lexExpr(source string) ([]exprToken, error) {
+	var tokens []exprToken
+	for i := 0; i < len(source); {
+		c := source[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '$':
+			if i+1 >= len(source) || source[i+1] != '(' {
+				return nil, errors.Wrapf(ErrExprSyntax, "expected '(' after '$' at offset %d", i)
+			}
+			end := strings.IndexByte(source[i:], ')')
+			if end < 0 {
+				return nil, errors.Wrapf(ErrExprSyntax, "unterminated variable at offset %d", i)
+			}
+			keypath := strings.TrimSpace(source[i+2 : i+end])
+			if keypath == "" {
+				return nil, errors.Wrapf(ErrExprSyntax, "empty variable at offset %d", i)
+			}
+			tokens = append(tokens, exprToken{exprTokenVar, keypath, i})
+			i += end + 1
+		case c >= '0' && c <= '9' || c == '.':
+			start := i
+			for i < len(source) && (source[i] >= '0' && source[i] <= '9' || source[i] == '.') {
+				i++
+			}
+			if i < len(source) && (source[i] == 'e' || source[i] == 'E') {
+				i++
+				if i < len(source) && (source[i] == '+' || source[i] == '-') {
+					i++
+				}
+				for i < len(source) && source[i] >= '0' && source[i] <= '9' {
+					i++
+				}
+			}
+			tokens = append(tokens, exprToken{exprTokenNumber, source[start:i], start})
+		case c == '\'' || c == '"':
+			start := i
+			var sb strings.Builder
+			for i++; ; i++ {
+				if i >= len(source) {
+					return nil, errors.Wrapf(ErrExprSyntax, "unterminated string at offset %d", start)
+				}
+				if source[i] == '\\' && i+1 < len(source) {
+					i++
+				} else if source[i] == c {
+					break
+				}
+				sb.WriteByte(source[i])
+			}
+			i++
+			tokens = append(tokens, exprToken{exprTokenString, sb.String(), start})
+		case isExprIdentChar(c, false):
+			start := i
+			for i < len(source) && isExprIdentChar(source[i], true) {
+				i++
+			}
+			tokens = append(tokens, exprToken{exprTokenIdent, source[start:i], start})
+		default:
+			var op string
+			for _, o := range exprOps {
+				if strings.HasPrefix(source[i:], o) {
+					op = o
+					break
+				}
+			}
+			if op == "" {
+				return nil, errors.Wrapf(ErrExprSyntax, "unexpected %q at offset %d", c, i)
+			}
+			tokens = append(tokens, exprToken{exprTokenOp, op, i})
+			i += len(op)
+		}
+	}
+	return append(tokens, exprToken{exprTokenEOF, "end of expression", len(source)}), nil
+}
+
+func isExprIdentChar(c byte, digits bool) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || digits && c >= '0' && c <= '9'
+}
+
+// Parser
+
+type exprParser struct {
+	tokens []exprToken
+	pos    int
+}
+
+func (p *exprParser) peek() exprToken {
+	return p.tokens[p.pos]
+}
+
+func (p *exprParser) next() exprToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != exprTokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// accept consumes the next token if it is one of the given operators.
+func (p *exprParser) accept(ops ...string) (string, bool) {
+	tok := p.peek()
+	if tok.kind != exprTokenOp {
+		return "", false
+	}
+	for _, op := range ops {
+		if tok.text == op {
+			p.pos++
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *exprParser) expect(op string) error {
+	if _, ok := p.accept(op); !ok {
+		tok := p.peek()
+		return errors.Wrapf(ErrExprSyntax, "expected %q at offset %d, got %q", op, tok.pos, tok.text)
+	}
+	return nil
+}
+
+func (p *exprParser) parseConditional() (expr, error) {
+	cond, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := p.accept("?"); !ok {
+		return cond, nil
+	}
+	then, err := p.parseConditional()
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expect(":"); err != nil {
+		return nil, err
+	}
+	otherwise, err := p.parseConditional()
+	if err != nil {
+		return nil, err
+	}
+	return &exprConditional{cond, then, otherwise}, nil
+}
+
+// exprPrecedence lists the binary operators from the lowest to the highest precedence.
+var exprPrecedence = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!=", "<", "<=", ">", ">="},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+func (p *exprParser) parseBinary(level int) (expr, error) {
+	if level == len(exprPrecedence) {
+		return p.parseUnary()
+	}
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.accept(exprPrecedence[level]...)
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = &exprBinary{op, left, right}
+	}
+}
+
+func (p *exprParser) parseUnary() (expr, error) {
+	if op, ok := p.accept("-", "!"); ok {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &exprUnary{op, operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *exprParser) parsePrimary() (expr, error) {
+	tok := p.next()
+	switch tok.kind {
+	case exprTokenNumber:
+		d, err := decimal.NewFromString(tok.text)
+		if err != nil {
+			return nil, errors.Wrapf(ErrExprSyntax, "invalid number %q at offset %d", tok.text, tok.pos)
+		}
+		if err = checkExprDecimal(d); err != nil {
+			return nil, err
+		}
+		return &exprLiteral{d}, nil
+	case exprTokenString:
+		return &exprLiteral{tok.text}, nil
+	case exprTokenVar:
+		if _, err := NewKeypathFromString(tok.text); err != nil {
+			return nil, errors.Wrapf(ErrExprSyntax, "invalid variable %q at offset %d: %v", tok.text, tok.pos, err)
+		}
+		return &exprVar{tok.text}, nil
+	case exprTokenIdent:
+		switch tok.text {
+		case "true":
+			return &exprLiteral{true}, nil
+		case "false":
+			return &exprLiteral{false}, nil
+		}
+		fn, exists := exprFuncs[tok.text]
+		if !exists {
+			return nil, errors.Wrapf(ErrExprSyntax, "unknown function %q at offset %d", tok.text, tok.pos)
+		}
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		var args []expr
+		if _, ok := p.accept(")"); !ok {
+			for {
+				arg, err := p.parseConditional()
+				if err != nil {
+					return nil, err
+				}
+				args = append(args, arg)
+				if _, ok := p.accept(","); !ok {
+					break
+				}
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+		}
+		if len(args) < fn.minArgs || (fn.maxArgs >= 0 && len(args) > fn.maxArgs) {
+			return nil, errors.Wrapf(ErrExprSyntax, "wrong number of arguments to %s at offset %d", tok.text, tok.pos)
+		}
+		return &exprCall{tok.text, fn, args}, nil
+	case exprTokenOp:
+		if tok.text == "(" {
+			e, err := p.parseConditional()
+			if err != nil {
+				return nil, err
+			}
+			if err = p.expect(")"); err != nil {
+				return nil, err
+			}
+			return e, nil
+		}
+	}
+	return nil, errors.Wrapf(ErrExprSyntax, "unexpected %q at offset %d", tok.text, tok.pos)
+}
+
+// Evaluation
+
+type exprLiteral struct {
+	value interface{}
+}
+
+func (e *exprLiteral) eval(ec *exprContext) (interface{}, error) {
+	return e.value, ec.ctx.Err()
+}
+
+type exprVar struct {
+	keypath string
+}
+
+func (e *exprVar) eval(ec *exprContext) (interface{}, error) {
+	if err := ec.ctx.Err(); err != nil {
+		return nil, err
+	}
+	val, err := ec.vars.Get(e.keypath)
+	if err != nil {
+		return nil, err
+	}
+	switch v := val.(type) {
+	case error:
+		return nil, errors.Wrapf(ErrTooManyErrors, "$(%s): %v", e.keypath, v)
+	case string, bool:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case nil:
+		return nil, errors.Wrapf(ErrExprType, "$(%s) is null", e.keypath)
+	}
+	d, err := utils.ToDecimal(val)
+	if err != nil {
+		return nil, errors.Wrapf(ErrExprType, "$(%s): %v", e.keypath, err)
+	}
+	return d, checkExprDecimal(d)
+}
+
+type exprUnary struct {
+	op      string
+	operand expr
+}
+
+func (e *exprUnary) eval(ec *exprContext) (interface{}, error) {
+	val, err := e.operand.eval(ec)
+	if err != nil {
+		return nil, err
+	}
+	if e.op == "!" {
+		b, err := exprBool(val)
+		if err != nil {
+			return nil, err
+		}
+		return !b, nil
+	}
+	d, err := exprDecimal(val)
+	if err != nil {
+		return nil, err
+	}
+	return d.Neg(), nil
+}
+
+type exprBinary struct {
+	op          string
+	left, right expr
+}
+
+func (e *exprBinary) eval(ec *exprContext) (interface{}, error) {
+	left, err := e.left.eval(ec)
+	if err != nil {
+		return nil, err
+	}
+
+	// logical operators short-circuit
+	if e.op == "&&" || e.op == "||" {
+		l, err2 := exprBool(left)
+		if err2 != nil {
+			return nil, err2
+		}
+		if l == (e.op == "||") {
+			return l, nil
+		}
+		right, err2 := e.right.eval(ec)
+		if err2 != nil {
+			return nil, err2
+		}
+		return exprBool(right)
+	}
+
+	right, err := e.right.eval(ec)
+	if err != nil {
+		return nil, err
+	}
+
+	switch e.op {
+	case "==", "!=":
+		equal, err2 := exprEqual(left, right)
+		if err2 != nil {
+			return nil, err2
+		}
+		return equal == (e.op == "=="), nil
+	case "<", "<=", ">", ">=":
+		cmp, err2 := exprCompare(left, right)
+		if err2 != nil {
+			return nil, err2
+		}
+		switch e.op {
+		case "<":
+			return cmp < 0, nil
+		case "<=":
+			return cmp <= 0, nil
+		case ">":
+			return cmp > 0, nil
+		default:
+			return cmp >= 0, nil
+		}
+	}
+
+	if e.op == "+" {
+		ls, lok := left.(string)
+		rs, rok := right.(string)
+		if lok && rok {
+			if len(ls)+len(rs) > maxExprLength {
+				return nil, errors.Wrapf(ErrExprOverflow, "string longer than %d bytes", maxExprLength)
+			}
+			return ls + rs, nil
+		}
+	}
+
+	l, err := exprDecimal(left)
+	if err != nil {
+		return nil, err
+	}
+	r, err := exprDecimal(right)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkExprScales(l, r); err != nil {
+		return nil, err
+	}
+
+	var result decimal.Decimal
+	switch e.op {
+	case "+":
+		result = l.Add(r)
+	case "-":
+		result = l.Sub(r)
+	case "*":
+		result = l.Mul(r)
+	case "/":
+		if r.IsZero() {
+			return nil, ErrDivideByZero
+		}
+		if ec.precision != nil {
+			result = l.DivRound(r, *ec.precision)
+		} else {
+			result = l.Div(r)
+		}
+	case "%":
+		if r.IsZero() {
+			return nil, ErrDivideByZero
+		}
+		result = l.Mod(r)
+	default:
+		return nil, errors.Wrapf(ErrExprSyntax, "unknown operator %q", e.op)
+	}
+	return result, checkExprDecimal(result)
+}
+
+type exprConditional struct {
+	cond, then, otherwise expr
+}
+
+func (e *exprConditional) eval(ec *exprContext) (interface{}, error) {
+	val, err := e.cond.eval(ec)
+	if err != nil {
+		return nil, err
+	}
+	cond, err := exprBool(val)
+	if err != nil {
+		return nil, err
+	}
+	if cond {
+		return e.then.eval(ec)
+	}
+	return e.otherwise.eval(ec)
+}
+
+type exprFunc struct {
+	minArgs, maxArgs int
+	fn               func(args []interface{}) (interface{}, error)
+}
+
+type exprCall struct {
+	name string
+	fn   exprFunc
+	args []expr
+}
+
+func (e *exprCall) eval(ec *exprContext) (interface{}, error) {
+	args := make([]interface{}, len(e.args))
+	for i, arg := range e.args {
+		val, err := arg.eval(ec)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = val
+	}
+	result, err := e.fn.fn(args)
+	if err != nil {
+		return nil, errors.Wrap(err, e.name)
+	}
+	if d, ok := result.(decimal.Decimal); ok {
+		return d, checkExprDecimal(d)
+	}
+	return result, nil
+}
+
+var exprFuncs = map[string]exprFunc{
+	"abs":   {1, 1, exprDecimalFunc(decimal.Decimal.Abs)},
+	"ceil":  {1, 1, exprDecimalFunc(decimal.Decimal.Ceil)},
+	"floor": {1, 1, exprDecimalFunc(decimal.Decimal.Floor)},
+	"round": {1, 2, func(args []interface{}) (interface{}, error) {
+		d, err := exprDecimal(args[0])
+		if err != nil {
+			return nil, err
+		}
+		var places int64
+		if len(args) == 2 {
+			if places, err = exprInt(args[1], maxExprDigits); err != nil {
+				return nil, err
+			}
+		}
+		return d.Round(int32(places)), nil
+	}},
+	"min": {1, -1, func(args []interface{}) (interface{}, error) {
+		return exprReduce(args, func(a, b decimal.Decimal) bool { return b.LessThan(a) })
+	}},
+	"max": {1, -1, func(args []interface{}) (interface{}, error) {
+		return exprReduce(args, func(a, b decimal.Decimal) bool { return b.GreaterThan(a) })
+	}},
+	"pow": {2, 2, func(args []interface{}) (interface{}, error) {
+		base, err := exprDecimal(args[0])
+		if err != nil {
+			return nil, err
+		}
+		exp, err := exprInt(args[1], maxExprPow)
+		if err != nil {
+			return nil, err
+		}
+		if exp < 0 && base.IsZero() {
+			return nil, ErrDivideByZero
+		}
+		if base.NumDigits()*int(max(exp, -exp)) > maxExprDigits {
+			return nil, errors.Wrapf(ErrExprOverflow, "result has more than %d digits", maxExprDigits)
+		}
+		return base.Pow(decimal.NewFromInt(exp)), nil
+	}},
+	"len": {1, 1, func(args []interface{}) (interface{}, error) {
+		s, ok := args[0].(string)
+		if !ok {
+			return nil, errors.Wrapf(ErrExprType, "expected a string, got %s", exprTypeName(args[0]))
+		}
+		return decimal.NewFromInt(int64(len(s))), nil
+	}},
+	"lower":   {1, 1, exprStringFunc(strings.ToLower)},
+	"upper":   {1, 1, exprStringFunc(strings.ToUpper)},
+	"decimal": {1, 1, func(args []interface{}) (interface{}, error) { return exprDecimal(args[0]) }},
+	"string": {1, 1, func(args []interface{}) (interface{}, error) {
+		switch v := args[0].(type) {
+		case decimal.Decimal:
+			return v.String(), nil
+		default:
+			return fmt.Sprint(v), nil
+		}
+	}},
+}
+
+func exprDecimalFunc(fn func(decimal.Decimal) decimal.Decimal) func([]interface{}) (interface{}, error) {
+	return func(args []interface{}) (interface{}, error) {
+		d, err := exprDecimal(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return fn(d), nil
+	}
+}
+
+func exprStringFunc(fn func(string) string) func([]interface{}) (interface{}, error) {
+	return func(args []interface{}) (interface{}, error) {
+		s, ok := args[0].(string)
+		if !ok {
+			return nil, errors.Wrapf(ErrExprType, "expected a string, got %s", exprTypeName(args[0]))
+		}
+		return fn(s), nil
+	}
+}
+
+func exprReduce(args []interface{}, replace func(current, candidate decimal.Decimal) bool) (interface{}, error) {
+	var result decimal.Decimal
+	for i, arg := range args {
+		d, err := exprDecimal(arg)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 || replace(result, d) {
+			result = d
+		}
+	}
+	return result, nil
+}
+
+func exprTypeName(val interface{}) string {
+	switch val.(type) {
+	case decimal.Decimal:
+		return "decimal"
+	case string:
+		return "string"
+	case bool:
+		return "bool"
+	default:
+		return fmt.Sprintf("%T", val)
+	}
+}
+
+func exprDecimal(val interface{}) (decimal.Decimal, error) {
+	switch v := val.(type) {
+	case decimal.Decimal:
+		return v, nil
+	case string:
+		d, err := decimal.NewFromString(strings.TrimSpace(v))
+		if err != nil {
+			return decimal.Decimal{}, errors.Wrapf(ErrExprType, "string %q is not a decimal", v)
+		}
+		return d, checkExprDecimal(d)
+	default:
+		return decimal.Decimal{}, errors.Wrapf(ErrExprType, "expected a decimal, got %s", exprTypeName(val))
+	}
+}
+
+func exprBool(val interface{}) (bool, error) {
+	b, ok := val.(bool)
+	if !ok {
+		return false, errors.Wrapf(ErrExprType, "expected a bool, got %s", exprTypeName(val))
+	}
+	return b, nil
+}
+
+// exprInt converts val to an integer with a magnitude of at most limit.
+func exprInt(val interface{}, limit int64) (int64, error) {
+	d, err := exprDecimal(val)
+	if err != nil {
+		return 0, err
+	}
+	if !d.IsInteger() {
+		return 0, errors.Wrapf(ErrExprType, "expected an integer, got %s", d)
+	}
+	if d.Abs().GreaterThan(decimal.NewFromInt(limit)) {
+		return 0, errors.Wrapf(ErrExprOverflow, "integer %s out of range [-%d, %d]", d, limit, limit)
+	}
+	return d.IntPart(), nil
+}
+
+func exprEqual(left, right interface{}) (bool, error) {
+	switch l := left.(type) {
+	case bool:
+		r, err := exprBool(right)
+		if err != nil {
+			return false, err
+		}
+		return l == r, nil
+	case string:
+		if r, ok := right.(string); ok {
+			return l == r, nil
+		}
+	}
+	cmp, err := exprCompare(left, right)
+	return cmp == 0, err
+}
+
+// exprCompare compares two strings lexicographically and anything else as decimals.
+func exprCompare(left, right interface{}) (int, error) {
+	ls, lok := left.(string)
+	rs, rok := right.(string)
+	if lok && rok {
+		return strings.Compare(ls, rs), nil
+	}
+	l, err := exprDecimal(left)
+	if err != nil {
+		return 0, err
+	}
+	r, err := exprDecimal(right)
+	if err != nil {
+		return 0, err
+	}
+	if err = checkExprScales(l, r); err != nil {
+		return 0, err
+	}
+	return l.Cmp(r), nil
+}
+
+// checkExprDecimal bounds the size of the decimals an expression operates on.
+func checkExprDecimal(d decimal.Decimal) error {
+	if d.NumDigits() > maxExprDigits || d.Exponent() > maxExprDigits || d.Exponent() < -maxExprDigits {
+		return errors.Wrapf(ErrExprOverflow, "decimal exceeds %d digits", maxExprDigits)
+	}
+	return nil
+}
+
+// checkExprScales bounds the rescaling needed to add or compare two decimals.
+func checkExprScales(l, r decimal.Decimal) error {
+	if diff := int64(l.Exponent()) - int64(r.Exponent()); diff > maxExprDigits || diff < -maxExprDigits {
+		return errors.Wrapf(ErrExprOverflow, "decimals %s and %s differ in scale by more than %d digits", l, r, maxExprDigits)
+	}
+	return nil
+}
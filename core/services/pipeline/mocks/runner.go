@@ -534,6 +534,66 @@ func (_c *Runner_Ready_Call) RunAndReturn(run func() error) *Runner_Ready_Call {
 	return _c
 }
 
+// ReplayRun provides a mock function with given fields: ctx, run, opts
+func (_m *Runner) ReplayRun(ctx context.Context, run pipeline.Run, opts pipeline.ReplayOptions) (*pipeline.RunReplay, error) {
+	ret := _m.Called(ctx, run, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplayRun")
+	}
+
+	var r0 *pipeline.RunReplay
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pipeline.Run, pipeline.ReplayOptions) (*pipeline.RunReplay, error)); ok {
+		return rf(ctx, run, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pipeline.Run, pipeline.ReplayOptions) *pipeline.RunReplay); ok {
+		r0 = rf(ctx, run, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pipeline.RunReplay)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pipeline.Run, pipeline.ReplayOptions) error); ok {
+		r1 = rf(ctx, run, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Runner_ReplayRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplayRun'
+type Runner_ReplayRun_Call struct {
+	*mock.Call
+}
+
+// ReplayRun is a helper method to define mock.On call
+//   - ctx context.Context
+//   - run pipeline.Run
+//   - opts pipeline.ReplayOptions
+func (_e *Runner_Expecter) ReplayRun(ctx interface{}, run interface{}, opts interface{}) *Runner_ReplayRun_Call {
+	return &Runner_ReplayRun_Call{Call: _e.mock.On("ReplayRun", ctx, run, opts)}
+}
+
+func (_c *Runner_ReplayRun_Call) Run(run func(ctx context.Context, run pipeline.Run, opts pipeline.ReplayOptions)) *Runner_ReplayRun_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pipeline.Run), args[2].(pipeline.ReplayOptions))
+	})
+	return _c
+}
+
+func (_c *Runner_ReplayRun_Call) Return(_a0 *pipeline.RunReplay, _a1 error) *Runner_ReplayRun_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Runner_ReplayRun_Call) RunAndReturn(run func(context.Context, pipeline.Run, pipeline.ReplayOptions) (*pipeline.RunReplay, error)) *Runner_ReplayRun_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ResumeRun provides a mock function with given fields: ctx, taskID, value, err
 func (_m *Runner) ResumeRun(ctx context.Context, taskID uuid.UUID, value interface{}, err error) error {
 	ret := _m.Called(ctx, taskID, value, err)
@@ -0,0 +1,165 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	pkgerrors "github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/jsonserializable"
+)
+
+// DefaultReplayTaskTypes are the task types whose recorded results are
+// substituted when a replay does not select any tasks explicitly. These are
+// the tasks which reach out to external data sources or the chain.
+var DefaultReplayTaskTypes = []TaskType{
+	TaskTypeBridge,
+	TaskTypeHTTP,
+	TaskTypeETHCall,
+	TaskTypeEstimateGasLimit,
+}
+
+// replayAlwaysSubstituted are task types that have side effects and are never
+// re-executed by a replay, regardless of the selected tasks.
+var replayAlwaysSubstituted = []TaskType{
+	TaskTypeETHTx,
+}
+
+// ReplayOptions selects the tasks of a run whose recorded results are
+// substituted during a replay. All other tasks are recomputed.
+type ReplayOptions struct {
+	// DotIDs selects tasks by their DOT ID.
+	DotIDs []string `json:"dotIds"`
+	// TaskTypes selects every task of the given types.
+	TaskTypes []TaskType `json:"taskTypes"`
+}
+
+func (o ReplayOptions) substitutes(task Task) bool {
+	if slices.Contains(replayAlwaysSubstituted, task.Type()) {
+		return true
+	}
+	if len(o.DotIDs) == 0 && len(o.TaskTypes) == 0 {
+		return slices.Contains(DefaultReplayTaskTypes, task.Type())
+	}
+	return slices.Contains(o.DotIDs, task.DotID()) || slices.Contains(o.TaskTypes, task.Type())
+}
+
+// TaskRunDiff compares the result of a single task in a replayed run with the
+// result recorded for it by the original run.
+type TaskRunDiff struct {
+	DotID string   `json:"dotId"`
+	Type  TaskType `json:"type"`
+	// Replayed is true if the recorded result was substituted rather than recomputed.
+	Replayed bool `json:"replayed"`
+	// Recorded is true if the original run persisted a result for this task.
+	Recorded bool `json:"recorded"`
+	// Changed is true if the replayed result differs from the recorded one.
+	Changed bool `json:"changed"`
+
+	OriginalOutput jsonserializable.JSONSerializable `json:"originalOutput"`
+	OriginalError  null.String                       `json:"originalError"`
+	Output         jsonserializable.JSONSerializable `json:"output"`
+	Error          null.String                       `json:"error"`
+}
+
+// RunReplay is the outcome of replaying a finished run.
+type RunReplay struct {
+	// Original is the run which was replayed.
+	Original Run
+	// Replay is the in-memory run produced by the replay. It is never persisted.
+	Replay *Run
+	// TaskRunDiffs holds one entry per task run of the replay, ordered by output index.
+	TaskRunDiffs []TaskRunDiff
+}
+
+// ReplayRun re-executes a finished run in-memory against its original spec and
+// inputs. Tasks selected by opts are not executed; their recorded results are
+// fed to the scheduler instead, so every downstream task is recomputed from the
+// same upstream data the original run observed.
+func (r *runner) ReplayRun(ctx context.Context, run Run, opts ReplayOptions) (*RunReplay, error) {
+	if !run.FinishedAt.Valid {
+		return nil, pkgerrors.Errorf("cannot replay run %d: run is not finished", run.ID)
+	}
+	inputs, ok := run.Inputs.Val.(map[string]interface{})
+	if run.Inputs.Valid && run.Inputs.Val != nil && !ok {
+		return nil, pkgerrors.Errorf("cannot replay run %d: unexpected inputs type %T", run.ID, run.Inputs.Val)
+	}
+
+	spec := run.PipelineSpec
+	spec.Pipeline = nil
+	pipeline, err := r.InitializePipeline(spec)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "cannot replay run %d", run.ID)
+	}
+
+	replay := NewRun(spec, NewVarsFrom(inputs))
+	replay.Meta = run.Meta
+	replayed := make(map[string]bool)
+	for _, task := range pipeline.Tasks {
+		if !opts.substitutes(task) {
+			continue
+		}
+		taskRun := run.ByDotID(task.DotID())
+		if taskRun == nil || taskRun.IsPending() {
+			return nil, pkgerrors.Errorf("cannot replay run %d: no recorded result for task %s(%s)", run.ID, task.DotID(), task.Type())
+		}
+		replay.PipelineTaskRuns = append(replay.PipelineTaskRuns, *taskRun)
+		replayed[task.DotID()] = true
+	}
+
+	// Vars are built separately from the run inputs, since the scheduler mutates them.
+	r.run(ctx, pipeline, replay, NewVarsFrom(inputs).Copy())
+	if replay.Pending {
+		return nil, fmt.Errorf("unexpected async run when replaying run %d", run.ID)
+	}
+
+	diffs := make([]TaskRunDiff, 0, len(replay.PipelineTaskRuns))
+	for _, taskRun := range replay.PipelineTaskRuns {
+		diff := TaskRunDiff{
+			DotID:    taskRun.DotID,
+			Type:     taskRun.Type,
+			Replayed: replayed[taskRun.DotID],
+			Output:   taskRun.Output,
+			Error:    taskRun.Error,
+		}
+		if original := run.ByDotID(taskRun.DotID); original != nil && !original.IsPending() {
+			diff.Recorded = true
+			diff.OriginalOutput = original.Output
+			diff.OriginalError = original.Error
+			diff.Changed = original.Error != taskRun.Error || !equalOutputs(original.Output, taskRun.Output)
+		}
+		diffs = append(diffs, diff)
+	}
+
+	return &RunReplay{Original: run, Replay: replay, TaskRunDiffs: diffs}, nil
+}
+
+// equalOutputs compares two task outputs by their canonical JSON encoding, so
+// that a freshly computed value matches the same value read back from the database.
+func equalOutputs(a, b jsonserializable.JSONSerializable) bool {
+	ab, err := canonicalJSON(a)
+	if err != nil {
+		return false
+	}
+	bb, err := canonicalJSON(b)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(ab, bb)
+}
+
+func canonicalJSON(js jsonserializable.JSONSerializable) ([]byte, error) {
+	b, err := js.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var v interface{}
+	if err = json.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
@@ -0,0 +1,113 @@
+package pipeline_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/jsonserializable"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/configtest"
+	clhttptest "github.com/smartcontractkit/chainlink/v2/core/internal/testutils/httptest"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+)
+
+func Test_PipelineRunner_ReplayRun(t *testing.T) {
+	cfg := configtest.NewTestGeneralConfig(t)
+	c := clhttptest.NewTestLocalOnlyHTTPClient()
+	r := pipeline.NewRunner(nil, nil, cfg.JobPipeline(), cfg.WebServer(), nil, nil, nil, logger.TestLogger(t), c, c)
+
+	s := httptest.NewServer(fakeStringResponder(t, `{"USD": 10}`))
+
+	spec := pipeline.Spec{DotDagSource: `
+ds1          [type=http method=GET url="$(url)"];
+ds1_parse    [type=jsonparse path="USD"];
+ds1_multiply [type=multiply input="$(ds1_parse)" times=2];
+ds1 -> ds1_parse -> ds1_multiply;
+`}
+	original, _, err := r.ExecuteRun(testutils.Context(t), spec, pipeline.NewVarsFrom(map[string]interface{}{"url": s.URL}))
+	require.NoError(t, err)
+	require.Len(t, original.PipelineTaskRuns, 3)
+	require.False(t, original.HasErrors())
+
+	// the data source is gone, so the http task can only be replayed from the recorded result
+	s.Close()
+
+	t.Run("substitutes data sources by default", func(t *testing.T) {
+		rr, err := r.ReplayRun(testutils.Context(t), *original, pipeline.ReplayOptions{})
+		require.NoError(t, err)
+		require.Len(t, rr.TaskRunDiffs, 3)
+		assert.False(t, rr.Replay.HasErrors())
+
+		for _, d := range rr.TaskRunDiffs {
+			assert.Equal(t, d.DotID == "ds1", d.Replayed, d.DotID)
+			assert.True(t, d.Recorded, d.DotID)
+			assert.False(t, d.Changed, d.DotID)
+		}
+	})
+
+	t.Run("recomputes downstream tasks from recorded outputs", func(t *testing.T) {
+		changed := *original
+		changed.PipelineTaskRuns = append([]pipeline.TaskRun(nil), original.PipelineTaskRuns...)
+		changed.ByDotID("ds1").Output = jsonserializable.JSONSerializable{Val: `{"USD": 21}`, Valid: true}
+
+		rr, err := r.ReplayRun(testutils.Context(t), changed, pipeline.ReplayOptions{DotIDs: []string{"ds1"}})
+		require.NoError(t, err)
+		require.Len(t, rr.TaskRunDiffs, 3)
+
+		for _, d := range rr.TaskRunDiffs {
+			switch d.DotID {
+			case "ds1":
+				assert.True(t, d.Replayed)
+				assert.False(t, d.Changed)
+			case "ds1_parse", "ds1_multiply":
+				assert.False(t, d.Replayed)
+				assert.True(t, d.Changed, d.DotID)
+			}
+		}
+		outputs, err := rr.Replay.StringOutputs()
+		require.NoError(t, err)
+		require.Len(t, outputs, 1)
+		assert.Equal(t, "42", *outputs[0])
+	})
+
+	t.Run("recomputes tasks which are not selected", func(t *testing.T) {
+		rr, err := r.ReplayRun(testutils.Context(t), *original, pipeline.ReplayOptions{TaskTypes: []pipeline.TaskType{pipeline.TaskTypeJSONParse}})
+		require.NoError(t, err)
+
+		var d pipeline.TaskRunDiff
+		for _, diff := range rr.TaskRunDiffs {
+			if diff.DotID == "ds1" {
+				d = diff
+			}
+		}
+		assert.Equal(t, pipeline.TaskTypeHTTP, d.Type)
+		assert.False(t, d.Replayed)
+		assert.True(t, d.Changed)
+		assert.True(t, d.Error.Valid)
+	})
+
+	t.Run("errors without a recorded result", func(t *testing.T) {
+		missing := *original
+		missing.PipelineTaskRuns = nil
+		for _, tr := range original.PipelineTaskRuns {
+			if tr.DotID != "ds1" {
+				missing.PipelineTaskRuns = append(missing.PipelineTaskRuns, tr)
+			}
+		}
+
+		_, err := r.ReplayRun(testutils.Context(t), missing, pipeline.ReplayOptions{})
+		require.ErrorContains(t, err, "no recorded result for task ds1(http)")
+	})
+
+	t.Run("errors for unfinished runs", func(t *testing.T) {
+		unfinished := *original
+		unfinished.FinishedAt.Valid = false
+
+		_, err := r.ReplayRun(testutils.Context(t), unfinished, pipeline.ReplayOptions{})
+		require.ErrorContains(t, err, "run is not finished")
+	})
+}
@@ -0,0 +1,379 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+// resultCacheMaxEntries bounds the number of results cached in memory.
+const resultCacheMaxEntries = 10_000
+
+var promResultCacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "pipeline_task_result_cache_lookups_total",
+	Help: "The total number of pipeline task result cache lookups, by task type and status (hit, stale or miss)",
+},
+	[]string{"task_type", "status"},
+)
+
+// resultCacheableTask is implemented by tasks whose results can be shared across runs, when opted in with the
+// resultCacheTTL attribute. Within the TTL, runs with the same key get the cached result instead of running the task.
+// Within the following resultCacheStaleTTL, they get the cached result while the task is run again in the background.
+// Concurrent runs with the same key share a single task run.
+type resultCacheableTask interface {
+	Task
+	// resultCacheKey resolves the params of the task and returns a key identifying the request it would make.
+	resultCacheKey(vars Vars, inputs []Result) (string, error)
+}
+
+var (
+	_ resultCacheableTask = (*HTTPTask)(nil)
+	_ resultCacheableTask = (*ETHCallTask)(nil)
+	_ resultCacheableTask = (*BridgeTask)(nil)
+)
+
+// validateResultCache checks the result cache attributes of the task.
+func validateResultCache(task Task) error {
+	base := task.Base()
+	if base.ResultCacheTTL < 0 || base.ResultCacheStaleTTL < 0 {
+		return pkgerrors.New("resultCacheTTL and resultCacheStaleTTL must not be negative")
+	}
+	if base.ResultCacheTTL == 0 {
+		if base.ResultCacheStaleTTL > 0 || base.ResultCachePersist {
+			return pkgerrors.New("resultCacheStaleTTL and resultCachePersist require resultCacheTTL to be set")
+		}
+		return nil
+	}
+	if _, ok := task.(resultCacheableTask); !ok {
+		return pkgerrors.Errorf("resultCacheTTL is not supported by %s tasks", task.Type())
+	}
+	if bt, ok := task.(*BridgeTask); ok && bt.Async == "true" {
+		return pkgerrors.New("resultCacheTTL is not supported by async bridge tasks")
+	}
+	return nil
+}
+
+// newResultCacheKey hashes the resolved params of a task into a result cache key.
+func newResultCacheKey(taskType TaskType, params ...interface{}) (string, error) {
+	b, err := json.Marshal(params)
+	if err != nil {
+		return "", pkgerrors.Wrap(err, "failed to encode result cache key")
+	}
+	sum := sha256.Sum256(b)
+	return string(taskType) + ":" + hex.EncodeToString(sum[:]), nil
+}
+
+type resultCacheEntry struct {
+	value     interface{}
+	createdAt time.Time
+	expiresAt time.Time
+}
+
+// copyResultValue copies byte slices so that runs sharing a result cannot modify it.
+func copyResultValue(v interface{}) interface{} {
+	if b, ok := v.([]byte); ok {
+		return bytes.Clone(b)
+	}
+	return v
+}
+
+// memoryResultCache is the in-memory layer of the result cache.
+type memoryResultCache struct {
+	mu         sync.Mutex
+	entries    map[string]resultCacheEntry
+	maxEntries int
+}
+
+func newMemoryResultCache(maxEntries int) *memoryResultCache {
+	return &memoryResultCache{entries: make(map[string]resultCacheEntry), maxEntries: maxEntries}
+}
+
+func (m *memoryResultCache) get(key string, now time.Time) (resultCacheEntry, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, ok := m.entries[key]
+	if !ok || !now.Before(entry.expiresAt) {
+		return resultCacheEntry{}, false
+	}
+	return entry, true
+}
+
+func (m *memoryResultCache) set(key string, entry resultCacheEntry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.entries[key]; !ok && len(m.entries) >= m.maxEntries {
+		m.evictExpiredLocked(entry.createdAt)
+		if len(m.entries) >= m.maxEntries {
+			// evict the entry closest to expiry
+			var oldestKey string
+			var oldest time.Time
+			for k, e := range m.entries {
+				if oldestKey == "" || e.expiresAt.Before(oldest) {
+					oldestKey, oldest = k, e.expiresAt
+				}
+			}
+			delete(m.entries, oldestKey)
+		}
+	}
+	m.entries[key] = entry
+}
+
+func (m *memoryResultCache) evictExpired(now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.evictExpiredLocked(now)
+}
+
+func (m *memoryResultCache) evictExpiredLocked(now time.Time) {
+	for k, e := range m.entries {
+		if !now.Before(e.expiresAt) {
+			delete(m.entries, k)
+		}
+	}
+}
+
+// Value types of persisted results. Only the result types of the cacheable tasks are supported.
+const (
+	resultCacheValueString = "string"
+	resultCacheValueBytes  = "bytes"
+)
+
+// resultCacheORM persists the cached results in the pipeline_task_result_cache table.
+type resultCacheORM struct {
+	ds sqlutil.DataSource
+}
+
+func (o resultCacheORM) get(ctx context.Context, key string, now time.Time) (entry resultCacheEntry, found bool, err error) {
+	var row struct {
+		Value     []byte    `db:"value"`
+		ValueType string    `db:"value_type"`
+		CreatedAt time.Time `db:"created_at"`
+		ExpiresAt time.Time `db:"expires_at"`
+	}
+	err = o.ds.GetContext(ctx, &row, `SELECT value, value_type, created_at, expires_at FROM pipeline_task_result_cache WHERE key = $1 AND expires_at > $2`, key, now)
+	if pkgerrors.Is(err, sql.ErrNoRows) {
+		return entry, false, nil
+	} else if err != nil {
+		return entry, false, pkgerrors.Wrap(err, "failed to get cached task result")
+	}
+	entry = resultCacheEntry{createdAt: row.CreatedAt, expiresAt: row.ExpiresAt}
+	switch row.ValueType {
+	case resultCacheValueString:
+		entry.value = string(row.Value)
+	case resultCacheValueBytes:
+		entry.value = row.Value
+	default:
+		return entry, false, pkgerrors.Errorf("unknown cached task result type %q", row.ValueType)
+	}
+	return entry, true, nil
+}
+
+func (o resultCacheORM) upsert(ctx context.Context, key string, entry resultCacheEntry) error {
+	var value []byte
+	var valueType string
+	switch v := entry.value.(type) {
+	case string:
+		value, valueType = []byte(v), resultCacheValueString
+	case []byte:
+		value, valueType = v, resultCacheValueBytes
+	default:
+		return pkgerrors.Errorf("cannot persist task result of type %T", entry.value)
+	}
+	_, err := o.ds.ExecContext(ctx, `INSERT INTO pipeline_task_result_cache (key, value, value_type, created_at, expires_at) VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value, value_type = EXCLUDED.value_type, created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at`,
+		key, value, valueType, entry.createdAt, entry.expiresAt)
+	return pkgerrors.Wrap(err, "failed to upsert cached task result")
+}
+
+func (o resultCacheORM) deleteExpired(ctx context.Context, now time.Time) error {
+	_, err := o.ds.ExecContext(ctx, `DELETE FROM pipeline_task_result_cache WHERE expires_at <= $1`, now)
+	return pkgerrors.Wrap(err, "failed to delete expired cached task results")
+}
+
+type resultCacheRun struct {
+	result  Result
+	runInfo RunInfo
+}
+
+// resultCache shares the results of resultCacheableTask runs, in memory and optionally in the database.
+type resultCache struct {
+	memory *memoryResultCache
+	// ds returns the data source of persisted results, and is only called for tasks with resultCachePersist.
+	// Results are kept in memory only if it is nil.
+	ds        func() sqlutil.DataSource
+	persisted atomic.Bool
+	group     singleflight.Group
+	now       func() time.Time
+
+	mu         sync.Mutex
+	closed     bool
+	refreshing map[string]struct{}
+	stopCh     services.StopChan
+	wg         sync.WaitGroup
+}
+
+func newResultCache(ds func() sqlutil.DataSource) *resultCache {
+	return &resultCache{
+		memory:     newMemoryResultCache(resultCacheMaxEntries),
+		ds:         ds,
+		now:        time.Now,
+		refreshing: make(map[string]struct{}),
+		stopCh:     make(chan struct{}),
+	}
+}
+
+// run returns the cached result of the task for key if there is one, and otherwise calls fn and caches its result.
+// fn is shared by concurrent runs with the same key, so it is called with a context detached from the one of the run,
+// and must apply its own timeout. Each run stops waiting for it when its own context is done.
+// Stale results are returned while fn is called in the background to revalidate them.
+func (c *resultCache) run(ctx context.Context, lggr logger.Logger, task Task, key string, fn func(context.Context) (Result, RunInfo)) (Result, RunInfo) {
+	base := task.Base()
+	entry, found := c.lookup(ctx, lggr, key, base.ResultCachePersist)
+	if found {
+		age := c.now().Sub(entry.createdAt)
+		if age < base.ResultCacheTTL {
+			promResultCacheLookups.WithLabelValues(string(task.Type()), "hit").Inc()
+			return Result{Value: copyResultValue(entry.value)}, RunInfo{}
+		}
+		if age < base.ResultCacheTTL+base.ResultCacheStaleTTL {
+			promResultCacheLookups.WithLabelValues(string(task.Type()), "stale").Inc()
+			c.revalidate(ctx, lggr, task, key, fn)
+			return Result{Value: copyResultValue(entry.value)}, RunInfo{}
+		}
+	}
+	promResultCacheLookups.WithLabelValues(string(task.Type()), "miss").Inc()
+
+	ch := c.group.DoChan(key, func() (interface{}, error) {
+		return c.runShared(ctx, lggr, task, key, fn), nil
+	})
+	select {
+	case <-ctx.Done():
+		return Result{Error: pkgerrors.Wrap(ctx.Err(), "stopped waiting for shared task run")}, RunInfo{IsRetryable: true}
+	case v := <-ch:
+		res := v.Val.(resultCacheRun)
+		return Result{Value: copyResultValue(res.result.Value), Error: res.result.Error}, res.runInfo
+	}
+}
+
+// runShared calls fn on behalf of all the runs with the same key. Its context keeps the values of ctx, but is only
+// cancelled when the cache is closed.
+func (c *resultCache) runShared(ctx context.Context, lggr logger.Logger, task Task, key string, fn func(context.Context) (Result, RunInfo)) resultCacheRun {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return resultCacheRun{result: Result{Error: pkgerrors.New("result cache is closed")}}
+	}
+	c.wg.Add(1)
+	c.mu.Unlock()
+	defer c.wg.Done()
+
+	ctx, cancel := c.stopCh.Ctx(context.WithoutCancel(ctx))
+	defer cancel()
+	return c.runAndStore(ctx, lggr, task, key, fn)
+}
+
+func (c *resultCache) lookup(ctx context.Context, lggr logger.Logger, key string, persist bool) (resultCacheEntry, bool) {
+	now := c.now()
+	if entry, ok := c.memory.get(key, now); ok {
+		return entry, true
+	}
+	if !persist || c.ds == nil {
+		return resultCacheEntry{}, false
+	}
+	entry, found, err := resultCacheORM{c.ds()}.get(ctx, key, now)
+	if err != nil {
+		lggr.Warnw("Result cache: failed to get persisted result", "err", err)
+		return resultCacheEntry{}, false
+	}
+	if found {
+		c.memory.set(key, entry)
+	}
+	return entry, found
+}
+
+func (c *resultCache) runAndStore(ctx context.Context, lggr logger.Logger, task Task, key string, fn func(context.Context) (Result, RunInfo)) resultCacheRun {
+	result, runInfo := fn(ctx)
+	if result.Error != nil || runInfo.IsPending {
+		return resultCacheRun{result, runInfo}
+	}
+	base := task.Base()
+	now := c.now()
+	entry := resultCacheEntry{
+		value:     copyResultValue(result.Value),
+		createdAt: now,
+		expiresAt: now.Add(base.ResultCacheTTL + base.ResultCacheStaleTTL),
+	}
+	c.memory.set(key, entry)
+	if base.ResultCachePersist && c.ds != nil {
+		c.persisted.Store(true)
+		ctx, cancel := overtimeContext(ctx)
+		defer cancel()
+		if err := (resultCacheORM{c.ds()}).upsert(ctx, key, entry); err != nil {
+			lggr.Warnw("Result cache: failed to persist result", "err", err)
+		}
+	}
+	return resultCacheRun{result, runInfo}
+}
+
+// revalidate runs the task in the background to refresh a stale result, unless a refresh of the same key is already
+// in progress. The refresh joins a shared run of the same key in progress.
+func (c *resultCache) revalidate(ctx context.Context, lggr logger.Logger, task Task, key string, fn func(context.Context) (Result, RunInfo)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return
+	}
+	if _, ok := c.refreshing[key]; ok {
+		return
+	}
+	c.refreshing[key] = struct{}{}
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		defer func() {
+			c.mu.Lock()
+			delete(c.refreshing, key)
+			c.mu.Unlock()
+		}()
+		v, _, _ := c.group.Do(key, func() (interface{}, error) {
+			return c.runShared(ctx, lggr, task, key, fn), nil
+		})
+		if err := v.(resultCacheRun).result.Error; err != nil {
+			lggr.Debugw("Result cache: failed to refresh stale result", "err", err)
+		}
+	}()
+}
+
+// deleteExpired evicts the expired results, including the persisted ones if any were stored.
+func (c *resultCache) deleteExpired(ctx context.Context) error {
+	now := c.now()
+	c.memory.evictExpired(now)
+	if !c.persisted.Load() {
+		return nil
+	}
+	return resultCacheORM{c.ds()}.deleteExpired(ctx, now)
+}
+
+func (c *resultCache) close() {
+	c.mu.Lock()
+	c.closed = true
+	c.mu.Unlock()
+	close(c.stopCh)
+	c.wg.Wait()
+}
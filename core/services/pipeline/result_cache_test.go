@@ -0,0 +1,216 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestResultCache_Run(t *testing.T) {
+	t.Parallel()
+
+	lggr := logger.TestLogger(t)
+	task := &HTTPTask{BaseTask: BaseTask{dotID: "ds", ResultCacheTTL: time.Minute, ResultCacheStaleTTL: time.Minute}}
+	c := newResultCache(nil)
+	t.Cleanup(c.close)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	var calls atomic.Int32
+	fn := func(context.Context) (Result, RunInfo) {
+		return Result{Value: []byte{byte(calls.Add(1))}}, RunInfo{}
+	}
+	run := func() Result {
+		result, _ := c.run(testutils.Context(t), lggr, task, "key", fn)
+		require.NoError(t, result.Error)
+		return result
+	}
+
+	assert.Equal(t, []byte{1}, run().Value)
+	now = now.Add(59 * time.Second)
+	cached := run()
+	assert.Equal(t, []byte{1}, cached.Value)
+	assert.Equal(t, int32(1), calls.Load())
+
+	// results are copied
+	cached.Value.([]byte)[0] = 42
+	assert.Equal(t, []byte{1}, run().Value)
+
+	// stale results are returned while refreshed in the background
+	now = now.Add(time.Minute)
+	assert.Equal(t, []byte{1}, run().Value)
+	require.Eventually(t, func() bool {
+		entry, ok := c.memory.get("key", now)
+		return ok && entry.value.([]byte)[0] == 2
+	}, testutils.WaitTimeout(t), 10*time.Millisecond)
+	assert.Equal(t, []byte{2}, run().Value)
+
+	// expired results are not returned
+	now = now.Add(2 * time.Minute)
+	assert.Equal(t, []byte{3}, run().Value)
+	assert.Equal(t, int32(3), calls.Load())
+
+	t.Run("does not cache errors", func(t *testing.T) {
+		var errCalls int
+		fail := func(context.Context) (Result, RunInfo) {
+			errCalls++
+			return Result{Error: errors.New("boom")}, RunInfo{IsRetryable: true}
+		}
+		for range 2 {
+			result, runInfo := c.run(testutils.Context(t), lggr, task, "failing", fail)
+			require.EqualError(t, result.Error, "boom")
+			assert.True(t, runInfo.IsRetryable)
+		}
+		assert.Equal(t, 2, errCalls)
+	})
+
+	t.Run("shares concurrent runs", func(t *testing.T) {
+		var shared atomic.Int32
+		release := make(chan struct{})
+		slow := func(context.Context) (Result, RunInfo) {
+			shared.Add(1)
+			<-release
+			return Result{Value: "v"}, RunInfo{}
+		}
+		var wg sync.WaitGroup
+		for range 5 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				result, _ := c.run(testutils.Context(t), lggr, task, "concurrent", slow)
+				assert.Equal(t, "v", result.Value)
+			}()
+		}
+		time.Sleep(100 * time.Millisecond)
+		close(release)
+		wg.Wait()
+		assert.Equal(t, int32(1), shared.Load())
+	})
+
+	t.Run("cancelled runs do not fail shared runs", func(t *testing.T) {
+		release := make(chan struct{})
+		slow := func(ctx context.Context) (Result, RunInfo) {
+			select {
+			case <-release:
+				return Result{Value: "v"}, RunInfo{}
+			case <-ctx.Done():
+				return Result{Error: ctx.Err()}, RunInfo{}
+			}
+		}
+		ctx, cancel := context.WithCancel(testutils.Context(t))
+		cancelled := make(chan Result)
+		go func() {
+			result, _ := c.run(ctx, lggr, task, "cancelled", slow)
+			cancelled <- result
+		}()
+		waited := make(chan Result)
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			result, _ := c.run(testutils.Context(t), lggr, task, "cancelled", slow)
+			waited <- result
+		}()
+		time.Sleep(100 * time.Millisecond)
+		cancel()
+		require.ErrorIs(t, (<-cancelled).Error, context.Canceled)
+		close(release)
+		result := <-waited
+		require.NoError(t, result.Error)
+		assert.Equal(t, "v", result.Value)
+	})
+
+	t.Run("dedupes refreshes", func(t *testing.T) {
+		var refreshes atomic.Int32
+		release := make(chan struct{})
+		refresh := func(context.Context) (Result, RunInfo) {
+			if refreshes.Add(1) > 1 {
+				<-release
+			}
+			return Result{Value: "v"}, RunInfo{}
+		}
+		result, _ := c.run(testutils.Context(t), lggr, task, "refreshed", refresh)
+		require.NoError(t, result.Error)
+		now = now.Add(90 * time.Second)
+		for range 5 {
+			result, _ = c.run(testutils.Context(t), lggr, task, "refreshed", refresh)
+			assert.Equal(t, "v", result.Value)
+		}
+		c.mu.Lock()
+		assert.Len(t, c.refreshing, 1)
+		c.mu.Unlock()
+		close(release)
+		require.Eventually(t, func() bool {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			return len(c.refreshing) == 0
+		}, testutils.WaitTimeout(t), 10*time.Millisecond)
+		assert.Equal(t, int32(2), refreshes.Load())
+	})
+}
+
+func TestMemoryResultCache_Evict(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	m := newMemoryResultCache(2)
+	m.set("a", resultCacheEntry{value: "a", createdAt: now, expiresAt: now.Add(time.Minute)})
+	m.set("b", resultCacheEntry{value: "b", createdAt: now, expiresAt: now.Add(time.Hour)})
+	m.set("c", resultCacheEntry{value: "c", createdAt: now, expiresAt: now.Add(time.Hour)})
+
+	_, ok := m.get("a", now)
+	assert.False(t, ok, "entry closest to expiry is evicted")
+	_, ok = m.get("c", now)
+	assert.True(t, ok)
+
+	m.evictExpired(now.Add(time.Hour))
+	assert.Empty(t, m.entries)
+}
+
+func TestValidateResultCache(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		taskType TaskType
+		attrs    map[string]interface{}
+		err      string
+	}{
+		{TaskTypeHTTP, map[string]interface{}{"resultCacheTTL": "10s", "resultCacheStaleTTL": "1m", "resultCachePersist": "true"}, ""},
+		{TaskTypeETHCall, map[string]interface{}{"resultCacheTTL": "10s"}, ""},
+		{TaskTypeBridge, map[string]interface{}{"resultCacheTTL": "10s"}, ""},
+		{TaskTypeBridge, map[string]interface{}{"resultCacheTTL": "10s", "async": "true"}, "not supported by async bridge tasks"},
+		{TaskTypeMemo, map[string]interface{}{"resultCacheTTL": "10s"}, "not supported by memo tasks"},
+		{TaskTypeHTTP, map[string]interface{}{"resultCacheStaleTTL": "10s"}, "require resultCacheTTL"},
+		{TaskTypeHTTP, map[string]interface{}{"resultCacheTTL": "-10s"}, "must not be negative"},
+	} {
+		_, err := UnmarshalTaskFromMap(tc.taskType, tc.attrs, 0, "task")
+		if tc.err == "" {
+			assert.NoError(t, err, tc.attrs)
+		} else {
+			assert.ErrorContains(t, err, tc.err, tc.attrs)
+		}
+	}
+}
+
+func TestHTTPTask_ResultCacheKey(t *testing.T) {
+	t.Parallel()
+
+	task := &HTTPTask{URL: "$(url)", Headers: `["X-Api-Key", "secret"]`}
+	key := func(vars map[string]interface{}) string {
+		k, err := task.resultCacheKey(NewVarsFrom(vars), nil)
+		require.NoError(t, err)
+		return k
+	}
+
+	a := key(map[string]interface{}{"url": "https://example.com/a"})
+	assert.Equal(t, a, key(map[string]interface{}{"url": "https://example.com/a", "other": 1}))
+	assert.NotEqual(t, a, key(map[string]interface{}{"url": "https://example.com/b"}))
+	assert.Contains(t, a, "http:")
+}
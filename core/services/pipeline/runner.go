@@ -51,6 +51,10 @@ type Runner interface {
 	// This will persist the Spec in the DB if it doesn't have an ID.
 	ExecuteAndInsertFinishedRun(ctx context.Context, spec Spec, vars Vars, saveSuccessfulTaskRuns bool) (runID int64, results TaskRunResults, err error)
 
+	// ReplayRun re-executes a finished run in-memory, substituting the recorded results of the tasks selected by opts,
+	// and diffs every task against the original run. Nothing is persisted.
+	ReplayRun(ctx context.Context, run Run, opts ReplayOptions) (*RunReplay, error)
+
 	OnRunFinished(func(*Run))
 	InitializePipeline(spec Spec) (*Pipeline, error)
 }
@@ -68,6 +72,7 @@ type runner struct {
 	lggr                   logger.Logger
 	httpClient             *http.Client
 	unrestrictedHTTPClient *http.Client
+	resultCache            *resultCache
 
 	// test helper
 	runFinished func(*Run)
@@ -134,6 +139,11 @@ func NewRunner(
 		httpClient:             httpClient,
 		unrestrictedHTTPClient: unrestrictedHTTPClient,
 	}
+	if orm != nil {
+		r.resultCache = newResultCache(orm.DataSource)
+	} else {
+		r.resultCache = newResultCache(nil)
+	}
 
 	r.runReaperWorker = commonutils.NewSleeperTask(
 		commonutils.SleeperFuncTask(r.runReaper, "PipelineRunnerReaper"),
@@ -166,6 +176,7 @@ func (r *runner) Close() error {
 	return r.StopOnce("PipelineRunner", func() error {
 		close(r.chStop)
 		r.wgDone.Wait()
+		r.resultCache.close()
 
 		// the btORM can be a cache service or a static ORM if the constructor changes
 		if closer, isCloser := r.btORM.(io.Closer); isCloser {
@@ -328,8 +339,14 @@ func (r *runner) InitializePipeline(spec Spec) (pipeline *Pipeline, err error) {
 		return
 	}
 
-	// initialize certain task params
-	for _, task := range pipeline.Tasks {
+	r.initializeTasks(spec, pipeline.Tasks)
+
+	return pipeline, nil
+}
+
+// initializeTasks initializes certain task params, including the tasks nested in map task bodies.
+func (r *runner) initializeTasks(spec Spec, tasks []Task) {
+	for _, task := range tasks {
 		task.Base().uuid = uuid.New()
 
 		switch task.Type() {
@@ -368,11 +385,13 @@ func (r *runner) InitializePipeline(spec Spec) (pipeline *Pipeline, err error) {
 			task.(*ETHTxTask).specGasLimit = spec.GasLimit
 			task.(*ETHTxTask).jobType = spec.JobType
 			task.(*ETHTxTask).forwardingAllowed = spec.ForwardingAllowed
+		case TaskTypeMap:
+			task.(*MapTask).runner = r
+			task.(*MapTask).spec = spec
+			r.initializeTasks(spec, task.(*MapTask).body.Tasks)
 		default:
 		}
 	}
-
-	return pipeline, nil
 }
 
 func (r *runner) run(ctx context.Context, pipeline *Pipeline, run *Run, vars Vars) TaskRunResults {
@@ -382,38 +401,14 @@ func (r *runner) run(ctx context.Context, pipeline *Pipeline, run *Run, vars Var
 	}
 
 	scheduler := newScheduler(pipeline, run, vars, l)
-	go scheduler.Run()
-
-	// This is "just in case" for cleaning up any stray reports.
-	// Normally the scheduler loop doesn't stop until all in progress runs report back
-	reportCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
-	defer cancel()
 
 	if pipelineTimeout := r.config.MaxRunDuration(); pipelineTimeout != 0 {
+		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, pipelineTimeout)
 		defer cancel()
 	}
 
-	for taskRun := range scheduler.taskCh {
-		taskRun := taskRun
-		// execute
-		go recovery.WrapRecoverHandle(l, func() {
-			result := r.executeTaskRun(ctx, run.PipelineSpec, taskRun, l)
-
-			logTaskRunToPrometheus(result, run.PipelineSpec)
-
-			scheduler.report(reportCtx, result)
-		}, func(err interface{}) {
-			t := time.Now()
-			scheduler.report(reportCtx, TaskRunResult{
-				ID:         uuid.New(),
-				Task:       taskRun.task,
-				Result:     Result{Error: ErrRunPanicked{err}},
-				FinishedAt: null.TimeFrom(t),
-				CreatedAt:  t, // TODO: more accurate start time
-			})
-		})
-	}
+	r.executeScheduled(ctx, scheduler, run.PipelineSpec, l)
 
 	// if the run is suspended, awaiting resumption
 	run.Pending = scheduler.pending
@@ -535,6 +530,50 @@ func (r *runner) run(ctx context.Context, pipeline *Pipeline, run *Run, vars Var
 	return taskRunResults
 }
 
+// executeScheduled executes the task runs handed out by the scheduler until it has no more work.
+func (r *runner) executeScheduled(ctx context.Context, scheduler *scheduler, spec Spec, l logger.Logger) {
+	go scheduler.Run()
+
+	// This is "just in case" for cleaning up any stray reports.
+	// Normally the scheduler loop doesn't stop until all in progress runs report back
+	reportCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancel()
+
+	for taskRun := range scheduler.taskCh {
+		taskRun := taskRun
+		// execute
+		go recovery.WrapRecoverHandle(l, func() {
+			result := r.executeTaskRun(ctx, spec, taskRun, l)
+
+			logTaskRunToPrometheus(result, spec)
+
+			scheduler.report(reportCtx, result)
+		}, func(err interface{}) {
+			t := time.Now()
+			scheduler.report(reportCtx, TaskRunResult{
+				ID:         uuid.New(),
+				Task:       taskRun.task,
+				Result:     Result{Error: ErrRunPanicked{err}},
+				FinishedAt: null.TimeFrom(t),
+				CreatedAt:  t, // TODO: more accurate start time
+			})
+		})
+	}
+}
+
+// runSubPipeline executes a nested pipeline, such as the body of a map task, and returns the result of its terminal task.
+func (r *runner) runSubPipeline(ctx context.Context, spec Spec, p *Pipeline, vars Vars, l logger.Logger) Result {
+	scheduler := newScheduler(p, &Run{PipelineSpec: spec}, vars, l)
+	r.executeScheduled(ctx, scheduler, spec, l)
+
+	for _, result := range scheduler.results {
+		if len(result.Task.Outputs()) == 0 {
+			return result.Result
+		}
+	}
+	return Result{Error: pkgerrors.New("sub-pipeline did not produce a result")}
+}
+
 func (r *runner) executeTaskRun(ctx context.Context, spec Spec, taskRun *memoryTaskRun, l logger.Logger) TaskRunResult {
 	start := time.Now()
 	l = l.With("taskName", taskRun.task.DotID(),
@@ -562,7 +601,7 @@ func (r *runner) executeTaskRun(ctx context.Context, spec Spec, taskRun *memoryT
 		defer cancel()
 	}
 
-	result, runInfo := taskRun.task.Run(ctx, l, taskRun.vars, taskRun.inputs)
+	result, runInfo := r.runTask(ctx, l, taskRun)
 	loggerFields := []interface{}{"runInfo", runInfo,
 		"resultValue", result.Value,
 		"resultError", result.Error,
@@ -593,6 +632,37 @@ func (r *runner) executeTaskRun(ctx context.Context, spec Spec, taskRun *memoryT
 	}
 }
 
+// runTask runs the task, sharing its result across runs if it opted into the result cache.
+func (r *runner) runTask(ctx context.Context, l logger.Logger, taskRun *memoryTaskRun) (Result, RunInfo) {
+	task := taskRun.task
+	run := func(ctx context.Context) (Result, RunInfo) {
+		return task.Run(ctx, l, taskRun.vars, taskRun.inputs)
+	}
+	cacheable, ok := task.(resultCacheableTask)
+	if !ok || task.Base().ResultCacheTTL <= 0 {
+		return run(ctx)
+	}
+	key, err := cacheable.resultCacheKey(taskRun.vars, taskRun.inputs)
+	if err != nil {
+		// the task reports invalid params itself
+		return run(ctx)
+	}
+	// the run is shared with other runs, so it is not bound to the deadline of this one
+	shared := func(ctx context.Context) (Result, RunInfo) {
+		timeout, isSet := task.TaskTimeout()
+		if !isSet || timeout <= 0 {
+			timeout = r.config.DefaultHTTPTimeout().Duration()
+		}
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		return run(ctx)
+	}
+	return r.resultCache.run(ctx, l, task, key, shared)
+}
+
 func logTaskRunToPrometheus(trr TaskRunResult, spec Spec) {
 	elapsed := trr.FinishedAt.Time.Sub(trr.CreatedAt)
 
@@ -781,6 +851,11 @@ func (r *runner) runReaper() {
 	} else {
 		r.lggr.Debugw("Pipeline run reaper completed successfully")
 	}
+
+	if err = r.resultCache.deleteExpired(ctx); err != nil {
+		r.lggr.Errorw("Pipeline run reaper failed to delete expired task results", "err", err)
+		r.SvcErrBuffer.Append(err)
+	}
 }
 
 // init task: Searches the database for runs stuck in the 'running' state while the node was previously killed.
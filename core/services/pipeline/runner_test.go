@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -1077,3 +1078,40 @@ succeed;
 		assert.Equal(t, "1", trrs[0].Result.Value.(pipeline.ObjectParam).DecimalValue.Decimal().String())
 	})
 }
+
+func Test_PipelineRunner_ResultCache(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests.Add(1)
+		_, _ = io.WriteString(w, req.URL.Query().Get("q"))
+	}))
+	defer s.Close()
+
+	cfg := configtest.NewTestGeneralConfig(t)
+	c := clhttptest.NewTestLocalOnlyHTTPClient()
+	r := pipeline.NewRunner(nil, nil, cfg.JobPipeline(), cfg.WebServer(), nil, nil, nil, logger.TestLogger(t), c, c)
+
+	spec := pipeline.Spec{DotDagSource: `ds [type=http method=GET url="$(url)" resultCacheTTL="1h"]`}
+	execute := func(q string) interface{} {
+		_, trrs, err := r.ExecuteRun(testutils.Context(t), spec, pipeline.NewVarsFrom(map[string]interface{}{"url": s.URL + "?q=" + q}))
+		require.NoError(t, err)
+		require.Len(t, trrs, 1)
+		require.NoError(t, trrs[0].Result.Error)
+		return trrs[0].Result.Value
+	}
+
+	assert.Equal(t, "a", execute("a"))
+	assert.Equal(t, "a", execute("a"))
+	assert.Equal(t, int32(1), requests.Load())
+
+	// a request with other resolved inputs is not cached
+	assert.Equal(t, "b", execute("b"))
+	assert.Equal(t, int32(2), requests.Load())
+
+	t.Run("rejects tasks without result cache support", func(t *testing.T) {
+		_, _, err := r.ExecuteRun(testutils.Context(t), pipeline.Spec{DotDagSource: `memo [type=memo value=1 resultCacheTTL="1h"]`}, pipeline.NewVarsFrom(nil))
+		require.ErrorContains(t, err, "resultCacheTTL is not supported by memo tasks")
+	})
+}
@@ -261,6 +261,11 @@ func (s *scheduler) Run() {
 
 			// if all dependencies are done, schedule task run
 			if s.dependencies[id] == 0 {
+				// skip tasks whose results were reconstructed, e.g. when replaying a run
+				if _, exists := s.results[id]; exists {
+					continue
+				}
+
 				task := s.pipeline.Tasks[id]
 				run := s.newMemoryTaskRun(task, s.vars.Copy())
 
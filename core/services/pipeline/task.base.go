@@ -26,6 +26,13 @@ type BaseTask struct {
 
 	StreamID null.Uint32 `mapstructure:"streamID"`
 
+	// ResultCacheTTL opts the task into sharing its results across runs for the given duration, see resultCacheableTask.
+	ResultCacheTTL time.Duration `mapstructure:"resultCacheTTL"`
+	// ResultCacheStaleTTL is how long after ResultCacheTTL a cached result is still returned while it is refreshed in the background.
+	ResultCacheStaleTTL time.Duration `mapstructure:"resultCacheStaleTTL"`
+	// ResultCachePersist stores the cached results in the database as well, so they survive restarts.
+	ResultCachePersist bool `mapstructure:"resultCachePersist"`
+
 	uuid uuid.UUID
 }
 
@@ -87,32 +87,19 @@ func (t *BridgeTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, inp
 		return Result{Error: errors.Wrap(err, "task inputs")}, runInfo
 	}
 
-	var (
-		name              StringParam
-		requestData       MapParam
-		includeInputAtKey StringParam
-		cacheTTL          Uint64Param
-		reqHeaders        StringSliceParam
-	)
-	err = multierr.Combine(
-		errors.Wrap(ResolveParam(&name, From(NonemptyString(t.Name))), "name"),
-		errors.Wrap(ResolveParam(&requestData, From(VarExpr(t.RequestData, vars), JSONWithVarExprs(t.RequestData, vars, false), nil)), "requestData"),
-		errors.Wrap(ResolveParam(&includeInputAtKey, From(t.IncludeInputAtKey)), "includeInputAtKey"),
-		errors.Wrap(ResolveParam(&cacheTTL, From(ValidDurationInSeconds(t.CacheTTL), t.bridgeConfig.BridgeCacheTTL().Seconds())), "cacheTTL"),
-		errors.Wrap(ResolveParam(&reqHeaders, From(NonemptyString(t.Headers), "[]")), "reqHeaders"),
-	)
+	p, err := t.resolveParams(vars)
 	if err != nil {
 		return Result{Error: err}, runInfo
 	}
 
-	if len(reqHeaders)%2 != 0 {
+	if len(p.reqHeaders)%2 != 0 {
 		return Result{Error: errors.Errorf("headers must have an even number of elements")}, runInfo
 	}
 
 	overtimeCtx, cancel := overtimeContext(ctx)
 	defer cancel()
 
-	url, err := t.getBridgeURLFromName(overtimeCtx, name)
+	url, err := t.getBridgeURLFromName(overtimeCtx, p.name)
 	if err != nil {
 		return Result{Error: err}, runInfo
 	}
@@ -131,10 +118,10 @@ func (t *BridgeTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, inp
 		)
 	}
 
-	requestData = withRunInfo(requestData, metaMap)
+	p.requestData = withRunInfo(p.requestData, metaMap)
 	if t.IncludeInputAtKey != "" {
 		if len(inputValues) > 0 {
-			requestData[string(includeInputAtKey)] = inputValues[0]
+			p.requestData[string(p.includeInputAtKey)] = inputValues[0]
 		}
 	}
 
@@ -147,10 +134,10 @@ func (t *BridgeTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, inp
 		if responseURL != nil {
 			s = responseURL.String()
 		}
-		requestData["responseURL"] = s
+		p.requestData["responseURL"] = s
 	}
 
-	requestDataJSON, err := json.Marshal(requestData)
+	requestDataJSON, err := json.Marshal(p.requestData)
 	if err != nil {
 		return Result{Error: err}, runInfo
 	}
@@ -163,14 +150,14 @@ func (t *BridgeTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, inp
 	defer cancel()
 
 	// cacheTTL should not exceed stalenessCap.
-	cacheDuration := time.Duration(cacheTTL) * time.Second
+	cacheDuration := time.Duration(p.cacheTTL) * time.Second
 	if cacheDuration > stalenessCap {
 		lggr.Warnf("bridge task cacheTTL exceeds stalenessCap %s, overriding value to stalenessCap", stalenessCap)
 		cacheDuration = stalenessCap
 	}
 
 	var cachedResponse bool
-	responseBytes, statusCode, headers, elapsed, err := makeHTTPRequest(requestCtx, lggr, "POST", url, reqHeaders, requestData, t.httpClient, t.config.DefaultHTTPLimit())
+	responseBytes, statusCode, headers, elapsed, err := makeHTTPRequest(requestCtx, lggr, "POST", url, p.reqHeaders, p.requestData, t.httpClient, t.config.DefaultHTTPLimit())
 
 	// check for external adapter response object status
 	if code, ok := eautils.BestEffortExtractEAStatus(responseBytes); ok {
@@ -183,7 +170,7 @@ func (t *BridgeTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, inp
 		}
 
 		promBridgeErrors.WithLabelValues(t.Name).Inc()
-		if cacheTTL == 0 {
+		if p.cacheTTL == 0 {
 			return Result{Error: err}, RunInfo{IsRetryable: isRetryableHTTPError(statusCode, err)}
 		}
 
@@ -223,7 +210,7 @@ func (t *BridgeTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, inp
 		}
 	}
 
-	if !cachedResponse && cacheTTL > 0 {
+	if !cachedResponse && p.cacheTTL > 0 {
 		err := t.orm.UpsertBridgeResponse(overtimeCtx, t.dotID, t.specId, responseBytes)
 		if err != nil {
 			lggr.Errorw("Bridge task: failed to upsert response in bridge cache", "err", err)
@@ -248,6 +235,39 @@ func (t *BridgeTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, inp
 	return result, runInfo
 }
 
+type bridgeTaskParams struct {
+	name              StringParam
+	requestData       MapParam
+	includeInputAtKey StringParam
+	cacheTTL          Uint64Param
+	reqHeaders        StringSliceParam
+}
+
+func (t *BridgeTask) resolveParams(vars Vars) (p bridgeTaskParams, err error) {
+	err = multierr.Combine(
+		errors.Wrap(ResolveParam(&p.name, From(NonemptyString(t.Name))), "name"),
+		errors.Wrap(ResolveParam(&p.requestData, From(VarExpr(t.RequestData, vars), JSONWithVarExprs(t.RequestData, vars, false), nil)), "requestData"),
+		errors.Wrap(ResolveParam(&p.includeInputAtKey, From(t.IncludeInputAtKey)), "includeInputAtKey"),
+		errors.Wrap(ResolveParam(&p.cacheTTL, From(ValidDurationInSeconds(t.CacheTTL), t.bridgeConfig.BridgeCacheTTL().Seconds())), "cacheTTL"),
+		errors.Wrap(ResolveParam(&p.reqHeaders, From(NonemptyString(t.Headers), "[]")), "reqHeaders"),
+	)
+	return
+}
+
+// resultCacheKey identifies the bridge request by its params and included input, but not by the run metadata,
+// so that jobs sharing the same bridge request share the cached response.
+func (t *BridgeTask) resultCacheKey(vars Vars, inputs []Result) (string, error) {
+	p, err := t.resolveParams(vars)
+	if err != nil {
+		return "", err
+	}
+	var input interface{}
+	if t.IncludeInputAtKey != "" && len(inputs) > 0 {
+		input = inputs[0].Value
+	}
+	return newResultCacheKey(t.Type(), p.name, p.requestData, p.includeInputAtKey, input, p.reqHeaders)
+}
+
 func (t *BridgeTask) getBridgeURLFromName(ctx context.Context, name StringParam) (URLParam, error) {
 	bt, err := t.orm.FindBridge(ctx, bridges.BridgeName(name))
 	if err != nil {
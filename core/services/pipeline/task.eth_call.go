@@ -70,63 +70,40 @@ func (t *ETHCallTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, in
 		return Result{Error: errors.Wrap(err, "task inputs")}, runInfo
 	}
 
-	var (
-		contractAddr AddressParam
-		from         AddressParam
-		data         BytesParam
-		gas          Uint64Param
-		gasPrice     MaybeBigIntParam
-		gasTipCap    MaybeBigIntParam
-		gasFeeCap    MaybeBigIntParam
-		gasUnlimited BoolParam
-		chainID      StringParam
-		block        StringParam
-	)
-	err = multierr.Combine(
-		errors.Wrap(ResolveParam(&contractAddr, From(VarExpr(t.Contract, vars), NonemptyString(t.Contract))), "contract"),
-		errors.Wrap(ResolveParam(&from, From(VarExpr(t.From, vars), NonemptyString(t.From), utils.ZeroAddress)), "from"),
-		errors.Wrap(ResolveParam(&data, From(VarExpr(t.Data, vars), JSONWithVarExprs(t.Data, vars, false))), "data"),
-		errors.Wrap(ResolveParam(&gas, From(VarExpr(t.Gas, vars), NonemptyString(t.Gas), 0)), "gas"),
-		errors.Wrap(ResolveParam(&gasPrice, From(VarExpr(t.GasPrice, vars), t.GasPrice)), "gasPrice"),
-		errors.Wrap(ResolveParam(&gasTipCap, From(VarExpr(t.GasTipCap, vars), t.GasTipCap)), "gasTipCap"),
-		errors.Wrap(ResolveParam(&gasFeeCap, From(VarExpr(t.GasFeeCap, vars), t.GasFeeCap)), "gasFeeCap"),
-		errors.Wrap(ResolveParam(&chainID, From(VarExpr(t.getEvmChainID(), vars), NonemptyString(t.getEvmChainID()), "")), "evmChainID"),
-		errors.Wrap(ResolveParam(&gasUnlimited, From(VarExpr(t.GasUnlimited, vars), NonemptyString(t.GasUnlimited), false)), "gasUnlimited"),
-		errors.Wrap(ResolveParam(&block, From(VarExpr(t.Block, vars), t.Block)), "block"),
-	)
+	p, err := t.resolveParams(vars)
 	if err != nil {
 		return Result{Error: err}, runInfo
-	} else if len(data) == 0 {
+	} else if len(p.data) == 0 {
 		return Result{Error: errors.Wrapf(ErrBadInput, "data param must not be empty")}, runInfo
 	}
 
-	chain, err := t.legacyChains.Get(string(chainID))
+	chain, err := t.legacyChains.Get(string(p.chainID))
 	if err != nil {
-		err = fmt.Errorf("%w: %s: %w", ErrInvalidEVMChainID, chainID, err)
+		err = fmt.Errorf("%w: %s: %w", ErrInvalidEVMChainID, p.chainID, err)
 		return Result{Error: err}, runInfo
 	}
 
 	var selectedGas uint64
-	if gasUnlimited {
-		if gas > 0 {
+	if p.gasUnlimited {
+		if p.gas > 0 {
 			return Result{Error: errors.Wrapf(ErrBadInput, "gas must be zero when gasUnlimited is true")}, runInfo
 		}
 	} else {
-		if gas > 0 {
-			selectedGas = uint64(gas)
+		if p.gas > 0 {
+			selectedGas = uint64(p.gas)
 		} else {
 			selectedGas = SelectGasLimit(chain.Config().EVM().GasEstimator(), t.jobType, t.specGasLimit)
 		}
 	}
 
 	call := ethereum.CallMsg{
-		To:        (*common.Address)(&contractAddr),
-		From:      (common.Address)(from),
-		Data:      []byte(data),
+		To:        (*common.Address)(&p.contractAddr),
+		From:      (common.Address)(p.from),
+		Data:      []byte(p.data),
 		Gas:       selectedGas,
-		GasPrice:  gasPrice.BigInt(),
-		GasTipCap: gasTipCap.BigInt(),
-		GasFeeCap: gasFeeCap.BigInt(),
+		GasPrice:  p.gasPrice.BigInt(),
+		GasTipCap: p.gasTipCap.BigInt(),
+		GasFeeCap: p.gasFeeCap.BigInt(),
 	}
 
 	lggr = lggr.With("gas", call.Gas).
@@ -137,7 +114,7 @@ func (t *ETHCallTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, in
 	start := time.Now()
 
 	var resp []byte
-	blockStr := block.String()
+	blockStr := p.block.String()
 	if blockStr == "" || strings.ToLower(blockStr) == "latest" {
 		resp, err = chain.Client().CallContract(ctx, call, nil)
 	} else if strings.ToLower(blockStr) == "pending" {
@@ -163,3 +140,40 @@ func (t *ETHCallTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, in
 	promETHCallTime.WithLabelValues(t.DotID()).Set(float64(elapsed))
 	return Result{Value: resp}, runInfo
 }
+
+type ethCallTaskParams struct {
+	contractAddr AddressParam
+	from         AddressParam
+	data         BytesParam
+	gas          Uint64Param
+	gasPrice     MaybeBigIntParam
+	gasTipCap    MaybeBigIntParam
+	gasFeeCap    MaybeBigIntParam
+	gasUnlimited BoolParam
+	chainID      StringParam
+	block        StringParam
+}
+
+func (t *ETHCallTask) resolveParams(vars Vars) (p ethCallTaskParams, err error) {
+	err = multierr.Combine(
+		errors.Wrap(ResolveParam(&p.contractAddr, From(VarExpr(t.Contract, vars), NonemptyString(t.Contract))), "contract"),
+		errors.Wrap(ResolveParam(&p.from, From(VarExpr(t.From, vars), NonemptyString(t.From), utils.ZeroAddress)), "from"),
+		errors.Wrap(ResolveParam(&p.data, From(VarExpr(t.Data, vars), JSONWithVarExprs(t.Data, vars, false))), "data"),
+		errors.Wrap(ResolveParam(&p.gas, From(VarExpr(t.Gas, vars), NonemptyString(t.Gas), 0)), "gas"),
+		errors.Wrap(ResolveParam(&p.gasPrice, From(VarExpr(t.GasPrice, vars), t.GasPrice)), "gasPrice"),
+		errors.Wrap(ResolveParam(&p.gasTipCap, From(VarExpr(t.GasTipCap, vars), t.GasTipCap)), "gasTipCap"),
+		errors.Wrap(ResolveParam(&p.gasFeeCap, From(VarExpr(t.GasFeeCap, vars), t.GasFeeCap)), "gasFeeCap"),
+		errors.Wrap(ResolveParam(&p.chainID, From(VarExpr(t.getEvmChainID(), vars), NonemptyString(t.getEvmChainID()), "")), "evmChainID"),
+		errors.Wrap(ResolveParam(&p.gasUnlimited, From(VarExpr(t.GasUnlimited, vars), NonemptyString(t.GasUnlimited), false)), "gasUnlimited"),
+		errors.Wrap(ResolveParam(&p.block, From(VarExpr(t.Block, vars), t.Block)), "block"),
+	)
+	return
+}
+
+func (t *ETHCallTask) resultCacheKey(vars Vars, _ []Result) (string, error) {
+	p, err := t.resolveParams(vars)
+	if err != nil {
+		return "", err
+	}
+	return newResultCacheKey(t.Type(), p.chainID, p.contractAddr, p.from, p.data, p.gas, p.gasPrice, p.gasTipCap, p.gasFeeCap, p.gasUnlimited, p.block)
+}
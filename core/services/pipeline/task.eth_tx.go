@@ -39,6 +39,10 @@ type ETHTxTask struct {
 	FailOnRevert    string `json:"failOnRevert"`
 	EVMChainID      string `json:"evmChainID" mapstructure:"evmChainID"`
 	TransmitChecker string `json:"transmitChecker"`
+	// Bundle, if set, is a list of transactions with a `to`, `data` and optional `gasLimit`, which are sent from the
+	// same key with consecutive nonces before this one, e.g. to approve a token before calling the contract spending it.
+	// The task is resumed once every transaction is finalized, or errors if any of them fails.
+	Bundle string `json:"bundle"`
 
 	forwardingAllowed bool
 	specGasLimit      *uint32
@@ -95,6 +99,7 @@ func (t *ETHTxTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, inpu
 		maybeMinConfirmations MaybeUint64Param
 		transmitCheckerMap    MapParam
 		failOnRevert          BoolParam
+		bundle                SliceParam
 	)
 	err = multierr.Combine(
 		errors.Wrap(ResolveParam(&fromAddrs, From(VarExpr(t.From, vars), JSONWithVarExprs(t.From, vars, false), NonemptyString(t.From), nil)), "from"),
@@ -105,6 +110,7 @@ func (t *ETHTxTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, inpu
 		errors.Wrap(ResolveParam(&maybeMinConfirmations, From(VarExpr(t.MinConfirmations, vars), NonemptyString(t.MinConfirmations), "")), "minConfirmations"),
 		errors.Wrap(ResolveParam(&transmitCheckerMap, From(VarExpr(t.TransmitChecker, vars), JSONWithVarExprs(t.TransmitChecker, vars, false), MapParam{})), "transmitChecker"),
 		errors.Wrap(ResolveParam(&failOnRevert, From(NonemptyString(t.FailOnRevert), false)), "failOnRevert"),
+		errors.Wrap(ResolveParam(&bundle, From(VarExpr(t.Bundle, vars), JSONWithVarExprs(t.Bundle, vars, false), nil)), "bundle"),
 	)
 	if err != nil {
 		return Result{Error: err}, RunInfo{}
@@ -134,7 +140,8 @@ func (t *ETHTxTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, inpu
 	strategy := txmgrcommon.NewSendEveryStrategy()
 
 	var forwarderAddress common.Address
-	if t.forwardingAllowed {
+	// Transaction bundles are never forwarded
+	if t.forwardingAllowed && len(bundle) == 0 {
 		var fwderr error
 		forwarderAddress, fwderr = chain.TxManager().GetForwarderForEOA(ctx, fromAddr)
 		if fwderr != nil {
@@ -163,9 +170,24 @@ func (t *ETHTxTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, inpu
 		txRequest.MinConfirmations = clnull.Uint32From(uint32(minOutgoingConfirmations))
 	}
 
-	_, err = txManager.CreateTransaction(ctx, txRequest)
-	if err != nil {
-		return Result{Error: errors.Wrapf(ErrTaskRunFailed, "while creating transaction: %v", err)}, retryableRunInfo()
+	if len(bundle) > 0 {
+		bundleManager, ok := txManager.(txmgr.TxBundleManager)
+		if !ok {
+			return Result{Error: errors.Wrapf(ErrBadInput, "bundle: transaction manager of chain %s does not support transaction bundles", chainID)}, RunInfo{}
+		}
+		txRequests, err2 := bundleTxRequests(bundle, fromAddr, maximumGasLimit, txMeta)
+		if err2 != nil {
+			return Result{Error: err2}, RunInfo{}
+		}
+		_, err = bundleManager.CreateTransactionBundle(ctx, append(txRequests, txRequest))
+		if err != nil {
+			return Result{Error: errors.Wrapf(ErrTaskRunFailed, "while creating transaction bundle: %v", err)}, retryableRunInfo()
+		}
+	} else {
+		_, err = txManager.CreateTransaction(ctx, txRequest)
+		if err != nil {
+			return Result{Error: errors.Wrapf(ErrTaskRunFailed, "while creating transaction: %v", err)}, retryableRunInfo()
+		}
 	}
 
 	if txRequest.PipelineTaskRunID != nil {
@@ -175,6 +197,40 @@ func (t *ETHTxTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, inpu
 	return Result{}, RunInfo{}
 }
 
+// bundleTxRequests returns the requests of the transactions sent before the task's own transaction in its bundle
+func bundleTxRequests(bundle SliceParam, fromAddr common.Address, defaultGasLimit uint64, txMeta *txmgr.TxMeta) ([]txmgr.TxRequest, error) {
+	txRequests := make([]txmgr.TxRequest, len(bundle))
+	for i, item := range bundle {
+		var m MapParam
+		if err := m.UnmarshalPipelineParam(item); err != nil {
+			return nil, errors.Wrapf(err, "bundle: transaction %d", i)
+		}
+		var (
+			toAddr   AddressParam
+			data     BytesParam
+			gasLimit = Uint64Param(defaultGasLimit)
+		)
+		err := multierr.Combine(
+			errors.Wrapf(toAddr.UnmarshalPipelineParam(m["to"]), "bundle: transaction %d: to", i),
+			errors.Wrapf(data.UnmarshalPipelineParam(m["data"]), "bundle: transaction %d: data", i),
+		)
+		if v, ok := m["gasLimit"]; ok {
+			err = multierr.Append(err, errors.Wrapf(gasLimit.UnmarshalPipelineParam(v), "bundle: transaction %d: gasLimit", i))
+		}
+		if err != nil {
+			return nil, err
+		}
+		txRequests[i] = txmgr.TxRequest{
+			FromAddress:    fromAddr,
+			ToAddress:      common.Address(toAddr),
+			EncodedPayload: []byte(data),
+			FeeLimit:       uint64(gasLimit),
+			Meta:           txMeta,
+		}
+	}
+	return txRequests, nil
+}
+
 func decodeMeta(metaMap MapParam) (*txmgr.TxMeta, error) {
 	var txMeta txmgr.TxMeta
 	metaDecoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
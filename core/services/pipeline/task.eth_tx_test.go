@@ -1,9 +1,12 @@
 package pipeline_test
 
 import (
+	"bytes"
+	"context"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -15,6 +18,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/chains"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/txmgr"
 	txmmocks "github.com/smartcontractkit/chainlink/v2/core/chains/evm/txmgr/mocks"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/legacyevm"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/configtest"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/evmtest"
@@ -610,4 +614,110 @@ func TestETHTxTask(t *testing.T) {
 	}
 }
 
+type bundleTxManager struct {
+	*txmmocks.MockEvmTxManager
+}
+
+func (m bundleTxManager) CreateTransactionBundle(ctx context.Context, txRequests []txmgr.TxRequest) (txmgr.TxBundle, error) {
+	ret := m.Called(ctx, txRequests)
+	return ret.Get(0).(txmgr.TxBundle), ret.Error(1)
+}
+
+func (m bundleTxManager) FindTxBundle(ctx context.Context, bundleID uuid.UUID) (*txmgr.TxBundle, error) {
+	ret := m.Called(ctx, bundleID)
+	return ret.Get(0).(*txmgr.TxBundle), ret.Error(1)
+}
+
+func TestETHTxTask_Bundle(t *testing.T) {
+	from := common.HexToAddress("0x882969652440ccf14a5dbb9bd53eb21cb1e11e5c")
+	to := common.HexToAddress("0xDeaDbeefdEAdbeefdEadbEEFdeadbeEFdEaDbeeF")
+	token := common.HexToAddress("0x2E396ecbc8223Ebc16EC45136228AE5EDB649943")
+	const defaultGasLimit uint64 = 999
+
+	newTask := func(bundle string) pipeline.ETHTxTask {
+		return pipeline.ETHTxTask{
+			BaseTask:         pipeline.NewBaseTask(0, "ethtx", nil, nil, 0),
+			From:             `[ "0x882969652440ccf14a5dbb9bd53eb21cb1e11e5c" ]`,
+			To:               "0xDeaDbeefdEAdbeefdEadbEEFdeadbeEFdEaDbeeF",
+			Data:             "0x02",
+			GasLimit:         "12345",
+			MinConfirmations: "1",
+			EVMChainID:       "0",
+			Bundle:           bundle,
+		}
+	}
+	newChains := func(t *testing.T, keyStore *keystoremocks.Eth, txManager txmgr.TxManager) legacyevm.LegacyChainContainer {
+		db := pgtest.NewSqlxDB(t)
+		cfg := configtest.NewGeneralConfig(t, func(c *chainlink.Config, s *chainlink.Secrets) {
+			c.EVM[0].GasEstimator.LimitDefault = ptr(defaultGasLimit)
+		})
+		return evmtest.NewLegacyChains(t, evmtest.TestChainOpts{
+			DB:             db,
+			GeneralConfig:  cfg,
+			DatabaseConfig: cfg.Database(),
+			FeatureConfig:  cfg.Feature(),
+			ListenerConfig: cfg.Database().Listener(),
+			TxManager:      txManager,
+			KeyStore:       keyStore,
+		})
+	}
+
+	t.Run("creates a bundle of the bundled transactions and the task's transaction", func(t *testing.T) {
+		task := newTask(`[{"to": "0x2E396ecbc8223Ebc16EC45136228AE5EDB649943", "data": "0x01", "gasLimit": 500}]`)
+		keyStore := keystoremocks.NewEth(t)
+		txManager := bundleTxManager{txmmocks.NewMockEvmTxManager(t)}
+		keyStore.On("GetRoundRobinAddress", mock.Anything, testutils.FixtureChainID, from).Return(from, nil)
+		txManager.On("CreateTransactionBundle", mock.Anything, mock.MatchedBy(func(txRequests []txmgr.TxRequest) bool {
+			return len(txRequests) == 2 &&
+				txRequests[0].FromAddress == from && txRequests[0].ToAddress == token &&
+				bytes.Equal([]byte{1}, txRequests[0].EncodedPayload) && txRequests[0].FeeLimit == 500 &&
+				txRequests[0].PipelineTaskRunID == nil &&
+				txRequests[1].FromAddress == from && txRequests[1].ToAddress == to &&
+				txRequests[1].FeeLimit == 12345 && txRequests[1].PipelineTaskRunID != nil
+		})).Return(txmgr.TxBundle{}, nil)
+		task.HelperSetDependencies(newChains(t, keyStore, txManager), keyStore, nil, pipeline.DirectRequestJobType)
+
+		result, runInfo := task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(nil), nil)
+		require.NoError(t, result.Error)
+		assert.Equal(t, pipeline.RunInfo{IsPending: true}, runInfo)
+	})
+
+	t.Run("bundled transactions default to the configured gas limit", func(t *testing.T) {
+		task := newTask(`[{"to": $(token), "data": "0x01"}]`)
+		keyStore := keystoremocks.NewEth(t)
+		txManager := bundleTxManager{txmmocks.NewMockEvmTxManager(t)}
+		keyStore.On("GetRoundRobinAddress", mock.Anything, testutils.FixtureChainID, from).Return(from, nil)
+		txManager.On("CreateTransactionBundle", mock.Anything, mock.MatchedBy(func(txRequests []txmgr.TxRequest) bool {
+			return len(txRequests) == 2 && txRequests[0].ToAddress == token && txRequests[0].FeeLimit == defaultGasLimit
+		})).Return(txmgr.TxBundle{}, nil)
+		task.HelperSetDependencies(newChains(t, keyStore, txManager), keyStore, nil, pipeline.DirectRequestJobType)
+
+		result, _ := task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(map[string]interface{}{"token": token}), nil)
+		require.NoError(t, result.Error)
+	})
+
+	t.Run("errors on an invalid bundled transaction", func(t *testing.T) {
+		task := newTask(`[{"to": "0x42", "data": "0x01"}]`)
+		keyStore := keystoremocks.NewEth(t)
+		txManager := bundleTxManager{txmmocks.NewMockEvmTxManager(t)}
+		keyStore.On("GetRoundRobinAddress", mock.Anything, testutils.FixtureChainID, from).Return(from, nil)
+		task.HelperSetDependencies(newChains(t, keyStore, txManager), keyStore, nil, pipeline.DirectRequestJobType)
+
+		result, _ := task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(nil), nil)
+		require.ErrorIs(t, result.Error, pipeline.ErrBadInput)
+		assert.Contains(t, result.Error.Error(), "bundle: transaction 0: to")
+	})
+
+	t.Run("errors if the transaction manager does not support bundles", func(t *testing.T) {
+		task := newTask(`[{"to": "0x2E396ecbc8223Ebc16EC45136228AE5EDB649943", "data": "0x01"}]`)
+		keyStore := keystoremocks.NewEth(t)
+		txManager := txmmocks.NewMockEvmTxManager(t)
+		keyStore.On("GetRoundRobinAddress", mock.Anything, testutils.FixtureChainID, from).Return(from, nil)
+		task.HelperSetDependencies(newChains(t, keyStore, txManager), keyStore, nil, pipeline.DirectRequestJobType)
+
+		result, _ := task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(nil), nil)
+		require.ErrorIs(t, result.Error, pipeline.ErrBadInput)
+	})
+}
+
 func ptr[T any](t T) *T { return &t }
@@ -0,0 +1,84 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"go.uber.org/multierr"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+// ExprTask evaluates an expression over the pipeline variables, e.g.
+//
+//	expr [type=expr expr="$(a.price) * (1 + $(b.spread)) / 1e8" precision=8]
+//
+// See expr.go for the language. If Precision is set, divisions and decimal
+// results are rounded to that many decimal places.
+//
+// Return types:
+//
+//	decimal.Decimal
+//	string
+//	bool
+type ExprTask struct {
+	BaseTask  `mapstructure:",squash"`
+	Expr      string `json:"expr"`
+	Precision string `json:"precision"`
+
+	expr expr
+}
+
+var _ Task = (*ExprTask)(nil)
+
+func (t *ExprTask) Type() TaskType {
+	return TaskTypeExpr
+}
+
+// parseExpr parses the expression of the task, so that syntax errors are caught when the spec is parsed.
+func (t *ExprTask) parseExpr() error {
+	e, err := parseExpr(t.Expr)
+	if err != nil {
+		return errors.Wrap(err, "expr")
+	}
+	t.expr = e
+	return nil
+}
+
+func (t *ExprTask) Run(ctx context.Context, _ logger.Logger, vars Vars, inputs []Result) (result Result, runInfo RunInfo) {
+	_, err := CheckInputs(inputs, -1, -1, 0)
+	if err != nil {
+		return Result{Error: errors.Wrap(err, "task inputs")}, runInfo
+	}
+
+	var maybePrecision MaybeInt32Param
+	err = multierr.Combine(
+		errors.Wrap(ResolveParam(&maybePrecision, From(VarExpr(t.Precision, vars), t.Precision)), "precision"),
+	)
+	if err != nil {
+		return Result{Error: err}, runInfo
+	}
+
+	if t.expr == nil {
+		if err = t.parseExpr(); err != nil {
+			return Result{Error: err}, runInfo
+		}
+	}
+
+	var precision *int32
+	if p, isSet := maybePrecision.Int32(); isSet {
+		if p > maxExprDigits || p < -maxExprDigits {
+			return Result{Error: errors.Wrapf(ErrBadInput, "precision: must be within [-%d, %d]", maxExprDigits, maxExprDigits)}, runInfo
+		}
+		precision = &p
+	}
+	value, err := evalExpr(ctx, t.expr, vars, precision)
+	if err != nil {
+		return Result{Error: errors.Wrap(err, "expr")}, runInfo
+	}
+	if d, ok := value.(decimal.Decimal); ok && precision != nil {
+		value = d.Round(*precision)
+	}
+	return Result{Value: value}, runInfo
+}
@@ -0,0 +1,127 @@
+package pipeline_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+)
+
+func TestExprTask(t *testing.T) {
+	t.Parallel()
+
+	vars := map[string]interface{}{
+		"a":     map[string]interface{}{"price": "123456789.5", "symbol": "ETH"},
+		"b":     map[string]interface{}{"spread": 0.01},
+		"count": int64(3),
+		"flag":  true,
+		"list":  []interface{}{1, 2, 3},
+		"nil":   nil,
+	}
+
+	tests := []struct {
+		name      string
+		expr      string
+		precision string
+		want      interface{}
+		wantErr   error
+	}{
+		{"formula", "$(a.price) * (1 + $(b.spread)) / 1e8", "", decimal.RequireFromString("1.24691357395"), nil},
+		{"precedence", "1 + 2 * 3 - 4 / 2", "", decimal.NewFromInt(5), nil},
+		{"unary minus", "-(2 - 5) * -1", "", decimal.NewFromInt(-3), nil},
+		{"modulo", "$(count) % 2", "", decimal.NewFromInt(1), nil},
+		{"exact decimals", "0.1 + 0.2 == 0.3", "", true, nil},
+		{"division precision", "1 / 3", "4", decimal.RequireFromString("0.3333"), nil},
+		{"result precision", "$(a.price) * 1.005", "2", decimal.RequireFromString("124074073.45"), nil},
+		{"variable precision", "2 / 3", "$(count)", decimal.RequireFromString("0.667"), nil},
+		{"slice index", "$(list.2) * 2", "", decimal.NewFromInt(6), nil},
+		{"comparison", "$(a.price) > 100 && $(count) <= 3", "", true, nil},
+		{"logic", "!$(flag) || $(count) != 3", "", false, nil},
+		{"short circuit", "$(flag) || $(missing)", "", true, nil},
+		{"conditional", "$(count) > 2 ? 'many' : 'few'", "", "many", nil},
+		{"nested conditional", "$(count) < 2 ? 1 : $(count) < 3 ? 2 : 3", "", decimal.NewFromInt(3), nil},
+		{"string concatenation", `lower($(a.symbol)) + "/usd"`, "", "eth/usd", nil},
+		{"string comparison", "upper('eth') == $(a.symbol)", "", true, nil},
+		{"numeric strings", "'1.5' * 2 + '1'", "", decimal.NewFromInt(4), nil},
+		{"functions", "max(1, $(count), 2) + min(4, 5) + abs(-1) + floor(1.5) + ceil(1.5) + round(1.25, 1)", "", decimal.RequireFromString("12.3"), nil},
+		{"pow", "pow(10, 18) * pow(2, -1)", "", decimal.RequireFromString("500000000000000000"), nil},
+		{"conversions", "string(decimal('1.50') + 1) + len('abc')", "", decimal.RequireFromString("5.5"), nil},
+
+		{"divide by zero", "1 / ($(count) - 3)", "", nil, pipeline.ErrDivideByZero},
+		{"modulo by zero", "1 % 0", "", nil, pipeline.ErrDivideByZero},
+		{"missing variable", "$(a.missing) + 1", "", nil, pipeline.ErrKeypathNotFound},
+		{"null variable", "$(nil) + 1", "", nil, pipeline.ErrExprType},
+		{"non-numeric string", "$(a.symbol) * 2", "", nil, pipeline.ErrExprType},
+		{"non-bool condition", "1 ? 2 : 3", "", nil, pipeline.ErrExprType},
+		{"bool arithmetic", "$(flag) + 1", "", nil, pipeline.ErrExprType},
+		{"huge literal", "1e100000", "", nil, pipeline.ErrExprOverflow},
+		{"huge rescale", "1e1000 + 1e-1000", "", nil, pipeline.ErrExprOverflow},
+		{"huge pow", "pow(123456789, 256)", "", nil, pipeline.ErrExprOverflow},
+		{"huge pow exponent", "pow(1, 1000000)", "", nil, pipeline.ErrExprOverflow},
+		{"huge precision", "1 / 3", "1000000", nil, pipeline.ErrBadInput},
+		{"syntax error", "1 +", "", nil, pipeline.ErrExprSyntax},
+		{"unbalanced parens", "(1 + 2", "", nil, pipeline.ErrExprSyntax},
+		{"unknown function", "exec('rm')", "", nil, pipeline.ErrExprSyntax},
+		{"wrong number of arguments", "abs(1, 2)", "", nil, pipeline.ErrExprSyntax},
+		{"unterminated string", "'abc", "", nil, pipeline.ErrExprSyntax},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			task := pipeline.ExprTask{
+				BaseTask:  pipeline.NewBaseTask(0, "task", nil, nil, 0),
+				Expr:      test.expr,
+				Precision: test.precision,
+			}
+			result, runInfo := task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(vars), nil)
+			assert.False(t, runInfo.IsPending)
+			assert.False(t, runInfo.IsRetryable)
+
+			if test.wantErr != nil {
+				require.ErrorIs(t, result.Error, test.wantErr)
+				require.Nil(t, result.Value)
+				return
+			}
+			require.NoError(t, result.Error)
+			if want, ok := test.want.(decimal.Decimal); ok {
+				require.IsType(t, decimal.Decimal{}, result.Value)
+				assert.True(t, want.Equal(result.Value.(decimal.Decimal)), "%s != %s", want, result.Value)
+			} else {
+				assert.Equal(t, test.want, result.Value)
+			}
+		})
+	}
+
+	t.Run("errored inputs", func(t *testing.T) {
+		task := pipeline.ExprTask{BaseTask: pipeline.NewBaseTask(0, "task", nil, nil, 0), Expr: "1"}
+		result, _ := task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(nil), []pipeline.Result{{Error: assert.AnError}})
+		require.ErrorIs(t, result.Error, pipeline.ErrTooManyErrors)
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(testutils.Context(t))
+		cancel()
+		task := pipeline.ExprTask{BaseTask: pipeline.NewBaseTask(0, "task", nil, nil, 0), Expr: "1 + 1"}
+		result, _ := task.Run(ctx, logger.TestLogger(t), pipeline.NewVarsFrom(nil), nil)
+		require.ErrorIs(t, result.Error, context.Canceled)
+	})
+
+	t.Run("parses the expression with the spec", func(t *testing.T) {
+		_, err := pipeline.Parse(`a [type=expr expr="1 +"];`)
+		require.ErrorIs(t, err, pipeline.ErrExprSyntax)
+
+		p, err := pipeline.Parse(`
+a [type=memo value="2"];
+b [type=expr expr="$(a) * 21"];
+`)
+		require.NoError(t, err)
+		require.Len(t, p.Tasks, 2)
+		assert.Len(t, p.ByDotID("b").Inputs(), 1, "expected an implicit dependency on a")
+	})
+}
@@ -57,53 +57,37 @@ func (t *HTTPTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, input
 		return Result{Error: errors.Wrap(err, "task inputs")}, runInfo
 	}
 
-	var (
-		method                         StringParam
-		url                            URLParam
-		requestData                    MapParam
-		allowUnrestrictedNetworkAccess BoolParam
-		reqHeaders                     StringSliceParam
-	)
-	err = multierr.Combine(
-		errors.Wrap(ResolveParam(&method, From(NonemptyString(t.Method), "GET")), "method"),
-		errors.Wrap(ResolveParam(&url, From(VarExpr(t.URL, vars), NonemptyString(t.URL))), "url"),
-		errors.Wrap(ResolveParam(&requestData, From(VarExpr(t.RequestData, vars), JSONWithVarExprs(t.RequestData, vars, false), nil)), "requestData"),
-		// Any hardcoded strings used for URL uses the unrestricted HTTP adapter
-		// Interpolated variable URLs use restricted HTTP adapter by default
-		// You must set allowUnrestrictedNetworkAccess=true on the task to enable variable-interpolated URLs to make restricted network requests
-		errors.Wrap(ResolveParam(&allowUnrestrictedNetworkAccess, From(NonemptyString(t.AllowUnrestrictedNetworkAccess), !variableRegexp.MatchString(t.URL))), "allowUnrestrictedNetworkAccess"),
-		errors.Wrap(ResolveParam(&reqHeaders, From(NonemptyString(t.Headers), "[]")), "reqHeaders"),
-	)
+	p, err := t.resolveParams(vars)
 	if err != nil {
 		return Result{Error: err}, runInfo
 	}
 
-	if len(reqHeaders)%2 != 0 {
+	if len(p.reqHeaders)%2 != 0 {
 		return Result{Error: errors.Errorf("headers must have an even number of elements")}, runInfo
 	}
 
-	requestDataJSON, err := json.Marshal(requestData)
+	requestDataJSON, err := json.Marshal(p.requestData)
 	if err != nil {
 		return Result{Error: err}, runInfo
 	}
 	lggr.Debugw("HTTP task: sending request",
 		"requestData", string(requestDataJSON),
-		"url", url.String(),
-		"method", method,
-		"reqHeaders", reqHeaders,
-		"allowUnrestrictedNetworkAccess", allowUnrestrictedNetworkAccess,
+		"url", p.url.String(),
+		"method", p.method,
+		"reqHeaders", p.reqHeaders,
+		"allowUnrestrictedNetworkAccess", p.allowUnrestrictedNetworkAccess,
 	)
 
 	requestCtx, cancel := httpRequestCtx(ctx, t, t.config)
 	defer cancel()
 
 	var client *http.Client
-	if allowUnrestrictedNetworkAccess {
+	if p.allowUnrestrictedNetworkAccess {
 		client = t.unrestrictedHTTPClient
 	} else {
 		client = t.httpClient
 	}
-	responseBytes, statusCode, respHeaders, elapsed, err := makeHTTPRequest(requestCtx, lggr, method, url, reqHeaders, requestData, client, t.config.DefaultHTTPLimit())
+	responseBytes, statusCode, respHeaders, elapsed, err := makeHTTPRequest(requestCtx, lggr, p.method, p.url, p.reqHeaders, p.requestData, client, t.config.DefaultHTTPLimit())
 	if err != nil {
 		if errors.Is(errors.Cause(err), clhttp.ErrDisallowedIP) {
 			err = errors.Wrap(err, `connections to local resources are disabled by default, if you are sure this is safe, you can enable on a per-task basis by setting allowUnrestrictedNetworkAccess="true" in the pipeline task spec, e.g. fetch [type="http" method=GET url="$(decode_cbor.url)" allowUnrestrictedNetworkAccess="true"]`)
@@ -114,7 +98,7 @@ func (t *HTTPTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, input
 	lggr.Debugw("HTTP task got response",
 		"response", string(responseBytes),
 		"respHeaders", respHeaders,
-		"url", url.String(),
+		"url", p.url.String(),
 		"dotID", t.DotID(),
 	)
 
@@ -127,3 +111,33 @@ func (t *HTTPTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, input
 	// value instead.
 	return Result{Value: string(responseBytes)}, runInfo
 }
+
+type httpTaskParams struct {
+	method                         StringParam
+	url                            URLParam
+	requestData                    MapParam
+	allowUnrestrictedNetworkAccess BoolParam
+	reqHeaders                     StringSliceParam
+}
+
+func (t *HTTPTask) resolveParams(vars Vars) (p httpTaskParams, err error) {
+	err = multierr.Combine(
+		errors.Wrap(ResolveParam(&p.method, From(NonemptyString(t.Method), "GET")), "method"),
+		errors.Wrap(ResolveParam(&p.url, From(VarExpr(t.URL, vars), NonemptyString(t.URL))), "url"),
+		errors.Wrap(ResolveParam(&p.requestData, From(VarExpr(t.RequestData, vars), JSONWithVarExprs(t.RequestData, vars, false), nil)), "requestData"),
+		// Any hardcoded strings used for URL uses the unrestricted HTTP adapter
+		// Interpolated variable URLs use restricted HTTP adapter by default
+		// You must set allowUnrestrictedNetworkAccess=true on the task to enable variable-interpolated URLs to make restricted network requests
+		errors.Wrap(ResolveParam(&p.allowUnrestrictedNetworkAccess, From(NonemptyString(t.AllowUnrestrictedNetworkAccess), !variableRegexp.MatchString(t.URL))), "allowUnrestrictedNetworkAccess"),
+		errors.Wrap(ResolveParam(&p.reqHeaders, From(NonemptyString(t.Headers), "[]")), "reqHeaders"),
+	)
+	return
+}
+
+func (t *HTTPTask) resultCacheKey(vars Vars, _ []Result) (string, error) {
+	p, err := t.resolveParams(vars)
+	if err != nil {
+		return "", err
+	}
+	return newResultCacheKey(t.Type(), p.method, p.url.String(), p.requestData, p.allowUnrestrictedNetworkAccess, p.reqHeaders)
+}
@@ -0,0 +1,170 @@
+package pipeline
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+const (
+	// MapItemKey is the variable holding the current element within the body of a map task.
+	MapItemKey = "item"
+	// MapIndexKey is the variable holding the index of the current element within the body of a map task.
+	MapIndexKey = "index"
+)
+
+// MapTask executes Body, a nested pipeline, once for every element of Input
+// and collects the result of each execution into an array. Within the body,
+// $(item) and $(index) refer to the current element and its position; all
+// variables of the enclosing pipeline are available as well.
+//
+// The body must have exactly one terminal task, whose result becomes the
+// element's value in the output. Elements whose body fails are counted as
+// faults and left out of the output; the task fails if there are more faults
+// than AllowedFaults (default 0). Concurrency limits how many elements are
+// executed at once (default: all of them).
+//
+// The body is either a quoted string, with inner quotes escaped, or an
+// HTML-like <...> string. As the latter cannot contain "->", tasks of such a
+// body are connected through their $(task) references instead:
+//
+//	m [type=map input="$(values)" body=<
+//	    mul [type=multiply input="$(item)" times=10];
+//	    add [type=sum values=<[ $(mul), $(index) ]>];
+//	>];
+//
+// Return types:
+//
+//	[]interface{}
+type MapTask struct {
+	BaseTask      `mapstructure:",squash"`
+	Input         string `json:"input"`
+	Body          string `json:"body"`
+	Concurrency   string `json:"concurrency"`
+	AllowedFaults string `json:"allowedFaults"`
+
+	body   *Pipeline
+	spec   Spec
+	runner *runner
+}
+
+var _ Task = (*MapTask)(nil)
+
+func (t *MapTask) Type() TaskType {
+	return TaskTypeMap
+}
+
+// parseBody parses and validates the nested pipeline of the task.
+func (t *MapTask) parseBody() error {
+	source := strings.TrimSpace(t.Body)
+	// Bodies containing angle brackets themselves are not unquoted by the DOT parser
+	if strings.HasPrefix(source, "<") && strings.HasSuffix(source, ">") {
+		source = source[1 : len(source)-1]
+	}
+	body, err := Parse(source)
+	if err != nil {
+		return errors.Wrap(err, "body")
+	}
+	if body.RequiresPreInsert() {
+		return errors.New("body: async tasks are not supported")
+	}
+	var terminal int
+	for _, task := range body.Tasks {
+		if len(task.Outputs()) == 0 {
+			terminal++
+		}
+	}
+	if terminal != 1 {
+		return errors.Errorf("body: expected exactly one terminal task, got %d", terminal)
+	}
+	t.body = body
+	return nil
+}
+
+func (t *MapTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, inputs []Result) (result Result, runInfo RunInfo) {
+	_, err := CheckInputs(inputs, 0, 1, 0)
+	if err != nil {
+		return Result{Error: errors.Wrap(err, "task inputs")}, runInfo
+	}
+
+	var (
+		items              SliceParam
+		maybeConcurrency   MaybeUint64Param
+		maybeAllowedFaults MaybeUint64Param
+	)
+	err = multierr.Combine(
+		errors.Wrap(ResolveParam(&items, From(VarExpr(t.Input, vars), JSONWithVarExprs(t.Input, vars, false), Input(inputs, 0))), "input"),
+		errors.Wrap(ResolveParam(&maybeConcurrency, From(t.Concurrency)), "concurrency"),
+		errors.Wrap(ResolveParam(&maybeAllowedFaults, From(t.AllowedFaults)), "allowedFaults"),
+	)
+	if err != nil {
+		return Result{Error: err}, runInfo
+	}
+	if t.runner == nil || t.body == nil {
+		return Result{Error: errors.New("map task is not initialized")}, runInfo
+	}
+
+	concurrency := len(items)
+	if c, isSet := maybeConcurrency.Uint64(); isSet && c > 0 && c < uint64(concurrency) {
+		concurrency = int(c)
+	}
+	var allowedFaults int
+	if allowed, isSet := maybeAllowedFaults.Uint64(); isSet {
+		allowedFaults = int(allowed)
+	}
+
+	// Elements still running are cancelled if the task returns early
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]Result, len(items))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, item := range items {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			results[i] = Result{Error: ctx.Err()}
+			continue
+		}
+
+		itemVars := vars.Copy()
+		err = multierr.Combine(itemVars.Set(MapItemKey, item), itemVars.Set(MapIndexKey, i))
+		if err != nil {
+			<-sem
+			cancel()
+			wg.Wait()
+			return Result{Error: err}, runInfo
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = t.runner.runSubPipeline(ctx, t.spec, t.body, itemVars, lggr.With("mapDotID", t.DotID(), "mapIndex", i))
+		}(i)
+	}
+	wg.Wait()
+
+	values := make([]interface{}, 0, len(items))
+	var faults int
+	var errs error
+	for i, r := range results {
+		if r.Error != nil {
+			faults++
+			errs = multierr.Append(errs, errors.Wrapf(r.Error, "element %d", i))
+			continue
+		}
+		values = append(values, r.Value)
+	}
+	if faults > allowedFaults {
+		return Result{Error: errors.Wrapf(ErrTooManyErrors, "number of faulty elements %v to map task > number allowed faults %v: %v", faults, allowedFaults, errs)}, runInfo
+	}
+
+	return Result{Value: values}, runInfo
+}
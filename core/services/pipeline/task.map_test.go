@@ -0,0 +1,166 @@
+package pipeline_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/configtest"
+	clhttptest "github.com/smartcontractkit/chainlink/v2/core/internal/testutils/httptest"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+)
+
+func TestMapTask_Parse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		dot  string
+		err  string
+	}{
+		{"valid", `
+m [type=map input="[1,2]" body=<
+	a [type=multiply input="$(item)" times=2];
+	b [type=sum values=<[ $(a), 1 ]>];
+>];`, ""},
+		{"quoted body", `m [type=map input="[1,2]" body="a [type=multiply input=\"$(item)\" times=2]; b [type=sum values=<[ $(a), 1 ]>]; a -> b;"];`, ""},
+		{"invalid body", `m [type=map input="[1,2]" body="a ->"];`, "body"},
+		{"multiple terminal tasks", `
+m [type=map input="[1,2]" body=<
+	a [type=multiply input="$(item)" times=2];
+	b [type=multiply input="$(item)" times=3];
+>];`, "expected exactly one terminal task, got 2"},
+		{"async tasks", `
+m [type=map input="[1,2]" body=<
+	a [type=ethtx to="0x0"];
+>];`, "async tasks are not supported"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := pipeline.Parse(test.dot)
+			if test.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestMapTask(t *testing.T) {
+	t.Parallel()
+
+	cfg := configtest.NewTestGeneralConfig(t)
+	c := clhttptest.NewTestLocalOnlyHTTPClient()
+	r := pipeline.NewRunner(nil, nil, cfg.JobPipeline(), cfg.WebServer(), nil, nil, nil, logger.TestLogger(t), c, c)
+
+	s := httptest.NewServer(fakeStringResponder(t, `{"USD": 10}`))
+	defer s.Close()
+
+	tests := []struct {
+		name    string
+		dot     string
+		vars    map[string]interface{}
+		want    []decimal.Decimal
+		wantErr string
+	}{
+		{
+			"fans out over an array",
+			`
+list [type=jsonparse data="$(json)" path="values"];
+m    [type=map body=<
+	mul [type=multiply input="$(item)" times=10];
+	add [type=sum values=<[ $(mul), $(index), $(offset) ]>];
+>];
+list -> m;
+`,
+			map[string]interface{}{"json": `{"values": [1, 2, 3]}`, "offset": 100},
+			[]decimal.Decimal{decimal.NewFromInt(110), decimal.NewFromInt(121), decimal.NewFromInt(132)},
+			"",
+		},
+		{
+			"executes nested data sources with limited concurrency",
+			`
+m [type=map input="$(urls)" concurrency=1 body=<
+	fetch [type=http method=GET url="$(item)"];
+	parse [type=jsonparse data="$(fetch)" path="USD"];
+>];
+`,
+			map[string]interface{}{"urls": []interface{}{s.URL, s.URL}},
+			[]decimal.Decimal{decimal.NewFromInt(10), decimal.NewFromInt(10)},
+			"",
+		},
+		{
+			"fails on faulty elements",
+			`
+m [type=map input="$(values)" body=<
+	div [type=divide input="1" divisor="$(item)"];
+>];
+`,
+			map[string]interface{}{"values": []interface{}{1, 0, 2}},
+			nil,
+			"number of faulty elements 1 to map task > number allowed faults 0",
+		},
+		{
+			"drops allowed faulty elements",
+			`
+m [type=map input="$(values)" allowedFaults=1 body=<
+	div [type=divide input="1" divisor="$(item)"];
+>];
+`,
+			map[string]interface{}{"values": []interface{}{1, 0, 2}},
+			[]decimal.Decimal{decimal.NewFromInt(1), decimal.RequireFromString("0.5")},
+			"",
+		},
+		{
+			"feeds aggregate tasks",
+			`
+m      [type=map input="$(values)" body=<
+	mul [type=multiply input="$(item)" times=2];
+>];
+median [type=median values="$(m)"];
+m -> median;
+`,
+			map[string]interface{}{"values": []interface{}{1, 5, 3}},
+			[]decimal.Decimal{decimal.NewFromInt(6)},
+			"",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, trrs, err := r.ExecuteRun(testutils.Context(t), pipeline.Spec{DotDagSource: test.dot}, pipeline.NewVarsFrom(test.vars))
+			require.NoError(t, err)
+
+			result := trrs.FinalResult()
+			if test.wantErr != "" {
+				require.True(t, result.HasFatalErrors())
+				assert.ErrorContains(t, result.FatalErrors[0], test.wantErr)
+				return
+			}
+			require.False(t, result.HasFatalErrors(), result.FatalErrors)
+
+			var got []decimal.Decimal
+			switch v := result.Values[0].(type) {
+			case []interface{}:
+				var values pipeline.DecimalSliceParam
+				require.NoError(t, values.UnmarshalPipelineParam(v))
+				got = values
+			case decimal.Decimal:
+				got = []decimal.Decimal{v}
+			default:
+				t.Fatalf("unexpected result type %T", v)
+			}
+			require.Len(t, got, len(test.want))
+			for i := range test.want {
+				assert.True(t, test.want[i].Equal(got[i]), "%s != %s", test.want[i], got[i])
+			}
+		})
+	}
+}
@@ -48,7 +48,9 @@ func NewEVMBackendTH(t *testing.T) *EVMBackendTH {
 	lggr := logger.Test(t)
 
 	ownerKey := cltest.MustGenerateRandomKey(t)
-	contractsOwner, err := bind.NewKeyedTransactorWithChainID(ownerKey.ToEcdsaPrivKey(), testutils.SimulatedChainID)
+	ownerPrivKey, err := ownerKey.ToEcdsaPrivKey()
+	require.NoError(t, err)
+	contractsOwner, err := bind.NewKeyedTransactorWithChainID(ownerPrivKey, testutils.SimulatedChainID)
 	require.NoError(t, err)
 
 	// Setup simulated go-ethereum EVM backend
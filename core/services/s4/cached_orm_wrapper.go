@@ -20,6 +20,8 @@ const (
 	cleanupInterval = 5 * time.Minute
 
 	getSnapshotCachePrefix = "GetSnapshot"
+	getVersionCachePrefix  = "GetVersion"
+	historyCachePrefix     = "History"
 )
 
 // CachedORM is a cached orm wrapper that implements the ORM interface.
@@ -44,12 +46,59 @@ func (c CachedORM) Get(ctx context.Context, address *ubig.Big, slotId uint) (*Ro
 	return c.underlayingORM.Get(ctx, address, slotId)
 }
 
+func (c CachedORM) GetVersion(ctx context.Context, address *ubig.Big, slotId uint, version uint64) (*Row, error) {
+	key := fmt.Sprintf("%s_%s_%d_%d", getVersionCachePrefix, address.String(), slotId, version)
+
+	cached, found := c.cache.Get(key)
+	if found {
+		return cached.(*Row).Clone(), nil
+	}
+
+	c.lggr.Debug("Row version not found in cache, fetching it from underlaying implementation")
+	row, err := c.underlayingORM.GetVersion(ctx, address, slotId, version)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.Set(key, row.Clone(), defaultExpiration)
+
+	return row, nil
+}
+
+func (c CachedORM) History(ctx context.Context, address *ubig.Big, slotId uint, limit uint) ([]*Row, error) {
+	key := fmt.Sprintf("%s_%s_%d_%d", historyCachePrefix, address.String(), slotId, limit)
+
+	cached, found := c.cache.Get(key)
+	if found {
+		return cloneRows(cached.([]*Row)), nil
+	}
+
+	c.lggr.Debug("Row history not found in cache, fetching it from underlaying implementation")
+	rows, err := c.underlayingORM.History(ctx, address, slotId, limit)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.Set(key, cloneRows(rows), defaultExpiration)
+
+	return rows, nil
+}
+
+func (c CachedORM) GetHistory(ctx context.Context, keys []HistoryKey, limit uint) ([]*Row, error) {
+	return c.underlayingORM.GetHistory(ctx, keys, limit)
+}
+
 func (c CachedORM) Update(ctx context.Context, row *Row) error {
 	c.deleteRowFromSnapshotCache(row)
+	c.deleteRowFromVersionCache(row)
 
 	return c.underlayingORM.Update(ctx, row)
 }
 
+func (c CachedORM) AddHistory(ctx context.Context, row *Row) error {
+	c.deleteRowFromVersionCache(row)
+
+	return c.underlayingORM.AddHistory(ctx, row)
+}
+
 func (c CachedORM) DeleteExpired(ctx context.Context, limit uint, utcNow time.Time) (int64, error) {
 	deletedRows, err := c.underlayingORM.DeleteExpired(ctx, limit, utcNow)
 	if err != nil {
@@ -117,3 +166,23 @@ func (c CachedORM) deleteRowFromSnapshotCache(row *Row) {
 		}
 	}
 }
+
+// deleteRowFromVersionCache will clean the cached versions and history of a given row,
+// as an update may confirm the current version and prune the history.
+func (c CachedORM) deleteRowFromVersionCache(row *Row) {
+	getVersionPrefix := fmt.Sprintf("%s_%s_%d_", getVersionCachePrefix, row.Address.String(), row.SlotId)
+	historyPrefix := fmt.Sprintf("%s_%s_%d_", historyCachePrefix, row.Address.String(), row.SlotId)
+	for key := range c.cache.Items() {
+		if strings.HasPrefix(key, getVersionPrefix) || strings.HasPrefix(key, historyPrefix) {
+			c.cache.Delete(key)
+		}
+	}
+}
+
+func cloneRows(rows []*Row) []*Row {
+	clones := make([]*Row, len(rows))
+	for i, row := range rows {
+		clones[i] = row.Clone()
+	}
+	return clones
+}
@@ -199,6 +199,71 @@ func TestGet(t *testing.T) {
 	})
 }
 
+func TestGetVersionAndHistoryCache(t *testing.T) {
+	address := big.New(testutils.NewAddress().Big())
+	var slotID uint = 1
+
+	lggr := logger.TestLogger(t)
+
+	t.Run("OK-GetVersion_and_History_cached_until_update", func(t *testing.T) {
+		ctx := testutils.Context(t)
+		versions := []*s4.Row{
+			{Address: address, SlotId: slotID, Version: 2, Payload: []byte{2}},
+			{Address: address, SlotId: slotID, Version: 1, Payload: []byte{1}},
+		}
+		underlayingORM := mocks.NewORM(t)
+		underlayingORM.On("GetVersion", mock.Anything, address, slotID, uint64(1)).Return(versions[1], nil).Twice()
+		underlayingORM.On("History", mock.Anything, address, slotID, uint(2)).Return(versions, nil).Twice()
+		orm := s4.NewCachedORMWrapper(underlayingORM, lggr)
+
+		for i := 0; i < 2; i++ {
+			row, err := orm.GetVersion(ctx, address, slotID, 1)
+			require.NoError(t, err)
+			require.Equal(t, uint64(1), row.Version)
+			require.Equal(t, []byte{1}, row.Payload)
+
+			rows, err := orm.History(ctx, address, slotID, 2)
+			require.NoError(t, err)
+			require.Len(t, rows, 2)
+			require.Equal(t, []byte{2}, rows[0].Payload)
+		}
+
+		// the cache returns copies
+		row, err := orm.GetVersion(ctx, address, slotID, 1)
+		require.NoError(t, err)
+		row.Payload[0] = 42
+		row, err = orm.GetVersion(ctx, address, slotID, 1)
+		require.NoError(t, err)
+		require.Equal(t, []byte{1}, row.Payload)
+
+		// an update of another slot keeps the cache
+		underlayingORM.On("Update", mock.Anything, mock.Anything).Return(nil).Twice()
+		require.NoError(t, orm.Update(ctx, &s4.Row{Address: address, SlotId: slotID + 1, Version: 1}))
+		_, err = orm.GetVersion(ctx, address, slotID, 1)
+		require.NoError(t, err)
+
+		// an update of the slot invalidates the cache, if not the mock will return an error because of .Twice()
+		require.NoError(t, orm.Update(ctx, &s4.Row{Address: address, SlotId: slotID, Version: 3}))
+		_, err = orm.GetVersion(ctx, address, slotID, 1)
+		require.NoError(t, err)
+		_, err = orm.History(ctx, address, slotID, 2)
+		require.NoError(t, err)
+	})
+
+	t.Run("NOK-GetVersion_underlaying_ORM_returns_an_error", func(t *testing.T) {
+		ctx := testutils.Context(t)
+		underlayingORM := mocks.NewORM(t)
+		underlayingORM.On("GetVersion", mock.Anything, address, slotID, uint64(1)).Return(nil, s4.ErrNotFound).Twice()
+		orm := s4.NewCachedORMWrapper(underlayingORM, lggr)
+
+		for i := 0; i < 2; i++ {
+			row, err := orm.GetVersion(ctx, address, slotID, 1)
+			require.Nil(t, row)
+			require.ErrorIs(t, err, s4.ErrNotFound)
+		}
+	})
+}
+
 func TestDeletedExpired(t *testing.T) {
 	var limit uint = 1
 	now := time.Now()
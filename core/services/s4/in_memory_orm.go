@@ -17,18 +17,24 @@ type key struct {
 type mrow struct {
 	Row       *Row
 	UpdatedAt time.Time
+	// History holds the previous versions, ordered by descending version.
+	History []*Row
 }
 
 type inMemoryOrm struct {
-	rows map[key]*mrow
-	mu   sync.RWMutex
+	rows               map[key]*mrow
+	maxHistoryVersions uint
+	mu                 sync.RWMutex
 }
 
 var _ ORM = (*inMemoryOrm)(nil)
 
-func NewInMemoryORM() ORM {
+// NewInMemoryORM returns an ORM which keeps rows in memory,
+// retaining up to maxHistoryVersions previous versions of every row.
+func NewInMemoryORM(maxHistoryVersions uint) ORM {
 	return &inMemoryOrm{
-		rows: make(map[key]*mrow),
+		rows:               make(map[key]*mrow),
+		maxHistoryVersions: maxHistoryVersions,
 	}
 }
 
@@ -41,12 +47,83 @@ func (o *inMemoryOrm) Get(ctx context.Context, address *big.Big, slotId uint) (*
 		slot:    slotId,
 	}
 	mrow, ok := o.rows[mkey]
-	if !ok {
+	if !ok || mrow.Row == nil {
 		return nil, ErrNotFound
 	}
 	return mrow.Row.Clone(), nil
 }
 
+func (o *inMemoryOrm) GetVersion(ctx context.Context, address *big.Big, slotId uint, version uint64) (*Row, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	mkey := key{
+		address: address.Hex(),
+		slot:    slotId,
+	}
+	mrow, ok := o.rows[mkey]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if mrow.Row != nil && mrow.Row.Version == version {
+		return mrow.Row.Clone(), nil
+	}
+	for _, row := range mrow.History {
+		if row.Version == version {
+			return row.Clone(), nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (o *inMemoryOrm) History(ctx context.Context, address *big.Big, slotId uint, limit uint) ([]*Row, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	mkey := key{
+		address: address.Hex(),
+		slot:    slotId,
+	}
+	rows := make([]*Row, 0)
+	mrow, ok := o.rows[mkey]
+	if !ok {
+		return rows, nil
+	}
+	if mrow.Row != nil {
+		rows = append(rows, mrow.Row.Clone())
+	}
+	for _, row := range mrow.History {
+		rows = append(rows, row.Clone())
+	}
+	if uint(len(rows)) > limit {
+		rows = rows[:limit]
+	}
+	return rows, nil
+}
+
+func (o *inMemoryOrm) GetHistory(ctx context.Context, keys []HistoryKey, limit uint) ([]*Row, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	rows := make([]*Row, 0)
+	for _, k := range keys {
+		mrow, ok := o.rows[key{address: k.Address.Hex(), slot: k.SlotId}]
+		if !ok {
+			continue
+		}
+		// History is ordered by descending version
+		for i := len(mrow.History) - 1; i >= 0; i-- {
+			if uint(len(rows)) >= limit {
+				return rows, nil
+			}
+			if mrow.History[i].Version > k.AfterVersion {
+				rows = append(rows, mrow.History[i].Clone())
+			}
+		}
+	}
+	return rows, nil
+}
+
 func (o *inMemoryOrm) Update(ctx context.Context, row *Row) error {
 	o.mu.Lock()
 	defer o.mu.Unlock()
@@ -56,6 +133,10 @@ func (o *inMemoryOrm) Update(ctx context.Context, row *Row) error {
 		slot:    row.SlotId,
 	}
 	existing, ok := o.rows[mkey]
+	if ok && existing.Row == nil {
+		// only the history is left after the current row expired
+		ok = false
+	}
 	versionOk := false
 	if ok && row.Confirmed {
 		versionOk = existing.Row.Version <= row.Version
@@ -67,10 +148,51 @@ func (o *inMemoryOrm) Update(ctx context.Context, row *Row) error {
 		return ErrVersionTooLow
 	}
 
+	var history []*Row
+	if existing != nil {
+		history = existing.History
+	}
+	if ok && existing.Row.Version < row.Version && o.maxHistoryVersions > 0 {
+		history = append([]*Row{existing.Row}, history...)
+	}
+	if uint(len(history)) > o.maxHistoryVersions {
+		history = history[:o.maxHistoryVersions]
+	}
+
 	o.rows[mkey] = &mrow{
 		Row:       row.Clone(),
 		UpdatedAt: time.Now().UTC(),
+		History:   history,
+	}
+	return nil
+}
+
+func (o *inMemoryOrm) AddHistory(ctx context.Context, row *Row) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	mkey := key{
+		address: row.Address.Hex(),
+		slot:    row.SlotId,
 	}
+	existing, ok := o.rows[mkey]
+	if !ok || existing.Row == nil || existing.Row.Version <= row.Version || o.maxHistoryVersions == 0 {
+		return nil
+	}
+	i := sort.Search(len(existing.History), func(i int) bool {
+		return existing.History[i].Version <= row.Version
+	})
+	if i < len(existing.History) && existing.History[i].Version == row.Version {
+		return nil
+	}
+	history := make([]*Row, 0, len(existing.History)+1)
+	history = append(history, existing.History[:i]...)
+	history = append(history, row.Clone())
+	history = append(history, existing.History[i:]...)
+	if uint(len(history)) > o.maxHistoryVersions {
+		history = history[:o.maxHistoryVersions]
+	}
+	existing.History = history
 	return nil
 }
 
@@ -78,20 +200,30 @@ func (o *inMemoryOrm) DeleteExpired(ctx context.Context, limit uint, now time.Ti
 	o.mu.Lock()
 	defer o.mu.Unlock()
 
-	queue := make([]key, 0)
+	var deleted int64
 	for k, v := range o.rows {
-		if v.Row.Expiration < now.UnixMilli() {
-			queue = append(queue, k)
-			if len(queue) >= int(limit) {
-				break
+		if deleted >= int64(limit) {
+			break
+		}
+		if v.Row != nil && v.Row.Expiration < now.UnixMilli() {
+			v.Row = nil
+			deleted++
+		}
+		history := v.History[:0]
+		for _, row := range v.History {
+			if row.Expiration < now.UnixMilli() && deleted < int64(limit) {
+				deleted++
+				continue
 			}
+			history = append(history, row)
+		}
+		v.History = history
+		if v.Row == nil && len(v.History) == 0 {
+			delete(o.rows, k)
 		}
-	}
-	for _, k := range queue {
-		delete(o.rows, k)
 	}
 
-	return int64(len(queue)), nil
+	return deleted, nil
 }
 
 func (o *inMemoryOrm) GetSnapshot(ctx context.Context, _ *AddressRange) ([]*SnapshotRow, error) {
@@ -101,7 +233,7 @@ func (o *inMemoryOrm) GetSnapshot(ctx context.Context, _ *AddressRange) ([]*Snap
 	now := time.Now().UnixMilli()
 	var rows []*SnapshotRow
 	for _, mrow := range o.rows {
-		if mrow.Row.Expiration > now {
+		if mrow.Row != nil && mrow.Row.Expiration > now {
 			rows = append(rows, &SnapshotRow{
 				Address:    big.New(mrow.Row.Address.ToInt()),
 				SlotId:     mrow.Row.SlotId,
@@ -122,7 +254,7 @@ func (o *inMemoryOrm) GetUnconfirmedRows(ctx context.Context, limit uint) ([]*Ro
 	now := time.Now().UnixMilli()
 	var mrows []*mrow
 	for _, mrow := range o.rows {
-		if mrow.Row.Expiration > now && !mrow.Row.Confirmed {
+		if mrow.Row != nil && mrow.Row.Expiration > now && !mrow.Row.Confirmed {
 			mrows = append(mrows, mrow)
 		}
 	}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestInMemoryORM(t *testing.T) {
@@ -30,7 +31,7 @@ func TestInMemoryORM(t *testing.T) {
 		Signature:  signature[:],
 	}
 
-	orm := s4.NewInMemoryORM()
+	orm := s4.NewInMemoryORM(0)
 
 	t.Run("row not found", func(t *testing.T) {
 		ctx := testutils.Context(t)
@@ -72,7 +73,7 @@ func TestInMemoryORM_DeleteExpired(t *testing.T) {
 	t.Parallel()
 	ctx := testutils.Context(t)
 
-	orm := s4.NewInMemoryORM()
+	orm := s4.NewInMemoryORM(0)
 	baseTime := time.Now().Add(time.Minute).UTC()
 
 	for i := 0; i < 256; i++ {
@@ -106,7 +107,7 @@ func TestInMemoryORM_GetUnconfirmedRows(t *testing.T) {
 	t.Parallel()
 	ctx := testutils.Context(t)
 
-	orm := s4.NewInMemoryORM()
+	orm := s4.NewInMemoryORM(0)
 	expiration := time.Now().Add(100 * time.Second).UnixMilli()
 
 	for i := 0; i < 256; i++ {
@@ -136,7 +137,7 @@ func TestInMemoryORM_GetSnapshot(t *testing.T) {
 	t.Parallel()
 	ctx := testutils.Context(t)
 
-	orm := s4.NewInMemoryORM()
+	orm := s4.NewInMemoryORM(0)
 	expiration := time.Now().Add(100 * time.Second).UnixMilli()
 
 	const n = 256
@@ -170,3 +171,118 @@ func TestInMemoryORM_GetSnapshot(t *testing.T) {
 		assert.Equal(t, 1, c)
 	}
 }
+
+func TestInMemoryORM_History(t *testing.T) {
+	t.Parallel()
+	ctx := testutils.Context(t)
+
+	orm := s4.NewInMemoryORM(2)
+	address := big.New(testutils.NewAddress().Big())
+	expiration := time.Now().Add(time.Minute).UnixMilli()
+
+	for version := uint64(1); version <= 4; version++ {
+		err := orm.Update(ctx, &s4.Row{
+			Address:    address,
+			SlotId:     1,
+			Payload:    []byte{byte(version)},
+			Version:    version,
+			Expiration: expiration + int64(version),
+			Signature:  []byte{},
+		})
+		assert.NoError(t, err)
+	}
+	// confirming the current version does not add to the history
+	err := orm.Update(ctx, &s4.Row{Address: address, SlotId: 1, Payload: []byte{4}, Version: 4, Expiration: expiration + 4, Confirmed: true, Signature: []byte{}})
+	assert.NoError(t, err)
+
+	rows, err := orm.History(ctx, address, 1, 10)
+	assert.NoError(t, err)
+	var versions []uint64
+	for _, row := range rows {
+		versions = append(versions, row.Version)
+		assert.Equal(t, []byte{byte(row.Version)}, row.Payload)
+	}
+	assert.Equal(t, []uint64{4, 3, 2}, versions)
+	assert.True(t, rows[0].Confirmed)
+
+	rows, err = orm.History(ctx, address, 1, 2)
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+
+	rows, err = orm.History(ctx, address, 2, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, rows)
+
+	// GetHistory excludes the current rows, and returns the versions after AfterVersion in ascending order
+	rows, err = orm.GetHistory(ctx, []s4.HistoryKey{{Address: address, SlotId: 1}, {Address: address, SlotId: 2}}, 10)
+	assert.NoError(t, err)
+	versions = nil
+	for _, row := range rows {
+		versions = append(versions, row.Version)
+	}
+	assert.Equal(t, []uint64{2, 3}, versions)
+
+	rows, err = orm.GetHistory(ctx, []s4.HistoryKey{{Address: address, SlotId: 1, AfterVersion: 2}}, 10)
+	assert.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, uint64(3), rows[0].Version)
+
+	rows, err = orm.GetHistory(ctx, []s4.HistoryKey{{Address: address, SlotId: 1}}, 1)
+	assert.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, uint64(2), rows[0].Version)
+
+	row, err := orm.GetVersion(ctx, address, 1, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), row.Version)
+	assert.Equal(t, []byte{3}, row.Payload)
+
+	row, err = orm.GetVersion(ctx, address, 1, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), row.Version)
+
+	_, err = orm.GetVersion(ctx, address, 1, 1)
+	assert.ErrorIs(t, err, s4.ErrNotFound, "version 1 is pruned")
+
+	// versions 2 and 3 expire, only the current version remains
+	count, err := orm.DeleteExpired(ctx, 10, time.UnixMilli(expiration+4))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	rows, err = orm.History(ctx, address, 1, 10)
+	assert.NoError(t, err)
+	assert.Len(t, rows, 1)
+	_, err = orm.GetVersion(ctx, address, 1, 3)
+	assert.ErrorIs(t, err, s4.ErrNotFound)
+}
+
+func TestInMemoryORM_AddHistory(t *testing.T) {
+	t.Parallel()
+	ctx := testutils.Context(t)
+
+	orm := s4.NewInMemoryORM(2)
+	address := big.New(testutils.NewAddress().Big())
+	expiration := time.Now().Add(time.Minute).UnixMilli()
+	row := func(version uint64) *s4.Row {
+		return &s4.Row{Address: address, SlotId: 1, Payload: []byte{byte(version)}, Version: version, Expiration: expiration, Confirmed: true, Signature: []byte{}}
+	}
+
+	// no current version to retain history for
+	assert.NoError(t, orm.AddHistory(ctx, row(1)))
+	_, err := orm.Get(ctx, address, 1)
+	assert.ErrorIs(t, err, s4.ErrNotFound)
+
+	assert.NoError(t, orm.Update(ctx, row(5)))
+	for _, version := range []uint64{2, 4, 4, 5, 6, 1} {
+		assert.NoError(t, orm.AddHistory(ctx, row(version)))
+	}
+
+	rows, err := orm.History(ctx, address, 1, 10)
+	assert.NoError(t, err)
+	var versions []uint64
+	for _, row := range rows {
+		versions = append(versions, row.Version)
+	}
+	// versions not lower than the current one are ignored, the oldest versions are pruned
+	assert.Equal(t, []uint64{5, 4, 2}, versions)
+}
@@ -0,0 +1,100 @@
+package s4
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/smartcontractkit/chainlink/v2/evm/utils/big"
+)
+
+type inMemoryQuotaOrm struct {
+	quotas map[string]*Quota
+	mu     sync.RWMutex
+}
+
+var _ QuotaORM = (*inMemoryQuotaOrm)(nil)
+
+func NewInMemoryQuotaORM() QuotaORM {
+	return &inMemoryQuotaOrm{
+		quotas: make(map[string]*Quota),
+	}
+}
+
+func (o *inMemoryQuotaOrm) GetQuota(ctx context.Context, address *big.Big) (*Quota, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	quota, ok := o.quotas[address.Hex()]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return quota.Clone(), nil
+}
+
+func (o *inMemoryQuotaOrm) AddUsage(ctx context.Context, address *big.Big, periodStart int64, bytes, maxBytes, maxWrites uint64) (*Quota, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	quota, ok := o.quotas[address.Hex()]
+	if !ok || quota.PeriodStart < periodStart {
+		quota = &Quota{
+			Address:     big.New(address.ToInt()),
+			PeriodStart: periodStart,
+		}
+	}
+	if maxBytes > 0 && quota.Bytes+bytes > maxBytes {
+		return nil, ErrQuotaExceeded
+	}
+	if maxWrites > 0 && quota.Writes+1 > maxWrites {
+		return nil, ErrQuotaExceeded
+	}
+	quota.Bytes += bytes
+	quota.Writes++
+	o.quotas[address.Hex()] = quota
+	return quota.Clone(), nil
+}
+
+func (o *inMemoryQuotaOrm) ListQuotas(ctx context.Context) ([]*Quota, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	quotas := make([]*Quota, 0, len(o.quotas))
+	for _, quota := range o.quotas {
+		quotas = append(quotas, quota.Clone())
+	}
+	sort.Slice(quotas, func(i, j int) bool {
+		return quotas[i].Address.Cmp(quotas[j].Address) < 0
+	})
+	return quotas, nil
+}
+
+func (o *inMemoryQuotaOrm) ResetQuota(ctx context.Context, address *big.Big) (int64, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if address == nil {
+		count := len(o.quotas)
+		o.quotas = make(map[string]*Quota)
+		return int64(count), nil
+	}
+	if _, ok := o.quotas[address.Hex()]; !ok {
+		return 0, nil
+	}
+	delete(o.quotas, address.Hex())
+	return 1, nil
+}
+
+func (o *inMemoryQuotaOrm) DeleteStaleQuotas(ctx context.Context, periodStart int64) (int64, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var deleted int64
+	for k, quota := range o.quotas {
+		if quota.PeriodStart < periodStart {
+			delete(o.quotas, k)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
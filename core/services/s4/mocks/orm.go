@@ -27,6 +27,53 @@ func (_m *ORM) EXPECT() *ORM_Expecter {
 	return &ORM_Expecter{mock: &_m.Mock}
 }
 
+// AddHistory provides a mock function with given fields: ctx, row
+func (_m *ORM) AddHistory(ctx context.Context, row *s4.Row) error {
+	ret := _m.Called(ctx, row)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddHistory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *s4.Row) error); ok {
+		r0 = rf(ctx, row)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ORM_AddHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddHistory'
+type ORM_AddHistory_Call struct {
+	*mock.Call
+}
+
+// AddHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - row *s4.Row
+func (_e *ORM_Expecter) AddHistory(ctx interface{}, row interface{}) *ORM_AddHistory_Call {
+	return &ORM_AddHistory_Call{Call: _e.mock.On("AddHistory", ctx, row)}
+}
+
+func (_c *ORM_AddHistory_Call) Run(run func(ctx context.Context, row *s4.Row)) *ORM_AddHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*s4.Row))
+	})
+	return _c
+}
+
+func (_c *ORM_AddHistory_Call) Return(_a0 error) *ORM_AddHistory_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ORM_AddHistory_Call) RunAndReturn(run func(context.Context, *s4.Row) error) *ORM_AddHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteExpired provides a mock function with given fields: ctx, limit, utcNow
 func (_m *ORM) DeleteExpired(ctx context.Context, limit uint, utcNow time.Time) (int64, error) {
 	ret := _m.Called(ctx, limit, utcNow)
@@ -145,6 +192,66 @@ func (_c *ORM_Get_Call) RunAndReturn(run func(context.Context, *big.Big, uint) (
 	return _c
 }
 
+// GetHistory provides a mock function with given fields: ctx, keys, limit
+func (_m *ORM) GetHistory(ctx context.Context, keys []s4.HistoryKey, limit uint) ([]*s4.Row, error) {
+	ret := _m.Called(ctx, keys, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHistory")
+	}
+
+	var r0 []*s4.Row
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []s4.HistoryKey, uint) ([]*s4.Row, error)); ok {
+		return rf(ctx, keys, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []s4.HistoryKey, uint) []*s4.Row); ok {
+		r0 = rf(ctx, keys, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*s4.Row)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []s4.HistoryKey, uint) error); ok {
+		r1 = rf(ctx, keys, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ORM_GetHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetHistory'
+type ORM_GetHistory_Call struct {
+	*mock.Call
+}
+
+// GetHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - keys []s4.HistoryKey
+//   - limit uint
+func (_e *ORM_Expecter) GetHistory(ctx interface{}, keys interface{}, limit interface{}) *ORM_GetHistory_Call {
+	return &ORM_GetHistory_Call{Call: _e.mock.On("GetHistory", ctx, keys, limit)}
+}
+
+func (_c *ORM_GetHistory_Call) Run(run func(ctx context.Context, keys []s4.HistoryKey, limit uint)) *ORM_GetHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]s4.HistoryKey), args[2].(uint))
+	})
+	return _c
+}
+
+func (_c *ORM_GetHistory_Call) Return(_a0 []*s4.Row, _a1 error) *ORM_GetHistory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ORM_GetHistory_Call) RunAndReturn(run func(context.Context, []s4.HistoryKey, uint) ([]*s4.Row, error)) *ORM_GetHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetSnapshot provides a mock function with given fields: ctx, addressRange
 func (_m *ORM) GetSnapshot(ctx context.Context, addressRange *s4.AddressRange) ([]*s4.SnapshotRow, error) {
 	ret := _m.Called(ctx, addressRange)
@@ -263,6 +370,128 @@ func (_c *ORM_GetUnconfirmedRows_Call) RunAndReturn(run func(context.Context, ui
 	return _c
 }
 
+// GetVersion provides a mock function with given fields: ctx, address, slotId, version
+func (_m *ORM) GetVersion(ctx context.Context, address *big.Big, slotId uint, version uint64) (*s4.Row, error) {
+	ret := _m.Called(ctx, address, slotId, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetVersion")
+	}
+
+	var r0 *s4.Row
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *big.Big, uint, uint64) (*s4.Row, error)); ok {
+		return rf(ctx, address, slotId, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *big.Big, uint, uint64) *s4.Row); ok {
+		r0 = rf(ctx, address, slotId, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*s4.Row)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *big.Big, uint, uint64) error); ok {
+		r1 = rf(ctx, address, slotId, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ORM_GetVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetVersion'
+type ORM_GetVersion_Call struct {
+	*mock.Call
+}
+
+// GetVersion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - address *big.Big
+//   - slotId uint
+//   - version uint64
+func (_e *ORM_Expecter) GetVersion(ctx interface{}, address interface{}, slotId interface{}, version interface{}) *ORM_GetVersion_Call {
+	return &ORM_GetVersion_Call{Call: _e.mock.On("GetVersion", ctx, address, slotId, version)}
+}
+
+func (_c *ORM_GetVersion_Call) Run(run func(ctx context.Context, address *big.Big, slotId uint, version uint64)) *ORM_GetVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*big.Big), args[2].(uint), args[3].(uint64))
+	})
+	return _c
+}
+
+func (_c *ORM_GetVersion_Call) Return(_a0 *s4.Row, _a1 error) *ORM_GetVersion_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ORM_GetVersion_Call) RunAndReturn(run func(context.Context, *big.Big, uint, uint64) (*s4.Row, error)) *ORM_GetVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// History provides a mock function with given fields: ctx, address, slotId, limit
+func (_m *ORM) History(ctx context.Context, address *big.Big, slotId uint, limit uint) ([]*s4.Row, error) {
+	ret := _m.Called(ctx, address, slotId, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for History")
+	}
+
+	var r0 []*s4.Row
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *big.Big, uint, uint) ([]*s4.Row, error)); ok {
+		return rf(ctx, address, slotId, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *big.Big, uint, uint) []*s4.Row); ok {
+		r0 = rf(ctx, address, slotId, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*s4.Row)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *big.Big, uint, uint) error); ok {
+		r1 = rf(ctx, address, slotId, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ORM_History_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'History'
+type ORM_History_Call struct {
+	*mock.Call
+}
+
+// History is a helper method to define mock.On call
+//   - ctx context.Context
+//   - address *big.Big
+//   - slotId uint
+//   - limit uint
+func (_e *ORM_Expecter) History(ctx interface{}, address interface{}, slotId interface{}, limit interface{}) *ORM_History_Call {
+	return &ORM_History_Call{Call: _e.mock.On("History", ctx, address, slotId, limit)}
+}
+
+func (_c *ORM_History_Call) Run(run func(ctx context.Context, address *big.Big, slotId uint, limit uint)) *ORM_History_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*big.Big), args[2].(uint), args[3].(uint))
+	})
+	return _c
+}
+
+func (_c *ORM_History_Call) Return(_a0 []*s4.Row, _a1 error) *ORM_History_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ORM_History_Call) RunAndReturn(run func(context.Context, *big.Big, uint, uint) ([]*s4.Row, error)) *ORM_History_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function with given fields: ctx, row
 func (_m *ORM) Update(ctx context.Context, row *s4.Row) error {
 	ret := _m.Called(ctx, row)
@@ -138,6 +138,67 @@ func (_c *Storage_Get_Call) RunAndReturn(run func(context.Context, *s4.Key) (*s4
 	return _c
 }
 
+// History provides a mock function with given fields: ctx, address, slotId, limit
+func (_m *Storage) History(ctx context.Context, address common.Address, slotId uint, limit uint) ([]*s4.VersionedRecord, error) {
+	ret := _m.Called(ctx, address, slotId, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for History")
+	}
+
+	var r0 []*s4.VersionedRecord
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, uint, uint) ([]*s4.VersionedRecord, error)); ok {
+		return rf(ctx, address, slotId, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, uint, uint) []*s4.VersionedRecord); ok {
+		r0 = rf(ctx, address, slotId, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*s4.VersionedRecord)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Address, uint, uint) error); ok {
+		r1 = rf(ctx, address, slotId, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storage_History_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'History'
+type Storage_History_Call struct {
+	*mock.Call
+}
+
+// History is a helper method to define mock.On call
+//   - ctx context.Context
+//   - address common.Address
+//   - slotId uint
+//   - limit uint
+func (_e *Storage_Expecter) History(ctx interface{}, address interface{}, slotId interface{}, limit interface{}) *Storage_History_Call {
+	return &Storage_History_Call{Call: _e.mock.On("History", ctx, address, slotId, limit)}
+}
+
+func (_c *Storage_History_Call) Run(run func(ctx context.Context, address common.Address, slotId uint, limit uint)) *Storage_History_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Address), args[2].(uint), args[3].(uint))
+	})
+	return _c
+}
+
+func (_c *Storage_History_Call) Return(_a0 []*s4.VersionedRecord, _a1 error) *Storage_History_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Storage_History_Call) RunAndReturn(run func(context.Context, common.Address, uint, uint) ([]*s4.VersionedRecord, error)) *Storage_History_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // List provides a mock function with given fields: ctx, address
 func (_m *Storage) List(ctx context.Context, address common.Address) ([]*s4.SnapshotRow, error) {
 	ret := _m.Called(ctx, address)
@@ -28,6 +28,14 @@ type SnapshotRow struct {
 	PayloadSize uint64
 }
 
+// HistoryKey selects the versions retained in the history of the row identified by (Address, SlotId),
+// which are greater than AfterVersion.
+type HistoryKey struct {
+	Address      *big.Big
+	SlotId       uint
+	AfterVersion uint64
+}
+
 // ORM represents S4 persistence layer.
 // All functions are thread-safe.
 type ORM interface {
@@ -36,14 +44,37 @@ type ORM interface {
 	// There is no filter on Expiration.
 	Get(ctx context.Context, address *big.Big, slotId uint) (*Row, error)
 
+	// GetVersion reads the given version of a row for the given address and slotId combination,
+	// which is either the current row or one of the versions retained in the history.
+	// If such version does not exist, ErrNotFound is returned.
+	// There is no filter on Expiration.
+	GetVersion(ctx context.Context, address *big.Big, slotId uint, version uint64) (*Row, error)
+
+	// History reads up to limit most recent versions of a row for the given address and slotId combination,
+	// including the current row, ordered by descending version.
+	// There is no filter on Expiration.
+	History(ctx context.Context, address *big.Big, slotId uint, limit uint) ([]*Row, error)
+
+	// GetHistory reads up to limit versions retained in the history of the rows selected by keys,
+	// excluding the current rows, ordered by key and ascending version.
+	// There is no filter on Expiration.
+	GetHistory(ctx context.Context, keys []HistoryKey, limit uint) ([]*Row, error)
+
 	// Update inserts or updates the row identified by (Address, SlotId) pair.
 	// When updating, the new row must have greater or equal version,
 	// otherwise ErrVersionTooLow is returned.
+	// When the version increases, the replaced row is retained in the history,
+	// which keeps at most the configured number of versions per row.
 	// UpdatedAt field value is ignored.
 	Update(ctx context.Context, row *Row) error
 
-	// DeleteExpired deletes any entries having Expiration < utcNow,
-	// up to the given limit.
+	// AddHistory retains a previous version of the row identified by (Address, SlotId), replicated from another node,
+	// if it is lower than the current version and not retained yet. Versions beyond the configured number of
+	// versions per row are pruned.
+	AddHistory(ctx context.Context, row *Row) error
+
+	// DeleteExpired deletes any entries, including the versions retained in the history,
+	// having Expiration < utcNow, up to the given limit.
 	// Returns the number of deleted rows.
 	DeleteExpired(ctx context.Context, limit uint, utcNow time.Time) (int64, error)
 
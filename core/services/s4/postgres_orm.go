@@ -4,8 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strconv"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
@@ -18,18 +20,24 @@ const (
 )
 
 type orm struct {
-	ds        sqlutil.DataSource
-	tableName string
-	namespace string
+	ds                 sqlutil.DataSource
+	tableName          string
+	historyTableName   string
+	namespace          string
+	maxHistoryVersions uint
 }
 
 var _ ORM = (*orm)(nil)
 
-func NewPostgresORM(ds sqlutil.DataSource, tableName, namespace string) ORM {
+// NewPostgresORM returns an ORM backed by the given table, retaining up to
+// maxHistoryVersions previous versions of every row in the <tableName>_history table.
+func NewPostgresORM(ds sqlutil.DataSource, tableName, namespace string, maxHistoryVersions uint) ORM {
 	return &orm{
-		ds:        ds,
-		tableName: fmt.Sprintf(`"%s".%s`, s4PostgresSchema, tableName),
-		namespace: namespace,
+		ds:                 ds,
+		tableName:          fmt.Sprintf(`"%s".%s`, s4PostgresSchema, tableName),
+		historyTableName:   fmt.Sprintf(`"%s".%s_history`, s4PostgresSchema, tableName),
+		namespace:          namespace,
+		maxHistoryVersions: maxHistoryVersions,
 	}
 }
 
@@ -47,7 +55,120 @@ WHERE namespace=$1 AND address=$2 AND slot_id=$3;`, o.tableName)
 	return row, nil
 }
 
+func (o *orm) GetVersion(ctx context.Context, address *big.Big, slotId uint, version uint64) (*Row, error) {
+	row := &Row{}
+
+	stmt := fmt.Sprintf(`SELECT address, slot_id, version, expiration, confirmed, payload, signature FROM %s
+WHERE namespace=$1 AND address=$2 AND slot_id=$3 AND version=$4
+UNION ALL
+SELECT address, slot_id, version, expiration, confirmed, payload, signature FROM %s
+WHERE namespace=$1 AND address=$2 AND slot_id=$3 AND version=$4
+LIMIT 1;`, o.tableName, o.historyTableName)
+	if err := o.ds.GetContext(ctx, row, stmt, o.namespace, address, slotId, version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = ErrNotFound
+		}
+		return nil, err
+	}
+	return row, nil
+}
+
+func (o *orm) History(ctx context.Context, address *big.Big, slotId uint, limit uint) ([]*Row, error) {
+	rows := make([]*Row, 0)
+
+	stmt := fmt.Sprintf(`SELECT address, slot_id, version, expiration, confirmed, payload, signature FROM %s
+WHERE namespace=$1 AND address=$2 AND slot_id=$3
+UNION ALL
+SELECT address, slot_id, version, expiration, confirmed, payload, signature FROM %s
+WHERE namespace=$1 AND address=$2 AND slot_id=$3
+ORDER BY version DESC LIMIT $4;`, o.tableName, o.historyTableName)
+	if err := o.ds.SelectContext(ctx, &rows, stmt, o.namespace, address, slotId, limit); err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+	}
+	return rows, nil
+}
+
+func (o *orm) GetHistory(ctx context.Context, keys []HistoryKey, limit uint) ([]*Row, error) {
+	rows := make([]*Row, 0)
+	if len(keys) == 0 || limit == 0 {
+		return rows, nil
+	}
+	addresses := make([]string, len(keys))
+	slotIDs := make([]int64, len(keys))
+	afterVersions := make([]string, len(keys))
+	for i, k := range keys {
+		addresses[i] = k.Address.String()
+		slotIDs[i] = int64(k.SlotId)
+		afterVersions[i] = strconv.FormatUint(k.AfterVersion, 10)
+	}
+
+	stmt := fmt.Sprintf(`SELECT h.address, h.slot_id, h.version, h.expiration, h.confirmed, h.payload, h.signature
+FROM unnest($2::numeric[], $3::int[], $4::numeric[]) WITH ORDINALITY AS k(address, slot_id, after_version, ord)
+JOIN %s h ON h.namespace=$1 AND h.address=k.address AND h.slot_id=k.slot_id AND h.version>k.after_version
+ORDER BY k.ord, h.version LIMIT $5;`, o.historyTableName)
+	if err := o.ds.SelectContext(ctx, &rows, stmt, o.namespace, pq.Array(addresses), pq.Array(slotIDs), pq.Array(afterVersions), limit); err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+	}
+	return rows, nil
+}
+
 func (o *orm) Update(ctx context.Context, row *Row) error {
+	if o.maxHistoryVersions == 0 {
+		return o.update(ctx, o.ds, row)
+	}
+	return sqlutil.TransactDataSource(ctx, o.ds, nil, func(tx sqlutil.DataSource) error {
+		// The replaced row is archived first, the transaction is rolled back if the update is rejected.
+		archiveStmt := fmt.Sprintf(`INSERT INTO %s (namespace, address, slot_id, version, expiration, confirmed, payload, signature, created_at)
+SELECT namespace, address, slot_id, version, expiration, confirmed, payload, signature, NOW() FROM %s
+WHERE namespace=$1 AND address=$2 AND slot_id=$3 AND version<$4 FOR UPDATE
+ON CONFLICT (namespace, address, slot_id, version)
+DO UPDATE SET expiration = EXCLUDED.expiration,
+confirmed = EXCLUDED.confirmed,
+payload = EXCLUDED.payload,
+signature = EXCLUDED.signature,
+created_at = EXCLUDED.created_at;`, o.historyTableName, o.tableName)
+		if _, err := tx.ExecContext(ctx, archiveStmt, o.namespace, row.Address, row.SlotId, row.Version); err != nil {
+			return errors.Wrap(err, "failed to archive row version")
+		}
+
+		if err := o.update(ctx, tx, row); err != nil {
+			return err
+		}
+
+		return o.pruneHistory(ctx, tx, row)
+	})
+}
+
+func (o *orm) AddHistory(ctx context.Context, row *Row) error {
+	if o.maxHistoryVersions == 0 {
+		return nil
+	}
+	return sqlutil.TransactDataSource(ctx, o.ds, nil, func(tx sqlutil.DataSource) error {
+		stmt := fmt.Sprintf(`INSERT INTO %s (namespace, address, slot_id, version, expiration, confirmed, payload, signature, created_at)
+SELECT $1, $2, $3, $4, $5, $6, $7, $8, NOW()
+WHERE EXISTS (SELECT 1 FROM %s WHERE namespace=$1 AND address=$2 AND slot_id=$3 AND version>$4)
+ON CONFLICT (namespace, address, slot_id, version) DO NOTHING;`, o.historyTableName, o.tableName)
+		if _, err := tx.ExecContext(ctx, stmt, o.namespace, row.Address, row.SlotId, row.Version, row.Expiration, row.Confirmed, row.Payload, row.Signature); err != nil {
+			return errors.Wrap(err, "failed to add row version")
+		}
+		return o.pruneHistory(ctx, tx, row)
+	})
+}
+
+func (o *orm) pruneHistory(ctx context.Context, ds sqlutil.DataSource, row *Row) error {
+	pruneStmt := fmt.Sprintf(`DELETE FROM %[1]s WHERE namespace=$1 AND address=$2 AND slot_id=$3 AND version NOT IN
+(SELECT version FROM %[1]s WHERE namespace=$1 AND address=$2 AND slot_id=$3 ORDER BY version DESC LIMIT $4);`, o.historyTableName)
+	if _, err := ds.ExecContext(ctx, pruneStmt, o.namespace, row.Address, row.SlotId, o.maxHistoryVersions); err != nil {
+		return errors.Wrap(err, "failed to prune row history")
+	}
+	return nil
+}
+
+func (o *orm) update(ctx context.Context, ds sqlutil.DataSource, row *Row) error {
 	// This query inserts or updates a row, depending on whether the version is higher than the existing one.
 	// We only allow the same version when the row is confirmed.
 	// We never transition back from unconfirmed to confirmed state.
@@ -63,7 +184,7 @@ updated_at = NOW()
 WHERE (t.version < EXCLUDED.version) OR (t.version <= EXCLUDED.version AND EXCLUDED.confirmed IS TRUE)
 RETURNING id;`, o.tableName)
 	var id uint64
-	err := o.ds.GetContext(ctx, &id, stmt, o.namespace, row.Address, row.SlotId, row.Version, row.Expiration, row.Confirmed, row.Payload, row.Signature)
+	err := ds.GetContext(ctx, &id, stmt, o.namespace, row.Address, row.SlotId, row.Version, row.Expiration, row.Confirmed, row.Payload, row.Signature)
 	if errors.Is(err, sql.ErrNoRows) {
 		return ErrVersionTooLow
 	}
@@ -77,7 +198,19 @@ func (o *orm) DeleteExpired(ctx context.Context, limit uint, utcNow time.Time) (
 	if err != nil {
 		return 0, err
 	}
-	return result.RowsAffected()
+	deleted, err := result.RowsAffected()
+	if err != nil || deleted >= int64(limit) {
+		return deleted, err
+	}
+
+	with = fmt.Sprintf(`WITH rows AS (SELECT id FROM %s WHERE namespace = $1 AND expiration < $2 LIMIT $3)`, o.historyTableName)
+	stmt = fmt.Sprintf(`%s DELETE FROM %s WHERE id IN (SELECT id FROM rows);`, with, o.historyTableName)
+	result, err = o.ds.ExecContext(ctx, stmt, o.namespace, utcNow.UnixMilli(), int64(limit)-deleted)
+	if err != nil {
+		return deleted, err
+	}
+	deletedHistory, err := result.RowsAffected()
+	return deleted + deletedHistory, err
 }
 
 func (o *orm) GetSnapshot(ctx context.Context, addressRange *AddressRange) ([]*SnapshotRow, error) {
@@ -13,13 +13,14 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/evm/utils/big"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func setupORM(t *testing.T, namespace string) s4.ORM {
 	t.Helper()
 
 	db := pgtest.NewSqlxDB(t)
-	orm := s4.NewPostgresORM(db, s4.SharedTableName, namespace)
+	orm := s4.NewPostgresORM(db, s4.SharedTableName, namespace, 0)
 
 	t.Cleanup(func() {
 		assert.NoError(t, db.Close())
@@ -285,3 +286,117 @@ func TestPostgresORM_BigIntVersion(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, row, gotRow)
 }
+
+func TestPostgresORM_History(t *testing.T) {
+	t.Parallel()
+	ctx := testutils.Context(t)
+
+	db := pgtest.NewSqlxDB(t)
+	orm := s4.NewPostgresORM(db, s4.SharedTableName, "test", 2)
+	address := big.New(testutils.NewAddress().Big())
+	expiration := time.Now().Add(time.Hour).UnixMilli()
+
+	for version := uint64(1); version <= 4; version++ {
+		err := orm.Update(ctx, &s4.Row{
+			Address:    address,
+			SlotId:     1,
+			Payload:    []byte{byte(version)},
+			Version:    version,
+			Expiration: expiration + int64(version),
+			Signature:  []byte{},
+		})
+		assert.NoError(t, err)
+	}
+	// rejected updates are not added to the history
+	err := orm.Update(ctx, &s4.Row{Address: address, SlotId: 1, Payload: []byte{3}, Version: 3, Expiration: expiration, Signature: []byte{}})
+	assert.ErrorIs(t, err, s4.ErrVersionTooLow)
+	// confirming the current version does not add to the history
+	err = orm.Update(ctx, &s4.Row{Address: address, SlotId: 1, Payload: []byte{4}, Version: 4, Expiration: expiration + 4, Confirmed: true, Signature: []byte{}})
+	assert.NoError(t, err)
+
+	rows, err := orm.History(ctx, address, 1, 10)
+	assert.NoError(t, err)
+	var versions []uint64
+	for _, row := range rows {
+		versions = append(versions, row.Version)
+		assert.Equal(t, []byte{byte(row.Version)}, row.Payload)
+	}
+	assert.Equal(t, []uint64{4, 3, 2}, versions)
+	assert.True(t, rows[0].Confirmed)
+
+	rows, err = orm.History(ctx, address, 1, 2)
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+
+	// GetHistory excludes the current rows, and returns the versions after AfterVersion in ascending order
+	rows, err = orm.GetHistory(ctx, []s4.HistoryKey{{Address: address, SlotId: 1}, {Address: address, SlotId: 2}}, 10)
+	assert.NoError(t, err)
+	versions = nil
+	for _, row := range rows {
+		versions = append(versions, row.Version)
+	}
+	assert.Equal(t, []uint64{2, 3}, versions)
+
+	rows, err = orm.GetHistory(ctx, []s4.HistoryKey{{Address: address, SlotId: 1, AfterVersion: 2}}, 10)
+	assert.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, uint64(3), rows[0].Version)
+
+	rows, err = orm.GetHistory(ctx, []s4.HistoryKey{{Address: address, SlotId: 1}}, 1)
+	assert.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, uint64(2), rows[0].Version)
+
+	row, err := orm.GetVersion(ctx, address, 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{2}, row.Payload)
+
+	row, err = orm.GetVersion(ctx, address, 1, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{4}, row.Payload)
+
+	_, err = orm.GetVersion(ctx, address, 1, 1)
+	assert.ErrorIs(t, err, s4.ErrNotFound, "version 1 is pruned")
+
+	// versions 2 and 3 expire, only the current version remains
+	count, err := orm.DeleteExpired(ctx, 10, time.UnixMilli(expiration+4))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	rows, err = orm.History(ctx, address, 1, 10)
+	assert.NoError(t, err)
+	assert.Len(t, rows, 1)
+}
+
+func TestPostgresORM_AddHistory(t *testing.T) {
+	t.Parallel()
+	ctx := testutils.Context(t)
+
+	db := pgtest.NewSqlxDB(t)
+	orm := s4.NewPostgresORM(db, s4.SharedTableName, "test", 2)
+	address := big.New(testutils.NewAddress().Big())
+	expiration := time.Now().Add(time.Hour).UnixMilli()
+	row := func(version uint64) *s4.Row {
+		return &s4.Row{Address: address, SlotId: 1, Payload: []byte{byte(version)}, Version: version, Expiration: expiration, Confirmed: true, Signature: []byte{}}
+	}
+
+	// no current version to retain history for
+	assert.NoError(t, orm.AddHistory(ctx, row(1)))
+	_, err := orm.GetVersion(ctx, address, 1, 1)
+	assert.ErrorIs(t, err, s4.ErrNotFound)
+
+	assert.NoError(t, orm.Update(ctx, row(5)))
+	for _, version := range []uint64{2, 4, 4, 5, 6, 1} {
+		assert.NoError(t, orm.AddHistory(ctx, row(version)))
+	}
+
+	rows, err := orm.History(ctx, address, 1, 10)
+	assert.NoError(t, err)
+	var versions []uint64
+	for _, row := range rows {
+		versions = append(versions, row.Version)
+		assert.Equal(t, []byte{byte(row.Version)}, row.Payload)
+	}
+	// versions not lower than the current one are ignored, the oldest versions are pruned
+	assert.Equal(t, []uint64{5, 4, 2}, versions)
+}
@@ -0,0 +1,107 @@
+package s4
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+	"github.com/smartcontractkit/chainlink/v2/evm/utils/big"
+)
+
+type quotaOrm struct {
+	ds        sqlutil.DataSource
+	tableName string
+	namespace string
+}
+
+var _ QuotaORM = (*quotaOrm)(nil)
+
+// NewPostgresQuotaORM returns a QuotaORM accounting the usage of the given table in the <tableName>_quotas table.
+func NewPostgresQuotaORM(ds sqlutil.DataSource, tableName, namespace string) QuotaORM {
+	return &quotaOrm{
+		ds:        ds,
+		tableName: fmt.Sprintf(`"%s".%s_quotas`, s4PostgresSchema, tableName),
+		namespace: namespace,
+	}
+}
+
+func (o *quotaOrm) GetQuota(ctx context.Context, address *big.Big) (*Quota, error) {
+	quota := &Quota{}
+
+	stmt := fmt.Sprintf(`SELECT address, period_start, bytes, writes FROM %s WHERE namespace=$1 AND address=$2;`, o.tableName)
+	if err := o.ds.GetContext(ctx, quota, stmt, o.namespace, address); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = ErrNotFound
+		}
+		return nil, err
+	}
+	return quota, nil
+}
+
+func (o *quotaOrm) AddUsage(ctx context.Context, address *big.Big, periodStart int64, bytes, maxBytes, maxWrites uint64) (*Quota, error) {
+	if maxBytes > 0 && bytes > maxBytes {
+		return nil, ErrQuotaExceeded
+	}
+	quota := &Quota{}
+
+	// The usage of an earlier period is replaced, as the quota is only enforced over the current period.
+	// The row is not updated (and nothing is returned) if the write would exceed a limit.
+	stmt := fmt.Sprintf(`INSERT INTO %s as t (namespace, address, period_start, bytes, writes, updated_at)
+VALUES ($1, $2, $3, $4, 1, NOW())
+ON CONFLICT (namespace, address)
+DO UPDATE SET
+period_start = GREATEST(t.period_start, EXCLUDED.period_start),
+bytes = CASE WHEN t.period_start < EXCLUDED.period_start THEN EXCLUDED.bytes ELSE t.bytes + EXCLUDED.bytes END,
+writes = CASE WHEN t.period_start < EXCLUDED.period_start THEN 1 ELSE t.writes + 1 END,
+updated_at = NOW()
+WHERE ($5 = 0 OR t.period_start < EXCLUDED.period_start OR t.bytes + EXCLUDED.bytes <= $5)
+AND ($6 = 0 OR t.period_start < EXCLUDED.period_start OR t.writes + 1 <= $6)
+RETURNING address, period_start, bytes, writes;`, o.tableName)
+	if err := o.ds.GetContext(ctx, quota, stmt, o.namespace, address, periodStart, bytes, maxBytes, maxWrites); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = ErrQuotaExceeded
+		}
+		return nil, err
+	}
+	return quota, nil
+}
+
+func (o *quotaOrm) ListQuotas(ctx context.Context) ([]*Quota, error) {
+	quotas := make([]*Quota, 0)
+
+	stmt := fmt.Sprintf(`SELECT address, period_start, bytes, writes FROM %s WHERE namespace=$1 ORDER BY address;`, o.tableName)
+	if err := o.ds.SelectContext(ctx, &quotas, stmt, o.namespace); err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+	}
+	return quotas, nil
+}
+
+func (o *quotaOrm) ResetQuota(ctx context.Context, address *big.Big) (int64, error) {
+	var result sql.Result
+	var err error
+	if address == nil {
+		stmt := fmt.Sprintf(`DELETE FROM %s WHERE namespace=$1;`, o.tableName)
+		result, err = o.ds.ExecContext(ctx, stmt, o.namespace)
+	} else {
+		stmt := fmt.Sprintf(`DELETE FROM %s WHERE namespace=$1 AND address=$2;`, o.tableName)
+		result, err = o.ds.ExecContext(ctx, stmt, o.namespace, address)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (o *quotaOrm) DeleteStaleQuotas(ctx context.Context, periodStart int64) (int64, error) {
+	stmt := fmt.Sprintf(`DELETE FROM %s WHERE namespace=$1 AND period_start < $2;`, o.tableName)
+	result, err := o.ds.ExecContext(ctx, stmt, o.namespace, periodStart)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
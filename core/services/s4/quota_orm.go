@@ -0,0 +1,59 @@
+package s4
+
+import (
+	"context"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/v2/evm/utils/big"
+)
+
+// Quota is the usage accounted to an address during a quota period.
+type Quota struct {
+	Address *big.Big
+	// PeriodStart is the start of the quota period (unix time in milliseconds).
+	PeriodStart int64
+	// Bytes is the total payload size written during the period.
+	Bytes uint64
+	// Writes is the number of writes during the period.
+	Writes uint64
+}
+
+// QuotaORM represents the persistence layer of S4 quotas.
+// All functions are thread-safe.
+type QuotaORM interface {
+	// GetQuota reads the quota of the given address.
+	// If the address has no usage, ErrNotFound is returned.
+	// The returned quota may belong to a past period.
+	GetQuota(ctx context.Context, address *big.Big) (*Quota, error)
+
+	// AddUsage accounts a write of the given size to the address in the period starting at periodStart,
+	// resetting the usage of any earlier period. Returns the updated quota.
+	// If the write would exceed maxBytes or maxWrites in the period, nothing is accounted and ErrQuotaExceeded
+	// is returned. The check and the accounting are atomic. Zero limits are not enforced.
+	AddUsage(ctx context.Context, address *big.Big, periodStart int64, bytes, maxBytes, maxWrites uint64) (*Quota, error)
+
+	// ListQuotas selects the quotas of all addresses, ordered by address.
+	ListQuotas(ctx context.Context) ([]*Quota, error)
+
+	// ResetQuota deletes the quota of the given address, or of all addresses if address is nil.
+	// Returns the number of deleted quotas.
+	ResetQuota(ctx context.Context, address *big.Big) (int64, error)
+
+	// DeleteStaleQuotas deletes the quotas of periods starting before periodStart.
+	// Returns the number of deleted quotas.
+	DeleteStaleQuotas(ctx context.Context, periodStart int64) (int64, error)
+}
+
+// QuotaPeriodStart returns the start of the fixed-length quota period containing now (unix time in milliseconds).
+func QuotaPeriodStart(now time.Time, period time.Duration) int64 {
+	if period <= 0 {
+		return 0
+	}
+	return now.Truncate(period).UnixMilli()
+}
+
+func (q Quota) Clone() *Quota {
+	clone := q
+	clone.Address = big.New(q.Address.ToInt())
+	return &clone
+}
@@ -0,0 +1,123 @@
+package s4_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/v2/core/services/s4"
+	"github.com/smartcontractkit/chainlink/v2/evm/utils/big"
+)
+
+func testQuotaORM(t *testing.T, orm s4.QuotaORM) {
+	ctx := testutils.Context(t)
+	address1 := big.New(testutils.NewAddress().Big())
+	address2 := big.New(testutils.NewAddress().Big())
+
+	_, err := orm.GetQuota(ctx, address1)
+	require.ErrorIs(t, err, s4.ErrNotFound)
+
+	quota, err := orm.AddUsage(ctx, address1, 1000, 10, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, &s4.Quota{Address: address1, PeriodStart: 1000, Bytes: 10, Writes: 1}, quota)
+	quota, err = orm.AddUsage(ctx, address1, 1000, 5, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, &s4.Quota{Address: address1, PeriodStart: 1000, Bytes: 15, Writes: 2}, quota)
+
+	// a new period resets the usage
+	quota, err = orm.AddUsage(ctx, address1, 2000, 7, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, &s4.Quota{Address: address1, PeriodStart: 2000, Bytes: 7, Writes: 1}, quota)
+	// a late write of an earlier period is accounted to the current one
+	quota, err = orm.AddUsage(ctx, address1, 1000, 1, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, &s4.Quota{Address: address1, PeriodStart: 2000, Bytes: 8, Writes: 2}, quota)
+
+	_, err = orm.AddUsage(ctx, address2, 1000, 3, 0, 0)
+	require.NoError(t, err)
+
+	quota, err = orm.GetQuota(ctx, address1)
+	require.NoError(t, err)
+	assert.Equal(t, &s4.Quota{Address: address1, PeriodStart: 2000, Bytes: 8, Writes: 2}, quota)
+
+	// writes exceeding a limit are not accounted
+	_, err = orm.AddUsage(ctx, address1, 2000, 3, 10, 0)
+	require.ErrorIs(t, err, s4.ErrQuotaExceeded)
+	_, err = orm.AddUsage(ctx, address1, 2000, 1, 0, 2)
+	require.ErrorIs(t, err, s4.ErrQuotaExceeded)
+	_, err = orm.AddUsage(ctx, address2, 1000, 11, 10, 0)
+	require.ErrorIs(t, err, s4.ErrQuotaExceeded)
+	quota, err = orm.AddUsage(ctx, address1, 2000, 2, 10, 3)
+	require.NoError(t, err)
+	assert.Equal(t, &s4.Quota{Address: address1, PeriodStart: 2000, Bytes: 10, Writes: 3}, quota)
+	// the limits apply to the usage of the current period
+	quota, err = orm.AddUsage(ctx, address1, 3000, 10, 10, 1)
+	require.NoError(t, err)
+	assert.Equal(t, &s4.Quota{Address: address1, PeriodStart: 3000, Bytes: 10, Writes: 1}, quota)
+	quota, err = orm.AddUsage(ctx, address1, 2000, 0, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, &s4.Quota{Address: address1, PeriodStart: 3000, Bytes: 10, Writes: 2}, quota)
+
+	quotas, err := orm.ListQuotas(ctx)
+	require.NoError(t, err)
+	require.Len(t, quotas, 2)
+	assert.True(t, quotas[0].Address.Cmp(quotas[1].Address) < 0)
+
+	count, err := orm.DeleteStaleQuotas(ctx, 2000)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	_, err = orm.GetQuota(ctx, address2)
+	require.ErrorIs(t, err, s4.ErrNotFound)
+
+	count, err = orm.ResetQuota(ctx, address2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+	count, err = orm.ResetQuota(ctx, address1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	for i := 0; i < 3; i++ {
+		_, err = orm.AddUsage(ctx, big.New(testutils.NewAddress().Big()), 2000, 1, 0, 0)
+		require.NoError(t, err)
+	}
+	count, err = orm.ResetQuota(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+	quotas, err = orm.ListQuotas(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, quotas)
+}
+
+func TestInMemoryQuotaORM(t *testing.T) {
+	t.Parallel()
+
+	testQuotaORM(t, s4.NewInMemoryQuotaORM())
+}
+
+func TestPostgresQuotaORM(t *testing.T) {
+	t.Parallel()
+
+	db := pgtest.NewSqlxDB(t)
+	testQuotaORM(t, s4.NewPostgresQuotaORM(db, s4.SharedTableName, "test"))
+
+	t.Run("namespaces are isolated", func(t *testing.T) {
+		ctx := testutils.Context(t)
+		address := big.New(testutils.NewAddress().Big())
+		_, err := s4.NewPostgresQuotaORM(db, s4.SharedTableName, "other").AddUsage(ctx, address, 1000, 1, 0, 0)
+		require.NoError(t, err)
+		_, err = s4.NewPostgresQuotaORM(db, s4.SharedTableName, "test").GetQuota(ctx, address)
+		require.ErrorIs(t, err, s4.ErrNotFound)
+	})
+}
+
+func TestQuotaPeriodStart(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 12, 34, 56, 0, time.UTC)
+	assert.Equal(t, time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC).UnixMilli(), s4.QuotaPeriodStart(now, time.Hour))
+	assert.Equal(t, int64(0), s4.QuotaPeriodStart(now, 0))
+}
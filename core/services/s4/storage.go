@@ -2,6 +2,7 @@ package s4
 
 import (
 	"context"
+	"time"
 
 	"github.com/jonboulle/clockwork"
 
@@ -16,6 +17,14 @@ type Constraints struct {
 	MaxPayloadSizeBytes    uint   `json:"maxPayloadSizeBytes"`
 	MaxSlotsPerUser        uint   `json:"maxSlotsPerUser"`
 	MaxExpirationLengthSec uint64 `json:"maxExpirationLengthSec"`
+	// MaxHistoryVersions is the number of previous versions retained for every slot.
+	MaxHistoryVersions uint `json:"maxHistoryVersions"`
+	// QuotaPeriodSec is the length of the periods over which quotas are enforced, 0 disables quotas.
+	QuotaPeriodSec uint64 `json:"quotaPeriodSec"`
+	// MaxBytesPerPeriod limits the payload bytes written by an address per quota period, 0 means unlimited.
+	MaxBytesPerPeriod uint64 `json:"maxBytesPerPeriod"`
+	// MaxWritesPerPeriod limits the number of writes by an address per quota period, 0 means unlimited.
+	MaxWritesPerPeriod uint64 `json:"maxWritesPerPeriod"`
 }
 
 // Key identifies a versioned user record.
@@ -44,6 +53,13 @@ type Metadata struct {
 	Signature []byte
 }
 
+// VersionedRecord is a Record (with metadata) of a specific version, returned by History.
+type VersionedRecord struct {
+	Version  uint64
+	Record   Record
+	Metadata Metadata
+}
+
 // Storage represents S4 storage access interface.
 // All functions are thread-safe.
 type Storage interface {
@@ -52,10 +68,17 @@ type Storage interface {
 	Constraints() Constraints
 
 	// Get returns a copy of record (with metadata) associated with the specified key.
+	// Previous versions are returned as long as they are retained in the history.
 	// The returned Record & Metadata are always a copy.
 	Get(ctx context.Context, key *Key) (*Record, *Metadata, error)
 
+	// History returns up to limit most recent non-expired versions of the specified slot,
+	// including the current one, ordered by descending version.
+	// The limit is capped to MaxHistoryVersions+1.
+	History(ctx context.Context, address common.Address, slotId uint, limit uint) ([]*VersionedRecord, error)
+
 	// Put creates (or updates) a record identified by the specified key.
+	// The write is accounted to the address quota, ErrQuotaExceeded is returned once it is exhausted.
 	// For signature calculation see envelope.go
 	Put(ctx context.Context, key *Key, record *Record, signature []byte) error
 
@@ -68,16 +91,20 @@ type storage struct {
 	lggr       logger.Logger
 	contraints Constraints
 	orm        ORM
+	quotas     QuotaORM
 	clock      clockwork.Clock
 }
 
 var _ Storage = (*storage)(nil)
 
-func NewStorage(lggr logger.Logger, contraints Constraints, orm ORM, clock clockwork.Clock) Storage {
+// NewStorage returns a Storage backed by orm. Quotas are accounted in quotas, which may be nil if
+// Constraints.QuotaPeriodSec is zero.
+func NewStorage(lggr logger.Logger, contraints Constraints, orm ORM, quotas QuotaORM, clock clockwork.Clock) Storage {
 	return &storage{
 		lggr:       lggr.Named("S4Storage"),
 		contraints: contraints,
 		orm:        orm,
+		quotas:     quotas,
 		clock:      clock,
 	}
 }
@@ -97,23 +124,48 @@ func (s *storage) Get(ctx context.Context, key *Key) (*Record, *Metadata, error)
 		return nil, nil, err
 	}
 
+	if row.Version > key.Version {
+		row, err = s.orm.GetVersion(ctx, bigAddress, key.SlotId, key.Version)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	if row.Version != key.Version || row.Expiration <= s.clock.Now().UnixMilli() {
 		return nil, nil, ErrNotFound
 	}
 
-	record := &Record{
-		Payload:    make([]byte, len(row.Payload)),
-		Expiration: row.Expiration,
+	record, metadata := rowToRecord(row)
+	return record, metadata, nil
+}
+
+func (s *storage) History(ctx context.Context, address common.Address, slotId uint, limit uint) ([]*VersionedRecord, error) {
+	if slotId >= s.contraints.MaxSlotsPerUser {
+		return nil, ErrSlotIdTooBig
+	}
+	if limit > s.contraints.MaxHistoryVersions+1 {
+		limit = s.contraints.MaxHistoryVersions + 1
 	}
-	copy(record.Payload, row.Payload)
 
-	metadata := &Metadata{
-		Confirmed: row.Confirmed,
-		Signature: make([]byte, len(row.Signature)),
+	rows, err := s.orm.History(ctx, big.New(address.Big()), slotId, limit)
+	if err != nil {
+		return nil, err
 	}
-	copy(metadata.Signature, row.Signature)
 
-	return record, metadata, nil
+	now := s.clock.Now().UnixMilli()
+	records := make([]*VersionedRecord, 0, len(rows))
+	for _, row := range rows {
+		if row.Expiration <= now {
+			continue
+		}
+		record, metadata := rowToRecord(row)
+		records = append(records, &VersionedRecord{
+			Version:  row.Version,
+			Record:   *record,
+			Metadata: *metadata,
+		})
+	}
+	return records, nil
 }
 
 func (s *storage) List(ctx context.Context, address common.Address) ([]*SnapshotRow, error) {
@@ -146,8 +198,18 @@ func (s *storage) Put(ctx context.Context, key *Key, record *Record, signature [
 		return ErrWrongSignature
 	}
 
+	bigAddress := big.New(key.Address.Big())
+	if s.contraints.QuotaPeriodSec > 0 && s.quotas != nil {
+		// The write is accounted before it is stored, so that concurrent writes cannot exceed the quota.
+		// Writes rejected by the ORM, e.g. of an outdated version, still count towards the quota.
+		periodStart := QuotaPeriodStart(s.clock.Now(), time.Duration(s.contraints.QuotaPeriodSec)*time.Second)
+		if _, err = s.quotas.AddUsage(ctx, bigAddress, periodStart, uint64(len(record.Payload)), s.contraints.MaxBytesPerPeriod, s.contraints.MaxWritesPerPeriod); err != nil {
+			return err
+		}
+	}
+
 	row := &Row{
-		Address:    big.New(key.Address.Big()),
+		Address:    bigAddress,
 		SlotId:     key.SlotId,
 		Payload:    make([]byte, len(record.Payload)),
 		Version:    key.Version,
@@ -160,3 +222,19 @@ func (s *storage) Put(ctx context.Context, key *Key, record *Record, signature [
 
 	return s.orm.Update(ctx, row)
 }
+
+func rowToRecord(row *Row) (*Record, *Metadata) {
+	record := &Record{
+		Payload:    make([]byte, len(row.Payload)),
+		Expiration: row.Expiration,
+	}
+	copy(record.Payload, row.Payload)
+
+	metadata := &Metadata{
+		Confirmed: row.Confirmed,
+		Signature: make([]byte, len(row.Signature)),
+	}
+	copy(metadata.Signature, row.Signature)
+
+	return record, metadata
+}
@@ -1,6 +1,8 @@
 package s4_test
 
 import (
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -22,6 +24,7 @@ var (
 		MaxSlotsPerUser:        5,
 		MaxPayloadSizeBytes:    32,
 		MaxExpirationLengthSec: 3600,
+		MaxHistoryVersions:     2,
 	}
 )
 
@@ -29,7 +32,7 @@ func setupTestStorage(t *testing.T, now time.Time) (*mocks.ORM, s4.Storage) {
 	logger := logger.TestLogger(t)
 	orm := mocks.NewORM(t)
 	clock := clockwork.NewFakeClock()
-	storage := s4.NewStorage(logger, constraints, orm, clock)
+	storage := s4.NewStorage(logger, constraints, orm, nil, clock)
 	return orm, storage
 }
 
@@ -235,3 +238,141 @@ func TestStorage_List(t *testing.T) {
 		}
 	}
 }
+
+func TestStorage_GetPreviousVersion(t *testing.T) {
+	t.Parallel()
+
+	ormMock, storage := setupTestStorage(t, time.Now())
+	address := testutils.NewAddress()
+	bigAddress := big.New(address.Big())
+	expiration := time.Now().Add(time.Hour).UnixMilli()
+
+	ormMock.On("Get", mock.Anything, bigAddress, uint(1)).Return(&s4.Row{
+		Address: bigAddress, SlotId: 1, Version: 5, Payload: []byte("current"), Expiration: expiration,
+	}, nil)
+	ormMock.On("GetVersion", mock.Anything, bigAddress, uint(1), uint64(4)).Return(&s4.Row{
+		Address: bigAddress, SlotId: 1, Version: 4, Payload: []byte("previous"), Expiration: expiration, Confirmed: true,
+	}, nil)
+	ormMock.On("GetVersion", mock.Anything, bigAddress, uint(1), uint64(1)).Return(nil, s4.ErrNotFound)
+
+	record, metadata, err := storage.Get(testutils.Context(t), &s4.Key{Address: address, SlotId: 1, Version: 4})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("previous"), record.Payload)
+	assert.True(t, metadata.Confirmed)
+
+	_, _, err = storage.Get(testutils.Context(t), &s4.Key{Address: address, SlotId: 1, Version: 1})
+	assert.ErrorIs(t, err, s4.ErrNotFound)
+
+	_, _, err = storage.Get(testutils.Context(t), &s4.Key{Address: address, SlotId: 1, Version: 6})
+	assert.ErrorIs(t, err, s4.ErrNotFound)
+}
+
+func TestStorage_History(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	ormMock, storage := setupTestStorage(t, now)
+	address := testutils.NewAddress()
+	bigAddress := big.New(address.Big())
+
+	// the limit is capped to MaxHistoryVersions+1
+	ormMock.On("History", mock.Anything, bigAddress, uint(1), constraints.MaxHistoryVersions+1).Return([]*s4.Row{
+		{Address: bigAddress, SlotId: 1, Version: 3, Payload: []byte{3}, Expiration: now.Add(time.Hour).UnixMilli(), Signature: []byte{3}},
+		{Address: bigAddress, SlotId: 1, Version: 2, Payload: []byte{2}, Expiration: clockwork.NewFakeClock().Now().UnixMilli(), Signature: []byte{2}},
+		{Address: bigAddress, SlotId: 1, Version: 1, Payload: []byte{1}, Expiration: now.Add(time.Hour).UnixMilli(), Confirmed: true, Signature: []byte{1}},
+	}, nil)
+
+	records, err := storage.History(testutils.Context(t), address, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, records, 2, "expired versions are skipped")
+	assert.Equal(t, uint64(3), records[0].Version)
+	assert.Equal(t, []byte{3}, records[0].Record.Payload)
+	assert.Equal(t, []byte{3}, records[0].Metadata.Signature)
+	assert.Equal(t, uint64(1), records[1].Version)
+	assert.True(t, records[1].Metadata.Confirmed)
+
+	_, err = storage.History(testutils.Context(t), address, constraints.MaxSlotsPerUser, 1)
+	assert.ErrorIs(t, err, s4.ErrSlotIdTooBig)
+}
+
+func TestStorage_Quota(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	clock := clockwork.NewFakeClockAt(time.Date(2024, 1, 1, 0, 30, 0, 0, time.UTC))
+	quotas := s4.NewInMemoryQuotaORM()
+	quotaConstraints := constraints
+	quotaConstraints.QuotaPeriodSec = 3600
+	quotaConstraints.MaxBytesPerPeriod = 40
+	quotaConstraints.MaxWritesPerPeriod = 3
+	storage := s4.NewStorage(logger.TestLogger(t), quotaConstraints, s4.NewInMemoryORM(0), quotas, clock)
+
+	privateKey, address := testutils.NewPrivateKeyAndAddress(t)
+	put := func(version uint64, size int) error {
+		key := &s4.Key{Address: address, SlotId: 0, Version: version}
+		record := &s4.Record{
+			Payload:    make([]byte, size),
+			Expiration: clock.Now().Add(time.Minute).UnixMilli(),
+		}
+		signature, err := s4.NewEnvelopeFromRecord(key, record).Sign(privateKey)
+		require.NoError(t, err)
+		return storage.Put(ctx, key, record, signature)
+	}
+
+	require.NoError(t, put(1, 20))
+	require.NoError(t, put(2, 20))
+	assert.ErrorIs(t, put(3, 1), s4.ErrQuotaExceeded, "bytes quota")
+
+	quota, err := quotas.GetQuota(ctx, big.New(address.Big()))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(40), quota.Bytes)
+	assert.Equal(t, uint64(2), quota.Writes)
+
+	// the next period starts with a fresh quota
+	clock.Advance(time.Hour)
+	require.NoError(t, put(3, 1))
+	require.NoError(t, put(4, 1))
+	require.NoError(t, put(5, 1))
+	assert.ErrorIs(t, put(6, 1), s4.ErrQuotaExceeded, "writes quota")
+
+	t.Run("rejects a first write above the bytes quota", func(t *testing.T) {
+		c := quotaConstraints
+		c.MaxBytesPerPeriod = 10
+		storage := s4.NewStorage(logger.TestLogger(t), c, s4.NewInMemoryORM(0), s4.NewInMemoryQuotaORM(), clock)
+		key := &s4.Key{Address: address, SlotId: 0, Version: 1}
+		record := &s4.Record{Payload: make([]byte, 11), Expiration: clock.Now().Add(time.Minute).UnixMilli()}
+		signature, err := s4.NewEnvelopeFromRecord(key, record).Sign(privateKey)
+		require.NoError(t, err)
+		assert.ErrorIs(t, storage.Put(ctx, key, record, signature), s4.ErrQuotaExceeded)
+	})
+
+	t.Run("concurrent writes do not exceed the quota", func(t *testing.T) {
+		quotas := s4.NewInMemoryQuotaORM()
+		storage := s4.NewStorage(logger.TestLogger(t), quotaConstraints, s4.NewInMemoryORM(0), quotas, clock)
+		privateKey, address := testutils.NewPrivateKeyAndAddress(t)
+
+		var wg sync.WaitGroup
+		var stored atomic.Int64
+		for slot := uint(0); slot < 5; slot++ {
+			key := &s4.Key{Address: address, SlotId: slot, Version: 1}
+			record := &s4.Record{Payload: make([]byte, 1), Expiration: clock.Now().Add(time.Minute).UnixMilli()}
+			signature, err := s4.NewEnvelopeFromRecord(key, record).Sign(privateKey)
+			require.NoError(t, err)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := storage.Put(ctx, key, record, signature); err == nil {
+					stored.Add(1)
+				} else {
+					assert.ErrorIs(t, err, s4.ErrQuotaExceeded)
+				}
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int64(quotaConstraints.MaxWritesPerPeriod), stored.Load())
+
+		quota, err := quotas.GetQuota(ctx, big.New(address.Big()))
+		require.NoError(t, err)
+		assert.Equal(t, quotaConstraints.MaxWritesPerPeriod, quota.Writes)
+	})
+}
@@ -68,7 +68,9 @@ type coordinatorV2PlusUniverse struct {
 
 func newVRFCoordinatorV2PlusUniverse(t *testing.T, key ethkey.KeyV2, numConsumers int, trusting bool) coordinatorV2PlusUniverse {
 	tests.SkipShort(t, "VRFCoordinatorV2Universe")
-	oracleTransactor, err := bind.NewKeyedTransactorWithChainID(key.ToEcdsaPrivKey(), testutils.SimulatedChainID)
+	privKey, err := key.ToEcdsaPrivKey()
+	require.NoError(t, err)
+	oracleTransactor, err := bind.NewKeyedTransactorWithChainID(privKey, testutils.SimulatedChainID)
 	require.NoError(t, err)
 	var (
 		sergey       = testutils.MustNewSimTransactor(t)
@@ -147,7 +147,9 @@ func makeTestTxm(t *testing.T, txStore txmgr.TestEvmTxStore, keyStore keystore.M
 
 func newVRFCoordinatorV2Universe(t *testing.T, key ethkey.KeyV2, numConsumers int) coordinatorV2Universe {
 	tests.SkipShort(t, "VRFCoordinatorV2Universe")
-	oracleTransactor, err := bind.NewKeyedTransactorWithChainID(key.ToEcdsaPrivKey(), testutils.SimulatedChainID)
+	privKey, err := key.ToEcdsaPrivKey()
+	require.NoError(t, err)
+	oracleTransactor, err := bind.NewKeyedTransactorWithChainID(privKey, testutils.SimulatedChainID)
 	require.NoError(t, err)
 	var (
 		sergey       = testutils.MustNewSimTransactor(t)
@@ -492,7 +494,9 @@ func sendEth(t *testing.T, key ethkey.KeyV2, b types.Backend, to common.Address,
 	})
 	balBefore, err := b.Client().BalanceAt(ctx, to, nil)
 	require.NoError(t, err)
-	signedTx, err := gethtypes.SignTx(tx, gethtypes.NewLondonSigner(testutils.SimulatedChainID), key.ToEcdsaPrivKey())
+	privKey, err := key.ToEcdsaPrivKey()
+	require.NoError(t, err)
+	signedTx, err := gethtypes.SignTx(tx, gethtypes.NewLondonSigner(testutils.SimulatedChainID), privKey)
 	require.NoError(t, err)
 	err = b.Client().SendTransaction(ctx, signedTx)
 	require.NoError(t, err)
@@ -183,7 +183,9 @@ func NewVRFCoordinatorUniverseWithV08Consumer(t *testing.T, key ethkey.KeyV2) Co
 func NewVRFCoordinatorUniverse(t *testing.T, keys ...ethkey.KeyV2) CoordinatorUniverse {
 	var oracleTransactors []*bind.TransactOpts
 	for _, key := range keys {
-		oracleTransactor, err := bind.NewKeyedTransactorWithChainID(key.ToEcdsaPrivKey(), testutils.SimulatedChainID)
+		privKey, err := key.ToEcdsaPrivKey()
+		require.NoError(t, err)
+		oracleTransactor, err := bind.NewKeyedTransactorWithChainID(privKey, testutils.SimulatedChainID)
 		require.NoError(t, err)
 		oracleTransactors = append(oracleTransactors, oracleTransactor)
 	}
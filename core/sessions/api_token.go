@@ -0,0 +1,100 @@
+package sessions
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+// APITokenPrefix prefixes the bearer tokens of API tokens, to tell them apart from the tokens of an identity provider
+const APITokenPrefix = "clt_"
+
+// ErrAPITokenNotFound is returned when no API token exists with the given ID
+var ErrAPITokenNotFound = pkgerrors.New("API token not found")
+
+// ErrAPITokenInvalid is returned when a bearer token is malformed, unknown, expired, revoked, or has a wrong secret
+var ErrAPITokenInvalid = pkgerrors.New("API token is invalid, expired or revoked")
+
+var apiTokenNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]*$`)
+
+// APIToken is a named API token of a user, for automation such as CI pipelines. Requests authenticated by the token are
+// authorized by both its scopes and the role of its user, until it expires or is revoked. Scopes are permissions in the
+// format resource:action[:scope], like those of custom roles.
+type APIToken struct {
+	ID        string
+	Name      string
+	UserEmail string
+	// UserRole and CustomRole are the role of the user when the token was created, which authorize its requests while
+	// the user can not be found, as OIDC users without an active session
+	UserRole   UserRole
+	CustomRole null.String
+	Scopes     []Permission
+	ExpiresAt  time.Time
+	LastUsedAt null.Time
+	RevokedAt  null.Time
+	CreatedAt  time.Time
+}
+
+// Validate returns an error if the name of the token is invalid, it has no or an invalid scope, or it already expired.
+func (t APIToken) Validate() error {
+	if !apiTokenNameRegex.MatchString(t.Name) {
+		return pkgerrors.Errorf("invalid API token name %q, must be lower case alphanumeric, dots, dashes and underscores", t.Name)
+	}
+	if len(t.Scopes) == 0 {
+		return pkgerrors.New("API token must have at least one scope")
+	}
+	for _, p := range t.Scopes {
+		if err := p.Validate(); err != nil {
+			return err
+		}
+	}
+	if !t.ExpiresAt.After(time.Now()) {
+		return pkgerrors.New("API token must expire in the future")
+	}
+	return nil
+}
+
+// Active returns true if the token is neither revoked nor expired.
+func (t APIToken) Active() bool {
+	return !t.RevokedAt.Valid && t.ExpiresAt.After(time.Now())
+}
+
+// Allows returns true if any scope of the token grants the requested permission.
+func (t APIToken) Allows(requested Permission) bool {
+	return allows(t.Scopes, requested)
+}
+
+// APITokenBearer returns the bearer token of the API token with id and secret.
+func APITokenBearer(id, secret string) string {
+	return APITokenPrefix + id + "_" + secret
+}
+
+// ParseAPITokenBearer returns the ID and secret of the bearer token of an API token.
+func ParseAPITokenBearer(bearer string) (id, secret string, err error) {
+	rest, ok := strings.CutPrefix(bearer, APITokenPrefix)
+	if !ok {
+		return "", "", ErrAPITokenInvalid
+	}
+	id, secret, ok = strings.Cut(rest, "_")
+	if !ok || id == "" || secret == "" {
+		return "", "", ErrAPITokenInvalid
+	}
+	return id, secret, nil
+}
+
+// APITokensORM manages the scoped API tokens of users.
+type APITokensORM interface {
+	// ListAPITokens returns the tokens of the user with email, or of all users if email is empty.
+	ListAPITokens(ctx context.Context, email string) ([]APIToken, error)
+	FindAPIToken(ctx context.Context, id string) (APIToken, error)
+	// CreateAPIToken creates token, and returns its bearer token, which is not stored and can not be shown again.
+	CreateAPIToken(ctx context.Context, token *APIToken) (string, error)
+	RevokeAPIToken(ctx context.Context, id string) error
+	// AuthenticateAPIToken returns the active token of a bearer token, and records its use at most once a minute. It returns
+	// ErrAPITokenInvalid if the bearer token does not authenticate an active token.
+	AuthenticateAPIToken(ctx context.Context, bearer string) (APIToken, error)
+}
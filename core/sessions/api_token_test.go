@@ -0,0 +1,68 @@
+package sessions_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+)
+
+func TestAPIToken_Validate(t *testing.T) {
+	t.Parallel()
+
+	valid := sessions.APIToken{
+		Name:      "ci-deploy",
+		Scopes:    []sessions.Permission{sessions.NewPermission(sessions.ResourceJobs, sessions.ActionAll, "webhook")},
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	require.NoError(t, valid.Validate())
+	assert.True(t, valid.Active())
+	assert.True(t, valid.Allows(sessions.NewPermission(sessions.ResourceJobs, sessions.ActionCreate, "webhook")))
+	assert.False(t, valid.Allows(sessions.NewPermission(sessions.ResourceKeys, sessions.ActionRead, "")))
+
+	revoked := valid
+	revoked.RevokedAt = null.TimeFrom(time.Now())
+	assert.False(t, revoked.Active())
+
+	for name, token := range map[string]sessions.APIToken{
+		"empty name":      {Scopes: valid.Scopes, ExpiresAt: valid.ExpiresAt},
+		"upper case name": {Name: "CI", Scopes: valid.Scopes, ExpiresAt: valid.ExpiresAt},
+		"no scopes":       {Name: "ci", ExpiresAt: valid.ExpiresAt},
+		"bad scope":       {Name: "ci", Scopes: []sessions.Permission{{Resource: "widgets", Action: sessions.ActionRead}}, ExpiresAt: valid.ExpiresAt},
+		"expired":         {Name: "ci", Scopes: valid.Scopes, ExpiresAt: time.Now().Add(-time.Second)},
+	} {
+		assert.Error(t, token.Validate(), name)
+	}
+}
+
+func TestParseAPITokenBearer(t *testing.T) {
+	t.Parallel()
+
+	id, secret, err := sessions.ParseAPITokenBearer(sessions.APITokenBearer("0123abcd", "s3cr_et"))
+	require.NoError(t, err)
+	assert.Equal(t, "0123abcd", id)
+	assert.Equal(t, "s3cr_et", secret)
+
+	for _, bearer := range []string{"", "0123abcd_secret", "clt_", "clt_0123abcd", "clt__secret", "clt_0123abcd_"} {
+		_, _, err = sessions.ParseAPITokenBearer(bearer)
+		assert.ErrorIs(t, err, sessions.ErrAPITokenInvalid, bearer)
+	}
+}
+
+func TestUser_TokenAllows(t *testing.T) {
+	t.Parallel()
+
+	jobsRead := sessions.NewPermission(sessions.ResourceJobs, sessions.ActionRead, "")
+	user := sessions.User{Role: sessions.UserRoleAdmin}
+	assert.False(t, user.HasTokenScopes())
+	assert.True(t, user.TokenAllows(jobsRead))
+
+	user.TokenScopes = []sessions.Permission{jobsRead}
+	assert.True(t, user.HasTokenScopes())
+	assert.True(t, user.TokenAllows(jobsRead))
+	assert.False(t, user.TokenAllows(sessions.NewPermission(sessions.ResourceJobs, sessions.ActionDelete, "")))
+}
@@ -0,0 +1,180 @@
+package apitokens
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	pkgerrors "github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+	"github.com/smartcontractkit/chainlink/v2/core/auth"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+type orm struct {
+	ds sqlutil.DataSource
+}
+
+var _ sessions.APITokensORM = (*orm)(nil)
+
+func NewORM(ds sqlutil.DataSource) sessions.APITokensORM {
+	return &orm{ds: ds}
+}
+
+type apiTokenRow struct {
+	ID           string         `db:"id"`
+	Name         string         `db:"name"`
+	UserEmail    string         `db:"user_email"`
+	UserRole     string         `db:"user_role"`
+	CustomRole   null.String    `db:"custom_role"`
+	Scopes       pq.StringArray `db:"scopes"`
+	Salt         string         `db:"salt"`
+	HashedSecret string         `db:"hashed_secret"`
+	ExpiresAt    time.Time      `db:"expires_at"`
+	LastUsedAt   null.Time      `db:"last_used_at"`
+	RevokedAt    null.Time      `db:"revoked_at"`
+	CreatedAt    time.Time      `db:"created_at"`
+}
+
+func (r apiTokenRow) toAPIToken() (sessions.APIToken, error) {
+	scopes := make([]sessions.Permission, len(r.Scopes))
+	for i, s := range r.Scopes {
+		p, err := sessions.ParsePermission(s)
+		if err != nil {
+			return sessions.APIToken{}, pkgerrors.Wrapf(err, "invalid scope of API token %s", r.ID)
+		}
+		scopes[i] = p
+	}
+	return sessions.APIToken{
+		ID:         r.ID,
+		Name:       r.Name,
+		UserEmail:  r.UserEmail,
+		UserRole:   sessions.UserRole(r.UserRole),
+		CustomRole: r.CustomRole,
+		Scopes:     scopes,
+		ExpiresAt:  r.ExpiresAt,
+		LastUsedAt: r.LastUsedAt,
+		RevokedAt:  r.RevokedAt,
+		CreatedAt:  r.CreatedAt,
+	}, nil
+}
+
+func (o *orm) ListAPITokens(ctx context.Context, email string) ([]sessions.APIToken, error) {
+	var rows []apiTokenRow
+	err := o.ds.SelectContext(ctx, &rows, `SELECT * FROM api_tokens WHERE $1 = '' OR lower(user_email) = lower($1)
+		ORDER BY created_at, id`, email)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "failed to list API tokens")
+	}
+	tokens := []sessions.APIToken{}
+	for _, row := range rows {
+		token, err := row.toAPIToken()
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, nil
+}
+
+func (o *orm) FindAPIToken(ctx context.Context, id string) (sessions.APIToken, error) {
+	row, err := o.findRow(ctx, id)
+	if err != nil {
+		return sessions.APIToken{}, err
+	}
+	return row.toAPIToken()
+}
+
+func (o *orm) findRow(ctx context.Context, id string) (row apiTokenRow, err error) {
+	err = o.ds.GetContext(ctx, &row, `SELECT * FROM api_tokens WHERE id = $1`, id)
+	if pkgerrors.Is(err, sql.ErrNoRows) {
+		return row, sessions.ErrAPITokenNotFound
+	}
+	return row, pkgerrors.Wrap(err, "failed to find API token")
+}
+
+func (o *orm) CreateAPIToken(ctx context.Context, token *sessions.APIToken) (string, error) {
+	if err := token.Validate(); err != nil {
+		return "", err
+	}
+	secret := auth.NewToken()
+	salt := utils.NewSecret(utils.DefaultSecretSize)
+	hashedSecret, err := auth.HashedSecret(secret, salt)
+	if err != nil {
+		return "", pkgerrors.Wrap(err, "failed to hash API token secret")
+	}
+	scopes := make(pq.StringArray, len(token.Scopes))
+	for i, p := range token.Scopes {
+		scopes[i] = p.String()
+	}
+
+	var row apiTokenRow
+	err = o.ds.GetContext(ctx, &row, `INSERT INTO api_tokens (id, name, user_email, user_role, custom_role, scopes, salt, hashed_secret, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, now()) RETURNING *`,
+		secret.AccessKey, token.Name, strings.ToLower(token.UserEmail), token.UserRole, token.CustomRole, scopes, salt, hashedSecret, token.ExpiresAt)
+	if err != nil {
+		return "", pkgerrors.Wrap(err, "failed to create API token")
+	}
+	created, err := row.toAPIToken()
+	if err != nil {
+		return "", err
+	}
+	*token = created
+	return sessions.APITokenBearer(secret.AccessKey, secret.Secret), nil
+}
+
+func (o *orm) RevokeAPIToken(ctx context.Context, id string) error {
+	result, err := o.ds.ExecContext(ctx, `UPDATE api_tokens SET revoked_at = now() WHERE id = $1 AND revoked_at IS NULL`, id)
+	if err != nil {
+		return pkgerrors.Wrap(err, "failed to revoke API token")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sessions.ErrAPITokenNotFound
+	}
+	return nil
+}
+
+func (o *orm) AuthenticateAPIToken(ctx context.Context, bearer string) (sessions.APIToken, error) {
+	id, secret, err := sessions.ParseAPITokenBearer(bearer)
+	if err != nil {
+		return sessions.APIToken{}, err
+	}
+	row, err := o.findRow(ctx, id)
+	if pkgerrors.Is(err, sessions.ErrAPITokenNotFound) {
+		return sessions.APIToken{}, sessions.ErrAPITokenInvalid
+	} else if err != nil {
+		return sessions.APIToken{}, err
+	}
+	hashedSecret, err := auth.HashedSecret(&auth.Token{AccessKey: id, Secret: secret}, row.Salt)
+	if err != nil {
+		return sessions.APIToken{}, pkgerrors.Wrap(err, "failed to hash API token secret")
+	}
+	if subtle.ConstantTimeCompare([]byte(hashedSecret), []byte(row.HashedSecret)) != 1 {
+		return sessions.APIToken{}, sessions.ErrAPITokenInvalid
+	}
+	token, err := row.toAPIToken()
+	if err != nil {
+		return sessions.APIToken{}, err
+	}
+	if !token.Active() {
+		return sessions.APIToken{}, sessions.ErrAPITokenInvalid
+	}
+
+	// Uses are recorded at most once a minute, rather than writing on every request
+	err = o.ds.GetContext(ctx, &token.LastUsedAt, `UPDATE api_tokens SET last_used_at = now()
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < now() - interval '1 minute') RETURNING last_used_at`, id)
+	if err != nil && !pkgerrors.Is(err, sql.ErrNoRows) {
+		return sessions.APIToken{}, pkgerrors.Wrap(err, "failed to record use of API token")
+	}
+	return token, nil
+}
@@ -0,0 +1,128 @@
+package apitokens_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions/apitokens"
+)
+
+func TestORM_APITokens(t *testing.T) {
+	t.Parallel()
+	ctx := testutils.Context(t)
+
+	db := pgtest.NewSqlxDB(t)
+	orm := apitokens.NewORM(db)
+
+	token := sessions.APIToken{
+		Name:       "ci-deploy",
+		UserEmail:  "CI@example.com",
+		UserRole:   sessions.UserRoleView,
+		CustomRole: null.StringFrom("ci"),
+		Scopes:     []sessions.Permission{sessions.NewPermission(sessions.ResourceJobs, sessions.ActionAll, "webhook")},
+		ExpiresAt:  time.Now().Add(time.Hour),
+	}
+	bearer, err := orm.CreateAPIToken(ctx, &token)
+	require.NoError(t, err)
+	assert.NotEmpty(t, token.ID)
+	assert.Equal(t, "ci@example.com", token.UserEmail)
+	assert.Equal(t, sessions.UserRoleView, token.UserRole)
+	assert.Equal(t, null.StringFrom("ci"), token.CustomRole)
+	assert.False(t, token.CreatedAt.IsZero())
+	assert.False(t, token.LastUsedAt.Valid)
+
+	// Names are unique per user while the tokens are active
+	dup := sessions.APIToken{Name: token.Name, UserEmail: "ci@example.com", UserRole: sessions.UserRoleView, Scopes: token.Scopes, ExpiresAt: token.ExpiresAt}
+	_, err = orm.CreateAPIToken(ctx, &dup)
+	require.Error(t, err)
+
+	other := sessions.APIToken{Name: token.Name, UserEmail: "other@example.com", UserRole: sessions.UserRoleEdit, Scopes: token.Scopes, ExpiresAt: token.ExpiresAt}
+	_, err = orm.CreateAPIToken(ctx, &other)
+	require.NoError(t, err)
+
+	authenticated, err := orm.AuthenticateAPIToken(ctx, bearer)
+	require.NoError(t, err)
+	assert.Equal(t, token.ID, authenticated.ID)
+	assert.Equal(t, token.Scopes, authenticated.Scopes)
+	assert.True(t, authenticated.LastUsedAt.Valid)
+
+	// Uses are recorded at most once a minute
+	_, err = db.Exec(`UPDATE api_tokens SET last_used_at = now() - interval '30 seconds' WHERE id = $1`, token.ID)
+	require.NoError(t, err)
+	lastUsed, err := orm.FindAPIToken(ctx, token.ID)
+	require.NoError(t, err)
+	authenticated, err = orm.AuthenticateAPIToken(ctx, bearer)
+	require.NoError(t, err)
+	assert.Equal(t, lastUsed.LastUsedAt, authenticated.LastUsedAt)
+	_, err = db.Exec(`UPDATE api_tokens SET last_used_at = now() - interval '2 minutes' WHERE id = $1`, token.ID)
+	require.NoError(t, err)
+	authenticated, err = orm.AuthenticateAPIToken(ctx, bearer)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), authenticated.LastUsedAt.Time, 5*time.Second)
+
+	for _, invalid := range []string{"", "clt_unknown_secret", sessions.APITokenBearer(token.ID, "wrong"), bearer + "x"} {
+		_, err = orm.AuthenticateAPIToken(ctx, invalid)
+		require.ErrorIs(t, err, sessions.ErrAPITokenInvalid, invalid)
+	}
+
+	tokens, err := orm.ListAPITokens(ctx, "ci@example.com")
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	assert.True(t, tokens[0].LastUsedAt.Valid)
+
+	tokens, err = orm.ListAPITokens(ctx, "")
+	require.NoError(t, err)
+	require.Len(t, tokens, 2)
+
+	require.NoError(t, orm.RevokeAPIToken(ctx, token.ID))
+	require.ErrorIs(t, orm.RevokeAPIToken(ctx, token.ID), sessions.ErrAPITokenNotFound)
+	require.ErrorIs(t, orm.RevokeAPIToken(ctx, "unknown"), sessions.ErrAPITokenNotFound)
+
+	_, err = orm.AuthenticateAPIToken(ctx, bearer)
+	require.ErrorIs(t, err, sessions.ErrAPITokenInvalid)
+
+	found, err := orm.FindAPIToken(ctx, token.ID)
+	require.NoError(t, err)
+	assert.True(t, found.RevokedAt.Valid)
+	_, err = orm.FindAPIToken(ctx, "unknown")
+	require.ErrorIs(t, err, sessions.ErrAPITokenNotFound)
+
+	// The name of a revoked token can be reused
+	_, err = orm.CreateAPIToken(ctx, &dup)
+	require.NoError(t, err)
+}
+
+func TestORM_APITokenExpires(t *testing.T) {
+	t.Parallel()
+	ctx := testutils.Context(t)
+
+	db := pgtest.NewSqlxDB(t)
+	orm := apitokens.NewORM(db)
+
+	token := sessions.APIToken{
+		Name:      "nightly",
+		UserEmail: "ci@example.com",
+		UserRole:  sessions.UserRoleRun,
+		Scopes:    []sessions.Permission{sessions.NewPermission(sessions.ResourceJobs, sessions.ActionRead, "")},
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	bearer, err := orm.CreateAPIToken(ctx, &token)
+	require.NoError(t, err)
+
+	_, err = db.Exec(`UPDATE api_tokens SET expires_at = now() - interval '1 second' WHERE id = $1`, token.ID)
+	require.NoError(t, err)
+	_, err = orm.AuthenticateAPIToken(ctx, bearer)
+	require.ErrorIs(t, err, sessions.ErrAPITokenInvalid)
+
+	expired := token
+	expired.ExpiresAt = time.Now().Add(-time.Minute)
+	_, err = orm.CreateAPIToken(ctx, &expired)
+	require.ErrorContains(t, err, "must expire in the future")
+}
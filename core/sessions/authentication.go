@@ -15,6 +15,7 @@ type AuthenticationProviderName string
 const (
 	LocalAuth AuthenticationProviderName = "local"
 	LDAPAuth  AuthenticationProviderName = "ldap"
+	OIDCAuth  AuthenticationProviderName = "oidc"
 )
 
 // ErrUserSessionExpired defines the error triggered when the user session has expired
@@ -61,3 +62,18 @@ type AuthenticationProvider interface {
 
 	FindExternalInitiator(ctx context.Context, eia *auth.Token) (initiator *bridges.ExternalInitiator, err error)
 }
+
+// OIDCAuthenticationProvider is an AuthenticationProvider which delegates authentication to an OpenID Connect identity
+// provider. Operator UI users log in by the authorization code flow with PKCE, and API clients authenticate with bearer
+// tokens issued by the identity provider.
+type OIDCAuthenticationProvider interface {
+	AuthenticationProvider
+	// StartLogin begins an authorization code flow, returning the URL of the identity provider to redirect the user to,
+	// and the state which must be passed back to CreateSessionFromAuthCode.
+	StartLogin(ctx context.Context) (authURL string, state string, err error)
+	// CreateSessionFromAuthCode completes the authorization code flow started with state, and creates a session for the
+	// user of the ID token, expiring with the token.
+	CreateSessionFromAuthCode(ctx context.Context, state, code string) (string, error)
+	// FindUserByBearerToken validates a bearer token issued by the identity provider, and returns its user.
+	FindUserByBearerToken(ctx context.Context, token string) (User, error)
+}
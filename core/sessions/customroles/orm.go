@@ -0,0 +1,200 @@
+package customroles
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/lib/pq"
+	pkgerrors "github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+)
+
+// ErrCustomRoleAssigned is returned when deleting a custom role which is still assigned to local users
+var ErrCustomRoleAssigned = pkgerrors.New("custom role is still assigned to users")
+
+// ORM manages custom roles, and loads their permissions for the authentication providers.
+type ORM interface {
+	sessions.CustomRolesORM
+	// FindCustomRoleByGroups returns the custom role mapped to any of the LDAP or OIDC groups, picking the first by
+	// name if several are.
+	FindCustomRoleByGroups(ctx context.Context, groups []string) (sessions.CustomRole, error)
+	// LoadPermissions sets the permissions of the custom role of user, if any.
+	LoadPermissions(ctx context.Context, user *sessions.User) error
+}
+
+type orm struct {
+	ds sqlutil.DataSource
+}
+
+var _ ORM = (*orm)(nil)
+
+func NewORM(ds sqlutil.DataSource) ORM {
+	return &orm{ds: ds}
+}
+
+type customRoleRow struct {
+	Name        string      `db:"name"`
+	Description string      `db:"description"`
+	Group       null.String `db:"group_name"`
+	CreatedAt   time.Time   `db:"created_at"`
+	UpdatedAt   time.Time   `db:"updated_at"`
+}
+
+func (r customRoleRow) toCustomRole(permissions []sessions.Permission) sessions.CustomRole {
+	return sessions.CustomRole{
+		Name:        r.Name,
+		Description: r.Description,
+		Group:       r.Group,
+		Permissions: permissions,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+}
+
+type permissionRow struct {
+	RoleName string            `db:"role_name"`
+	Resource sessions.Resource `db:"resource"`
+	Action   sessions.Action   `db:"action"`
+	Scope    string            `db:"scope"`
+}
+
+func (o *orm) ListCustomRoles(ctx context.Context) ([]sessions.CustomRole, error) {
+	var rows []customRoleRow
+	if err := o.ds.SelectContext(ctx, &rows, `SELECT * FROM custom_roles ORDER BY name`); err != nil {
+		return nil, pkgerrors.Wrap(err, "failed to list custom roles")
+	}
+	var permissionRows []permissionRow
+	if err := o.ds.SelectContext(ctx, &permissionRows, `SELECT * FROM custom_role_permissions ORDER BY role_name, resource, action, scope`); err != nil {
+		return nil, pkgerrors.Wrap(err, "failed to list custom role permissions")
+	}
+	permissions := make(map[string][]sessions.Permission)
+	for _, p := range permissionRows {
+		permissions[p.RoleName] = append(permissions[p.RoleName], sessions.NewPermission(p.Resource, p.Action, p.Scope))
+	}
+	roles := []sessions.CustomRole{}
+	for _, row := range rows {
+		roles = append(roles, row.toCustomRole(permissions[row.Name]))
+	}
+	return roles, nil
+}
+
+func (o *orm) FindCustomRole(ctx context.Context, name string) (sessions.CustomRole, error) {
+	return o.findCustomRole(ctx, o.ds, `SELECT * FROM custom_roles WHERE name = $1`, name)
+}
+
+func (o *orm) FindCustomRoleByGroups(ctx context.Context, groups []string) (sessions.CustomRole, error) {
+	return o.findCustomRole(ctx, o.ds, `SELECT * FROM custom_roles WHERE group_name = ANY($1) ORDER BY name LIMIT 1`, pq.Array(groups))
+}
+
+func (o *orm) findCustomRole(ctx context.Context, ds sqlutil.DataSource, query string, args ...any) (sessions.CustomRole, error) {
+	var row customRoleRow
+	if err := ds.GetContext(ctx, &row, query, args...); err != nil {
+		if pkgerrors.Is(err, sql.ErrNoRows) {
+			return sessions.CustomRole{}, sessions.ErrCustomRoleNotFound
+		}
+		return sessions.CustomRole{}, pkgerrors.Wrap(err, "failed to find custom role")
+	}
+	permissions, err := o.permissions(ctx, ds, row.Name)
+	if err != nil {
+		return sessions.CustomRole{}, err
+	}
+	return row.toCustomRole(permissions), nil
+}
+
+func (o *orm) permissions(ctx context.Context, ds sqlutil.DataSource, name string) ([]sessions.Permission, error) {
+	var rows []permissionRow
+	if err := ds.SelectContext(ctx, &rows, `SELECT * FROM custom_role_permissions WHERE role_name = $1 ORDER BY resource, action, scope`, name); err != nil {
+		return nil, pkgerrors.Wrapf(err, "failed to load permissions of custom role %s", name)
+	}
+	permissions := []sessions.Permission{}
+	for _, p := range rows {
+		permissions = append(permissions, sessions.NewPermission(p.Resource, p.Action, p.Scope))
+	}
+	return permissions, nil
+}
+
+func (o *orm) CreateCustomRole(ctx context.Context, role *sessions.CustomRole) error {
+	if err := role.Validate(); err != nil {
+		return err
+	}
+	return sqlutil.TransactDataSource(ctx, o.ds, nil, func(tx sqlutil.DataSource) error {
+		var row customRoleRow
+		err := tx.GetContext(ctx, &row, `INSERT INTO custom_roles (name, description, group_name, created_at, updated_at)
+			VALUES ($1, $2, $3, now(), now()) RETURNING *`, role.Name, role.Description, role.Group)
+		if err != nil {
+			return pkgerrors.Wrap(err, "failed to create custom role")
+		}
+		if err = insertPermissions(ctx, tx, role.Name, role.Permissions); err != nil {
+			return err
+		}
+		*role = row.toCustomRole(role.Permissions)
+		return nil
+	})
+}
+
+func (o *orm) UpdateCustomRole(ctx context.Context, role *sessions.CustomRole) error {
+	if err := role.Validate(); err != nil {
+		return err
+	}
+	return sqlutil.TransactDataSource(ctx, o.ds, nil, func(tx sqlutil.DataSource) error {
+		var row customRoleRow
+		err := tx.GetContext(ctx, &row, `UPDATE custom_roles SET description = $2, group_name = $3, updated_at = now()
+			WHERE name = $1 RETURNING *`, role.Name, role.Description, role.Group)
+		if pkgerrors.Is(err, sql.ErrNoRows) {
+			return sessions.ErrCustomRoleNotFound
+		} else if err != nil {
+			return pkgerrors.Wrap(err, "failed to update custom role")
+		}
+		if _, err = tx.ExecContext(ctx, `DELETE FROM custom_role_permissions WHERE role_name = $1`, role.Name); err != nil {
+			return pkgerrors.Wrap(err, "failed to delete custom role permissions")
+		}
+		if err = insertPermissions(ctx, tx, role.Name, role.Permissions); err != nil {
+			return err
+		}
+		*role = row.toCustomRole(role.Permissions)
+		return nil
+	})
+}
+
+func insertPermissions(ctx context.Context, tx sqlutil.DataSource, name string, permissions []sessions.Permission) error {
+	for _, p := range permissions {
+		_, err := tx.ExecContext(ctx, `INSERT INTO custom_role_permissions (role_name, resource, action, scope)
+			VALUES ($1, $2, $3, $4) ON CONFLICT DO NOTHING`, name, p.Resource, p.Action, p.Scope)
+		if err != nil {
+			return pkgerrors.Wrapf(err, "failed to insert permission %s", p)
+		}
+	}
+	return nil
+}
+
+func (o *orm) DeleteCustomRole(ctx context.Context, name string) error {
+	result, err := o.ds.ExecContext(ctx, `DELETE FROM custom_roles WHERE name = $1`, name)
+	var pgErr *pgconn.PgError
+	if pkgerrors.As(err, &pgErr) && pgErr.Code == "23503" {
+		return pkgerrors.Wrapf(ErrCustomRoleAssigned, "can not delete custom role %s", name)
+	} else if err != nil {
+		return pkgerrors.Wrap(err, "failed to delete custom role")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sessions.ErrCustomRoleNotFound
+	}
+	return nil
+}
+
+func (o *orm) LoadPermissions(ctx context.Context, user *sessions.User) (err error) {
+	user.Permissions = nil
+	if !user.HasCustomRole() {
+		return nil
+	}
+	user.Permissions, err = o.permissions(ctx, o.ds, user.CustomRole.String)
+	return err
+}
@@ -0,0 +1,80 @@
+package customroles_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions/customroles"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions/localauth"
+)
+
+func TestORM_CustomRoles(t *testing.T) {
+	t.Parallel()
+	ctx := testutils.Context(t)
+
+	db := pgtest.NewSqlxDB(t)
+	orm := customroles.NewORM(db)
+
+	role := sessions.CustomRole{
+		Name:        "ocr-operator",
+		Description: "manages OCR2 jobs",
+		Group:       null.StringFrom("NodeOCROperators"),
+		Permissions: []sessions.Permission{
+			sessions.NewPermission(sessions.ResourceJobs, sessions.ActionAll, "offchainreporting2"),
+			sessions.NewPermission(sessions.ResourceKeys, sessions.ActionRead, "ocr2"),
+		},
+	}
+	require.NoError(t, orm.CreateCustomRole(ctx, &role))
+	assert.False(t, role.CreatedAt.IsZero())
+
+	dup := sessions.CustomRole{Name: role.Name}
+	require.Error(t, orm.CreateCustomRole(ctx, &dup))
+
+	found, err := orm.FindCustomRole(ctx, role.Name)
+	require.NoError(t, err)
+	assert.Equal(t, role.Description, found.Description)
+	assert.ElementsMatch(t, role.Permissions, found.Permissions)
+
+	found, err = orm.FindCustomRoleByGroups(ctx, []string{"NodeViewers", "NodeOCROperators"})
+	require.NoError(t, err)
+	assert.Equal(t, role.Name, found.Name)
+
+	_, err = orm.FindCustomRoleByGroups(ctx, []string{"NodeViewers"})
+	require.ErrorIs(t, err, sessions.ErrCustomRoleNotFound)
+
+	role.Permissions = []sessions.Permission{sessions.NewPermission(sessions.ResourceJobs, sessions.ActionRead, "")}
+	role.Group = null.String{}
+	require.NoError(t, orm.UpdateCustomRole(ctx, &role))
+
+	roles, err := orm.ListCustomRoles(ctx)
+	require.NoError(t, err)
+	require.Len(t, roles, 1)
+	assert.False(t, roles[0].Group.Valid)
+	assert.Equal(t, role.Permissions, roles[0].Permissions)
+
+	missing := sessions.CustomRole{Name: "missing"}
+	require.ErrorIs(t, orm.UpdateCustomRole(ctx, &missing), sessions.ErrCustomRoleNotFound)
+
+	user := cltest.MustRandomUser(t)
+	user.CustomRole = null.StringFrom(role.Name)
+	authORM := localauth.NewORM(db, time.Minute, logger.TestLogger(t), &audit.AuditLoggerService{})
+	require.NoError(t, authORM.CreateUser(ctx, &user))
+
+	require.NoError(t, orm.LoadPermissions(ctx, &user))
+	assert.Equal(t, role.Permissions, user.Permissions)
+
+	require.ErrorIs(t, orm.DeleteCustomRole(ctx, role.Name), customroles.ErrCustomRoleAssigned)
+	require.NoError(t, authORM.DeleteUser(ctx, user.Email))
+	require.NoError(t, orm.DeleteCustomRole(ctx, role.Name))
+	require.ErrorIs(t, orm.DeleteCustomRole(ctx, role.Name), sessions.ErrCustomRoleNotFound)
+}
@@ -7,6 +7,8 @@ This package relies on the two following local database tables:
 	ldap_sessions: 	Upon successful LDAP response, creates a keyed local copy of the user email
 	ldap_user_api_tokens: User created API tokens, tied to the node, storing user email.
 
+Members of an LDAP group mapped to a custom role are assigned that role, unless they are members of the admin group.
+
 Note: user can have only one API token at a time, and token expiration is enforced
 
 User session and roles are cached and revalidated with the upstream service at the interval defined in
@@ -33,6 +35,7 @@ import (
 	"time"
 
 	"github.com/go-ldap/ldap/v3"
+	"gopkg.in/guregu/null.v4"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
 	"github.com/smartcontractkit/chainlink-common/pkg/utils/mathutil"
@@ -42,6 +45,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
 	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions/customroles"
 	"github.com/smartcontractkit/chainlink/v2/core/utils"
 )
 
@@ -58,6 +62,7 @@ type ldapAuthenticator struct {
 	config      config.LDAP
 	lggr        logger.Logger
 	auditLogger audit.AuditLogger
+	customRoles customroles.ORM
 }
 
 // ldapAuthenticator implements sessions.AuthenticationProvider interface
@@ -93,6 +98,7 @@ func NewLDAPAuthenticator(
 		config:      ldapCfg,
 		lggr:        lggr.Named("LDAPAuthenticationProvider"),
 		auditLogger: auditLogger,
+		customRoles: customroles.NewORM(ds),
 	}
 
 	// Single override of library defined global
@@ -172,8 +178,11 @@ func (l *ldapAuthenticator) FindUser(ctx context.Context, email string) (session
 	if len(result.Entries) == 0 {
 		// Provided email is not present in upstream LDAP server, local admin CLI auth is supported
 		// So query and check the users table as well before failing
-		var localUserRole sessions.UserRole
-		if err = l.ds.GetContext(ctx, &localUserRole, "SELECT role FROM users WHERE email = $1", email); err != nil {
+		var localUser struct {
+			Role       sessions.UserRole
+			CustomRole null.String
+		}
+		if err = l.ds.GetContext(ctx, &localUser, "SELECT role, custom_role FROM users WHERE email = $1", email); err != nil {
 			// Above query for local user unsuccessful, return error
 			l.lggr.Warnf("No local users table user found with email %s", email)
 			return sessions.User{}, errors.New("no users found with provided email")
@@ -181,23 +190,19 @@ func (l *ldapAuthenticator) FindUser(ctx context.Context, email string) (session
 
 		// If the above query to the local users table was successful, return that local user's role
 		return sessions.User{
-			Email: email,
-			Role:  localUserRole,
+			Email:      email,
+			Role:       localUser.Role,
+			CustomRole: localUser.CustomRole,
 		}, nil
 	}
 
 	// Populate found user by email and role based on matched group names
-	userRole, err := l.groupSearchResultsToUserRole(result.Entries)
+	user, err := l.groupSearchResultsToUser(ctx, email, result.Entries)
 	if err != nil {
 		l.lggr.Warnf("User '%s' found but no matching assigned groups in LDAP to assume role", email)
 		return sessions.User{}, err
 	}
-
-	// Convert search result to sessions.User type with required fields
-	return sessions.User{
-		Email: email,
-		Role:  userRole,
-	}, nil
+	return user, nil
 }
 
 // FindUserByAPIToken retrieves a possible stored user and role from the ldap_user_api_tokens table store
@@ -210,12 +215,13 @@ func (l *ldapAuthenticator) FindUserByAPIToken(ctx context.Context, apiToken str
 	// no further upstream LDAP query is performed, sessions and tokens are synced against the upstream server
 	// via the UpstreamSyncInterval config and reaper.go sync implementation
 	var foundUserToken struct {
-		UserEmail string
-		UserRole  sessions.UserRole
-		Valid     bool
+		UserEmail  string
+		UserRole   sessions.UserRole
+		CustomRole null.String
+		Valid      bool
 	}
 	err := l.ds.GetContext(ctx, &foundUserToken,
-		"SELECT user_email, user_role, created_at + $2 >= now() as valid FROM ldap_user_api_tokens WHERE token_key = $1",
+		"SELECT user_email, user_role, custom_role, created_at + $2 >= now() as valid FROM ldap_user_api_tokens WHERE token_key = $1",
 		apiToken, l.config.UserAPITokenDuration().Duration(),
 	)
 	if err != nil {
@@ -228,10 +234,15 @@ func (l *ldapAuthenticator) FindUserByAPIToken(ctx context.Context, apiToken str
 		return sessions.User{}, sessions.ErrUserSessionExpired
 	}
 
-	return sessions.User{
-		Email: foundUserToken.UserEmail,
-		Role:  foundUserToken.UserRole,
-	}, nil
+	user := sessions.User{
+		Email:      foundUserToken.UserEmail,
+		Role:       foundUserToken.UserRole,
+		CustomRole: foundUserToken.CustomRole,
+	}
+	if err = l.customRoles.LoadPermissions(ctx, &user); err != nil {
+		return sessions.User{}, err
+	}
+	return user, nil
 }
 
 // ListUsers will load and return all active users in applicable LDAP groups, extended with local admin users as well
@@ -253,6 +264,13 @@ func (l *ldapAuthenticator) ListUsers(ctx context.Context) ([]sessions.User, err
 		l.lggr.Errorf("error in ldapGroupMembersListToUser: %v", err)
 		return users, errors.New("unable to list group users")
 	}
+	// Query for list of uniqueMember IDs present in groups mapped to custom roles, which take precedence over the
+	// remaining built-in roles
+	customRoleUsers, err := customRoleGroupMembersListToUser(ctx, l.customRoles, conn, l.config.GroupsDN(), l.config.BaseDN(), l.config.QueryTimeout(), l.lggr)
+	if err != nil {
+		l.lggr.Error("error in customRoleGroupMembersListToUser: ", err)
+		return users, errors.New("unable to list group users")
+	}
 	// Query for list of uniqueMember IDs present in Edit group
 	editUsers, err := l.ldapGroupMembersListToUser(conn, l.config.EditUserGroupCN(), sessions.UserRoleEdit)
 	if err != nil {
@@ -274,6 +292,7 @@ func (l *ldapAuthenticator) ListUsers(ctx context.Context) ([]sessions.User, err
 
 	// Aggregate full list
 	users = append(users, adminUsers...)
+	users = append(users, customRoleUsers...)
 	users = append(users, editUsers...)
 	users = append(users, runUsers...)
 	users = append(users, readUsers...)
@@ -348,12 +367,13 @@ func (l *ldapAuthenticator) AuthorizedUserWithSession(ctx context.Context, sessi
 	// Query the ldap_sessions table for given session ID, user role and email are cached so
 	// no further upstream LDAP query is performed
 	var foundSession struct {
-		UserEmail string
-		UserRole  sessions.UserRole
-		Valid     bool
+		UserEmail  string
+		UserRole   sessions.UserRole
+		CustomRole null.String
+		Valid      bool
 	}
 	if err := l.ds.GetContext(ctx, &foundSession,
-		"SELECT user_email, user_role, created_at + $2 >= now() as valid FROM ldap_sessions WHERE id = $1",
+		"SELECT user_email, user_role, custom_role, created_at + $2 >= now() as valid FROM ldap_sessions WHERE id = $1",
 		sessionID, l.config.SessionTimeout().Duration(),
 	); err != nil {
 		return sessions.User{}, sessions.ErrUserSessionExpired
@@ -365,10 +385,15 @@ func (l *ldapAuthenticator) AuthorizedUserWithSession(ctx context.Context, sessi
 		}
 		return sessions.User{}, sessions.ErrUserSessionExpired
 	}
-	return sessions.User{
-		Email: foundSession.UserEmail,
-		Role:  foundSession.UserRole,
-	}, nil
+	user := sessions.User{
+		Email:      foundSession.UserEmail,
+		Role:       foundSession.UserRole,
+		CustomRole: foundSession.CustomRole,
+	}
+	if err := l.customRoles.LoadPermissions(ctx, &user); err != nil {
+		return sessions.User{}, err
+	}
+	return user, nil
 }
 
 // DeleteUser is not supported for read only LDAP
@@ -439,10 +464,11 @@ func (l *ldapAuthenticator) CreateSession(ctx context.Context, sr sessions.Sessi
 	session := sessions.NewSession()
 	_, err = l.ds.ExecContext(
 		ctx,
-		"INSERT INTO ldap_sessions (id, user_email, user_role, localauth_user, created_at) VALUES ($1, $2, $3, $4, now())",
+		"INSERT INTO ldap_sessions (id, user_email, user_role, custom_role, localauth_user, created_at) VALUES ($1, $2, $3, $4, $5, now())",
 		session.ID,
 		strings.ToLower(sr.Email),
 		foundUser.Role,
+		foundUser.CustomRole,
 		isLocalUser,
 	)
 	if err != nil {
@@ -565,9 +591,10 @@ func (l *ldapAuthenticator) SetAuthToken(ctx context.Context, user *sessions.Use
 		// Create new API token for user
 		_, err = l.ds.ExecContext(
 			ctx,
-			"INSERT INTO ldap_user_api_tokens (user_email, user_role, localauth_user, token_key, token_salt, token_hashed_secret, created_at) VALUES ($1, $2, $3, $4, $5, $6, now())",
+			"INSERT INTO ldap_user_api_tokens (user_email, user_role, custom_role, localauth_user, token_key, token_salt, token_hashed_secret, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, now())",
 			user.Email,
 			user.Role,
+			user.CustomRole,
 			isLocalCLIAdmin,
 			token.AccessKey,
 			salt,
@@ -765,6 +792,67 @@ func ldapGroupMembersListToUser(
 	return users, nil
 }
 
+// customRoleGroupMembersListToUser queries the LDAP server given a conn for the members of the groups mapped to custom
+// roles, assigning each the custom role of its group. Groups missing in the LDAP directory are skipped. Reused by sync.go
+func customRoleGroupMembersListToUser(
+	ctx context.Context,
+	customRoles customroles.ORM,
+	conn LDAPConn,
+	groupsDN string,
+	baseDN string,
+	queryTimeout time.Duration,
+	lggr logger.Logger,
+) ([]sessions.User, error) {
+	users := []sessions.User{}
+	roles, err := customRoles.ListCustomRoles(ctx)
+	if err != nil {
+		return users, err
+	}
+	for _, role := range roles {
+		if !role.Group.Valid {
+			continue
+		}
+		members, err := ldapGroupMembersListToUser(conn, ldap.EscapeFilter(role.Group.String), sessions.UserRoleView, groupsDN, baseDN, queryTimeout, lggr)
+		if err != nil {
+			lggr.Warnf("Skipping group (%s) of custom role %s: %v", role.Group.String, role.Name, err)
+			continue
+		}
+		for _, member := range members {
+			member.CustomRole = null.StringFrom(role.Name)
+			users = append(users, member)
+		}
+	}
+	return users, nil
+}
+
+// groupSearchResultsToUser takes a list of LDAP group search result entries and returns the user with the associated
+// internal user role. Members of a group mapped to a custom role are assigned the custom role, unless they are members
+// of the admin group.
+func (l *ldapAuthenticator) groupSearchResultsToUser(ctx context.Context, email string, ldapGroups []*ldap.Entry) (sessions.User, error) {
+	userRole, roleErr := l.groupSearchResultsToUserRole(ldapGroups)
+	if roleErr == nil && userRole == sessions.UserRoleAdmin {
+		return sessions.User{Email: email, Role: userRole}, nil
+	}
+
+	groupCNs := make([]string, 0, len(ldapGroups))
+	for _, group := range ldapGroups {
+		groupCNs = append(groupCNs, group.GetAttributeValue("cn"))
+	}
+	customRole, err := l.customRoles.FindCustomRoleByGroups(ctx, groupCNs)
+	if err == nil {
+		return sessions.User{Email: email, Role: sessions.UserRoleView, CustomRole: null.StringFrom(customRole.Name)}, nil
+	}
+	if !errors.Is(err, sessions.ErrCustomRoleNotFound) {
+		l.lggr.Errorf("error finding custom role of LDAP groups: %v", err)
+		return sessions.User{}, errors.New("error finding custom role of user")
+	}
+
+	if roleErr != nil {
+		return sessions.User{}, roleErr
+	}
+	return sessions.User{Email: email, Role: userRole}, nil
+}
+
 // groupSearchResultsToUserRole takes a list of LDAP group search result entries and returns the associated
 // internal user role based on the group name mappings defined in the configuration
 func (l *ldapAuthenticator) groupSearchResultsToUserRole(ldapGroups []*ldap.Entry) (sessions.UserRole, error) {
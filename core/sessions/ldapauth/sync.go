@@ -14,10 +14,12 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/config"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions/customroles"
 )
 
 type LDAPServerStateSyncer struct {
 	ds           sqlutil.DataSource
+	customRoles  customroles.ORM
 	ldapClient   LDAPClient
 	config       config.LDAP
 	lggr         logger.Logger
@@ -33,12 +35,13 @@ func NewLDAPServerStateSyncer(
 	lggr logger.Logger,
 ) *LDAPServerStateSyncer {
 	return &LDAPServerStateSyncer{
-		ds:         ds,
-		ldapClient: newLDAPClient(config),
-		config:     config,
-		lggr:       lggr.Named("LDAPServerStateSync"),
-		done:       make(chan struct{}),
-		stopCh:     make(services.StopChan),
+		ds:          ds,
+		customRoles: customroles.NewORM(ds),
+		ldapClient:  newLDAPClient(config),
+		config:      config,
+		lggr:        lggr.Named("LDAPServerStateSync"),
+		done:        make(chan struct{}),
+		stopCh:      make(services.StopChan),
 	}
 }
 
@@ -135,6 +138,12 @@ func (l *LDAPServerStateSyncer) Work(ctx context.Context) {
 		l.lggr.Error("Error in ldapGroupMembersListToUser: ", err)
 		return
 	}
+	// Query for list of uniqueMember IDs present in groups mapped to custom roles
+	customRoleUsers, err := customRoleGroupMembersListToUser(ctx, l.customRoles, conn, l.config.GroupsDN(), l.config.BaseDN(), l.config.QueryTimeout(), l.lggr)
+	if err != nil {
+		l.lggr.Error("Error in customRoleGroupMembersListToUser: ", err)
+		return
+	}
 	// Query for list of uniqueMember IDs present in Edit group
 	editUsers, err := l.ldapGroupMembersListToUser(conn, l.config.EditUserGroupCN(), sessions.UserRoleEdit)
 	if err != nil {
@@ -155,6 +164,7 @@ func (l *LDAPServerStateSyncer) Work(ctx context.Context) {
 	}
 
 	users = append(users, adminUsers...)
+	users = append(users, customRoleUsers...)
 	users = append(users, editUsers...)
 	users = append(users, runUsers...)
 	users = append(users, readUsers...)
@@ -243,6 +253,7 @@ func (l *LDAPServerStateSyncer) Work(ctx context.Context) {
 
 		// For each user session row, update role to match state of user map from upstream source
 		queryWhenClause := ""
+		customRoleWhenClause := ""
 		emailValues := []interface{}{}
 		// Prepare CASE WHEN query statement with parameterized argument $n placeholders and matching role based on index
 		for email, user := range upstreamUserStateMap {
@@ -254,19 +265,21 @@ func (l *LDAPServerStateSyncer) Work(ctx context.Context) {
 			}
 			emailValues = append(emailValues, email)
 			queryWhenClause += fmt.Sprintf("WHEN user_email = $%d THEN '%s' ", len(emailValues), user.Role)
+			emailValues = append(emailValues, user.CustomRole)
+			customRoleWhenClause += fmt.Sprintf("WHEN user_email = $%d THEN $%d::text ", len(emailValues)-1, len(emailValues))
 		}
 
 		// If there are remaining user entries to update
 		if len(emailValues) != 0 {
 			// Set new role state for all rows in single Exec
-			query := fmt.Sprintf("UPDATE ldap_sessions SET user_role = CASE %s ELSE user_role END", queryWhenClause)
+			query := fmt.Sprintf("UPDATE ldap_sessions SET user_role = CASE %s ELSE user_role END, custom_role = CASE %s ELSE custom_role END", queryWhenClause, customRoleWhenClause)
 			_, err = tx.ExecContext(ctx, query, emailValues...)
 			if err != nil {
 				return err
 			}
 
 			// Update role of API tokens as well
-			query = fmt.Sprintf("UPDATE ldap_user_api_tokens SET user_role = CASE %s ELSE user_role END", queryWhenClause)
+			query = fmt.Sprintf("UPDATE ldap_user_api_tokens SET user_role = CASE %s ELSE user_role END, custom_role = CASE %s ELSE custom_role END", queryWhenClause, customRoleWhenClause)
 			_, err = tx.ExecContext(ctx, query, emailValues...)
 			if err != nil {
 				return err
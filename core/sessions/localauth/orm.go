@@ -8,6 +8,7 @@ import (
 	"time"
 
 	pkgerrors "github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
 	"github.com/smartcontractkit/chainlink-common/pkg/utils/mathutil"
@@ -16,6 +17,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
 	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions/customroles"
 	"github.com/smartcontractkit/chainlink/v2/core/utils"
 )
 
@@ -24,6 +26,7 @@ type orm struct {
 	sessionDuration time.Duration
 	lggr            logger.Logger
 	auditLogger     audit.AuditLogger
+	customRoles     customroles.ORM
 }
 
 // orm implements sessions.AuthenticationProvider and sessions.BasicAdminUsersORM interfaces
@@ -36,6 +39,7 @@ func NewORM(ds sqlutil.DataSource, sd time.Duration, lggr logger.Logger, auditLo
 		sessionDuration: sd,
 		lggr:            lggr.Named("LocalAuthAuthenticationProviderORM"),
 		auditLogger:     auditLogger,
+		customRoles:     customroles.NewORM(ds),
 	}
 }
 
@@ -47,7 +51,10 @@ func (o *orm) FindUser(ctx context.Context, email string) (sessions.User, error)
 // FindUserByAPIToken will attempt to return an API user via the user's table token_key column.
 func (o *orm) FindUserByAPIToken(ctx context.Context, apiToken string) (user sessions.User, err error) {
 	sql := "SELECT * FROM users WHERE token_key = $1"
-	err = o.ds.GetContext(ctx, &user, sql, apiToken)
+	if err = o.ds.GetContext(ctx, &user, sql, apiToken); err != nil {
+		return
+	}
+	err = o.customRoles.LoadPermissions(ctx, &user)
 	return
 }
 
@@ -98,6 +105,10 @@ func (o *orm) AuthorizedUserWithSession(ctx context.Context, sessionID string) (
 		return sessions.User{}, sessions.ErrUserSessionExpired
 	}
 
+	if err := o.customRoles.LoadPermissions(ctx, &user); err != nil {
+		return sessions.User{}, err
+	}
+
 	if err := o.updateSessionLastUsed(ctx, sessionID); err != nil {
 		return sessions.User{}, err
 	}
@@ -112,6 +123,11 @@ func (o *orm) DeleteUser(ctx context.Context, email string) error {
 		if _, err := tx.ExecContext(ctx, "DELETE FROM users WHERE email = $1", email); err != nil {
 			return err
 		}
+		// scoped API tokens are not tied to the users table, as they are also issued to LDAP and OIDC users, and are
+		// revoked rather than deleted to keep them for auditing
+		if _, err := tx.ExecContext(ctx, "UPDATE api_tokens SET revoked_at = now() WHERE lower(user_email) = lower($1) AND revoked_at IS NULL", email); err != nil {
+			return err
+		}
 		return nil
 	})
 }
@@ -247,11 +263,12 @@ func (o *orm) ClearNonCurrentSessions(ctx context.Context, sessionID string) err
 
 // CreateUser creates a new API user
 func (o *orm) CreateUser(ctx context.Context, user *sessions.User) error {
-	sql := "INSERT INTO users (email, hashed_password, role, created_at, updated_at) VALUES ($1, $2, $3, now(), now()) RETURNING *"
-	return o.ds.GetContext(ctx, user, sql, strings.ToLower(user.Email), user.HashedPassword, user.Role)
+	sql := "INSERT INTO users (email, hashed_password, role, custom_role, created_at, updated_at) VALUES ($1, $2, $3, $4, now(), now()) RETURNING *"
+	return o.ds.GetContext(ctx, user, sql, strings.ToLower(user.Email), user.HashedPassword, user.Role, user.CustomRole)
 }
 
-// UpdateRole overwrites role field of the user specified by email.
+// UpdateRole overwrites role field of the user specified by email. A newRole which is not a built-in role is assigned
+// as the custom role of the user, whose built-in role is then reset to view.
 func (o *orm) UpdateRole(ctx context.Context, email, newRole string) (sessions.User, error) {
 	var userToEdit sessions.User
 
@@ -267,10 +284,19 @@ func (o *orm) UpdateRole(ctx context.Context, email, newRole string) (sessions.U
 
 		// Patch validated role
 		userRole, err := sessions.GetUserRole(newRole)
-		if err != nil {
-			return err
+		if err == nil {
+			userToEdit.Role = userRole
+			userToEdit.CustomRole = null.String{}
+		} else {
+			if _, err2 := o.customRoles.FindCustomRole(ctx, newRole); err2 != nil {
+				if pkgerrors.Is(err2, sessions.ErrCustomRoleNotFound) {
+					return err
+				}
+				return err2
+			}
+			userToEdit.Role = sessions.UserRoleView
+			userToEdit.CustomRole = null.StringFrom(newRole)
 		}
-		userToEdit.Role = userRole
 
 		_, err = tx.ExecContext(ctx, "DELETE FROM sessions WHERE email = lower($1)", email)
 		if err != nil {
@@ -278,8 +304,8 @@ func (o *orm) UpdateRole(ctx context.Context, email, newRole string) (sessions.U
 			return pkgerrors.New("error updating API user")
 		}
 
-		sql := "UPDATE users SET role = $1, updated_at = now() WHERE lower(email) = lower($2) RETURNING *"
-		if err := tx.GetContext(ctx, &userToEdit, sql, userToEdit.Role, email); err != nil {
+		sql := "UPDATE users SET role = $1, custom_role = $2, updated_at = now() WHERE lower(email) = lower($3) RETURNING *"
+		if err := tx.GetContext(ctx, &userToEdit, sql, userToEdit.Role, userToEdit.CustomRole, email); err != nil {
 			o.lggr.Errorw("Error updating API user", "err", err)
 			return pkgerrors.New("error updating API user")
 		}
@@ -20,6 +20,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
 	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions/apitokens"
 	"github.com/smartcontractkit/chainlink/v2/core/sessions/localauth"
 	"github.com/smartcontractkit/chainlink/v2/core/utils"
 )
@@ -147,6 +148,17 @@ func TestORM_DeleteUserCascade(t *testing.T) {
 	_, err := db.Exec("INSERT INTO sessions (id, email, last_used, created_at) VALUES ($1, $2, now(), now())", session.ID, u.Email)
 	require.NoError(t, err)
 
+	tokensORM := apitokens.NewORM(db)
+	token := sessions.APIToken{
+		Name:      "ci-deploy",
+		UserEmail: u.Email,
+		UserRole:  u.Role,
+		Scopes:    []sessions.Permission{sessions.NewPermission(sessions.ResourceJobs, sessions.ActionRead, "")},
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	_, err = tokensORM.CreateAPIToken(ctx, &token)
+	require.NoError(t, err)
+
 	err = orm.DeleteUser(ctx, u.Email)
 	require.NoError(t, err)
 
@@ -156,6 +168,11 @@ func TestORM_DeleteUserCascade(t *testing.T) {
 	sessions, err := orm.Sessions(ctx, 0, 10)
 	assert.NoError(t, err)
 	require.Empty(t, sessions)
+
+	// API tokens are revoked, and kept for auditing
+	token, err = tokensORM.FindAPIToken(ctx, token.ID)
+	require.NoError(t, err)
+	assert.True(t, token.RevokedAt.Valid)
 }
 
 func TestORM_CreateSession(t *testing.T) {
@@ -0,0 +1,329 @@
+package oidcauth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2"
+
+	"github.com/smartcontractkit/chainlink/v2/core/config"
+)
+
+const (
+	// clockSkew is the leeway allowed when validating the time claims of tokens
+	clockSkew = time.Minute
+	// minKeySetRefreshInterval limits how often the key set is fetched again for tokens signed by an unknown key
+	minKeySetRefreshInterval = time.Minute
+	// minDiscoveryRetryInterval limits how often the discovery of an unreachable provider is retried
+	minDiscoveryRetryInterval = 10 * time.Second
+	// maxResponseSize limits the size of the discovery document and key set read from the provider
+	maxResponseSize = 1 << 20
+)
+
+// signingMethods are the asymmetric JWS algorithms accepted for tokens of the provider
+var signingMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+// providerMetadata is the subset of the OpenID Connect discovery document of an issuer used by the node.
+type providerMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// identityProvider exchanges authorization codes with an OpenID Connect issuer, and validates the tokens it issues.
+type identityProvider struct {
+	metadata providerMetadata
+	oauth2   oauth2.Config
+	keys     *keySet
+	client   *http.Client
+}
+
+// discoverProvider fetches the discovery document of the configured issuer and sets up the provider from it.
+func discoverProvider(ctx context.Context, client *http.Client, cfg config.OIDC) (*identityProvider, error) {
+	issuer := strings.TrimSuffix(cfg.IssuerURL(), "/")
+	var metadata providerMetadata
+	if err := getJSON(ctx, client, issuer+"/.well-known/openid-configuration", &metadata); err != nil {
+		return nil, fmt.Errorf("failed to fetch OIDC discovery document: %w", err)
+	}
+	if strings.TrimSuffix(metadata.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("OIDC discovery document issuer %q does not match IssuerURL %q", metadata.Issuer, cfg.IssuerURL())
+	}
+	if metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" || metadata.JWKSURI == "" {
+		return nil, errors.New("OIDC discovery document is missing the authorization, token or JWKS endpoint")
+	}
+	return &identityProvider{
+		metadata: metadata,
+		oauth2: oauth2.Config{
+			ClientID:     cfg.ClientID(),
+			ClientSecret: cfg.ClientSecret(),
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  metadata.AuthorizationEndpoint,
+				TokenURL: metadata.TokenEndpoint,
+			},
+			RedirectURL: cfg.RedirectURL(),
+			Scopes:      cfg.Scopes(),
+		},
+		keys:   newKeySet(client, metadata.JWKSURI),
+		client: client,
+	}, nil
+}
+
+// lazyProvider discovers the provider on first use, so that the node starts, and local users can log in, while the
+// issuer is unreachable. A failed discovery is retried on use at most once every retryInterval.
+type lazyProvider struct {
+	client        *http.Client
+	cfg           config.OIDC
+	retryInterval time.Duration
+
+	mu          sync.Mutex
+	provider    *identityProvider
+	err         error
+	lastAttempt time.Time
+}
+
+func newLazyProvider(client *http.Client, cfg config.OIDC) *lazyProvider {
+	return &lazyProvider{client: client, cfg: cfg, retryInterval: minDiscoveryRetryInterval}
+}
+
+// get returns the provider, discovering it unless it was already, or unless the last discovery failed less than
+// retryInterval ago, in which case its error is returned.
+func (l *lazyProvider) get(ctx context.Context) (*identityProvider, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.provider != nil {
+		return l.provider, nil
+	}
+	if l.err != nil && time.Since(l.lastAttempt) < l.retryInterval {
+		return nil, l.err
+	}
+	// the discovery is not canceled with the request, so that its outcome is shared with the following ones
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), l.cfg.ProviderTimeout())
+	defer cancel()
+	l.lastAttempt = time.Now()
+	l.provider, l.err = discoverProvider(ctx, l.client, l.cfg)
+	if l.err != nil {
+		l.err = fmt.Errorf("unable to discover OIDC provider with provided IssuerURL: %w", l.err)
+	}
+	return l.provider, l.err
+}
+
+// authCodeURL returns the URL of the authorization endpoint starting an authorization code flow with PKCE.
+func (p *identityProvider) authCodeURL(state, nonce, verifier string) string {
+	return p.oauth2.AuthCodeURL(state, oauth2.S256ChallengeOption(verifier), oauth2.SetAuthURLParam("nonce", nonce))
+}
+
+// exchange redeems an authorization code with its PKCE verifier, and returns the raw ID token of the response.
+func (p *identityProvider) exchange(ctx context.Context, code, verifier string) (string, error) {
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, p.client)
+	token, err := p.oauth2.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return "", fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+	idToken, ok := token.Extra("id_token").(string)
+	if !ok || idToken == "" {
+		return "", errors.New("token response has no ID token")
+	}
+	return idToken, nil
+}
+
+// verify validates the signature, issuer, audience and lifetime of a token, and returns its claims.
+func (p *identityProvider) verify(ctx context.Context, rawToken, audience string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(rawToken, claims,
+		func(t *jwt.Token) (any, error) {
+			kid, _ := t.Header["kid"].(string)
+			return p.keys.key(ctx, kid)
+		},
+		jwt.WithValidMethods(signingMethods),
+		jwt.WithIssuer(p.metadata.Issuer),
+		jwt.WithAudience(audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(clockSkew),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+	return claims, nil
+}
+
+// keySet caches the signing keys of the provider by key ID. The keys are fetched again when a token is signed by an
+// unknown key, as after the provider rotated its keys, at most once every minKeySetRefreshInterval.
+type keySet struct {
+	client *http.Client
+	url    string
+
+	mu        sync.Mutex
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+}
+
+func newKeySet(client *http.Client, url string) *keySet {
+	return &keySet{client: client, url: url}
+}
+
+// key returns the signing key with kid, which may be empty if the provider publishes a single key.
+func (s *keySet) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if key, ok := s.lookup(kid); ok {
+		return key, nil
+	}
+	if time.Since(s.fetchedAt) < minKeySetRefreshInterval {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	if err := s.fetch(ctx); err != nil {
+		return nil, err
+	}
+	if key, ok := s.lookup(kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+func (s *keySet) lookup(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key, true
+		}
+	}
+	key, ok := s.keys[kid]
+	return key, ok
+}
+
+// fetch replaces the cached keys with the signing keys of the set. Keys of unsupported types are skipped.
+func (s *keySet) fetch(ctx context.Context) error {
+	s.fetchedAt = time.Now()
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := getJSON(ctx, s.client, s.url, &set); err != nil {
+		return fmt.Errorf("failed to fetch OIDC JWKS: %w", err)
+	}
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			continue
+		}
+		keys[k.Kid] = key
+	}
+	if len(keys) == 0 {
+		return errors.New("OIDC JWKS has no supported signing keys")
+	}
+	s.keys = keys
+	return nil
+}
+
+// jsonWebKey is an RSA or EC public key of a JSON Web Key Set, as defined by RFC 7517 and RFC 7518.
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return nil, errors.New("invalid RSA exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		var ecdhCurve ecdh.Curve
+		switch k.Crv {
+		case "P-256":
+			curve, ecdhCurve = elliptic.P256(), ecdh.P256()
+		case "P-384":
+			curve, ecdhCurve = elliptic.P384(), ecdh.P384()
+		case "P-521":
+			curve, ecdhCurve = elliptic.P521(), ecdh.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		// Reject points which are not on the curve, using the uncompressed encoding validated by crypto/ecdh
+		size := (curve.Params().BitSize + 7) / 8
+		if len(x.Bytes()) > size || len(y.Bytes()) > size {
+			return nil, errors.New("invalid EC point")
+		}
+		point := make([]byte, 1+2*size)
+		point[0] = 4
+		x.FillBytes(point[1 : 1+size])
+		y.FillBytes(point[1+size:])
+		if _, err := ecdhCurve.NewPublicKey(point); err != nil {
+			return nil, fmt.Errorf("invalid EC point: %w", err)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key parameter: %w", err)
+	}
+	if len(b) == 0 {
+		return nil, errors.New("empty key parameter")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+func getJSON(ctx context.Context, client *http.Client, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response status %s from %s", resp.Status, url)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(v)
+}
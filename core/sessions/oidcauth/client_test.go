@@ -0,0 +1,158 @@
+package oidcauth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+)
+
+func TestIdentityProvider_Discover(t *testing.T) {
+	t.Parallel()
+	ctx := testutils.Context(t)
+	idp := NewMockIdentityProvider(t)
+
+	p, err := discoverProvider(ctx, http.DefaultClient, &TestConfig{IssuerURLValue: idp.URL + "/"})
+	require.NoError(t, err)
+	assert.Equal(t, idp.URL+"/token", p.oauth2.Endpoint.TokenURL)
+
+	_, err = discoverProvider(ctx, http.DefaultClient, &TestConfig{IssuerURLValue: idp.URL + "/realms/other"})
+	require.ErrorContains(t, err, "failed to fetch OIDC discovery document")
+}
+
+func TestLazyProvider(t *testing.T) {
+	t.Parallel()
+	ctx := testutils.Context(t)
+	idp := NewMockIdentityProvider(t)
+	idp.SetUnavailable(true)
+
+	l := newLazyProvider(http.DefaultClient, &TestConfig{IssuerURLValue: idp.URL})
+	_, err := l.get(ctx)
+	require.ErrorContains(t, err, "unable to discover OIDC provider")
+
+	// failures are not retried within the retry interval
+	idp.SetUnavailable(false)
+	_, err = l.get(ctx)
+	require.ErrorContains(t, err, "unable to discover OIDC provider")
+	assert.Equal(t, 1, idp.Discoveries())
+
+	l.retryInterval = 0
+	p, err := l.get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, idp.URL+"/token", p.oauth2.Endpoint.TokenURL)
+
+	// the provider is only discovered once
+	cached, err := l.get(ctx)
+	require.NoError(t, err)
+	assert.Same(t, p, cached)
+	assert.Equal(t, 2, idp.Discoveries())
+}
+
+func TestIdentityProvider_Verify(t *testing.T) {
+	t.Parallel()
+	ctx := testutils.Context(t)
+	idp := NewMockIdentityProvider(t)
+	p, err := discoverProvider(ctx, http.DefaultClient, &TestConfig{IssuerURLValue: idp.URL})
+	require.NoError(t, err)
+
+	claims, err := p.verify(ctx, idp.IssueToken(jwt.MapClaims{"aud": TestAudience, "email": "user@example.com"}), TestAudience)
+	require.NoError(t, err)
+	assert.Equal(t, "user@example.com", claims["email"])
+
+	tests := []struct {
+		name   string
+		token  string
+		errMsg string
+	}{
+		{"wrong audience", idp.IssueToken(jwt.MapClaims{"aud": TestClientID}), "token has invalid audience"},
+		{"wrong issuer", idp.IssueToken(jwt.MapClaims{"aud": TestAudience, "iss": "https://evil.example.com"}), "token has invalid issuer"},
+		{"expired", idp.IssueToken(jwt.MapClaims{"aud": TestAudience, "exp": time.Now().Add(-2 * clockSkew).Unix()}), "token is expired"},
+		{"no expiry", idp.IssueToken(jwt.MapClaims{"aud": TestAudience, "exp": nil}), "token is missing required claim"},
+		{"symmetric", hmacToken(t, idp.URL), "signing method HS256 is invalid"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := p.verify(ctx, test.token, TestAudience)
+			require.ErrorContains(t, err, test.errMsg)
+		})
+	}
+
+	t.Run("rotated key", func(t *testing.T) {
+		idp.RotateKey()
+		token := idp.IssueToken(jwt.MapClaims{"aud": TestAudience})
+		// The key set was just fetched, so it is not fetched again until minKeySetRefreshInterval elapsed
+		_, err := p.verify(ctx, token, TestAudience)
+		require.ErrorContains(t, err, "unknown signing key")
+
+		p.keys.mu.Lock()
+		p.keys.fetchedAt = time.Now().Add(-minKeySetRefreshInterval)
+		p.keys.mu.Unlock()
+		_, err = p.verify(ctx, token, TestAudience)
+		require.NoError(t, err)
+	})
+}
+
+func hmacToken(t *testing.T, issuer string) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iss": issuer,
+		"aud": TestAudience,
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+	signed, err := token.SignedString([]byte("secret"))
+	require.NoError(t, err)
+	return signed
+}
+
+func TestJSONWebKey_PublicKey(t *testing.T) {
+	t.Parallel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ec := jsonWebKey{
+		Kty: "EC",
+		Crv: "P-256",
+		X:   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, 32))),
+		Y:   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, 32))),
+	}
+	pub, err := ec.publicKey()
+	require.NoError(t, err)
+	assert.True(t, key.PublicKey.Equal(pub))
+
+	offCurve := ec
+	offCurve.Y = ec.X
+	_, err = offCurve.publicKey()
+	require.ErrorContains(t, err, "invalid EC point")
+
+	_, err = jsonWebKey{Kty: "EC", Crv: "P-192", X: ec.X, Y: ec.Y}.publicKey()
+	require.ErrorContains(t, err, "unsupported curve")
+
+	_, err = jsonWebKey{Kty: "oct"}.publicKey()
+	require.ErrorContains(t, err, "unsupported key type")
+
+	_, err = jsonWebKey{Kty: "RSA", N: "AQAB", E: "AQ"}.publicKey()
+	require.ErrorContains(t, err, "invalid RSA exponent")
+}
+
+func TestClaimStrings(t *testing.T) {
+	t.Parallel()
+
+	claims := jwt.MapClaims{
+		"email":        "user@example.com",
+		"groups":       []any{"NodeAdmins", 42, "NodeEditors"},
+		"realm_access": map[string]any{"roles": []any{"NodeRunners"}},
+	}
+	assert.Equal(t, []string{"user@example.com"}, claimStrings(claims, "email"))
+	assert.Equal(t, []string{"NodeAdmins", "NodeEditors"}, claimStrings(claims, "groups"))
+	assert.Equal(t, []string{"NodeRunners"}, claimStrings(claims, "realm_access.roles"))
+	assert.Nil(t, claimStrings(claims, "email.domain"))
+	assert.Nil(t, claimStrings(claims, "missing"))
+}
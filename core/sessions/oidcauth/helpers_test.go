@@ -0,0 +1,265 @@
+package oidcauth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+
+	commonconfig "github.com/smartcontractkit/chainlink-common/pkg/config"
+)
+
+// Default group name mappings of the test config
+const (
+	NodeAdminsGroup   = "NodeAdmins"
+	NodeEditorsGroup  = "NodeEditors"
+	NodeRunnersGroup  = "NodeRunners"
+	NodeReadOnlyGroup = "NodeReadOnly"
+
+	TestClientID = "chainlink-node"
+	TestAudience = "chainlink-api"
+)
+
+// TestConfig implements config.OIDC for the MockIdentityProvider at IssuerURLValue
+type TestConfig struct {
+	IssuerURLValue      string
+	SessionTimeoutValue time.Duration
+	// NoAudience disables bearer tokens
+	NoAudience bool
+}
+
+func (t *TestConfig) IssuerURL() string    { return t.IssuerURLValue }
+func (t *TestConfig) ClientID() string     { return TestClientID }
+func (t *TestConfig) ClientSecret() string { return "" }
+func (t *TestConfig) RedirectURL() string  { return "http://localhost:6688/oidc/callback" }
+func (t *TestConfig) Scopes() []string     { return []string{"openid", "email", "groups"} }
+func (t *TestConfig) EmailClaim() string   { return "email" }
+func (t *TestConfig) GroupsClaim() string  { return "groups" }
+
+func (t *TestConfig) Audience() string {
+	if t.NoAudience {
+		return ""
+	}
+	return TestAudience
+}
+
+func (t *TestConfig) AdminUserGroup() string { return NodeAdminsGroup }
+func (t *TestConfig) EditUserGroup() string  { return NodeEditorsGroup }
+func (t *TestConfig) RunUserGroup() string   { return NodeRunnersGroup }
+func (t *TestConfig) ReadUserGroup() string  { return NodeReadOnlyGroup }
+
+func (t *TestConfig) SessionTimeout() commonconfig.Duration {
+	if t.SessionTimeoutValue == 0 {
+		return *commonconfig.MustNewDuration(time.Hour)
+	}
+	return *commonconfig.MustNewDuration(t.SessionTimeoutValue)
+}
+
+func (t *TestConfig) ProviderTimeout() time.Duration { return 5 * time.Second }
+
+// MockIdentityProvider is a local OpenID Connect identity provider, serving discovery, JWKS, authorization and token
+// endpoints. Its authorization endpoint authenticates every request as the user of Claims.
+type MockIdentityProvider struct {
+	*httptest.Server
+	t *testing.T
+
+	mu sync.Mutex
+	// Claims are the claims of the user of the ID tokens issued by the token endpoint
+	Claims jwt.MapClaims
+	// TokenLifetime is the lifetime of the ID tokens issued by the token endpoint
+	TokenLifetime time.Duration
+	keyID         string
+	key           *rsa.PrivateKey
+	codes         map[string]authorization
+	unavailable   bool
+	discoveries   int
+}
+
+type authorization struct {
+	nonce, challenge string
+}
+
+func NewMockIdentityProvider(t *testing.T) *MockIdentityProvider {
+	p := &MockIdentityProvider{
+		t:             t,
+		TokenLifetime: time.Hour,
+		codes:         map[string]authorization{},
+	}
+	p.RotateKey()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", p.discovery)
+	mux.HandleFunc("/jwks", p.jwks)
+	mux.HandleFunc("/authorize", p.authorize)
+	mux.HandleFunc("/token", p.token)
+	p.Server = httptest.NewServer(mux)
+	t.Cleanup(p.Close)
+	return p
+}
+
+// RotateKey replaces the signing key of the provider with a new key.
+func (p *MockIdentityProvider) RotateKey() {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(p.t, err)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.key = key
+	p.keyID = base64.RawURLEncoding.EncodeToString(key.N.Bytes()[:8])
+}
+
+// SetUnavailable makes the discovery endpoint of the provider fail while unavailable is true.
+func (p *MockIdentityProvider) SetUnavailable(unavailable bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.unavailable = unavailable
+}
+
+// Discoveries returns the number of requests served by the discovery endpoint.
+func (p *MockIdentityProvider) Discoveries() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.discoveries
+}
+
+// SetClaims sets the claims of the user authenticated by the authorization endpoint.
+func (p *MockIdentityProvider) SetClaims(claims jwt.MapClaims) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.Claims = claims
+}
+
+// IssueToken returns a token signed by the provider with claims. The iss, iat and exp claims are set unless present,
+// and claims set to nil are omitted.
+func (p *MockIdentityProvider) IssueToken(claims jwt.MapClaims) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.issueToken(claims)
+}
+
+func (p *MockIdentityProvider) issueToken(claims jwt.MapClaims) string {
+	all := jwt.MapClaims{
+		"iss": p.URL,
+		"iat": time.Now().Unix(),
+		"exp": time.Now().Add(p.TokenLifetime).Unix(),
+	}
+	for k, v := range claims {
+		if v == nil {
+			delete(all, k)
+			continue
+		}
+		all[k] = v
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, all)
+	token.Header["kid"] = p.keyID
+	signed, err := token.SignedString(p.key)
+	require.NoError(p.t, err)
+	return signed
+}
+
+// Authorize follows the authorization URL of a login as the user of Claims, and returns the state and code the provider
+// redirects back with.
+func (p *MockIdentityProvider) Authorize(authURL string) (state, code string) {
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := client.Get(authURL)
+	require.NoError(p.t, err)
+	defer resp.Body.Close()
+	require.Equal(p.t, http.StatusFound, resp.StatusCode)
+	location, err := resp.Location()
+	require.NoError(p.t, err)
+	return location.Query().Get("state"), location.Query().Get("code")
+}
+
+func (p *MockIdentityProvider) discovery(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	p.discoveries++
+	unavailable := p.unavailable
+	p.mu.Unlock()
+	if unavailable {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	writeJSON(w, map[string]string{
+		"issuer":                 p.URL,
+		"authorization_endpoint": p.URL + "/authorize",
+		"token_endpoint":         p.URL + "/token",
+		"jwks_uri":               p.URL + "/jwks",
+	})
+}
+
+func (p *MockIdentityProvider) jwks(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	writeJSON(w, map[string]any{"keys": []map[string]string{{
+		"kty": "RSA",
+		"kid": p.keyID,
+		"use": "sig",
+		"alg": "RS256",
+		"n":   base64.RawURLEncoding.EncodeToString(p.key.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(p.key.E)).Bytes()),
+	}}})
+}
+
+func (p *MockIdentityProvider) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("response_type") != "code" || q.Get("code_challenge_method") != "S256" || q.Get("client_id") != TestClientID {
+		http.Error(w, "invalid authorization request", http.StatusBadRequest)
+		return
+	}
+	code, err := randomString()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	p.mu.Lock()
+	p.codes[code] = authorization{nonce: q.Get("nonce"), challenge: q.Get("code_challenge")}
+	p.mu.Unlock()
+	redirect, err := url.Parse(q.Get("redirect_uri"))
+	if err != nil {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+	redirect.RawQuery = url.Values{"state": {q.Get("state")}, "code": {code}}.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+func (p *MockIdentityProvider) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "authorization_code" {
+		http.Error(w, `{"error":"invalid_request"}`, http.StatusBadRequest)
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	code := r.PostForm.Get("code")
+	authz, ok := p.codes[code]
+	delete(p.codes, code)
+	challenge := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !ok || base64.RawURLEncoding.EncodeToString(challenge[:]) != authz.challenge {
+		http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+		return
+	}
+	claims := jwt.MapClaims{"aud": TestClientID, "nonce": authz.nonce}
+	for k, v := range p.Claims {
+		claims[k] = v
+	}
+	writeJSON(w, map[string]any{
+		"access_token": p.issueToken(jwt.MapClaims{"aud": TestAudience, "email": p.Claims["email"], "email_verified": p.Claims["email_verified"], "groups": p.Claims["groups"]}),
+		"token_type":   "Bearer",
+		"expires_in":   int(p.TokenLifetime.Seconds()),
+		"id_token":     p.issueToken(claims),
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
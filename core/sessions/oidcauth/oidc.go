@@ -0,0 +1,518 @@
+/*
+The OIDC authentication package delegates authentication of operator UI and API users to an OpenID Connect identity
+provider.
+
+Operator UI users log in by the authorization code flow with PKCE: StartLogin redirects them to the provider, which
+redirects them back with a code redeemed by CreateSessionFromAuthCode for an ID token. API clients present access
+tokens issued by the provider as bearer tokens, validated against the JSON Web Key Set of the provider by
+FindUserByBearerToken.
+
+This package relies on the two following local database tables:
+
+	oidc_sessions: Upon successful login, creates a keyed local copy of the user email and role, expiring with the ID token
+	oidc_auth_requests: Pending authorization code flows, storing the nonce and PKCE verifier of each state.
+
+Users are assigned the role of the first configured group present in their groups claim, in the order admin, edit,
+run and read. Members of a group mapped to a custom role are assigned that role, unless they are members of the admin
+group.
+
+Local users remain supported for the CLI: password logins, API tokens and WebAuthn are served by the local users
+table. This implementation is otherwise read only; user mutation actions such as Delete are not supported.
+*/
+package oidcauth
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+	"github.com/smartcontractkit/chainlink/v2/core/auth"
+	"github.com/smartcontractkit/chainlink/v2/core/bridges"
+	"github.com/smartcontractkit/chainlink/v2/core/config"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions/customroles"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions/localauth"
+)
+
+// AuthRequestTimeout is how long a user has to complete the login with the identity provider
+const AuthRequestTimeout = 10 * time.Minute
+
+var ErrUserNoGroups = errors.New("user authenticated by identity provider, but matching no role groups assigned")
+var ErrInvalidAuthRequest = errors.New("login request is unknown or expired, please login again")
+
+type oidcAuthenticator struct {
+	ds          sqlutil.DataSource
+	provider    *lazyProvider
+	config      config.OIDC
+	lggr        logger.Logger
+	auditLogger audit.AuditLogger
+	customRoles customroles.ORM
+	// local serves the local users, such as the admin user of the CLI
+	local sessions.AuthenticationProvider
+}
+
+// oidcAuthenticator implements sessions.OIDCAuthenticationProvider interface
+var _ sessions.OIDCAuthenticationProvider = (*oidcAuthenticator)(nil)
+
+func NewOIDCAuthenticator(
+	ds sqlutil.DataSource,
+	oidcCfg config.OIDC,
+	dev bool,
+	lggr logger.Logger,
+	auditLogger audit.AuditLogger,
+) (*oidcAuthenticator, error) {
+	issuer, err := url.Parse(oidcCfg.IssuerURL())
+	if err != nil || issuer.Host == "" {
+		return nil, errors.New("OIDC IssuerURL config required")
+	}
+	// If not chainlink dev and not https, error
+	if !dev && issuer.Scheme != "https" {
+		return nil, errors.New("OIDC Authentication driver requires an https IssuerURL when running in Production mode")
+	}
+	if oidcCfg.ClientID() == "" || oidcCfg.RedirectURL() == "" {
+		return nil, errors.New("OIDC ClientID and RedirectURL config required")
+	}
+	// Ensure all RBAC role mappings to groups are defined, or error on startup
+	if oidcCfg.AdminUserGroup() == "" || oidcCfg.EditUserGroup() == "" ||
+		oidcCfg.RunUserGroup() == "" || oidcCfg.ReadUserGroup() == "" {
+		return nil, errors.New("OIDC group mapping for all local RBAC roles required. Set group names for `_UserGroup` fields")
+	}
+
+	lggr = lggr.Named("OIDCAuthenticationProvider")
+	client := &http.Client{Timeout: oidcCfg.ProviderTimeout()}
+
+	// the provider is discovered on first use, so that local users can log in while the issuer is unreachable
+	return &oidcAuthenticator{
+		ds:          ds,
+		provider:    newLazyProvider(client, oidcCfg),
+		config:      oidcCfg,
+		lggr:        lggr,
+		auditLogger: auditLogger,
+		customRoles: customroles.NewORM(ds),
+		local:       localauth.NewORM(ds, oidcCfg.SessionTimeout().Duration(), lggr, auditLogger),
+	}, nil
+}
+
+// StartLogin stores a new authorization request, and returns the URL of the identity provider to redirect the user to.
+func (o *oidcAuthenticator) StartLogin(ctx context.Context) (string, string, error) {
+	state, err := randomString()
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := randomString()
+	if err != nil {
+		return "", "", err
+	}
+	provider, err := o.provider.get(ctx)
+	if err != nil {
+		o.lggr.Errorw("Unable to start OIDC login", "err", err)
+		return "", "", err
+	}
+	verifier := oauth2.GenerateVerifier()
+	if _, err = o.ds.ExecContext(ctx,
+		"INSERT INTO oidc_auth_requests (state, nonce, code_verifier, created_at) VALUES ($1, $2, $3, now())",
+		state, nonce, verifier,
+	); err != nil {
+		return "", "", fmt.Errorf("error creating OIDC auth request: %w", err)
+	}
+	return provider.authCodeURL(state, nonce, verifier), state, nil
+}
+
+// CreateSessionFromAuthCode redeems the authorization code of the request with state, and creates a session for the
+// user of the ID token. The session expires with the ID token, or after SessionTimeout if sooner.
+func (o *oidcAuthenticator) CreateSessionFromAuthCode(ctx context.Context, state, code string) (string, error) {
+	// Each request is deleted as it is used, so a state can not be replayed
+	var request struct {
+		Nonce        string
+		CodeVerifier string
+	}
+	err := o.ds.GetContext(ctx, &request,
+		"DELETE FROM oidc_auth_requests WHERE state = $1 AND created_at > $2 RETURNING nonce, code_verifier",
+		state, time.Now().Add(-AuthRequestTimeout),
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrInvalidAuthRequest
+	} else if err != nil {
+		return "", fmt.Errorf("error finding OIDC auth request: %w", err)
+	}
+
+	provider, err := o.provider.get(ctx)
+	if err != nil {
+		o.lggr.Errorw("Unable to complete OIDC login", "err", err)
+		return "", errors.New("unable to log in with OIDC provider")
+	}
+	rawIDToken, err := provider.exchange(ctx, code, request.CodeVerifier)
+	if err != nil {
+		o.lggr.Infof("Unable to redeem OIDC authorization code: %v", err)
+		return "", errors.New("unable to log in with OIDC provider")
+	}
+	claims, err := provider.verify(ctx, rawIDToken, o.config.ClientID())
+	if err != nil {
+		o.lggr.Infof("OIDC provider returned an invalid ID token: %v", err)
+		return "", errors.New("unable to log in with OIDC provider")
+	}
+	if nonce, _ := claims["nonce"].(string); nonce != request.Nonce {
+		return "", errors.New("unable to log in with OIDC provider: ID token nonce does not match")
+	}
+
+	user, err := o.claimsToUser(ctx, claims)
+	if err != nil {
+		return "", err
+	}
+	expiresAt := time.Now().Add(o.config.SessionTimeout().Duration())
+	if exp, err := claims.GetExpirationTime(); err == nil && exp != nil && exp.Before(expiresAt) {
+		expiresAt = exp.Time
+	}
+
+	o.lggr.Infof("Successful OIDC login request for user %s - %s", user.Email, user.Role)
+
+	session := sessions.NewSession()
+	_, err = o.ds.ExecContext(ctx,
+		"INSERT INTO oidc_sessions (id, user_email, user_role, custom_role, created_at, expires_at) VALUES ($1, $2, $3, $4, now(), $5)",
+		session.ID, user.Email, user.Role, user.CustomRole, expiresAt,
+	)
+	if err != nil {
+		o.lggr.Errorf("unable to create new session in oidc_sessions table %v", err)
+		return "", fmt.Errorf("error creating local OIDC session: %w", err)
+	}
+
+	o.auditLogger.Audit(audit.AuthLoginSuccessNo2FA, map[string]interface{}{"email": user.Email})
+
+	return session.ID, nil
+}
+
+// FindUserByBearerToken validates an access token issued by the identity provider for the configured Audience, and
+// returns the user of its claims.
+func (o *oidcAuthenticator) FindUserByBearerToken(ctx context.Context, token string) (sessions.User, error) {
+	audience := o.config.Audience()
+	if audience == "" {
+		o.lggr.Debug("Rejected OIDC bearer token: no OIDC.Audience is configured")
+		return sessions.User{}, auth.ErrorAuthFailed
+	}
+	provider, err := o.provider.get(ctx)
+	if err != nil {
+		o.lggr.Warnw("Rejected OIDC bearer token: the identity provider is unavailable", "err", err)
+		return sessions.User{}, auth.ErrorAuthFailed
+	}
+	claims, err := provider.verify(ctx, token, audience)
+	if err != nil {
+		o.lggr.Debugf("Rejected OIDC bearer token: %v", err)
+		return sessions.User{}, auth.ErrorAuthFailed
+	}
+	// ID tokens carry the nonce of the login request, access tokens do not
+	if _, ok := claims["nonce"]; ok {
+		o.lggr.Debug("Rejected OIDC bearer token: ID tokens are not accepted as bearer tokens")
+		return sessions.User{}, auth.ErrorAuthFailed
+	}
+	user, err := o.claimsToUser(ctx, claims)
+	if err != nil {
+		return sessions.User{}, err
+	}
+	if err = o.customRoles.LoadPermissions(ctx, &user); err != nil {
+		return sessions.User{}, err
+	}
+	return user, nil
+}
+
+// FindUser returns a local user by email, or the user of the latest active OIDC session.
+func (o *oidcAuthenticator) FindUser(ctx context.Context, email string) (sessions.User, error) {
+	user, err := o.local.FindUser(ctx, email)
+	if err == nil || !errors.Is(err, sql.ErrNoRows) {
+		return user, err
+	}
+	var found struct {
+		UserEmail  string
+		UserRole   sessions.UserRole
+		CustomRole null.String
+		CreatedAt  time.Time
+	}
+	if err = o.ds.GetContext(ctx, &found,
+		`SELECT user_email, user_role, custom_role, created_at FROM oidc_sessions
+		WHERE lower(user_email) = lower($1) AND expires_at > now() ORDER BY created_at DESC LIMIT 1`,
+		email,
+	); err != nil {
+		return sessions.User{}, err
+	}
+	return sessions.User{
+		Email:      found.UserEmail,
+		Role:       found.UserRole,
+		CustomRole: found.CustomRole,
+		CreatedAt:  found.CreatedAt,
+	}, nil
+}
+
+// FindUserByAPIToken returns the local user of the API token.
+func (o *oidcAuthenticator) FindUserByAPIToken(ctx context.Context, apiToken string) (sessions.User, error) {
+	return o.local.FindUserByAPIToken(ctx, apiToken)
+}
+
+// ListUsers returns the local users, and the users of active OIDC sessions. The identity provider is not queried, so
+// users who are not logged in are not listed.
+func (o *oidcAuthenticator) ListUsers(ctx context.Context) ([]sessions.User, error) {
+	users, err := o.local.ListUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var found []struct {
+		UserEmail  string
+		UserRole   sessions.UserRole
+		CustomRole null.String
+		CreatedAt  time.Time
+	}
+	if err = o.ds.SelectContext(ctx, &found,
+		`SELECT DISTINCT ON (user_email) user_email, user_role, custom_role, created_at FROM oidc_sessions
+		WHERE expires_at > now() AND user_email NOT IN (SELECT email FROM users)
+		ORDER BY user_email, created_at DESC`,
+	); err != nil {
+		return nil, err
+	}
+	for _, f := range found {
+		users = append(users, sessions.User{
+			Email:      f.UserEmail,
+			Role:       f.UserRole,
+			CustomRole: f.CustomRole,
+			CreatedAt:  f.CreatedAt,
+		})
+	}
+	return users, nil
+}
+
+// AuthorizedUserWithSession returns the user of an unexpired OIDC session, or of a local session.
+func (o *oidcAuthenticator) AuthorizedUserWithSession(ctx context.Context, sessionID string) (sessions.User, error) {
+	if len(sessionID) == 0 {
+		return sessions.User{}, sessions.ErrEmptySessionID
+	}
+	var foundSession struct {
+		UserEmail  string
+		UserRole   sessions.UserRole
+		CustomRole null.String
+		Valid      bool
+	}
+	err := o.ds.GetContext(ctx, &foundSession,
+		"SELECT user_email, user_role, custom_role, expires_at > now() AS valid FROM oidc_sessions WHERE id = $1",
+		sessionID,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return o.local.AuthorizedUserWithSession(ctx, sessionID)
+	} else if err != nil {
+		return sessions.User{}, sessions.ErrUserSessionExpired
+	}
+	if !foundSession.Valid {
+		// Session expired with its ID token, purge
+		if _, execErr := o.ds.ExecContext(ctx, "DELETE FROM oidc_sessions WHERE id = $1", sessionID); execErr != nil {
+			o.lggr.Errorf("error purging stale OIDC session: %v", execErr)
+		}
+		return sessions.User{}, sessions.ErrUserSessionExpired
+	}
+	user := sessions.User{
+		Email:      foundSession.UserEmail,
+		Role:       foundSession.UserRole,
+		CustomRole: foundSession.CustomRole,
+	}
+	if err := o.customRoles.LoadPermissions(ctx, &user); err != nil {
+		return sessions.User{}, err
+	}
+	return user, nil
+}
+
+// DeleteUser is not supported, users are managed by the identity provider
+func (o *oidcAuthenticator) DeleteUser(ctx context.Context, email string) error {
+	return sessions.ErrNotSupported
+}
+
+// DeleteUserSession removes an OIDC or local session by ID
+func (o *oidcAuthenticator) DeleteUserSession(ctx context.Context, sessionID string) error {
+	if _, err := o.ds.ExecContext(ctx, "DELETE FROM oidc_sessions WHERE id = $1", sessionID); err != nil {
+		return err
+	}
+	return o.local.DeleteUserSession(ctx, sessionID)
+}
+
+// GetUserWebAuthn returns the WebAuthn tokens of a local user.
+func (o *oidcAuthenticator) GetUserWebAuthn(ctx context.Context, email string) ([]sessions.WebAuthn, error) {
+	return o.local.GetUserWebAuthn(ctx, email)
+}
+
+// CreateSession logs in a local user with a password, such as the admin user of the CLI. Users of the identity
+// provider log in with StartLogin instead.
+func (o *oidcAuthenticator) CreateSession(ctx context.Context, sr sessions.SessionRequest) (string, error) {
+	return o.local.CreateSession(ctx, sr)
+}
+
+// ClearNonCurrentSessions removes all OIDC and local sessions but the id passed in.
+func (o *oidcAuthenticator) ClearNonCurrentSessions(ctx context.Context, sessionID string) error {
+	if _, err := o.ds.ExecContext(ctx, "DELETE FROM oidc_sessions where id != $1", sessionID); err != nil {
+		return err
+	}
+	return o.local.ClearNonCurrentSessions(ctx, sessionID)
+}
+
+// CreateUser is not supported, users are managed by the identity provider
+func (o *oidcAuthenticator) CreateUser(ctx context.Context, user *sessions.User) error {
+	return sessions.ErrNotSupported
+}
+
+// UpdateRole is not supported, roles are mapped from the groups of the identity provider
+func (o *oidcAuthenticator) UpdateRole(ctx context.Context, email, newRole string) (sessions.User, error) {
+	return sessions.User{}, sessions.ErrNotSupported
+}
+
+// SetPassword sets the password of a local user.
+func (o *oidcAuthenticator) SetPassword(ctx context.Context, user *sessions.User, newPassword string) error {
+	return o.local.SetPassword(ctx, user, newPassword)
+}
+
+// TestPassword checks the password of a local user.
+func (o *oidcAuthenticator) TestPassword(ctx context.Context, email string, password string) error {
+	return o.local.TestPassword(ctx, email, password)
+}
+
+// CreateAndSetAuthToken creates an API token for a local user. Users of the identity provider authenticate API
+// requests with bearer tokens instead.
+func (o *oidcAuthenticator) CreateAndSetAuthToken(ctx context.Context, user *sessions.User) (*auth.Token, error) {
+	return o.local.CreateAndSetAuthToken(ctx, user)
+}
+
+// SetAuthToken sets the API token of a local user.
+func (o *oidcAuthenticator) SetAuthToken(ctx context.Context, user *sessions.User, token *auth.Token) error {
+	return o.local.SetAuthToken(ctx, user, token)
+}
+
+// DeleteAuthToken removes the API token of a local user.
+func (o *oidcAuthenticator) DeleteAuthToken(ctx context.Context, user *sessions.User) error {
+	return o.local.DeleteAuthToken(ctx, user)
+}
+
+// SaveWebAuthn saves a WebAuthn token of a local user.
+func (o *oidcAuthenticator) SaveWebAuthn(ctx context.Context, token *sessions.WebAuthn) error {
+	return o.local.SaveWebAuthn(ctx, token)
+}
+
+// Sessions returns all OIDC and local sessions limited by the parameters.
+func (o *oidcAuthenticator) Sessions(ctx context.Context, offset, limit int) ([]sessions.Session, error) {
+	var sessions []sessions.Session
+	sql := `SELECT id, user_email AS email, created_at AS last_used, created_at FROM oidc_sessions
+		UNION ALL SELECT id, email, last_used, created_at FROM sessions
+		ORDER BY created_at, id LIMIT $1 OFFSET $2;`
+	if err := o.ds.SelectContext(ctx, &sessions, sql, limit, offset); err != nil {
+		return sessions, err
+	}
+	return sessions, nil
+}
+
+// FindExternalInitiator supports the 'Run' role external intiator header auth functionality
+func (o *oidcAuthenticator) FindExternalInitiator(ctx context.Context, eia *auth.Token) (*bridges.ExternalInitiator, error) {
+	return o.local.FindExternalInitiator(ctx, eia)
+}
+
+// claimsToUser returns the user of the email and groups claims of a token. Members of a group mapped to a custom role
+// are assigned the custom role, unless they are members of the admin group.
+func (o *oidcAuthenticator) claimsToUser(ctx context.Context, claims jwt.MapClaims) (sessions.User, error) {
+	emails := claimStrings(claims, o.config.EmailClaim())
+	if len(emails) != 1 || emails[0] == "" {
+		return sessions.User{}, fmt.Errorf("token has no %s claim", o.config.EmailClaim())
+	}
+	verified, ok := claims["email_verified"]
+	if (ok && verified != true) || (!ok && o.config.EmailClaim() == "email") {
+		o.lggr.Infof("OIDC user %s has no verified email", emails[0])
+		return sessions.User{}, errors.New("token has no verified email")
+	}
+	email := strings.ToLower(emails[0])
+	groups := claimStrings(claims, o.config.GroupsClaim())
+
+	userRole, roleErr := GroupsToUserRole(
+		groups,
+		o.config.AdminUserGroup(),
+		o.config.EditUserGroup(),
+		o.config.RunUserGroup(),
+		o.config.ReadUserGroup(),
+	)
+	if roleErr == nil && userRole == sessions.UserRoleAdmin {
+		return sessions.User{Email: email, Role: userRole}, nil
+	}
+
+	customRole, err := o.customRoles.FindCustomRoleByGroups(ctx, groups)
+	if err == nil {
+		return sessions.User{Email: email, Role: sessions.UserRoleView, CustomRole: null.StringFrom(customRole.Name)}, nil
+	}
+	if !errors.Is(err, sessions.ErrCustomRoleNotFound) {
+		o.lggr.Errorf("error finding custom role of OIDC groups: %v", err)
+		return sessions.User{}, errors.New("error finding custom role of user")
+	}
+
+	if roleErr != nil {
+		o.lggr.Infof("OIDC user %s has no groups mapped to a role", email)
+		return sessions.User{}, roleErr
+	}
+	return sessions.User{Email: email, Role: userRole}, nil
+}
+
+// GroupsToUserRole returns the internal user role of the highest privileged configured group present in groups
+func GroupsToUserRole(groups []string, adminGroup string, editGroup string, runGroup string, readGroup string) (sessions.UserRole, error) {
+	for _, mapping := range []struct {
+		group string
+		role  sessions.UserRole
+	}{
+		{adminGroup, sessions.UserRoleAdmin},
+		{editGroup, sessions.UserRoleEdit},
+		{runGroup, sessions.UserRoleRun},
+		{readGroup, sessions.UserRoleView},
+	} {
+		for _, group := range groups {
+			if group == mapping.group {
+				return mapping.role, nil
+			}
+		}
+	}
+	// No role group found, error
+	return sessions.UserRoleView, ErrUserNoGroups
+}
+
+// claimStrings returns the string or strings of a claim. Nested claims are selected with a dot separated name, such
+// as realm_access.roles.
+func claimStrings(claims jwt.MapClaims, name string) []string {
+	var value any = map[string]any(claims)
+	for _, part := range strings.Split(name, ".") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		value = object[part]
+	}
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		strs := make([]string, 0, len(v))
+		for _, s := range v {
+			if str, ok := s.(string); ok {
+				strs = append(strs, str)
+			}
+		}
+		return strs
+	default:
+		return nil
+	}
+}
+
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
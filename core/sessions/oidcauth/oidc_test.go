@@ -0,0 +1,270 @@
+package oidcauth_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/v2/core/auth"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions/customroles"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions/localauth"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions/oidcauth"
+)
+
+// Setup OIDC authenticator against a mock identity provider
+func setupAuthenticationProvider(t *testing.T, cfg *oidcauth.TestConfig) (*sqlx.DB, *oidcauth.MockIdentityProvider, sessions.OIDCAuthenticationProvider) {
+	t.Helper()
+
+	idp := oidcauth.NewMockIdentityProvider(t)
+	cfg.IssuerURLValue = idp.URL
+	db := pgtest.NewSqlxDB(t)
+	provider, err := oidcauth.NewOIDCAuthenticator(db, cfg, true, logger.TestLogger(t), &audit.AuditLoggerService{})
+	require.NoError(t, err)
+	return db, idp, provider
+}
+
+func login(t *testing.T, idp *oidcauth.MockIdentityProvider, provider sessions.OIDCAuthenticationProvider) (string, error) {
+	t.Helper()
+	ctx := testutils.Context(t)
+
+	authURL, state, err := provider.StartLogin(ctx)
+	require.NoError(t, err)
+	redirectedState, code := idp.Authorize(authURL)
+	require.Equal(t, state, redirectedState)
+	return provider.CreateSessionFromAuthCode(ctx, state, code)
+}
+
+func TestNewOIDCAuthenticator(t *testing.T) {
+	t.Parallel()
+
+	idp := oidcauth.NewMockIdentityProvider(t)
+	db := pgtest.NewSqlxDB(t)
+	lggr := logger.TestLogger(t)
+
+	_, err := oidcauth.NewOIDCAuthenticator(db, &oidcauth.TestConfig{IssuerURLValue: idp.URL}, false, lggr, audit.NoopLogger)
+	require.ErrorContains(t, err, "requires an https IssuerURL")
+
+	_, err = oidcauth.NewOIDCAuthenticator(db, &oidcauth.TestConfig{IssuerURLValue: idp.URL}, true, lggr, audit.NoopLogger)
+	require.NoError(t, err)
+}
+
+func TestOIDCAuthenticator_ProviderUnavailable(t *testing.T) {
+	t.Parallel()
+	ctx := testutils.Context(t)
+
+	db, idp, provider := setupAuthenticationProvider(t, &oidcauth.TestConfig{})
+	idp.SetUnavailable(true)
+
+	_, _, err := provider.StartLogin(ctx)
+	require.ErrorContains(t, err, "unable to discover OIDC provider")
+	_, err = provider.FindUserByBearerToken(ctx, idp.IssueToken(jwt.MapClaims{"aud": oidcauth.TestAudience}))
+	require.ErrorIs(t, err, auth.ErrorAuthFailed)
+
+	// local users log in while the identity provider is unreachable
+	user := cltest.MustRandomUser(t)
+	localORM := localauth.NewORM(db, time.Minute, logger.TestLogger(t), audit.NoopLogger)
+	require.NoError(t, localORM.CreateUser(ctx, &user))
+	_, err = provider.CreateSession(ctx, sessions.SessionRequest{Email: user.Email, Password: cltest.Password})
+	require.NoError(t, err)
+}
+
+func TestOIDCAuthenticator_Login(t *testing.T) {
+	t.Parallel()
+	ctx := testutils.Context(t)
+
+	_, idp, provider := setupAuthenticationProvider(t, &oidcauth.TestConfig{})
+	idp.SetClaims(jwt.MapClaims{"email_verified": true, "email": "User@Example.com", "groups": []string{oidcauth.NodeRunnersGroup, oidcauth.NodeEditorsGroup}})
+
+	sessionID, err := login(t, idp, provider)
+	require.NoError(t, err)
+
+	user, err := provider.AuthorizedUserWithSession(ctx, sessionID)
+	require.NoError(t, err)
+	assert.Equal(t, "user@example.com", user.Email)
+	assert.Equal(t, sessions.UserRoleEdit, user.Role)
+
+	found, err := provider.FindUser(ctx, "user@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, sessions.UserRoleEdit, found.Role)
+
+	users, err := provider.ListUsers(ctx)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+
+	sessionList, err := provider.Sessions(ctx, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, sessionList, 1)
+	assert.Equal(t, sessionID, sessionList[0].ID)
+
+	require.NoError(t, provider.DeleteUserSession(ctx, sessionID))
+	_, err = provider.AuthorizedUserWithSession(ctx, sessionID)
+	require.ErrorIs(t, err, sessions.ErrUserSessionExpired)
+}
+
+func TestOIDCAuthenticator_LoginRejected(t *testing.T) {
+	t.Parallel()
+	ctx := testutils.Context(t)
+
+	_, idp, provider := setupAuthenticationProvider(t, &oidcauth.TestConfig{})
+
+	idp.SetClaims(jwt.MapClaims{"email_verified": true, "email": "user@example.com", "groups": []string{"Other"}})
+	_, err := login(t, idp, provider)
+	require.ErrorIs(t, err, oidcauth.ErrUserNoGroups)
+
+	idp.SetClaims(jwt.MapClaims{"groups": []string{oidcauth.NodeAdminsGroup}})
+	_, err = login(t, idp, provider)
+	require.ErrorContains(t, err, "token has no email claim")
+
+	idp.SetClaims(jwt.MapClaims{"email": "user@example.com", "groups": []string{oidcauth.NodeAdminsGroup}})
+	_, err = login(t, idp, provider)
+	require.ErrorContains(t, err, "token has no verified email")
+
+	// A state can only be used once
+	idp.SetClaims(jwt.MapClaims{"email_verified": true, "email": "user@example.com", "groups": []string{oidcauth.NodeAdminsGroup}})
+	authURL, state, err := provider.StartLogin(ctx)
+	require.NoError(t, err)
+	_, code := idp.Authorize(authURL)
+	_, err = provider.CreateSessionFromAuthCode(ctx, state, code)
+	require.NoError(t, err)
+	_, err = provider.CreateSessionFromAuthCode(ctx, state, code)
+	require.ErrorIs(t, err, oidcauth.ErrInvalidAuthRequest)
+
+	_, err = provider.CreateSessionFromAuthCode(ctx, "unknown", code)
+	require.ErrorIs(t, err, oidcauth.ErrInvalidAuthRequest)
+}
+
+func TestOIDCAuthenticator_SessionExpiresWithToken(t *testing.T) {
+	t.Parallel()
+	ctx := testutils.Context(t)
+
+	db, idp, provider := setupAuthenticationProvider(t, &oidcauth.TestConfig{})
+	idp.SetClaims(jwt.MapClaims{"email_verified": true, "email": "user@example.com", "groups": []string{oidcauth.NodeReadOnlyGroup}})
+	idp.TokenLifetime = 2 * time.Minute
+
+	sessionID, err := login(t, idp, provider)
+	require.NoError(t, err)
+
+	var expiresAt time.Time
+	require.NoError(t, db.Get(&expiresAt, "SELECT expires_at FROM oidc_sessions WHERE id = $1", sessionID))
+	assert.WithinDuration(t, time.Now().Add(idp.TokenLifetime), expiresAt, 5*time.Second)
+
+	_, err = db.Exec("UPDATE oidc_sessions SET expires_at = now() - interval '1 second' WHERE id = $1", sessionID)
+	require.NoError(t, err)
+	_, err = provider.AuthorizedUserWithSession(ctx, sessionID)
+	require.ErrorIs(t, err, sessions.ErrUserSessionExpired)
+
+	var count int
+	require.NoError(t, db.Get(&count, "SELECT count(*) FROM oidc_sessions"))
+	assert.Zero(t, count)
+}
+
+func TestOIDCAuthenticator_FindUserByBearerToken(t *testing.T) {
+	t.Parallel()
+	ctx := testutils.Context(t)
+
+	db, idp, provider := setupAuthenticationProvider(t, &oidcauth.TestConfig{})
+
+	role := sessions.CustomRole{
+		Name:        "ocr-operator",
+		Group:       null.StringFrom("NodeOCROperators"),
+		Permissions: []sessions.Permission{sessions.NewPermission(sessions.ResourceJobs, sessions.ActionAll, "offchainreporting2")},
+	}
+	require.NoError(t, customroles.NewORM(db).CreateCustomRole(ctx, &role))
+
+	token := idp.IssueToken(jwt.MapClaims{
+		"aud":            oidcauth.TestAudience,
+		"email":          "operator@example.com",
+		"email_verified": true,
+		"groups":         []string{"NodeOCROperators", oidcauth.NodeRunnersGroup},
+	})
+	user, err := provider.FindUserByBearerToken(ctx, token)
+	require.NoError(t, err)
+	assert.Equal(t, "operator@example.com", user.Email)
+	assert.Equal(t, null.StringFrom(role.Name), user.CustomRole)
+	assert.Equal(t, role.Permissions, user.Permissions)
+
+	// Admins are not restricted by custom roles
+	token = idp.IssueToken(jwt.MapClaims{
+		"aud":            oidcauth.TestAudience,
+		"email":          "admin@example.com",
+		"email_verified": true,
+		"groups":         []string{"NodeOCROperators", oidcauth.NodeAdminsGroup},
+	})
+	user, err = provider.FindUserByBearerToken(ctx, token)
+	require.NoError(t, err)
+	assert.Equal(t, sessions.UserRoleAdmin, user.Role)
+	assert.False(t, user.HasCustomRole())
+
+	// Tokens for the UI client are not accepted by the API
+	claims := jwt.MapClaims{"aud": oidcauth.TestClientID, "email": "admin@example.com", "email_verified": true, "groups": []string{oidcauth.NodeAdminsGroup}}
+	_, err = provider.FindUserByBearerToken(ctx, idp.IssueToken(claims))
+	require.ErrorIs(t, err, auth.ErrorAuthFailed)
+
+	// Nor are ID tokens, even if issued for the API audience
+	claims["aud"] = []string{oidcauth.TestClientID, oidcauth.TestAudience}
+	claims["nonce"] = "nonce"
+	_, err = provider.FindUserByBearerToken(ctx, idp.IssueToken(claims))
+	require.ErrorIs(t, err, auth.ErrorAuthFailed)
+
+	// Emails must be verified
+	token = idp.IssueToken(jwt.MapClaims{"aud": oidcauth.TestAudience, "email": "admin@example.com", "email_verified": false, "groups": []string{oidcauth.NodeAdminsGroup}})
+	_, err = provider.FindUserByBearerToken(ctx, token)
+	require.ErrorContains(t, err, "token has no verified email")
+
+	// Bearer tokens are rejected without an audience
+	_, _, provider = setupAuthenticationProvider(t, &oidcauth.TestConfig{NoAudience: true})
+	_, err = provider.FindUserByBearerToken(ctx, idp.IssueToken(jwt.MapClaims{"aud": ""}))
+	require.ErrorIs(t, err, auth.ErrorAuthFailed)
+}
+
+func TestOIDCAuthenticator_LocalUsers(t *testing.T) {
+	t.Parallel()
+	ctx := testutils.Context(t)
+
+	db, _, provider := setupAuthenticationProvider(t, &oidcauth.TestConfig{})
+	user := cltest.MustRandomUser(t)
+	localORM := localauth.NewORM(db, time.Minute, logger.TestLogger(t), audit.NoopLogger)
+	require.NoError(t, localORM.CreateUser(ctx, &user))
+
+	sessionID, err := provider.CreateSession(ctx, sessions.SessionRequest{Email: user.Email, Password: cltest.Password})
+	require.NoError(t, err)
+	found, err := provider.AuthorizedUserWithSession(ctx, sessionID)
+	require.NoError(t, err)
+	assert.Equal(t, user.Email, found.Email)
+
+	require.ErrorIs(t, provider.CreateUser(ctx, &user), sessions.ErrNotSupported)
+	require.ErrorIs(t, provider.DeleteUser(ctx, user.Email), sessions.ErrNotSupported)
+}
+
+func TestGroupsToUserRole(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		groups []string
+		want   sessions.UserRole
+	}{
+		{[]string{oidcauth.NodeReadOnlyGroup, oidcauth.NodeAdminsGroup}, sessions.UserRoleAdmin},
+		{[]string{oidcauth.NodeRunnersGroup, oidcauth.NodeEditorsGroup}, sessions.UserRoleEdit},
+		{[]string{oidcauth.NodeRunnersGroup}, sessions.UserRoleRun},
+		{[]string{"Other", oidcauth.NodeReadOnlyGroup}, sessions.UserRoleView},
+	}
+	for _, test := range tests {
+		role, err := oidcauth.GroupsToUserRole(test.groups, oidcauth.NodeAdminsGroup, oidcauth.NodeEditorsGroup, oidcauth.NodeRunnersGroup, oidcauth.NodeReadOnlyGroup)
+		require.NoError(t, err)
+		assert.Equal(t, test.want, role)
+	}
+
+	_, err := oidcauth.GroupsToUserRole([]string{"Other"}, oidcauth.NodeAdminsGroup, oidcauth.NodeEditorsGroup, oidcauth.NodeRunnersGroup, oidcauth.NodeReadOnlyGroup)
+	require.ErrorIs(t, err, oidcauth.ErrUserNoGroups)
+}
@@ -0,0 +1,43 @@
+package oidcauth
+
+import (
+	"context"
+	"time"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions/localauth"
+)
+
+type sessionReaper struct {
+	ds     sqlutil.DataSource
+	config localauth.SessionReaperConfig
+	lggr   logger.Logger
+}
+
+// NewSessionReaper creates a reaper that cleans expired OIDC sessions, abandoned logins and stale local sessions from
+// the store.
+func NewSessionReaper(ds sqlutil.DataSource, config localauth.SessionReaperConfig, lggr logger.Logger) *utils.SleeperTask {
+	return utils.NewSleeperTaskCtx(&sessionReaper{
+		ds,
+		config,
+		lggr.Named("OIDCSessionReaper"),
+	})
+}
+
+func (sr *sessionReaper) Name() string { return sr.lggr.Name() }
+
+func (sr *sessionReaper) Work(ctx context.Context) {
+	if _, err := sr.ds.ExecContext(ctx, "DELETE FROM oidc_sessions WHERE expires_at < now()"); err != nil {
+		sr.lggr.Error("unable to reap expired OIDC sessions: ", err)
+	}
+	if _, err := sr.ds.ExecContext(ctx, "DELETE FROM oidc_auth_requests WHERE created_at < $1", time.Now().Add(-AuthRequestTimeout)); err != nil {
+		sr.lggr.Error("unable to reap abandoned OIDC auth requests: ", err)
+	}
+	recordCreationStaleThreshold := sr.config.SessionReaperExpiration().Before(
+		sr.config.SessionTimeout().Before(time.Now()))
+	if _, err := sr.ds.ExecContext(ctx, "DELETE FROM sessions WHERE last_used < $1", recordCreationStaleThreshold); err != nil {
+		sr.lggr.Error("unable to reap stale sessions: ", err)
+	}
+}
@@ -0,0 +1,204 @@
+package sessions
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+// Resource is a kind of node resource, to which a Permission grants access.
+type Resource string
+
+const (
+	ResourceAll                Resource = "*"
+	ResourceJobs               Resource = "jobs"
+	ResourceKeys               Resource = "keys"
+	ResourceChains             Resource = "chains"
+	ResourceTransactions       Resource = "transactions"
+	ResourceBridges            Resource = "bridges"
+	ResourceExternalInitiators Resource = "external_initiators"
+	ResourceFeedsManagers      Resource = "feeds_managers"
+	ResourceConfig             Resource = "config"
+	ResourceS4                 Resource = "s4"
+	ResourceUsers              Resource = "users"
+)
+
+var resources = []Resource{
+	ResourceAll,
+	ResourceJobs,
+	ResourceKeys,
+	ResourceChains,
+	ResourceTransactions,
+	ResourceBridges,
+	ResourceExternalInitiators,
+	ResourceFeedsManagers,
+	ResourceConfig,
+	ResourceS4,
+	ResourceUsers,
+}
+
+// Action is an action on a resource, to which a Permission grants access.
+type Action string
+
+const (
+	ActionAll    Action = "*"
+	ActionRead   Action = "read"
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+	ActionRun    Action = "run"
+	ActionImport Action = "import"
+	ActionExport Action = "export"
+)
+
+// ScopeAny is the scope of a requested permission which is granted by a permission of any scope. It is used to
+// authorize a request before the scope of the resource is known, which must then be authorized again in its scope.
+const ScopeAny = "*"
+
+var actions = []Action{
+	ActionAll,
+	ActionRead,
+	ActionCreate,
+	ActionUpdate,
+	ActionDelete,
+	ActionRun,
+	ActionImport,
+	ActionExport,
+}
+
+// Permission grants an action on a resource, optionally only within a scope. The scope of jobs is the job type, of keys
+// the key type, and of chains and transactions the network, or the network and chain ID separated by a slash, like
+// evm/1. Listing resources across scopes requires an unscoped permission.
+type Permission struct {
+	Resource Resource
+	Action   Action
+	Scope    string
+}
+
+// NewPermission returns the permission of action on resource within scope, or on any scope if scope is empty.
+func NewPermission(resource Resource, action Action, scope string) Permission {
+	return Permission{Resource: resource, Action: action, Scope: scope}
+}
+
+// ParsePermission parses a permission in the format resource:action[:scope], like keys:export:csa.
+func ParsePermission(s string) (Permission, error) {
+	parts := strings.SplitN(strings.TrimSpace(s), ":", 3)
+	if len(parts) < 2 {
+		return Permission{}, pkgerrors.Errorf("invalid permission %q, must be in the format resource:action[:scope]", s)
+	}
+	p := Permission{Resource: Resource(parts[0]), Action: Action(parts[1])}
+	if len(parts) == 3 {
+		p.Scope = parts[2]
+	}
+	return p, p.Validate()
+}
+
+// Validate returns an error if the resource or the action are unknown, or the scope is invalid.
+func (p Permission) Validate() error {
+	if !slices.Contains(resources, p.Resource) {
+		return pkgerrors.Errorf("invalid permission %s: unknown resource %q, allowed resources: %s", p, p.Resource, join(resources))
+	}
+	if !slices.Contains(actions, p.Action) {
+		return pkgerrors.Errorf("invalid permission %s: unknown action %q, allowed actions: %s", p, p.Action, join(actions))
+	}
+	if strings.ContainsAny(p.Scope, " \t\n") {
+		return pkgerrors.Errorf("invalid permission %s: scope must not contain whitespace", p)
+	}
+	return nil
+}
+
+func (p Permission) String() string {
+	if p.Scope == "" {
+		return fmt.Sprintf("%s:%s", p.Resource, p.Action)
+	}
+	return fmt.Sprintf("%s:%s:%s", p.Resource, p.Action, p.Scope)
+}
+
+// Grants returns true if p grants the requested permission. A scoped permission only grants requests within its
+// scope, or a scope nested below it, such that chains:read:evm grants chains:read:evm/1, but not chains:read.
+func (p Permission) Grants(requested Permission) bool {
+	if p.Resource != ResourceAll && p.Resource != requested.Resource {
+		return false
+	}
+	if p.Action != ActionAll && p.Action != requested.Action {
+		return false
+	}
+	if p.Scope == "" || p.Scope == ScopeAny || requested.Scope == ScopeAny {
+		return true
+	}
+	return strings.EqualFold(p.Scope, requested.Scope) ||
+		strings.HasPrefix(strings.ToLower(requested.Scope), strings.ToLower(p.Scope)+"/")
+}
+
+var customRoleNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// ErrCustomRoleNotFound is returned when no custom role exists with the given name
+var ErrCustomRoleNotFound = pkgerrors.New("custom role not found")
+
+// CustomRole is a role defined by an admin as a set of permissions. A user with a custom role is authorized by the
+// permissions of the role, instead of by its built-in role. Members of the LDAP or OIDC group Group are assigned
+// the role, unless they are members of the admin group.
+type CustomRole struct {
+	Name        string
+	Description string
+	Group       null.String
+	Permissions []Permission
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Validate returns an error if the name of the role is invalid, or the role has an invalid permission.
+func (r CustomRole) Validate() error {
+	if !customRoleNameRegex.MatchString(r.Name) {
+		return pkgerrors.Errorf("invalid custom role name %q, must be lower case alphanumeric, dashes and underscores", r.Name)
+	}
+	if _, err := GetUserRole(r.Name); err == nil {
+		return pkgerrors.Errorf("invalid custom role name %q, must not be a built-in role", r.Name)
+	}
+	if r.Group.Valid && r.Group.String == "" {
+		return pkgerrors.New("group of custom role must not be empty")
+	}
+	for _, p := range r.Permissions {
+		if err := p.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Allows returns true if any permission of the role grants the requested permission.
+func (r CustomRole) Allows(requested Permission) bool {
+	return allows(r.Permissions, requested)
+}
+
+// CustomRolesORM manages the custom roles defined by admins.
+type CustomRolesORM interface {
+	ListCustomRoles(ctx context.Context) ([]CustomRole, error)
+	FindCustomRole(ctx context.Context, name string) (CustomRole, error)
+	CreateCustomRole(ctx context.Context, role *CustomRole) error
+	UpdateCustomRole(ctx context.Context, role *CustomRole) error
+	DeleteCustomRole(ctx context.Context, name string) error
+}
+
+func allows(permissions []Permission, requested Permission) bool {
+	for _, p := range permissions {
+		if p.Grants(requested) {
+			return true
+		}
+	}
+	return false
+}
+
+func join[T ~string](values []T) string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = string(v)
+	}
+	return strings.Join(s, ", ")
+}
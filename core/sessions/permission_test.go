@@ -0,0 +1,113 @@
+package sessions_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+)
+
+func TestParsePermission(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input     string
+		want      sessions.Permission
+		wantError bool
+	}{
+		{"jobs:read", sessions.NewPermission(sessions.ResourceJobs, sessions.ActionRead, ""), false},
+		{"keys:export:csa", sessions.NewPermission(sessions.ResourceKeys, sessions.ActionExport, "csa"), false},
+		{"chains:*:evm/1", sessions.NewPermission(sessions.ResourceChains, sessions.ActionAll, "evm/1"), false},
+		{"*:*", sessions.NewPermission(sessions.ResourceAll, sessions.ActionAll, ""), false},
+		{"jobs", sessions.Permission{}, true},
+		{"widgets:read", sessions.Permission{}, true},
+		{"jobs:fly", sessions.Permission{}, true},
+		{"jobs:read:bad scope", sessions.Permission{}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			p, err := sessions.ParsePermission(test.input)
+			if test.wantError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.want, p)
+			assert.Equal(t, test.input, p.String())
+		})
+	}
+}
+
+func TestPermission_Grants(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		granted, requested string
+		want               bool
+	}{
+		{"jobs:read", "jobs:read", true},
+		{"jobs:read", "jobs:read:offchainreporting2", true},
+		{"jobs:read", "jobs:update", false},
+		{"jobs:*", "jobs:delete:webhook", true},
+		{"*:read", "keys:read:csa", true},
+		{"jobs:read:webhook", "jobs:read:webhook", true},
+		{"jobs:read:webhook", "jobs:read:cron", false},
+		{"jobs:read:webhook", "jobs:read", false},
+		{"jobs:read:webhook", "jobs:read:*", true},
+		{"chains:read:evm", "chains:read:evm/1", true},
+		{"chains:read:evm", "chains:read:EVM/1", true},
+		{"chains:read:evm", "chains:read:evmx", false},
+		{"chains:read:evm/1", "chains:read:evm", false},
+		{"keys:export:csa", "keys:read:csa", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.granted+"/"+test.requested, func(t *testing.T) {
+			granted, err := sessions.ParsePermission(test.granted)
+			require.NoError(t, err)
+			requested, err := sessions.ParsePermission(test.requested)
+			require.NoError(t, err)
+			assert.Equal(t, test.want, granted.Grants(requested))
+		})
+	}
+}
+
+func TestCustomRole_Validate(t *testing.T) {
+	t.Parallel()
+
+	valid := sessions.CustomRole{
+		Name:        "ocr-operator",
+		Group:       null.StringFrom("NodeOCROperators"),
+		Permissions: []sessions.Permission{sessions.NewPermission(sessions.ResourceJobs, sessions.ActionAll, "offchainreporting2")},
+	}
+	require.NoError(t, valid.Validate())
+	assert.True(t, valid.Allows(sessions.NewPermission(sessions.ResourceJobs, sessions.ActionCreate, "offchainreporting2")))
+	assert.False(t, valid.Allows(sessions.NewPermission(sessions.ResourceJobs, sessions.ActionCreate, "webhook")))
+
+	for name, role := range map[string]sessions.CustomRole{
+		"empty name":      {},
+		"upper case name": {Name: "Operator"},
+		"built-in role":   {Name: "admin"},
+		"empty group":     {Name: "operator", Group: null.StringFrom("")},
+		"bad permission":  {Name: "operator", Permissions: []sessions.Permission{{Resource: "widgets", Action: sessions.ActionRead}}},
+	} {
+		assert.Error(t, role.Validate(), name)
+	}
+}
+
+func TestUser_Allows(t *testing.T) {
+	t.Parallel()
+
+	user := sessions.User{
+		Role:        sessions.UserRoleView,
+		CustomRole:  null.StringFrom("key-exporter"),
+		Permissions: []sessions.Permission{sessions.NewPermission(sessions.ResourceKeys, sessions.ActionExport, "csa")},
+	}
+	require.True(t, user.HasCustomRole())
+	assert.True(t, user.Allows(sessions.NewPermission(sessions.ResourceKeys, sessions.ActionExport, "csa")))
+	assert.False(t, user.Allows(sessions.NewPermission(sessions.ResourceKeys, sessions.ActionExport, "eth")))
+}
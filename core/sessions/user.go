@@ -21,6 +21,13 @@ type User struct {
 	TokenSalt         null.String
 	TokenHashedSecret null.String
 	UpdatedAt         time.Time
+	// CustomRole is the name of the custom role of the user, which takes precedence over Role
+	CustomRole null.String
+	// Permissions are the permissions of the custom role, loaded with the user by the AuthenticationProvider
+	Permissions []Permission `db:"-"`
+	// TokenScopes are the scopes of the API token authenticating the request of the user, if any, which further
+	// restrict the permissions of the user
+	TokenScopes []Permission `db:"-"`
 }
 
 type UserRole string
@@ -32,6 +39,28 @@ const (
 	UserRoleView  UserRole = "view"
 )
 
+// HasCustomRole returns true if the user is authorized by the permissions of a custom role, rather than by Role.
+func (u *User) HasCustomRole() bool {
+	return u.CustomRole.Valid
+}
+
+// Allows returns true if the custom role of the user grants the requested permission. It returns false for users
+// without a custom role, who are authorized by Role instead.
+func (u *User) Allows(requested Permission) bool {
+	return u.HasCustomRole() && allows(u.Permissions, requested)
+}
+
+// HasTokenScopes returns true if the request of the user is authenticated by a scoped API token.
+func (u *User) HasTokenScopes() bool {
+	return len(u.TokenScopes) > 0
+}
+
+// TokenAllows returns true if the scopes of the API token authenticating the request of the user grant the requested
+// permission, or the request is not authenticated by a scoped API token.
+func (u *User) TokenAllows(requested Permission) bool {
+	return !u.HasTokenScopes() || allows(u.TokenScopes, requested)
+}
+
 // https://security.stackexchange.com/questions/39849/does-bcrypt-have-a-maximum-password-length
 const (
 	MaxBcryptPasswordLength = 50
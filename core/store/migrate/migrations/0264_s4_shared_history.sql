@@ -0,0 +1,21 @@
+-- +goose Up
+
+CREATE TABLE "s4".shared_history(
+    id BIGSERIAL PRIMARY KEY,
+    namespace TEXT NOT NULL,
+    address NUMERIC(78,0) NOT NULL,
+    slot_id INT NOT NULL,
+    version NUMERIC NOT NULL,
+    expiration BIGINT NOT NULL,
+    confirmed BOOLEAN NOT NULL,
+    payload BYTEA NOT NULL,
+    signature BYTEA NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE UNIQUE INDEX shared_history_namespace_address_slot_id_version_idx ON "s4".shared_history(namespace, address, slot_id, version);
+CREATE INDEX shared_history_namespace_expiration_idx ON "s4".shared_history(namespace, expiration);
+
+-- +goose Down
+
+DROP TABLE "s4".shared_history;
@@ -0,0 +1,17 @@
+-- +goose Up
+
+CREATE TABLE "s4".shared_quotas(
+    namespace TEXT NOT NULL,
+    address NUMERIC(78,0) NOT NULL,
+    period_start BIGINT NOT NULL,
+    bytes NUMERIC NOT NULL,
+    writes NUMERIC NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (namespace, address)
+);
+
+CREATE INDEX shared_quotas_namespace_period_start_idx ON "s4".shared_quotas(namespace, period_start);
+
+-- +goose Down
+
+DROP TABLE "s4".shared_quotas;
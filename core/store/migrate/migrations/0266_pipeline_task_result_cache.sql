@@ -0,0 +1,15 @@
+-- +goose Up
+
+CREATE TABLE pipeline_task_result_cache(
+    key TEXT PRIMARY KEY,
+    value BYTEA NOT NULL,
+    value_type TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX pipeline_task_result_cache_expires_at_idx ON pipeline_task_result_cache(expires_at);
+
+-- +goose Down
+
+DROP TABLE pipeline_task_result_cache;
@@ -0,0 +1,15 @@
+-- +goose Up
+
+CREATE TABLE evm.log_poller_subscriptions(
+    evm_chain_id NUMERIC(78,0) NOT NULL,
+    filter_name TEXT NOT NULL,
+    confs BIGINT NOT NULL,
+    block_number BIGINT NOT NULL,
+    log_index BIGINT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (evm_chain_id, filter_name, confs)
+);
+
+-- +goose Down
+
+DROP TABLE evm.log_poller_subscriptions;
@@ -0,0 +1,15 @@
+-- +goose Up
+
+ALTER TABLE evm.txes
+    ADD COLUMN bundle_id UUID,
+    ADD COLUMN bundle_index INTEGER,
+    ADD CONSTRAINT chk_bundle CHECK ((bundle_id IS NULL) = (bundle_index IS NULL));
+CREATE UNIQUE INDEX idx_evm_txes_bundle_id_bundle_index ON evm.txes (bundle_id, bundle_index) WHERE bundle_id IS NOT NULL;
+
+-- +goose Down
+
+DROP INDEX evm.idx_evm_txes_bundle_id_bundle_index;
+ALTER TABLE evm.txes
+    DROP CONSTRAINT chk_bundle,
+    DROP COLUMN bundle_id,
+    DROP COLUMN bundle_index;
@@ -0,0 +1,19 @@
+-- +goose Up
+
+CREATE TABLE key_rotations (
+    id BIGSERIAL PRIMARY KEY,
+    key_type TEXT NOT NULL,
+    old_key_id TEXT NOT NULL,
+    new_key_id TEXT NOT NULL,
+    state TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    retired_at TIMESTAMPTZ,
+    CONSTRAINT chk_key_rotations_retired_at CHECK ((state = 'retired') = (retired_at IS NOT NULL))
+);
+CREATE UNIQUE INDEX idx_key_rotations_draining_old_key_id ON key_rotations (key_type, old_key_id) WHERE state = 'draining';
+CREATE INDEX idx_key_rotations_new_key_id ON key_rotations (key_type, new_key_id);
+
+-- +goose Down
+
+DROP TABLE key_rotations;
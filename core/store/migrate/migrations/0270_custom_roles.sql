@@ -0,0 +1,34 @@
+-- +goose Up
+
+CREATE TABLE custom_roles (
+    name TEXT PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT '',
+    ldap_group_cn TEXT UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT chk_custom_roles_name CHECK (name ~ '^[a-z0-9][a-z0-9_-]*$' AND name NOT IN ('admin', 'edit', 'run', 'view'))
+);
+
+CREATE TABLE custom_role_permissions (
+    role_name TEXT NOT NULL REFERENCES custom_roles (name) ON DELETE CASCADE ON UPDATE CASCADE,
+    resource TEXT NOT NULL,
+    action TEXT NOT NULL,
+    scope TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (role_name, resource, action, scope)
+);
+
+-- local users keep their built-in role, which is only used if the custom role is unset; a custom role which is still
+-- assigned to local users can not be deleted
+ALTER TABLE users ADD COLUMN custom_role TEXT REFERENCES custom_roles (name) ON UPDATE CASCADE;
+
+-- LDAP sessions and API tokens are removed with their custom role, so that their users log in again
+ALTER TABLE ldap_sessions ADD COLUMN custom_role TEXT REFERENCES custom_roles (name) ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE ldap_user_api_tokens ADD COLUMN custom_role TEXT REFERENCES custom_roles (name) ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- +goose Down
+
+ALTER TABLE ldap_user_api_tokens DROP COLUMN custom_role;
+ALTER TABLE ldap_sessions DROP COLUMN custom_role;
+ALTER TABLE users DROP COLUMN custom_role;
+DROP TABLE custom_role_permissions;
+DROP TABLE custom_roles;
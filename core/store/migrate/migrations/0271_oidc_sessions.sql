@@ -0,0 +1,26 @@
+-- +goose Up
+
+-- sessions of users logged in with the OIDC identity provider, which expire with the ID token they were created with
+CREATE TABLE oidc_sessions (
+    id TEXT PRIMARY KEY,
+    user_email TEXT NOT NULL,
+    user_role user_roles NOT NULL,
+    custom_role TEXT REFERENCES custom_roles (name) ON DELETE CASCADE ON UPDATE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_oidc_sessions_expires_at ON oidc_sessions (expires_at);
+
+-- pending authorization code flows, keyed by the state passed through the identity provider
+CREATE TABLE oidc_auth_requests (
+    state TEXT PRIMARY KEY,
+    nonce TEXT NOT NULL,
+    code_verifier TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+-- +goose Down
+
+DROP TABLE oidc_auth_requests;
+DROP TABLE oidc_sessions;
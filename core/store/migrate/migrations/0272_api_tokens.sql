@@ -0,0 +1,27 @@
+-- +goose Up
+
+-- user_email has no foreign key to users, as LDAP and OIDC users are not stored locally. user_role and custom_role are
+-- the role of the user when the token was created, for OIDC users who have no active session when the token is used.
+CREATE TABLE api_tokens (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    user_email TEXT NOT NULL,
+    user_role user_roles NOT NULL,
+    custom_role TEXT,
+    scopes TEXT[] NOT NULL,
+    salt TEXT NOT NULL,
+    hashed_secret TEXT NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    last_used_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT chk_api_tokens_name CHECK (name ~ '^[a-z0-9][a-z0-9_.-]*$'),
+    CONSTRAINT chk_api_tokens_scopes CHECK (cardinality(scopes) > 0)
+);
+
+-- revoked tokens are kept for auditing, and their names can be reused
+CREATE UNIQUE INDEX idx_api_tokens_user_email_name ON api_tokens (lower(user_email), name) WHERE revoked_at IS NULL;
+
+-- +goose Down
+
+DROP TABLE api_tokens;
@@ -0,0 +1,8 @@
+-- +goose Up
+
+-- custom roles are mapped to the groups of both LDAP and OIDC users
+ALTER TABLE custom_roles RENAME COLUMN ldap_group_cn TO group_name;
+
+-- +goose Down
+
+ALTER TABLE custom_roles RENAME COLUMN group_name TO ldap_group_cn;
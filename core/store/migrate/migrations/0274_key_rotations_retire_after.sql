@@ -0,0 +1,10 @@
+-- +goose Up
+
+-- the old keys of draining rotations are retired automatically once retire_after has passed
+ALTER TABLE key_rotations ADD COLUMN retire_after TIMESTAMPTZ;
+CREATE INDEX idx_key_rotations_retire_after ON key_rotations (retire_after) WHERE state = 'draining';
+
+-- +goose Down
+
+DROP INDEX idx_key_rotations_retire_after;
+ALTER TABLE key_rotations DROP COLUMN retire_after;
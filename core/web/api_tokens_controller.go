@@ -0,0 +1,168 @@
+package web
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgconn"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+	"github.com/smartcontractkit/chainlink/v2/core/web/auth"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+// APITokensController manages the scoped API tokens of users, for automation such as CI pipelines
+type APITokensController struct {
+	App chainlink.Application
+}
+
+// APITokenRequest is the request to create a scoped API token. Scopes are permissions in the format
+// resource:action[:scope], and ExpiresIn is a duration like 720h, at most WebServer.APITokens.MaxExpiry.
+type APITokenRequest struct {
+	Name      string   `json:"name"`
+	Scopes    []string `json:"scopes"`
+	ExpiresIn string   `json:"expiresIn"`
+}
+
+func (r APITokenRequest) apiToken(user *sessions.User, maxExpiry time.Duration) (sessions.APIToken, error) {
+	expiresIn, err := time.ParseDuration(r.ExpiresIn)
+	if err != nil {
+		return sessions.APIToken{}, errors.Wrap(err, "invalid expiresIn")
+	}
+	if expiresIn <= 0 {
+		return sessions.APIToken{}, errors.New("expiresIn must be positive")
+	}
+	if expiresIn > maxExpiry {
+		return sessions.APIToken{}, errors.Errorf("expiresIn must not exceed %s", maxExpiry)
+	}
+	token := sessions.APIToken{
+		Name:       r.Name,
+		UserEmail:  user.Email,
+		UserRole:   user.Role,
+		CustomRole: user.CustomRole,
+		ExpiresAt:  time.Now().Add(expiresIn),
+	}
+	for _, s := range r.Scopes {
+		p, err := sessions.ParsePermission(s)
+		if err != nil {
+			return sessions.APIToken{}, err
+		}
+		// Tokens are also restricted by the role of their user, this only rejects scopes which could never be used
+		if user.HasCustomRole() && !user.Allows(p) {
+			return sessions.APIToken{}, errors.Errorf("scope %s is not granted by custom role %s", p, user.CustomRole.String)
+		}
+		token.Scopes = append(token.Scopes, p)
+	}
+	return token, token.Validate()
+}
+
+// isAdmin returns true if user has the built-in admin role, and may manage the tokens of all users.
+func isAdmin(user *sessions.User) bool {
+	return !user.HasCustomRole() && user.Role == sessions.UserRoleAdmin
+}
+
+// Index lists the API tokens of the user, or of all users for admins
+// Example:
+// "GET <application>/tokens"
+func (atc *APITokensController) Index(c *gin.Context) {
+	user, ok := auth.GetAuthenticatedUser(c)
+	if !ok {
+		jsonAPIError(c, http.StatusUnauthorized, errors.New("failed to obtain current user from context"))
+		return
+	}
+	email := user.Email
+	if isAdmin(user) {
+		email = ""
+	}
+	tokens, err := atc.App.APITokensORM().ListAPITokens(c.Request.Context(), email)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, presenters.NewAPITokenResources(tokens), "apiTokens")
+}
+
+// Create creates an API token of the user. The bearer token is only returned in this response.
+// Example:
+// "POST <application>/tokens"
+func (atc *APITokensController) Create(c *gin.Context) {
+	user, ok := auth.GetAuthenticatedUser(c)
+	if !ok {
+		jsonAPIError(c, http.StatusUnauthorized, errors.New("failed to obtain current user from context"))
+		return
+	}
+	var request APITokenRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	token, err := request.apiToken(user, atc.App.GetConfig().WebServer().APITokens().MaxExpiry())
+	if err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	bearer, err := atc.App.APITokensORM().CreateAPIToken(c.Request.Context(), &token)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			jsonAPIError(c, http.StatusConflict, errors.Errorf("API token %s already exists", token.Name))
+			return
+		}
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	atc.App.GetAuditLogger().Audit(audit.ScopedAPITokenCreated, map[string]interface{}{
+		"id":        token.ID,
+		"name":      token.Name,
+		"user":      token.UserEmail,
+		"scopes":    request.Scopes,
+		"expiresAt": token.ExpiresAt,
+	})
+	resource := presenters.NewAPITokenResource(token)
+	resource.Token = bearer
+	jsonAPIResponseWithStatus(c, resource, "apiToken", http.StatusCreated)
+}
+
+// Delete revokes an API token of the user, or of any user for admins
+// Example:
+// "DELETE <application>/tokens/:id"
+func (atc *APITokensController) Delete(c *gin.Context) {
+	user, ok := auth.GetAuthenticatedUser(c)
+	if !ok {
+		jsonAPIError(c, http.StatusUnauthorized, errors.New("failed to obtain current user from context"))
+		return
+	}
+	ctx := c.Request.Context()
+	orm := atc.App.APITokensORM()
+
+	token, err := orm.FindAPIToken(ctx, c.Param("id"))
+	if err == nil && !isAdmin(user) && !strings.EqualFold(token.UserEmail, user.Email) {
+		// Tokens of other users are not disclosed
+		err = sessions.ErrAPITokenNotFound
+	}
+	if err == nil {
+		err = orm.RevokeAPIToken(ctx, token.ID)
+	}
+	if errors.Is(err, sessions.ErrAPITokenNotFound) {
+		jsonAPIError(c, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	atc.App.GetAuditLogger().Audit(audit.ScopedAPITokenRevoked, map[string]interface{}{
+		"id":        token.ID,
+		"name":      token.Name,
+		"user":      token.UserEmail,
+		"revokedBy": user.Email,
+	})
+	jsonAPIResponseWithStatus(c, nil, "API token", http.StatusNoContent)
+}
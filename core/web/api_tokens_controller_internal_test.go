@@ -0,0 +1,28 @@
+package web
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+)
+
+func TestAPITokenRequest_apiToken(t *testing.T) {
+	user := &sessions.User{Email: "ci@example.com", Role: sessions.UserRoleEdit}
+	request := APITokenRequest{Name: "ci", Scopes: []string{"jobs:read"}, ExpiresIn: "720h"}
+
+	token, err := request.apiToken(user, 720*time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, "ci@example.com", token.UserEmail)
+	assert.WithinDuration(t, time.Now().Add(720*time.Hour), token.ExpiresAt, time.Minute)
+
+	_, err = request.apiToken(user, 24*time.Hour)
+	require.EqualError(t, err, "expiresIn must not exceed 24h0m0s")
+
+	request.ExpiresIn = "-1h"
+	_, err = request.apiToken(user, 720*time.Hour)
+	require.EqualError(t, err, "expiresIn must be positive")
+}
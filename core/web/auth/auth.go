@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"net/http"
+	"strings"
 
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink/v2/core/auth"
 	"github.com/smartcontractkit/chainlink/v2/core/bridges"
+	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
 	clsessions "github.com/smartcontractkit/chainlink/v2/core/sessions"
 	"github.com/smartcontractkit/chainlink/v2/core/static"
 )
@@ -33,6 +35,13 @@ const (
 
 	// SessionExternalInitiatorKey is the External Initiator key in the session map
 	SessionExternalInitiatorKey = "external_initiator"
+
+	// SessionCustomRoleAuthorizedKey is the key in the session map flagging a request as authorized by the custom role
+	// of the user
+	SessionCustomRoleAuthorizedKey = "custom_role_authorized"
+
+	// SessionAPITokenKey is the key in the session map of the scoped API token which authenticated the request
+	SessionAPITokenKey = "api_token"
 )
 
 // Authenticator defines the interface to authenticate requests against a
@@ -113,6 +122,91 @@ func AuthenticateByToken(c *gin.Context, authr Authenticator) error {
 
 var _ authMethod = AuthenticateByToken
 
+// BearerTokenAuthenticator is implemented by Authenticators accepting bearer tokens issued by an identity provider.
+type BearerTokenAuthenticator interface {
+	FindUserByBearerToken(ctx context.Context, token string) (clsessions.User, error)
+}
+
+// AuthenticateByBearerToken authenticates a User by a bearer token in the Authorization header, when supported by the
+// Authenticator.
+//
+// Implements authMethod
+func AuthenticateByBearerToken(c *gin.Context, authr Authenticator) error {
+	bearerAuthr, ok := authr.(BearerTokenAuthenticator)
+	if !ok {
+		return auth.ErrorAuthFailed
+	}
+	scheme, token, found := strings.Cut(c.GetHeader("Authorization"), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return auth.ErrorAuthFailed
+	}
+
+	user, err := bearerAuthr.FindUserByBearerToken(c.Request.Context(), strings.TrimSpace(token))
+	if err != nil {
+		return err
+	}
+
+	c.Set(SessionUserKey, &user)
+
+	return nil
+}
+
+var _ authMethod = AuthenticateByBearerToken
+
+// AuthenticateByAPIToken returns an authMethod which authenticates the user of a scoped API token, in the Authorization
+// header as a bearer token with the APITokenPrefix. Requests are then restricted to the scopes of the token. The
+// permissions of the custom role of the user are loaded with roles, and every authentication is audited.
+func AuthenticateByAPIToken(tokens clsessions.APITokensORM, roles clsessions.CustomRolesORM, auditLogger audit.AuditLogger) authMethod {
+	return func(c *gin.Context, authr Authenticator) error {
+		scheme, bearer, found := strings.Cut(c.GetHeader("Authorization"), " ")
+		bearer = strings.TrimSpace(bearer)
+		if !found || !strings.EqualFold(scheme, "Bearer") || !strings.HasPrefix(bearer, clsessions.APITokenPrefix) {
+			return auth.ErrorAuthFailed
+		}
+		ctx := c.Request.Context()
+
+		token, err := tokens.AuthenticateAPIToken(ctx, bearer)
+		if err != nil {
+			auditLogger.Audit(audit.ScopedAPITokenRejected, map[string]interface{}{"method": c.Request.Method, "path": c.Request.URL.Path})
+			if errors.Is(err, clsessions.ErrAPITokenInvalid) {
+				return auth.ErrorAuthFailed
+			}
+			return err
+		}
+		auditData := map[string]interface{}{
+			"id":     token.ID,
+			"name":   token.Name,
+			"user":   token.UserEmail,
+			"method": c.Request.Method,
+			"path":   c.Request.URL.Path,
+		}
+
+		// The user is looked up on every request, so that tokens stop working with the user, and follow its role. OIDC
+		// users are only found while they have an active session, otherwise the role stored with the token is used.
+		user, err := authr.FindUser(ctx, token.UserEmail)
+		if errors.Is(err, sql.ErrNoRows) {
+			user, err = clsessions.User{Email: token.UserEmail, Role: token.UserRole, CustomRole: token.CustomRole}, nil
+		}
+		if err == nil && user.HasCustomRole() {
+			var role clsessions.CustomRole
+			if role, err = roles.FindCustomRole(ctx, user.CustomRole.String); err == nil {
+				user.Permissions = role.Permissions
+			}
+		}
+		if err != nil {
+			auditLogger.Audit(audit.ScopedAPITokenRejected, auditData)
+			return auth.ErrorAuthFailed
+		}
+		user.TokenScopes = token.Scopes
+
+		c.Set(SessionUserKey, &user)
+		c.Set(SessionAPITokenKey, &token)
+		auditLogger.Audit(audit.ScopedAPITokenUsed, auditData)
+
+		return nil
+	}
+}
+
 // AuthenticateExternalInitiator authenticates an external initiator request.
 //
 // Implements authMethod
@@ -174,6 +268,42 @@ func Authenticate(store Authenticator, methods ...authMethod) gin.HandlerFunc {
 	}
 }
 
+// PermissionResolver returns the permission required by a request from users with a custom role. A nil permission
+// means the request requires none, while an error means it can not be authorized by a custom role.
+type PermissionResolver func(c *gin.Context) (*clsessions.Permission, error)
+
+// AuthorizeCustomRole is middleware which authorizes the requests of users with a custom role by the permission
+// resolved for the request, and forbids them otherwise. Requests authenticated by a scoped API token are also forbidden
+// unless a scope of the token grants the permission. Requests of other users are left to be authorized by the role
+// required by the route.
+func AuthorizeCustomRole(resolve PermissionResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := GetAuthenticatedUser(c)
+		if !ok || (!user.HasCustomRole() && !user.HasTokenScopes()) {
+			c.Next()
+			return
+		}
+		p, err := resolve(c)
+		if err != nil || (p != nil && (!user.TokenAllows(*p) || (user.HasCustomRole() && !user.Allows(*p)))) {
+			required, role := "unknown", string(user.Role)
+			if p != nil {
+				required = p.String()
+			}
+			if user.HasCustomRole() {
+				role = user.CustomRole.String
+			}
+			c.Abort()
+			addForbiddenErrorHeaders(c, required, role, user.Email)
+			jsonAPIError(c, http.StatusForbidden, errors.New("Forbidden"))
+			return
+		}
+		if p != nil && user.HasCustomRole() {
+			c.Set(SessionCustomRoleAuthorizedKey, true)
+		}
+		c.Next()
+	}
+}
+
 // GetAuthenticatedUser extracts the authentication user from the context.
 func GetAuthenticatedUser(c *gin.Context) (*clsessions.User, bool) {
 	obj, ok := c.Get(SessionUserKey)
@@ -186,6 +316,18 @@ func GetAuthenticatedUser(c *gin.Context) (*clsessions.User, bool) {
 	return user, ok
 }
 
+// GetAuthenticatedAPIToken extracts the scoped API token which authenticated the request from the context.
+func GetAuthenticatedAPIToken(c *gin.Context) (*clsessions.APIToken, bool) {
+	obj, ok := c.Get(SessionAPITokenKey)
+	if !ok {
+		return nil, false
+	}
+
+	token, ok := obj.(*clsessions.APIToken)
+
+	return token, ok
+}
+
 // GetAuthenticatedExternalInitiator extracts the external initiator from the
 // context.
 func GetAuthenticatedExternalInitiator(c *gin.Context) (*bridges.ExternalInitiator, bool) {
@@ -197,8 +339,20 @@ func GetAuthenticatedExternalInitiator(c *gin.Context) (*bridges.ExternalInitiat
 	return obj.(*bridges.ExternalInitiator), ok
 }
 
+// customRoleAuthorized returns true if the request of a user with a custom role was authorized by
+// AuthorizeCustomRole, and forbids it otherwise.
+func customRoleAuthorized(c *gin.Context, user *clsessions.User) bool {
+	if c.GetBool(SessionCustomRoleAuthorizedKey) {
+		return true
+	}
+	c.Abort()
+	addForbiddenErrorHeaders(c, "unknown", user.CustomRole.String, user.Email)
+	jsonAPIError(c, http.StatusForbidden, errors.New("Forbidden"))
+	return false
+}
+
 // RequiresRunRole extracts the user object from the context, and asserts the user's role is at least
-// 'run'
+// 'run', or the request was authorized by the custom role of the user
 func RequiresRunRole(handler func(*gin.Context)) func(*gin.Context) {
 	return func(c *gin.Context) {
 		user, ok := GetAuthenticatedUser(c)
@@ -207,6 +361,12 @@ func RequiresRunRole(handler func(*gin.Context)) func(*gin.Context) {
 			jsonAPIError(c, http.StatusUnauthorized, errors.New("not a valid session"))
 			return
 		}
+		if user.HasCustomRole() {
+			if customRoleAuthorized(c, user) {
+				handler(c)
+			}
+			return
+		}
 		if user.Role == clsessions.UserRoleView {
 			c.Abort()
 			jsonAPIError(c, http.StatusUnauthorized, errors.New("Unauthorized"))
@@ -217,7 +377,7 @@ func RequiresRunRole(handler func(*gin.Context)) func(*gin.Context) {
 }
 
 // RequiresEditRole extracts the user object from the context, and asserts the user's role is at least
-// 'edit'
+// 'edit', or the request was authorized by the custom role of the user
 func RequiresEditRole(handler func(*gin.Context)) func(*gin.Context) {
 	return func(c *gin.Context) {
 		user, ok := GetAuthenticatedUser(c)
@@ -226,6 +386,12 @@ func RequiresEditRole(handler func(*gin.Context)) func(*gin.Context) {
 			jsonAPIError(c, http.StatusUnauthorized, errors.New("not a valid session"))
 			return
 		}
+		if user.HasCustomRole() {
+			if customRoleAuthorized(c, user) {
+				handler(c)
+			}
+			return
+		}
 		if user.Role == clsessions.UserRoleView || user.Role == clsessions.UserRoleRun {
 			c.Abort()
 			jsonAPIError(c, http.StatusUnauthorized, errors.New("Unauthorized"))
@@ -235,7 +401,8 @@ func RequiresEditRole(handler func(*gin.Context)) func(*gin.Context) {
 	}
 }
 
-// RequiresAdminRole extracts the user object from the context, and asserts the user's role is 'admin'
+// RequiresAdminRole extracts the user object from the context, and asserts the user's role is 'admin', or the
+// request was authorized by the custom role of the user
 func RequiresAdminRole(handler func(*gin.Context)) func(*gin.Context) {
 	return func(c *gin.Context) {
 		user, ok := GetAuthenticatedUser(c)
@@ -244,6 +411,12 @@ func RequiresAdminRole(handler func(*gin.Context)) func(*gin.Context) {
 			jsonAPIError(c, http.StatusUnauthorized, errors.New("not a valid session"))
 			return
 		}
+		if user.HasCustomRole() {
+			if customRoleAuthorized(c, user) {
+				handler(c)
+			}
+			return
+		}
 		if user.Role != clsessions.UserRoleAdmin {
 			c.Abort()
 			addForbiddenErrorHeaders(c, "admin", string(user.Role), user.Email)
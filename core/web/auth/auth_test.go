@@ -2,6 +2,7 @@ package auth_test
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"io"
 	"net/http"
@@ -13,10 +14,12 @@ import (
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
 
 	"github.com/smartcontractkit/chainlink/v2/core/auth"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
 	"github.com/smartcontractkit/chainlink/v2/core/sessions"
 	"github.com/smartcontractkit/chainlink/v2/core/web"
 	webauth "github.com/smartcontractkit/chainlink/v2/core/web/auth"
@@ -133,6 +136,167 @@ func TestAuthenticateByToken_RejectsBlankAccessKey(t *testing.T) {
 	assert.Equal(t, http.StatusText(http.StatusUnauthorized), http.StatusText(w.Code))
 }
 
+type bearerTokenAuthenticator struct {
+	sessions.AuthenticationProvider
+	token string
+	user  sessions.User
+}
+
+func (b bearerTokenAuthenticator) FindUserByBearerToken(ctx context.Context, token string) (sessions.User, error) {
+	if token != b.token {
+		return sessions.User{}, auth.ErrorAuthFailed
+	}
+	return b.user, nil
+}
+
+func TestAuthenticateByBearerToken(t *testing.T) {
+	user := cltest.MustRandomUser(t)
+	authr := bearerTokenAuthenticator{token: "valid-token", user: user}
+
+	tests := []struct {
+		name   string
+		authr  webauth.Authenticator
+		header string
+		wantOK bool
+	}{
+		{"valid", authr, "Bearer valid-token", true},
+		{"lower case scheme", authr, "bearer valid-token", true},
+		{"invalid", authr, "Bearer other-token", false},
+		{"basic", authr, "Basic dXNlcjpwYXNz", false},
+		{"missing", authr, "", false},
+		{"unsupported", userFindSuccesser{user: user}, "Bearer valid-token", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var authenticated *sessions.User
+			router := gin.New()
+			router.Use(webauth.Authenticate(test.authr, webauth.AuthenticateByBearerToken))
+			router.GET("/", func(c *gin.Context) {
+				authenticated, _ = webauth.GetAuthenticatedUser(c)
+				c.String(http.StatusOK, "")
+			})
+
+			w := httptest.NewRecorder()
+			req := mustRequest(t, "GET", "/", nil)
+			if test.header != "" {
+				req.Header.Set("Authorization", test.header)
+			}
+			router.ServeHTTP(w, req)
+
+			if !test.wantOK {
+				assert.Nil(t, authenticated)
+				assert.Equal(t, http.StatusText(http.StatusUnauthorized), http.StatusText(w.Code))
+				return
+			}
+			require.NotNil(t, authenticated)
+			assert.Equal(t, user.Email, authenticated.Email)
+			assert.Equal(t, http.StatusText(http.StatusOK), http.StatusText(w.Code))
+		})
+	}
+}
+
+type apiTokensORM struct {
+	sessions.APITokensORM
+	bearer string
+	token  sessions.APIToken
+}
+
+func (o apiTokensORM) AuthenticateAPIToken(ctx context.Context, bearer string) (sessions.APIToken, error) {
+	if bearer != o.bearer {
+		return sessions.APIToken{}, sessions.ErrAPITokenInvalid
+	}
+	return o.token, nil
+}
+
+type customRolesORM struct {
+	sessions.CustomRolesORM
+	role sessions.CustomRole
+}
+
+func (o customRolesORM) FindCustomRole(ctx context.Context, name string) (sessions.CustomRole, error) {
+	if name != o.role.Name {
+		return sessions.CustomRole{}, sessions.ErrCustomRoleNotFound
+	}
+	return o.role, nil
+}
+
+type auditRecorder struct {
+	audit.AuditLogger
+	events []audit.EventID
+}
+
+func (a *auditRecorder) Audit(eventID audit.EventID, data map[string]interface{}) {
+	a.events = append(a.events, eventID)
+}
+
+func TestAuthenticateByAPIToken(t *testing.T) {
+	user := cltest.MustRandomUser(t)
+	user.CustomRole = null.StringFrom("ocr-operator")
+	role := sessions.CustomRole{
+		Name:        "ocr-operator",
+		Permissions: []sessions.Permission{sessions.NewPermission(sessions.ResourceJobs, sessions.ActionAll, "")},
+	}
+	bearer := sessions.APITokenBearer("0123abcd", "secret")
+	token := sessions.APIToken{
+		ID:         "0123abcd",
+		Name:       "ci-deploy",
+		UserEmail:  user.Email,
+		UserRole:   user.Role,
+		CustomRole: user.CustomRole,
+		Scopes:     []sessions.Permission{sessions.NewPermission(sessions.ResourceJobs, sessions.ActionRead, "")},
+	}
+	tokens := apiTokensORM{bearer: bearer, token: token}
+
+	tests := []struct {
+		name      string
+		authr     webauth.Authenticator
+		header    string
+		wantOK    bool
+		wantAudit []audit.EventID
+	}{
+		{"valid", userFindSuccesser{user: user}, "Bearer " + bearer, true, []audit.EventID{audit.ScopedAPITokenUsed}},
+		{"invalid", userFindSuccesser{user: user}, "Bearer " + sessions.APITokenBearer("0123abcd", "wrong"), false, []audit.EventID{audit.ScopedAPITokenRejected}},
+		{"user without session", userFindFailer{err: sql.ErrNoRows}, "Bearer " + bearer, true, []audit.EventID{audit.ScopedAPITokenUsed}},
+		{"user not found", userFindFailer{err: errors.New("user not active")}, "Bearer " + bearer, false, []audit.EventID{audit.ScopedAPITokenRejected}},
+		{"other bearer token", userFindSuccesser{user: user}, "Bearer valid-token", false, nil},
+		{"missing", userFindSuccesser{user: user}, "", false, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var authenticated *sessions.User
+			var authenticatedToken *sessions.APIToken
+			auditLogger := &auditRecorder{}
+			router := gin.New()
+			router.Use(webauth.Authenticate(test.authr, webauth.AuthenticateByAPIToken(tokens, customRolesORM{role: role}, auditLogger)))
+			router.GET("/", func(c *gin.Context) {
+				authenticated, _ = webauth.GetAuthenticatedUser(c)
+				authenticatedToken, _ = webauth.GetAuthenticatedAPIToken(c)
+				c.String(http.StatusOK, "")
+			})
+
+			w := httptest.NewRecorder()
+			req := mustRequest(t, "GET", "/", nil)
+			if test.header != "" {
+				req.Header.Set("Authorization", test.header)
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, test.wantAudit, auditLogger.events)
+			if !test.wantOK {
+				assert.Nil(t, authenticated)
+				assert.Equal(t, http.StatusText(http.StatusUnauthorized), http.StatusText(w.Code))
+				return
+			}
+			require.NotNil(t, authenticated)
+			assert.Equal(t, user.Email, authenticated.Email)
+			assert.Equal(t, role.Permissions, authenticated.Permissions)
+			assert.Equal(t, token.Scopes, authenticated.TokenScopes)
+			require.NotNil(t, authenticatedToken)
+			assert.Equal(t, token.ID, authenticatedToken.ID)
+		})
+	}
+}
+
 func TestRequireAuth_NoneRequired(t *testing.T) {
 	called := false
 	var authr webauth.Authenticator
@@ -227,6 +391,9 @@ var routesRolesMap = [...]routeRules{
 	{"POST", "/v2/user/token/delete", true, true, true},
 	{"GET", "/v2/enroll_webauthn", true, true, true},
 	{"POST", "/v2/enroll_webauthn", true, true, true},
+	{"GET", "/v2/tokens", true, true, true},
+	{"POST", "/v2/tokens", true, true, true},
+	{"DELETE", "/v2/tokens/MOCK", true, true, true},
 	{"GET", "/v2/external_initiators", true, true, true},
 	{"POST", "/v2/external_initiators", false, false, true},
 	{"DELETE", "/v2/external_initiators/MOCK", false, false, true},
@@ -248,6 +415,7 @@ var routesRolesMap = [...]routeRules{
 	{"GET", "/v2/transactions", true, true, true},
 	{"GET", "/v2/transactions/MOCK", true, true, true},
 	{"POST", "/v2/replay_from_block/MOCK", false, true, true},
+	{"POST", "/v2/logs/query", false, true, true},
 	{"GET", "/v2/keys/csa", true, true, true},
 	{"POST", "/v2/keys/csa", false, false, true},
 	{"POST", "/v2/keys/csa/import", false, false, false},
@@ -257,6 +425,11 @@ var routesRolesMap = [...]routeRules{
 	{"DELETE", "/v2/keys/eth/MOCK", false, false, false},
 	{"POST", "/v2/keys/eth/import", false, false, false},
 	{"POST", "/v2/keys/eth/export/MOCK", false, false, false},
+	{"POST", "/v2/keys/evm/remote", false, false, false},
+	{"GET", "/v2/keys/rotations", true, true, true},
+	{"GET", "/v2/keys/rotations/MOCK", true, true, true},
+	{"POST", "/v2/keys/rotations", false, false, false},
+	{"POST", "/v2/keys/rotations/MOCK/retire", false, false, false},
 	{"GET", "/v2/keys/ocr", true, true, true},
 	{"POST", "/v2/keys/ocr", false, false, true},
 	{"DELETE", "/v2/keys/ocr/:MOCKkeyID", false, false, false},
@@ -309,10 +482,14 @@ var routesRolesMap = [...]routeRules{
 	{"GET", "/v2/pipeline/runs", true, true, true},
 	{"GET", "/v2/jobs/MOCK/runs", true, true, true},
 	{"GET", "/v2/jobs/MOCK/runs/MOCK", true, true, true},
+	{"POST", "/v2/pipeline/runs/MOCK/replay", false, true, true},
 	{"GET", "/v2/features", true, true, true},
 	{"DELETE", "/v2/pipeline/job_spec_errors/MOCK", false, false, true},
 	{"GET", "/v2/log", true, true, true},
 	{"PATCH", "/v2/log", false, false, false},
+	{"GET", "/v2/s4/quotas", false, false, false},
+	{"DELETE", "/v2/s4/quotas", false, false, false},
+	{"DELETE", "/v2/s4/quotas/MOCK", false, false, false},
 	{"GET", "/v2/chains/evm", true, true, true},
 	{"GET", "/v2/chains/solana", true, true, true},
 	{"GET", "/v2/chains/cosmos", true, true, true},
@@ -523,3 +700,108 @@ func mustRequest(t *testing.T, method, url string, body io.Reader) *http.Request
 	require.NoError(t, err)
 	return req
 }
+
+func TestAuthorizeCustomRole(t *testing.T) {
+	t.Parallel()
+
+	user := &sessions.User{
+		Email:       cltest.APIEmailAdmin,
+		Role:        sessions.UserRoleView,
+		CustomRole:  null.StringFrom("key-exporter"),
+		Permissions: []sessions.Permission{sessions.NewPermission(sessions.ResourceKeys, sessions.ActionExport, "csa")},
+	}
+	exportCSA := sessions.NewPermission(sessions.ResourceKeys, sessions.ActionExport, "csa")
+	exportETH := sessions.NewPermission(sessions.ResourceKeys, sessions.ActionExport, "eth")
+
+	tests := []struct {
+		name       string
+		permission *sessions.Permission
+		err        error
+		requires   func(func(*gin.Context)) func(*gin.Context)
+		wantCalled bool
+		wantStatus int
+	}{
+		{"granted", &exportCSA, nil, webauth.RequiresAdminRole, true, http.StatusOK},
+		{"not granted", &exportETH, nil, webauth.RequiresAdminRole, false, http.StatusForbidden},
+		{"unknown route", nil, errors.New("unknown"), webauth.RequiresRunRole, false, http.StatusForbidden},
+		{"self-service", nil, nil, func(h func(*gin.Context)) func(*gin.Context) { return h }, true, http.StatusOK},
+		{"self-service requiring role", nil, nil, webauth.RequiresEditRole, false, http.StatusForbidden},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			called := false
+			router := gin.New()
+			router.Use(func(c *gin.Context) { c.Set(webauth.SessionUserKey, user) })
+			router.Use(webauth.AuthorizeCustomRole(func(*gin.Context) (*sessions.Permission, error) {
+				return test.permission, test.err
+			}))
+			router.GET("/", test.requires(func(c *gin.Context) {
+				called = true
+				c.String(http.StatusOK, "")
+			}))
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, mustRequest(t, "GET", "/", nil))
+
+			assert.Equal(t, test.wantCalled, called)
+			assert.Equal(t, test.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestAuthorizeCustomRole_APITokenScopes(t *testing.T) {
+	t.Parallel()
+
+	readJobs := sessions.NewPermission(sessions.ResourceJobs, sessions.ActionRead, "")
+	deleteJobs := sessions.NewPermission(sessions.ResourceJobs, sessions.ActionDelete, "")
+	exportCSA := sessions.NewPermission(sessions.ResourceKeys, sessions.ActionExport, "csa")
+
+	admin := &sessions.User{Email: cltest.APIEmailAdmin, Role: sessions.UserRoleAdmin, TokenScopes: []sessions.Permission{readJobs}}
+	viewer := &sessions.User{Email: cltest.APIEmailViewOnly, Role: sessions.UserRoleView, TokenScopes: []sessions.Permission{readJobs, deleteJobs}}
+	custom := &sessions.User{
+		Email:       cltest.APIEmailAdmin,
+		Role:        sessions.UserRoleView,
+		CustomRole:  null.StringFrom("key-exporter"),
+		Permissions: []sessions.Permission{exportCSA},
+		TokenScopes: []sessions.Permission{exportCSA, readJobs},
+	}
+
+	tests := []struct {
+		name       string
+		user       *sessions.User
+		permission *sessions.Permission
+		err        error
+		requires   func(func(*gin.Context)) func(*gin.Context)
+		wantCalled bool
+		wantStatus int
+	}{
+		{"in scope", admin, &readJobs, nil, webauth.RequiresAdminRole, true, http.StatusOK},
+		{"out of scope", admin, &deleteJobs, nil, webauth.RequiresAdminRole, false, http.StatusForbidden},
+		{"self-service", admin, nil, errors.New("self-service"), func(h func(*gin.Context)) func(*gin.Context) { return h }, false, http.StatusForbidden},
+		{"in scope not granted by role", viewer, &deleteJobs, nil, webauth.RequiresEditRole, false, http.StatusUnauthorized},
+		{"in scope granted by custom role", custom, &exportCSA, nil, webauth.RequiresAdminRole, true, http.StatusOK},
+		{"in scope not granted by custom role", custom, &readJobs, nil, webauth.RequiresAdminRole, false, http.StatusForbidden},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			called := false
+			router := gin.New()
+			router.Use(func(c *gin.Context) { c.Set(webauth.SessionUserKey, test.user) })
+			router.Use(webauth.AuthorizeCustomRole(func(*gin.Context) (*sessions.Permission, error) {
+				return test.permission, test.err
+			}))
+			router.GET("/", test.requires(func(c *gin.Context) {
+				called = true
+				c.String(http.StatusOK, "")
+			}))
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, mustRequest(t, "GET", "/", nil))
+
+			assert.Equal(t, test.wantCalled, called)
+			assert.Equal(t, test.wantStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+	"github.com/smartcontractkit/chainlink/v2/core/web/auth"
+)
+
+var errNoCustomRolePermission = errors.New("request can not be authorized by a custom role")
+
+// CustomRolePermissions resolves the permission required from users with a custom role by each API request. Requests
+// of self-service routes require no permission, and requests of unknown routes can not be authorized by a custom role.
+type CustomRolePermissions struct {
+	App chainlink.Application
+}
+
+var _ auth.PermissionResolver = CustomRolePermissions{}.Resolve
+
+// Resolve returns the permission required by the request of c.
+func (p CustomRolePermissions) Resolve(c *gin.Context) (*sessions.Permission, error) {
+	route := strings.Split(strings.Trim(strings.TrimPrefix(c.FullPath(), "/v2"), "/"), "/")
+	method := c.Request.Method
+	switch route[0] {
+	case "user", "enroll_webauthn", "tokens":
+		// Scoped API tokens can not manage the user which they authenticate
+		if _, ok := auth.GetAuthenticatedAPIToken(c); ok {
+			return nil, errNoCustomRolePermission
+		}
+		return nil, nil
+	case "features", "build_info", "ping":
+		return nil, nil
+	case "users", "roles":
+		return newPermission(sessions.ResourceUsers, methodAction(method))
+	case "bridge_types":
+		return newPermission(sessions.ResourceBridges, methodAction(method))
+	case "external_initiators":
+		return newPermission(sessions.ResourceExternalInitiators, methodAction(method))
+	case "config", "log", "debug":
+		return newPermission(sessions.ResourceConfig, methodAction(method))
+	case "s4":
+		return newPermission(sessions.ResourceS4, methodAction(method))
+	case "transfers":
+		network := "evm"
+		if len(route) > 1 {
+			network = route[1]
+		}
+		return newPermission(sessions.ResourceTransactions, sessions.ActionCreate, network)
+	case "tx_attempts", "transactions":
+		return newPermission(sessions.ResourceTransactions, sessions.ActionRead, "evm")
+	case "replay_from_block", "find_lca":
+		return newPermission(sessions.ResourceChains, sessions.ActionRun, evmChainScope(c))
+	case "logs":
+		return newPermission(sessions.ResourceChains, sessions.ActionRead, evmChainScope(c))
+	case "chains":
+		return newPermission(sessions.ResourceChains, sessions.ActionRead, c.Param("network"), c.Param("ID"))
+	case "nodes":
+		if len(route) > 2 && route[2] == "forwarders" {
+			action := sessions.ActionRead
+			if method != http.MethodGet {
+				action = sessions.ActionUpdate
+			}
+			return newPermission(sessions.ResourceChains, action, "evm")
+		}
+		return newPermission(sessions.ResourceChains, sessions.ActionRead, c.Param("network"))
+	case "keys":
+		return keyPermission(c, method, route[1:])
+	case "jobs":
+		return p.jobPermission(c, method, route[1:])
+	case "pipeline":
+		switch {
+		case len(route) > 1 && route[1] == "job_spec_errors":
+			return newPermission(sessions.ResourceJobs, sessions.ActionUpdate)
+		case method == http.MethodGet:
+			return newPermission(sessions.ResourceJobs, sessions.ActionRead)
+		default:
+			return newPermission(sessions.ResourceJobs, sessions.ActionRun)
+		}
+	}
+	return nil, errNoCustomRolePermission
+}
+
+// keyPermission returns the permission of a request to the keys of the type in route, which is the path of the request
+// below /keys.
+func keyPermission(c *gin.Context, method string, route []string) (*sessions.Permission, error) {
+	if len(route) == 0 {
+		return nil, errNoCustomRolePermission
+	}
+	if route[0] == "rotations" {
+		switch {
+		case method == http.MethodGet:
+			return newPermission(sessions.ResourceKeys, sessions.ActionRead)
+		case len(route) == 1:
+			return newPermission(sessions.ResourceKeys, sessions.ActionCreate, c.Query("keyType"))
+		default:
+			return newPermission(sessions.ResourceKeys, sessions.ActionDelete)
+		}
+	}
+
+	keyType := route[0]
+	if keyType == "evm" {
+		keyType = "eth"
+	}
+	action := methodAction(method)
+	if len(route) > 1 {
+		switch route[1] {
+		case "import", "remote":
+			action = sessions.ActionImport
+		case "export":
+			action = sessions.ActionExport
+		case "chain":
+			action = sessions.ActionUpdate
+		}
+	}
+	return newPermission(sessions.ResourceKeys, action, keyType)
+}
+
+// jobPermission returns the permission of a request to jobs, in the scope of the job type. route is the path of the
+// request below /jobs.
+func (p CustomRolePermissions) jobPermission(c *gin.Context, method string, route []string) (*sessions.Permission, error) {
+	if len(route) == 0 {
+		if method == http.MethodGet {
+			return newPermission(sessions.ResourceJobs, sessions.ActionRead)
+		}
+		jobType, err := requestJobType(c)
+		if err != nil {
+			return nil, err
+		}
+		return newPermission(sessions.ResourceJobs, sessions.ActionCreate, jobType)
+	}
+
+	// the type is loaded with the job, even if loading its specs fails
+	jb, err := p.findJob(c, c.Param("ID"))
+	if err != nil && jb.Type == "" {
+		return nil, err
+	}
+	action := methodAction(method)
+	if len(route) > 1 && route[1] == "runs" && method == http.MethodPost {
+		action = sessions.ActionRun
+	}
+	return newPermission(sessions.ResourceJobs, action, string(jb.Type))
+}
+
+// findJob finds a job by external job ID, or by job ID.
+func (p CustomRolePermissions) findJob(c *gin.Context, id string) (job.Job, error) {
+	ctx := c.Request.Context()
+	if externalJobID, err := uuid.Parse(id); err == nil {
+		return p.App.JobORM().FindJobByExternalJobID(ctx, externalJobID)
+	}
+	var jb job.Job
+	if err := jb.SetID(id); err != nil {
+		return job.Job{}, err
+	}
+	return p.App.JobORM().FindJobWithoutSpecErrors(ctx, jb.ID)
+}
+
+// requestJobType returns the type of the job spec in the body of a create job request, leaving the body to be read
+// again by the controller.
+func requestJobType(c *gin.Context) (string, error) {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return "", err
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	var request CreateJobRequest
+	if err = json.Unmarshal(body, &request); err != nil {
+		return "", err
+	}
+	jobType, err := job.ValidateSpec(request.TOML)
+	if err != nil {
+		return "", err
+	}
+	return string(jobType), nil
+}
+
+// evmChainScope returns the scope of the EVM chain of the evmChainID query parameter, or of all EVM chains if unset.
+func evmChainScope(c *gin.Context) string {
+	return strings.TrimSuffix("evm/"+c.Query("evmChainID"), "/")
+}
+
+func methodAction(method string) sessions.Action {
+	switch method {
+	case http.MethodGet:
+		return sessions.ActionRead
+	case http.MethodPost:
+		return sessions.ActionCreate
+	case http.MethodPut, http.MethodPatch:
+		return sessions.ActionUpdate
+	case http.MethodDelete:
+		return sessions.ActionDelete
+	default:
+		return sessions.ActionAll
+	}
+}
+
+// newPermission returns the permission of action on resource, in the scope joining the non-empty scope parts. Scope
+// parts are taken from the request, and must not contain wildcards.
+func newPermission(resource sessions.Resource, action sessions.Action, scopeParts ...string) (*sessions.Permission, error) {
+	var scope []string
+	for _, part := range scopeParts {
+		if strings.Contains(part, sessions.ScopeAny) {
+			return nil, errNoCustomRolePermission
+		}
+		if part != "" {
+			scope = append(scope, part)
+		}
+	}
+	p := sessions.NewPermission(resource, action, strings.Join(scope, "/"))
+	return &p, nil
+}
@@ -0,0 +1,165 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgconn"
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions/customroles"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+// CustomRolesController manages the custom roles of users
+type CustomRolesController struct {
+	App chainlink.Application
+}
+
+// CustomRoleRequest is the request to create or update a custom role. Permissions are in the format
+// resource:action[:scope].
+type CustomRoleRequest struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Group       *string  `json:"group"`
+	Permissions []string `json:"permissions"`
+}
+
+func (r CustomRoleRequest) customRole() (sessions.CustomRole, error) {
+	role := sessions.CustomRole{
+		Name:        r.Name,
+		Description: r.Description,
+		Group:       null.StringFromPtr(r.Group),
+	}
+	for _, s := range r.Permissions {
+		p, err := sessions.ParsePermission(s)
+		if err != nil {
+			return sessions.CustomRole{}, err
+		}
+		role.Permissions = append(role.Permissions, p)
+	}
+	return role, role.Validate()
+}
+
+// Index lists custom roles
+// Example:
+// "GET <application>/roles"
+func (crc *CustomRolesController) Index(c *gin.Context) {
+	roles, err := crc.App.CustomRolesORM().ListCustomRoles(c.Request.Context())
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, presenters.NewCustomRoleResources(roles), "customRoles")
+}
+
+// Show returns a custom role
+// Example:
+// "GET <application>/roles/:name"
+func (crc *CustomRolesController) Show(c *gin.Context) {
+	role, err := crc.App.CustomRolesORM().FindCustomRole(c.Request.Context(), c.Param("name"))
+	if errors.Is(err, sessions.ErrCustomRoleNotFound) {
+		jsonAPIError(c, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, presenters.NewCustomRoleResource(role), "customRole")
+}
+
+// Create creates a custom role
+// Example:
+// "POST <application>/roles"
+func (crc *CustomRolesController) Create(c *gin.Context) {
+	var request CustomRoleRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	role, err := request.customRole()
+	if err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err = crc.App.CustomRolesORM().CreateCustomRole(c.Request.Context(), &role); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			jsonAPIError(c, http.StatusConflict, errors.Errorf("custom role %s, or a role of group %s, already exists", role.Name, role.Group.String))
+			return
+		}
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	crc.App.GetAuditLogger().Audit(audit.CustomRoleCreated, map[string]interface{}{
+		"name":        role.Name,
+		"permissions": request.Permissions,
+	})
+	jsonAPIResponse(c, presenters.NewCustomRoleResource(role), "customRole")
+}
+
+// Update replaces the description, group and permissions of a custom role. Users of the role are authorized by
+// the new permissions on their next request.
+// Example:
+// "PUT <application>/roles/:name"
+func (crc *CustomRolesController) Update(c *gin.Context) {
+	var request CustomRoleRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	request.Name = c.Param("name")
+	role, err := request.customRole()
+	if err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err = crc.App.CustomRolesORM().UpdateCustomRole(c.Request.Context(), &role); err != nil {
+		var pgErr *pgconn.PgError
+		switch {
+		case errors.Is(err, sessions.ErrCustomRoleNotFound):
+			jsonAPIError(c, http.StatusNotFound, err)
+		case errors.As(err, &pgErr) && pgErr.Code == "23505":
+			jsonAPIError(c, http.StatusConflict, errors.Errorf("a role of group %s already exists", role.Group.String))
+		default:
+			jsonAPIError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	crc.App.GetAuditLogger().Audit(audit.CustomRoleUpdated, map[string]interface{}{
+		"name":        role.Name,
+		"permissions": request.Permissions,
+	})
+	jsonAPIResponse(c, presenters.NewCustomRoleResource(role), "customRole")
+}
+
+// Delete deletes a custom role which is not assigned to local users. Sessions and API tokens of LDAP users with the
+// role are deleted with it.
+// Example:
+// "DELETE <application>/roles/:name"
+func (crc *CustomRolesController) Delete(c *gin.Context) {
+	name := c.Param("name")
+	err := crc.App.CustomRolesORM().DeleteCustomRole(c.Request.Context(), name)
+	switch {
+	case errors.Is(err, sessions.ErrCustomRoleNotFound):
+		jsonAPIError(c, http.StatusNotFound, err)
+		return
+	case errors.Is(err, customroles.ErrCustomRoleAssigned):
+		jsonAPIError(c, http.StatusConflict, err)
+		return
+	case err != nil:
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	crc.App.GetAuditLogger().Audit(audit.CustomRoleDeleted, map[string]interface{}{"name": name})
+	jsonAPIResponseWithStatus(c, nil, "custom role", http.StatusNoContent)
+}
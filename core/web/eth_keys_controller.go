@@ -238,6 +238,54 @@ func (ekc *ETHKeysController) Import(c *gin.Context) {
 	})
 }
 
+// RegisterRemote adds a key held by the remote signer
+// Example:
+//
+//	"<application>/keys/evm/remote?address=0x...&evmChainID=1"
+func (ekc *ETHKeysController) RegisterRemote(c *gin.Context) {
+	ethKeyStore := ekc.app.GetKeyStore().Eth()
+
+	keyID := c.Query("address")
+	if !common.IsHexAddress(keyID) {
+		jsonAPIError(c, http.StatusBadRequest, errors.Errorf("invalid address: %s, must be hex address", keyID))
+		return
+	}
+	cid := c.Query("evmChainID")
+	chain, ok := ekc.getChain(c, cid)
+	if !ok {
+		return
+	}
+
+	key, err := ethKeyStore.AddRemote(c.Request.Context(), common.HexToAddress(keyID), chain.ID())
+	if err != nil {
+		switch {
+		case errors.Is(err, keystore.ErrNoRemoteSigner):
+			jsonAPIError(c, http.StatusBadRequest, err)
+		case errors.Is(err, keystore.ErrKeyExists):
+			jsonAPIError(c, http.StatusConflict, err)
+		default:
+			jsonAPIError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	state, err := ethKeyStore.GetState(c.Request.Context(), key.ID(), chain.ID())
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Set("key", key)
+	c.Set("state", state)
+	c.Status(http.StatusCreated)
+
+	ekc.app.GetAuditLogger().Audit(audit.KeyCreated, map[string]interface{}{
+		"type":   "ethereum",
+		"id":     key.ID(),
+		"remote": true,
+	})
+}
+
 func (ekc *ETHKeysController) Export(c *gin.Context) {
 	defer ekc.app.GetLogger().ErrorIfFn(c.Request.Body.Close, "Error closing Export request body")
 
@@ -745,3 +745,30 @@ func TestETHKeysController_DeleteFailure_KeyMissing(t *testing.T) {
 
 	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
 }
+
+func TestETHKeysController_RegisterRemoteFailure_NoRemoteSigner(t *testing.T) {
+	t.Parallel()
+	ctx := testutils.Context(t)
+	ethClient := cltest.NewEthMocksWithStartupAssertions(t)
+	cfg := configtest.NewGeneralConfig(t, func(c *chainlink.Config, s *chainlink.Secrets) {
+		c.EVM[0].NonceAutoSync = ptr(false)
+		c.EVM[0].BalanceMonitor.Enabled = ptr(false)
+	})
+	app := cltest.NewApplicationWithConfig(t, cfg, ethClient)
+	require.NoError(t, app.KeyStore.Unlock(ctx, cltest.Password))
+
+	require.NoError(t, app.Start(ctx))
+
+	client := app.NewHTTPClient(nil)
+	remoteURL := url.URL{Path: "/v2/keys/evm/remote"}
+	query := remoteURL.Query()
+
+	query.Set("address", testutils.NewAddress().Hex())
+	query.Set("evmChainID", cltest.FixtureChainID.String())
+
+	remoteURL.RawQuery = query.Encode()
+	resp, cleanup := client.Post(remoteURL.String(), nil)
+	defer cleanup()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,89 @@
+package web
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller"
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+// LogsQueryRequest is a logpoller.LogsQuery, and the ABI of the contract emitting the logs used to decode the logs of
+// its events.
+type LogsQueryRequest struct {
+	logpoller.LogsQuery
+	ABI string `json:"abi,omitempty"`
+}
+
+// EVMLogsController queries the logs saved by the log poller.
+type EVMLogsController struct {
+	App chainlink.Application
+}
+
+// Query returns the logs matching a LogsQueryRequest. A top level expression must restrict the addresses or the events
+// of the logs, so that queries are always served by an index.
+// Example:
+//
+//	"POST <application>/v2/logs/query?evmChainID=1"
+func (lc *EVMLogsController) Query(c *gin.Context) {
+	var request LogsQueryRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	chain, err := getChain(lc.App.GetRelayers().LegacyEVMChains(), c.Query("evmChainID"))
+	if err != nil {
+		if errors.Is(err, ErrInvalidChainID) || errors.Is(err, ErrMultipleChains) || errors.Is(err, ErrMissingChainID) {
+			jsonAPIError(c, http.StatusUnprocessableEntity, err)
+			return
+		}
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	expressions, limitAndSort, err := request.Parse()
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	var contract *abi.ABI
+	if request.ABI != "" {
+		parsed, err2 := abi.JSON(strings.NewReader(request.ABI))
+		if err2 != nil {
+			jsonAPIError(c, http.StatusUnprocessableEntity, errors.Wrap(err2, "invalid abi"))
+			return
+		}
+		contract = &parsed
+	}
+
+	logs, err := chain.LogPoller().FilteredLogs(c.Request.Context(), expressions, limitAndSort, "LogsQuery")
+	if err != nil {
+		if errors.Is(err, logpoller.ErrDisabled) {
+			jsonAPIError(c, http.StatusBadRequest, err)
+			return
+		}
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	resources := []presenters.EVMLogResource{}
+	for _, log := range logs {
+		var event string
+		var args map[string]any
+		if contract != nil {
+			if event, args, err = logpoller.DecodeLog(contract, log); err != nil {
+				jsonAPIError(c, http.StatusUnprocessableEntity, errors.Wrapf(err, "failed to decode log %s", logpoller.FormatContractReaderCursor(log)))
+				return
+			}
+		}
+		resources = append(resources, *presenters.NewEVMLogResource(log, event, args))
+	}
+	jsonAPIResponse(c, resources, "evmLogs")
+}
@@ -0,0 +1,53 @@
+package web_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/configtest"
+)
+
+func TestEVMLogsController_Query(t *testing.T) {
+	cfg := configtest.NewTestGeneralConfig(t)
+	ec := setupEthClientForControllerTests(t)
+	app := cltest.NewApplicationWithConfigAndKey(t, cfg, cltest.DefaultP2PKey, ec)
+	require.NoError(t, app.Start(testutils.Context(t)))
+	client := app.NewHTTPClient(nil)
+
+	for name, tc := range map[string]struct {
+		path, body, err string
+	}{
+		"unknown chain":         {"/v2/logs/query?evmChainID=1", `{"expressions": [{"address": "0x0000000000000000000000000000000000000042"}]}`, "chain id does not match any local chains"},
+		"not indexed":           {"/v2/logs/query", `{"expressions": [{"block": {"value": 1}}]}`, "query must have an address or eventSig expression"},
+		"invalid":               {"/v2/logs/query", `{"expressions": [{"address": "0x0000000000000000000000000000000000000042"}, {"topic": {"index": 4, "values": ["0x0000000000000000000000000000000000000000000000000000000000000001"]}}]}`, "invalid topic index"},
+		"cursor not finalized":  {"/v2/logs/query", `{"expressions": [{"address": "0x0000000000000000000000000000000000000042"}], "cursor": "1-0-0x01"}`, "cursor requires"},
+		"single and":            {"/v2/logs/query", `{"expressions": [{"address": "0x0000000000000000000000000000000000000042"}, {"and": [{"block": {"value": 1}}]}]}`, "and requires at least two expressions"},
+		"two fields":            {"/v2/logs/query", `{"expressions": [{"address": "0x0000000000000000000000000000000000000042", "block": {"value": 1}}]}`, "expression must have exactly one field set"},
+		"empty expression":      {"/v2/logs/query", `{"expressions": [{"address": "0x0000000000000000000000000000000000000042"}, {}]}`, "empty expression"},
+		"invalid operator":      {"/v2/logs/query", `{"expressions": [{"address": "0x0000000000000000000000000000000000000042"}, {"block": {"value": 1, "operator": "=<"}}]}`, "invalid comparison operator"},
+		"no topic values":       {"/v2/logs/query", `{"expressions": [{"address": "0x0000000000000000000000000000000000000042"}, {"topic": {"index": 1, "values": []}}]}`, "at least one value must be specified"},
+		"invalid confirmations": {"/v2/logs/query", `{"expressions": [{"address": "0x0000000000000000000000000000000000000042"}, {"confirmations": "safe"}]}`, "invalid confirmations"},
+		"too many expressions":  {"/v2/logs/query", `{"expressions": [{"address": "0x0000000000000000000000000000000000000042"}` + strings.Repeat(`, {"block": {"value": 1}}`, logpoller.MaxQueryExpressions) + `]}`, "too many expressions"},
+		"limit too high":        {"/v2/logs/query", `{"expressions": [{"address": "0x0000000000000000000000000000000000000042"}], "limit": 1001}`, "limit must be at most 1000"},
+		"invalid sort":          {"/v2/logs/query", `{"expressions": [{"address": "0x0000000000000000000000000000000000000042"}], "sort": "up"}`, "invalid sort"},
+		"invalid abi":           {"/v2/logs/query", `{"expressions": [{"address": "0x0000000000000000000000000000000000000042"}], "abi": "{"}`, "invalid abi"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resp, cleanup := client.Post(tc.path, bytes.NewBufferString(tc.body))
+			t.Cleanup(cleanup)
+			assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+			b, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Contains(t, string(b), tc.err)
+		})
+	}
+}
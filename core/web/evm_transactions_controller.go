@@ -9,6 +9,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 )
 
@@ -47,3 +48,27 @@ func (tc *TransactionsController) Show(c *gin.Context) {
 
 	jsonAPIResponse(c, presenters.NewEthTxResourceFromAttempt(*ethTxAttempt), "transaction")
 }
+
+// ShowBundle returns the details of a bundle of Ethereum Transactions.
+// Example:
+//
+//	"<application>/transactions/evm/bundles/:BundleID"
+func (tc *TransactionsController) ShowBundle(c *gin.Context) {
+	bundleID, err := uuid.Parse(c.Param("BundleID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	bundle, err := tc.App.TxmStorageService().FindTxBundle(c, bundleID)
+	if errors.Is(err, sql.ErrNoRows) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("Transaction bundle not found"))
+		return
+	}
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonAPIResponse(c, presenters.NewEthTxBundleResource(*bundle), "transaction bundle")
+}
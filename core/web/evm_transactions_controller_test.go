@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	txmgrtypes "github.com/smartcontractkit/chainlink-framework/chains/txmgr/types"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/txmgr"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
 	"github.com/smartcontractkit/chainlink/v2/core/web"
@@ -13,6 +14,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/evm/assets"
 	"github.com/smartcontractkit/chainlink/v2/evm/gas"
 
+	"github.com/google/uuid"
 	"github.com/manyminds/api2go/jsonapi"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -129,3 +131,47 @@ func TestTransactionsController_Show_NotFound(t *testing.T) {
 	t.Cleanup(cleanup)
 	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
 }
+
+func TestTransactionsController_ShowBundle_Success(t *testing.T) {
+	t.Parallel()
+
+	app := cltest.NewApplicationWithKey(t)
+	ctx := testutils.Context(t)
+	require.NoError(t, app.Start(ctx))
+
+	txStore := cltest.NewTestTxStore(t, app.GetDB())
+	client := app.NewHTTPClient(nil)
+	_, from := cltest.MustInsertRandomKey(t, app.KeyStore.Eth())
+
+	bundle, err := txStore.CreateTransactionBundle(ctx, []txmgr.TxRequest{
+		{FromAddress: from, ToAddress: testutils.NewAddress(), FeeLimit: 21000},
+		{FromAddress: from, ToAddress: testutils.NewAddress(), FeeLimit: 21000},
+	}, testutils.FixtureChainID)
+	require.NoError(t, err)
+
+	resp, cleanup := client.Get("/v2/transactions/evm/bundles/" + bundle.ID.String())
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	pbundle := presenters.EthTxBundleResource{}
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &pbundle))
+	assert.Equal(t, bundle.ID.String(), pbundle.ID)
+	assert.Equal(t, string(txmgr.TxBundleUnstarted), pbundle.State)
+	require.Len(t, pbundle.Transactions, 2)
+	assert.Equal(t, bundle.Txs[0].ToAddress, *pbundle.Transactions[0].To)
+	assert.Equal(t, bundle.Txs[1].ToAddress, *pbundle.Transactions[1].To)
+}
+
+func TestTransactionsController_ShowBundle_NotFound(t *testing.T) {
+	t.Parallel()
+
+	app := cltest.NewApplicationWithKey(t)
+	ctx := testutils.Context(t)
+	require.NoError(t, app.Start(ctx))
+
+	client := app.NewHTTPClient(nil)
+
+	resp, cleanup := client.Get("/v2/transactions/evm/bundles/" + uuid.NewString())
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+}
@@ -0,0 +1,230 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/forwarders"
+	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/ethkey"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+// KeyRotationsController manages key rotations
+type KeyRotationsController struct {
+	App chainlink.Application
+}
+
+// Index lists key rotations, optionally of a single key
+// Example:
+// "GET <application>/keys/rotations?keyID=<keyID>"
+func (krc *KeyRotationsController) Index(c *gin.Context) {
+	ctx := c.Request.Context()
+	krs, err := krc.App.GetKeyStore().Rotation().History(ctx, c.Query("keyID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	resources := []presenters.KeyRotationResource{}
+	for _, kr := range krs {
+		r, err := krc.newResource(ctx, kr)
+		if err != nil {
+			jsonAPIError(c, http.StatusInternalServerError, err)
+			return
+		}
+		resources = append(resources, *r)
+	}
+	jsonAPIResponse(c, resources, "keyRotations")
+}
+
+// Show returns a key rotation, with the references and in-flight transactions of its old key
+// Example:
+// "GET <application>/keys/rotations/:rotationID"
+func (krc *KeyRotationsController) Show(c *gin.Context) {
+	ctx := c.Request.Context()
+	id, err := strconv.ParseInt(c.Param("rotationID"), 10, 64)
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	kr, err := krc.App.GetKeyStore().Rotation().Get(ctx, id)
+	if errors.Is(err, keystore.ErrRotationNotFound) {
+		jsonAPIError(c, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	r, err := krc.newResource(ctx, kr)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, r, "keyRotation")
+}
+
+// Create rotates a key, creating its successor. The old key is retired automatically once retireAfter has elapsed if
+// set, and it is no longer referenced and has drained.
+// Example:
+// "POST <application>/keys/rotations?keyType=eth&keyID=<address>&retireAfter=72h"
+func (krc *KeyRotationsController) Create(c *gin.Context) {
+	ctx := c.Request.Context()
+	keyType := keystore.RotationKeyType(c.Query("keyType"))
+	keyID := c.Query("keyID")
+	if keyID == "" {
+		jsonAPIError(c, http.StatusBadRequest, errors.New("keyID is required"))
+		return
+	}
+	if keyType != keystore.RotationKeyTypeEth && keyType != keystore.RotationKeyTypeOCR2 {
+		jsonAPIError(c, http.StatusBadRequest, errors.Errorf("unsupported key type for rotation: %s", keyType))
+		return
+	}
+	var retireAfter *time.Time
+	if ra := c.Query("retireAfter"); ra != "" {
+		d, err2 := time.ParseDuration(ra)
+		if err2 != nil {
+			jsonAPIError(c, http.StatusUnprocessableEntity, err2)
+			return
+		}
+		if d <= 0 {
+			jsonAPIError(c, http.StatusUnprocessableEntity, errors.Errorf("retireAfter must be positive, got %s", d))
+			return
+		}
+		t := time.Now().Add(d)
+		retireAfter = &t
+	}
+	kr, err := krc.App.GetKeyStore().Rotation().Rotate(ctx, keyType, keyID, retireAfter)
+	if errors.Is(err, keystore.ErrKeyRotating) {
+		jsonAPIError(c, http.StatusConflict, err)
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	krc.App.GetAuditLogger().Audit(audit.KeyRotationStarted, map[string]interface{}{
+		"rotationID":  kr.ID,
+		"keyType":     kr.KeyType,
+		"oldKeyID":    kr.OldKeyID,
+		"newKeyID":    kr.NewKeyID,
+		"retireAfter": kr.RetireAfter,
+	})
+	r, err := krc.newResource(ctx, kr)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, r, "keyRotation")
+}
+
+// Retire retires the old key of a rotation, and deletes it if delete is true
+// Example:
+// "POST <application>/keys/rotations/:rotationID/retire?delete=true"
+func (krc *KeyRotationsController) Retire(c *gin.Context) {
+	ctx := c.Request.Context()
+	id, err := strconv.ParseInt(c.Param("rotationID"), 10, 64)
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	deleteKey := false
+	if d := c.Query("delete"); d != "" {
+		if deleteKey, err = strconv.ParseBool(d); err != nil {
+			jsonAPIError(c, http.StatusUnprocessableEntity, err)
+			return
+		}
+	}
+	kr, err := krc.App.GetKeyStore().Rotation().Retire(ctx, id, deleteKey)
+	switch {
+	case errors.Is(err, keystore.ErrRotationNotFound):
+		jsonAPIError(c, http.StatusNotFound, err)
+		return
+	case errors.Is(err, keystore.ErrRotationNotActive), errors.Is(err, keystore.ErrKeyReferenced), errors.Is(err, keystore.ErrKeyDraining):
+		jsonAPIError(c, http.StatusConflict, err)
+		return
+	case err != nil:
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	krc.App.GetAuditLogger().Audit(audit.KeyRotationRetired, map[string]interface{}{
+		"rotationID": kr.ID,
+		"keyType":    kr.KeyType,
+		"oldKeyID":   kr.OldKeyID,
+		"newKeyID":   kr.NewKeyID,
+		"deleted":    deleteKey,
+	})
+	jsonAPIResponse(c, presenters.NewKeyRotationResource(kr, nil, 0), "keyRotation")
+}
+
+// newResource returns the resource of kr, with the references and in-flight transactions of its old key while it is
+// draining.
+func (krc *KeyRotationsController) newResource(ctx context.Context, kr keystore.KeyRotation) (*presenters.KeyRotationResource, error) {
+	if kr.State != keystore.KeyRotationStateDraining {
+		return presenters.NewKeyRotationResource(kr, nil, 0), nil
+	}
+	rotation := krc.App.GetKeyStore().Rotation()
+	refs, err := rotation.References(ctx, kr.KeyType, kr.OldKeyID)
+	if err != nil {
+		return nil, err
+	}
+	if kr.KeyType == keystore.RotationKeyTypeEth {
+		fwdRefs, err2 := krc.forwarderReferences(ctx, kr.OldKeyID)
+		if err2 != nil {
+			return nil, err2
+		}
+		refs = append(refs, fwdRefs...)
+	}
+	inFlight, err := rotation.InFlightTxs(ctx, kr.KeyType, kr.OldKeyID)
+	if err != nil {
+		return nil, err
+	}
+	return presenters.NewKeyRotationResource(kr, refs, inFlight), nil
+}
+
+// forwarderReferences returns the forwarders on the chains of the eth key which authorize it on-chain. Forwarders
+// whose authorized senders cannot be read are skipped.
+func (krc *KeyRotationsController) forwarderReferences(ctx context.Context, keyID string) ([]keystore.KeyReference, error) {
+	ethKeyStore := krc.App.GetKeyStore().Eth()
+	key, err := ethKeyStore.Get(ctx, common.HexToAddress(keyID).Hex())
+	if err != nil {
+		return nil, err
+	}
+	states, err := ethKeyStore.GetStatesForKeys(ctx, []ethkey.KeyV2{key})
+	if err != nil {
+		return nil, err
+	}
+	orm := forwarders.NewORM(krc.App.GetDB())
+	var refs []keystore.KeyReference
+	for _, state := range states {
+		chain, err2 := krc.App.GetRelayers().LegacyEVMChains().Get(state.EVMChainID.String())
+		if err2 != nil {
+			// the forwarders of chains which are not enabled cannot be read
+			continue
+		}
+		fwds, err2 := orm.FindForwardersByChain(ctx, state.EVMChainID)
+		if err2 != nil {
+			return nil, err2
+		}
+		for _, fwd := range fwds {
+			senders, err2 := forwarders.AuthorizedSenders(ctx, chain.Client(), fwd.Address)
+			if err2 != nil {
+				krc.App.GetLogger().Warnw("Failed to get authorized senders of forwarder", "forwarder", fwd.Address, "evmChainID", fwd.EVMChainID, "err", err2)
+				continue
+			}
+			if slices.Contains(senders, key.Address) {
+				refs = append(refs, keystore.KeyReference{Kind: keystore.KeyReferenceKindForwarder, ID: fwd.ID, Name: fwd.Address.Hex()})
+			}
+		}
+	}
+	return refs, nil
+}
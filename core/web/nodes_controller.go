@@ -10,6 +10,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
 	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/v2/core/services/relay"
 	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
 )
 
@@ -37,7 +38,7 @@ func (n *NetworkScopedNodeStatuser) NodeStatuses(ctx context.Context, offset, li
 
 type nodesController[R jsonapi.EntityNamer] struct {
 	relayers    chainlink.RelayerChainInteroperators
-	newResource func(status types.NodeStatus) R
+	newResource func(status types.NodeStatus, archive string) R
 	auditLogger audit.AuditLogger
 }
 
@@ -46,7 +47,11 @@ func NewNodesController(
 ) NodesController {
 	return &nodesController[presenters.NodeResource]{
 		relayers:    relayers,
-		newResource: presenters.NewNodeResource,
+		newResource: func(status types.NodeStatus, archive string) presenters.NodeResource {
+			r := presenters.NewNodeResource(status)
+			r.Archive = archive
+			return r
+		},
 		auditLogger: auditLogger,
 	}
 }
@@ -80,11 +85,39 @@ func (n *nodesController[R]) Index(c *gin.Context, size, page, offset int) {
 		nodes, count, err = relayers.NodeStatuses(ctx, offset, size, rid)
 	}
 
+	var archive map[string]map[string]string
+	if network == "" || network == relay.NetworkEVM {
+		archive = n.evmArchiveStatuses(nodes)
+	}
+
 	var resources []R
 	for _, node := range nodes {
-		res := n.newResource(node)
+		res := n.newResource(node, archive[node.ChainID][node.Name])
 		resources = append(resources, res)
 	}
 
 	paginatedResponse(c, "node", size, page, resources, count, err)
 }
+
+// evmArchiveStatuses returns the archive statuses of the EVM nodes by chain ID and name, as detected by their clients.
+func (n *nodesController[R]) evmArchiveStatuses(nodes []types.NodeStatus) map[string]map[string]string {
+	legacyChains := n.relayers.LegacyEVMChains()
+	if legacyChains == nil {
+		return nil
+	}
+	statuses := map[string]map[string]string{}
+	for _, node := range nodes {
+		if _, ok := statuses[node.ChainID]; ok {
+			continue
+		}
+		statuses[node.ChainID] = map[string]string{}
+		chain, err := legacyChains.Get(node.ChainID)
+		if err != nil {
+			continue
+		}
+		for name, s := range chain.Client().NodeArchiveStatuses() {
+			statuses[node.ChainID][name] = s.String()
+		}
+	}
+	return statuses
+}
@@ -0,0 +1,87 @@
+package web
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/multierr"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	clsessions "github.com/smartcontractkit/chainlink/v2/core/sessions"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions/oidcauth"
+)
+
+const (
+	// oidcStateCookie binds the login started by a browser to the callback of the identity provider
+	oidcStateCookie = "clsession_oidc_state"
+	oidcCookiePath  = "/oidc"
+)
+
+// OIDCController manages the login of users with the OIDC identity provider.
+type OIDCController struct {
+	App chainlink.Application
+}
+
+// Login starts an authorization code flow, and redirects to the identity provider.
+func (oc *OIDCController) Login(c *gin.Context) {
+	provider, ok := oc.App.AuthenticationProvider().(clsessions.OIDCAuthenticationProvider)
+	if !ok {
+		jsonAPIError(c, http.StatusNotFound, errors.New("OIDC authentication is not enabled"))
+		return
+	}
+
+	authURL, state, err := provider.StartLogin(c.Request.Context())
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	// The callback is a cross-site redirect from the identity provider, so the cookie can not be SameSite Strict
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcStateCookie, state, int(oidcauth.AuthRequestTimeout.Seconds()), oidcCookiePath, "",
+		oc.App.GetConfig().WebServer().SecureCookies(), true)
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// Callback completes the authorization code flow with the code returned by the identity provider, creates a session
+// and returns it in a cookie.
+func (oc *OIDCController) Callback(c *gin.Context) {
+	defer oc.App.WakeSessionReaper()
+
+	provider, ok := oc.App.AuthenticationProvider().(clsessions.OIDCAuthenticationProvider)
+	if !ok {
+		jsonAPIError(c, http.StatusNotFound, errors.New("OIDC authentication is not enabled"))
+		return
+	}
+
+	cookieState, _ := c.Cookie(oidcStateCookie)
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcStateCookie, "", -1, oidcCookiePath, "", oc.App.GetConfig().WebServer().SecureCookies(), true)
+
+	if errCode := c.Query("error"); errCode != "" {
+		jsonAPIError(c, http.StatusUnauthorized, fmt.Errorf("identity provider returned error: %s %s", errCode, c.Query("error_description")))
+		return
+	}
+	state := c.Query("state")
+	if state == "" || subtle.ConstantTimeCompare([]byte(state), []byte(cookieState)) != 1 {
+		jsonAPIError(c, http.StatusUnauthorized, errors.New("login was not started by this browser, please login again"))
+		return
+	}
+
+	sid, err := provider.CreateSessionFromAuthCode(c.Request.Context(), state, c.Query("code"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnauthorized, err)
+		return
+	}
+
+	if err := saveSessionID(sessions.Default(c), sid); err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, multierr.Append(errors.New("unable to save session id"), err))
+		return
+	}
+
+	c.Redirect(http.StatusFound, "/")
+}
@@ -1,6 +1,7 @@
 package web
 
 import (
+	"database/sql"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -155,6 +156,39 @@ func (prc *PipelineRunsController) Create(c *gin.Context) {
 	jsonAPIError(c, http.StatusUnprocessableEntity, errors.New("bad job ID"))
 }
 
+// Replay re-executes a finished pipeline run in-memory, substituting the recorded
+// results of the selected tasks, and returns a per-task diff against the original run.
+// Example:
+// "POST <application>/pipeline/runs/:runID/replay"
+func (prc *PipelineRunsController) Replay(c *gin.Context) {
+	pipelineRun := pipeline.Run{}
+	err := pipelineRun.SetID(c.Param("runID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	var opts pipeline.ReplayOptions
+	if c.Request.ContentLength != 0 {
+		if err = json.NewDecoder(c.Request.Body).Decode(&opts); err != nil {
+			jsonAPIError(c, http.StatusUnprocessableEntity, errors.Wrap(err, "failed to unmarshal JSON body"))
+			return
+		}
+	}
+
+	replay, err := prc.App.ReplayPipelineRun(c.Request.Context(), pipelineRun.ID, opts)
+	if errors.Is(err, sql.ErrNoRows) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("pipeline run not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	res := presenters.NewPipelineRunReplayResource(*replay, prc.App.GetLogger())
+	jsonAPIResponse(c, res, "pipelineRunReplay")
+}
+
 // Resume finishes a task and resumes the pipeline run.
 // Example:
 // "PATCH <application>/jobs/:ID/runs/:runID"
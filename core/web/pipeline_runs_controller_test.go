@@ -24,6 +24,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/configtest"
 	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
 	"github.com/smartcontractkit/chainlink/v2/core/services/webhook"
 	"github.com/smartcontractkit/chainlink/v2/core/testdata/testspecs"
 	"github.com/smartcontractkit/chainlink/v2/core/web"
@@ -250,6 +251,38 @@ func TestPipelineRunsController_ShowRun_InvalidID(t *testing.T) {
 	cltest.AssertServerResponse(t, response, http.StatusUnprocessableEntity)
 }
 
+func TestPipelineRunsController_Replay_HappyPath(t *testing.T) {
+	client, _, runIDs := setupPipelineRunsControllerTests(t)
+
+	body := strings.NewReader(`{"taskTypes":["memo"]}`)
+	response, cleanup := client.Post("/v2/pipeline/runs/"+fmt.Sprintf("%v", runIDs[0])+"/replay", body)
+	defer cleanup()
+	cltest.AssertServerResponse(t, response, http.StatusOK)
+
+	var parsedResponse presenters.PipelineRunReplayResource
+	err := web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, response), &parsedResponse)
+	require.NoError(t, err)
+
+	assert.Equal(t, parsedResponse.ID, strconv.Itoa(int(runIDs[0])))
+	require.Len(t, parsedResponse.TaskRunDiffs, 8)
+	for _, d := range parsedResponse.TaskRunDiffs {
+		assert.Equal(t, d.Type == pipeline.TaskTypeMemo, d.Replayed, d.DotID)
+		assert.True(t, d.Recorded, d.DotID)
+		assert.False(t, d.Changed, d.DotID)
+	}
+}
+
+func TestPipelineRunsController_Replay_NotFound(t *testing.T) {
+	t.Parallel()
+	app := cltest.NewApplicationEVMDisabled(t)
+	require.NoError(t, app.Start(testutils.Context(t)))
+	client := app.NewHTTPClient(nil)
+
+	response, cleanup := client.Post("/v2/pipeline/runs/999999/replay", nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, response, http.StatusNotFound)
+}
+
 func setupPipelineRunsControllerTests(t *testing.T) (cltest.HTTPClientCleaner, int32, []int64) {
 	t.Parallel()
 	ctx := testutils.Context(t)
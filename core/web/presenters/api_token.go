@@ -0,0 +1,53 @@
+package presenters
+
+import (
+	"time"
+
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+)
+
+// APITokenResource represents a scoped API token JSONAPI resource. Token is the bearer token, which is only returned
+// when the API token is created.
+type APITokenResource struct {
+	JAID
+	Name       string     `json:"name"`
+	UserEmail  string     `json:"userEmail"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  time.Time  `json:"expiresAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	RevokedAt  *time.Time `json:"revokedAt"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	Token      string     `json:"token,omitempty"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r APITokenResource) GetName() string {
+	return "apiTokens"
+}
+
+// NewAPITokenResource constructs a new APITokenResource.
+func NewAPITokenResource(token sessions.APIToken) *APITokenResource {
+	scopes := []string{}
+	for _, p := range token.Scopes {
+		scopes = append(scopes, p.String())
+	}
+	return &APITokenResource{
+		JAID:       NewJAID(token.ID),
+		Name:       token.Name,
+		UserEmail:  token.UserEmail,
+		Scopes:     scopes,
+		ExpiresAt:  token.ExpiresAt,
+		LastUsedAt: token.LastUsedAt.Ptr(),
+		RevokedAt:  token.RevokedAt.Ptr(),
+		CreatedAt:  token.CreatedAt,
+	}
+}
+
+// NewAPITokenResources constructs a slice of APITokenResources.
+func NewAPITokenResources(tokens []sessions.APIToken) []APITokenResource {
+	rs := []APITokenResource{}
+	for _, token := range tokens {
+		rs = append(rs, *NewAPITokenResource(token))
+	}
+	return rs
+}
@@ -0,0 +1,58 @@
+package presenters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/manyminds/api2go/jsonapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+)
+
+func TestAPITokenResource(t *testing.T) {
+	ts := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	token := sessions.APIToken{
+		ID:        "0123abcd",
+		Name:      "ci-deploy",
+		UserEmail: "ci@example.com",
+		Scopes: []sessions.Permission{
+			sessions.NewPermission(sessions.ResourceJobs, sessions.ActionAll, "webhook"),
+			sessions.NewPermission(sessions.ResourceBridges, sessions.ActionRead, ""),
+		},
+		ExpiresAt:  ts.Add(time.Hour),
+		LastUsedAt: null.TimeFrom(ts.Add(time.Minute)),
+		CreatedAt:  ts,
+	}
+
+	r := NewAPITokenResource(token)
+	r.Token = "clt_0123abcd_secret"
+	b, err := jsonapi.Marshal(r)
+	require.NoError(t, err)
+
+	expected := `
+	{
+		"data": {
+			"type": "apiTokens",
+			"id": "0123abcd",
+			"attributes": {
+				"name": "ci-deploy",
+				"userEmail": "ci@example.com",
+				"scopes": ["jobs:*:webhook", "bridges:read"],
+				"expiresAt": "2000-01-01T01:00:00Z",
+				"lastUsedAt": "2000-01-01T00:01:00Z",
+				"revokedAt": null,
+				"createdAt": "2000-01-01T00:00:00Z",
+				"token": "clt_0123abcd_secret"
+			}
+		}
+	}
+	`
+	assert.JSONEq(t, expected, string(b))
+
+	b, err = jsonapi.Marshal(NewAPITokenResource(token))
+	require.NoError(t, err)
+	assert.NotContains(t, string(b), `"token"`)
+}
@@ -33,6 +33,8 @@ type NodeResource struct {
 	Name    string `json:"name"`
 	Config  string `json:"config"` // TOML
 	State   string `json:"state"`
+	// Archive is whether the node serves the state of old blocks, for networks detecting it.
+	Archive string `json:"archive,omitempty"`
 }
 
 // NewNodeResource returns a new NodeResource for node.
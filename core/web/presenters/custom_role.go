@@ -0,0 +1,49 @@
+package presenters
+
+import (
+	"time"
+
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+)
+
+// CustomRoleResource represents a custom role JSONAPI resource.
+type CustomRoleResource struct {
+	JAID
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Group       *string   `json:"group"`
+	Permissions []string  `json:"permissions"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r CustomRoleResource) GetName() string {
+	return "customRoles"
+}
+
+// NewCustomRoleResource constructs a new CustomRoleResource.
+func NewCustomRoleResource(role sessions.CustomRole) *CustomRoleResource {
+	permissions := []string{}
+	for _, p := range role.Permissions {
+		permissions = append(permissions, p.String())
+	}
+	return &CustomRoleResource{
+		JAID:        NewJAID(role.Name),
+		Name:        role.Name,
+		Description: role.Description,
+		Group:       role.Group.Ptr(),
+		Permissions: permissions,
+		CreatedAt:   role.CreatedAt,
+		UpdatedAt:   role.UpdatedAt,
+	}
+}
+
+// NewCustomRoleResources constructs a slice of CustomRoleResources.
+func NewCustomRoleResources(roles []sessions.CustomRole) []CustomRoleResource {
+	rs := []CustomRoleResource{}
+	for _, role := range roles {
+		rs = append(rs, *NewCustomRoleResource(role))
+	}
+	return rs
+}
@@ -0,0 +1,49 @@
+package presenters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/manyminds/api2go/jsonapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+)
+
+func TestCustomRoleResource(t *testing.T) {
+	ts := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	role := sessions.CustomRole{
+		Name:        "csa-exporter",
+		Description: "exports CSA keys",
+		Group:       null.StringFrom("NodeCSAExporters"),
+		Permissions: []sessions.Permission{
+			sessions.NewPermission(sessions.ResourceKeys, sessions.ActionExport, "csa"),
+			sessions.NewPermission(sessions.ResourceKeys, sessions.ActionRead, ""),
+		},
+		CreatedAt: ts,
+		UpdatedAt: ts,
+	}
+
+	b, err := jsonapi.Marshal(NewCustomRoleResource(role))
+	require.NoError(t, err)
+
+	expected := `
+	{
+		"data": {
+			"type": "customRoles",
+			"id": "csa-exporter",
+			"attributes": {
+				"name": "csa-exporter",
+				"description": "exports CSA keys",
+				"group": "NodeCSAExporters",
+				"permissions": ["keys:export:csa", "keys:read"],
+				"createdAt": "2000-01-01T00:00:00Z",
+				"updatedAt": "2000-01-01T00:00:00Z"
+			}
+		}
+	}
+	`
+	assert.JSONEq(t, expected, string(b))
+}
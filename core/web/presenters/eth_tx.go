@@ -77,3 +77,39 @@ func NewEthTxResourceFromAttempt(txa txmgr.TxAttempt) EthTxResource {
 	}
 	return r
 }
+
+// EthTxBundleResource represents a JSONAPI resource for a bundle of Ethereum Transactions.
+type EthTxBundleResource struct {
+	JAID
+	State        string          `json:"state"`
+	EVMChainID   big.Big         `json:"evmChainID"`
+	Transactions []EthTxResource `json:"transactions"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (EthTxBundleResource) GetName() string {
+	return "evm_transaction_bundles"
+}
+
+// NewEthTxBundleResource generates a EthTxBundleResource from a TxBundle, with
+// each member presented from its latest attempt, if any.
+func NewEthTxBundleResource(bundle txmgr.TxBundle) EthTxBundleResource {
+	r := EthTxBundleResource{
+		JAID:         NewJAID(bundle.ID.String()),
+		State:        string(bundle.State()),
+		Transactions: make([]EthTxResource, len(bundle.Txs)),
+	}
+	for i, tx := range bundle.Txs {
+		if len(tx.TxAttempts) > 0 {
+			txa := tx.TxAttempts[0]
+			txa.Tx = tx
+			r.Transactions[i] = NewEthTxResourceFromAttempt(txa)
+		} else {
+			r.Transactions[i] = NewEthTxResource(tx)
+		}
+		if tx.ChainID != nil {
+			r.EVMChainID = *big.New(tx.ChainID)
+		}
+	}
+	return r
+}
@@ -6,9 +6,11 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/google/uuid"
 	"github.com/manyminds/api2go/jsonapi"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
 
 	txmgrcommon "github.com/smartcontractkit/chainlink-framework/chains/txmgr"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/txmgr"
@@ -108,3 +110,50 @@ func TestEthTxResource(t *testing.T) {
 
 	assert.JSONEq(t, expected, string(b))
 }
+
+func TestEthTxBundleResource(t *testing.T) {
+	t.Parallel()
+
+	chainID := big.NewInt(54321)
+	nonce := evmtypes.Nonce(7)
+	bundle := txmgr.TxBundle{
+		ID: uuid.MustParse("7f1e4c38-7d43-4c5b-a4a9-1c1cc8b2a1d4"),
+		Txs: []txmgr.Tx{
+			{
+				FromAddress: common.HexToAddress("0x1"),
+				ToAddress:   common.HexToAddress("0x2"),
+				FeeLimit:    uint64(5000),
+				ChainID:     chainID,
+				State:       txmgrcommon.TxUnconfirmed,
+				Sequence:    &nonce,
+				TxAttempts: []txmgr.TxAttempt{{
+					Hash:  common.BytesToHash([]byte{1, 2, 3}),
+					TxFee: gas.EvmFee{GasPrice: assets.NewWeiI(1000)},
+				}},
+			},
+			{
+				FromAddress: common.HexToAddress("0x1"),
+				ToAddress:   common.HexToAddress("0x3"),
+				FeeLimit:    uint64(6000),
+				ChainID:     chainID,
+				State:       txmgrcommon.TxUnstarted,
+			},
+		},
+	}
+
+	r := NewEthTxBundleResource(bundle)
+	assert.Equal(t, "7f1e4c38-7d43-4c5b-a4a9-1c1cc8b2a1d4", r.ID)
+	assert.Equal(t, "pending", r.State)
+	assert.Equal(t, "54321", r.EVMChainID.String())
+	require.Len(t, r.Transactions, 2)
+	assert.Equal(t, common.BytesToHash([]byte{1, 2, 3}), r.Transactions[0].Hash)
+	assert.Equal(t, "7", r.Transactions[0].Nonce)
+	assert.Equal(t, "unstarted", r.Transactions[1].State)
+	assert.Equal(t, "", r.Transactions[1].Nonce)
+
+	b, err := jsonapi.Marshal(r)
+	require.NoError(t, err)
+	assert.Equal(t, "evm_transaction_bundles", gjson.GetBytes(b, "data.type").String())
+	assert.Equal(t, "pending", gjson.GetBytes(b, "data.attributes.state").String())
+	assert.Len(t, gjson.GetBytes(b, "data.attributes.transactions").Array(), 2)
+}
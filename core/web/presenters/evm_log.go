@@ -0,0 +1,53 @@
+package presenters
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller"
+)
+
+// EVMLogResource represents a log saved by the log poller. Its ID is the cursor of the log.
+type EVMLogResource struct {
+	JAID
+	BlockHash      string         `json:"blockHash"`
+	BlockNumber    int64          `json:"blockNumber"`
+	BlockTimestamp time.Time      `json:"blockTimestamp"`
+	LogIndex       int64          `json:"logIndex"`
+	Address        string         `json:"address"`
+	EventSig       string         `json:"eventSig"`
+	Topics         []string       `json:"topics"`
+	TxHash         string         `json:"txHash"`
+	Data           string         `json:"data"`
+	Event          string         `json:"event,omitempty"`
+	Args           map[string]any `json:"args,omitempty"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r EVMLogResource) GetName() string {
+	return "evmLogs"
+}
+
+// NewEVMLogResource constructs a new EVMLogResource. event and args are the name and arguments of the decoded log, if
+// any.
+func NewEVMLogResource(log logpoller.Log, event string, args map[string]any) *EVMLogResource {
+	topics := make([]string, len(log.Topics))
+	for i, t := range log.GetTopics() {
+		topics[i] = t.Hex()
+	}
+	return &EVMLogResource{
+		JAID:           NewJAID(logpoller.FormatContractReaderCursor(log)),
+		BlockHash:      log.BlockHash.Hex(),
+		BlockNumber:    log.BlockNumber,
+		BlockTimestamp: log.BlockTimestamp,
+		LogIndex:       log.LogIndex,
+		Address:        log.Address.Hex(),
+		EventSig:       log.EventSig.Hex(),
+		Topics:         topics,
+		TxHash:         log.TxHash.Hex(),
+		Data:           hexutil.Encode(log.Data),
+		Event:          event,
+		Args:           args,
+	}
+}
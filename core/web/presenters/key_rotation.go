@@ -0,0 +1,62 @@
+package presenters
+
+import (
+	"time"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore"
+)
+
+// KeyRotationReference is a resource which references the old key of a rotation.
+type KeyRotationReference struct {
+	Kind             keystore.KeyReferenceKind `json:"kind"`
+	ID               int64                     `json:"id"`
+	Name             string                    `json:"name"`
+	BlocksRetirement bool                      `json:"blocksRetirement"`
+}
+
+// KeyRotationResource is a key rotation JSONAPI resource.
+type KeyRotationResource struct {
+	JAID
+	KeyType     keystore.RotationKeyType  `json:"keyType"`
+	OldKeyID    string                    `json:"oldKeyID"`
+	NewKeyID    string                    `json:"newKeyID"`
+	State       keystore.KeyRotationState `json:"state"`
+	References  []KeyRotationReference    `json:"references"`
+	InFlightTxs int64                     `json:"inFlightTxs"`
+	CreatedAt   time.Time                 `json:"createdAt"`
+	UpdatedAt   time.Time                 `json:"updatedAt"`
+	RetireAfter *time.Time                `json:"retireAfter"`
+	RetiredAt   *time.Time                `json:"retiredAt"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r KeyRotationResource) GetName() string {
+	return "keyRotations"
+}
+
+// NewKeyRotationResource returns a new KeyRotationResource for kr, with the references and in-flight transactions of
+// its old key.
+func NewKeyRotationResource(kr keystore.KeyRotation, refs []keystore.KeyReference, inFlightTxs int64) *KeyRotationResource {
+	r := &KeyRotationResource{
+		JAID:        NewJAIDInt64(kr.ID),
+		KeyType:     kr.KeyType,
+		OldKeyID:    kr.OldKeyID,
+		NewKeyID:    kr.NewKeyID,
+		State:       kr.State,
+		References:  []KeyRotationReference{},
+		InFlightTxs: inFlightTxs,
+		CreatedAt:   kr.CreatedAt,
+		UpdatedAt:   kr.UpdatedAt,
+		RetireAfter: kr.RetireAfter,
+		RetiredAt:   kr.RetiredAt,
+	}
+	for _, ref := range refs {
+		r.References = append(r.References, KeyRotationReference{
+			Kind:             ref.Kind,
+			ID:               ref.ID,
+			Name:             ref.Name,
+			BlocksRetirement: ref.BlocksRetirement(),
+		})
+	}
+	return r
+}
@@ -0,0 +1,62 @@
+package presenters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/manyminds/api2go/jsonapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore"
+)
+
+func TestKeyRotationResource(t *testing.T) {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	retireAfter := createdAt.Add(72 * time.Hour)
+	kr := keystore.KeyRotation{
+		ID:          7,
+		KeyType:     keystore.RotationKeyTypeEth,
+		OldKeyID:    "0x0000000000000000000000000000000000000001",
+		NewKeyID:    "0x0000000000000000000000000000000000000002",
+		State:       keystore.KeyRotationStateDraining,
+		CreatedAt:   createdAt,
+		UpdatedAt:   createdAt,
+		RetireAfter: &retireAfter,
+	}
+	refs := []keystore.KeyReference{
+		{Kind: keystore.KeyReferenceKindJob, ID: 1, Name: "ocr job"},
+		{Kind: keystore.KeyReferenceKindForwarder, ID: 2, Name: "0x0000000000000000000000000000000000000003"},
+	}
+
+	r := NewKeyRotationResource(kr, refs, 3)
+	assert.Equal(t, "7", r.ID)
+
+	b, err := jsonapi.Marshal(r)
+	require.NoError(t, err)
+
+	expected := `
+	{
+	   "data":{
+		  "type":"keyRotations",
+		  "id":"7",
+		  "attributes":{
+			 "keyType":"eth",
+			 "oldKeyID":"0x0000000000000000000000000000000000000001",
+			 "newKeyID":"0x0000000000000000000000000000000000000002",
+			 "state":"draining",
+			 "references":[
+				{"kind":"job","id":1,"name":"ocr job","blocksRetirement":true},
+				{"kind":"forwarder","id":2,"name":"0x0000000000000000000000000000000000000003","blocksRetirement":false}
+			 ],
+			 "inFlightTxs":3,
+			 "createdAt":"2024-01-02T03:04:05Z",
+			 "updatedAt":"2024-01-02T03:04:05Z",
+			 "retireAfter":"2024-01-05T03:04:05Z",
+			 "retiredAt":null
+		  }
+	   }
+	}
+	`
+	assert.JSONEq(t, expected, string(b))
+}
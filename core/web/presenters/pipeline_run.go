@@ -75,12 +75,7 @@ func (r PipelineTaskRunResource) GetName() string {
 }
 
 func NewPipelineTaskRunResource(tr pipeline.TaskRun) PipelineTaskRunResource {
-	var output *string
-	if tr.Output.Valid {
-		outputBytes, _ := tr.Output.MarshalJSON()
-		outputStr := string(outputBytes)
-		output = &outputStr
-	}
+	output := taskRunOutputString(tr.Output)
 	var errString *string
 	if tr.Error.Valid {
 		errString = &tr.Error.String
@@ -104,3 +99,74 @@ func NewPipelineRunResources(prs []pipeline.Run, lggr logger.Logger) []PipelineR
 
 	return out
 }
+
+// PipelineRunReplayResource presents the outcome of replaying a pipeline run.
+type PipelineRunReplayResource struct {
+	JAID
+	Outputs      []*string                     `json:"outputs"`
+	AllErrors    []*string                     `json:"allErrors"`
+	FatalErrors  []*string                     `json:"fatalErrors"`
+	TaskRunDiffs []PipelineTaskRunDiffResource `json:"taskRunDiffs"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r PipelineRunReplayResource) GetName() string {
+	return "pipelineRunReplay"
+}
+
+func NewPipelineRunReplayResource(rr pipeline.RunReplay, lggr logger.Logger) PipelineRunReplayResource {
+	lggr = lggr.Named("PipelineRunReplayResource")
+	outputs, err := rr.Replay.StringOutputs()
+	if err != nil {
+		lggr.Errorw(err.Error(), "out", rr.Replay.Outputs)
+	}
+
+	diffs := make([]PipelineTaskRunDiffResource, 0, len(rr.TaskRunDiffs))
+	for _, d := range rr.TaskRunDiffs {
+		diffs = append(diffs, NewPipelineTaskRunDiffResource(d))
+	}
+
+	return PipelineRunReplayResource{
+		JAID:         NewJAIDInt64(rr.Original.ID),
+		Outputs:      outputs,
+		AllErrors:    rr.Replay.StringAllErrors(),
+		FatalErrors:  rr.Replay.StringFatalErrors(),
+		TaskRunDiffs: diffs,
+	}
+}
+
+// PipelineTaskRunDiffResource compares a replayed task run with the recorded one.
+type PipelineTaskRunDiffResource struct {
+	DotID          string            `json:"dotId"`
+	Type           pipeline.TaskType `json:"type"`
+	Replayed       bool              `json:"replayed"`
+	Recorded       bool              `json:"recorded"`
+	Changed        bool              `json:"changed"`
+	OriginalOutput *string           `json:"originalOutput"`
+	OriginalError  *string           `json:"originalError"`
+	Output         *string           `json:"output"`
+	Error          *string           `json:"error"`
+}
+
+func NewPipelineTaskRunDiffResource(d pipeline.TaskRunDiff) PipelineTaskRunDiffResource {
+	return PipelineTaskRunDiffResource{
+		DotID:          d.DotID,
+		Type:           d.Type,
+		Replayed:       d.Replayed,
+		Recorded:       d.Recorded,
+		Changed:        d.Changed,
+		OriginalOutput: taskRunOutputString(d.OriginalOutput),
+		OriginalError:  d.OriginalError.Ptr(),
+		Output:         taskRunOutputString(d.Output),
+		Error:          d.Error.Ptr(),
+	}
+}
+
+func taskRunOutputString(output jsonserializable.JSONSerializable) *string {
+	if !output.Valid {
+		return nil
+	}
+	outputBytes, _ := output.MarshalJSON()
+	outputStr := string(outputBytes)
+	return &outputStr
+}
@@ -0,0 +1,43 @@
+package presenters
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/s4"
+)
+
+// S4QuotaResource represents the S4 quota usage of an address.
+type S4QuotaResource struct {
+	JAID
+	Namespace   string    `json:"namespace"`
+	PeriodStart time.Time `json:"periodStart"`
+	Bytes       uint64    `json:"bytes"`
+	Writes      uint64    `json:"writes"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r S4QuotaResource) GetName() string {
+	return "s4Quotas"
+}
+
+// NewS4QuotaResource constructs a new S4QuotaResource.
+func NewS4QuotaResource(namespace string, quota *s4.Quota) *S4QuotaResource {
+	return &S4QuotaResource{
+		JAID:        NewJAID(common.BigToAddress(quota.Address.ToInt()).Hex()),
+		Namespace:   namespace,
+		PeriodStart: time.UnixMilli(quota.PeriodStart).UTC(),
+		Bytes:       quota.Bytes,
+		Writes:      quota.Writes,
+	}
+}
+
+// NewS4QuotaResources constructs a slice of S4QuotaResource.
+func NewS4QuotaResources(namespace string, quotas []*s4.Quota) []S4QuotaResource {
+	resources := []S4QuotaResource{}
+	for _, quota := range quotas {
+		resources = append(resources, *NewS4QuotaResource(namespace, quota))
+	}
+	return resources
+}
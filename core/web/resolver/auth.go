@@ -2,8 +2,13 @@ package resolver
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 
+	"github.com/smartcontractkit/chainlink/v2/core/chains"
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
 	"github.com/smartcontractkit/chainlink/v2/core/sessions"
 	"github.com/smartcontractkit/chainlink/v2/core/web/auth"
 )
@@ -16,24 +21,39 @@ func authenticateUser(ctx context.Context) error {
 	return nil
 }
 
-// Authenticates the user from the session cookie and asserts at least 'run' role.
-func authenticateUserCanRun(ctx context.Context) error {
+// Authenticates the user from the session cookie and asserts a custom role grants the permission to read p.
+func authenticateUserCanRead(ctx context.Context, p sessions.Permission) error {
 	session, ok := auth.GetGQLAuthenticatedSession(ctx)
 	if !ok {
 		return unauthorizedError{}
 	}
+	return authorizeCustomRole(session.User, p)
+}
+
+// Authenticates the user from the session cookie and asserts at least 'run' role, or a custom role granting p.
+func authenticateUserCanRun(ctx context.Context, p sessions.Permission) error {
+	session, ok := auth.GetGQLAuthenticatedSession(ctx)
+	if !ok {
+		return unauthorizedError{}
+	}
+	if session.User.HasCustomRole() {
+		return authorizeCustomRole(session.User, p)
+	}
 	if session.User.Role == sessions.UserRoleView {
 		return RoleNotPermittedErr{session.User.Role}
 	}
 	return nil
 }
 
-// Authenticates the user from the session cookie and asserts at least 'edit' role.
-func authenticateUserCanEdit(ctx context.Context) error {
+// Authenticates the user from the session cookie and asserts at least 'edit' role, or a custom role granting p.
+func authenticateUserCanEdit(ctx context.Context, p sessions.Permission) error {
 	session, ok := auth.GetGQLAuthenticatedSession(ctx)
 	if !ok {
 		return unauthorizedError{}
 	}
+	if session.User.HasCustomRole() {
+		return authorizeCustomRole(session.User, p)
+	}
 	switch session.User.Role {
 	case sessions.UserRoleView, sessions.UserRoleRun:
 		return RoleNotPermittedErr{session.User.Role}
@@ -42,18 +62,66 @@ func authenticateUserCanEdit(ctx context.Context) error {
 	return nil
 }
 
-// Authenticates the user from the session cookie and asserts has 'admin' role
-func authenticateUserIsAdmin(ctx context.Context) error {
+// Authenticates the user from the session cookie and asserts has 'admin' role, or a custom role granting p.
+func authenticateUserIsAdmin(ctx context.Context, p sessions.Permission) error {
 	session, ok := auth.GetGQLAuthenticatedSession(ctx)
 	if !ok {
 		return unauthorizedError{}
 	}
+	if session.User.HasCustomRole() {
+		return authorizeCustomRole(session.User, p)
+	}
 	if session.User.Role != sessions.UserRoleAdmin {
 		return RoleNotPermittedErr{session.User.Role}
 	}
 	return nil
 }
 
+// Asserts the custom role of the authenticated user, if any, grants p. It is used to authorize a request again once
+// the scope of the resource is known.
+func authorizeUserInScope(ctx context.Context, p sessions.Permission) error {
+	session, ok := auth.GetGQLAuthenticatedSession(ctx)
+	if !ok {
+		return unauthorizedError{}
+	}
+	return authorizeCustomRole(session.User, p)
+}
+
+// Asserts the custom role of user, if any, grants p. Users without a custom role are authorized by their role.
+func authorizeCustomRole(user *sessions.User, p sessions.Permission) error {
+	if user.HasCustomRole() && !user.Allows(p) {
+		return PermissionNotGrantedErr{Role: user.CustomRole.String, Permission: p}
+	}
+	return nil
+}
+
+// Asserts the custom role of the authenticated user, if any, grants action on the job with jobID, in the scope of its
+// type. A missing job is left to be reported by the resolver.
+func (r *Resolver) authorizeUserForJob(ctx context.Context, action sessions.Action, jobID int32) error {
+	session, ok := auth.GetGQLAuthenticatedSession(ctx)
+	if !ok {
+		return unauthorizedError{}
+	}
+	if !session.User.HasCustomRole() {
+		return nil
+	}
+	j, err := r.App.JobORM().FindJobWithoutSpecErrors(ctx, jobID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	} else if err != nil && !errors.Is(err, chains.ErrNoSuchChainID) {
+		return err
+	}
+	return authorizeCustomRole(session.User, jobPermission(action, j))
+}
+
+func jobPermission(action sessions.Action, j job.Job) sessions.Permission {
+	return sessions.NewPermission(sessions.ResourceJobs, action, string(j.Type))
+}
+
+func chainPermission(chain chainlink.NetworkChainStatus) sessions.Permission {
+	return sessions.NewPermission(sessions.ResourceChains, sessions.ActionRead, chain.Network+"/"+chain.ID)
+}
+
 type unauthorizedError struct{}
 
 func (e unauthorizedError) Error() string {
@@ -73,3 +141,12 @@ type RoleNotPermittedErr struct {
 func (e RoleNotPermittedErr) Error() string {
 	return fmt.Sprintf("Not permitted with current role: %s", e.Role)
 }
+
+type PermissionNotGrantedErr struct {
+	Role       string
+	Permission sessions.Permission
+}
+
+func (e PermissionNotGrantedErr) Error() string {
+	return fmt.Sprintf("Not permitted with current role: %s does not grant %s", e.Role, e.Permission)
+}
@@ -0,0 +1,48 @@
+package resolver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+	"github.com/smartcontractkit/chainlink/v2/core/web/auth"
+)
+
+func TestAuthenticate_CustomRole(t *testing.T) {
+	t.Parallel()
+
+	user := sessions.User{
+		Email:       "gqltester@chain.link",
+		Role:        sessions.UserRoleView,
+		CustomRole:  null.StringFrom("ocr-operator"),
+		Permissions: []sessions.Permission{sessions.NewPermission(sessions.ResourceJobs, sessions.ActionAll, "offchainreporting2")},
+	}
+	ctx := auth.WithGQLAuthenticatedSession(testutils.Context(t), user, "gqltesterSession")
+
+	ocr2 := sessions.NewPermission(sessions.ResourceJobs, sessions.ActionCreate, "offchainreporting2")
+	webhook := sessions.NewPermission(sessions.ResourceJobs, sessions.ActionCreate, "webhook")
+	anyScope := sessions.NewPermission(sessions.ResourceJobs, sessions.ActionDelete, sessions.ScopeAny)
+	keys := sessions.NewPermission(sessions.ResourceKeys, sessions.ActionRead, "")
+
+	require.NoError(t, authenticateUserIsAdmin(ctx, ocr2))
+	require.NoError(t, authenticateUserCanEdit(ctx, anyScope))
+	require.NoError(t, authorizeUserInScope(ctx, ocr2))
+
+	err := authenticateUserCanRun(ctx, webhook)
+	require.Error(t, err)
+	assert.Equal(t, PermissionNotGrantedErr{Role: "ocr-operator", Permission: webhook}, err)
+	assert.Equal(t, "Not permitted with current role: ocr-operator does not grant jobs:create:webhook", err.Error())
+
+	require.Error(t, authenticateUserCanRead(ctx, keys))
+	require.Error(t, authorizeUserInScope(ctx, webhook))
+
+	// users without a custom role are authorized by their built-in role only
+	admin := sessions.User{Email: "admin@chain.link", Role: sessions.UserRoleAdmin}
+	ctx = auth.WithGQLAuthenticatedSession(testutils.Context(t), admin, "adminSession")
+	require.NoError(t, authenticateUserCanRead(ctx, keys))
+	require.NoError(t, authorizeUserInScope(ctx, webhook))
+}
@@ -0,0 +1,294 @@
+package resolver
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller"
+	"github.com/smartcontractkit/chainlink/v2/core/utils/stringutils"
+	"github.com/smartcontractkit/chainlink/v2/core/web/gqlscalar"
+)
+
+type EVMLogResolver struct {
+	log   logpoller.Log
+	event string
+	args  map[string]any
+}
+
+// NewEVMLog creates an EVMLogResolver. event and args are the name and arguments of the decoded log, if any.
+func NewEVMLog(log logpoller.Log, event string, args map[string]any) *EVMLogResolver {
+	return &EVMLogResolver{log: log, event: event, args: args}
+}
+
+// ID resolves the cursor of the log.
+func (r *EVMLogResolver) ID() graphql.ID {
+	return graphql.ID(logpoller.FormatContractReaderCursor(r.log))
+}
+
+func (r *EVMLogResolver) BlockHash() string {
+	return r.log.BlockHash.Hex()
+}
+
+func (r *EVMLogResolver) BlockNumber() string {
+	return stringutils.FromInt64(r.log.BlockNumber)
+}
+
+func (r *EVMLogResolver) BlockTimestamp() graphql.Time {
+	return graphql.Time{Time: r.log.BlockTimestamp}
+}
+
+func (r *EVMLogResolver) LogIndex() string {
+	return stringutils.FromInt64(r.log.LogIndex)
+}
+
+func (r *EVMLogResolver) Address() string {
+	return r.log.Address.Hex()
+}
+
+func (r *EVMLogResolver) EventSig() string {
+	return r.log.EventSig.Hex()
+}
+
+func (r *EVMLogResolver) Topics() []string {
+	topics := []string{}
+	for _, t := range r.log.GetTopics() {
+		topics = append(topics, t.Hex())
+	}
+	return topics
+}
+
+func (r *EVMLogResolver) TxHash() string {
+	return r.log.TxHash.Hex()
+}
+
+func (r *EVMLogResolver) Data() hexutil.Bytes {
+	return hexutil.Bytes(r.log.Data)
+}
+
+func (r *EVMLogResolver) Event() *string {
+	if r.event == "" {
+		return nil
+	}
+	return &r.event
+}
+
+func (r *EVMLogResolver) Args() *gqlscalar.Map {
+	if r.event == "" {
+		return nil
+	}
+	args := gqlscalar.Map(r.args)
+	return &args
+}
+
+// -- EVMLogs Query --
+
+type evmLogsHashFilterInput struct {
+	Index    int32
+	Values   []string
+	Operator *string
+}
+
+type evmLogsComparisonInput struct {
+	Value    string
+	Operator *string
+}
+
+type evmLogsExpressionInput struct {
+	And           *[]evmLogsExpressionInput
+	Or            *[]evmLogsExpressionInput
+	Address       *string
+	EventSig      *string
+	Topic         *evmLogsHashFilterInput
+	Word          *evmLogsHashFilterInput
+	Confirmations *string
+	Block         *evmLogsComparisonInput
+	Timestamp     *evmLogsComparisonInput
+	TxHash        *string
+}
+
+type evmLogsQueryInput struct {
+	Expressions []evmLogsExpressionInput
+	Limit       *int32
+	Cursor      *string
+	Sort        *string
+	ABI         *string
+}
+
+// logsQuery converts the input to a logpoller.LogsQuery, which validates the expressions when parsed.
+func (i evmLogsQueryInput) logsQuery() (q logpoller.LogsQuery, err error) {
+	if q.Expressions, err = queryExpressions(i.Expressions); err != nil {
+		return q, err
+	}
+	if i.Limit != nil {
+		if *i.Limit < 0 {
+			return q, fmt.Errorf("invalid limit %d", *i.Limit)
+		}
+		q.Limit = uint64(*i.Limit)
+	}
+	if i.Cursor != nil {
+		q.Cursor = *i.Cursor
+	}
+	if i.Sort != nil {
+		q.Sort = *i.Sort
+	}
+	return q, nil
+}
+
+func queryExpressions(inputs []evmLogsExpressionInput) ([]logpoller.QueryExpression, error) {
+	exprs := make([]logpoller.QueryExpression, len(inputs))
+	for i, input := range inputs {
+		var err error
+		if exprs[i], err = input.queryExpression(); err != nil {
+			return nil, err
+		}
+	}
+	return exprs, nil
+}
+
+func (i evmLogsExpressionInput) queryExpression() (e logpoller.QueryExpression, err error) {
+	if i.And != nil {
+		if e.And, err = queryExpressions(*i.And); err != nil {
+			return e, err
+		}
+	}
+	if i.Or != nil {
+		if e.Or, err = queryExpressions(*i.Or); err != nil {
+			return e, err
+		}
+	}
+	if i.Address != nil {
+		if !common.IsHexAddress(*i.Address) {
+			return e, fmt.Errorf("invalid address %q", *i.Address)
+		}
+		address := common.HexToAddress(*i.Address)
+		e.Address = &address
+	}
+	if i.EventSig != nil {
+		if e.EventSig, err = parseHash(*i.EventSig); err != nil {
+			return e, err
+		}
+	}
+	if i.Topic != nil {
+		if e.Topic, err = i.Topic.hashFilter(); err != nil {
+			return e, err
+		}
+	}
+	if i.Word != nil {
+		if e.Word, err = i.Word.hashFilter(); err != nil {
+			return e, err
+		}
+	}
+	if i.Confirmations != nil {
+		confs, err2 := logpoller.ParseConfirmations(*i.Confirmations)
+		if err2 != nil {
+			return e, err2
+		}
+		c := logpoller.QueryConfirmations(confs)
+		e.Confirmations = &c
+	}
+	if i.Block != nil {
+		if e.Block, err = i.Block.comparison(); err != nil {
+			return e, err
+		}
+	}
+	if i.Timestamp != nil {
+		if e.Timestamp, err = i.Timestamp.comparison(); err != nil {
+			return e, err
+		}
+	}
+	if i.TxHash != nil {
+		if e.TxHash, err = parseHash(*i.TxHash); err != nil {
+			return e, err
+		}
+	}
+	return e, nil
+}
+
+func (i evmLogsHashFilterInput) hashFilter() (*logpoller.QueryHashFilter, error) {
+	if i.Index < 0 {
+		return nil, fmt.Errorf("invalid index %d", i.Index)
+	}
+	f := &logpoller.QueryHashFilter{Index: uint64(i.Index)}
+	for _, v := range i.Values {
+		h, err := parseHash(v)
+		if err != nil {
+			return nil, err
+		}
+		f.Values = append(f.Values, *h)
+	}
+	if i.Operator != nil {
+		f.Operator = *i.Operator
+	}
+	return f, nil
+}
+
+func (i evmLogsComparisonInput) comparison() (*logpoller.QueryComparison, error) {
+	value, err := strconv.ParseUint(i.Value, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value %q", i.Value)
+	}
+	c := &logpoller.QueryComparison{Value: value}
+	if i.Operator != nil {
+		c.Operator = *i.Operator
+	}
+	return c, nil
+}
+
+func parseHash(s string) (*common.Hash, error) {
+	b, err := hexutil.Decode(s)
+	if err != nil || len(b) != common.HashLength {
+		return nil, fmt.Errorf("invalid hash %q", s)
+	}
+	h := common.BytesToHash(b)
+	return &h, nil
+}
+
+type EVMLogsPayloadResolver struct {
+	results   []*EVMLogResolver
+	inputErrs map[string]string
+	NotFoundErrorUnionType
+}
+
+func NewEVMLogsPayload(results []*EVMLogResolver, err error, inputErrs map[string]string) *EVMLogsPayloadResolver {
+	e := NotFoundErrorUnionType{err: err, message: "chain not found"}
+
+	return &EVMLogsPayloadResolver{results: results, inputErrs: inputErrs, NotFoundErrorUnionType: e}
+}
+
+func (r *EVMLogsPayloadResolver) ToEVMLogsSuccess() (*EVMLogsSuccessResolver, bool) {
+	if r.err != nil || r.inputErrs != nil {
+		return nil, false
+	}
+
+	return NewEVMLogsSuccess(r.results), true
+}
+
+func (r *EVMLogsPayloadResolver) ToInputErrors() (*InputErrorsResolver, bool) {
+	if r.inputErrs != nil {
+		var errs []*InputErrorResolver
+
+		for path, message := range r.inputErrs {
+			errs = append(errs, NewInputError(path, message))
+		}
+
+		return NewInputErrors(errs), true
+	}
+
+	return nil, false
+}
+
+type EVMLogsSuccessResolver struct {
+	results []*EVMLogResolver
+}
+
+func NewEVMLogsSuccess(results []*EVMLogResolver) *EVMLogsSuccessResolver {
+	return &EVMLogsSuccessResolver{results: results}
+}
+
+func (r *EVMLogsSuccessResolver) Results() []*EVMLogResolver {
+	return r.results
+}
@@ -0,0 +1,159 @@
+package resolver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller"
+	lpmocks "github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller/mocks"
+)
+
+func TestResolver_EVMLogs(t *testing.T) {
+	t.Parallel()
+
+	query := `
+		query GetEVMLogs($chainID: ID!, $input: EVMLogsQueryInput!) {
+			evmLogs(chainID: $chainID, input: $input) {
+				... on EVMLogsSuccess {
+					results {
+						id
+						blockNumber
+						blockTimestamp
+						logIndex
+						address
+						topics
+						data
+						event
+						args
+					}
+				}
+				... on NotFoundError {
+					code
+					message
+				}
+				... on InputErrors {
+					errors {
+						path
+						message
+						code
+					}
+				}
+			}
+		}`
+	address := "0x5431F5F973781809D18643b87B44921b11355d81"
+	from := common.HexToAddress("0x0000000000000000000000000000000000000042")
+	transfer := crypto.Keccak256Hash([]byte("Transfer(address,uint256)"))
+	transferABI := `[{"type":"event","name":"Transfer","inputs":[{"name":"from","type":"address","indexed":true},{"name":"value","type":"uint256","indexed":false}]}]`
+	input := func(overrides map[string]interface{}) map[string]interface{} {
+		in := map[string]interface{}{
+			"expressions": []interface{}{map[string]interface{}{"address": address}},
+		}
+		for k, v := range overrides {
+			in[k] = v
+		}
+		return map[string]interface{}{"chainID": "22", "input": in}
+	}
+	inputErrors := func(path, message string) string {
+		return `{"evmLogs": {"errors": [{"path": "` + path + `", "message": "` + message + `", "code": "INVALID_INPUT"}]}}`
+	}
+	chainFound := func(ctx context.Context, f *gqlTestFramework) {
+		f.App.On("GetRelayers").Return(f.Mocks.relayerChainInterops)
+		f.Mocks.relayerChainInterops.EVMChains = f.Mocks.legacyEVMChains
+		f.Mocks.legacyEVMChains.On("Get", "22").Return(f.Mocks.chain, nil)
+	}
+
+	testCases := []GQLTestCase{
+		unauthorizedTestCase(GQLTestCase{query: query, variables: input(nil)}, "evmLogs"),
+		{
+			name:          "success",
+			authenticated: true,
+			before: func(ctx context.Context, f *gqlTestFramework) {
+				chainFound(ctx, f)
+				lp := lpmocks.NewLogPoller(t)
+				lp.On("FilteredLogs", mock.Anything, mock.Anything, mock.Anything, "LogsQuery").Return([]logpoller.Log{{
+					BlockNumber:    10,
+					BlockTimestamp: f.Timestamp(),
+					LogIndex:       1,
+					Address:        common.HexToAddress(address),
+					EventSig:       transfer,
+					Topics:         [][]byte{transfer.Bytes(), common.BytesToHash(from.Bytes()).Bytes()},
+					TxHash:         common.HexToHash("0x01"),
+					Data:           common.BigToHash(common.Big2).Bytes(),
+				}}, nil)
+				f.Mocks.chain.On("LogPoller").Return(lp)
+			},
+			query:     query,
+			variables: input(map[string]interface{}{"abi": transferABI}),
+			result: `
+				{
+					"evmLogs": {
+						"results": [{
+							"id": "10-1-0x0000000000000000000000000000000000000000000000000000000000000001",
+							"blockNumber": "10",
+							"blockTimestamp": "2021-01-01T00:00:00Z",
+							"logIndex": "1",
+							"address": "0x5431F5F973781809D18643b87B44921b11355d81",
+							"topics": [
+								"` + transfer.Hex() + `",
+								"0x0000000000000000000000000000000000000000000000000000000000000042"
+							],
+							"data": "0x0000000000000000000000000000000000000000000000000000000000000002",
+							"event": "Transfer",
+							"args": {"from": "0x0000000000000000000000000000000000000042", "value": 2}
+						}]
+					}
+				}`,
+		},
+		{
+			name:          "chain not found",
+			authenticated: true,
+			before: func(ctx context.Context, f *gqlTestFramework) {
+				f.App.On("GetRelayers").Return(f.Mocks.relayerChainInterops)
+				f.Mocks.relayerChainInterops.EVMChains = f.Mocks.legacyEVMChains
+				f.Mocks.legacyEVMChains.On("Get", "22").Return(nil, chains.ErrNotFound)
+			},
+			query:     query,
+			variables: input(nil),
+			result:    `{"evmLogs": {"code": "NOT_FOUND", "message": "chain not found"}}`,
+		},
+		{
+			name:          "invalid address",
+			authenticated: true,
+			before:        chainFound,
+			query:         query,
+			variables:     input(map[string]interface{}{"expressions": []interface{}{map[string]interface{}{"address": "0x42"}}}),
+			result:        inputErrors("input/expressions", `invalid address \"0x42\"`),
+		},
+		{
+			name:          "not indexed",
+			authenticated: true,
+			before:        chainFound,
+			query:         query,
+			variables:     input(map[string]interface{}{"expressions": []interface{}{map[string]interface{}{"block": map[string]interface{}{"value": "1"}}}}),
+			result:        inputErrors("input", "query must have an address or eventSig expression"),
+		},
+		{
+			name:          "invalid sort",
+			authenticated: true,
+			before:        chainFound,
+			query:         query,
+			variables:     input(map[string]interface{}{"sort": "up"}),
+			result:        inputErrors("input", `invalid sort \"up\": must be asc or desc`),
+		},
+		{
+			name:          "invalid abi",
+			authenticated: true,
+			before:        chainFound,
+			query:         query,
+			variables:     input(map[string]interface{}{"abi": "{"}),
+			result:        inputErrors("input/abi", "unexpected EOF"),
+		},
+	}
+
+	RunGQLTests(t, testCases)
+}
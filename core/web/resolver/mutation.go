@@ -43,6 +43,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
 	"github.com/smartcontractkit/chainlink/v2/core/services/webhook"
 	"github.com/smartcontractkit/chainlink/v2/core/services/workflows"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
 	"github.com/smartcontractkit/chainlink/v2/core/store/models"
 	"github.com/smartcontractkit/chainlink/v2/core/utils"
 	"github.com/smartcontractkit/chainlink/v2/core/utils/crypto"
@@ -63,7 +64,7 @@ type createBridgeInput struct {
 
 // CreateBridge creates a new bridge.
 func (r *Resolver) CreateBridge(ctx context.Context, args struct{ Input createBridgeInput }) (*CreateBridgePayloadResolver, error) {
-	if err := authenticateUserCanEdit(ctx); err != nil {
+	if err := authenticateUserCanEdit(ctx, sessions.NewPermission(sessions.ResourceBridges, sessions.ActionCreate, "")); err != nil {
 		return nil, err
 	}
 
@@ -113,7 +114,7 @@ func (r *Resolver) CreateBridge(ctx context.Context, args struct{ Input createBr
 }
 
 func (r *Resolver) CreateCSAKey(ctx context.Context) (*CreateCSAKeyPayloadResolver, error) {
-	if err := authenticateUserCanEdit(ctx); err != nil {
+	if err := authenticateUserCanEdit(ctx, sessions.NewPermission(sessions.ResourceKeys, sessions.ActionCreate, "csa")); err != nil {
 		return nil, err
 	}
 
@@ -137,7 +138,7 @@ func (r *Resolver) CreateCSAKey(ctx context.Context) (*CreateCSAKeyPayloadResolv
 func (r *Resolver) DeleteCSAKey(ctx context.Context, args struct {
 	ID graphql.ID
 }) (*DeleteCSAKeyPayloadResolver, error) {
-	if err := authenticateUserIsAdmin(ctx); err != nil {
+	if err := authenticateUserIsAdmin(ctx, sessions.NewPermission(sessions.ResourceKeys, sessions.ActionDelete, "csa")); err != nil {
 		return nil, err
 	}
 
@@ -180,7 +181,7 @@ type createFeedsManagerChainConfigInput struct {
 func (r *Resolver) CreateFeedsManagerChainConfig(ctx context.Context, args struct {
 	Input *createFeedsManagerChainConfigInput
 }) (*CreateFeedsManagerChainConfigPayloadResolver, error) {
-	if err := authenticateUserCanEdit(ctx); err != nil {
+	if err := authenticateUserCanEdit(ctx, sessions.NewPermission(sessions.ResourceFeedsManagers, sessions.ActionCreate, "")); err != nil {
 		return nil, err
 	}
 
@@ -265,7 +266,7 @@ func (r *Resolver) CreateFeedsManagerChainConfig(ctx context.Context, args struc
 func (r *Resolver) DeleteFeedsManagerChainConfig(ctx context.Context, args struct {
 	ID string
 }) (*DeleteFeedsManagerChainConfigPayloadResolver, error) {
-	if err := authenticateUserCanEdit(ctx); err != nil {
+	if err := authenticateUserCanEdit(ctx, sessions.NewPermission(sessions.ResourceFeedsManagers, sessions.ActionDelete, "")); err != nil {
 		return nil, err
 	}
 
@@ -321,7 +322,7 @@ func (r *Resolver) UpdateFeedsManagerChainConfig(ctx context.Context, args struc
 	ID    string
 	Input *updateFeedsManagerChainConfigInput
 }) (*UpdateFeedsManagerChainConfigPayloadResolver, error) {
-	if err := authenticateUserCanEdit(ctx); err != nil {
+	if err := authenticateUserCanEdit(ctx, sessions.NewPermission(sessions.ResourceFeedsManagers, sessions.ActionUpdate, "")); err != nil {
 		return nil, err
 	}
 
@@ -405,7 +406,7 @@ type createFeedsManagerInput struct {
 func (r *Resolver) CreateFeedsManager(ctx context.Context, args struct {
 	Input *createFeedsManagerInput
 }) (*CreateFeedsManagerPayloadResolver, error) {
-	if err := authenticateUserCanEdit(ctx); err != nil {
+	if err := authenticateUserCanEdit(ctx, sessions.NewPermission(sessions.ResourceFeedsManagers, sessions.ActionCreate, "")); err != nil {
 		return nil, err
 	}
 
@@ -458,7 +459,7 @@ func (r *Resolver) UpdateBridge(ctx context.Context, args struct {
 	ID    graphql.ID
 	Input updateBridgeInput
 }) (*UpdateBridgePayloadResolver, error) {
-	if err := authenticateUserCanEdit(ctx); err != nil {
+	if err := authenticateUserCanEdit(ctx, sessions.NewPermission(sessions.ResourceBridges, sessions.ActionUpdate, "")); err != nil {
 		return nil, err
 	}
 
@@ -526,7 +527,7 @@ func (r *Resolver) UpdateFeedsManager(ctx context.Context, args struct {
 	ID    graphql.ID
 	Input *updateFeedsManagerInput
 }) (*UpdateFeedsManagerPayloadResolver, error) {
-	if err := authenticateUserCanEdit(ctx); err != nil {
+	if err := authenticateUserCanEdit(ctx, sessions.NewPermission(sessions.ResourceFeedsManagers, sessions.ActionUpdate, "")); err != nil {
 		return nil, err
 	}
 
@@ -574,7 +575,7 @@ func (r *Resolver) EnableFeedsManager(ctx context.Context, args struct {
 	ID graphql.ID
 },
 ) (*EnableFeedsManagerPayloadResolver, error) {
-	if err := authenticateUserCanEdit(ctx); err != nil {
+	if err := authenticateUserCanEdit(ctx, sessions.NewPermission(sessions.ResourceFeedsManagers, sessions.ActionUpdate, "")); err != nil {
 		return nil, err
 	}
 
@@ -596,7 +597,7 @@ func (r *Resolver) DisableFeedsManager(ctx context.Context, args struct {
 	ID graphql.ID
 },
 ) (*DisableFeedsManagerPayloadResolver, error) {
-	if err := authenticateUserCanEdit(ctx); err != nil {
+	if err := authenticateUserCanEdit(ctx, sessions.NewPermission(sessions.ResourceFeedsManagers, sessions.ActionUpdate, "")); err != nil {
 		return nil, err
 	}
 
@@ -615,7 +616,7 @@ func (r *Resolver) DisableFeedsManager(ctx context.Context, args struct {
 }
 
 func (r *Resolver) CreateOCRKeyBundle(ctx context.Context) (*CreateOCRKeyBundlePayloadResolver, error) {
-	if err := authenticateUserCanEdit(ctx); err != nil {
+	if err := authenticateUserCanEdit(ctx, sessions.NewPermission(sessions.ResourceKeys, sessions.ActionCreate, "ocr")); err != nil {
 		return nil, err
 	}
 
@@ -635,7 +636,7 @@ func (r *Resolver) CreateOCRKeyBundle(ctx context.Context) (*CreateOCRKeyBundleP
 func (r *Resolver) DeleteOCRKeyBundle(ctx context.Context, args struct {
 	ID string
 }) (*DeleteOCRKeyBundlePayloadResolver, error) {
-	if err := authenticateUserIsAdmin(ctx); err != nil {
+	if err := authenticateUserIsAdmin(ctx, sessions.NewPermission(sessions.ResourceKeys, sessions.ActionDelete, "ocr")); err != nil {
 		return nil, err
 	}
 
@@ -654,7 +655,7 @@ func (r *Resolver) DeleteOCRKeyBundle(ctx context.Context, args struct {
 func (r *Resolver) DeleteBridge(ctx context.Context, args struct {
 	ID graphql.ID
 }) (*DeleteBridgePayloadResolver, error) {
-	if err := authenticateUserCanEdit(ctx); err != nil {
+	if err := authenticateUserCanEdit(ctx, sessions.NewPermission(sessions.ResourceBridges, sessions.ActionDelete, "")); err != nil {
 		return nil, err
 	}
 
@@ -690,7 +691,7 @@ func (r *Resolver) DeleteBridge(ctx context.Context, args struct {
 }
 
 func (r *Resolver) CreateP2PKey(ctx context.Context) (*CreateP2PKeyPayloadResolver, error) {
-	if err := authenticateUserCanEdit(ctx); err != nil {
+	if err := authenticateUserCanEdit(ctx, sessions.NewPermission(sessions.ResourceKeys, sessions.ActionCreate, "p2p")); err != nil {
 		return nil, err
 	}
 
@@ -714,7 +715,7 @@ func (r *Resolver) CreateP2PKey(ctx context.Context) (*CreateP2PKeyPayloadResolv
 func (r *Resolver) DeleteP2PKey(ctx context.Context, args struct {
 	ID graphql.ID
 }) (*DeleteP2PKeyPayloadResolver, error) {
-	if err := authenticateUserIsAdmin(ctx); err != nil {
+	if err := authenticateUserIsAdmin(ctx, sessions.NewPermission(sessions.ResourceKeys, sessions.ActionDelete, "p2p")); err != nil {
 		return nil, err
 	}
 
@@ -740,7 +741,7 @@ func (r *Resolver) DeleteP2PKey(ctx context.Context, args struct {
 }
 
 func (r *Resolver) CreateVRFKey(ctx context.Context) (*CreateVRFKeyPayloadResolver, error) {
-	if err := authenticateUserCanEdit(ctx); err != nil {
+	if err := authenticateUserCanEdit(ctx, sessions.NewPermission(sessions.ResourceKeys, sessions.ActionCreate, "vrf")); err != nil {
 		return nil, err
 	}
 
@@ -762,7 +763,7 @@ func (r *Resolver) CreateVRFKey(ctx context.Context) (*CreateVRFKeyPayloadResolv
 func (r *Resolver) DeleteVRFKey(ctx context.Context, args struct {
 	ID graphql.ID
 }) (*DeleteVRFKeyPayloadResolver, error) {
-	if err := authenticateUserIsAdmin(ctx); err != nil {
+	if err := authenticateUserIsAdmin(ctx, sessions.NewPermission(sessions.ResourceKeys, sessions.ActionDelete, "vrf")); err != nil {
 		return nil, err
 	}
 
@@ -787,7 +788,7 @@ func (r *Resolver) ApproveJobProposalSpec(ctx context.Context, args struct {
 	ID    graphql.ID
 	Force *bool
 }) (*ApproveJobProposalSpecPayloadResolver, error) {
-	if err := authenticateUserCanEdit(ctx); err != nil {
+	if err := authenticateUserCanEdit(ctx, sessions.NewPermission(sessions.ResourceFeedsManagers, sessions.ActionUpdate, "")); err != nil {
 		return nil, err
 	}
 
@@ -826,7 +827,7 @@ func (r *Resolver) ApproveJobProposalSpec(ctx context.Context, args struct {
 func (r *Resolver) CancelJobProposalSpec(ctx context.Context, args struct {
 	ID graphql.ID
 }) (*CancelJobProposalSpecPayloadResolver, error) {
-	if err := authenticateUserCanEdit(ctx); err != nil {
+	if err := authenticateUserCanEdit(ctx, sessions.NewPermission(sessions.ResourceFeedsManagers, sessions.ActionUpdate, "")); err != nil {
 		return nil, err
 	}
 
@@ -861,7 +862,7 @@ func (r *Resolver) CancelJobProposalSpec(ctx context.Context, args struct {
 func (r *Resolver) RejectJobProposalSpec(ctx context.Context, args struct {
 	ID graphql.ID
 }) (*RejectJobProposalSpecPayloadResolver, error) {
-	if err := authenticateUserCanEdit(ctx); err != nil {
+	if err := authenticateUserCanEdit(ctx, sessions.NewPermission(sessions.ResourceFeedsManagers, sessions.ActionUpdate, "")); err != nil {
 		return nil, err
 	}
 
@@ -897,7 +898,7 @@ func (r *Resolver) UpdateJobProposalSpecDefinition(ctx context.Context, args str
 	ID    graphql.ID
 	Input *struct{ Definition string }
 }) (*UpdateJobProposalSpecDefinitionPayloadResolver, error) {
-	if err := authenticateUserCanEdit(ctx); err != nil {
+	if err := authenticateUserCanEdit(ctx, sessions.NewPermission(sessions.ResourceFeedsManagers, sessions.ActionUpdate, "")); err != nil {
 		return nil, err
 	}
 
@@ -971,7 +972,7 @@ func (r *Resolver) UpdateUserPassword(ctx context.Context, args struct {
 func (r *Resolver) SetSQLLogging(ctx context.Context, args struct {
 	Input struct{ Enabled bool }
 }) (*SetSQLLoggingPayloadResolver, error) {
-	if err := authenticateUserIsAdmin(ctx); err != nil {
+	if err := authenticateUserIsAdmin(ctx, sessions.NewPermission(sessions.ResourceConfig, sessions.ActionUpdate, "")); err != nil {
 		return nil, err
 	}
 
@@ -1062,7 +1063,7 @@ func (r *Resolver) CreateJob(ctx context.Context, args struct {
 		TOML string
 	}
 }) (*CreateJobPayloadResolver, error) {
-	if err := authenticateUserCanEdit(ctx); err != nil {
+	if err := authenticateUserCanEdit(ctx, sessions.NewPermission(sessions.ResourceJobs, sessions.ActionCreate, sessions.ScopeAny)); err != nil {
 		return nil, err
 	}
 
@@ -1072,6 +1073,9 @@ func (r *Resolver) CreateJob(ctx context.Context, args struct {
 			"TOML spec": errors.Wrap(err, "failed to parse TOML").Error(),
 		}), nil
 	}
+	if err = authorizeUserInScope(ctx, sessions.NewPermission(sessions.ResourceJobs, sessions.ActionCreate, string(jbt))); err != nil {
+		return nil, err
+	}
 
 	var jb job.Job
 	config := r.App.GetConfig()
@@ -1140,7 +1144,7 @@ func (r *Resolver) CreateJob(ctx context.Context, args struct {
 func (r *Resolver) DeleteJob(ctx context.Context, args struct {
 	ID graphql.ID
 }) (*DeleteJobPayloadResolver, error) {
-	if err := authenticateUserCanEdit(ctx); err != nil {
+	if err := authenticateUserCanEdit(ctx, sessions.NewPermission(sessions.ResourceJobs, sessions.ActionDelete, sessions.ScopeAny)); err != nil {
 		return nil, err
 	}
 
@@ -1157,6 +1161,9 @@ func (r *Resolver) DeleteJob(ctx context.Context, args struct {
 
 		return nil, err
 	}
+	if err = authorizeUserInScope(ctx, jobPermission(sessions.ActionDelete, j)); err != nil {
+		return nil, err
+	}
 
 	err = r.App.DeleteJob(ctx, id)
 	if err != nil {
@@ -1174,7 +1181,7 @@ func (r *Resolver) DeleteJob(ctx context.Context, args struct {
 func (r *Resolver) DismissJobError(ctx context.Context, args struct {
 	ID graphql.ID
 }) (*DismissJobErrorPayloadResolver, error) {
-	if err := authenticateUserCanEdit(ctx); err != nil {
+	if err := authenticateUserCanEdit(ctx, sessions.NewPermission(sessions.ResourceJobs, sessions.ActionUpdate, sessions.ScopeAny)); err != nil {
 		return nil, err
 	}
 
@@ -1191,6 +1198,9 @@ func (r *Resolver) DismissJobError(ctx context.Context, args struct {
 
 		return nil, err
 	}
+	if err = r.authorizeUserForJob(ctx, sessions.ActionUpdate, specErr.JobID); err != nil {
+		return nil, err
+	}
 
 	err = r.App.JobORM().DismissError(ctx, id)
 	if err != nil {
@@ -1208,7 +1218,7 @@ func (r *Resolver) DismissJobError(ctx context.Context, args struct {
 func (r *Resolver) RunJob(ctx context.Context, args struct {
 	ID graphql.ID
 }) (*RunJobPayloadResolver, error) {
-	if err := authenticateUserCanRun(ctx); err != nil {
+	if err := authenticateUserCanRun(ctx, sessions.NewPermission(sessions.ResourceJobs, sessions.ActionRun, sessions.ScopeAny)); err != nil {
 		return nil, err
 	}
 
@@ -1216,6 +1226,9 @@ func (r *Resolver) RunJob(ctx context.Context, args struct {
 	if err != nil {
 		return nil, err
 	}
+	if err = r.authorizeUserForJob(ctx, sessions.ActionRun, jobID); err != nil {
+		return nil, err
+	}
 
 	jobRunID, err := r.App.RunJobV2(ctx, jobID, nil)
 	if err != nil {
@@ -1238,7 +1251,7 @@ func (r *Resolver) RunJob(ctx context.Context, args struct {
 func (r *Resolver) SetGlobalLogLevel(ctx context.Context, args struct {
 	Level LogLevel
 }) (*SetGlobalLogLevelPayloadResolver, error) {
-	if err := authenticateUserIsAdmin(ctx); err != nil {
+	if err := authenticateUserIsAdmin(ctx, sessions.NewPermission(sessions.ResourceConfig, sessions.ActionUpdate, "")); err != nil {
 		return nil, err
 	}
 
@@ -1264,7 +1277,7 @@ func (r *Resolver) SetGlobalLogLevel(ctx context.Context, args struct {
 func (r *Resolver) CreateOCR2KeyBundle(ctx context.Context, args struct {
 	ChainType OCR2ChainType
 }) (*CreateOCR2KeyBundlePayloadResolver, error) {
-	if err := authenticateUserCanEdit(ctx); err != nil {
+	if err := authenticateUserCanEdit(ctx, sessions.NewPermission(sessions.ResourceKeys, sessions.ActionCreate, "ocr2")); err != nil {
 		return nil, err
 	}
 
@@ -1292,7 +1305,7 @@ func (r *Resolver) CreateOCR2KeyBundle(ctx context.Context, args struct {
 func (r *Resolver) DeleteOCR2KeyBundle(ctx context.Context, args struct {
 	ID graphql.ID
 }) (*DeleteOCR2KeyBundlePayloadResolver, error) {
-	if err := authenticateUserIsAdmin(ctx); err != nil {
+	if err := authenticateUserIsAdmin(ctx, sessions.NewPermission(sessions.ResourceKeys, sessions.ActionDelete, "ocr2")); err != nil {
 		return nil, err
 	}
 
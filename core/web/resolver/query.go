@@ -5,7 +5,9 @@ import (
 	"database/sql"
 	"fmt"
 	"sort"
+	"strings"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/graph-gophers/graphql-go"
 	"github.com/pkg/errors"
@@ -15,16 +17,19 @@ import (
 
 	"github.com/smartcontractkit/chainlink/v2/core/bridges"
 	"github.com/smartcontractkit/chainlink/v2/core/chains"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller"
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore"
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/vrfkey"
+	"github.com/smartcontractkit/chainlink/v2/core/services/relay"
 	evmrelay "github.com/smartcontractkit/chainlink/v2/core/services/relay/evm"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
 	"github.com/smartcontractkit/chainlink/v2/core/utils/stringutils"
 	"github.com/smartcontractkit/chainlink/v2/core/web/loader"
 )
 
 // Bridge retrieves a bridges by name.
 func (r *Resolver) Bridge(ctx context.Context, args struct{ ID graphql.ID }) (*BridgePayloadResolver, error) {
-	if err := authenticateUser(ctx); err != nil {
+	if err := authenticateUserCanRead(ctx, sessions.NewPermission(sessions.ResourceBridges, sessions.ActionRead, "")); err != nil {
 		return nil, err
 	}
 
@@ -50,7 +55,7 @@ func (r *Resolver) Bridges(ctx context.Context, args struct {
 	Offset *int32
 	Limit  *int32
 }) (*BridgesPayloadResolver, error) {
-	if err := authenticateUser(ctx); err != nil {
+	if err := authenticateUserCanRead(ctx, sessions.NewPermission(sessions.ResourceBridges, sessions.ActionRead, "")); err != nil {
 		return nil, err
 	}
 
@@ -71,7 +76,7 @@ func (r *Resolver) Chain(ctx context.Context,
 		ID      graphql.ID
 		Network *string
 	}) (*ChainPayloadResolver, error) {
-	if err := authenticateUser(ctx); err != nil {
+	if err := authenticateUserCanRead(ctx, sessions.NewPermission(sessions.ResourceChains, sessions.ActionRead, sessions.ScopeAny)); err != nil {
 		return nil, err
 	}
 
@@ -84,6 +89,9 @@ func (r *Resolver) Chain(ctx context.Context,
 			}
 			return nil, err
 		}
+		if err = authorizeUserInScope(ctx, chainPermission(*id)); err != nil {
+			return nil, err
+		}
 		return NewChainPayload(*id, nil), nil
 	}
 
@@ -95,6 +103,9 @@ func (r *Resolver) Chain(ctx context.Context,
 		}
 		return nil, err
 	}
+	if err = authorizeUserInScope(ctx, chainPermission(*id)); err != nil {
+		return nil, err
+	}
 
 	return NewChainPayload(*id, nil), nil
 }
@@ -104,7 +115,7 @@ func (r *Resolver) Chains(ctx context.Context, args struct {
 	Offset *int32
 	Limit  *int32
 }) (*ChainsPayloadResolver, error) {
-	if err := authenticateUser(ctx); err != nil {
+	if err := authenticateUserCanRead(ctx, sessions.NewPermission(sessions.ResourceChains, sessions.ActionRead, "")); err != nil {
 		return nil, err
 	}
 
@@ -159,7 +170,7 @@ func sortByNetworkAndID(chains []chainlink.NetworkChainStatus) {
 
 // FeedsManager retrieves a feeds manager by id.
 func (r *Resolver) FeedsManager(ctx context.Context, args struct{ ID graphql.ID }) (*FeedsManagerPayloadResolver, error) {
-	if err := authenticateUser(ctx); err != nil {
+	if err := authenticateUserCanRead(ctx, sessions.NewPermission(sessions.ResourceFeedsManagers, sessions.ActionRead, "")); err != nil {
 		return nil, err
 	}
 
@@ -181,7 +192,7 @@ func (r *Resolver) FeedsManager(ctx context.Context, args struct{ ID graphql.ID
 }
 
 func (r *Resolver) FeedsManagers(ctx context.Context) (*FeedsManagersPayloadResolver, error) {
-	if err := authenticateUser(ctx); err != nil {
+	if err := authenticateUserCanRead(ctx, sessions.NewPermission(sessions.ResourceFeedsManagers, sessions.ActionRead, "")); err != nil {
 		return nil, err
 	}
 
@@ -195,7 +206,7 @@ func (r *Resolver) FeedsManagers(ctx context.Context) (*FeedsManagersPayloadReso
 
 // Job retrieves a job by id.
 func (r *Resolver) Job(ctx context.Context, args struct{ ID graphql.ID }) (*JobPayloadResolver, error) {
-	if err := authenticateUser(ctx); err != nil {
+	if err := authenticateUserCanRead(ctx, sessions.NewPermission(sessions.ResourceJobs, sessions.ActionRead, sessions.ScopeAny)); err != nil {
 		return nil, err
 	}
 
@@ -212,11 +223,17 @@ func (r *Resolver) Job(ctx context.Context, args struct{ ID graphql.ID }) (*JobP
 
 		// We still need to show the job in UI/CLI even if the chain id is disabled
 		if errors.Is(err, chains.ErrNoSuchChainID) {
+			if err2 := authorizeUserInScope(ctx, jobPermission(sessions.ActionRead, j)); err2 != nil {
+				return nil, err2
+			}
 			return NewJobPayload(r.App, &j, err), nil
 		}
 
 		return nil, err
 	}
+	if err = authorizeUserInScope(ctx, jobPermission(sessions.ActionRead, j)); err != nil {
+		return nil, err
+	}
 
 	return NewJobPayload(r.App, &j, nil), nil
 }
@@ -226,7 +243,7 @@ func (r *Resolver) Jobs(ctx context.Context, args struct {
 	Offset *int32
 	Limit  *int32
 }) (*JobsPayloadResolver, error) {
-	if err := authenticateUser(ctx); err != nil {
+	if err := authenticateUserCanRead(ctx, sessions.NewPermission(sessions.ResourceJobs, sessions.ActionRead, "")); err != nil {
 		return nil, err
 	}
 
@@ -242,7 +259,7 @@ func (r *Resolver) Jobs(ctx context.Context, args struct {
 }
 
 func (r *Resolver) OCRKeyBundles(ctx context.Context) (*OCRKeyBundlesPayloadResolver, error) {
-	if err := authenticateUser(ctx); err != nil {
+	if err := authenticateUserCanRead(ctx, sessions.NewPermission(sessions.ResourceKeys, sessions.ActionRead, "ocr")); err != nil {
 		return nil, err
 	}
 
@@ -255,7 +272,7 @@ func (r *Resolver) OCRKeyBundles(ctx context.Context) (*OCRKeyBundlesPayloadReso
 }
 
 func (r *Resolver) CSAKeys(ctx context.Context) (*CSAKeysPayloadResolver, error) {
-	if err := authenticateUser(ctx); err != nil {
+	if err := authenticateUserCanRead(ctx, sessions.NewPermission(sessions.ResourceKeys, sessions.ActionRead, "csa")); err != nil {
 		return nil, err
 	}
 
@@ -278,7 +295,7 @@ func (r *Resolver) Features(ctx context.Context) (*FeaturesPayloadResolver, erro
 
 // Node retrieves a node by ID (Name)
 func (r *Resolver) Node(ctx context.Context, args struct{ ID graphql.ID }) (*NodePayloadResolver, error) {
-	if err := authenticateUser(ctx); err != nil {
+	if err := authenticateUserCanRead(ctx, sessions.NewPermission(sessions.ResourceChains, sessions.ActionRead, "")); err != nil {
 		return nil, err
 	}
 	r.App.GetLogger().Debug("resolver Node args %v", args)
@@ -306,7 +323,7 @@ func (r *Resolver) Node(ctx context.Context, args struct{ ID graphql.ID }) (*Nod
 }
 
 func (r *Resolver) P2PKeys(ctx context.Context) (*P2PKeysPayloadResolver, error) {
-	if err := authenticateUser(ctx); err != nil {
+	if err := authenticateUserCanRead(ctx, sessions.NewPermission(sessions.ResourceKeys, sessions.ActionRead, "p2p")); err != nil {
 		return nil, err
 	}
 
@@ -320,7 +337,7 @@ func (r *Resolver) P2PKeys(ctx context.Context) (*P2PKeysPayloadResolver, error)
 
 // VRFKeys fetches all VRF keys.
 func (r *Resolver) VRFKeys(ctx context.Context) (*VRFKeysPayloadResolver, error) {
-	if err := authenticateUser(ctx); err != nil {
+	if err := authenticateUserCanRead(ctx, sessions.NewPermission(sessions.ResourceKeys, sessions.ActionRead, "vrf")); err != nil {
 		return nil, err
 	}
 
@@ -336,7 +353,7 @@ func (r *Resolver) VRFKeys(ctx context.Context) (*VRFKeysPayloadResolver, error)
 func (r *Resolver) VRFKey(ctx context.Context, args struct {
 	ID graphql.ID
 }) (*VRFKeyPayloadResolver, error) {
-	if err := authenticateUser(ctx); err != nil {
+	if err := authenticateUserCanRead(ctx, sessions.NewPermission(sessions.ResourceKeys, sessions.ActionRead, "vrf")); err != nil {
 		return nil, err
 	}
 
@@ -355,7 +372,7 @@ func (r *Resolver) VRFKey(ctx context.Context, args struct {
 func (r *Resolver) JobProposal(ctx context.Context, args struct {
 	ID graphql.ID
 }) (*JobProposalPayloadResolver, error) {
-	if err := authenticateUser(ctx); err != nil {
+	if err := authenticateUserCanRead(ctx, sessions.NewPermission(sessions.ResourceFeedsManagers, sessions.ActionRead, "")); err != nil {
 		return nil, err
 	}
 
@@ -381,7 +398,7 @@ func (r *Resolver) Nodes(ctx context.Context, args struct {
 	Offset *int32
 	Limit  *int32
 }) (*NodesPayloadResolver, error) {
-	if err := authenticateUser(ctx); err != nil {
+	if err := authenticateUserCanRead(ctx, sessions.NewPermission(sessions.ResourceChains, sessions.ActionRead, "")); err != nil {
 		return nil, err
 	}
 
@@ -406,7 +423,7 @@ func (r *Resolver) JobRuns(ctx context.Context, args struct {
 	Offset *int32
 	Limit  *int32
 }) (*JobRunsPayloadResolver, error) {
-	if err := authenticateUser(ctx); err != nil {
+	if err := authenticateUserCanRead(ctx, sessions.NewPermission(sessions.ResourceJobs, sessions.ActionRead, "")); err != nil {
 		return nil, err
 	}
 
@@ -424,7 +441,7 @@ func (r *Resolver) JobRuns(ctx context.Context, args struct {
 func (r *Resolver) JobRun(ctx context.Context, args struct {
 	ID graphql.ID
 }) (*JobRunPayloadResolver, error) {
-	if err := authenticateUser(ctx); err != nil {
+	if err := authenticateUserCanRead(ctx, sessions.NewPermission(sessions.ResourceJobs, sessions.ActionRead, "")); err != nil {
 		return nil, err
 	}
 
@@ -446,7 +463,7 @@ func (r *Resolver) JobRun(ctx context.Context, args struct {
 }
 
 func (r *Resolver) ETHKeys(ctx context.Context) (*ETHKeysPayloadResolver, error) {
-	if err := authenticateUser(ctx); err != nil {
+	if err := authenticateUserCanRead(ctx, sessions.NewPermission(sessions.ResourceKeys, sessions.ActionRead, "eth")); err != nil {
 		return nil, err
 	}
 
@@ -499,7 +516,7 @@ func (r *Resolver) ETHKeys(ctx context.Context) (*ETHKeysPayloadResolver, error)
 
 // ConfigV2 retrieves the Chainlink node's configuration (V2 mode)
 func (r *Resolver) ConfigV2(ctx context.Context) (*ConfigV2PayloadResolver, error) {
-	if err := authenticateUser(ctx); err != nil {
+	if err := authenticateUserCanRead(ctx, sessions.NewPermission(sessions.ResourceConfig, sessions.ActionRead, "")); err != nil {
 		return nil, err
 	}
 
@@ -510,7 +527,7 @@ func (r *Resolver) ConfigV2(ctx context.Context) (*ConfigV2PayloadResolver, erro
 func (r *Resolver) EthTransaction(ctx context.Context, args struct {
 	Hash graphql.ID
 }) (*EthTransactionPayloadResolver, error) {
-	if err := authenticateUser(ctx); err != nil {
+	if err := authenticateUserCanRead(ctx, sessions.NewPermission(sessions.ResourceTransactions, sessions.ActionRead, "evm")); err != nil {
 		return nil, err
 	}
 
@@ -531,7 +548,7 @@ func (r *Resolver) EthTransactions(ctx context.Context, args struct {
 	Offset *int32
 	Limit  *int32
 }) (*EthTransactionsPayloadResolver, error) {
-	if err := authenticateUser(ctx); err != nil {
+	if err := authenticateUserCanRead(ctx, sessions.NewPermission(sessions.ResourceTransactions, sessions.ActionRead, "evm")); err != nil {
 		return nil, err
 	}
 
@@ -550,7 +567,7 @@ func (r *Resolver) EthTransactionsAttempts(ctx context.Context, args struct {
 	Offset *int32
 	Limit  *int32
 }) (*EthTransactionsAttemptsPayloadResolver, error) {
-	if err := authenticateUser(ctx); err != nil {
+	if err := authenticateUserCanRead(ctx, sessions.NewPermission(sessions.ResourceTransactions, sessions.ActionRead, "evm")); err != nil {
 		return nil, err
 	}
 
@@ -565,8 +582,63 @@ func (r *Resolver) EthTransactionsAttempts(ctx context.Context, args struct {
 	return NewEthTransactionsAttemptsPayload(attempts, int32(count)), nil
 }
 
+// EVMLogs retrieves the logs saved by the log poller of a chain matching a query.
+func (r *Resolver) EVMLogs(ctx context.Context, args struct {
+	ChainID graphql.ID
+	Input   evmLogsQueryInput
+}) (*EVMLogsPayloadResolver, error) {
+	if err := authenticateUserCanRun(ctx, sessions.NewPermission(sessions.ResourceChains, sessions.ActionRead, relay.NetworkEVM+"/"+string(args.ChainID))); err != nil {
+		return nil, err
+	}
+
+	chain, err := r.App.GetRelayers().LegacyEVMChains().Get(string(args.ChainID))
+	if err != nil {
+		if errors.Is(err, chains.ErrNotFound) {
+			return NewEVMLogsPayload(nil, err, nil), nil
+		}
+		return nil, err
+	}
+
+	q, err := args.Input.logsQuery()
+	if err != nil {
+		return NewEVMLogsPayload(nil, nil, map[string]string{"input/expressions": err.Error()}), nil
+	}
+	expressions, limitAndSort, err := q.Parse()
+	if err != nil {
+		return NewEVMLogsPayload(nil, nil, map[string]string{"input": err.Error()}), nil
+	}
+	var contract *abi.ABI
+	if args.Input.ABI != nil {
+		parsed, err2 := abi.JSON(strings.NewReader(*args.Input.ABI))
+		if err2 != nil {
+			return NewEVMLogsPayload(nil, nil, map[string]string{"input/abi": err2.Error()}), nil
+		}
+		contract = &parsed
+	}
+
+	logs, err := chain.LogPoller().FilteredLogs(ctx, expressions, limitAndSort, "LogsQuery")
+	if err != nil {
+		return nil, err
+	}
+	results := []*EVMLogResolver{}
+	for _, log := range logs {
+		var event string
+		var decoded map[string]any
+		if contract != nil {
+			if event, decoded, err = logpoller.DecodeLog(contract, log); err != nil {
+				return NewEVMLogsPayload(nil, nil, map[string]string{
+					"input/abi": fmt.Sprintf("failed to decode log %s: %v", logpoller.FormatContractReaderCursor(log), err),
+				}), nil
+			}
+		}
+		results = append(results, NewEVMLog(log, event, decoded))
+	}
+
+	return NewEVMLogsPayload(results, nil, nil), nil
+}
+
 func (r *Resolver) GlobalLogLevel(ctx context.Context) (*GlobalLogLevelPayloadResolver, error) {
-	if err := authenticateUser(ctx); err != nil {
+	if err := authenticateUserCanRead(ctx, sessions.NewPermission(sessions.ResourceConfig, sessions.ActionRead, "")); err != nil {
 		return nil, err
 	}
 
@@ -576,7 +648,7 @@ func (r *Resolver) GlobalLogLevel(ctx context.Context) (*GlobalLogLevelPayloadRe
 }
 
 func (r *Resolver) SolanaKeys(ctx context.Context) (*SolanaKeysPayloadResolver, error) {
-	if err := authenticateUser(ctx); err != nil {
+	if err := authenticateUserCanRead(ctx, sessions.NewPermission(sessions.ResourceKeys, sessions.ActionRead, "solana")); err != nil {
 		return nil, err
 	}
 
@@ -589,7 +661,7 @@ func (r *Resolver) SolanaKeys(ctx context.Context) (*SolanaKeysPayloadResolver,
 }
 
 func (r *Resolver) AptosKeys(ctx context.Context) (*AptosKeysPayloadResolver, error) {
-	if err := authenticateUser(ctx); err != nil {
+	if err := authenticateUserCanRead(ctx, sessions.NewPermission(sessions.ResourceKeys, sessions.ActionRead, "aptos")); err != nil {
 		return nil, err
 	}
 
@@ -602,7 +674,7 @@ func (r *Resolver) AptosKeys(ctx context.Context) (*AptosKeysPayloadResolver, er
 }
 
 func (r *Resolver) CosmosKeys(ctx context.Context) (*CosmosKeysPayloadResolver, error) {
-	if err := authenticateUser(ctx); err != nil {
+	if err := authenticateUserCanRead(ctx, sessions.NewPermission(sessions.ResourceKeys, sessions.ActionRead, "cosmos")); err != nil {
 		return nil, err
 	}
 	keys, err := r.App.GetKeyStore().Cosmos().GetAll()
@@ -614,7 +686,7 @@ func (r *Resolver) CosmosKeys(ctx context.Context) (*CosmosKeysPayloadResolver,
 }
 
 func (r *Resolver) StarkNetKeys(ctx context.Context) (*StarkNetKeysPayloadResolver, error) {
-	if err := authenticateUser(ctx); err != nil {
+	if err := authenticateUserCanRead(ctx, sessions.NewPermission(sessions.ResourceKeys, sessions.ActionRead, "starknet")); err != nil {
 		return nil, err
 	}
 	keys, err := r.App.GetKeyStore().StarkNet().GetAll()
@@ -626,7 +698,7 @@ func (r *Resolver) StarkNetKeys(ctx context.Context) (*StarkNetKeysPayloadResolv
 }
 
 func (r *Resolver) TronKeys(ctx context.Context) (*TronKeysPayloadResolver, error) {
-	if err := authenticateUser(ctx); err != nil {
+	if err := authenticateUserCanRead(ctx, sessions.NewPermission(sessions.ResourceKeys, sessions.ActionRead, "tron")); err != nil {
 		return nil, err
 	}
 
@@ -639,7 +711,7 @@ func (r *Resolver) TronKeys(ctx context.Context) (*TronKeysPayloadResolver, erro
 }
 
 func (r *Resolver) SQLLogging(ctx context.Context) (*GetSQLLoggingPayloadResolver, error) {
-	if err := authenticateUser(ctx); err != nil {
+	if err := authenticateUserCanRead(ctx, sessions.NewPermission(sessions.ResourceConfig, sessions.ActionRead, "")); err != nil {
 		return nil, err
 	}
 
@@ -650,7 +722,7 @@ func (r *Resolver) SQLLogging(ctx context.Context) (*GetSQLLoggingPayloadResolve
 
 // OCR2KeyBundles resolves the list of OCR2 key bundles
 func (r *Resolver) OCR2KeyBundles(ctx context.Context) (*OCR2KeyBundlesPayloadResolver, error) {
-	if err := authenticateUser(ctx); err != nil {
+	if err := authenticateUserCanRead(ctx, sessions.NewPermission(sessions.ResourceKeys, sessions.ActionRead, "ocr2")); err != nil {
 		return nil, err
 	}
 
@@ -174,7 +174,10 @@ func secureMiddleware(tlsRedirect bool, tlsHost string, devWebServer bool) gin.H
 }
 
 func debugRoutes(app chainlink.Application, r *gin.RouterGroup) {
-	group := r.Group("/debug", auth.Authenticate(app.AuthenticationProvider(), auth.AuthenticateBySession))
+	group := r.Group("/debug",
+		auth.Authenticate(app.AuthenticationProvider(), auth.AuthenticateBySession),
+		auth.AuthorizeCustomRole(CustomRolePermissions{app}.Resolve),
+	)
 	group.GET("/vars", expvar.Handler())
 }
 
@@ -211,6 +214,9 @@ func sessionRoutes(app chainlink.Application, r *gin.RouterGroup) {
 	))
 	sc := NewSessionsController(app)
 	unauth.POST("/sessions", sc.Create)
+	oc := OIDCController{app}
+	unauth.GET("/oidc/login", oc.Login)
+	unauth.GET("/oidc/callback", oc.Callback)
 	auth := r.Group("/", auth.Authenticate(app.AuthenticationProvider(), auth.AuthenticateBySession))
 	auth.DELETE("/sessions", sc.Destroy)
 }
@@ -237,16 +243,33 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 	psec := PipelineJobSpecErrorsController{app}
 	unauthedv2.PATCH("/resume/:runID", prc.Resume)
 
-	authv2 := r.Group("/v2", auth.Authenticate(app.AuthenticationProvider(),
-		auth.AuthenticateByToken,
-		auth.AuthenticateBySession,
-	))
+	customRolePermissions := CustomRolePermissions{app}
+	authenticateByAPIToken := auth.AuthenticateByAPIToken(app.APITokensORM(), app.CustomRolesORM(), app.GetAuditLogger())
+	authv2 := r.Group("/v2",
+		auth.Authenticate(app.AuthenticationProvider(),
+			auth.AuthenticateByToken,
+			authenticateByAPIToken,
+			auth.AuthenticateByBearerToken,
+			auth.AuthenticateBySession,
+		),
+		auth.AuthorizeCustomRole(customRolePermissions.Resolve),
+	)
 	{
 		uc := UserController{app}
 		authv2.GET("/users", auth.RequiresAdminRole(uc.Index))
 		authv2.POST("/users", auth.RequiresAdminRole(uc.Create))
 		authv2.PATCH("/users", auth.RequiresAdminRole(uc.UpdateRole))
 		authv2.DELETE("/users/:email", auth.RequiresAdminRole(uc.Delete))
+		crc := CustomRolesController{app}
+		authv2.GET("/roles", auth.RequiresAdminRole(crc.Index))
+		authv2.GET("/roles/:name", auth.RequiresAdminRole(crc.Show))
+		authv2.POST("/roles", auth.RequiresAdminRole(crc.Create))
+		authv2.PUT("/roles/:name", auth.RequiresAdminRole(crc.Update))
+		authv2.DELETE("/roles/:name", auth.RequiresAdminRole(crc.Delete))
+		atc := APITokensController{app}
+		authv2.GET("/tokens", atc.Index)
+		authv2.POST("/tokens", atc.Create)
+		authv2.DELETE("/tokens/:id", atc.Delete)
 		authv2.PATCH("/user/password", uc.UpdatePassword)
 		authv2.POST("/user/token", uc.NewAPIToken)
 		authv2.POST("/user/token/delete", uc.DeleteAPIToken)
@@ -286,6 +309,7 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 		txs := TransactionsController{app}
 		authv2.GET("/transactions/evm", paginatedRequest(txs.Index))
 		authv2.GET("/transactions/evm/:TxHash", txs.Show)
+		authv2.GET("/transactions/evm/bundles/:BundleID", txs.ShowBundle)
 		authv2.GET("/transactions", paginatedRequest(txs.Index))
 		authv2.GET("/transactions/:TxHash", txs.Show)
 
@@ -293,6 +317,8 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 		authv2.POST("/replay_from_block/:number", auth.RequiresRunRole(rc.ReplayFromBlock))
 		lcaC := LCAController{app}
 		authv2.GET("/find_lca", auth.RequiresRunRole(lcaC.FindLCA))
+		elc := EVMLogsController{app}
+		authv2.POST("/logs/query", auth.RequiresRunRole(elc.Query))
 
 		csakc := CSAKeysController{app}
 		authv2.GET("/keys/csa", csakc.Index)
@@ -311,6 +337,8 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 
 		ethKeysGroup := authv2.Group("", auth.Authenticate(app.AuthenticationProvider(),
 			auth.AuthenticateByToken,
+			authenticateByAPIToken,
+			auth.AuthenticateByBearerToken,
 			auth.AuthenticateBySession,
 		))
 
@@ -319,6 +347,7 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 		ethKeysGroup.POST("/keys/evm", auth.RequiresEditRole(ekc.Create))
 		ethKeysGroup.DELETE("/keys/evm/:address", auth.RequiresAdminRole(ekc.Delete))
 		ethKeysGroup.POST("/keys/evm/import", auth.RequiresAdminRole(ekc.Import))
+		ethKeysGroup.POST("/keys/evm/remote", auth.RequiresAdminRole(ekc.RegisterRemote))
 		authv2.POST("/keys/evm/export/:address", auth.RequiresAdminRole(ekc.Export))
 		ethKeysGroup.POST("/keys/evm/chain", auth.RequiresAdminRole(ekc.Chain))
 
@@ -336,6 +365,12 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 		authv2.POST("/keys/ocr2/import", auth.RequiresAdminRole(ocr2kc.Import))
 		authv2.POST("/keys/ocr2/export/:ID", auth.RequiresAdminRole(ocr2kc.Export))
 
+		krc := KeyRotationsController{app}
+		authv2.GET("/keys/rotations", krc.Index)
+		authv2.GET("/keys/rotations/:rotationID", krc.Show)
+		authv2.POST("/keys/rotations", auth.RequiresAdminRole(krc.Create))
+		authv2.POST("/keys/rotations/:rotationID/retire", auth.RequiresAdminRole(krc.Retire))
+
 		p2pkc := P2PKeysController{app}
 		authv2.GET("/keys/p2p", p2pkc.Index)
 		authv2.POST("/keys/p2p", auth.RequiresEditRole(p2pkc.Create))
@@ -378,11 +413,18 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 		authv2.GET("/pipeline/runs", paginatedRequest(prc.Index))
 		authv2.GET("/jobs/:ID/runs", paginatedRequest(prc.Index))
 		authv2.GET("/jobs/:ID/runs/:runID", prc.Show)
+		authv2.POST("/pipeline/runs/:runID/replay", auth.RequiresRunRole(prc.Replay))
 
 		// FeaturesController
 		fc := FeaturesController{app}
 		authv2.GET("/features", fc.Index)
 
+		// S4QuotasController
+		sqc := S4QuotasController{app}
+		authv2.GET("/s4/quotas", auth.RequiresAdminRole(sqc.Index))
+		authv2.DELETE("/s4/quotas", auth.RequiresAdminRole(sqc.Reset))
+		authv2.DELETE("/s4/quotas/:address", auth.RequiresAdminRole(sqc.Reset))
+
 		// PipelineJobSpecErrorsController
 		authv2.DELETE("/pipeline/job_spec_errors/:ID", auth.RequiresEditRole(psec.Destroy))
 
@@ -422,11 +464,16 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 	}
 
 	ping := PingController{app}
-	userOrEI := r.Group("/v2", auth.Authenticate(app.AuthenticationProvider(),
-		auth.AuthenticateExternalInitiator,
-		auth.AuthenticateByToken,
-		auth.AuthenticateBySession,
-	))
+	userOrEI := r.Group("/v2",
+		auth.Authenticate(app.AuthenticationProvider(),
+			auth.AuthenticateExternalInitiator,
+			auth.AuthenticateByToken,
+			authenticateByAPIToken,
+			auth.AuthenticateByBearerToken,
+			auth.AuthenticateBySession,
+		),
+		auth.AuthorizeCustomRole(customRolePermissions.Resolve),
+	)
 	userOrEI.GET("/ping", ping.Show)
 	userOrEI.POST("/jobs/:ID/runs", auth.RequiresRunRole(prc.Create))
 }
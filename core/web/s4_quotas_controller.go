@@ -0,0 +1,74 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/v2/core/services/s4"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+	"github.com/smartcontractkit/chainlink/v2/evm/utils/big"
+)
+
+// defaultS4QuotaNamespace is the namespace of the S4 storage used by Functions.
+const defaultS4QuotaNamespace = "functions"
+
+// S4QuotasController inspects and resets the per-address quotas of S4 storages.
+type S4QuotasController struct {
+	App chainlink.Application
+}
+
+func (sqc *S4QuotasController) orm(c *gin.Context) (s4.QuotaORM, string) {
+	namespace := c.DefaultQuery("namespace", defaultS4QuotaNamespace)
+	return s4.NewPostgresQuotaORM(sqc.App.GetDB(), s4.SharedTableName, namespace), namespace
+}
+
+// Index lists the quotas of all addresses in a namespace.
+// Example:
+// "GET <application>/s4/quotas?namespace=functions"
+func (sqc *S4QuotasController) Index(c *gin.Context) {
+	orm, namespace := sqc.orm(c)
+	quotas, err := orm.ListQuotas(c.Request.Context())
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, presenters.NewS4QuotaResources(namespace, quotas), "s4Quotas")
+}
+
+// Reset resets the quota of an address, or of all addresses when no address is given.
+// Example:
+// "DELETE <application>/s4/quotas/:address?namespace=functions"
+// "DELETE <application>/s4/quotas?namespace=functions"
+func (sqc *S4QuotasController) Reset(c *gin.Context) {
+	orm, namespace := sqc.orm(c)
+	var address *big.Big
+	if param := c.Param("address"); param != "" {
+		if !common.IsHexAddress(param) {
+			jsonAPIError(c, http.StatusUnprocessableEntity, errors.Errorf("invalid address: %s", param))
+			return
+		}
+		address = big.New(common.HexToAddress(param).Big())
+	}
+
+	count, err := orm.ResetQuota(c.Request.Context(), address)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if address != nil && count == 0 {
+		jsonAPIError(c, http.StatusNotFound, errors.New("quota not found"))
+		return
+	}
+
+	sqc.App.GetAuditLogger().Audit(audit.S4QuotaReset, map[string]interface{}{
+		"namespace": namespace,
+		"address":   c.Param("address"),
+		"count":     count,
+	})
+	jsonAPIResponseWithStatus(c, nil, "s4Quotas", http.StatusNoContent)
+}
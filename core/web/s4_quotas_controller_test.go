@@ -0,0 +1,82 @@
+package web_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/services/s4"
+	"github.com/smartcontractkit/chainlink/v2/core/web"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+	"github.com/smartcontractkit/chainlink/v2/evm/utils/big"
+)
+
+func TestS4QuotasController_IndexAndReset(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	app := cltest.NewApplicationEVMDisabled(t)
+	require.NoError(t, app.Start(ctx))
+	client := app.NewHTTPClient(nil)
+
+	functions := s4.NewPostgresQuotaORM(app.GetDB(), s4.SharedTableName, "functions")
+	other := s4.NewPostgresQuotaORM(app.GetDB(), s4.SharedTableName, "other")
+	address1, address2 := testutils.NewAddress(), testutils.NewAddress()
+	_, err := functions.AddUsage(ctx, big.New(address1.Big()), 1000, 10, 0, 0)
+	require.NoError(t, err)
+	_, err = functions.AddUsage(ctx, big.New(address2.Big()), 1000, 5, 0, 0)
+	require.NoError(t, err)
+	_, err = other.AddUsage(ctx, big.New(address1.Big()), 1000, 1, 0, 0)
+	require.NoError(t, err)
+
+	index := func(path string) []presenters.S4QuotaResource {
+		resp, cleanup := client.Get(path)
+		t.Cleanup(cleanup)
+		cltest.AssertServerResponse(t, resp, http.StatusOK)
+		var resources []presenters.S4QuotaResource
+		require.NoError(t, web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, resp), &resources))
+		return resources
+	}
+
+	resources := index("/v2/s4/quotas")
+	require.Len(t, resources, 2)
+	for _, r := range resources {
+		assert.Equal(t, "functions", r.Namespace)
+	}
+
+	resources = index("/v2/s4/quotas?namespace=other")
+	require.Len(t, resources, 1)
+	assert.Equal(t, address1.Hex(), resources[0].ID)
+	assert.Equal(t, uint64(1), resources[0].Bytes)
+	assert.Equal(t, uint64(1), resources[0].Writes)
+
+	t.Run("invalid address", func(t *testing.T) {
+		resp, cleanup := client.Delete("/v2/s4/quotas/invalid")
+		t.Cleanup(cleanup)
+		cltest.AssertServerResponse(t, resp, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("unknown address", func(t *testing.T) {
+		resp, cleanup := client.Delete("/v2/s4/quotas/" + testutils.NewAddress().Hex())
+		t.Cleanup(cleanup)
+		cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+	})
+
+	resp, cleanup := client.Delete("/v2/s4/quotas/" + address1.Hex())
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusNoContent)
+	resources = index("/v2/s4/quotas")
+	require.Len(t, resources, 1)
+	assert.Equal(t, address2.Hex(), resources[0].ID)
+
+	// resetting all quotas of a namespace leaves other namespaces untouched
+	resp, cleanup = client.Delete("/v2/s4/quotas")
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusNoContent)
+	assert.Empty(t, index("/v2/s4/quotas"))
+	assert.Len(t, index("/v2/s4/quotas?namespace=other"), 1)
+}
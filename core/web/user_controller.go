@@ -1,6 +1,7 @@
 package web
 
 import (
+	"context"
 	"net/http"
 	"strings"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgconn"
 	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
 
 	"github.com/smartcontractkit/chainlink/v2/core/auth"
 	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
@@ -63,7 +65,7 @@ func (u *UserController) Create(c *gin.Context) {
 		return
 	}
 
-	userRole, err := clsession.GetUserRole(request.Role)
+	userRole, customRole, err := u.findRole(ctx, request.Role)
 	if err != nil {
 		jsonAPIError(c, http.StatusBadRequest, err)
 		return
@@ -84,6 +86,7 @@ func (u *UserController) Create(c *gin.Context) {
 		jsonAPIError(c, http.StatusBadRequest, errors.Errorf("error creating API user: %s", err))
 		return
 	}
+	user.CustomRole = customRole
 	if err = u.App.AuthenticationProvider().CreateUser(ctx, &user); err != nil {
 		// If this is a duplicate key error (code 23505), return a nicer error message
 		var pgErr *pgconn.PgError
@@ -136,12 +139,11 @@ func (u *UserController) UpdateRole(c *gin.Context) {
 		return
 	}
 	if request.NewRole == "" {
-		jsonAPIError(c, http.StatusBadRequest, errors.New("new-role flag is empty, must specify a new role, possible options are 'admin', 'edit', 'run', 'view'"))
+		jsonAPIError(c, http.StatusBadRequest, errors.New("new-role flag is empty, must specify a new role, possible options are 'admin', 'edit', 'run', 'view', or a custom role"))
 		return
 	}
-	_, err := clsession.GetUserRole(request.NewRole)
-	if err != nil {
-		jsonAPIError(c, http.StatusBadRequest, errors.New("new role does not exist, possible options are 'admin', 'edit', 'run', 'view'"))
+	if _, _, err := u.findRole(ctx, request.NewRole); err != nil {
+		jsonAPIError(c, http.StatusBadRequest, errors.New("new role does not exist, possible options are 'admin', 'edit', 'run', 'view', or a custom role"))
 		return
 	}
 
@@ -158,6 +160,22 @@ func (u *UserController) UpdateRole(c *gin.Context) {
 	jsonAPIResponse(c, presenters.NewUserResource(user), "user")
 }
 
+// findRole returns the built-in role named name, or the custom role named name along with the view role, which users
+// of a custom role are assigned.
+func (u *UserController) findRole(ctx context.Context, name string) (clsession.UserRole, null.String, error) {
+	userRole, err := clsession.GetUserRole(name)
+	if err == nil {
+		return userRole, null.String{}, nil
+	}
+	if _, cerr := u.App.CustomRolesORM().FindCustomRole(ctx, name); cerr != nil {
+		if errors.Is(cerr, clsession.ErrCustomRoleNotFound) {
+			return "", null.String{}, err
+		}
+		return "", null.String{}, cerr
+	}
+	return clsession.UserRoleView, null.StringFrom(name), nil
+}
+
 // Delete deletes an API user and any sessions by email
 func (u *UserController) Delete(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -0,0 +1,36 @@
+package abi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// ParseErrors converts custom error signatures, such as `InsufficientBalance(address,uint256)`, into an ABI with these
+// errors.
+func ParseErrors(signatures ...string) (abi.ABI, error) {
+	type abiError struct {
+		Type   string                   `json:"type"`
+		Name   string                   `json:"name"`
+		Inputs []abi.ArgumentMarshaling `json:"inputs"`
+	}
+	errs := make([]abiError, len(signatures))
+	for i, signature := range signatures {
+		selector, err := ParseSelector(signature)
+		if err != nil {
+			return abi.ABI{}, err
+		}
+		errs[i] = abiError{Type: "error", Name: selector.Name, Inputs: selector.Inputs}
+	}
+	b, err := json.Marshal(errs)
+	if err != nil {
+		return abi.ABI{}, err
+	}
+	parsed, err := abi.JSON(bytes.NewReader(b))
+	if err != nil {
+		return abi.ABI{}, fmt.Errorf("failed to parse errors %v: %w", signatures, err)
+	}
+	return parsed, nil
+}
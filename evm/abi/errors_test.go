@@ -0,0 +1,27 @@
+package abi
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseErrors(t *testing.T) {
+	parsed, err := ParseErrors("InsufficientBalance(address,uint256)", "StalePrice()")
+	require.NoError(t, err)
+	require.Len(t, parsed.Errors, 2)
+
+	insufficientBalance := parsed.Errors["InsufficientBalance"]
+	assert.Equal(t, crypto.Keccak256Hash([]byte("InsufficientBalance(address,uint256)")), insufficientBalance.ID)
+	require.Len(t, insufficientBalance.Inputs, 2)
+	assert.Equal(t, "address", insufficientBalance.Inputs[0].Type.String())
+	assert.Equal(t, "uint256", insufficientBalance.Inputs[1].Type.String())
+	assert.Empty(t, parsed.Errors["StalePrice"].Inputs)
+
+	_, err = ParseErrors("StalePrice(price)")
+	require.Error(t, err)
+	_, err = ParseErrors("StalePrice(")
+	require.Error(t, err)
+}
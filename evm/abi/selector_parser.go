@@ -94,7 +94,7 @@ func parseCompositeType(unescapedSelector string) ([]abi.ArgumentMarshaling, str
 	i := 0
 	for len(rest) > 0 && rest[0] != ')' {
 		// skip any leading whitespace
-		for rest[0] == ' ' {
+		for len(rest) > 0 && rest[0] == ' ' {
 			rest = rest[1:]
 		}
 
@@ -104,9 +104,12 @@ func parseCompositeType(unescapedSelector string) ([]abi.ArgumentMarshaling, str
 		}
 
 		// skip whitespace between name and identifier
-		for rest[0] == ' ' {
+		for len(rest) > 0 && rest[0] == ' ' {
 			rest = rest[1:]
 		}
+		if len(rest) == 0 {
+			return nil, "", fmt.Errorf("expected ')', got '%s'", rest)
+		}
 
 		name := fmt.Sprintf("name%d", i)
 		// if we're at a delimiter the parameter is unnamed
@@ -127,7 +130,7 @@ func parseCompositeType(unescapedSelector string) ([]abi.ArgumentMarshaling, str
 		i++
 
 		// skip trailing whitespace, consume comma
-		for rest[0] == ' ' || rest[0] == ',' {
+		for len(rest) > 0 && (rest[0] == ' ' || rest[0] == ',') {
 			rest = rest[1:]
 		}
 	}
@@ -146,6 +146,11 @@ func TestParseSelectorErrors(t *testing.T) {
 			input:         "noargs(",
 			expectedError: "failed to parse selector args 'noargs(': expected ')', got ''",
 		},
+		{
+			description:   "missing closing parenthesis after argument",
+			input:         "onearg(uint256",
+			expectedError: "failed to parse selector args 'onearg(uint256': expected ')', got ''",
+		},
 		{
 			description:   "missing opening parenthesis",
 			input:         "noargs)",
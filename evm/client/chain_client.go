@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"math/big"
+	"slices"
 	"sync"
 	"time"
 
@@ -18,6 +19,7 @@ import (
 
 	evmconfig "github.com/smartcontractkit/chainlink/v2/evm/config"
 	"github.com/smartcontractkit/chainlink/v2/evm/config/chaintype"
+	"github.com/smartcontractkit/chainlink/v2/evm/config/toml"
 	evmtypes "github.com/smartcontractkit/chainlink/v2/evm/types"
 )
 
@@ -35,6 +37,9 @@ type Client interface {
 	// NodeStates returns a map of node Name->node state
 	// It might be nil or empty, e.g. for mock clients etc
 	NodeStates() map[string]string
+	// NodeArchiveStatuses returns a map of node Name->ArchiveStatus of the primary nodes, as detected when dialed
+	// It might be nil or empty, e.g. for mock clients etc
+	NodeArchiveStatuses() map[string]ArchiveStatus
 
 	TokenBalance(ctx context.Context, address common.Address, contractAddress common.Address) (*big.Int, error)
 	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
@@ -106,6 +111,10 @@ type chainClient struct {
 	logger       logger.SugaredLogger
 	chainType    chaintype.ChainType
 	clientErrors evmconfig.ClientErrors
+	// routes are the pools of the nodes with roles other than read, see selectRPC
+	routes map[toml.NodeRole]route
+	// nodes are the primary nodes of the read pool and of the routes, once each, see NodeArchiveStatuses
+	nodes []multinode.Node[*big.Int, *RPCClient]
 }
 
 func NewChainClient(
@@ -118,6 +127,25 @@ func NewChainClient(
 	clientErrors evmconfig.ClientErrors,
 	deathDeclarationDelay time.Duration,
 	chainType chaintype.ChainType,
+) Client {
+	return NewChainClientWithRoutes(lggr, selectionMode, leaseDuration, nodes, sendonlys, nil, chainID, clientErrors,
+		deathDeclarationDelay, chainType)
+}
+
+// NewChainClientWithRoutes returns a Client routing the RPC methods of each role to the given nodes, which fall back to
+// the read pool of nodes and sendonlys when none of them is alive. The nodes of each role are a separate pool, with
+// its own health tracking and node selection.
+func NewChainClientWithRoutes(
+	lggr logger.Logger,
+	selectionMode string,
+	leaseDuration time.Duration,
+	nodes []multinode.Node[*big.Int, *RPCClient],
+	sendonlys []multinode.SendOnlyNode[*big.Int, *RPCClient],
+	roles map[toml.NodeRole]RoleNodes,
+	chainID *big.Int,
+	clientErrors evmconfig.ClientErrors,
+	deathDeclarationDelay time.Duration,
+	chainType chaintype.ChainType,
 ) Client {
 	chainFamily := "EVM"
 	multiNode := multinode.NewMultiNode[*big.Int, *RPCClient](
@@ -140,21 +168,30 @@ func NewChainClient(
 		0, // use the default value provided by the implementation
 	)
 
+	allNodes := slices.Clone(nodes)
+	for _, r := range roles {
+		for _, n := range r.Nodes {
+			if _, shared := n.(sharedNode); !shared {
+				allNodes = append(allNodes, n)
+			}
+		}
+	}
+
 	return &chainClient{
 		multiNode:    multiNode,
 		txSender:     txSender,
 		logger:       logger.Sugared(lggr),
 		chainType:    chainType,
 		clientErrors: clientErrors,
+		routes:       newRoutes(lggr, selectionMode, leaseDuration, roles, chainID, chainFamily, deathDeclarationDelay),
+		nodes:        allNodes,
 	}
 }
 
 func (c *chainClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
-	r, err := c.multiNode.SelectRPC()
-	if err != nil {
-		return nil, err
-	}
-	return r.BalanceAt(ctx, account, blockNumber)
+	return readState(ctx, c, blockNumber, func(r *RPCClient) (*big.Int, error) {
+		return r.BalanceAt(ctx, account, blockNumber)
+	})
 }
 
 // BatchCallContext - sends all given requests as a single batch.
@@ -165,7 +202,7 @@ func (c *chainClient) BalanceAt(ctx context.Context, account common.Address, blo
 // might not be properly handled and returned results might have weaker finality guarantees. It's highly recommended
 // to use HeadTracker to identify latest finalized block.
 func (c *chainClient) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
-	r, err := c.multiNode.SelectRPC()
+	r, err := c.selectRPC(toml.NodeRoleLogs)
 	if err != nil {
 		return err
 	}
@@ -244,11 +281,9 @@ func (c *chainClient) CallContext(ctx context.Context, result interface{}, metho
 }
 
 func (c *chainClient) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
-	r, err := c.multiNode.SelectRPC()
-	if err != nil {
-		return nil, err
-	}
-	return r.CallContract(ctx, msg, blockNumber)
+	return readState(ctx, c, blockNumber, func(r *RPCClient) ([]byte, error) {
+		return r.CallContract(ctx, msg, blockNumber)
+	})
 }
 
 func (c *chainClient) PendingCallContract(ctx context.Context, msg ethereum.CallMsg) ([]byte, error) {
@@ -260,16 +295,17 @@ func (c *chainClient) PendingCallContract(ctx context.Context, msg ethereum.Call
 }
 
 func (c *chainClient) Close() {
+	for _, r := range c.routes {
+		r.close()
+	}
 	_ = c.txSender.Close()
 	_ = c.multiNode.Close()
 }
 
 func (c *chainClient) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
-	r, err := c.multiNode.SelectRPC()
-	if err != nil {
-		return nil, err
-	}
-	return r.CodeAt(ctx, account, blockNumber)
+	return readState(ctx, c, blockNumber, func(r *RPCClient) ([]byte, error) {
+		return r.CodeAt(ctx, account, blockNumber)
+	})
 }
 
 func (c *chainClient) ConfiguredChainID() *big.Int {
@@ -281,7 +317,10 @@ func (c *chainClient) Dial(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	return c.txSender.Start(ctx)
+	if err = c.txSender.Start(ctx); err != nil {
+		return err
+	}
+	return c.startRoutes(ctx)
 }
 
 func (c *chainClient) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
@@ -292,7 +331,7 @@ func (c *chainClient) EstimateGas(ctx context.Context, call ethereum.CallMsg) (u
 	return r.EstimateGas(ctx, call)
 }
 func (c *chainClient) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
-	r, err := c.multiNode.SelectRPC()
+	r, err := c.selectRPC(toml.NodeRoleLogs)
 	if err != nil {
 		return nil, err
 	}
@@ -351,8 +390,26 @@ func (c *chainClient) LatestBlockHeight(ctx context.Context) (*big.Int, error) {
 	return r.LatestBlockHeight(ctx)
 }
 
+// NodeStates returns the states of the nodes of the read pool, and of the nodes only serving other roles.
 func (c *chainClient) NodeStates() map[string]string {
-	return c.multiNode.NodeStates()
+	states := c.multiNode.NodeStates()
+	for _, r := range c.routes {
+		for name, state := range r.multiNode.NodeStates() {
+			if _, ok := states[name]; !ok {
+				states[name] = state
+			}
+		}
+	}
+	return states
+}
+
+// NodeArchiveStatuses returns the archive statuses of the primary nodes.
+func (c *chainClient) NodeArchiveStatuses() map[string]ArchiveStatus {
+	statuses := make(map[string]ArchiveStatus, len(c.nodes))
+	for _, n := range c.nodes {
+		statuses[n.Name()] = n.RPC().ArchiveStatus()
+	}
+	return statuses
 }
 
 func (c *chainClient) PendingCodeAt(ctx context.Context, account common.Address) (b []byte, err error) {
@@ -376,13 +433,13 @@ func (c *chainClient) PendingNonceAt(ctx context.Context, account common.Address
 func (c *chainClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
 	var result *SendTxResult
 	if c.chainType == chaintype.ChainHedera {
-		activeRPC, err := c.multiNode.SelectRPC()
+		activeRPC, err := c.selectRPC(toml.NodeRoleSend)
 		if err != nil {
 			return err
 		}
 		result = activeRPC.SendTransaction(ctx, tx)
 	} else {
-		result = c.txSender.SendTransaction(ctx, tx)
+		result = c.selectTxSender().SendTransaction(ctx, tx)
 	}
 	if result == nil {
 		return errors.New("SendTransaction failed: result is nil")
@@ -397,15 +454,13 @@ func (c *chainClient) SendTransactionReturnCode(ctx context.Context, tx *types.T
 }
 
 func (c *chainClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
-	r, err := c.multiNode.SelectRPC()
-	if err != nil {
-		return 0, err
-	}
-	return r.NonceAt(ctx, account, blockNumber)
+	return readState(ctx, c, blockNumber, func(r *RPCClient) (uint64, error) {
+		return r.NonceAt(ctx, account, blockNumber)
+	})
 }
 
 func (c *chainClient) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (s ethereum.Subscription, err error) {
-	r, err := c.multiNode.SelectRPC()
+	r, err := c.selectRPC(toml.NodeRoleLogs)
 	if err != nil {
 		return s, err
 	}
@@ -0,0 +1,252 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-framework/multinode"
+
+	"github.com/smartcontractkit/chainlink/v2/evm/config/toml"
+)
+
+var promEVMPoolRPCRoleCalls = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "evm_pool_rpc_role_calls_total",
+	Help: "The total number of RPC calls of the given role, by the role of the nodes serving them. Calls served by another role fell back to the read nodes",
+}, []string{"evmChainID", "role", "servedBy"})
+
+// RoleNodes are the nodes serving the RPC methods of a toml.NodeRole other than toml.NodeRoleRead. A node shared with
+// another pool must be wrapped with sharedNode, so that it is only started and closed by the pool owning it.
+type RoleNodes struct {
+	Nodes     []multinode.Node[*big.Int, *RPCClient]
+	SendOnlys []multinode.SendOnlyNode[*big.Int, *RPCClient]
+}
+
+// sharedNode is a node of several pools, which is started and closed by the pool owning it. The owning pool also
+// provides the chain info the node compares its own with, and manages its subscriptions.
+type sharedNode struct {
+	multinode.Node[*big.Int, *RPCClient]
+}
+
+func (sharedNode) Start(context.Context) error { return nil }
+
+func (sharedNode) Close() error { return nil }
+
+func (sharedNode) SetPoolChainInfoProvider(multinode.PoolChainInfoProvider) {}
+
+func (sharedNode) UnsubscribeAllExceptAliveLoop() {}
+
+// sharedSendOnlyNode is a send only node of several pools, which is started and closed by the read pool.
+type sharedSendOnlyNode struct {
+	multinode.SendOnlyNode[*big.Int, *RPCClient]
+}
+
+func (sharedSendOnlyNode) Start(context.Context) error { return nil }
+
+func (sharedSendOnlyNode) Close() error { return nil }
+
+// route is the pool of nodes serving a role.
+type route struct {
+	multiNode *multinode.MultiNode[*big.Int, *RPCClient]
+	// txSender is only set for toml.NodeRoleSend
+	txSender *multinode.TransactionSender[*types.Transaction, *SendTxResult, *big.Int, *RPCClient]
+}
+
+func newRoutes(lggr logger.Logger, selectionMode string, leaseDuration time.Duration, roles map[toml.NodeRole]RoleNodes,
+	chainID *big.Int, chainFamily string, deathDeclarationDelay time.Duration) map[toml.NodeRole]route {
+	routes := make(map[toml.NodeRole]route, len(roles))
+	for role, nodes := range roles {
+		if role == toml.NodeRoleRead || len(nodes.Nodes) == 0 {
+			continue
+		}
+		roleLggr := logger.Named(lggr, string(role))
+		r := route{multiNode: multinode.NewMultiNode[*big.Int, *RPCClient](
+			roleLggr,
+			selectionMode,
+			leaseDuration,
+			nodes.Nodes,
+			nodes.SendOnlys,
+			chainID,
+			chainFamily,
+			deathDeclarationDelay,
+		)}
+		if role == toml.NodeRoleSend {
+			r.txSender = multinode.NewTransactionSender[*types.Transaction, *SendTxResult, *big.Int, *RPCClient](
+				roleLggr,
+				chainID,
+				chainFamily,
+				r.multiNode,
+				NewSendTxResult,
+				0, // use the default value provided by the implementation
+			)
+		}
+		routes[role] = r
+	}
+	return routes
+}
+
+func (r route) start(ctx context.Context) error {
+	if err := r.multiNode.Start(ctx); err != nil {
+		return err
+	}
+	if r.txSender != nil {
+		return r.txSender.Start(ctx)
+	}
+	return nil
+}
+
+func (r route) close() {
+	if r.txSender != nil {
+		_ = r.txSender.Close()
+	}
+	_ = r.multiNode.Close()
+}
+
+// startRoutes starts the pools of every role, and closes the ones already started on failure.
+func (c *chainClient) startRoutes(ctx context.Context) error {
+	var started []route
+	for _, r := range c.routes {
+		if err := r.start(ctx); err != nil {
+			r.close()
+			for _, s := range started {
+				s.close()
+			}
+			return err
+		}
+		started = append(started, r)
+	}
+	return nil
+}
+
+// selectRPC returns a live node of role, or a live node of the read pool if role has no configured or live nodes.
+func (c *chainClient) selectRPC(role toml.NodeRole) (*RPCClient, error) {
+	if r, ok := c.routes[role]; ok {
+		rpc, err := r.multiNode.SelectRPC()
+		if err == nil {
+			promEVMPoolRPCRoleCalls.WithLabelValues(c.chainIDLabel(), string(role), string(role)).Inc()
+			return rpc, nil
+		}
+		c.logger.Debugw("No live node with RPC role, falling back to read nodes", "role", role, "err", err)
+	}
+	promEVMPoolRPCRoleCalls.WithLabelValues(c.chainIDLabel(), string(role), string(toml.NodeRoleRead)).Inc()
+	return c.multiNode.SelectRPC()
+}
+
+// selectTxSender returns the transaction sender of the send role, or of the read pool if the send role has no
+// configured or live nodes.
+func (c *chainClient) selectTxSender() *multinode.TransactionSender[*types.Transaction, *SendTxResult, *big.Int, *RPCClient] {
+	if r, ok := c.routes[toml.NodeRoleSend]; ok {
+		if _, err := r.multiNode.SelectRPC(); err == nil {
+			promEVMPoolRPCRoleCalls.WithLabelValues(c.chainIDLabel(), string(toml.NodeRoleSend), string(toml.NodeRoleSend)).Inc()
+			return r.txSender
+		}
+		c.logger.Debugw("No live node with RPC role, falling back to read nodes", "role", toml.NodeRoleSend)
+	}
+	promEVMPoolRPCRoleCalls.WithLabelValues(c.chainIDLabel(), string(toml.NodeRoleSend), string(toml.NodeRoleRead)).Inc()
+	return c.txSender
+}
+
+// readState reads the state of blockNumber with a node of the read pool, unless the state is older than full nodes keep
+// or the node answers with pruned state. Such reads are then sent to a node of the archive role if configured, and
+// otherwise to the nodes of the read pool not known to have pruned it, retrying on the next candidate when a node
+// answers with pruned state.
+func readState[T any](ctx context.Context, c *chainClient, blockNumber *big.Int, read func(rpc *RPCClient) (T, error)) (T, error) {
+	var zero T
+	if !historical(blockNumber) {
+		rpc, err := c.multiNode.SelectRPC()
+		if err != nil {
+			return zero, err
+		}
+		return read(rpc)
+	}
+	var tried *RPCClient
+	if !c.pruned(blockNumber) {
+		rpc, err := c.multiNode.SelectRPC()
+		if err != nil {
+			return zero, err
+		}
+		result, err := read(rpc)
+		if !IsMissingTrieNode(err) {
+			return result, err
+		}
+		rpc.setArchiveStatus(ArchiveStatusFull)
+		tried = rpc
+	}
+	if _, ok := c.routes[toml.NodeRoleArchive]; ok {
+		rpc, err := c.selectRPC(toml.NodeRoleArchive)
+		if err != nil {
+			return zero, err
+		}
+		return read(rpc)
+	}
+
+	candidates, err := c.archiveCandidates(ctx)
+	if err != nil {
+		return zero, err
+	}
+	for _, rpc := range candidates {
+		if rpc == tried {
+			continue
+		}
+		var result T
+		result, err = read(rpc)
+		if !IsMissingTrieNode(err) {
+			return result, err
+		}
+		c.logger.Debugw("RPC node pruned historical state, trying next node", "rpc", rpc.Name(), "blockNumber", blockNumber, "err", err)
+		rpc.setArchiveStatus(ArchiveStatusFull)
+	}
+	if err != nil {
+		return zero, err
+	}
+	return zero, fmt.Errorf("no live archive node to read the state of block %s", blockNumber)
+}
+
+// archiveCandidates returns the live nodes of the read pool not known to have pruned historical state, detected archive
+// nodes first.
+func (c *chainClient) archiveCandidates(ctx context.Context) ([]*RPCClient, error) {
+	var archives, unknowns []*RPCClient
+	err := c.multiNode.DoAll(ctx, func(_ context.Context, rpc *RPCClient, isSendOnly bool) {
+		if isSendOnly {
+			return
+		}
+		switch rpc.ArchiveStatus() {
+		case ArchiveStatusArchive:
+			archives = append(archives, rpc)
+		case ArchiveStatusUnknown:
+			unknowns = append(unknowns, rpc)
+		case ArchiveStatusFull:
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append(archives, unknowns...), nil
+}
+
+// pruned returns whether the state of blockNumber is older than the state kept by full nodes, using the latest block of
+// the live nodes of the read pool.
+func (c *chainClient) pruned(blockNumber *big.Int) bool {
+	_, latest := c.multiNode.LatestChainInfo()
+	return blockNumber.Cmp(big.NewInt(latest.BlockNumber-fullNodeStateDepth)) <= 0
+}
+
+func (c *chainClient) chainIDLabel() string {
+	return c.multiNode.ChainID().String()
+}
+
+// fullNodeStateDepth is the number of most recent blocks whose state is kept by full nodes with the default pruning
+// settings of geth and its forks.
+const fullNodeStateDepth = 128
+
+// historical returns whether blockNumber is an explicit block rather than a tag such as latest or pending. The state of
+// old blocks may only be served by archive nodes.
+func historical(blockNumber *big.Int) bool {
+	return blockNumber != nil && blockNumber.Sign() >= 0
+}
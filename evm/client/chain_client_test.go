@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"os"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -24,10 +25,14 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/tidwall/gjson"
 
+	commonconfig "github.com/smartcontractkit/chainlink-common/pkg/config"
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
 	"github.com/smartcontractkit/chainlink-framework/multinode"
+	"github.com/smartcontractkit/chainlink-framework/multinode/mocks"
 
 	"github.com/smartcontractkit/chainlink/v2/evm/client"
+	"github.com/smartcontractkit/chainlink/v2/evm/config/toml"
 	"github.com/smartcontractkit/chainlink/v2/evm/testutils"
 	evmtypes "github.com/smartcontractkit/chainlink/v2/evm/types"
 	"github.com/smartcontractkit/chainlink/v2/evm/utils"
@@ -893,3 +898,241 @@ func TestEthClient_ErroringClient(t *testing.T) {
 }
 
 const headResult = client.HeadResult
+
+func TestChainClient_Routes(t *testing.T) {
+	t.Parallel()
+
+	newServer := func(t *testing.T, calls *sync.Map) *url.URL {
+		return testutils.NewWSServer(t, testutils.FixtureChainID, func(method string, params gjson.Result) (resp testutils.JSONRPCResponse) {
+			switch method {
+			case "eth_subscribe":
+				resp.Result = `"0x00"`
+				resp.Notify = headResult
+				return
+			case "eth_unsubscribe":
+				resp.Result = "true"
+				return
+			case "eth_getBalance":
+				resp.Result = `"0x1"`
+			case "eth_getLogs":
+				resp.Result = `[]`
+			}
+			n, _ := calls.LoadOrStore(method, new(atomic.Int32))
+			n.(*atomic.Int32).Add(1)
+			return
+		}).WSURL()
+	}
+	var readCalls, logsCalls sync.Map
+	deadServer := httptest.NewServer(nil)
+	deadServer.Close()
+	newNode := func(name string, u *url.URL, roles ...toml.NodeRole) *toml.Node {
+		return &toml.Node{Name: ptr(name), WSURL: (*commonconfig.URL)(u), Order: ptr(int32(1)), Roles: roles}
+	}
+	nodes := []*toml.Node{
+		newNode("read", newServer(t, &readCalls)),
+		newNode("logs", newServer(t, &logsCalls), toml.NodeRoleLogs),
+		newNode("archive", testutils.WSServerURL(t, deadServer), toml.NodeRoleArchive),
+	}
+	cfg := client.TestNodePoolConfig{NodeSelectionMode: multinode.NodeSelectionModeRoundRobin, NodeFinalizedBlockPollInterval: time.Second}
+	clientErrors := client.NewTestClientErrors()
+	c, err := client.NewEvmClient(cfg, mocks.ChainConfig{}, &clientErrors, logger.Test(t), testutils.FixtureChainID, nodes, "")
+	require.NoError(t, err)
+	require.NoError(t, c.Dial(tests.Context(t)))
+	t.Cleanup(c.Close)
+
+	count := func(calls *sync.Map, method string) int32 {
+		n, ok := calls.Load(method)
+		if !ok {
+			return 0
+		}
+		return n.(*atomic.Int32).Load()
+	}
+
+	_, err = c.FilterLogs(tests.Context(t), ethereum.FilterQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), count(&logsCalls, "eth_getLogs"), "logs are served by the logs node")
+	assert.Equal(t, int32(0), count(&readCalls, "eth_getLogs"))
+
+	_, err = c.BalanceAt(tests.Context(t), testutils.NewAddress(), nil)
+	require.NoError(t, err)
+	_, err = c.BalanceAt(tests.Context(t), testutils.NewAddress(), big.NewInt(1))
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), count(&readCalls, "eth_getBalance"), "archive queries fall back to the read node")
+	assert.Equal(t, int32(0), count(&logsCalls, "eth_getBalance"))
+
+	states := c.NodeStates()
+	assert.Equal(t, "Alive", states["read"])
+	assert.Equal(t, "Alive", states["logs"])
+	assert.NotEqual(t, "Alive", states["archive"])
+}
+
+func TestChainClient_RoutesShareNodes(t *testing.T) {
+	t.Parallel()
+
+	var subscriptions, getLogs atomic.Int32
+	u := testutils.NewWSServer(t, testutils.FixtureChainID, func(method string, params gjson.Result) (resp testutils.JSONRPCResponse) {
+		switch method {
+		case "eth_subscribe":
+			subscriptions.Add(1)
+			resp.Result = `"0x00"`
+			resp.Notify = headResult
+		case "eth_unsubscribe":
+			resp.Result = "true"
+		case "eth_getLogs":
+			getLogs.Add(1)
+			resp.Result = `[]`
+		}
+		return
+	}).WSURL()
+	nodes := []*toml.Node{
+		{Name: ptr("shared"), WSURL: (*commonconfig.URL)(u), Order: ptr(int32(1)), Roles: []toml.NodeRole{toml.NodeRoleRead, toml.NodeRoleLogs, toml.NodeRoleSend}},
+	}
+	cfg := client.TestNodePoolConfig{NodeSelectionMode: multinode.NodeSelectionModeRoundRobin, NodeFinalizedBlockPollInterval: time.Second}
+	clientErrors := client.NewTestClientErrors()
+	c, err := client.NewEvmClient(cfg, mocks.ChainConfig{}, &clientErrors, logger.Test(t), testutils.FixtureChainID, nodes, "")
+	require.NoError(t, err)
+	require.NoError(t, c.Dial(tests.Context(t)))
+	t.Cleanup(c.Close)
+
+	_, err = c.FilterLogs(tests.Context(t), ethereum.FilterQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), getLogs.Load())
+	// the pools of the roles share a single instance of the node, which subscribes to heads once
+	assert.Equal(t, int32(1), subscriptions.Load())
+	assert.Equal(t, map[string]string{"shared": "Alive"}, c.NodeStates())
+}
+
+func TestChainClient_HistoricalStateReads(t *testing.T) {
+	t.Parallel()
+
+	newServer := func(t *testing.T, archive bool, height string) *url.URL {
+		return testutils.NewWSServer(t, testutils.FixtureChainID, func(method string, params gjson.Result) (resp testutils.JSONRPCResponse) {
+			switch method {
+			case "eth_subscribe":
+				resp.Result = `"0x00"`
+				resp.Notify = headResult
+			case "eth_unsubscribe":
+				resp.Result = "true"
+			case "eth_blockNumber":
+				resp.Result = `"` + height + `"`
+			case "eth_getBalance":
+				if !archive && params.Array()[1].String() == "0x1" {
+					resp.Error.Code = -32000
+					resp.Error.Message = "missing trie node 41800b5c3f1717687d85fc9018faac0a6e90b39deaa0b99e7fe4fe796ddeb26a (path )"
+					return
+				}
+				if archive {
+					resp.Result = `"0x2"`
+				} else {
+					resp.Result = `"0x1"`
+				}
+			}
+			return
+		}).WSURL()
+	}
+	newClient := func(t *testing.T, probe bool, height string) client.Client {
+		nodes := []*toml.Node{
+			{Name: ptr("full"), WSURL: (*commonconfig.URL)(newServer(t, false, height)), Order: ptr(int32(1))},
+			{Name: ptr("archive"), WSURL: (*commonconfig.URL)(newServer(t, true, height)), Order: ptr(int32(1))},
+		}
+		cfg := client.TestNodePoolConfig{NodeSelectionMode: multinode.NodeSelectionModeRoundRobin, NodeFinalizedBlockPollInterval: time.Second, ArchiveProbeEnabledVal: probe}
+		clientErrors := client.NewTestClientErrors()
+		c, err := client.NewEvmClient(cfg, mocks.ChainConfig{}, &clientErrors, logger.Test(t), testutils.FixtureChainID, nodes, "")
+		require.NoError(t, err)
+		require.NoError(t, c.Dial(tests.Context(t)))
+		t.Cleanup(c.Close)
+		return c
+	}
+
+	t.Run("detects archive nodes at dial", func(t *testing.T) {
+		c := newClient(t, true, "0x186a0")
+		assert.Equal(t, map[string]client.ArchiveStatus{
+			"full":    client.ArchiveStatusFull,
+			"archive": client.ArchiveStatusArchive,
+		}, c.NodeArchiveStatuses())
+
+		for range 2 {
+			balance, err := c.BalanceAt(tests.Context(t), testutils.NewAddress(), big.NewInt(1))
+			require.NoError(t, err)
+			assert.Equal(t, big.NewInt(2), balance)
+		}
+	})
+
+	t.Run("does not detect archive nodes of young chains", func(t *testing.T) {
+		c := newClient(t, true, "0x1869f")
+		assert.Equal(t, map[string]client.ArchiveStatus{
+			"full":    client.ArchiveStatusUnknown,
+			"archive": client.ArchiveStatusUnknown,
+		}, c.NodeArchiveStatuses())
+	})
+
+	t.Run("retries reads of pruned state on other nodes", func(t *testing.T) {
+		c := newClient(t, false, "0x186a0")
+		assert.Equal(t, map[string]client.ArchiveStatus{
+			"full":    client.ArchiveStatusUnknown,
+			"archive": client.ArchiveStatusUnknown,
+		}, c.NodeArchiveStatuses())
+
+		for range 2 {
+			balance, err := c.BalanceAt(tests.Context(t), testutils.NewAddress(), big.NewInt(1))
+			require.NoError(t, err)
+			assert.Equal(t, big.NewInt(2), balance)
+		}
+		assert.Equal(t, client.ArchiveStatusFull, c.NodeArchiveStatuses()["full"])
+
+		balance, err := c.BalanceAt(tests.Context(t), testutils.NewAddress(), nil)
+		require.NoError(t, err)
+		assert.NotNil(t, balance, "latest state is served by every node")
+	})
+}
+
+func TestChainClient_ArchiveRoute(t *testing.T) {
+	t.Parallel()
+
+	// The state of blocks up to 0x100 is older than the state kept by full nodes at the head 0x180
+	head := strings.Replace(headResult, `"number":"0x1"`, `"number":"0x180"`, 1)
+	newServer := func(t *testing.T, calls *atomic.Int32) *url.URL {
+		return testutils.NewWSServer(t, testutils.FixtureChainID, func(method string, params gjson.Result) (resp testutils.JSONRPCResponse) {
+			switch method {
+			case "eth_subscribe":
+				resp.Result = `"0x00"`
+				resp.Notify = head
+			case "eth_unsubscribe":
+				resp.Result = "true"
+			case "eth_getBalance":
+				calls.Add(1)
+				resp.Result = `"0x1"`
+			}
+			return
+		}).WSURL()
+	}
+	var readCalls, archiveCalls atomic.Int32
+	nodes := []*toml.Node{
+		{Name: ptr("read"), WSURL: (*commonconfig.URL)(newServer(t, &readCalls)), Order: ptr(int32(1))},
+		{Name: ptr("archive"), WSURL: (*commonconfig.URL)(newServer(t, &archiveCalls)), Order: ptr(int32(1)), Roles: []toml.NodeRole{toml.NodeRoleArchive}},
+	}
+	cfg := client.TestNodePoolConfig{NodeSelectionMode: multinode.NodeSelectionModeRoundRobin, NodeFinalizedBlockPollInterval: time.Second}
+	clientErrors := client.NewTestClientErrors()
+	c, err := client.NewEvmClient(cfg, mocks.ChainConfig{}, &clientErrors, logger.Test(t), testutils.FixtureChainID, nodes, "")
+	require.NoError(t, err)
+	require.NoError(t, c.Dial(tests.Context(t)))
+	t.Cleanup(c.Close)
+
+	// Reads are sent to the read pool until the head is received
+	require.Eventually(t, func() bool {
+		_, err = c.BalanceAt(tests.Context(t), testutils.NewAddress(), big.NewInt(0x100))
+		require.NoError(t, err)
+		return archiveCalls.Load() == 1
+	}, tests.WaitTimeout(t), tests.TestInterval)
+	reads := readCalls.Load()
+
+	_, err = c.BalanceAt(tests.Context(t), testutils.NewAddress(), big.NewInt(0x101))
+	require.NoError(t, err)
+	assert.Equal(t, reads+1, readCalls.Load(), "recent state is read from the read pool")
+	assert.Equal(t, int32(1), archiveCalls.Load())
+
+	_, err = c.BalanceAt(tests.Context(t), testutils.NewAddress(), big.NewInt(0x100))
+	require.NoError(t, err)
+	assert.Equal(t, reads+1, readCalls.Load())
+	assert.Equal(t, int32(2), archiveCalls.Load(), "old state is read from the archive nodes")
+}
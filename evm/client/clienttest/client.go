@@ -1300,6 +1300,53 @@ func (_c *Client_LatestFinalizedBlock_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
+// NodeArchiveStatuses provides a mock function with no fields
+func (_m *Client) NodeArchiveStatuses() map[string]client.ArchiveStatus {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for NodeArchiveStatuses")
+	}
+
+	var r0 map[string]client.ArchiveStatus
+	if rf, ok := ret.Get(0).(func() map[string]client.ArchiveStatus); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]client.ArchiveStatus)
+		}
+	}
+
+	return r0
+}
+
+// Client_NodeArchiveStatuses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NodeArchiveStatuses'
+type Client_NodeArchiveStatuses_Call struct {
+	*mock.Call
+}
+
+// NodeArchiveStatuses is a helper method to define mock.On call
+func (_e *Client_Expecter) NodeArchiveStatuses() *Client_NodeArchiveStatuses_Call {
+	return &Client_NodeArchiveStatuses_Call{Call: _e.mock.On("NodeArchiveStatuses")}
+}
+
+func (_c *Client_NodeArchiveStatuses_Call) Run(run func()) *Client_NodeArchiveStatuses_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Client_NodeArchiveStatuses_Call) Return(_a0 map[string]client.ArchiveStatus) *Client_NodeArchiveStatuses_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Client_NodeArchiveStatuses_Call) RunAndReturn(run func() map[string]client.ArchiveStatus) *Client_NodeArchiveStatuses_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NodeStates provides a mock function with no fields
 func (_m *Client) NodeStates() map[string]string {
 	ret := _m.Called()
@@ -21,6 +21,7 @@ type NodeConfig struct {
 	HTTPURL  *string
 	SendOnly *bool
 	Order    *int32
+	Roles    []string
 }
 
 // Build the configs needed to initialize the chain client
@@ -98,6 +99,9 @@ func parseNodeConfigs(nodeCfgs []NodeConfig) ([]*toml.Node, error) {
 			SendOnly: nodeCfg.SendOnly,
 			Order:    nodeCfg.Order,
 		}
+		for _, role := range nodeCfg.Roles {
+			node.Roles = append(node.Roles, toml.NodeRole(role))
+		}
 		nodes[i] = node
 	}
 
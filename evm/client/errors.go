@@ -64,6 +64,8 @@ const (
 	TerminallyStuck
 	TooManyResults
 	ServiceTimeout
+	// MissingTrieNode is returned by full nodes for reads of state they have pruned. It is retriable on an archive node.
+	MissingTrieNode
 )
 
 type ClientErrors map[int]*regexp.Regexp
@@ -754,3 +756,16 @@ func IsTooManyResults(err error, clientErrors config.ClientErrors) bool {
 	}
 	return false
 }
+
+// Pruned state errors returned by full nodes for reads of historical state.
+// Geth, Erigon and their forks return "missing trie node", Nethermind and Besu return "world state not available" or
+// similar and hosted providers return variations of the others.
+var prunedState = ClientErrors{
+	MissingTrieNode: regexp.MustCompile(`(?i)(missing trie node|(world|historical) state (is )?(not available|unavailable)|state (is )?pruned)`),
+}
+
+// IsMissingTrieNode returns true if err was caused by a read of state the node no longer has, such as the state of an
+// old block on a non archive node. The read may succeed on an archive node.
+func IsMissingTrieNode(err error) bool {
+	return prunedState.ErrIs(err, MissingTrieNode)
+}
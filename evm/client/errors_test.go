@@ -570,3 +570,22 @@ func Test_IsTooManyResultsError(t *testing.T) {
 		})
 	}
 }
+
+func Test_IsMissingTrieNode(t *testing.T) {
+	tests := []errorCase{
+		{"missing trie node 1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a2b (path )", true, "geth"},
+		{"World state not available for block 0x1", true, "besu"},
+		{"historical state unavailable", true, "provider"},
+		{"state is pruned", true, "pruned"},
+		{"execution reverted", false, "revert"},
+		{"header not found", false, "missing block"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.network, func(t *testing.T) {
+			assert.Equal(t, test.expect, evmclient.IsMissingTrieNode(errors.New(test.message)))
+			assert.Equal(t, test.expect, evmclient.IsMissingTrieNode(evmclient.JsonError{Code: -32000, Message: test.message}))
+		})
+	}
+	assert.False(t, evmclient.IsMissingTrieNode(nil))
+}
@@ -18,27 +18,59 @@ const QueryTimeout = 10 * time.Second
 func NewEvmClient(cfg evmconfig.NodePool, chainCfg multinode.ChainConfig, clientErrors evmconfig.ClientErrors, lggr logger.Logger, chainID *big.Int, nodes []*toml.Node, chainType chaintype.ChainType) (Client, error) {
 	var primaries []multinode.Node[*big.Int, *RPCClient]
 	var sendonlys []multinode.SendOnlyNode[*big.Int, *RPCClient]
+	roles := map[toml.NodeRole]RoleNodes{}
 	largePayloadRPCTimeout, defaultRPCTimeout := getRPCTimeouts(chainType)
 
+	newSendOnly := func(i int, node *toml.Node) multinode.SendOnlyNode[*big.Int, *RPCClient] {
+		rpc := NewRPCClient(cfg, lggr, nil, node.HTTPURL.URL(), *node.Name, i, chainID,
+			multinode.Secondary, largePayloadRPCTimeout, defaultRPCTimeout, chainType)
+		return multinode.NewSendOnlyNode(lggr, (url.URL)(*node.HTTPURL),
+			*node.Name, chainID, rpc)
+	}
+	newPrimary := func(i int, node *toml.Node) multinode.Node[*big.Int, *RPCClient] {
+		rpc := NewRPCClient(cfg, lggr, node.WSURL.URL(), node.HTTPURL.URL(), *node.Name, i,
+			chainID, multinode.Primary, largePayloadRPCTimeout, defaultRPCTimeout, chainType)
+		return multinode.NewNode(cfg, chainCfg,
+			lggr, node.WSURL.URL(), node.HTTPURL.URL(), *node.Name, i, chainID, *node.Order,
+			rpc, "EVM")
+	}
+
 	for i, node := range nodes {
 		if node.SendOnly != nil && *node.SendOnly {
-			rpc := NewRPCClient(cfg, lggr, nil, node.HTTPURL.URL(), *node.Name, i, chainID,
-				multinode.Secondary, largePayloadRPCTimeout, defaultRPCTimeout, chainType)
-			sendonly := multinode.NewSendOnlyNode(lggr, (url.URL)(*node.HTTPURL),
-				*node.Name, chainID, rpc)
-			sendonlys = append(sendonlys, sendonly)
-		} else {
-			rpc := NewRPCClient(cfg, lggr, node.WSURL.URL(), node.HTTPURL.URL(), *node.Name, i,
-				chainID, multinode.Primary, largePayloadRPCTimeout, defaultRPCTimeout, chainType)
-			primaryNode := multinode.NewNode(cfg, chainCfg,
-				lggr, node.WSURL.URL(), node.HTTPURL.URL(), *node.Name, i, chainID, *node.Order,
-				rpc, "EVM")
-			primaries = append(primaries, primaryNode)
+			sendonlys = append(sendonlys, newSendOnly(i, node))
+			continue
+		}
+		// each node has a single instance, started by the read pool if it serves reads, and otherwise by the pool of
+		// its first role. The pools of its other roles share the instance.
+		n := newPrimary(i, node)
+		owned := !node.ServesReads()
+		if !owned {
+			primaries = append(primaries, n)
+		}
+		for _, role := range node.Roles {
+			if role == toml.NodeRoleRead {
+				continue
+			}
+			r := roles[role]
+			if owned {
+				r.Nodes = append(r.Nodes, n)
+				owned = false
+			} else {
+				r.Nodes = append(r.Nodes, sharedNode{n})
+			}
+			roles[role] = r
+		}
+	}
+	if r, ok := roles[toml.NodeRoleSend]; ok {
+		// send only nodes broadcast the transactions of the send role too
+		for _, s := range sendonlys {
+			r.SendOnlys = append(r.SendOnlys, sharedSendOnlyNode{s})
 		}
+		roles[toml.NodeRoleSend] = r
 	}
 
-	return NewChainClient(lggr, cfg.SelectionMode(), cfg.LeaseDuration(),
-		primaries, sendonlys, chainID, clientErrors, cfg.DeathDeclarationDelay(), chainType), nil
+	return NewChainClientWithRoutes(lggr, cfg.SelectionMode(), cfg.LeaseDuration(),
+		primaries, sendonlys, roles, chainID, clientErrors, cfg.DeathDeclarationDelay(), chainType), nil
 }
 
 func getRPCTimeouts(chainType chaintype.ChainType) (largePayload, defaultTimeout time.Duration) {
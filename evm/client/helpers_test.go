@@ -94,6 +94,7 @@ type TestNodePoolConfig struct {
 	EnforceRepeatableReadVal       bool
 	NodeDeathDeclarationDelay      time.Duration
 	NodeNewHeadsPollInterval       time.Duration
+	ArchiveProbeEnabledVal         bool
 }
 
 func (tc TestNodePoolConfig) PollFailureThreshold() uint32 { return tc.NodePollFailureThreshold }
@@ -128,6 +129,10 @@ func (tc TestNodePoolConfig) DeathDeclarationDelay() time.Duration {
 	return tc.NodeDeathDeclarationDelay
 }
 
+func (tc TestNodePoolConfig) ArchiveProbeEnabled() bool {
+	return tc.ArchiveProbeEnabledVal
+}
+
 func NewChainClientWithTestNode(
 	t *testing.T,
 	nodeCfg multinode.NodeConfig,
@@ -161,5 +161,7 @@ func TestNullClient(t *testing.T) {
 
 		m := nc.NodeStates()
 		require.Nil(t, m)
+
+		require.Nil(t, nc.NodeArchiveStatuses())
 	})
 }
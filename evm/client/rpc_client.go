@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
@@ -120,6 +121,9 @@ type RPCClient struct {
 	highestUserObservations multinode.ChainInfo
 	// most recent chain info observed during current lifecycle (reseted on DisconnectAll)
 	latestChainInfo multinode.ChainInfo
+
+	// archiveStatus holds the ArchiveStatus detected by probeArchive
+	archiveStatus atomic.Int32
 }
 
 var _ multinode.RPCClient[*big.Int, *evmtypes.Head] = (*RPCClient)(nil)
@@ -229,6 +233,10 @@ func (r *RPCClient) Dial(callerCtx context.Context) error {
 
 	lggr.Debugw("RPC dial: evmclient.Client#dial")
 	promEVMPoolRPCNodeDialsSuccess.WithLabelValues(r.chainID.String(), r.name).Inc()
+
+	if r.cfg.ArchiveProbeEnabled() {
+		r.probeArchive(callerCtx)
+	}
 	return nil
 }
 
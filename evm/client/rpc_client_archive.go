@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ArchiveStatus is whether an RPC node serves the state of blocks older than the state kept by full nodes.
+type ArchiveStatus int32
+
+const (
+	// ArchiveStatusUnknown is the status of nodes not probed yet, or whose probe failed for another reason than pruned
+	// state.
+	ArchiveStatusUnknown ArchiveStatus = iota
+	// ArchiveStatusArchive is the status of nodes serving the state of every block.
+	ArchiveStatusArchive
+	// ArchiveStatusFull is the status of nodes which pruned the state of old blocks.
+	ArchiveStatusFull
+)
+
+func (s ArchiveStatus) String() string {
+	switch s {
+	case ArchiveStatusArchive:
+		return "archive"
+	case ArchiveStatusFull:
+		return "full"
+	default:
+		return "unknown"
+	}
+}
+
+// archiveProbeBlock is the block whose state is read to detect archive nodes.
+var archiveProbeBlock = big.NewInt(1)
+
+// archiveProbeMinHeight is the height chains must have reached for the state of archiveProbeBlock to be pruned by full
+// nodes. It exceeds the 90000 blocks of state history kept by default by geth's path based state scheme, so that the
+// state of archiveProbeBlock is only served by archive nodes.
+const archiveProbeMinHeight = 100_000
+
+// ArchiveStatus returns whether the node serves the state of old blocks, as detected when dialed or by the last read of
+// pruned state.
+func (r *RPCClient) ArchiveStatus() ArchiveStatus {
+	return ArchiveStatus(r.archiveStatus.Load())
+}
+
+func (r *RPCClient) setArchiveStatus(s ArchiveStatus) {
+	if ArchiveStatus(r.archiveStatus.Swap(int32(s))) != s {
+		r.rpcLog.Infow("RPC archive status changed", "archiveStatus", s)
+	}
+}
+
+// probeArchive detects whether the node is an archive node by reading the state of an old block. The status is left
+// unknown on chains too young for full nodes to have pruned that state, and on errors other than pruned state, so that
+// the node is still tried for historical reads.
+func (r *RPCClient) probeArchive(ctx context.Context) {
+	height, err := r.BlockNumber(ctx)
+	if err != nil {
+		r.rpcLog.Debugw("RPC archive probe failed", "err", err)
+		r.setArchiveStatus(ArchiveStatusUnknown)
+		return
+	}
+	if height < archiveProbeMinHeight {
+		r.rpcLog.Debugw("RPC archive probe skipped, chain too young for pruned state", "height", height)
+		r.setArchiveStatus(ArchiveStatusUnknown)
+		return
+	}
+	_, err = r.BalanceAt(ctx, common.Address{}, archiveProbeBlock)
+	switch {
+	case err == nil:
+		r.setArchiveStatus(ArchiveStatusArchive)
+	case IsMissingTrieNode(err):
+		r.setArchiveStatus(ArchiveStatusFull)
+	default:
+		r.rpcLog.Debugw("RPC archive probe failed", "err", err)
+		r.setArchiveStatus(ArchiveStatusUnknown)
+	}
+}
@@ -568,6 +568,9 @@ func (c *SimulatedBackendClient) Backend() evmtypes.Backend {
 // NodeStates implements evmclient.Client
 func (c *SimulatedBackendClient) NodeStates() map[string]string { return nil }
 
+// NodeArchiveStatuses implements evmclient.Client
+func (c *SimulatedBackendClient) NodeArchiveStatuses() map[string]ArchiveStatus { return nil }
+
 // Commit imports all the pending transactions as a single block and starts a
 // fresh new state.
 func (c *SimulatedBackendClient) Commit() common.Hash {
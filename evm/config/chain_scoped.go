@@ -49,11 +49,11 @@ func (e *EVMConfig) TOMLString() (string, error) {
 }
 
 func (e *EVMConfig) BalanceMonitor() BalanceMonitor {
-	return &balanceMonitorConfig{c: e.C.BalanceMonitor}
+	return &balanceMonitorConfig{c: e.C.BalanceMonitor, k: e.C.KeySpecific}
 }
 
 func (e *EVMConfig) Transactions() Transactions {
-	return &transactionsConfig{c: e.C.Transactions}
+	return &transactionsConfig{c: e.C.Transactions, k: e.C.KeySpecific}
 }
 
 func (e *EVMConfig) HeadTracker() HeadTracker {
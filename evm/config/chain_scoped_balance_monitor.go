@@ -1,13 +1,129 @@
 package config
 
 import (
+	"math/big"
+	"net/url"
+	"time"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+
+	commonassets "github.com/smartcontractkit/chainlink-common/pkg/assets"
+
+	"github.com/smartcontractkit/chainlink/v2/evm/assets"
 	"github.com/smartcontractkit/chainlink/v2/evm/config/toml"
 )
 
 type balanceMonitorConfig struct {
 	c toml.BalanceMonitor
+	k toml.KeySpecificConfig
 }
 
 func (b *balanceMonitorConfig) Enabled() bool {
 	return *b.c.Enabled
 }
+
+func (b *balanceMonitorConfig) Tokens() []gethcommon.Address {
+	tokens := make([]gethcommon.Address, 0, len(b.c.Tokens))
+	for _, t := range b.c.Tokens {
+		tokens = append(tokens, t.Address.Address())
+	}
+	return tokens
+}
+
+// TokenFloor returns the balance of the token below which an alert is raised, or nil if there is none.
+func (b *balanceMonitorConfig) TokenFloor(token gethcommon.Address) *big.Int {
+	for _, t := range b.c.Tokens {
+		if t.Address.Address() == token && t.Floor != nil {
+			return t.Floor.ToInt()
+		}
+	}
+	return nil
+}
+
+func (b *balanceMonitorConfig) keySpecific(addr gethcommon.Address) *toml.KeySpecificBalanceMonitor {
+	for i := range b.k {
+		if b.k[i].Key.Address() == addr {
+			return &b.k[i].BalanceMonitor
+		}
+	}
+	return nil
+}
+
+// EthFloor returns the ETH balance below which an alert is raised for the key, or nil if there is none.
+func (b *balanceMonitorConfig) EthFloor(addr gethcommon.Address) *assets.Wei {
+	if ks := b.keySpecific(addr); ks != nil && ks.EthFloor != nil {
+		return ks.EthFloor
+	}
+	return b.c.EthFloor
+}
+
+// LinkFloor returns the LINK balance below which an alert is raised for the key, or nil if there is none.
+func (b *balanceMonitorConfig) LinkFloor(addr gethcommon.Address) *commonassets.Link {
+	if ks := b.keySpecific(addr); ks != nil && ks.LinkFloor != nil {
+		return ks.LinkFloor
+	}
+	return b.c.LinkFloor
+}
+
+func (b *balanceMonitorConfig) AlertInterval() time.Duration {
+	if b.c.AlertInterval == nil {
+		return time.Hour
+	}
+	return b.c.AlertInterval.Duration()
+}
+
+func (b *balanceMonitorConfig) Notifiers() []toml.BalanceMonitorNotifier {
+	if len(b.c.Notifiers) == 0 {
+		return []toml.BalanceMonitorNotifier{toml.BalanceMonitorNotifierLog}
+	}
+	return b.c.Notifiers
+}
+
+func (b *balanceMonitorConfig) WebhookURL() *url.URL {
+	if b.c.WebhookURL == nil {
+		return nil
+	}
+	return b.c.WebhookURL.URL()
+}
+
+func (b *balanceMonitorConfig) TopUp() BalanceMonitorTopUp {
+	return &balanceMonitorTopUpConfig{c: b.c.TopUp}
+}
+
+type balanceMonitorTopUpConfig struct {
+	c toml.BalanceMonitorTopUp
+}
+
+func (t *balanceMonitorTopUpConfig) Enabled() bool {
+	return t.c.Enabled != nil && *t.c.Enabled
+}
+
+func (t *balanceMonitorTopUpConfig) FundingAddress() gethcommon.Address {
+	if t.c.FundingAddress == nil {
+		return gethcommon.Address{}
+	}
+	return t.c.FundingAddress.Address()
+}
+
+func (t *balanceMonitorTopUpConfig) Floor() *assets.Wei {
+	return t.c.Floor
+}
+
+func (t *balanceMonitorTopUpConfig) Amount() *assets.Wei {
+	return t.c.Amount
+}
+
+func (t *balanceMonitorTopUpConfig) MinInterval() time.Duration {
+	if t.c.MinInterval == nil {
+		return time.Hour
+	}
+	return t.c.MinInterval.Duration()
+}
+
+// MaxPerDay returns the maximum number of top-ups in any 24 hours, or zero if unlimited.
+func (t *balanceMonitorTopUpConfig) MaxPerDay() uint32 {
+	if t.c.MaxPerDay == nil {
+		return 0
+	}
+	return *t.c.MaxPerDay
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"net/url"
 	"time"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
@@ -43,6 +44,14 @@ func (g *gasEstimatorConfig) FeeHistory() FeeHistory {
 	return &feeHistoryConfig{c: g.c.FeeHistory}
 }
 
+func (g *gasEstimatorConfig) Composite() Composite {
+	return &compositeConfig{c: g.c.Composite}
+}
+
+func (g *gasEstimatorConfig) FeeAPI() FeeAPI {
+	return &feeAPIConfig{c: g.c.FeeAPI}
+}
+
 func (g *gasEstimatorConfig) DAOracle() DAOracle {
 	return &daOracleConfig{c: g.c.DAOracle}
 }
@@ -216,3 +225,74 @@ type feeHistoryConfig struct {
 func (u *feeHistoryConfig) CacheTimeout() time.Duration {
 	return u.c.CacheTimeout.Duration()
 }
+
+type compositeConfig struct {
+	c toml.CompositeEstimator
+}
+
+func (c *compositeConfig) Estimators() []string {
+	if c.c.Estimators == nil {
+		return nil
+	}
+	return *c.c.Estimators
+}
+
+func (c *compositeConfig) Policy() toml.CompositePolicy {
+	if c.c.Policy == nil {
+		return toml.CompositePolicyMedian
+	}
+	return *c.c.Policy
+}
+
+func (c *compositeConfig) MaxStaleness() time.Duration {
+	if c.c.MaxStaleness == nil {
+		return 0
+	}
+	return c.c.MaxStaleness.Duration()
+}
+
+type feeAPIConfig struct {
+	c toml.FeeAPIEstimator
+}
+
+func (a *feeAPIConfig) URL() *url.URL {
+	if a.c.URL == nil {
+		return nil
+	}
+	return a.c.URL.URL()
+}
+
+func (a *feeAPIConfig) PollPeriod() time.Duration {
+	if a.c.PollPeriod == nil {
+		return 10 * time.Second
+	}
+	return a.c.PollPeriod.Duration()
+}
+
+func (a *feeAPIConfig) Unit() toml.FeeAPIUnit {
+	if a.c.Unit == nil {
+		return toml.FeeAPIUnitWei
+	}
+	return *a.c.Unit
+}
+
+func (a *feeAPIConfig) GasPricePath() string {
+	if a.c.GasPricePath == nil {
+		return ""
+	}
+	return *a.c.GasPricePath
+}
+
+func (a *feeAPIConfig) GasFeeCapPath() string {
+	if a.c.GasFeeCapPath == nil {
+		return ""
+	}
+	return *a.c.GasFeeCapPath
+}
+
+func (a *feeAPIConfig) GasTipCapPath() string {
+	if a.c.GasTipCapPath == nil {
+		return ""
+	}
+	return *a.c.GasTipCapPath
+}
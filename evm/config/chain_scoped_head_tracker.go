@@ -1,9 +1,11 @@
 package config
 
 import (
+	"net/url"
 	"time"
 
 	"github.com/smartcontractkit/chainlink/v2/evm/config/toml"
+	"github.com/smartcontractkit/chainlink/v2/evm/types"
 )
 
 type headTrackerConfig struct {
@@ -33,3 +35,39 @@ func (h *headTrackerConfig) MaxAllowedFinalityDepth() uint32 {
 func (h *headTrackerConfig) PersistenceEnabled() bool {
 	return *h.c.PersistenceEnabled
 }
+
+func (h *headTrackerConfig) FinalityProvider() FinalityProvider {
+	return &finalityProviderConfig{c: h.c.FinalityProvider}
+}
+
+// defaultL1Confirmations is the number of L1 confirmations of the arbitrum batch of a block before it is considered
+// finalized, about the two epochs needed for finality on Ethereum.
+const defaultL1Confirmations = 64
+
+type finalityProviderConfig struct {
+	c toml.FinalityProvider
+}
+
+// Type returns the configured type of finality provider, or the empty type when FinalityTagEnabled and FinalityDepth
+// determine finality.
+func (f *finalityProviderConfig) Type() toml.FinalityProviderType {
+	if f.c.Type == nil {
+		return ""
+	}
+	return *f.c.Type
+}
+
+func (f *finalityProviderConfig) L1URL() *url.URL {
+	return f.c.L1URL.URL()
+}
+
+func (f *finalityProviderConfig) ContractAddress() *types.EIP55Address {
+	return f.c.ContractAddress
+}
+
+func (f *finalityProviderConfig) L1Confirmations() uint32 {
+	if f.c.L1Confirmations == nil {
+		return defaultL1Confirmations
+	}
+	return *f.c.L1Confirmations
+}
@@ -51,3 +51,8 @@ func (n *NodePoolConfig) EnforceRepeatableRead() bool {
 func (n *NodePoolConfig) DeathDeclarationDelay() time.Duration {
 	return n.C.DeathDeclarationDelay.Duration()
 }
+
+func (n *NodePoolConfig) ArchiveProbeEnabled() bool {
+	// not set by client.NewClientConfigs
+	return n.C.ArchiveProbeEnabled != nil && *n.C.ArchiveProbeEnabled
+}
@@ -4,11 +4,14 @@ import (
 	"net/url"
 	"time"
 
+	gethcommon "github.com/ethereum/go-ethereum/common"
+
 	"github.com/smartcontractkit/chainlink/v2/evm/config/toml"
 )
 
 type transactionsConfig struct {
 	c toml.Transactions
+	k toml.KeySpecificConfig
 }
 
 func (t *transactionsConfig) Enabled() bool {
@@ -87,3 +90,72 @@ func (a *autoPurgeConfig) MinAttempts() *uint32 {
 func (a *autoPurgeConfig) DetectionApiUrl() *url.URL {
 	return a.c.DetectionApiUrl.URL()
 }
+
+func (t *transactionsConfig) PrivateRelay() PrivateRelay {
+	return &privateRelayConfig{c: t.c.PrivateRelay, k: t.k}
+}
+
+type privateRelayConfig struct {
+	c toml.PrivateRelayConfig
+	k toml.KeySpecificConfig
+}
+
+// Enabled returns whether transactions from the key are sent through the private relay.
+func (p *privateRelayConfig) Enabled(addr gethcommon.Address) bool {
+	for i := range p.k {
+		if p.k[i].Key.Address() == addr && p.k[i].PrivateRelay.Enabled != nil {
+			return *p.k[i].PrivateRelay.Enabled
+		}
+	}
+	return p.c.Enabled != nil && *p.c.Enabled
+}
+
+func (p *privateRelayConfig) URL() *url.URL {
+	if p.c.URL == nil {
+		return nil
+	}
+	return p.c.URL.URL()
+}
+
+func (p *privateRelayConfig) Method() toml.PrivateRelayMethod {
+	if p.c.Method == nil {
+		return toml.PrivateRelayMethodSendPrivateTransaction
+	}
+	return *p.c.Method
+}
+
+func (p *privateRelayConfig) FallbackBlocks() uint32 {
+	if p.c.FallbackBlocks == nil {
+		return 25
+	}
+	return *p.c.FallbackBlocks
+}
+
+func (t *transactionsConfig) Simulation() Simulation {
+	return &simulationConfig{c: t.c.Simulation}
+}
+
+type simulationConfig struct {
+	c toml.SimulationConfig
+}
+
+func (s *simulationConfig) Errors() []string {
+	if s.c.Errors == nil {
+		return nil
+	}
+	return *s.c.Errors
+}
+
+func (s *simulationConfig) TransientErrors() []string {
+	if s.c.TransientErrors == nil {
+		return nil
+	}
+	return *s.c.TransientErrors
+}
+
+func (s *simulationConfig) TransientReasons() []string {
+	if s.c.TransientReasons == nil {
+		return nil
+	}
+	return *s.c.TransientReasons
+}
@@ -78,10 +78,35 @@ type HeadTracker interface {
 	FinalityTagBypass() bool
 	MaxAllowedFinalityDepth() uint32
 	PersistenceEnabled() bool
+	FinalityProvider() FinalityProvider
+}
+
+type FinalityProvider interface {
+	Type() toml.FinalityProviderType
+	L1URL() *url.URL
+	ContractAddress() *types.EIP55Address
+	L1Confirmations() uint32
 }
 
 type BalanceMonitor interface {
 	Enabled() bool
+	Tokens() []gethcommon.Address
+	TokenFloor(token gethcommon.Address) *big.Int
+	EthFloor(addr gethcommon.Address) *assets.Wei
+	LinkFloor(addr gethcommon.Address) *commonassets.Link
+	AlertInterval() time.Duration
+	Notifiers() []toml.BalanceMonitorNotifier
+	WebhookURL() *url.URL
+	TopUp() BalanceMonitorTopUp
+}
+
+type BalanceMonitorTopUp interface {
+	Enabled() bool
+	FundingAddress() gethcommon.Address
+	Floor() *assets.Wei
+	Amount() *assets.Wei
+	MinInterval() time.Duration
+	MaxPerDay() uint32
 }
 
 type ClientErrors interface {
@@ -112,6 +137,21 @@ type Transactions interface {
 	MaxQueued() uint64
 	AutoPurge() AutoPurgeConfig
 	TransactionManagerV2() TransactionManagerV2
+	PrivateRelay() PrivateRelay
+	Simulation() Simulation
+}
+
+type Simulation interface {
+	Errors() []string
+	TransientErrors() []string
+	TransientReasons() []string
+}
+
+type PrivateRelay interface {
+	Enabled(addr gethcommon.Address) bool
+	URL() *url.URL
+	Method() toml.PrivateRelayMethod
+	FallbackBlocks() uint32
 }
 
 type AutoPurgeConfig interface {
@@ -131,6 +171,8 @@ type TransactionManagerV2 interface {
 type GasEstimator interface {
 	BlockHistory() BlockHistory
 	FeeHistory() FeeHistory
+	Composite() Composite
+	FeeAPI() FeeAPI
 	LimitJobType() LimitJobType
 
 	EIP1559DynamicFees() bool
@@ -183,6 +225,21 @@ type FeeHistory interface {
 	CacheTimeout() time.Duration
 }
 
+type Composite interface {
+	Estimators() []string
+	Policy() toml.CompositePolicy
+	MaxStaleness() time.Duration
+}
+
+type FeeAPI interface {
+	URL() *url.URL
+	PollPeriod() time.Duration
+	Unit() toml.FeeAPIUnit
+	GasPricePath() string
+	GasFeeCapPath() string
+	GasTipCapPath() string
+}
+
 type Workflow interface {
 	FromAddress() *types.EIP55Address
 	ForwarderAddress() *types.EIP55Address
@@ -201,6 +258,7 @@ type NodePool interface {
 	EnforceRepeatableRead() bool
 	DeathDeclarationDelay() time.Duration
 	NewHeadsPollInterval() time.Duration
+	ArchiveProbeEnabled() bool
 }
 
 type ChainScopedConfig interface {
@@ -7,9 +7,13 @@ import (
 	"testing"
 	"time"
 
+	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	commonassets "github.com/smartcontractkit/chainlink-common/pkg/assets"
+	commonconfig "github.com/smartcontractkit/chainlink-common/pkg/config"
+
 	"github.com/smartcontractkit/chainlink/v2/evm/assets"
 	"github.com/smartcontractkit/chainlink/v2/evm/config/configtest"
 	"github.com/smartcontractkit/chainlink/v2/evm/config/toml"
@@ -259,6 +263,179 @@ func TestChainScopedConfig_FeeHistory(t *testing.T) {
 	assert.Equal(t, 10*time.Second, u.CacheTimeout())
 }
 
+func TestChainScopedConfig_Composite(t *testing.T) {
+	t.Parallel()
+	cfg := configtest.NewChainScopedConfig(t, nil)
+
+	c := cfg.EVM().GasEstimator().Composite()
+	assert.Empty(t, c.Estimators())
+	assert.Equal(t, toml.CompositePolicyMedian, c.Policy())
+	assert.Zero(t, c.MaxStaleness())
+
+	cfg = configtest.NewChainScopedConfig(t, func(c *toml.EVMConfig) {
+		c.GasEstimator.Mode = ptr("Composite")
+		c.GasEstimator.Composite = toml.CompositeEstimator{
+			Estimators:   &[]string{"BlockHistory", "SuggestedPrice"},
+			Policy:       ptr(toml.CompositePolicyFallback),
+			MaxStaleness: commonconfig.MustNewDuration(time.Minute),
+		}
+	})
+	c = cfg.EVM().GasEstimator().Composite()
+	assert.Equal(t, []string{"BlockHistory", "SuggestedPrice"}, c.Estimators())
+	assert.Equal(t, toml.CompositePolicyFallback, c.Policy())
+	assert.Equal(t, time.Minute, c.MaxStaleness())
+}
+
+func TestChainScopedConfig_FeeAPI(t *testing.T) {
+	t.Parallel()
+	cfg := configtest.NewChainScopedConfig(t, nil)
+
+	a := cfg.EVM().GasEstimator().FeeAPI()
+	assert.Nil(t, a.URL())
+	assert.Equal(t, 10*time.Second, a.PollPeriod())
+	assert.Equal(t, toml.FeeAPIUnitWei, a.Unit())
+	assert.Empty(t, a.GasPricePath())
+
+	cfg = configtest.NewChainScopedConfig(t, func(c *toml.EVMConfig) {
+		c.GasEstimator.Mode = ptr("FeeAPI")
+		c.GasEstimator.FeeAPI = toml.FeeAPIEstimator{
+			URL:           commonconfig.MustParseURL("https://fees.example.com/v1/prices"),
+			PollPeriod:    commonconfig.MustNewDuration(time.Minute),
+			Unit:          ptr(toml.FeeAPIUnitGwei),
+			GasPricePath:  ptr("data.gasPrice"),
+			GasFeeCapPath: ptr("data.maxFeePerGas"),
+			GasTipCapPath: ptr("data.maxPriorityFeePerGas"),
+		}
+	})
+	a = cfg.EVM().GasEstimator().FeeAPI()
+	assert.Equal(t, "https://fees.example.com/v1/prices", a.URL().String())
+	assert.Equal(t, time.Minute, a.PollPeriod())
+	assert.Equal(t, toml.FeeAPIUnitGwei, a.Unit())
+	assert.Equal(t, "data.gasPrice", a.GasPricePath())
+	assert.Equal(t, "data.maxFeePerGas", a.GasFeeCapPath())
+	assert.Equal(t, "data.maxPriorityFeePerGas", a.GasTipCapPath())
+}
+
+func TestChainScopedConfig_BalanceMonitor(t *testing.T) {
+	t.Parallel()
+	cfg := configtest.NewChainScopedConfig(t, nil)
+
+	bm := cfg.EVM().BalanceMonitor()
+	addr := utils.RandomAddress()
+	assert.True(t, bm.Enabled())
+	assert.Empty(t, bm.Tokens())
+	assert.Nil(t, bm.EthFloor(addr))
+	assert.Nil(t, bm.LinkFloor(addr))
+	assert.Equal(t, time.Hour, bm.AlertInterval())
+	assert.Equal(t, []toml.BalanceMonitorNotifier{toml.BalanceMonitorNotifierLog}, bm.Notifiers())
+	assert.False(t, bm.TopUp().Enabled())
+	assert.Equal(t, time.Hour, bm.TopUp().MinInterval())
+	assert.Zero(t, bm.TopUp().MaxPerDay())
+
+	key := utils.RandomAddress()
+	funding := utils.RandomAddress()
+	cfg = configtest.NewChainScopedConfig(t, func(c *toml.EVMConfig) {
+		c.BalanceMonitor.EthFloor = assets.NewWeiI(100)
+		c.BalanceMonitor.LinkFloor = commonassets.NewLinkFromJuels(200)
+		c.BalanceMonitor.TopUp = toml.BalanceMonitorTopUp{
+			Enabled:        ptr(true),
+			FundingAddress: ptr(types.EIP55AddressFromAddress(funding)),
+			Floor:          assets.NewWeiI(10),
+			Amount:         assets.NewWeiI(1000),
+			MaxPerDay:      ptr[uint32](5),
+		}
+		c.KeySpecific = append(c.KeySpecific, toml.KeySpecific{
+			Key:            ptr(types.EIP55AddressFromAddress(key)),
+			BalanceMonitor: toml.KeySpecificBalanceMonitor{EthFloor: assets.NewWeiI(50)},
+		})
+	})
+	bm = cfg.EVM().BalanceMonitor()
+	assert.Equal(t, assets.NewWeiI(100), bm.EthFloor(addr))
+	assert.Equal(t, assets.NewWeiI(50), bm.EthFloor(key))
+	assert.Equal(t, commonassets.NewLinkFromJuels(200), bm.LinkFloor(key))
+	assert.True(t, bm.TopUp().Enabled())
+	assert.Equal(t, funding, bm.TopUp().FundingAddress())
+	assert.Equal(t, assets.NewWeiI(1000), bm.TopUp().Amount())
+	assert.Equal(t, uint32(5), bm.TopUp().MaxPerDay())
+
+	token := utils.RandomAddress()
+	other := utils.RandomAddress()
+	cfg = configtest.NewChainScopedConfig(t, func(c *toml.EVMConfig) {
+		c.BalanceMonitor.Tokens = []toml.BalanceMonitorToken{
+			{Address: ptr(types.EIP55AddressFromAddress(token)), Floor: ubig.NewI(10)},
+			{Address: ptr(types.EIP55AddressFromAddress(other))},
+		}
+	})
+	bm = cfg.EVM().BalanceMonitor()
+	assert.Equal(t, []gethcommon.Address{token, other}, bm.Tokens())
+	assert.Equal(t, big.NewInt(10), bm.TokenFloor(token))
+	assert.Nil(t, bm.TokenFloor(other))
+}
+
+func TestChainScopedConfig_PrivateRelay(t *testing.T) {
+	t.Parallel()
+	cfg := configtest.NewChainScopedConfig(t, nil)
+
+	pr := cfg.EVM().Transactions().PrivateRelay()
+	addr := utils.RandomAddress()
+	assert.False(t, pr.Enabled(addr))
+	assert.Nil(t, pr.URL())
+	assert.Equal(t, toml.PrivateRelayMethodSendPrivateTransaction, pr.Method())
+	assert.Equal(t, uint32(25), pr.FallbackBlocks())
+
+	private := utils.RandomAddress()
+	public := utils.RandomAddress()
+	cfg = configtest.NewChainScopedConfig(t, func(c *toml.EVMConfig) {
+		c.Transactions.PrivateRelay = toml.PrivateRelayConfig{
+			URL:            commonconfig.MustParseURL("https://relay.example"),
+			Method:         ptr(toml.PrivateRelayMethodSendBundle),
+			FallbackBlocks: ptr[uint32](5),
+		}
+		c.KeySpecific = append(c.KeySpecific,
+			toml.KeySpecific{Key: ptr(types.EIP55AddressFromAddress(private)), PrivateRelay: toml.KeySpecificPrivateRelay{Enabled: ptr(true)}},
+			toml.KeySpecific{Key: ptr(types.EIP55AddressFromAddress(public)), PrivateRelay: toml.KeySpecificPrivateRelay{Enabled: ptr(false)}},
+		)
+	})
+	pr = cfg.EVM().Transactions().PrivateRelay()
+	assert.False(t, pr.Enabled(addr))
+	assert.True(t, pr.Enabled(private))
+	assert.Equal(t, "https://relay.example", pr.URL().String())
+	assert.Equal(t, toml.PrivateRelayMethodSendBundle, pr.Method())
+	assert.Equal(t, uint32(5), pr.FallbackBlocks())
+
+	cfg = configtest.NewChainScopedConfig(t, func(c *toml.EVMConfig) {
+		c.Transactions.PrivateRelay.Enabled = ptr(true)
+		c.KeySpecific = append(c.KeySpecific,
+			toml.KeySpecific{Key: ptr(types.EIP55AddressFromAddress(public)), PrivateRelay: toml.KeySpecificPrivateRelay{Enabled: ptr(false)}},
+		)
+	})
+	pr = cfg.EVM().Transactions().PrivateRelay()
+	assert.True(t, pr.Enabled(addr))
+	assert.False(t, pr.Enabled(public))
+}
+
+func TestChainScopedConfig_Simulation(t *testing.T) {
+	t.Parallel()
+	cfg := configtest.NewChainScopedConfig(t, nil)
+
+	sim := cfg.EVM().Transactions().Simulation()
+	assert.Empty(t, sim.Errors())
+	assert.Empty(t, sim.TransientErrors())
+	assert.Empty(t, sim.TransientReasons())
+
+	cfg = configtest.NewChainScopedConfig(t, func(c *toml.EVMConfig) {
+		c.Transactions.Simulation = toml.SimulationConfig{
+			Errors:           &[]string{"InsufficientBalance(address,uint256)"},
+			TransientErrors:  &[]string{"StalePrice(uint256)"},
+			TransientReasons: &[]string{"price stale"},
+		}
+	})
+	sim = cfg.EVM().Transactions().Simulation()
+	assert.Equal(t, []string{"InsufficientBalance(address,uint256)"}, sim.Errors())
+	assert.Equal(t, []string{"StalePrice(uint256)"}, sim.TransientErrors())
+	assert.Equal(t, []string{"price stale"}, sim.TransientReasons())
+}
+
 func TestChainScopedConfig_GasEstimator(t *testing.T) {
 	t.Parallel()
 	cfg := configtest.NewChainScopedConfig(t, func(c *toml.EVMConfig) {
@@ -6,6 +6,7 @@ import (
 	"net/url"
 	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
@@ -18,6 +19,7 @@ import (
 	commonconfig "github.com/smartcontractkit/chainlink-common/pkg/config"
 	commontypes "github.com/smartcontractkit/chainlink-common/pkg/types"
 
+	evmabi "github.com/smartcontractkit/chainlink/v2/evm/abi"
 	"github.com/smartcontractkit/chainlink/v2/evm/assets"
 	"github.com/smartcontractkit/chainlink/v2/evm/config/chaintype"
 	"github.com/smartcontractkit/chainlink/v2/evm/types"
@@ -313,7 +315,7 @@ func (c *EVMConfig) ValidateConfig() (err error) {
 	if len(c.Nodes) == 0 {
 		err = multierr.Append(err, commonconfig.ErrMissing{Name: "Nodes", Msg: "must have at least one node"})
 	} else {
-		var hasPrimary bool
+		var hasPrimary, hasReadPrimary bool
 		var logBroadcasterEnabled bool
 		var newHeadsPollingInterval commonconfig.Duration
 		if c.LogBroadcasterEnabled != nil {
@@ -330,6 +332,7 @@ func (c *EVMConfig) ValidateConfig() (err error) {
 			}
 
 			hasPrimary = true
+			hasReadPrimary = hasReadPrimary || n.ServesReads()
 
 			// if the node is a primary node, then the WS URL is required when
 			//	1. LogBroadcaster is enabled
@@ -346,6 +349,9 @@ func (c *EVMConfig) ValidateConfig() (err error) {
 		if !hasPrimary {
 			err = multierr.Append(err, commonconfig.ErrMissing{Name: "Nodes",
 				Msg: "must have at least one primary node"})
+		} else if !hasReadPrimary {
+			err = multierr.Append(err, commonconfig.ErrMissing{Name: "Nodes",
+				Msg: "must have at least one primary node with no roles or the read role"})
 		}
 	}
 
@@ -469,6 +475,14 @@ func (c *Chain) ValidateConfig() (err error) {
 		}
 	}
 
+	if c.Transactions.PrivateRelay.URL == nil {
+		for _, ks := range c.KeySpecific {
+			if ks.PrivateRelay.Enabled != nil && *ks.PrivateRelay.Enabled {
+				err = multierr.Append(err, commonconfig.ErrMissing{Name: "Transactions.PrivateRelay.URL", Msg: fmt.Sprintf("must be set if private relay is enabled for key %s", ks.Key)})
+			}
+		}
+	}
+
 	return
 }
 
@@ -504,6 +518,8 @@ type Transactions struct {
 
 	AutoPurge            AutoPurgeConfig            `toml:",omitempty"`
 	TransactionManagerV2 TransactionManagerV2Config `toml:",omitempty"`
+	PrivateRelay         PrivateRelayConfig         `toml:",omitempty"`
+	Simulation           SimulationConfig           `toml:",omitempty"`
 }
 
 func (t *Transactions) setFrom(f *Transactions) {
@@ -530,6 +546,8 @@ func (t *Transactions) setFrom(f *Transactions) {
 	}
 	t.AutoPurge.setFrom(&f.AutoPurge)
 	t.TransactionManagerV2.setFrom(&f.TransactionManagerV2)
+	t.PrivateRelay.setFrom(&f.PrivateRelay)
+	t.Simulation.setFrom(&f.Simulation)
 }
 
 type AutoPurgeConfig struct {
@@ -554,6 +572,95 @@ func (a *AutoPurgeConfig) setFrom(f *AutoPurgeConfig) {
 	}
 }
 
+type PrivateRelayConfig struct {
+	Enabled        *bool
+	URL            *commonconfig.URL
+	Method         *PrivateRelayMethod
+	FallbackBlocks *uint32
+}
+
+type PrivateRelayMethod string
+
+const (
+	PrivateRelayMethodSendPrivateTransaction = PrivateRelayMethod("eth_sendPrivateTransaction")
+	PrivateRelayMethodSendBundle             = PrivateRelayMethod("eth_sendBundle")
+)
+
+func (p *PrivateRelayConfig) ValidateConfig() (err error) {
+	if p.Enabled != nil && *p.Enabled && p.URL == nil {
+		err = multierr.Append(err, commonconfig.ErrMissing{Name: "URL", Msg: "required if private relay is enabled"})
+	}
+	if p.URL != nil {
+		switch p.URL.Scheme {
+		case "http", "https":
+		default:
+			err = multierr.Append(err, commonconfig.ErrInvalid{Name: "URL", Value: p.URL.Scheme, Msg: "must be http or https"})
+		}
+	}
+	if p.Method != nil {
+		switch *p.Method {
+		case PrivateRelayMethodSendPrivateTransaction, PrivateRelayMethodSendBundle:
+		default:
+			err = multierr.Append(err, commonconfig.ErrInvalid{Name: "Method", Value: *p.Method,
+				Msg: "must be eth_sendPrivateTransaction or eth_sendBundle"})
+		}
+	}
+	if p.FallbackBlocks != nil && *p.FallbackBlocks == 0 {
+		err = multierr.Append(err, commonconfig.ErrInvalid{Name: "FallbackBlocks", Value: 0, Msg: "must be greater than 0"})
+	}
+	return
+}
+
+func (p *PrivateRelayConfig) setFrom(f *PrivateRelayConfig) {
+	if v := f.Enabled; v != nil {
+		p.Enabled = v
+	}
+	if v := f.URL; v != nil {
+		p.URL = v
+	}
+	if v := f.Method; v != nil {
+		p.Method = v
+	}
+	if v := f.FallbackBlocks; v != nil {
+		p.FallbackBlocks = v
+	}
+}
+
+type SimulationConfig struct {
+	Errors           *[]string
+	TransientErrors  *[]string
+	TransientReasons *[]string
+}
+
+func (s *SimulationConfig) ValidateConfig() (err error) {
+	err = multierr.Combine(validateErrorSignatures("Errors", s.Errors), validateErrorSignatures("TransientErrors", s.TransientErrors))
+	return
+}
+
+func validateErrorSignatures(name string, signatures *[]string) (err error) {
+	if signatures == nil {
+		return
+	}
+	for i, signature := range *signatures {
+		if _, parseErr := evmabi.ParseErrors(signature); parseErr != nil {
+			err = multierr.Append(err, commonconfig.ErrInvalid{Name: fmt.Sprintf("%s.%d", name, i), Value: signature, Msg: parseErr.Error()})
+		}
+	}
+	return
+}
+
+func (s *SimulationConfig) setFrom(f *SimulationConfig) {
+	if v := f.Errors; v != nil {
+		s.Errors = v
+	}
+	if v := f.TransientErrors; v != nil {
+		s.TransientErrors = v
+	}
+	if v := f.TransientReasons; v != nil {
+		s.TransientReasons = v
+	}
+}
+
 type TransactionManagerV2Config struct {
 	Enabled       *bool                  `toml:",omitempty"`
 	BlockTime     *commonconfig.Duration `toml:",omitempty"`
@@ -628,12 +735,140 @@ func (m *Workflow) setFrom(f *Workflow) {
 
 type BalanceMonitor struct {
 	Enabled *bool
+
+	EthFloor      *assets.Wei
+	LinkFloor     *commonassets.Link
+	AlertInterval *commonconfig.Duration
+	Notifiers     []BalanceMonitorNotifier `toml:",omitempty"`
+	WebhookURL    *commonconfig.URL
+	Tokens        []BalanceMonitorToken `toml:",omitempty"`
+	TopUp         BalanceMonitorTopUp   `toml:",omitempty"`
+}
+
+type BalanceMonitorToken struct {
+	Address *types.EIP55Address
+	Floor   *big.Big
+}
+
+type BalanceMonitorNotifier string
+
+const (
+	BalanceMonitorNotifierLog       = BalanceMonitorNotifier("log")
+	BalanceMonitorNotifierWebhook   = BalanceMonitorNotifier("webhook")
+	BalanceMonitorNotifierTelemetry = BalanceMonitorNotifier("telemetry")
+)
+
+func (m *BalanceMonitor) ValidateConfig() (err error) {
+	if m.AlertInterval != nil && m.AlertInterval.Duration() <= 0 {
+		err = multierr.Append(err, commonconfig.ErrInvalid{Name: "AlertInterval", Value: m.AlertInterval,
+			Msg: "must be greater than zero"})
+	}
+	for _, n := range m.Notifiers {
+		switch n {
+		case BalanceMonitorNotifierLog, BalanceMonitorNotifierTelemetry:
+		case BalanceMonitorNotifierWebhook:
+			if m.WebhookURL == nil {
+				err = multierr.Append(err, commonconfig.ErrMissing{Name: "WebhookURL", Msg: "must be set for the webhook notifier"})
+			}
+		default:
+			err = multierr.Append(err, commonconfig.ErrInvalid{Name: "Notifiers", Value: n,
+				Msg: fmt.Sprintf("must be one of: %s, %s, %s", BalanceMonitorNotifierLog, BalanceMonitorNotifierWebhook, BalanceMonitorNotifierTelemetry)})
+		}
+	}
+	if m.WebhookURL != nil {
+		if u := m.WebhookURL.URL(); u.Scheme != "http" && u.Scheme != "https" {
+			err = multierr.Append(err, commonconfig.ErrInvalid{Name: "WebhookURL", Value: u.String(),
+				Msg: "must be an http or https URL"})
+		}
+	}
+	for i, t := range m.Tokens {
+		if t.Address == nil {
+			err = multierr.Append(err, commonconfig.ErrMissing{Name: "Tokens", Msg: fmt.Sprintf("%vth token must have an Address", i)})
+		}
+		if t.Floor != nil && t.Floor.Cmp(big.NewI(0)) < 0 {
+			err = multierr.Append(err, commonconfig.ErrInvalid{Name: "Tokens", Value: t.Floor,
+				Msg: fmt.Sprintf("%vth token must not have a negative Floor", i)})
+		}
+	}
+	return
 }
 
 func (m *BalanceMonitor) setFrom(f *BalanceMonitor) {
 	if v := f.Enabled; v != nil {
 		m.Enabled = v
 	}
+	if v := f.Tokens; v != nil {
+		m.Tokens = v
+	}
+	if v := f.EthFloor; v != nil {
+		m.EthFloor = v
+	}
+	if v := f.LinkFloor; v != nil {
+		m.LinkFloor = v
+	}
+	if v := f.AlertInterval; v != nil {
+		m.AlertInterval = v
+	}
+	if v := f.Notifiers; v != nil {
+		m.Notifiers = v
+	}
+	if v := f.WebhookURL; v != nil {
+		m.WebhookURL = v
+	}
+	m.TopUp.setFrom(&f.TopUp)
+}
+
+type BalanceMonitorTopUp struct {
+	Enabled        *bool
+	FundingAddress *types.EIP55Address
+	Floor          *assets.Wei
+	Amount         *assets.Wei
+	MinInterval    *commonconfig.Duration
+	MaxPerDay      *uint32
+}
+
+func (t *BalanceMonitorTopUp) ValidateConfig() (err error) {
+	if t.Enabled == nil || !*t.Enabled {
+		return
+	}
+	if t.FundingAddress == nil {
+		err = multierr.Append(err, commonconfig.ErrMissing{Name: "FundingAddress", Msg: "must be set when top-ups are enabled"})
+	}
+	if t.Floor == nil {
+		err = multierr.Append(err, commonconfig.ErrMissing{Name: "Floor", Msg: "must be set when top-ups are enabled"})
+	}
+	if t.Amount == nil {
+		err = multierr.Append(err, commonconfig.ErrMissing{Name: "Amount", Msg: "must be set when top-ups are enabled"})
+	} else if t.Amount.Cmp(assets.NewWeiI(0)) <= 0 {
+		err = multierr.Append(err, commonconfig.ErrInvalid{Name: "Amount", Value: t.Amount,
+			Msg: "must be greater than zero"})
+	}
+	if t.MinInterval != nil && t.MinInterval.Duration() < 0 {
+		err = multierr.Append(err, commonconfig.ErrInvalid{Name: "MinInterval", Value: t.MinInterval,
+			Msg: "must not be negative"})
+	}
+	return
+}
+
+func (t *BalanceMonitorTopUp) setFrom(f *BalanceMonitorTopUp) {
+	if v := f.Enabled; v != nil {
+		t.Enabled = v
+	}
+	if v := f.FundingAddress; v != nil {
+		t.FundingAddress = v
+	}
+	if v := f.Floor; v != nil {
+		t.Floor = v
+	}
+	if v := f.Amount; v != nil {
+		t.Amount = v
+	}
+	if v := f.MinInterval; v != nil {
+		t.MinInterval = v
+	}
+	if v := f.MaxPerDay; v != nil {
+		t.MaxPerDay = v
+	}
 }
 
 type GasEstimator struct {
@@ -663,6 +898,8 @@ type GasEstimator struct {
 
 	BlockHistory BlockHistoryEstimator `toml:",omitempty"`
 	FeeHistory   FeeHistoryEstimator   `toml:",omitempty"`
+	Composite    CompositeEstimator    `toml:",omitempty"`
+	FeeAPI       FeeAPIEstimator       `toml:",omitempty"`
 	DAOracle     DAOracle              `toml:",omitempty"`
 }
 
@@ -700,6 +937,33 @@ func (e *GasEstimator) ValidateConfig() (err error) {
 		err = multierr.Append(err, commonconfig.ErrInvalid{Name: "BlockHistory.BlockHistorySize", Value: *e.BlockHistory.BlockHistorySize,
 			Msg: "must be greater than or equal to 1 with BlockHistory Mode"})
 	}
+	if *e.Mode == "Composite" {
+		if e.Composite.Estimators == nil || len(*e.Composite.Estimators) == 0 {
+			err = multierr.Append(err, commonconfig.ErrMissing{Name: "Composite.Estimators", Msg: "required with Composite Mode"})
+		} else if *e.BlockHistory.BlockHistorySize <= 0 && slices.Contains(*e.Composite.Estimators, "BlockHistory") {
+			err = multierr.Append(err, commonconfig.ErrInvalid{Name: "BlockHistory.BlockHistorySize", Value: *e.BlockHistory.BlockHistorySize,
+				Msg: "must be greater than or equal to 1 with the BlockHistory Composite estimator"})
+		}
+	}
+	if *e.Mode == "FeeAPI" {
+		if e.FeeAPI.URL == nil {
+			err = multierr.Append(err, commonconfig.ErrMissing{Name: "FeeAPI.URL", Msg: "required with FeeAPI Mode"})
+		}
+		if *e.EIP1559DynamicFees {
+			if e.FeeAPI.GasFeeCapPath == nil || *e.FeeAPI.GasFeeCapPath == "" {
+				err = multierr.Append(err, commonconfig.ErrMissing{Name: "FeeAPI.GasFeeCapPath", Msg: "required with FeeAPI Mode and EIP1559DynamicFees"})
+			}
+			if e.FeeAPI.GasTipCapPath == nil || *e.FeeAPI.GasTipCapPath == "" {
+				err = multierr.Append(err, commonconfig.ErrMissing{Name: "FeeAPI.GasTipCapPath", Msg: "required with FeeAPI Mode and EIP1559DynamicFees"})
+			}
+		} else if e.FeeAPI.GasPricePath == nil || *e.FeeAPI.GasPricePath == "" {
+			err = multierr.Append(err, commonconfig.ErrMissing{Name: "FeeAPI.GasPricePath", Msg: "required with FeeAPI Mode"})
+		}
+		if *e.BlockHistory.BlockHistorySize <= 0 {
+			err = multierr.Append(err, commonconfig.ErrInvalid{Name: "BlockHistory.BlockHistorySize", Value: *e.BlockHistory.BlockHistorySize,
+				Msg: "must be greater than or equal to 1 with FeeAPI Mode, which falls back to the BlockHistory estimator"})
+		}
+	}
 
 	return
 }
@@ -759,6 +1023,8 @@ func (e *GasEstimator) setFrom(f *GasEstimator) {
 	e.LimitJobType.setFrom(&f.LimitJobType)
 	e.BlockHistory.setFrom(&f.BlockHistory)
 	e.FeeHistory.setFrom(&f.FeeHistory)
+	e.Composite.setFrom(&f.Composite)
+	e.FeeAPI.setFrom(&f.FeeAPI)
 	e.DAOracle.setFrom(&f.DAOracle)
 }
 
@@ -832,6 +1098,123 @@ func (u *FeeHistoryEstimator) setFrom(f *FeeHistoryEstimator) {
 	}
 }
 
+type CompositeEstimator struct {
+	Estimators   *[]string
+	Policy       *CompositePolicy
+	MaxStaleness *commonconfig.Duration
+}
+
+type CompositePolicy string
+
+const (
+	CompositePolicyMax      = CompositePolicy("max")
+	CompositePolicyMedian   = CompositePolicy("median")
+	CompositePolicyFallback = CompositePolicy("fallback")
+)
+
+// compositeEstimatorModes are the modes which may be combined by the Composite estimator.
+var compositeEstimatorModes = []string{"Arbitrum", "BlockHistory", "FeeHistory", "FixedPrice", "SuggestedPrice"}
+
+func (c *CompositeEstimator) ValidateConfig() (err error) {
+	if c.Estimators != nil {
+		seen := make(map[string]bool)
+		for i, mode := range *c.Estimators {
+			if !slices.Contains(compositeEstimatorModes, mode) {
+				err = multierr.Append(err, commonconfig.ErrInvalid{Name: fmt.Sprintf("Estimators[%d]", i), Value: mode,
+					Msg: fmt.Sprintf("must be one of: %s", strings.Join(compositeEstimatorModes, ", "))})
+			} else if seen[mode] {
+				err = multierr.Append(err, commonconfig.ErrInvalid{Name: fmt.Sprintf("Estimators[%d]", i), Value: mode,
+					Msg: "duplicate estimator"})
+			}
+			seen[mode] = true
+		}
+	}
+	if c.Policy != nil {
+		switch *c.Policy {
+		case CompositePolicyMax, CompositePolicyMedian, CompositePolicyFallback:
+		default:
+			err = multierr.Append(err, commonconfig.ErrInvalid{Name: "Policy", Value: *c.Policy,
+				Msg: fmt.Sprintf("must be one of: %s, %s, %s", CompositePolicyMax, CompositePolicyMedian, CompositePolicyFallback)})
+		}
+	}
+	if c.MaxStaleness != nil && c.MaxStaleness.Duration() < 0 {
+		err = multierr.Append(err, commonconfig.ErrInvalid{Name: "MaxStaleness", Value: c.MaxStaleness,
+			Msg: "must not be negative"})
+	}
+	return
+}
+
+func (c *CompositeEstimator) setFrom(f *CompositeEstimator) {
+	if v := f.Estimators; v != nil {
+		c.Estimators = v
+	}
+	if v := f.Policy; v != nil {
+		c.Policy = v
+	}
+	if v := f.MaxStaleness; v != nil {
+		c.MaxStaleness = v
+	}
+}
+
+type FeeAPIEstimator struct {
+	URL           *commonconfig.URL
+	PollPeriod    *commonconfig.Duration
+	Unit          *FeeAPIUnit
+	GasPricePath  *string
+	GasFeeCapPath *string
+	GasTipCapPath *string
+}
+
+type FeeAPIUnit string
+
+const (
+	FeeAPIUnitWei  = FeeAPIUnit("wei")
+	FeeAPIUnitGwei = FeeAPIUnit("gwei")
+)
+
+func (a *FeeAPIEstimator) ValidateConfig() (err error) {
+	if a.URL != nil {
+		if u := a.URL.URL(); u.Scheme != "http" && u.Scheme != "https" {
+			err = multierr.Append(err, commonconfig.ErrInvalid{Name: "URL", Value: u.String(),
+				Msg: "must be an http or https URL"})
+		}
+	}
+	if a.PollPeriod != nil && a.PollPeriod.Duration() <= 0 {
+		err = multierr.Append(err, commonconfig.ErrInvalid{Name: "PollPeriod", Value: a.PollPeriod,
+			Msg: "must be greater than zero"})
+	}
+	if a.Unit != nil {
+		switch *a.Unit {
+		case FeeAPIUnitWei, FeeAPIUnitGwei:
+		default:
+			err = multierr.Append(err, commonconfig.ErrInvalid{Name: "Unit", Value: *a.Unit,
+				Msg: fmt.Sprintf("must be one of: %s, %s", FeeAPIUnitWei, FeeAPIUnitGwei)})
+		}
+	}
+	return
+}
+
+func (a *FeeAPIEstimator) setFrom(f *FeeAPIEstimator) {
+	if v := f.URL; v != nil {
+		a.URL = v
+	}
+	if v := f.PollPeriod; v != nil {
+		a.PollPeriod = v
+	}
+	if v := f.Unit; v != nil {
+		a.Unit = v
+	}
+	if v := f.GasPricePath; v != nil {
+		a.GasPricePath = v
+	}
+	if v := f.GasFeeCapPath; v != nil {
+		a.GasFeeCapPath = v
+	}
+	if v := f.GasTipCapPath; v != nil {
+		a.GasTipCapPath = v
+	}
+}
+
 type DAOracle struct {
 	OracleType             *DAOracleType
 	OracleAddress          *types.EIP55Address
@@ -903,8 +1286,34 @@ func (ks KeySpecificConfig) ValidateConfig() (err error) {
 }
 
 type KeySpecific struct {
-	Key          *types.EIP55Address
-	GasEstimator KeySpecificGasEstimator `toml:",omitempty"`
+	Key            *types.EIP55Address
+	GasEstimator   KeySpecificGasEstimator   `toml:",omitempty"`
+	BalanceMonitor KeySpecificBalanceMonitor `toml:",omitempty"`
+	PrivateRelay   KeySpecificPrivateRelay   `toml:",omitempty"`
+}
+
+type KeySpecificPrivateRelay struct {
+	Enabled *bool
+}
+
+func (r *KeySpecificPrivateRelay) setFrom(f *KeySpecificPrivateRelay) {
+	if v := f.Enabled; v != nil {
+		r.Enabled = v
+	}
+}
+
+type KeySpecificBalanceMonitor struct {
+	EthFloor  *assets.Wei
+	LinkFloor *commonassets.Link
+}
+
+func (m *KeySpecificBalanceMonitor) setFrom(f *KeySpecificBalanceMonitor) {
+	if v := f.EthFloor; v != nil {
+		m.EthFloor = v
+	}
+	if v := f.LinkFloor; v != nil {
+		m.LinkFloor = v
+	}
 }
 
 type KeySpecificGasEstimator struct {
@@ -924,6 +1333,8 @@ type HeadTracker struct {
 	MaxAllowedFinalityDepth *uint32
 	FinalityTagBypass       *bool
 	PersistenceEnabled      *bool
+
+	FinalityProvider FinalityProvider `toml:",omitempty"`
 }
 
 func (t *HeadTracker) setFrom(f *HeadTracker) {
@@ -945,6 +1356,7 @@ func (t *HeadTracker) setFrom(f *HeadTracker) {
 	if v := f.PersistenceEnabled; v != nil {
 		t.PersistenceEnabled = v
 	}
+	t.FinalityProvider.setFrom(&f.FinalityProvider)
 }
 
 func (t *HeadTracker) ValidateConfig() (err error) {
@@ -956,6 +1368,57 @@ func (t *HeadTracker) ValidateConfig() (err error) {
 	return
 }
 
+type FinalityProvider struct {
+	Type            *FinalityProviderType
+	L1URL           *commonconfig.URL
+	ContractAddress *types.EIP55Address
+	L1Confirmations *uint32
+}
+
+type FinalityProviderType string
+
+const (
+	FinalityProviderDepth    = FinalityProviderType("depth")
+	FinalityProviderTag      = FinalityProviderType("tag")
+	FinalityProviderOPStack  = FinalityProviderType("opstack")
+	FinalityProviderArbitrum = FinalityProviderType("arbitrum")
+)
+
+func (p *FinalityProvider) ValidateConfig() (err error) {
+	if p.Type == nil {
+		return
+	}
+	switch *p.Type {
+	case FinalityProviderDepth, FinalityProviderTag, FinalityProviderArbitrum:
+	case FinalityProviderOPStack:
+		if p.L1URL == nil {
+			err = multierr.Append(err, commonconfig.ErrMissing{Name: "L1URL", Msg: "required for 'opstack' finality providers"})
+		}
+		if p.ContractAddress == nil {
+			err = multierr.Append(err, commonconfig.ErrMissing{Name: "ContractAddress", Msg: "required for 'opstack' finality providers"})
+		}
+	default:
+		err = multierr.Append(err, commonconfig.ErrInvalid{Name: "Type", Value: *p.Type,
+			Msg: "must be one of depth, tag, opstack or arbitrum"})
+	}
+	return
+}
+
+func (p *FinalityProvider) setFrom(f *FinalityProvider) {
+	if v := f.Type; v != nil {
+		p.Type = v
+	}
+	if v := f.L1URL; v != nil {
+		p.L1URL = v
+	}
+	if v := f.ContractAddress; v != nil {
+		p.ContractAddress = v
+	}
+	if v := f.L1Confirmations; v != nil {
+		p.L1Confirmations = v
+	}
+}
+
 type ClientErrors struct {
 	NonceTooLow                       *string `toml:",omitempty"`
 	NonceTooHigh                      *string `toml:",omitempty"`
@@ -1035,6 +1498,7 @@ type NodePool struct {
 	EnforceRepeatableRead      *bool
 	DeathDeclarationDelay      *commonconfig.Duration
 	NewHeadsPollInterval       *commonconfig.Duration
+	ArchiveProbeEnabled        *bool
 }
 
 func (p *NodePool) setFrom(f *NodePool) {
@@ -1072,6 +1536,10 @@ func (p *NodePool) setFrom(f *NodePool) {
 		p.NewHeadsPollInterval = v
 	}
 
+	if v := f.ArchiveProbeEnabled; v != nil {
+		p.ArchiveProbeEnabled = v
+	}
+
 	p.Errors.setFrom(&f.Errors)
 }
 
@@ -1125,6 +1593,30 @@ type Node struct {
 	HTTPURL  *commonconfig.URL
 	SendOnly *bool
 	Order    *int32
+	Roles    []NodeRole `toml:",omitempty"`
+}
+
+// NodeRole is a class of RPC methods served by a node.
+type NodeRole string
+
+const (
+	// NodeRoleRead serves every method not routed to the nodes of a more specific role, and is the fallback of the
+	// other roles when none of their nodes is alive.
+	NodeRoleRead NodeRole = "read"
+	// NodeRoleLogs serves log queries and subscriptions, and batch calls.
+	NodeRoleLogs NodeRole = "logs"
+	// NodeRoleArchive serves state queries of past blocks.
+	NodeRoleArchive NodeRole = "archive"
+	// NodeRoleSend serves transaction broadcasts, along with send only nodes.
+	NodeRoleSend NodeRole = "send"
+)
+
+// NodeRoles are the valid roles of nodes.
+var NodeRoles = []NodeRole{NodeRoleRead, NodeRoleLogs, NodeRoleArchive, NodeRoleSend}
+
+// ServesReads returns whether the node has the read role. Nodes without roles have the read role only.
+func (n *Node) ServesReads() bool {
+	return len(n.Roles) == 0 || slices.Contains(n.Roles, NodeRoleRead)
 }
 
 func (n *Node) ValidateConfig() (err error) {
@@ -1162,6 +1654,19 @@ func (n *Node) ValidateConfig() (err error) {
 		n.Order = &z
 	}
 
+	seen := map[NodeRole]bool{}
+	for _, r := range n.Roles {
+		if !slices.Contains(NodeRoles, r) {
+			err = multierr.Append(err, commonconfig.ErrInvalid{Name: "Roles", Value: r, Msg: "must be one of read, logs, archive or send"})
+		} else if seen[r] {
+			err = multierr.Append(err, commonconfig.ErrInvalid{Name: "Roles", Value: r, Msg: "must not be duplicated"})
+		}
+		seen[r] = true
+	}
+	if len(n.Roles) > 0 && n.SendOnly != nil && *n.SendOnly {
+		err = multierr.Append(err, commonconfig.ErrInvalid{Name: "Roles", Value: n.Roles, Msg: "must not be set for send only nodes"})
+	}
+
 	return
 }
 
@@ -1181,6 +1686,9 @@ func (n *Node) SetFrom(f *Node) {
 	if f.Order != nil {
 		n.Order = f.Order
 	}
+	if f.Roles != nil {
+		n.Roles = f.Roles
+	}
 }
 
 func ChainIDInt64(cid string) (int64, error) {
@@ -8,6 +8,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink-common/pkg/config"
 	"github.com/smartcontractkit/chainlink/v2/evm/config/toml"
+	"github.com/smartcontractkit/chainlink/v2/evm/types"
 )
 
 func TestEVMConfig_ValidateConfig(t *testing.T) {
@@ -28,3 +29,52 @@ func TestEVMConfig_ValidateConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestFinalityProvider_ValidateConfig(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		provider toml.FinalityProvider
+		errors   []string
+	}{
+		{name: "unset"},
+		{name: "depth", provider: toml.FinalityProvider{Type: ptr(toml.FinalityProviderDepth)}},
+		{name: "arbitrum", provider: toml.FinalityProvider{Type: ptr(toml.FinalityProviderArbitrum)}},
+		{name: "opstack", provider: toml.FinalityProvider{
+			Type:            ptr(toml.FinalityProviderOPStack),
+			L1URL:           config.MustParseURL("https://l1.test"),
+			ContractAddress: ptr(types.MustEIP55Address("0xdfe97868233d1aa22e815a266982f2cf17685a27")),
+		}},
+		{name: "opstack missing settlement", provider: toml.FinalityProvider{Type: ptr(toml.FinalityProviderOPStack)},
+			errors: []string{"L1URL: missing: required for 'opstack' finality providers", "ContractAddress: missing: required for 'opstack' finality providers"}},
+		{name: "invalid", provider: toml.FinalityProvider{Type: ptr(toml.FinalityProviderType("zksync"))},
+			errors: []string{"Type: invalid value (zksync): must be one of depth, tag, opstack or arbitrum"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.provider.ValidateConfig()
+			if len(tt.errors) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			for _, msg := range tt.errors {
+				assert.ErrorContains(t, err, msg)
+			}
+		})
+	}
+}
+
+func TestSimulationConfig_ValidateConfig(t *testing.T) {
+	assert.NoError(t, (&toml.SimulationConfig{}).ValidateConfig())
+	assert.NoError(t, (&toml.SimulationConfig{
+		Errors:          &[]string{"InsufficientBalance(address,uint256)"},
+		TransientErrors: &[]string{"StalePrice()"},
+	}).ValidateConfig())
+
+	err := (&toml.SimulationConfig{
+		Errors:          &[]string{"InsufficientBalance(address,uint256)", "InsufficientBalance(address"},
+		TransientErrors: &[]string{"StalePrice(price)"},
+	}).ValidateConfig()
+	assert.ErrorContains(t, err, "Errors.1: invalid value (InsufficientBalance(address)")
+	assert.ErrorContains(t, err, "TransientErrors.0: invalid value (StalePrice(price))")
+}
+
+func ptr[T any](v T) *T { return &v }
@@ -191,6 +191,14 @@ func (a *arbitrumEstimator) run() {
 	}
 }
 
+// LastRefreshed returns when the gas price was last refreshed by the embedded SuggestedPriceEstimator.
+func (a *arbitrumEstimator) LastRefreshed() time.Time {
+	if refreshed, ok := a.EvmEstimator.(refreshedEstimator); ok {
+		return refreshed.LastRefreshed()
+	}
+	return time.Time{}
+}
+
 // refreshPricesInArbGas calls getPricesInArbGas() and caches the refreshed prices.
 func (a *arbitrumEstimator) refreshPricesInArbGas() {
 	perL2Tx, perL1CalldataUnit, err := a.l1Oracle.GetPricesInArbGas()
@@ -114,6 +114,7 @@ type BlockHistoryEstimator struct {
 	latest                *evmtypes.Head
 	latestMu              sync.RWMutex
 	initialFetch          atomic.Bool
+	refreshTime
 
 	logger logger.SugaredLogger
 
@@ -500,6 +501,7 @@ func (b *BlockHistoryEstimator) FetchBlocksAndRecalculate(ctx context.Context, h
 	}
 	b.initialFetch.Store(true)
 	b.Recalculate(head)
+	b.refreshed(time.Now())
 }
 
 // Recalculate adds the given heads to the history and recalculates gas price.
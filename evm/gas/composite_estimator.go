@@ -0,0 +1,383 @@
+package gas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/multierr"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+
+	"github.com/smartcontractkit/chainlink-framework/chains/fees"
+
+	"github.com/smartcontractkit/chainlink/v2/evm/assets"
+	"github.com/smartcontractkit/chainlink/v2/evm/config/toml"
+	"github.com/smartcontractkit/chainlink/v2/evm/gas/rollups"
+	evmtypes "github.com/smartcontractkit/chainlink/v2/evm/types"
+)
+
+// metrics are thread safe
+var (
+	promCompositeEstimatorSourcePrice = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gas_estimator_composite_source_price",
+		Help: "Latest fee (in Wei) estimated by a source of the composite gas estimator",
+	},
+		[]string{"evmChainID", "source", "fee"},
+	)
+	promCompositeEstimatorSourceErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "gas_estimator_composite_source_errors",
+		Help: "Counter is incremented every time a source of the composite gas estimator fails to estimate a fee",
+	},
+		[]string{"evmChainID", "source", "method"},
+	)
+	promCompositeEstimatorSourceStale = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gas_estimator_composite_source_stale",
+		Help: "Set to 1 while the estimates of a source of the composite gas estimator are stale and ignored, 0 otherwise",
+	},
+		[]string{"evmChainID", "source", "method"},
+	)
+	promCompositeEstimatorPrice = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gas_estimator_composite_price",
+		Help: "Latest fee (in Wei) returned by the composite gas estimator",
+	},
+		[]string{"evmChainID", "fee"},
+	)
+)
+
+const (
+	compositeFeeGasPrice = "gas_price"
+	compositeFeeFeeCap   = "fee_cap"
+	compositeFeeTipCap   = "tip_cap"
+)
+
+// CompositeEstimatorConfig configures the CompositeEstimator.
+type CompositeEstimatorConfig struct {
+	// Policy combines the estimates of the sources.
+	Policy toml.CompositePolicy
+	// MaxStaleness is the longest a source may go without successfully refreshing its estimates before it is ignored.
+	// Zero disables the check.
+	MaxStaleness time.Duration
+}
+
+// CompositeSource is a named estimator combined by the CompositeEstimator.
+type CompositeSource struct {
+	Name      string
+	Estimator EvmEstimator
+}
+
+// refreshedEstimator is implemented by estimators which refresh their estimates in the background.
+type refreshedEstimator interface {
+	// LastRefreshed returns when the estimates were last refreshed successfully, or the zero time if they never were.
+	LastRefreshed() time.Time
+}
+
+// refreshTime records when an estimator last refreshed its estimates successfully. It implements refreshedEstimator.
+type refreshTime struct {
+	unixNano atomic.Int64
+}
+
+func (r *refreshTime) refreshed(t time.Time) {
+	r.unixNano.Store(t.UnixNano())
+}
+
+func (r *refreshTime) LastRefreshed() time.Time {
+	if n := r.unixNano.Load(); n != 0 {
+		return time.Unix(0, n)
+	}
+	return time.Time{}
+}
+
+type compositeSource struct {
+	CompositeSource
+}
+
+// stale reports whether the source has not refreshed its estimates for longer than maxStaleness.
+// Sources which do not refresh estimates in the background, like the FixedPrice estimator, are never stale.
+// Unchanged estimates do not make a source stale, as fees of many chains are stable or fixed.
+func (s *compositeSource) stale(now time.Time, maxStaleness time.Duration) bool {
+	refreshed, ok := s.Estimator.(refreshedEstimator)
+	return ok && maxStaleness > 0 && now.Sub(refreshed.LastRefreshed()) > maxStaleness
+}
+
+type compositeResult[T any] struct {
+	source *compositeSource
+	value  T
+}
+
+var _ EvmEstimator = (*CompositeEstimator)(nil)
+
+// CompositeEstimator queries several estimators and combines their estimates with a policy:
+//   - max: the highest estimate.
+//   - median: the median estimate, so that a single source returning garbage cannot dictate the fee.
+//   - fallback: the estimate of the first source, in configured order, which neither fails nor is stale.
+//
+// Failed and stale sources are ignored. If every source is stale, the stale estimates are used rather than failing.
+type CompositeEstimator struct {
+	services.StateMachine
+
+	lggr    logger.SugaredLogger
+	cfg     CompositeEstimatorConfig
+	chainID string
+	sources []*compositeSource
+	now     func() time.Time
+}
+
+// NewCompositeEstimator returns a CompositeEstimator of sources, which must not be empty.
+func NewCompositeEstimator(lggr logger.Logger, cfg CompositeEstimatorConfig, chainID *big.Int, sources []CompositeSource) *CompositeEstimator {
+	e := &CompositeEstimator{
+		lggr:    logger.Sugared(logger.Named(lggr, "CompositeEstimator")),
+		cfg:     cfg,
+		chainID: chainID.String(),
+		now:     time.Now,
+	}
+	for _, source := range sources {
+		e.sources = append(e.sources, &compositeSource{CompositeSource: source})
+	}
+	return e
+}
+
+func (e *CompositeEstimator) Name() string {
+	return e.lggr.Name()
+}
+
+func (e *CompositeEstimator) Start(ctx context.Context) error {
+	return e.StartOnce("CompositeEstimator", func() error {
+		var ms services.MultiStart
+		for _, source := range e.sources {
+			if err := ms.Start(ctx, source.Estimator); err != nil {
+				return fmt.Errorf("failed to start %s estimator: %w", source.Name, err)
+			}
+		}
+		// The wrapping EvmFeeEstimator only starts the L1 oracle of the first source.
+		for _, oracle := range e.extraL1Oracles() {
+			if err := ms.Start(ctx, oracle); err != nil {
+				return fmt.Errorf("failed to start L1 oracle: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+func (e *CompositeEstimator) Close() error {
+	return e.StopOnce("CompositeEstimator", func() (err error) {
+		for _, oracle := range e.extraL1Oracles() {
+			err = multierr.Append(err, oracle.Close())
+		}
+		for _, source := range e.sources {
+			err = multierr.Append(err, source.Estimator.Close())
+		}
+		return err
+	})
+}
+
+func (e *CompositeEstimator) Ready() (err error) {
+	err = e.StateMachine.Ready()
+	for _, source := range e.sources {
+		err = multierr.Append(err, source.Estimator.Ready())
+	}
+	return err
+}
+
+func (e *CompositeEstimator) HealthReport() map[string]error {
+	report := map[string]error{e.Name(): e.Healthy()}
+	for _, source := range e.sources {
+		services.CopyHealth(report, source.Estimator.HealthReport())
+	}
+	return report
+}
+
+// L1Oracle returns the L1 oracle of the first source.
+func (e *CompositeEstimator) L1Oracle() rollups.L1Oracle {
+	return e.sources[0].Estimator.L1Oracle()
+}
+
+// extraL1Oracles returns the distinct L1 oracles of the sources, other than the one returned by L1Oracle.
+func (e *CompositeEstimator) extraL1Oracles() (oracles []rollups.L1Oracle) {
+	seen := []rollups.L1Oracle{e.L1Oracle()}
+	for _, source := range e.sources[1:] {
+		oracle := source.Estimator.L1Oracle()
+		if oracle == nil || slices.Contains(seen, oracle) {
+			continue
+		}
+		seen = append(seen, oracle)
+		oracles = append(oracles, oracle)
+	}
+	return oracles
+}
+
+func (e *CompositeEstimator) OnNewLongestChain(ctx context.Context, head *evmtypes.Head) {
+	for _, source := range e.sources {
+		source.Estimator.OnNewLongestChain(ctx, head)
+	}
+}
+
+type legacyEstimate struct {
+	gasPrice *assets.Wei
+	gasLimit uint64
+}
+
+func (e *CompositeEstimator) GetLegacyGas(ctx context.Context, calldata []byte, gasLimit uint64, maxGasPriceWei *assets.Wei, opts ...fees.Opt) (*assets.Wei, uint64, error) {
+	results, err := queryComposite(e, "GetLegacyGas", true, func(s *compositeSource) (legacyEstimate, error) {
+		gasPrice, chainSpecificGasLimit, err := s.Estimator.GetLegacyGas(ctx, calldata, gasLimit, maxGasPriceWei, opts...)
+		return legacyEstimate{gasPrice, chainSpecificGasLimit}, err
+	}, legacyEstimate.String)
+	if err != nil {
+		return nil, 0, err
+	}
+	return e.combineLegacy(results)
+}
+
+func (e *CompositeEstimator) BumpLegacyGas(ctx context.Context, originalGasPrice *assets.Wei, gasLimit uint64, maxGasPriceWei *assets.Wei, attempts []EvmPriorAttempt) (*assets.Wei, uint64, error) {
+	results, err := queryComposite(e, "BumpLegacyGas", false, func(s *compositeSource) (legacyEstimate, error) {
+		gasPrice, chainSpecificGasLimit, err := s.Estimator.BumpLegacyGas(ctx, originalGasPrice, gasLimit, maxGasPriceWei, attempts)
+		return legacyEstimate{gasPrice, chainSpecificGasLimit}, err
+	}, legacyEstimate.String)
+	if err != nil {
+		return nil, 0, err
+	}
+	return e.combineLegacy(results)
+}
+
+func (e *CompositeEstimator) GetDynamicFee(ctx context.Context, maxGasPriceWei *assets.Wei) (DynamicFee, error) {
+	results, err := queryComposite(e, "GetDynamicFee", true, func(s *compositeSource) (DynamicFee, error) {
+		return s.Estimator.GetDynamicFee(ctx, maxGasPriceWei)
+	}, dynamicFeeString)
+	if err != nil {
+		return DynamicFee{}, err
+	}
+	return e.combineDynamic(results), nil
+}
+
+func (e *CompositeEstimator) BumpDynamicFee(ctx context.Context, original DynamicFee, maxGasPriceWei *assets.Wei, attempts []EvmPriorAttempt) (DynamicFee, error) {
+	results, err := queryComposite(e, "BumpDynamicFee", false, func(s *compositeSource) (DynamicFee, error) {
+		return s.Estimator.BumpDynamicFee(ctx, original, maxGasPriceWei, attempts)
+	}, dynamicFeeString)
+	if err != nil {
+		return DynamicFee{}, err
+	}
+	return e.combineDynamic(results), nil
+}
+
+// queryComposite calls the sources and returns the results to combine. With the fallback policy, only the selected result is returned.
+// Staleness is only checked if checkStaleness is set, as bumped fees derive from the original fee rather than the market.
+// A fees.ErrConnectivity from any source is returned as is, so that bumping is halted.
+func queryComposite[T any](e *CompositeEstimator, method string, checkStaleness bool, call func(*compositeSource) (T, error), key func(T) string) ([]compositeResult[T], error) {
+	if err := e.StateMachine.Ready(); err != nil {
+		return nil, err
+	}
+
+	var fresh, stale []compositeResult[T]
+	var errs error
+	for _, source := range e.sources {
+		value, err := call(source)
+		if err != nil {
+			if errors.Is(err, fees.ErrConnectivity) {
+				return nil, err
+			}
+			promCompositeEstimatorSourceErrors.WithLabelValues(e.chainID, source.Name, method).Inc()
+			e.lggr.Warnw("Composite gas estimator source failed", "source", source.Name, "method", method, "err", err)
+			errs = multierr.Append(errs, fmt.Errorf("%s: %w", source.Name, err))
+			continue
+		}
+
+		result := compositeResult[T]{source: source, value: value}
+		if checkStaleness && source.stale(e.now(), e.cfg.MaxStaleness) {
+			promCompositeEstimatorSourceStale.WithLabelValues(e.chainID, source.Name, method).Set(1)
+			e.lggr.Debugw("Composite gas estimator source is stale", "source", source.Name, "method", method, "value", key(value), "maxStaleness", e.cfg.MaxStaleness)
+			stale = append(stale, result)
+			continue
+		}
+		promCompositeEstimatorSourceStale.WithLabelValues(e.chainID, source.Name, method).Set(0)
+		fresh = append(fresh, result)
+		if e.cfg.Policy == toml.CompositePolicyFallback {
+			break
+		}
+	}
+
+	results := fresh
+	if len(results) == 0 {
+		if len(stale) == 0 {
+			return nil, fmt.Errorf("all sources of the composite gas estimator failed: %w", errs)
+		}
+		e.lggr.Warnw("All sources of the composite gas estimator are stale, using stale estimates", "method", method)
+		results = stale
+	}
+	if e.cfg.Policy == toml.CompositePolicyFallback {
+		results = results[:1]
+	}
+	estimates := make(map[string]string, len(results))
+	for _, result := range results {
+		estimates[result.source.Name] = key(result.value)
+	}
+	e.lggr.Debugw("Composite gas estimator estimates", "method", method, "policy", e.cfg.Policy, "estimates", estimates)
+	return results, nil
+}
+
+// combineLegacy combines the gas prices with the policy, and returns the highest gas limit so that no source's limit is undercut.
+func (e *CompositeEstimator) combineLegacy(results []compositeResult[legacyEstimate]) (*assets.Wei, uint64, error) {
+	prices := make([]*assets.Wei, len(results))
+	var gasLimit uint64
+	for i, result := range results {
+		prices[i] = result.value.gasPrice
+		gasLimit = max(gasLimit, result.value.gasLimit)
+		promCompositeEstimatorSourcePrice.WithLabelValues(e.chainID, result.source.Name, compositeFeeGasPrice).Set(float64(result.value.gasPrice.Int64()))
+	}
+	gasPrice := e.combine(prices)
+	promCompositeEstimatorPrice.WithLabelValues(e.chainID, compositeFeeGasPrice).Set(float64(gasPrice.Int64()))
+	return gasPrice, gasLimit, nil
+}
+
+// combineDynamic combines the fee caps and the tip caps independently. As the tip cap of every result is at most its fee cap,
+// the combined tip cap is at most the combined fee cap for every policy.
+func (e *CompositeEstimator) combineDynamic(results []compositeResult[DynamicFee]) DynamicFee {
+	feeCaps := make([]*assets.Wei, len(results))
+	tipCaps := make([]*assets.Wei, len(results))
+	for i, result := range results {
+		feeCaps[i], tipCaps[i] = result.value.GasFeeCap, result.value.GasTipCap
+		promCompositeEstimatorSourcePrice.WithLabelValues(e.chainID, result.source.Name, compositeFeeFeeCap).Set(float64(result.value.GasFeeCap.Int64()))
+		promCompositeEstimatorSourcePrice.WithLabelValues(e.chainID, result.source.Name, compositeFeeTipCap).Set(float64(result.value.GasTipCap.Int64()))
+	}
+	fee := DynamicFee{GasFeeCap: e.combine(feeCaps), GasTipCap: e.combine(tipCaps)}
+	promCompositeEstimatorPrice.WithLabelValues(e.chainID, compositeFeeFeeCap).Set(float64(fee.GasFeeCap.Int64()))
+	promCompositeEstimatorPrice.WithLabelValues(e.chainID, compositeFeeTipCap).Set(float64(fee.GasTipCap.Int64()))
+	return fee
+}
+
+func (e *CompositeEstimator) combine(values []*assets.Wei) *assets.Wei {
+	switch e.cfg.Policy {
+	case toml.CompositePolicyFallback:
+		return values[0]
+	case toml.CompositePolicyMax:
+		return slices.MaxFunc(values, func(a, b *assets.Wei) int { return a.Cmp(b) })
+	default:
+		return medianWei(values)
+	}
+}
+
+// medianWei returns the median of values, or the mean of the two middle values if there is an even number of them.
+func medianWei(values []*assets.Wei) *assets.Wei {
+	sorted := slices.Clone(values)
+	slices.SortFunc(sorted, func(a, b *assets.Wei) int { return a.Cmp(b) })
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid]
+	}
+	sum := new(big.Int).Add(sorted[mid-1].ToInt(), sorted[mid].ToInt())
+	return assets.NewWei(sum.Div(sum, big.NewInt(2)))
+}
+
+func (l legacyEstimate) String() string {
+	return fmt.Sprintf("{GasPrice: %s, GasLimit: %d}", l.gasPrice, l.gasLimit)
+}
+
+func dynamicFeeString(d DynamicFee) string {
+	return fmt.Sprintf("{GasFeeCap: %s, GasTipCap: %s}", d.GasFeeCap, d.GasTipCap)
+}
@@ -0,0 +1,264 @@
+package gas_test
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/services/servicetest"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink-framework/chains/fees"
+
+	"github.com/smartcontractkit/chainlink/v2/evm/assets"
+	"github.com/smartcontractkit/chainlink/v2/evm/config/toml"
+	"github.com/smartcontractkit/chainlink/v2/evm/gas"
+	"github.com/smartcontractkit/chainlink/v2/evm/gas/mocks"
+)
+
+func newCompositeSource(t *testing.T, name string) gas.CompositeSource {
+	est := mocks.NewEvmEstimator(t)
+	est.On("Start", mock.Anything).Return(nil).Maybe()
+	est.On("Close").Return(nil).Maybe()
+	est.On("L1Oracle").Return(nil).Maybe()
+	return gas.CompositeSource{Name: name, Estimator: est}
+}
+
+// refreshedEstimator is an estimator refreshing its estimates in the background
+type refreshedEstimator struct {
+	*mocks.EvmEstimator
+	refreshedAt time.Time
+}
+
+func (r *refreshedEstimator) LastRefreshed() time.Time {
+	return r.refreshedAt
+}
+
+func newRefreshedCompositeSource(t *testing.T, name string, refreshedAt time.Time) gas.CompositeSource {
+	source := newCompositeSource(t, name)
+	source.Estimator = &refreshedEstimator{EvmEstimator: source.Estimator.(*mocks.EvmEstimator), refreshedAt: refreshedAt}
+	return source
+}
+
+func estimatorMock(source gas.CompositeSource) *mocks.EvmEstimator {
+	if r, ok := source.Estimator.(*refreshedEstimator); ok {
+		return r.EvmEstimator
+	}
+	return source.Estimator.(*mocks.EvmEstimator)
+}
+
+func newCompositeEstimator(t *testing.T, cfg gas.CompositeEstimatorConfig, sources ...gas.CompositeSource) *gas.CompositeEstimator {
+	e := gas.NewCompositeEstimator(logger.Test(t), cfg, big.NewInt(1), sources)
+	servicetest.Run(t, e)
+	return e
+}
+
+func onLegacy(source gas.CompositeSource, price int64, gasLimit uint64, err error) *mock.Call {
+	var p *assets.Wei
+	if err == nil {
+		p = assets.NewWeiI(price)
+	}
+	return estimatorMock(source).On("GetLegacyGas", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(p, gasLimit, err)
+}
+
+func onDynamic(source gas.CompositeSource, feeCap, tipCap int64) *mock.Call {
+	return estimatorMock(source).On("GetDynamicFee", mock.Anything, mock.Anything).
+		Return(gas.DynamicFee{GasFeeCap: assets.NewWeiI(feeCap), GasTipCap: assets.NewWeiI(tipCap)}, nil)
+}
+
+func TestCompositeEstimator_Policies(t *testing.T) {
+	t.Parallel()
+
+	maxPrice := assets.NewWeiI(1_000_000)
+	for _, tc := range []struct {
+		policy   toml.CompositePolicy
+		price    int64
+		feeCap   int64
+		tipCap   int64
+		gasLimit uint64
+	}{
+		{toml.CompositePolicyMedian, 20, 25, 2, 30_000},
+		{toml.CompositePolicyMax, 1000, 40, 3, 30_000},
+		{toml.CompositePolicyFallback, 10, 10, 1, 21_000},
+	} {
+		t.Run(string(tc.policy), func(t *testing.T) {
+			blockHistory := newCompositeSource(t, "BlockHistory")
+			suggested := newCompositeSource(t, "SuggestedPrice")
+			feeHistory := newCompositeSource(t, "FeeHistory")
+			e := newCompositeEstimator(t, gas.CompositeEstimatorConfig{Policy: tc.policy}, blockHistory, suggested, feeHistory)
+
+			onLegacy(blockHistory, 10, 21_000, nil)
+			onDynamic(blockHistory, 10, 1)
+			if tc.policy != toml.CompositePolicyFallback {
+				// garbage from eth_gasPrice
+				onLegacy(suggested, 1000, 21_000, nil)
+				onDynamic(suggested, 40, 3)
+				onLegacy(feeHistory, 20, 30_000, nil)
+				onDynamic(feeHistory, 25, 2)
+			}
+
+			price, gasLimit, err := e.GetLegacyGas(tests.Context(t), nil, 21_000, maxPrice)
+			require.NoError(t, err)
+			assert.Equal(t, assets.NewWeiI(tc.price), price)
+			assert.Equal(t, tc.gasLimit, gasLimit)
+
+			fee, err := e.GetDynamicFee(tests.Context(t), maxPrice)
+			require.NoError(t, err)
+			assert.Equal(t, assets.NewWeiI(tc.feeCap), fee.GasFeeCap)
+			assert.Equal(t, assets.NewWeiI(tc.tipCap), fee.GasTipCap)
+		})
+	}
+
+	t.Run("median of an even number of estimates", func(t *testing.T) {
+		a := newCompositeSource(t, "BlockHistory")
+		b := newCompositeSource(t, "SuggestedPrice")
+		e := newCompositeEstimator(t, gas.CompositeEstimatorConfig{Policy: toml.CompositePolicyMedian}, a, b)
+		onDynamic(a, 10, 1)
+		onDynamic(b, 21, 4)
+
+		fee, err := e.GetDynamicFee(tests.Context(t), maxPrice)
+		require.NoError(t, err)
+		assert.Equal(t, assets.NewWeiI(15), fee.GasFeeCap)
+		assert.Equal(t, assets.NewWeiI(2), fee.GasTipCap)
+	})
+}
+
+func TestCompositeEstimator_Errors(t *testing.T) {
+	t.Parallel()
+
+	maxPrice := assets.NewWeiI(1_000_000)
+
+	t.Run("failed sources are ignored", func(t *testing.T) {
+		a := newCompositeSource(t, "BlockHistory")
+		b := newCompositeSource(t, "SuggestedPrice")
+		c := newCompositeSource(t, "FeeHistory")
+		e := newCompositeEstimator(t, gas.CompositeEstimatorConfig{Policy: toml.CompositePolicyMedian}, a, b, c)
+		onLegacy(a, 0, 0, errors.New("boom"))
+		onLegacy(b, 30, 21_000, nil)
+		onLegacy(c, 10, 21_000, nil)
+
+		price, _, err := e.GetLegacyGas(tests.Context(t), nil, 21_000, maxPrice)
+		require.NoError(t, err)
+		assert.Equal(t, assets.NewWeiI(20), price)
+	})
+
+	t.Run("fallback chain", func(t *testing.T) {
+		a := newCompositeSource(t, "BlockHistory")
+		b := newCompositeSource(t, "SuggestedPrice")
+		c := newCompositeSource(t, "FeeHistory")
+		e := newCompositeEstimator(t, gas.CompositeEstimatorConfig{Policy: toml.CompositePolicyFallback}, a, b, c)
+		onLegacy(a, 0, 0, errors.New("boom"))
+		onLegacy(b, 30, 21_000, nil)
+
+		price, _, err := e.GetLegacyGas(tests.Context(t), nil, 21_000, maxPrice)
+		require.NoError(t, err)
+		assert.Equal(t, assets.NewWeiI(30), price)
+	})
+
+	t.Run("all sources failed", func(t *testing.T) {
+		a := newCompositeSource(t, "BlockHistory")
+		b := newCompositeSource(t, "SuggestedPrice")
+		e := newCompositeEstimator(t, gas.CompositeEstimatorConfig{Policy: toml.CompositePolicyMax}, a, b)
+		onLegacy(a, 0, 0, errors.New("boom"))
+		onLegacy(b, 0, 0, errors.New("bang"))
+
+		_, _, err := e.GetLegacyGas(tests.Context(t), nil, 21_000, maxPrice)
+		require.ErrorContains(t, err, "all sources of the composite gas estimator failed")
+		require.ErrorContains(t, err, "BlockHistory: boom")
+		require.ErrorContains(t, err, "SuggestedPrice: bang")
+	})
+
+	t.Run("connectivity errors halt bumping", func(t *testing.T) {
+		a := newCompositeSource(t, "BlockHistory")
+		b := newCompositeSource(t, "SuggestedPrice")
+		e := newCompositeEstimator(t, gas.CompositeEstimatorConfig{Policy: toml.CompositePolicyFallback}, a, b)
+		a.Estimator.(*mocks.EvmEstimator).On("BumpLegacyGas", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, uint64(0), fmt.Errorf("price too high: %w", fees.ErrConnectivity))
+
+		_, _, err := e.BumpLegacyGas(tests.Context(t), assets.NewWeiI(10), 21_000, maxPrice, nil)
+		require.ErrorIs(t, err, fees.ErrConnectivity)
+	})
+
+	t.Run("bumps are combined", func(t *testing.T) {
+		a := newCompositeSource(t, "BlockHistory")
+		b := newCompositeSource(t, "SuggestedPrice")
+		e := newCompositeEstimator(t, gas.CompositeEstimatorConfig{Policy: toml.CompositePolicyMax}, a, b)
+		original := gas.DynamicFee{GasFeeCap: assets.NewWeiI(10), GasTipCap: assets.NewWeiI(1)}
+		a.Estimator.(*mocks.EvmEstimator).On("BumpDynamicFee", mock.Anything, original, mock.Anything, mock.Anything).
+			Return(gas.DynamicFee{GasFeeCap: assets.NewWeiI(12), GasTipCap: assets.NewWeiI(2)}, nil)
+		b.Estimator.(*mocks.EvmEstimator).On("BumpDynamicFee", mock.Anything, original, mock.Anything, mock.Anything).
+			Return(gas.DynamicFee{GasFeeCap: assets.NewWeiI(15), GasTipCap: assets.NewWeiI(2)}, nil)
+
+		fee, err := e.BumpDynamicFee(tests.Context(t), original, maxPrice, nil)
+		require.NoError(t, err)
+		assert.Equal(t, assets.NewWeiI(15), fee.GasFeeCap)
+		assert.Equal(t, assets.NewWeiI(2), fee.GasTipCap)
+	})
+
+	t.Run("not started", func(t *testing.T) {
+		e := gas.NewCompositeEstimator(logger.Test(t), gas.CompositeEstimatorConfig{}, big.NewInt(1), []gas.CompositeSource{newCompositeSource(t, "BlockHistory")})
+		_, _, err := e.GetLegacyGas(tests.Context(t), nil, 21_000, maxPrice)
+		require.Error(t, err)
+	})
+}
+
+func TestCompositeEstimator_Staleness(t *testing.T) {
+	t.Parallel()
+
+	maxPrice := assets.NewWeiI(1_000_000)
+	now := time.Now()
+	a := newRefreshedCompositeSource(t, "SuggestedPrice", now)
+	b := newRefreshedCompositeSource(t, "BlockHistory", now)
+	fixed := newCompositeSource(t, "FixedPrice")
+	e := newCompositeEstimator(t, gas.CompositeEstimatorConfig{Policy: toml.CompositePolicyFallback, MaxStaleness: time.Minute}, a, b, fixed)
+	e.SetNow(func() time.Time { return now })
+
+	onLegacy(a, 10, 21_000, nil)
+	onLegacy(b, 20, 21_000, nil)
+	onLegacy(fixed, 30, 21_000, nil)
+
+	price, _, err := e.GetLegacyGas(tests.Context(t), nil, 21_000, maxPrice)
+	require.NoError(t, err)
+	assert.Equal(t, assets.NewWeiI(10), price)
+
+	// a keeps returning the same estimate, but refreshes it successfully
+	now = now.Add(2 * time.Minute)
+	a.Estimator.(*refreshedEstimator).refreshedAt = now
+	price, _, err = e.GetLegacyGas(tests.Context(t), nil, 21_000, maxPrice)
+	require.NoError(t, err)
+	assert.Equal(t, assets.NewWeiI(10), price, "unchanged estimates are not stale")
+
+	// a fails to refresh, b does
+	now = now.Add(2 * time.Minute)
+	b.Estimator.(*refreshedEstimator).refreshedAt = now
+	price, _, err = e.GetLegacyGas(tests.Context(t), nil, 21_000, maxPrice)
+	require.NoError(t, err)
+	assert.Equal(t, assets.NewWeiI(20), price, "stale source is skipped")
+
+	// sources which do not refresh estimates are never stale
+	now = now.Add(2 * time.Minute)
+	price, _, err = e.GetLegacyGas(tests.Context(t), nil, 21_000, maxPrice)
+	require.NoError(t, err)
+	assert.Equal(t, assets.NewWeiI(30), price)
+
+	t.Run("all sources stale", func(t *testing.T) {
+		a := newRefreshedCompositeSource(t, "SuggestedPrice", now.Add(-2*time.Minute))
+		b := newRefreshedCompositeSource(t, "BlockHistory", time.Time{})
+		e := newCompositeEstimator(t, gas.CompositeEstimatorConfig{Policy: toml.CompositePolicyFallback, MaxStaleness: time.Minute}, a, b)
+		e.SetNow(func() time.Time { return now })
+		onLegacy(a, 10, 21_000, nil)
+		onLegacy(b, 20, 21_000, nil)
+
+		// stale estimates are used rather than failing
+		price, _, err := e.GetLegacyGas(tests.Context(t), nil, 21_000, maxPrice)
+		require.NoError(t, err)
+		assert.Equal(t, assets.NewWeiI(10), price)
+	})
+}
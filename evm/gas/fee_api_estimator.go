@@ -0,0 +1,428 @@
+package gas
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/shopspring/decimal"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+
+	"github.com/smartcontractkit/chainlink-framework/chains/fees"
+	"github.com/smartcontractkit/chainlink/v2/evm/assets"
+	"github.com/smartcontractkit/chainlink/v2/evm/client"
+	"github.com/smartcontractkit/chainlink/v2/evm/config/toml"
+	"github.com/smartcontractkit/chainlink/v2/evm/gas/rollups"
+	evmtypes "github.com/smartcontractkit/chainlink/v2/evm/types"
+)
+
+// metrics are thread safe
+var (
+	promFeeAPIEstimatorPrice = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gas_estimator_fee_api_price",
+		Help: "Latest price (in Wei) returned by the fee API",
+	},
+		[]string{"evmChainID", "fee"},
+	)
+	promFeeAPIEstimatorErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "gas_estimator_fee_api_errors",
+		Help: "Number of failed requests to the fee API",
+	},
+		[]string{"evmChainID"},
+	)
+	promFeeAPIEstimatorFallbacks = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "gas_estimator_fee_api_fallbacks",
+		Help: "Number of estimates delegated to the fallback estimator, because the fee API was unavailable",
+	},
+		[]string{"evmChainID", "method"},
+	)
+)
+
+// maxFeeAPIResponseSize limits the size of the fee API response body which is read.
+const maxFeeAPIResponseSize = 1 << 20
+
+var _ EvmEstimator = &FeeAPIEstimator{}
+
+// FeeAPIPrices are the gas prices returned by a fee API. Prices which are not mapped are nil.
+type FeeAPIPrices struct {
+	GasPrice   *assets.Wei
+	DynamicFee DynamicFee
+}
+
+// FeeAPIResponseMapper maps the response body of a fee API to gas prices.
+type FeeAPIResponseMapper interface {
+	MapResponse(body []byte) (FeeAPIPrices, error)
+}
+
+// JSONPathMapper is a FeeAPIResponseMapper which reads the prices from a JSON response body, at dot separated paths
+// like "data.fast.maxFeePerGas". Array elements are selected by index, like "result.0.gasPrice", and empty paths are not mapped.
+// Prices may be JSON numbers, decimal strings or hex strings, and are converted from Unit to Wei.
+type JSONPathMapper struct {
+	GasPricePath  string
+	GasFeeCapPath string
+	GasTipCapPath string
+	Unit          toml.FeeAPIUnit
+}
+
+var _ FeeAPIResponseMapper = JSONPathMapper{}
+
+func (m JSONPathMapper) MapResponse(body []byte) (prices FeeAPIPrices, err error) {
+	var doc any
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	if err = dec.Decode(&doc); err != nil {
+		return prices, fmt.Errorf("failed to decode json response: %w", err)
+	}
+	if prices.GasPrice, err = m.price(doc, m.GasPricePath); err != nil {
+		return prices, err
+	}
+	if prices.DynamicFee.GasFeeCap, err = m.price(doc, m.GasFeeCapPath); err != nil {
+		return prices, err
+	}
+	if prices.DynamicFee.GasTipCap, err = m.price(doc, m.GasTipCapPath); err != nil {
+		return prices, err
+	}
+	return prices, nil
+}
+
+func (m JSONPathMapper) price(doc any, path string) (*assets.Wei, error) {
+	if path == "" {
+		return nil, nil
+	}
+	v := doc
+	for _, key := range strings.Split(path, ".") {
+		switch node := v.(type) {
+		case map[string]any:
+			var ok bool
+			if v, ok = node[key]; !ok {
+				return nil, fmt.Errorf("path %q: key %q not found", path, key)
+			}
+		case []any:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, fmt.Errorf("path %q: invalid index %q for array of length %d", path, key, len(node))
+			}
+			v = node[i]
+		default:
+			return nil, fmt.Errorf("path %q: cannot select %q from %T", path, key, v)
+		}
+	}
+
+	var d decimal.Decimal
+	switch value := v.(type) {
+	case json.Number:
+		var err error
+		if d, err = decimal.NewFromString(value.String()); err != nil {
+			return nil, fmt.Errorf("path %q: invalid number %q: %w", path, value, err)
+		}
+	case string:
+		if strings.HasPrefix(value, "0x") {
+			i, err := hexutil.DecodeBig(value)
+			if err != nil {
+				return nil, fmt.Errorf("path %q: invalid hex number %q: %w", path, value, err)
+			}
+			d = decimal.NewFromBigInt(i, 0)
+		} else {
+			var err error
+			if d, err = decimal.NewFromString(value); err != nil {
+				return nil, fmt.Errorf("path %q: invalid number %q: %w", path, value, err)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("path %q: expected a number but got %T", path, v)
+	}
+	if m.Unit == toml.FeeAPIUnitGwei {
+		d = d.Shift(9)
+	}
+	if d.IsNegative() {
+		return nil, fmt.Errorf("path %q: negative price %s", path, d)
+	}
+	return assets.NewWei(d.BigInt()), nil
+}
+
+type FeeAPIEstimatorConfig struct {
+	URL        *url.URL
+	PollPeriod time.Duration
+	EIP1559    bool
+
+	// EIP1559FeeCapBufferBlocks is used to bump the fee cap of dynamic fees.
+	EIP1559FeeCapBufferBlocks uint16
+}
+
+// FeeAPIEstimator is an Estimator which polls an external HTTP fee API for gas prices.
+// Estimates are capped by PriceMax and bumped by at least BumpMin and BumpPercent.
+// Whenever the latest request to the fee API failed, estimates are delegated to the fallback estimator.
+type FeeAPIEstimator struct {
+	services.StateMachine
+
+	cfg        FeeAPIEstimatorConfig
+	bumpCfg    bumpConfig
+	mapper     FeeAPIResponseMapper
+	fallback   EvmEstimator
+	httpClient *http.Client
+	logger     logger.SugaredLogger
+	chainID    *big.Int
+	l1Oracle   rollups.L1Oracle
+
+	pricesMu sync.RWMutex
+	prices   *FeeAPIPrices
+	refreshTime
+
+	chInitialised chan struct{}
+	chStop        services.StopChan
+	chDone        chan struct{}
+}
+
+// NewFeeAPIEstimator returns a new Estimator which uses the gas prices of a fee API, and the fallback estimator while the fee API is unavailable.
+func NewFeeAPIEstimator(lggr logger.Logger, cfg FeeAPIEstimatorConfig, bumpCfg bumpConfig, mapper FeeAPIResponseMapper, fallback EvmEstimator, chainID *big.Int, l1Oracle rollups.L1Oracle) *FeeAPIEstimator {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	return &FeeAPIEstimator{
+		cfg:           cfg,
+		bumpCfg:       bumpCfg,
+		mapper:        mapper,
+		fallback:      fallback,
+		httpClient:    &http.Client{Transport: t, Timeout: client.QueryTimeout},
+		logger:        logger.Sugared(logger.Named(lggr, "FeeAPIEstimator")),
+		chainID:       chainID,
+		l1Oracle:      l1Oracle,
+		chInitialised: make(chan struct{}),
+		chStop:        make(chan struct{}),
+		chDone:        make(chan struct{}),
+	}
+}
+
+func (f *FeeAPIEstimator) Name() string {
+	return f.logger.Name()
+}
+
+func (f *FeeAPIEstimator) L1Oracle() rollups.L1Oracle {
+	return f.l1Oracle
+}
+
+func (f *FeeAPIEstimator) Start(ctx context.Context) error {
+	return f.StartOnce("FeeAPIEstimator", func() error {
+		if err := f.fallback.Start(ctx); err != nil {
+			return pkgerrors.Wrap(err, "failed to start fallback estimator")
+		}
+		go f.run()
+		<-f.chInitialised
+		return nil
+	})
+}
+
+func (f *FeeAPIEstimator) Close() error {
+	return f.StopOnce("FeeAPIEstimator", func() error {
+		close(f.chStop)
+		<-f.chDone
+		return f.fallback.Close()
+	})
+}
+
+func (f *FeeAPIEstimator) HealthReport() map[string]error {
+	report := map[string]error{f.Name(): f.Healthy()}
+	services.CopyHealth(report, f.fallback.HealthReport())
+	return report
+}
+
+func (f *FeeAPIEstimator) OnNewLongestChain(ctx context.Context, head *evmtypes.Head) {
+	f.fallback.OnNewLongestChain(ctx, head)
+}
+
+func (f *FeeAPIEstimator) run() {
+	defer close(f.chDone)
+
+	f.refreshPrices()
+	close(f.chInitialised)
+
+	t := services.TickerConfig{
+		Initial:   f.cfg.PollPeriod,
+		JitterPct: services.DefaultJitter,
+	}.NewTicker(f.cfg.PollPeriod)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-f.chStop:
+			return
+		case <-t.C:
+			f.refreshPrices()
+		}
+	}
+}
+
+func (f *FeeAPIEstimator) refreshPrices() {
+	ctx, cancel := f.chStop.CtxWithTimeout(client.QueryTimeout)
+	defer cancel()
+
+	prices, err := f.fetchPrices(ctx)
+	if err != nil {
+		promFeeAPIEstimatorErrors.WithLabelValues(f.chainID.String()).Inc()
+		f.logger.Warnw("Failed to refresh prices from the fee API, falling back to the BlockHistory estimator", "err", err)
+	} else {
+		f.logger.Debugw("refreshPrices", "GasPrice", prices.GasPrice, "GasFeeCap", prices.DynamicFee.GasFeeCap, "GasTipCap", prices.DynamicFee.GasTipCap)
+		if f.cfg.EIP1559 {
+			promFeeAPIEstimatorPrice.WithLabelValues(f.chainID.String(), "feeCap").Set(float64(prices.DynamicFee.GasFeeCap.Int64()))
+			promFeeAPIEstimatorPrice.WithLabelValues(f.chainID.String(), "tipCap").Set(float64(prices.DynamicFee.GasTipCap.Int64()))
+		} else {
+			promFeeAPIEstimatorPrice.WithLabelValues(f.chainID.String(), "gasPrice").Set(float64(prices.GasPrice.Int64()))
+		}
+		f.refreshed(time.Now())
+	}
+
+	f.pricesMu.Lock()
+	defer f.pricesMu.Unlock()
+	f.prices = prices
+}
+
+func (f *FeeAPIEstimator) fetchPrices(ctx context.Context) (*FeeAPIPrices, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.cfg.URL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make new request with context: %w", err)
+	}
+	req.Header.Add("Accept", "application/json")
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		// Omit the URL from the error, as it may contain an API key
+		var urlErr *url.Error
+		if pkgerrors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return nil, fmt.Errorf("request to fee API failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("request failed with status %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxFeeAPIResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	prices, err := f.mapper.MapResponse(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map response: %w", err)
+	}
+	if f.cfg.EIP1559 {
+		if prices.DynamicFee.GasFeeCap == nil || prices.DynamicFee.GasTipCap == nil {
+			return nil, pkgerrors.New("response is missing the dynamic fee")
+		}
+		if prices.DynamicFee.GasTipCap.Cmp(prices.DynamicFee.GasFeeCap) > 0 {
+			return nil, fmt.Errorf("tip cap %s is greater than fee cap %s", prices.DynamicFee.GasTipCap, prices.DynamicFee.GasFeeCap)
+		}
+	} else if prices.GasPrice == nil {
+		return nil, pkgerrors.New("response is missing the gas price")
+	}
+	return &prices, nil
+}
+
+// LastRefreshed returns when the fee API was last queried successfully, or when the fallback estimator
+// last refreshed its estimates while the fee API is unavailable.
+func (f *FeeAPIEstimator) LastRefreshed() time.Time {
+	if f.getPrices() == nil {
+		if fallback, ok := f.fallback.(refreshedEstimator); ok {
+			return fallback.LastRefreshed()
+		}
+	}
+	return f.refreshTime.LastRefreshed()
+}
+
+// getPrices returns the latest prices from the fee API, or nil if the latest request failed.
+func (f *FeeAPIEstimator) getPrices() *FeeAPIPrices {
+	f.pricesMu.RLock()
+	defer f.pricesMu.RUnlock()
+	return f.prices
+}
+
+func (f *FeeAPIEstimator) fallbackPrices(method string) *FeeAPIPrices {
+	prices := f.getPrices()
+	if prices == nil {
+		promFeeAPIEstimatorFallbacks.WithLabelValues(f.chainID.String(), method).Inc()
+		f.logger.Debugw("Fee API unavailable, using fallback estimator", "method", method)
+	}
+	return prices
+}
+
+func (f *FeeAPIEstimator) GetLegacyGas(ctx context.Context, calldata []byte, gasLimit uint64, maxGasPriceWei *assets.Wei, opts ...fees.Opt) (gasPrice *assets.Wei, chainSpecificGasLimit uint64, err error) {
+	if !f.IfStarted(func() {}) {
+		return nil, 0, pkgerrors.New("estimator is not started")
+	}
+	if slices.Contains(opts, fees.OptForceRefetch) {
+		f.refreshPrices()
+	}
+	prices := f.fallbackPrices("GetLegacyGas")
+	if prices == nil || prices.GasPrice == nil {
+		return f.fallback.GetLegacyGas(ctx, calldata, gasLimit, maxGasPriceWei, opts...)
+	}
+	gasPrice = capGasPrice(prices.GasPrice, maxGasPriceWei, f.bumpCfg.PriceMax())
+	f.logger.Debugw("GetLegacyGas", "GasPrice", gasPrice, "GasLimit", gasLimit)
+	return gasPrice, gasLimit, nil
+}
+
+func (f *FeeAPIEstimator) BumpLegacyGas(ctx context.Context, originalGasPrice *assets.Wei, gasLimit uint64, maxGasPriceWei *assets.Wei, attempts []EvmPriorAttempt) (bumpedGasPrice *assets.Wei, chainSpecificGasLimit uint64, err error) {
+	if !f.IfStarted(func() {}) {
+		return nil, 0, pkgerrors.New("estimator is not started")
+	}
+	prices := f.fallbackPrices("BumpLegacyGas")
+	if prices == nil || prices.GasPrice == nil {
+		return f.fallback.BumpLegacyGas(ctx, originalGasPrice, gasLimit, maxGasPriceWei, attempts)
+	}
+	bumpedGasPrice, err = BumpLegacyGasPriceOnly(f.bumpCfg, f.logger, capGasPrice(prices.GasPrice, maxGasPriceWei, f.bumpCfg.PriceMax()), originalGasPrice, maxGasPriceWei)
+	if err != nil {
+		return nil, 0, err
+	}
+	return bumpedGasPrice, gasLimit, nil
+}
+
+func (f *FeeAPIEstimator) GetDynamicFee(ctx context.Context, maxGasPriceWei *assets.Wei) (fee DynamicFee, err error) {
+	if !f.IfStarted(func() {}) {
+		return fee, pkgerrors.New("estimator is not started")
+	}
+	prices := f.fallbackPrices("GetDynamicFee")
+	if prices == nil || prices.DynamicFee.GasFeeCap == nil || prices.DynamicFee.GasTipCap == nil {
+		return f.fallback.GetDynamicFee(ctx, maxGasPriceWei)
+	}
+	feeCap := capGasPrice(prices.DynamicFee.GasFeeCap, maxGasPriceWei, f.bumpCfg.PriceMax())
+	fee = DynamicFee{
+		GasFeeCap: feeCap,
+		GasTipCap: assets.WeiMin(prices.DynamicFee.GasTipCap, feeCap),
+	}
+	f.logger.Debugw("GetDynamicFee", "GasFeeCap", fee.GasFeeCap, "GasTipCap", fee.GasTipCap)
+	return fee, nil
+}
+
+func (f *FeeAPIEstimator) BumpDynamicFee(ctx context.Context, originalFee DynamicFee, maxGasPriceWei *assets.Wei, attempts []EvmPriorAttempt) (bumped DynamicFee, err error) {
+	if !f.IfStarted(func() {}) {
+		return bumped, pkgerrors.New("estimator is not started")
+	}
+	prices := f.fallbackPrices("BumpDynamicFee")
+	if prices == nil || prices.DynamicFee.GasFeeCap == nil || prices.DynamicFee.GasTipCap == nil {
+		return f.fallback.BumpDynamicFee(ctx, originalFee, maxGasPriceWei, attempts)
+	}
+	maxGasPrice := getMaxGasPrice(maxGasPriceWei, f.bumpCfg.PriceMax())
+	currentFeeCap := assets.WeiMin(prices.DynamicFee.GasFeeCap, maxGasPrice)
+	currentTipCap := assets.WeiMin(prices.DynamicFee.GasTipCap, currentFeeCap)
+	bumped, err = BumpDynamicFeeOnly(f.bumpCfg, f.cfg.EIP1559FeeCapBufferBlocks, f.logger, currentTipCap, nil, originalFee, maxGasPriceWei)
+	if err != nil {
+		return bumped, err
+	}
+	// The fee API returns the fee cap rather than the base fee, which is used instead if it is greater than the bumped fee cap.
+	bumped.GasFeeCap = assets.WeiMax(bumped.GasFeeCap, currentFeeCap)
+	return bumped, nil
+}
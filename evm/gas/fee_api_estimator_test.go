@@ -0,0 +1,219 @@
+package gas_test
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/services/servicetest"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink-framework/chains/fees"
+
+	"github.com/smartcontractkit/chainlink/v2/evm/assets"
+	"github.com/smartcontractkit/chainlink/v2/evm/config/toml"
+	"github.com/smartcontractkit/chainlink/v2/evm/gas"
+	"github.com/smartcontractkit/chainlink/v2/evm/gas/mocks"
+)
+
+func TestJSONPathMapper(t *testing.T) {
+	t.Parallel()
+
+	body := []byte(`{"data": {"fast": {"maxFeePerGas": 1.5, "maxPriorityFeePerGas": "0.1"}, "legacy": ["0x3b9aca00", "-1", true]}}`)
+
+	prices, err := gas.JSONPathMapper{
+		GasPricePath:  "data.legacy.0",
+		GasFeeCapPath: "data.fast.maxFeePerGas",
+		GasTipCapPath: "data.fast.maxPriorityFeePerGas",
+		Unit:          toml.FeeAPIUnitGwei,
+	}.MapResponse(body)
+	require.NoError(t, err)
+	assert.Equal(t, assets.GWei(1_000_000_000), prices.GasPrice)
+	assert.Equal(t, assets.NewWeiI(1_500_000_000), prices.DynamicFee.GasFeeCap)
+	assert.Equal(t, assets.NewWeiI(100_000_000), prices.DynamicFee.GasTipCap)
+
+	prices, err = gas.JSONPathMapper{GasPricePath: "data.legacy.0", Unit: toml.FeeAPIUnitWei}.MapResponse(body)
+	require.NoError(t, err)
+	assert.Equal(t, assets.GWei(1), prices.GasPrice)
+	assert.Nil(t, prices.DynamicFee.GasFeeCap)
+	assert.Nil(t, prices.DynamicFee.GasTipCap)
+
+	for path, errMsg := range map[string]string{
+		"data.slow":                  `key "slow" not found`,
+		"data.legacy.3":              `invalid index "3"`,
+		"data.legacy.1":              "negative price",
+		"data.legacy.2":              "expected a number but got bool",
+		"data.fast.maxFeePerGas.gas": `cannot select "gas"`,
+	} {
+		_, err = gas.JSONPathMapper{GasPricePath: path}.MapResponse(body)
+		assert.ErrorContains(t, err, errMsg, path)
+	}
+
+	_, err = gas.JSONPathMapper{GasPricePath: "gasPrice"}.MapResponse([]byte("<html>"))
+	assert.ErrorContains(t, err, "failed to decode json response")
+}
+
+type feeAPIServer struct {
+	*httptest.Server
+	body atomic.Pointer[string]
+}
+
+// newFeeAPIServer returns a fee API serving the given body, or an internal server error if the body is empty.
+func newFeeAPIServer(t *testing.T, body string) *feeAPIServer {
+	s := &feeAPIServer{}
+	s.body.Store(&body)
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		body := *s.body.Load()
+		if body == "" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func newFeeAPIEstimator(t *testing.T, server *feeAPIServer, eip1559 bool, bumpCfg *gas.MockGasEstimatorConfig) (*gas.FeeAPIEstimator, *mocks.EvmEstimator) {
+	fallback := mocks.NewEvmEstimator(t)
+	fallback.On("Start", mock.Anything).Return(nil)
+	fallback.On("Close").Return(nil)
+	fallback.On("HealthReport").Return(map[string]error{}).Maybe()
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	mapper := gas.JSONPathMapper{GasPricePath: "gasPrice", Unit: toml.FeeAPIUnitGwei}
+	if eip1559 {
+		mapper = gas.JSONPathMapper{GasFeeCapPath: "maxFeePerGas", GasTipCapPath: "maxPriorityFeePerGas", Unit: toml.FeeAPIUnitGwei}
+	}
+	e := gas.NewFeeAPIEstimator(logger.Test(t), gas.FeeAPIEstimatorConfig{URL: u, PollPeriod: time.Hour, EIP1559: eip1559}, bumpCfg, mapper, fallback, big.NewInt(1), nil)
+	servicetest.Run(t, e)
+	return e, fallback
+}
+
+func TestFeeAPIEstimator_Legacy(t *testing.T) {
+	t.Parallel()
+
+	bumpCfg := &gas.MockGasEstimatorConfig{PriceMaxF: assets.GWei(100), BumpMinF: assets.GWei(5), BumpPercentF: 10}
+	server := newFeeAPIServer(t, `{"gasPrice": 50}`)
+	e, _ := newFeeAPIEstimator(t, server, false, bumpCfg)
+	ctx := tests.Context(t)
+
+	t.Run("returns the fee API price capped by the max gas price", func(t *testing.T) {
+		gasPrice, gasLimit, err := e.GetLegacyGas(ctx, nil, 21_000, assets.GWei(100))
+		require.NoError(t, err)
+		assert.Equal(t, assets.GWei(50), gasPrice)
+		assert.Equal(t, uint64(21_000), gasLimit)
+
+		gasPrice, _, err = e.GetLegacyGas(ctx, nil, 21_000, assets.GWei(30))
+		require.NoError(t, err)
+		assert.Equal(t, assets.GWei(30), gasPrice)
+	})
+
+	t.Run("caps the fee API price by PriceMax", func(t *testing.T) {
+		e, _ := newFeeAPIEstimator(t, server, false, &gas.MockGasEstimatorConfig{PriceMaxF: assets.GWei(40)})
+		gasPrice, _, err := e.GetLegacyGas(ctx, nil, 21_000, assets.GWei(100))
+		require.NoError(t, err)
+		assert.Equal(t, assets.GWei(40), gasPrice)
+	})
+
+	t.Run("bumps by at least BumpMin and up to the fee API price", func(t *testing.T) {
+		gasPrice, gasLimit, err := e.BumpLegacyGas(ctx, assets.GWei(48), 21_000, assets.GWei(100), nil)
+		require.NoError(t, err)
+		assert.Equal(t, assets.GWei(53), gasPrice)
+		assert.Equal(t, uint64(21_000), gasLimit)
+
+		gasPrice, _, err = e.BumpLegacyGas(ctx, assets.GWei(20), 21_000, assets.GWei(100), nil)
+		require.NoError(t, err)
+		assert.Equal(t, assets.GWei(50), gasPrice)
+
+		_, _, err = e.BumpLegacyGas(ctx, assets.GWei(98), 21_000, assets.GWei(100), nil)
+		require.ErrorIs(t, err, fees.ErrBumpFeeExceedsLimit)
+	})
+}
+
+func TestFeeAPIEstimator_Dynamic(t *testing.T) {
+	t.Parallel()
+
+	bumpCfg := &gas.MockGasEstimatorConfig{PriceMaxF: assets.GWei(100), BumpMinF: assets.GWei(5), BumpPercentF: 10, TipCapDefaultF: assets.GWei(1)}
+	server := newFeeAPIServer(t, `{"maxFeePerGas": "60", "maxPriorityFeePerGas": "2"}`)
+	e, _ := newFeeAPIEstimator(t, server, true, bumpCfg)
+	ctx := tests.Context(t)
+
+	fee, err := e.GetDynamicFee(ctx, assets.GWei(100))
+	require.NoError(t, err)
+	assert.Equal(t, gas.DynamicFee{GasFeeCap: assets.GWei(60), GasTipCap: assets.GWei(2)}, fee)
+
+	fee, err = e.GetDynamicFee(ctx, assets.GWei(50))
+	require.NoError(t, err)
+	assert.Equal(t, gas.DynamicFee{GasFeeCap: assets.GWei(50), GasTipCap: assets.GWei(2)}, fee)
+
+	bumped, err := e.BumpDynamicFee(ctx, gas.DynamicFee{GasFeeCap: assets.GWei(40), GasTipCap: assets.GWei(1)}, assets.GWei(100), nil)
+	require.NoError(t, err)
+	assert.Equal(t, gas.DynamicFee{GasFeeCap: assets.GWei(60), GasTipCap: assets.GWei(6)}, bumped)
+
+	bumped, err = e.BumpDynamicFee(ctx, gas.DynamicFee{GasFeeCap: assets.GWei(80), GasTipCap: assets.GWei(10)}, assets.GWei(100), nil)
+	require.NoError(t, err)
+	assert.Equal(t, gas.DynamicFee{GasFeeCap: assets.GWei(88), GasTipCap: assets.GWei(15)}, bumped)
+}
+
+func TestFeeAPIEstimator_Fallback(t *testing.T) {
+	t.Parallel()
+
+	bumpCfg := &gas.MockGasEstimatorConfig{PriceMaxF: assets.GWei(100), BumpMinF: assets.GWei(5), BumpPercentF: 10}
+	for name, body := range map[string]string{
+		"server error":     "",
+		"invalid json":     "<html>",
+		"missing price":    `{"fast": 10}`,
+		"invalid price":    `{"gasPrice": "fast"}`,
+		"tip cap too high": `{"maxFeePerGas": 1, "maxPriorityFeePerGas": 2}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			server := newFeeAPIServer(t, body)
+			e, fallback := newFeeAPIEstimator(t, server, name == "tip cap too high", bumpCfg)
+			ctx := tests.Context(t)
+
+			fallback.On("GetLegacyGas", mock.Anything, mock.Anything, uint64(21_000), assets.GWei(100)).Return(assets.GWei(7), uint64(21_000), nil).Once()
+			gasPrice, _, err := e.GetLegacyGas(ctx, nil, 21_000, assets.GWei(100))
+			require.NoError(t, err)
+			assert.Equal(t, assets.GWei(7), gasPrice)
+
+			fallback.On("BumpLegacyGas", mock.Anything, assets.GWei(7), uint64(21_000), assets.GWei(100), mock.Anything).Return(assets.GWei(12), uint64(21_000), nil).Once()
+			gasPrice, _, err = e.BumpLegacyGas(ctx, assets.GWei(7), 21_000, assets.GWei(100), nil)
+			require.NoError(t, err)
+			assert.Equal(t, assets.GWei(12), gasPrice)
+
+			fallback.On("GetDynamicFee", mock.Anything, assets.GWei(100)).Return(gas.DynamicFee{GasFeeCap: assets.GWei(9), GasTipCap: assets.GWei(1)}, nil).Once()
+			fee, err := e.GetDynamicFee(ctx, assets.GWei(100))
+			require.NoError(t, err)
+			assert.Equal(t, gas.DynamicFee{GasFeeCap: assets.GWei(9), GasTipCap: assets.GWei(1)}, fee)
+		})
+	}
+
+	t.Run("recovers once the fee API is available", func(t *testing.T) {
+		server := newFeeAPIServer(t, "")
+		e, fallback := newFeeAPIEstimator(t, server, false, bumpCfg)
+		ctx := tests.Context(t)
+
+		fallback.On("GetLegacyGas", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(assets.GWei(7), uint64(21_000), nil).Once()
+		gasPrice, _, err := e.GetLegacyGas(ctx, nil, 21_000, assets.GWei(100))
+		require.NoError(t, err)
+		assert.Equal(t, assets.GWei(7), gasPrice)
+
+		body := fmt.Sprintf(`{"gasPrice": %d}`, 30)
+		server.body.Store(&body)
+		gasPrice, _, err = e.GetLegacyGas(ctx, nil, 21_000, assets.GWei(100), fees.OptForceRefetch)
+		require.NoError(t, err)
+		assert.Equal(t, assets.GWei(30), gasPrice)
+	})
+}
@@ -88,6 +88,8 @@ type FeeHistoryEstimator struct {
 	priorityFeeThresholdMu sync.RWMutex
 	priorityFeeThreshold   *assets.Wei
 
+	refreshTime
+
 	l1Oracle rollups.L1Oracle
 
 	wg        *sync.WaitGroup
@@ -193,6 +195,7 @@ func (f *FeeHistoryEstimator) RefreshGasPrice() (*assets.Wei, error) {
 	f.gasPriceMu.Lock()
 	defer f.gasPriceMu.Unlock()
 	f.gasPrice = gasPriceWei
+	f.refreshed(time.Now())
 	return f.gasPrice, nil
 }
 
@@ -295,6 +298,7 @@ func (f *FeeHistoryEstimator) RefreshDynamicPrice() error {
 	defer f.dynamicPriceMu.Unlock()
 	f.dynamicPrice.GasFeeCap = maxFeePerGas
 	f.dynamicPrice.GasTipCap = maxPriorityFeePerGas
+	f.refreshed(time.Now())
 	return nil
 }
 
@@ -197,3 +197,7 @@ func (m *MockGasEstimatorConfig) Mode() string {
 func (m *MockGasEstimatorConfig) EstimateLimit() bool {
 	return m.EstimateLimitF
 }
+
+func (e *CompositeEstimator) SetNow(now func() time.Time) {
+	e.now = now
+}
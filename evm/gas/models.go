@@ -7,6 +7,7 @@ import (
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rpc"
 	pkgerrors "github.com/pkg/errors"
 
@@ -38,7 +39,8 @@ type EvmFeeEstimator interface {
 	GetFee(ctx context.Context, calldata []byte, feeLimit uint64, maxFeePrice *assets.Wei, fromAddress, toAddress *common.Address, opts ...fees.Opt) (fee EvmFee, estimatedFeeLimit uint64, err error)
 	BumpFee(ctx context.Context, originalFee EvmFee, feeLimit uint64, maxFeePrice *assets.Wei, attempts []EvmPriorAttempt) (bumpedFee EvmFee, chainSpecificFeeLimit uint64, err error)
 
-	// GetMaxCost returns the total value = max price x fee units + transferred value
+	// GetMaxCost returns the total value = max price x fee units + transferred value,
+	// plus the L1 data fee on rollups whose L1 oracle estimates it (rollups.L1FeeOracle)
 	GetMaxCost(ctx context.Context, amount assets.Eth, calldata []byte, feeLimit uint64, maxFeePrice *assets.Wei, fromAddress, toAddress *common.Address, opts ...fees.Opt) (*big.Int, error)
 }
 
@@ -87,7 +89,22 @@ func NewEstimator(lggr logger.Logger, ethClient feeEstimatorClient, chaintype ch
 	}
 
 	var newEstimator func(logger.Logger) EvmEstimator
-	switch s {
+	if s == "Composite" {
+		newEstimator, err = newCompositeEstimator(lggr, ethClient, chaintype, chainID, geCfg, l1Oracle)
+	} else {
+		newEstimator, err = newEvmEstimator(lggr, s, ethClient, chaintype, chainID, geCfg, l1Oracle)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return NewEvmFeeEstimator(lggr, newEstimator, df, geCfg, ethClient), nil
+}
+
+// newEvmEstimator returns a constructor of the estimator for a given mode
+func newEvmEstimator(lggr logger.Logger, mode string, ethClient feeEstimatorClient, chaintype chaintype.ChainType, chainID *big.Int, geCfg evmconfig.GasEstimator, l1Oracle rollups.L1Oracle) (func(logger.Logger) EvmEstimator, error) {
+	bh := geCfg.BlockHistory()
+	var newEstimator func(logger.Logger) EvmEstimator
+	switch mode {
 	case "Arbitrum":
 		arbOracle, err := rollups.NewArbitrumL1GasOracle(lggr, ethClient)
 		if err != nil {
@@ -119,14 +136,64 @@ func NewEstimator(lggr logger.Logger, ethClient feeEstimatorClient, chaintype ch
 			}
 			return NewFeeHistoryEstimator(lggr, ethClient, ccfg, chainID, l1Oracle)
 		}
+	case "FeeAPI":
+		feeAPI := geCfg.FeeAPI()
+		newEstimator = func(l logger.Logger) EvmEstimator {
+			ccfg := FeeAPIEstimatorConfig{
+				URL:                       feeAPI.URL(),
+				PollPeriod:                feeAPI.PollPeriod(),
+				EIP1559:                   geCfg.EIP1559DynamicFees(),
+				EIP1559FeeCapBufferBlocks: bh.EIP1559FeeCapBufferBlocks(),
+			}
+			mapper := JSONPathMapper{
+				GasPricePath:  feeAPI.GasPricePath(),
+				GasFeeCapPath: feeAPI.GasFeeCapPath(),
+				GasTipCapPath: feeAPI.GasTipCapPath(),
+				Unit:          feeAPI.Unit(),
+			}
+			fallback := NewBlockHistoryEstimator(lggr, ethClient, chaintype, geCfg, bh, chainID, l1Oracle)
+			return NewFeeAPIEstimator(lggr, ccfg, geCfg, mapper, fallback, chainID, l1Oracle)
+		}
 
 	default:
-		lggr.Warnf("GasEstimator: unrecognised mode '%s', falling back to FixedPriceEstimator", s)
+		lggr.Warnf("GasEstimator: unrecognised mode '%s', falling back to FixedPriceEstimator", mode)
 		newEstimator = func(l logger.Logger) EvmEstimator {
 			return NewFixedPriceEstimator(geCfg, ethClient, bh, lggr, l1Oracle)
 		}
 	}
-	return NewEvmFeeEstimator(lggr, newEstimator, df, geCfg, ethClient), nil
+	return newEstimator, nil
+}
+
+// newCompositeEstimator returns a constructor of a CompositeEstimator combining the configured estimators
+func newCompositeEstimator(lggr logger.Logger, ethClient feeEstimatorClient, chaintype chaintype.ChainType, chainID *big.Int, geCfg evmconfig.GasEstimator, l1Oracle rollups.L1Oracle) (func(logger.Logger) EvmEstimator, error) {
+	composite := geCfg.Composite()
+	modes := composite.Estimators()
+	if len(modes) == 0 {
+		return nil, pkgerrors.New("composite gas estimator requires at least one estimator")
+	}
+	newSources := make([]func(logger.Logger) EvmEstimator, len(modes))
+	for i, mode := range modes {
+		if mode == "Composite" {
+			return nil, pkgerrors.New("composite gas estimator cannot include itself")
+		}
+		newSource, err := newEvmEstimator(lggr, mode, ethClient, chaintype, chainID, geCfg, l1Oracle)
+		if err != nil {
+			return nil, err
+		}
+		newSources[i] = newSource
+	}
+	lggr.Infow("Initializing composite EVM gas estimator", "estimators", modes, "policy", composite.Policy(), "maxStaleness", composite.MaxStaleness())
+
+	return func(l logger.Logger) EvmEstimator {
+		sources := make([]CompositeSource, len(modes))
+		for i, mode := range modes {
+			sources[i] = CompositeSource{Name: mode, Estimator: newSources[i](l)}
+		}
+		return NewCompositeEstimator(lggr, CompositeEstimatorConfig{
+			Policy:       composite.Policy(),
+			MaxStaleness: composite.MaxStaleness(),
+		}, chainID, sources)
+	}, nil
 }
 
 // DynamicFee encompasses both FeeCap and TipCap for EIP1559 transactions
@@ -316,9 +383,51 @@ func (e *evmFeeEstimator) GetMaxCost(ctx context.Context, amount assets.Eth, cal
 
 	fee := new(big.Int).Mul(gasPrice.ToInt(), big.NewInt(int64(gasLimit)))
 	amountWithFees := new(big.Int).Add(amount.ToInt(), fee)
+
+	l1Fee, err := e.getL1Fee(ctx, amount, calldata, gasLimit, fees, toAddress)
+	if err != nil {
+		// the L1 oracle has no fresh fee parameters, e.g. it is not started yet or failed to refresh them
+		e.lggr.Warnw("Failed to estimate L1 data fee, max cost only includes the L2 fee", "err", err)
+		return amountWithFees, nil
+	}
+	if l1Fee != nil {
+		amountWithFees.Add(amountWithFees, l1Fee.ToInt())
+	}
 	return amountWithFees, nil
 }
 
+// getL1Fee returns the L1 data fee of the transaction if the L1 oracle of the chain estimates it, e.g. on OP Stack chains, or nil otherwise.
+func (e *evmFeeEstimator) getL1Fee(ctx context.Context, amount assets.Eth, calldata []byte, gasLimit uint64, fee EvmFee, toAddress *common.Address) (*assets.Wei, error) {
+	oracle, ok := e.L1Oracle().(rollups.L1FeeOracle)
+	if !ok {
+		return nil, nil
+	}
+	var tx *gethtypes.Transaction
+	if e.EIP1559Enabled {
+		tx = gethtypes.NewTx(&gethtypes.DynamicFeeTx{
+			GasTipCap: fee.GasTipCap.ToInt(),
+			GasFeeCap: fee.GasFeeCap.ToInt(),
+			Gas:       gasLimit,
+			To:        toAddress,
+			Value:     amount.ToInt(),
+			Data:      calldata,
+		})
+	} else {
+		tx = gethtypes.NewTx(&gethtypes.LegacyTx{
+			GasPrice: fee.GasPrice.ToInt(),
+			Gas:      gasLimit,
+			To:       toAddress,
+			Value:    amount.ToInt(),
+			Data:     calldata,
+		})
+	}
+	txData, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return oracle.GetL1Fee(ctx, txData)
+}
+
 func (e *evmFeeEstimator) BumpFee(ctx context.Context, originalFee EvmFee, feeLimit uint64, maxFeePrice *assets.Wei, attempts []EvmPriorAttempt) (bumpedFee EvmFee, chainSpecificFeeLimit uint64, err error) {
 	// validate only 1 fee type is present
 	if (!originalFee.ValidDynamic() && originalFee.GasPrice == nil) || (originalFee.ValidDynamic() && originalFee.GasPrice != nil) {
@@ -1,10 +1,12 @@
 package gas_test
 
 import (
+	"context"
 	"errors"
 	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/core/types"
 	pkgerrors "github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -146,6 +148,7 @@ func TestWrappedEvmEstimator(t *testing.T) {
 	t.Run("GetMaxCost", func(t *testing.T) {
 		lggr := logger.Test(t)
 		val := assets.NewEthValue(1)
+		est.On("L1Oracle").Return(nil).Twice()
 
 		// expect legacy fee data
 		dynamicFees := false
@@ -166,6 +169,42 @@ func TestWrappedEvmEstimator(t *testing.T) {
 		assert.Equal(t, new(big.Int).Add(val.ToInt(), fee), total)
 	})
 
+	t.Run("GetMaxCost includes the L1 data fee", func(t *testing.T) {
+		lggr := logger.Test(t)
+		val := assets.NewEthValue(1)
+		to := testutils.NewAddress()
+		calldata := []byte{0x01, 0x02, 0x03}
+
+		oracle := &l1FeeOracle{L1Oracle: rollupMocks.NewL1Oracle(t), fee: assets.NewWeiI(1_000)}
+		evmEstimator := mocks.NewEvmEstimator(t)
+		evmEstimator.On("L1Oracle").Return(oracle)
+		evmEstimator.On("GetDynamicFee", mock.Anything, mock.Anything).Return(dynamicFee, nil).Once()
+
+		estimator := gas.NewEvmFeeEstimator(lggr, func(logger.Logger) gas.EvmEstimator { return evmEstimator }, true, geCfg, nil)
+		total, err := estimator.GetMaxCost(ctx, val, calldata, gasLimit, nil, nil, &to)
+		require.NoError(t, err)
+		fee := new(big.Int).Mul(dynamicFee.GasFeeCap.ToInt(), big.NewInt(10))
+		fee, _ = new(big.Float).Mul(new(big.Float).SetInt(fee), big.NewFloat(float64(limitMultiplier))).Int(nil)
+		fee.Add(fee, oracle.fee.ToInt())
+		assert.Equal(t, new(big.Int).Add(val.ToInt(), fee), total)
+
+		// the oracle estimates the fee of the unsigned transaction
+		tx := new(types.Transaction)
+		require.NoError(t, tx.UnmarshalBinary(oracle.txData))
+		assert.Equal(t, uint8(types.DynamicFeeTxType), tx.Type())
+		assert.Equal(t, calldata, tx.Data())
+		assert.Equal(t, &to, tx.To())
+		assert.Equal(t, val.ToInt(), tx.Value())
+
+		// falls back to the L2 fee if the oracle has no fresh fee parameters
+		oracle.err = errors.New("stale")
+		evmEstimator.On("GetDynamicFee", mock.Anything, mock.Anything).Return(dynamicFee, nil).Once()
+		total, err = estimator.GetMaxCost(ctx, val, calldata, gasLimit, nil, nil, &to)
+		require.NoError(t, err)
+		fee.Sub(fee, oracle.fee.ToInt())
+		assert.Equal(t, new(big.Int).Add(val.ToInt(), fee), total)
+	})
+
 	t.Run("Name", func(t *testing.T) {
 		lggr := logger.Test(t)
 
@@ -408,3 +447,16 @@ func TestWrappedEvmEstimator(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+// l1FeeOracle is an L1 oracle which estimates the L1 data fee of transactions
+type l1FeeOracle struct {
+	*rollupMocks.L1Oracle
+	fee    *assets.Wei
+	err    error
+	txData []byte
+}
+
+func (o *l1FeeOracle) GetL1Fee(_ context.Context, txData []byte) (*assets.Wei, error) {
+	o.txData = txData
+	return o.fee, o.err
+}
@@ -28,6 +28,14 @@ type L1Oracle interface {
 	GasPrice(ctx context.Context) (*assets.Wei, error)
 }
 
+// L1FeeOracle is implemented by L1 oracles which estimate the L1 data fee of a transaction.
+// For example, after the Ecotone upgrade the L1 data fee of OP Stack chains depends on both the l1BaseFee and the blobBaseFee,
+// and after the Fjord upgrade on the compressed size of the transaction, so it cannot be derived from the L1 gas price alone.
+type L1FeeOracle interface {
+	// GetL1Fee returns the L1 data fee of the RLP encoded, unsigned transaction
+	GetL1Fee(ctx context.Context, txData []byte) (*assets.Wei, error)
+}
+
 type l1OracleClient interface {
 	CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
 	BatchCallContext(ctx context.Context, b []rpc.BatchElem) error
@@ -0,0 +1,179 @@
+package rollups
+
+import (
+	"math/big"
+)
+
+// L1 fee constants of the OP Stack GasPriceOracle contract
+// https://github.com/ethereum-optimism/optimism/blob/71b93116738ee98c9f8713b1a5dfe626ce06c1b2/packages/contracts-bedrock/src/L2/GasPriceOracle.sol
+const (
+	// opTxSignatureSize is the size added to the unsigned transaction for its signature
+	opTxSignatureSize = 68
+	// opFjordCostIntercept is the intercept of the Fjord linear regression, scaled by 1e6
+	opFjordCostIntercept = -42_585_600
+	// opFjordCostFastLZCoef is the FastLZ coefficient of the Fjord linear regression, scaled by 1e6
+	opFjordCostFastLZCoef = 836_500
+	// opFjordMinTransactionSize is the minimum estimated size of a transaction
+	opFjordMinTransactionSize = 100
+)
+
+// opStackFeeParams are the fee parameters of the GasPriceOracle contract
+type opStackFeeParams struct {
+	l1BaseFee         *big.Int
+	baseFeeScalar     *big.Int
+	blobBaseFee       *big.Int
+	blobBaseFeeScalar *big.Int
+	decimals          *big.Int
+	isFjord           bool
+}
+
+// opStackV1FeeParams returns the fee parameters before the Ecotone upgrade, which price the calldata gas at the l1BaseFee
+// with a base fee scalar of 1. The overhead and scalar of the contract are left out of the estimate.
+func opStackV1FeeParams(l1BaseFee *big.Int) *opStackFeeParams {
+	return &opStackFeeParams{
+		l1BaseFee:         l1BaseFee,
+		baseFeeScalar:     big.NewInt(1e6),
+		blobBaseFee:       big.NewInt(0),
+		blobBaseFeeScalar: big.NewInt(0),
+		decimals:          big.NewInt(6),
+	}
+}
+
+// scaledFee returns baseFeeScalar * 16 * l1BaseFee + blobBaseFeeScalar * blobBaseFee
+func (p *opStackFeeParams) scaledFee() *big.Int {
+	scaledBaseFee := new(big.Int).Mul(p.l1BaseFee, p.baseFeeScalar)
+	scaledBaseFee.Mul(scaledBaseFee, big.NewInt(16))
+	scaledBlobBaseFee := new(big.Int).Mul(p.blobBaseFee, p.blobBaseFeeScalar)
+	return scaledBaseFee.Add(scaledBaseFee, scaledBlobBaseFee)
+}
+
+// gasPrice returns the weighted L1 gas price, scaled down by (16 * 10 ^ decimals)
+// This formula is extracted from the gas cost methods in the precompile contract
+// Note: The Fjord calculation in the contract uses estimated size instead of gas used which is why we have to scale down by (16 * 10 ^ decimals) as well
+// Ecotone: https://github.com/ethereum-optimism/optimism/blob/71b93116738ee98c9f8713b1a5dfe626ce06c1b2/packages/contracts-bedrock/src/L2/GasPriceOracle.sol#L192
+// Fjord: https://github.com/ethereum-optimism/optimism/blob/71b93116738ee98c9f8713b1a5dfe626ce06c1b2/packages/contracts-bedrock/src/L2/GasPriceOracle.sol#L229-L230
+func (p *opStackFeeParams) gasPrice() *big.Int {
+	scale := new(big.Int).Exp(big.NewInt(10), p.decimals, nil)
+	scale.Mul(scale, big.NewInt(16))
+	return new(big.Int).Div(p.scaledFee(), scale)
+}
+
+// l1Fee returns the L1 data fee of the RLP encoded, unsigned transaction, like the getL1Fee method of the contract.
+func (p *opStackFeeParams) l1Fee(txData []byte) *big.Int {
+	if p.isFjord {
+		// fee = estimatedSize * scaledFee / 10 ^ (2 * decimals), where estimatedSize is scaled by 1e6 like the scalars
+		estimatedSize := opFjordEstimatedSize(uint64(flzCompressLen(txData)) + opTxSignatureSize)
+		fee := new(big.Int).Mul(estimatedSize, p.scaledFee())
+		scale := new(big.Int).Exp(big.NewInt(10), new(big.Int).Mul(p.decimals, big.NewInt(2)), nil)
+		return fee.Div(fee, scale)
+	}
+	// fee = l1GasUsed * scaledFee / (16 * 10 ^ decimals)
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(opCalldataGas(txData)), p.scaledFee())
+	scale := new(big.Int).Exp(big.NewInt(10), p.decimals, nil)
+	scale.Mul(scale, big.NewInt(16))
+	return fee.Div(fee, scale)
+}
+
+// opCalldataGas returns the L1 gas used by the transaction data before Fjord, including its signature.
+func opCalldataGas(txData []byte) uint64 {
+	var total uint64
+	for _, b := range txData {
+		if b == 0 {
+			total += 4
+		} else {
+			total += 16
+		}
+	}
+	return total + opTxSignatureSize*16
+}
+
+// opFjordEstimatedSize estimates the Brotli compressed size of a transaction, scaled by 1e6,
+// from its FastLZ compressed size with the linear regression of the Fjord upgrade.
+func opFjordEstimatedSize(fastLzSize uint64) *big.Int {
+	estimatedSize := new(big.Int).SetUint64(fastLzSize)
+	estimatedSize.Mul(estimatedSize, big.NewInt(opFjordCostFastLZCoef))
+	estimatedSize.Add(estimatedSize, big.NewInt(opFjordCostIntercept))
+	if minSize := big.NewInt(opFjordMinTransactionSize * 1e6); estimatedSize.Cmp(minSize) < 0 {
+		return minSize
+	}
+	return estimatedSize
+}
+
+// flzCompressLen returns the length of the data compressed with FastLZ, as implemented by the
+// flzCompress method of Solady's LibZip which is used by the GasPriceOracle contract after Fjord.
+// https://github.com/Vectorized/solady/blob/5315d937d79b335c668896d7533ac603adac5315/src/utils/LibZip.sol
+func flzCompressLen(ib []byte) uint32 {
+	n := uint32(0)
+	ht := make([]uint32, 8192)
+	u24 := func(i uint32) uint32 {
+		return uint32(ib[i]) | (uint32(ib[i+1]) << 8) | (uint32(ib[i+2]) << 16)
+	}
+	cmp := func(p uint32, q uint32, e uint32) uint32 {
+		l := uint32(0)
+		for e -= q; l < e; l++ {
+			if ib[p+l] != ib[q+l] {
+				e = 0
+			}
+		}
+		return l
+	}
+	literals := func(r uint32) {
+		n += 0x21 * (r / 0x20)
+		r %= 0x20
+		if r != 0 {
+			n += r + 1
+		}
+	}
+	match := func(l uint32) {
+		l--
+		n += 3 * (l / 262)
+		if l%262 >= 6 {
+			n += 3
+		} else {
+			n += 2
+		}
+	}
+	hash := func(v uint32) uint32 {
+		return ((2654435769 * v) >> 19) & 0x1fff
+	}
+	setNextHash := func(ip uint32) uint32 {
+		ht[hash(u24(ip))] = ip
+		return ip + 1
+	}
+
+	a := uint32(0)
+	ipLimit := uint32(0)
+	if len(ib) > 13 {
+		ipLimit = uint32(len(ib)) - 13
+	}
+	for ip := a + 2; ip < ipLimit; {
+		var r, d uint32
+		for {
+			s := u24(ip)
+			h := hash(s)
+			r = ht[h]
+			ht[h] = ip
+			d = ip - r
+			if ip >= ipLimit {
+				break
+			}
+			ip++
+			if d <= 0x1fff && s == u24(r) {
+				break
+			}
+		}
+		if ip >= ipLimit {
+			break
+		}
+		ip--
+		if ip > a {
+			literals(ip - a)
+		}
+		l := cmp(r+3, ip+3, ipLimit+9)
+		match(l)
+		ip = setNextHash(setNextHash(ip + l))
+		a = ip
+	}
+	literals(uint32(len(ib)) - a)
+	return n
+}
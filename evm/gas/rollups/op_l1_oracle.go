@@ -37,6 +37,7 @@ type optimismL1Oracle struct {
 	daOracleAddress common.Address
 	l1GasPriceMu    sync.RWMutex
 	l1GasPrice      priceEntry
+	l1FeeParams     *opStackFeeParams
 	isEcotone       bool
 	isFjord         bool
 	upgradeCheckTs  time.Time
@@ -45,7 +46,6 @@ type optimismL1Oracle struct {
 	chStop        services.StopChan
 	chDone        chan struct{}
 
-	getL1FeeMethodAbi         abi.ABI
 	l1BaseFeeCalldata         []byte
 	baseFeeScalarCalldata     []byte
 	blobBaseFeeCalldata       []byte
@@ -58,6 +58,8 @@ type optimismL1Oracle struct {
 	isFjordMethodAbi          abi.ABI
 }
 
+var _ L1FeeOracle = &optimismL1Oracle{}
+
 const (
 	// upgradePollingPeriod is the interval to poll if chain has been upgraded
 	upgradePollingPeriod = 4 * time.Hour
@@ -65,10 +67,6 @@ const (
 	isEcotoneMethod = "isEcotone"
 	// isFjord fetches if the OP Stack GasPriceOracle contract has upgraded to Fjord
 	isFjordMethod = "isFjord"
-	// getL1Fee fetches the l1 fee for given tx bytes
-	// getL1Fee is a hex encoded call to:
-	// `function getL1Fee(bytes) external view returns (uint256);`
-	getL1FeeMethod = "getL1Fee"
 	// l1BaseFee fetches the l1 base fee set in the OP Stack GasPriceOracle contract
 	// l1BaseFee is a hex encoded call to:
 	// `function l1BaseFee() external view returns (uint256);`
@@ -106,11 +104,6 @@ func NewOpStackL1GasOracle(lggr logger.Logger, ethClient l1OracleClient, chainTy
 	}
 	oracleAddress := *daOracle.OracleAddress()
 
-	getL1FeeMethodAbi, err := abi.JSON(strings.NewReader(GetL1FeeAbiString))
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse L1 gas cost method ABI for chain: %s", chainType)
-	}
-
 	// encode calldata for each method; these calldata will remain the same for each call, we can encode them just once
 	// Encode calldata for l1BaseFee method
 	l1BaseFeeCalldata, _, err := encodeCalldata(L1BaseFeeAbiString, l1BaseFeeMethod)
@@ -168,7 +161,6 @@ func NewOpStackL1GasOracle(lggr logger.Logger, ethClient l1OracleClient, chainTy
 		chStop:        make(chan struct{}),
 		chDone:        make(chan struct{}),
 
-		getL1FeeMethodAbi:         getL1FeeMethodAbi,
 		l1BaseFeeCalldata:         l1BaseFeeCalldata,
 		baseFeeScalarCalldata:     baseFeeScalarCalldata,
 		blobBaseFeeCalldata:       blobBaseFeeCalldata,
@@ -238,7 +230,7 @@ func (o *optimismL1Oracle) refreshWithError() error {
 	ctx, cancel := o.chStop.CtxWithTimeout(client.QueryTimeout)
 	defer cancel()
 
-	price, err := o.GetDAGasPrice(ctx)
+	price, params, err := o.getDAGasPrice(ctx)
 	if err != nil {
 		return err
 	}
@@ -246,6 +238,7 @@ func (o *optimismL1Oracle) refreshWithError() error {
 	o.l1GasPriceMu.Lock()
 	defer o.l1GasPriceMu.Unlock()
 	o.l1GasPrice = priceEntry{price: assets.NewWei(price), timestamp: time.Now()}
+	o.l1FeeParams = params
 	return nil
 }
 
@@ -271,16 +264,55 @@ func (o *optimismL1Oracle) GasPrice(_ context.Context) (l1GasPrice *assets.Wei,
 	return
 }
 
+// GetL1Fee returns the L1 data fee of the RLP encoded, unsigned transaction, like the getL1Fee method of the GasPriceOracle contract.
+// After the Ecotone upgrade, the fee is computed from the cached l1BaseFee and blobBaseFee parameters, and the size of the transaction,
+// which is estimated from its compressed size after the Fjord upgrade. Before the Ecotone upgrade, the calldata gas of the transaction
+// is priced at the cached l1BaseFee, without the overhead and scalar of the contract.
+func (o *optimismL1Oracle) GetL1Fee(_ context.Context, txData []byte) (*assets.Wei, error) {
+	var params *opStackFeeParams
+	var timestamp time.Time
+	ok := o.IfStarted(func() {
+		o.l1GasPriceMu.RLock()
+		params = o.l1FeeParams
+		timestamp = o.l1GasPrice.timestamp
+		o.l1GasPriceMu.RUnlock()
+	})
+	if !ok {
+		return nil, fmt.Errorf("L1GasOracle is not started; cannot estimate l1 fee")
+	}
+	if params == nil {
+		return nil, fmt.Errorf("failed to get l1 fee; fee parameters not set")
+	}
+	if time.Since(timestamp) > o.pollPeriod*2 {
+		return nil, fmt.Errorf("l1 fee parameters are stale")
+	}
+	return assets.NewWei(params.l1Fee(txData)), nil
+}
+
 func (o *optimismL1Oracle) GetDAGasPrice(ctx context.Context) (*big.Int, error) {
+	price, _, err := o.getDAGasPrice(ctx)
+	return price, err
+}
+
+// getDAGasPrice returns the DA gas price, and the fee parameters used to compute the l1 fee of transactions
+func (o *optimismL1Oracle) getDAGasPrice(ctx context.Context) (*big.Int, *opStackFeeParams, error) {
 	err := o.checkForUpgrade(ctx)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if o.isFjord || o.isEcotone {
-		return o.getEcotoneFjordGasPrice(ctx)
+		params, err := o.getEcotoneFjordFeeParams(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		return params.gasPrice(), params, nil
 	}
 
-	return o.getV1GasPrice(ctx)
+	price, err := o.getV1GasPrice(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return price, opStackV1FeeParams(price), nil
 }
 
 // Checks oracle flags for Ecotone and Fjord upgrades
@@ -369,9 +401,9 @@ func (o *optimismL1Oracle) getV1GasPrice(ctx context.Context) (*big.Int, error)
 	return new(big.Int).SetBytes(b), nil
 }
 
-// Returns the scaled gas price using baseFeeScalar, l1BaseFee, blobBaseFeeScalar, and blobBaseFee fields from the oracle
-// Confirmed the same calculation is used to determine gas price for both Ecotone and Fjord
-func (o *optimismL1Oracle) getEcotoneFjordGasPrice(ctx context.Context) (*big.Int, error) {
+// Returns the baseFeeScalar, l1BaseFee, blobBaseFeeScalar, blobBaseFee and decimals fields from the oracle
+// Confirmed the same parameters are used to determine gas price for both Ecotone and Fjord
+func (o *optimismL1Oracle) getEcotoneFjordFeeParams(ctx context.Context) (*opStackFeeParams, error) {
 	rpcBatchCalls := []rpc.BatchElem{
 		{
 			Method: "eth_call",
@@ -440,19 +472,19 @@ func (o *optimismL1Oracle) getEcotoneFjordGasPrice(ctx context.Context) (*big.In
 		return nil, fmt.Errorf("fetch gas price parameters batch call failed: %w", err)
 	}
 	if rpcBatchCalls[0].Error != nil {
-		return nil, fmt.Errorf("%s call failed in a batch: %w", l1BaseFeeMethod, err)
+		return nil, fmt.Errorf("%s call failed in a batch: %w", l1BaseFeeMethod, rpcBatchCalls[0].Error)
 	}
 	if rpcBatchCalls[1].Error != nil {
-		return nil, fmt.Errorf("%s call failed in a batch: %w", baseFeeScalarMethod, err)
+		return nil, fmt.Errorf("%s call failed in a batch: %w", baseFeeScalarMethod, rpcBatchCalls[1].Error)
 	}
 	if rpcBatchCalls[2].Error != nil {
-		return nil, fmt.Errorf("%s call failed in a batch: %w", blobBaseFeeMethod, err)
+		return nil, fmt.Errorf("%s call failed in a batch: %w", blobBaseFeeMethod, rpcBatchCalls[2].Error)
 	}
 	if rpcBatchCalls[3].Error != nil {
-		return nil, fmt.Errorf("%s call failed in a batch: %w", blobBaseFeeScalarMethod, err)
+		return nil, fmt.Errorf("%s call failed in a batch: %w", blobBaseFeeScalarMethod, rpcBatchCalls[3].Error)
 	}
 	if rpcBatchCalls[4].Error != nil {
-		return nil, fmt.Errorf("%s call failed in a batch: %w", decimalsMethod, err)
+		return nil, fmt.Errorf("%s call failed in a batch: %w", decimalsMethod, rpcBatchCalls[4].Error)
 	}
 
 	// Extract values from responses
@@ -493,21 +525,14 @@ func (o *optimismL1Oracle) getEcotoneFjordGasPrice(ctx context.Context) (*big.In
 
 	o.logger.Debugw("gas price parameters", "l1BaseFee", l1BaseFee, "baseFeeScalar", baseFeeScalar, "blobBaseFee", blobBaseFee, "blobBaseFeeScalar", blobBaseFeeScalar, "decimals", decimals)
 
-	// Scaled gas price = baseFee * 16 * baseFeeScalar + blobBaseFee * blobBaseFeeScalar
-	scaledBaseFee := new(big.Int).Mul(l1BaseFee, baseFeeScalar)
-	scaledBaseFee = new(big.Int).Mul(scaledBaseFee, big.NewInt(16))
-	scaledBlobBaseFee := new(big.Int).Mul(blobBaseFee, blobBaseFeeScalar)
-	scaledGasPrice := new(big.Int).Add(scaledBaseFee, scaledBlobBaseFee)
-
-	// Gas price = scaled gas price / (16 * 10 ^ decimals)
-	// This formula is extracted from the gas cost methods in the precompile contract
-	// Note: The Fjord calculation in the contract uses estimated size instead of gas used which is why we have to scale down by (16 * 10 ^ decimals) as well
-	// Ecotone: https://github.com/ethereum-optimism/optimism/blob/71b93116738ee98c9f8713b1a5dfe626ce06c1b2/packages/contracts-bedrock/src/L2/GasPriceOracle.sol#L192
-	// Fjord: https://github.com/ethereum-optimism/optimism/blob/71b93116738ee98c9f8713b1a5dfe626ce06c1b2/packages/contracts-bedrock/src/L2/GasPriceOracle.sol#L229-L230
-	scale := new(big.Int).Exp(big.NewInt(10), decimals, nil)
-	scale = new(big.Int).Mul(scale, big.NewInt(16))
-
-	return new(big.Int).Div(scaledGasPrice, scale), nil
+	return &opStackFeeParams{
+		l1BaseFee:         l1BaseFee,
+		baseFeeScalar:     baseFeeScalar,
+		blobBaseFee:       blobBaseFee,
+		blobBaseFeeScalar: blobBaseFeeScalar,
+		decimals:          decimals,
+		isFjord:           o.isFjord,
+	}, nil
 }
 
 func encodeCalldata(abiString, methodName string) ([]byte, abi.ABI, error) {
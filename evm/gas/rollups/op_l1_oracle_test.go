@@ -16,6 +16,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/services/servicetest"
 	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
 
 	"github.com/smartcontractkit/chainlink/v2/evm/config/chaintype"
@@ -348,3 +349,104 @@ func TestOPL1Oracle_CalculateFjordGasPrice(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestOPL1Oracle_GetL1Fee(t *testing.T) {
+	baseFee := big.NewInt(100000000)
+	blobBaseFee := big.NewInt(25000000)
+	baseFeeScalar := big.NewInt(10)
+	blobBaseFeeScalar := big.NewInt(5)
+	decimals := big.NewInt(6)
+	oracleAddress := utils.RandomAddress().String()
+	txData := []byte{0, 0, 1, 2}
+	t.Parallel()
+
+	t.Run("computes the l1 fee from the calldata gas if chain has upgraded to Ecotone", func(t *testing.T) {
+		ethClient := setupUpgradeCheck(t, oracleAddress, false, true)
+		mockBatchContractCall(t, ethClient, oracleAddress, baseFee, baseFeeScalar, blobBaseFee, blobBaseFeeScalar, decimals)
+
+		daOracle := CreateTestDAOracle(t, toml.DAOracleOPStack, oracleAddress, "")
+		oracle, err := NewOpStackL1GasOracle(logger.Test(t), ethClient, chaintype.ChainOptimismBedrock, daOracle)
+		require.NoError(t, err)
+		servicetest.Run(t, oracle)
+
+		fee, err := oracle.GetL1Fee(tests.Context(t), txData)
+		require.NoError(t, err)
+		l1GasUsed := big.NewInt(2*4 + 2*16 + 68*16) // zero bytes * 4 + non-zero bytes * 16 + signature
+		scaledFee := big.NewInt(16125000000)        // baseFee * scalar * 16 + blobBaseFee * scalar
+		expectedFee := new(big.Int).Div(new(big.Int).Mul(l1GasUsed, scaledFee), big.NewInt(16000000))
+		assert.Equal(t, expectedFee, fee.ToInt())
+	})
+
+	t.Run("computes the l1 fee from the estimated size if chain has upgraded to Fjord", func(t *testing.T) {
+		ethClient := setupUpgradeCheck(t, oracleAddress, true, true)
+		mockBatchContractCall(t, ethClient, oracleAddress, baseFee, baseFeeScalar, blobBaseFee, blobBaseFeeScalar, decimals)
+
+		daOracle := CreateTestDAOracle(t, toml.DAOracleOPStack, oracleAddress, "")
+		oracle, err := NewOpStackL1GasOracle(logger.Test(t), ethClient, chaintype.ChainOptimismBedrock, daOracle)
+		require.NoError(t, err)
+		servicetest.Run(t, oracle)
+
+		// small transactions are charged the minimum size
+		fee, err := oracle.GetL1Fee(tests.Context(t), txData)
+		require.NoError(t, err)
+		scaledFee := big.NewInt(16125000000)
+		expectedFee := new(big.Int).Div(new(big.Int).Mul(big.NewInt(100e6), scaledFee), big.NewInt(1e12))
+		assert.Equal(t, expectedFee, fee.ToInt())
+
+		largeTxData := make([]byte, 2000)
+		for i := range largeTxData {
+			largeTxData[i] = byte(i * 7919 % 251)
+		}
+		fee, err = oracle.GetL1Fee(tests.Context(t), largeTxData)
+		require.NoError(t, err)
+		estimatedSize := big.NewInt(opFjordCostFastLZCoef*int64(flzCompressLen(largeTxData)+opTxSignatureSize) + opFjordCostIntercept)
+		expectedFee = new(big.Int).Div(new(big.Int).Mul(estimatedSize, scaledFee), big.NewInt(1e12))
+		assert.Equal(t, expectedFee, fee.ToInt())
+	})
+
+	t.Run("prices the calldata gas at the cached l1BaseFee if chain has not upgraded to Ecotone", func(t *testing.T) {
+		ethClient := setupUpgradeCheck(t, oracleAddress, false, false)
+		ethClient.On("CallContract", mock.Anything, mock.Anything, mock.Anything).Return(common.BigToHash(baseFee).Bytes(), nil).Once()
+
+		daOracle := CreateTestDAOracle(t, toml.DAOracleOPStack, oracleAddress, "")
+		oracle, err := NewOpStackL1GasOracle(logger.Test(t), ethClient, chaintype.ChainOptimismBedrock, daOracle)
+		require.NoError(t, err)
+		servicetest.Run(t, oracle)
+
+		// no call is made per transaction
+		fee, err := oracle.GetL1Fee(tests.Context(t), txData)
+		require.NoError(t, err)
+		l1GasUsed := big.NewInt(2*4 + 2*16 + 68*16)
+		assert.Equal(t, new(big.Int).Mul(l1GasUsed, baseFee), fee.ToInt())
+
+		price, err := oracle.GasPrice(tests.Context(t))
+		require.NoError(t, err)
+		assert.Equal(t, baseFee, price.ToInt())
+	})
+
+	t.Run("errors if oracle is not started", func(t *testing.T) {
+		daOracle := CreateTestDAOracle(t, toml.DAOracleOPStack, oracleAddress, "")
+		oracle, err := NewOpStackL1GasOracle(logger.Test(t), mocks.NewL1OracleClient(t), chaintype.ChainOptimismBedrock, daOracle)
+		require.NoError(t, err)
+
+		_, err = oracle.GetL1Fee(tests.Context(t), txData)
+		assert.ErrorContains(t, err, "not started")
+	})
+}
+
+func TestFlzCompressLen(t *testing.T) {
+	t.Parallel()
+
+	// short data is stored as literals, with a 1 byte header per 32 bytes
+	assert.Equal(t, uint32(0), flzCompressLen(nil))
+	assert.Equal(t, uint32(11), flzCompressLen(make([]byte, 10)))
+
+	// 1000 zero bytes are 2 literals, a match of 991 bytes split in 4 chunks, and 5 literals
+	assert.Equal(t, uint32(3+4*3+6), flzCompressLen(make([]byte, 1000)))
+
+	incompressible := make([]byte, 64)
+	for i := range incompressible {
+		incompressible[i] = byte(i)
+	}
+	assert.Equal(t, uint32(2*33), flzCompressLen(incompressible))
+}
@@ -45,6 +45,7 @@ type SuggestedPriceEstimator struct {
 
 	gasPriceMu sync.RWMutex
 	GasPrice   *assets.Wei
+	refreshTime
 
 	chForceRefetch chan (chan struct{})
 	chInitialised  chan struct{}
@@ -137,6 +138,7 @@ func (o *SuggestedPriceEstimator) refreshPrice() {
 	o.gasPriceMu.Lock()
 	defer o.gasPriceMu.Unlock()
 	o.GasPrice = bi
+	o.refreshed(time.Now())
 }
 
 // Uses the force refetch chan to trigger a price update and blocks until complete
@@ -3,6 +3,7 @@ package gas_test
 import (
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	pkgerrors "github.com/pkg/errors"
@@ -54,6 +55,18 @@ func TestSuggestedPriceEstimator(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, assets.NewWeiI(42), gasPrice)
 		assert.Equal(t, gasLimit, chainSpecificGasLimit)
+		assert.WithinDuration(t, time.Now(), o.(*gas.SuggestedPriceEstimator).LastRefreshed(), time.Minute)
+	})
+
+	t.Run("failed refresh is not recorded", func(t *testing.T) {
+		feeEstimatorClient := mocks.NewFeeEstimatorClient(t)
+		l1Oracle := rollupMocks.NewL1Oracle(t)
+
+		feeEstimatorClient.On("CallContext", mock.Anything, mock.Anything, "eth_gasPrice").Return(pkgerrors.New("kaboom"))
+
+		o := gas.NewSuggestedPriceEstimator(logger.Test(t), feeEstimatorClient, cfg, l1Oracle)
+		servicetest.RunHealthy(t, o)
+		assert.True(t, o.(*gas.SuggestedPriceEstimator).LastRefreshed().IsZero())
 	})
 
 	t.Run("gas price is lower than user specified max gas price", func(t *testing.T) {
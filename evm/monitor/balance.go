@@ -20,6 +20,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink/v2/evm/assets"
 	evmclient "github.com/smartcontractkit/chainlink/v2/evm/client"
+	"github.com/smartcontractkit/chainlink/v2/evm/config"
 	"github.com/smartcontractkit/chainlink/v2/evm/keystore"
 	evmtypes "github.com/smartcontractkit/chainlink/v2/evm/types"
 )
@@ -44,6 +45,34 @@ type (
 		ethBalances    map[common.Address]*assets.Eth
 		ethBalancesMtx sync.RWMutex
 		sleeperTask    *utils.SleeperTask
+
+		cfg         config.BalanceMonitor
+		linkAddress common.Address
+		notifiers   []Notifier
+		topUpSender TopUpSender
+		now         func() time.Time
+
+		alertsMtx sync.Mutex
+		alerts    map[alertKey]time.Time // last alert of each key and asset below its floor
+
+		topUpsMtx sync.Mutex // serializes top-ups, so that each one is accounted by the next
+	}
+
+	// Opts configures the optional token balances, alerts and top-ups of the BalanceMonitor.
+	Opts struct {
+		// Config enables tracking tokens, alerts and top-ups. Only ETH balances are tracked if it is nil.
+		Config config.BalanceMonitor
+		// LinkAddress is the LINK token contract. LINK balances are tracked if it is set.
+		LinkAddress common.Address
+		// Notifiers overrides the notifiers configured by Config.
+		Notifiers []Notifier
+		// TopUpSender sends the top-ups configured by Config. Top-ups are disabled if it is nil.
+		TopUpSender TopUpSender
+	}
+
+	alertKey struct {
+		address common.Address
+		asset   string
 	}
 
 	NullBalanceMonitor struct{}
@@ -52,7 +81,7 @@ type (
 var _ BalanceMonitor = (*balanceMonitor)(nil)
 
 // NewBalanceMonitor returns a new balanceMonitor
-func NewBalanceMonitor(ethClient evmclient.Client, ethKeyStore keystore.Eth, lggr logger.Logger) *balanceMonitor {
+func NewBalanceMonitor(ethClient evmclient.Client, ethKeyStore keystore.Eth, lggr logger.Logger, opts Opts) *balanceMonitor {
 	chainId := ethClient.ConfiguredChainID()
 	bm := &balanceMonitor{
 		ethClient:   ethClient,
@@ -60,6 +89,15 @@ func NewBalanceMonitor(ethClient evmclient.Client, ethKeyStore keystore.Eth, lgg
 		chainIDStr:  chainId.String(),
 		ethKeyStore: ethKeyStore,
 		ethBalances: make(map[common.Address]*assets.Eth),
+		cfg:         opts.Config,
+		linkAddress: opts.LinkAddress,
+		notifiers:   opts.Notifiers,
+		topUpSender: opts.TopUpSender,
+		now:         time.Now,
+		alerts:      make(map[alertKey]time.Time),
+	}
+	if bm.notifiers == nil && bm.cfg != nil {
+		bm.notifiers = newNotifiers(bm.cfg, lggr)
 	}
 	bm.Service, bm.eng = services.Config{
 		Name:  "BalanceMonitor",
@@ -139,6 +177,19 @@ func (bm *balanceMonitor) promUpdateEthBalance(balance *assets.Eth, from common.
 	promETHBalance.WithLabelValues(from.Hex(), bm.chainIDStr).Set(balanceFloat)
 }
 
+var promTokenBalance = promauto.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "token_balance",
+		Help: "Each account's balance of the tracked tokens, in the smallest unit of the token",
+	},
+	[]string{"account", "evmChainID", "token"},
+)
+
+func (bm *balanceMonitor) promUpdateTokenBalance(balance *big.Int, from common.Address, token common.Address) {
+	balanceFloat, _ := new(big.Float).SetInt(balance).Float64()
+	promTokenBalance.WithLabelValues(from.Hex(), bm.chainIDStr, token.Hex()).Set(balanceFloat)
+}
+
 type worker struct {
 	bm *balanceMonitor
 }
@@ -186,6 +237,41 @@ func (w *worker) checkAccountBalance(ctx context.Context, address common.Address
 	} else {
 		ethBal := assets.Eth(*bal)
 		w.bm.updateBalance(ethBal, address)
+		w.bm.checkEthBalance(ctx, address, &ethBal)
+	}
+
+	w.checkTokenBalances(ctx, address)
+}
+
+func (w *worker) checkTokenBalances(ctx context.Context, address common.Address) {
+	if w.bm.linkAddress != (common.Address{}) {
+		bal, err := w.bm.ethClient.LINKBalance(ctx, address, w.bm.linkAddress)
+		if err != nil {
+			w.bm.eng.Errorw(fmt.Sprintf("BalanceMonitor: error getting LINK balance for key %s", address.Hex()),
+				"err", err,
+				"address", address,
+			)
+		} else if bal != nil {
+			w.bm.promUpdateTokenBalance(bal.ToInt(), address, w.bm.linkAddress)
+			w.bm.checkLinkBalance(ctx, address, bal)
+		}
+	}
+
+	if w.bm.cfg == nil {
+		return
+	}
+	for _, token := range w.bm.cfg.Tokens() {
+		bal, err := w.bm.ethClient.TokenBalance(ctx, address, token)
+		if err != nil {
+			w.bm.eng.Errorw(fmt.Sprintf("BalanceMonitor: error getting balance of token %s for key %s", token.Hex(), address.Hex()),
+				"err", err,
+				"address", address,
+				"token", token,
+			)
+		} else if bal != nil {
+			w.bm.promUpdateTokenBalance(bal, address, token)
+			w.bm.checkTokenBalance(ctx, address, token, bal)
+		}
 	}
 }
 
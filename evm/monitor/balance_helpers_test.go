@@ -1,5 +1,11 @@
 package monitor
 
+import "time"
+
 func (bm *balanceMonitor) WorkDone() <-chan struct{} {
 	return bm.sleeperTask.WorkDone()
 }
+
+func (bm *balanceMonitor) SetNow(now func() time.Time) {
+	bm.now = now
+}
@@ -3,6 +3,7 @@ package monitor_test
 import (
 	"context"
 	"math/big"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -14,15 +15,21 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	commonassets "github.com/smartcontractkit/chainlink-common/pkg/assets"
+	commonconfig "github.com/smartcontractkit/chainlink-common/pkg/config"
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	"github.com/smartcontractkit/chainlink-common/pkg/services/servicetest"
 	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
 
 	"github.com/smartcontractkit/chainlink/v2/evm/assets"
 	"github.com/smartcontractkit/chainlink/v2/evm/client/clienttest"
+	"github.com/smartcontractkit/chainlink/v2/evm/config/configtest"
+	"github.com/smartcontractkit/chainlink/v2/evm/config/toml"
 	ksmocks "github.com/smartcontractkit/chainlink/v2/evm/keystore/mocks"
 	"github.com/smartcontractkit/chainlink/v2/evm/monitor"
 	"github.com/smartcontractkit/chainlink/v2/evm/testutils"
+	"github.com/smartcontractkit/chainlink/v2/evm/types"
+	ubig "github.com/smartcontractkit/chainlink/v2/evm/utils/big"
 )
 
 var nilBigInt *big.Int
@@ -44,7 +51,7 @@ func TestBalanceMonitor_Start(t *testing.T) {
 			Return([]common.Address{k0Addr, k1Addr}, nil)
 		ethClient := newEthClientMock(t)
 
-		bm := monitor.NewBalanceMonitor(ethClient, ethKeyStore, logger.Test(t))
+		bm := monitor.NewBalanceMonitor(ethClient, ethKeyStore, logger.Test(t), monitor.Opts{})
 
 		k0bal := big.NewInt(42)
 		k1bal := big.NewInt(43)
@@ -71,7 +78,7 @@ func TestBalanceMonitor_Start(t *testing.T) {
 			Return([]common.Address{k0Addr}, nil)
 		ethClient := newEthClientMock(t)
 
-		bm := monitor.NewBalanceMonitor(ethClient, ethKeyStore, logger.Test(t))
+		bm := monitor.NewBalanceMonitor(ethClient, ethKeyStore, logger.Test(t), monitor.Opts{})
 		k0bal := big.NewInt(42)
 
 		ethClient.On("BalanceAt", mock.Anything, k0Addr, nilBigInt).Once().Return(k0bal, nil)
@@ -90,7 +97,7 @@ func TestBalanceMonitor_Start(t *testing.T) {
 			Return([]common.Address{k0Addr}, nil)
 		ethClient := newEthClientMock(t)
 
-		bm := monitor.NewBalanceMonitor(ethClient, ethKeyStore, logger.Test(t))
+		bm := monitor.NewBalanceMonitor(ethClient, ethKeyStore, logger.Test(t), monitor.Opts{})
 		ctxCancelledAwaiter := testutils.NewAwaiter()
 
 		ethClient.On("BalanceAt", mock.Anything, k0Addr, nilBigInt).Once().Run(func(args mock.Arguments) {
@@ -119,7 +126,7 @@ func TestBalanceMonitor_Start(t *testing.T) {
 			Return([]common.Address{k0Addr}, nil)
 		ethClient := newEthClientMock(t)
 
-		bm := monitor.NewBalanceMonitor(ethClient, ethKeyStore, logger.Test(t))
+		bm := monitor.NewBalanceMonitor(ethClient, ethKeyStore, logger.Test(t), monitor.Opts{})
 
 		ethClient.On("BalanceAt", mock.Anything, k0Addr, nilBigInt).
 			Once().
@@ -144,7 +151,7 @@ func TestBalanceMonitor_OnNewLongestChain_UpdatesBalance(t *testing.T) {
 			Return([]common.Address{k0Addr, k1Addr}, nil)
 		ethClient := newEthClientMock(t)
 
-		bm := monitor.NewBalanceMonitor(ethClient, ethKeyStore, logger.Test(t))
+		bm := monitor.NewBalanceMonitor(ethClient, ethKeyStore, logger.Test(t), monitor.Opts{})
 		k0bal := big.NewInt(42)
 		// Deliberately larger than a 64 bit unsigned integer to test overflow
 		k1bal := big.NewInt(0)
@@ -193,7 +200,7 @@ func TestBalanceMonitor_FewerRPCCallsWhenBehind(t *testing.T) {
 
 	ethClient := newEthClientMock(t)
 
-	bm := monitor.NewBalanceMonitor(ethClient, ethKeyStore, logger.Test(t))
+	bm := monitor.NewBalanceMonitor(ethClient, ethKeyStore, logger.Test(t), monitor.Opts{})
 	ethClient.On("BalanceAt", mock.Anything, mock.Anything, mock.Anything).
 		Once().
 		Return(big.NewInt(1), nil)
@@ -274,3 +281,246 @@ func callbackOrTimeout(t testing.TB, msg string, callback func()) {
 		t.Fatalf("CallbackOrTimeout: %s timed out", msg)
 	}
 }
+
+type fakeNotifier struct {
+	mu     sync.Mutex
+	alerts []monitor.Alert
+}
+
+func (n *fakeNotifier) Notify(_ context.Context, alert monitor.Alert) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.alerts = append(n.alerts, alert)
+	return nil
+}
+
+func (n *fakeNotifier) Alerts() []monitor.Alert {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]monitor.Alert(nil), n.alerts...)
+}
+
+type topUp struct {
+	from, to common.Address
+	amount   *assets.Wei
+}
+
+type fakeTopUpSender struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	topUps  []topUp
+	sent    []monitor.TopUp
+	pending bool // whether sent top-ups are pending until confirm is called
+}
+
+func (s *fakeTopUpSender) SendTopUp(_ context.Context, from, to common.Address, amount *assets.Wei) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.topUps = append(s.topUps, topUp{from, to, amount})
+	s.sent = append(s.sent, monitor.TopUp{To: to, CreatedAt: s.now(), Pending: s.pending})
+	return nil
+}
+
+func (s *fakeTopUpSender) TopUps(_ context.Context, _ common.Address, since time.Time) ([]monitor.TopUp, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var topUps []monitor.TopUp
+	for _, t := range s.sent {
+		if t.CreatedAt.After(since) {
+			topUps = append(topUps, t)
+		}
+	}
+	return topUps, nil
+}
+
+func (s *fakeTopUpSender) confirm() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.sent {
+		s.sent[i].Pending = false
+	}
+	s.pending = false
+}
+
+func (s *fakeTopUpSender) TopUpsSent() []topUp {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]topUp(nil), s.topUps...)
+}
+
+func TestBalanceMonitor_Alerts(t *testing.T) {
+	t.Parallel()
+
+	ethKeyStore := ksmocks.NewEth(t)
+	k0Addr := testutils.NewAddress()
+	k1Addr := testutils.NewAddress()
+	linkAddr := testutils.NewAddress()
+	ethKeyStore.On("EnabledAddressesForChain", mock.Anything, mock.Anything).
+		Return([]common.Address{k0Addr, k1Addr}, nil)
+	ethClient := newEthClientMock(t)
+
+	cfg := configtest.NewChainScopedConfig(t, func(c *toml.EVMConfig) {
+		c.BalanceMonitor.EthFloor = assets.NewWeiI(100)
+		c.KeySpecific = append(c.KeySpecific, toml.KeySpecific{
+			Key: ptr(types.EIP55AddressFromAddress(k1Addr)),
+			BalanceMonitor: toml.KeySpecificBalanceMonitor{
+				EthFloor:  assets.NewWeiI(10),
+				LinkFloor: commonassets.NewLinkFromJuels(50),
+			},
+		})
+	})
+	notifier := &fakeNotifier{}
+	bm := monitor.NewBalanceMonitor(ethClient, ethKeyStore, logger.Test(t), monitor.Opts{
+		Config:      cfg.EVM().BalanceMonitor(),
+		LinkAddress: linkAddr,
+		Notifiers:   []monitor.Notifier{notifier},
+	})
+
+	// k0 is below the chain floor, k1 is above its key specific ETH floor but below its LINK floor
+	ethClient.On("BalanceAt", mock.Anything, k0Addr, nilBigInt).Once().Return(big.NewInt(42), nil)
+	ethClient.On("BalanceAt", mock.Anything, k1Addr, nilBigInt).Once().Return(big.NewInt(42), nil)
+	ethClient.On("LINKBalance", mock.Anything, k0Addr, linkAddr).Once().Return(commonassets.NewLinkFromJuels(1), nil)
+	ethClient.On("LINKBalance", mock.Anything, k1Addr, linkAddr).Once().Return(commonassets.NewLinkFromJuels(1), nil)
+
+	servicetest.RunHealthy(t, bm)
+
+	alerts := notifier.Alerts()
+	require.Len(t, alerts, 2)
+	byAsset := map[string]monitor.Alert{}
+	for _, a := range alerts {
+		byAsset[a.Asset] = a
+	}
+	assert.Equal(t, k0Addr, byAsset[monitor.AssetETH].Address)
+	assert.Equal(t, k1Addr, byAsset[monitor.AssetLINK].Address)
+	assert.Equal(t, "0", byAsset[monitor.AssetETH].EVMChainID)
+
+	// still below the floor within the alert interval
+	ethClient.On("BalanceAt", mock.Anything, k0Addr, nilBigInt).Once().Return(big.NewInt(42), nil)
+	ethClient.On("BalanceAt", mock.Anything, k1Addr, nilBigInt).Once().Return(big.NewInt(42), nil)
+	ethClient.On("LINKBalance", mock.Anything, k0Addr, linkAddr).Once().Return(commonassets.NewLinkFromJuels(1), nil)
+	ethClient.On("LINKBalance", mock.Anything, k1Addr, linkAddr).Once().Return(commonassets.NewLinkFromJuels(100), nil)
+	bm.OnNewLongestChain(tests.Context(t), testutils.Head(0))
+	<-bm.WorkDone()
+	assert.Len(t, notifier.Alerts(), 2)
+
+	// k1 recovered, so falling below the floor again alerts immediately
+	ethClient.On("BalanceAt", mock.Anything, k0Addr, nilBigInt).Once().Return(big.NewInt(200), nil)
+	ethClient.On("BalanceAt", mock.Anything, k1Addr, nilBigInt).Once().Return(big.NewInt(42), nil)
+	ethClient.On("LINKBalance", mock.Anything, k0Addr, linkAddr).Once().Return(commonassets.NewLinkFromJuels(1), nil)
+	ethClient.On("LINKBalance", mock.Anything, k1Addr, linkAddr).Once().Return(commonassets.NewLinkFromJuels(1), nil)
+	bm.OnNewLongestChain(tests.Context(t), testutils.Head(1))
+	<-bm.WorkDone()
+	alerts = notifier.Alerts()
+	require.Len(t, alerts, 3)
+	assert.Equal(t, k1Addr, alerts[2].Address)
+	assert.Equal(t, monitor.AssetLINK, alerts[2].Asset)
+}
+
+func TestBalanceMonitor_TokenBalances(t *testing.T) {
+	t.Parallel()
+
+	ethKeyStore := ksmocks.NewEth(t)
+	k0Addr := testutils.NewAddress()
+	token := testutils.NewAddress()
+	ethKeyStore.On("EnabledAddressesForChain", mock.Anything, mock.Anything).
+		Return([]common.Address{k0Addr}, nil)
+	ethClient := newEthClientMock(t)
+
+	cfg := configtest.NewChainScopedConfig(t, func(c *toml.EVMConfig) {
+		c.BalanceMonitor.Tokens = []toml.BalanceMonitorToken{{
+			Address: ptr(types.EIP55AddressFromAddress(token)),
+			Floor:   ubig.NewI(10),
+		}}
+	})
+	notifier := &fakeNotifier{}
+	bm := monitor.NewBalanceMonitor(ethClient, ethKeyStore, logger.Test(t), monitor.Opts{
+		Config:    cfg.EVM().BalanceMonitor(),
+		Notifiers: []monitor.Notifier{notifier},
+	})
+
+	ethClient.On("BalanceAt", mock.Anything, k0Addr, nilBigInt).Once().Return(big.NewInt(42), nil)
+	ethClient.On("TokenBalance", mock.Anything, k0Addr, token).Once().Return(big.NewInt(7), nil)
+
+	servicetest.RunHealthy(t, bm)
+
+	alerts := notifier.Alerts()
+	require.Len(t, alerts, 1)
+	assert.Equal(t, k0Addr, alerts[0].Address)
+	assert.Equal(t, token.Hex(), alerts[0].Asset)
+	assert.Equal(t, "7", alerts[0].Balance)
+	assert.Equal(t, "10", alerts[0].Floor)
+}
+
+func TestBalanceMonitor_TopUp(t *testing.T) {
+	t.Parallel()
+
+	ethKeyStore := ksmocks.NewEth(t)
+	fundingAddr := testutils.NewAddress()
+	k0Addr := testutils.NewAddress()
+	ethKeyStore.On("EnabledAddressesForChain", mock.Anything, mock.Anything).
+		Return([]common.Address{fundingAddr, k0Addr}, nil)
+	ethClient := newEthClientMock(t)
+
+	cfg := configtest.NewChainScopedConfig(t, func(c *toml.EVMConfig) {
+		c.BalanceMonitor.TopUp = toml.BalanceMonitorTopUp{
+			Enabled:        ptr(true),
+			FundingAddress: ptr(types.EIP55AddressFromAddress(fundingAddr)),
+			Floor:          assets.NewWeiI(100),
+			Amount:         assets.NewWeiI(1000),
+			MinInterval:    commonconfig.MustNewDuration(time.Hour),
+			MaxPerDay:      ptr[uint32](2),
+		}
+	})
+	now := time.Now()
+	sender := &fakeTopUpSender{now: func() time.Time { return now }, pending: true}
+	opts := monitor.Opts{
+		Config:      cfg.EVM().BalanceMonitor(),
+		TopUpSender: sender,
+	}
+	bm := monitor.NewBalanceMonitor(ethClient, ethKeyStore, logger.Test(t), opts)
+	bm.SetNow(func() time.Time { return now })
+
+	ethClient.On("BalanceAt", mock.Anything, fundingAddr, nilBigInt).Return(big.NewInt(1_000_000), nil)
+	ethClient.On("BalanceAt", mock.Anything, k0Addr, nilBigInt).Return(big.NewInt(42), nil)
+
+	// the funding key's balance is unknown on the first run, so it is attempted
+	servicetest.RunHealthy(t, bm)
+	topUps := sender.TopUpsSent()
+	require.Len(t, topUps, 1)
+	assert.Equal(t, topUp{fundingAddr, k0Addr, assets.NewWeiI(1000)}, topUps[0])
+
+	// the top-up is pending
+	now = now.Add(2 * time.Hour)
+	bm.OnNewLongestChain(tests.Context(t), testutils.Head(0))
+	<-bm.WorkDone()
+	assert.Len(t, sender.TopUpsSent(), 1)
+
+	sender.confirm()
+	bm.OnNewLongestChain(tests.Context(t), testutils.Head(1))
+	<-bm.WorkDone()
+	assert.Len(t, sender.TopUpsSent(), 2)
+
+	// within MinInterval
+	now = now.Add(30 * time.Minute)
+	bm.OnNewLongestChain(tests.Context(t), testutils.Head(2))
+	<-bm.WorkDone()
+	assert.Len(t, sender.TopUpsSent(), 2)
+
+	// MaxPerDay reached, also after a restart
+	now = now.Add(2 * time.Hour)
+	bm.OnNewLongestChain(tests.Context(t), testutils.Head(3))
+	<-bm.WorkDone()
+	assert.Len(t, sender.TopUpsSent(), 2)
+	bm = monitor.NewBalanceMonitor(ethClient, ethKeyStore, logger.Test(t), opts)
+	bm.SetNow(func() time.Time { return now })
+	servicetest.RunHealthy(t, bm)
+	assert.Len(t, sender.TopUpsSent(), 2)
+
+	// a day after the first top-up
+	now = now.Add(20 * time.Hour)
+	bm.OnNewLongestChain(tests.Context(t), testutils.Head(4))
+	<-bm.WorkDone()
+	assert.Len(t, sender.TopUpsSent(), 3)
+}
+
+func ptr[T any](t T) *T { return &t }
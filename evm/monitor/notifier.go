@@ -0,0 +1,216 @@
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	commonassets "github.com/smartcontractkit/chainlink-common/pkg/assets"
+	"github.com/smartcontractkit/chainlink-common/pkg/custmsg"
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	"github.com/smartcontractkit/chainlink/v2/evm/assets"
+	"github.com/smartcontractkit/chainlink/v2/evm/config"
+	"github.com/smartcontractkit/chainlink/v2/evm/config/toml"
+)
+
+// Assets of balance alerts. Alerts of the configured ERC-20 tokens have the address of the token as asset.
+const (
+	AssetETH  = "ETH"
+	AssetLINK = "LINK"
+)
+
+// Alert is raised when the balance of a key falls below its configured floor.
+type Alert struct {
+	EVMChainID string         `json:"evmChainID"`
+	Address    common.Address `json:"address"`
+	Asset      string         `json:"asset"`
+	Balance    string         `json:"balance"`
+	Floor      string         `json:"floor"`
+	Timestamp  time.Time      `json:"timestamp"`
+}
+
+func (a Alert) String() string {
+	return fmt.Sprintf("%s balance of %s on chain %s is %s, below the floor of %s", a.Asset, a.Address.Hex(), a.EVMChainID, a.Balance, a.Floor)
+}
+
+// Notifier delivers balance alerts, e.g. to a log, a webhook or telemetry.
+type Notifier interface {
+	// Notify delivers the alert.
+	Notify(ctx context.Context, alert Alert) error
+}
+
+// newNotifiers returns the notifiers configured by EVM.BalanceMonitor.Notifiers, which were validated with the config.
+func newNotifiers(cfg config.BalanceMonitor, lggr logger.Logger) []Notifier {
+	var notifiers []Notifier
+	for _, n := range cfg.Notifiers() {
+		switch n {
+		case toml.BalanceMonitorNotifierLog:
+			notifiers = append(notifiers, &logNotifier{lggr: logger.Named(lggr, "BalanceAlert")})
+		case toml.BalanceMonitorNotifierWebhook:
+			if u := cfg.WebhookURL(); u != nil {
+				notifiers = append(notifiers, NewWebhookNotifier(u, &http.Client{Timeout: webhookTimeout}))
+			}
+		case toml.BalanceMonitorNotifierTelemetry:
+			notifiers = append(notifiers, &telemetryNotifier{emitter: custmsg.NewLabeler()})
+		}
+	}
+	return notifiers
+}
+
+type logNotifier struct {
+	lggr logger.Logger
+}
+
+func (n *logNotifier) Notify(_ context.Context, alert Alert) error {
+	n.lggr.Warnw("BalanceMonitor: "+alert.String(),
+		"evmChainID", alert.EVMChainID,
+		"address", alert.Address,
+		"asset", alert.Asset,
+		"balance", alert.Balance,
+		"floor", alert.Floor)
+	return nil
+}
+
+const webhookTimeout = 10 * time.Second
+
+type webhookNotifier struct {
+	url    *url.URL
+	client *http.Client
+}
+
+// NewWebhookNotifier returns a Notifier which POSTs alerts as JSON to the URL.
+func NewWebhookNotifier(u *url.URL, client *http.Client) Notifier {
+	return &webhookNotifier{url: u, client: client}
+}
+
+func (n *webhookNotifier) Notify(ctx context.Context, alert Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return pkgerrors.Wrap(err, "failed to encode alert")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url.String(), bytes.NewReader(body))
+	if err != nil {
+		return pkgerrors.Wrap(err, "failed to create webhook request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if pkgerrors.As(err, &urlErr) {
+			// the URL may contain credentials
+			err = urlErr.Err
+		}
+		return pkgerrors.Wrap(err, "failed to send webhook")
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<10))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return pkgerrors.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+type telemetryNotifier struct {
+	emitter custmsg.MessageEmitter
+}
+
+func (n *telemetryNotifier) Notify(ctx context.Context, alert Alert) error {
+	return n.emitter.With(
+		"evmChainID", alert.EVMChainID,
+		"address", alert.Address.Hex(),
+		"asset", alert.Asset,
+		"balance", alert.Balance,
+		"floor", alert.Floor,
+	).Emit(ctx, alert.String())
+}
+
+// checkEthBalance alerts if the ETH balance of the key is below its floor.
+func (bm *balanceMonitor) checkEthBalance(ctx context.Context, address common.Address, balance *assets.Eth) {
+	if bm.cfg == nil {
+		return
+	}
+	bm.maybeTopUp(ctx, address, balance)
+	floor := bm.cfg.EthFloor(address)
+	if floor == nil {
+		return
+	}
+	bm.checkFloor(ctx, address, AssetETH, balance.Cmp((*assets.Eth)(floor.ToInt())) < 0, balance.String(), (*assets.Eth)(floor.ToInt()).String())
+}
+
+// checkLinkBalance alerts if the LINK balance of the key is below its floor.
+func (bm *balanceMonitor) checkLinkBalance(ctx context.Context, address common.Address, balance *commonassets.Link) {
+	if bm.cfg == nil {
+		return
+	}
+	floor := bm.cfg.LinkFloor(address)
+	if floor == nil {
+		return
+	}
+	bm.checkFloor(ctx, address, AssetLINK, balance.Cmp(floor) < 0, balance.String(), floor.String())
+}
+
+// checkTokenBalance alerts if the balance of the ERC-20 token of the key is below its floor.
+func (bm *balanceMonitor) checkTokenBalance(ctx context.Context, address common.Address, token common.Address, balance *big.Int) {
+	if bm.cfg == nil {
+		return
+	}
+	floor := bm.cfg.TokenFloor(token)
+	if floor == nil {
+		return
+	}
+	bm.checkFloor(ctx, address, token.Hex(), balance.Cmp(floor) < 0, balance.String(), floor.String())
+}
+
+// checkFloor notifies an alert while the balance is below the floor, at most once per AlertInterval.
+func (bm *balanceMonitor) checkFloor(ctx context.Context, address common.Address, asset string, below bool, balance, floor string) {
+	key := alertKey{address: address, asset: asset}
+	now := bm.now()
+
+	bm.alertsMtx.Lock()
+	if !below {
+		delete(bm.alerts, key)
+		bm.alertsMtx.Unlock()
+		return
+	}
+	if last, ok := bm.alerts[key]; ok && now.Sub(last) < bm.cfg.AlertInterval() {
+		bm.alertsMtx.Unlock()
+		return
+	}
+	bm.alerts[key] = now
+	bm.alertsMtx.Unlock()
+
+	alert := Alert{
+		EVMChainID: bm.chainIDStr,
+		Address:    address,
+		Asset:      asset,
+		Balance:    balance,
+		Floor:      floor,
+		Timestamp:  now,
+	}
+	promBalanceAlerts.WithLabelValues(bm.chainIDStr, asset).Inc()
+	for _, n := range bm.notifiers {
+		if err := n.Notify(ctx, alert); err != nil {
+			bm.eng.Errorw("BalanceMonitor: failed to notify alert", "err", err, "address", address, "asset", asset)
+		}
+	}
+}
+
+var promBalanceAlerts = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "balance_monitor_alerts",
+		Help: "The number of alerts raised for balances below their floor",
+	},
+	[]string{"evmChainID", "asset"},
+)
@@ -0,0 +1,60 @@
+package monitor_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink/v2/evm/monitor"
+	"github.com/smartcontractkit/chainlink/v2/evm/testutils"
+)
+
+func TestWebhookNotifier(t *testing.T) {
+	t.Parallel()
+
+	alert := monitor.Alert{
+		EVMChainID: "1",
+		Address:    testutils.NewAddress(),
+		Asset:      monitor.AssetETH,
+		Balance:    "0.1",
+		Floor:      "1",
+		Timestamp:  time.Unix(1700000000, 0).UTC(),
+	}
+
+	t.Run("posts alert", func(t *testing.T) {
+		received := make(chan monitor.Alert, 1)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			var a monitor.Alert
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&a))
+			received <- a
+		}))
+		t.Cleanup(srv.Close)
+		u, err := url.Parse(srv.URL)
+		require.NoError(t, err)
+
+		require.NoError(t, monitor.NewWebhookNotifier(u, srv.Client()).Notify(tests.Context(t), alert))
+		assert.Equal(t, alert, <-received)
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		t.Cleanup(srv.Close)
+		u, err := url.Parse(srv.URL)
+		require.NoError(t, err)
+
+		err = monitor.NewWebhookNotifier(u, srv.Client()).Notify(tests.Context(t), alert)
+		require.ErrorContains(t, err, "status 500")
+	})
+}
@@ -0,0 +1,99 @@
+package monitor
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/smartcontractkit/chainlink/v2/evm/assets"
+)
+
+// TopUpSender enqueues a transfer of native tokens, e.g. through the TxManager.
+type TopUpSender interface {
+	SendTopUp(ctx context.Context, from, to common.Address, amount *assets.Wei) error
+	// TopUps returns the top-ups sent from the funding key since the given time, including the ones not confirmed yet.
+	// They are read from where the transfers are stored, so that the limits of top-ups hold across restarts.
+	TopUps(ctx context.Context, from common.Address, since time.Time) ([]TopUp, error)
+}
+
+// TopUp is a top-up sent by a TopUpSender.
+type TopUp struct {
+	To        common.Address
+	CreatedAt time.Time
+	// Pending is true until the transfer is confirmed on chain.
+	Pending bool
+}
+
+var promTopUps = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "balance_monitor_top_ups",
+		Help: "The number of top-ups sent from the funding key to keys below the floor",
+	},
+	[]string{"evmChainID"},
+)
+
+// maybeTopUp sends a top-up from the funding key if the ETH balance of the key is below the floor, unless a top-up of
+// the key is pending, subject to MinInterval per key and MaxPerDay for the chain.
+func (bm *balanceMonitor) maybeTopUp(ctx context.Context, address common.Address, balance *assets.Eth) {
+	if bm.topUpSender == nil {
+		return
+	}
+	topUp := bm.cfg.TopUp()
+	if !topUp.Enabled() || address == topUp.FundingAddress() {
+		return
+	}
+	if balance.ToInt().Cmp(topUp.Floor().ToInt()) >= 0 {
+		return
+	}
+	lggr := bm.eng.With("address", address, "balance", balance, "floor", topUp.Floor(), "amount", topUp.Amount())
+
+	bm.topUpsMtx.Lock()
+	defer bm.topUpsMtx.Unlock()
+
+	funding := topUp.FundingAddress()
+	now := bm.now()
+	since := now.Add(-24 * time.Hour)
+	if minInterval := topUp.MinInterval(); minInterval > 24*time.Hour {
+		since = now.Add(-minInterval)
+	}
+	topUps, err := bm.topUpSender.TopUps(ctx, funding, since)
+	if err != nil {
+		lggr.Errorw("BalanceMonitor: skipping top-up, failed to get previous top-ups", "err", err, "fundingAddress", funding)
+		return
+	}
+	// only the top-ups of the last day count against MaxPerDay
+	var lastDay int
+	for _, t := range topUps {
+		if t.To == address {
+			if t.Pending {
+				lggr.Debugw("BalanceMonitor: skipping top-up, a top-up of the key is pending", "pendingTopUp", t.CreatedAt)
+				return
+			}
+			if now.Sub(t.CreatedAt) < topUp.MinInterval() {
+				lggr.Debugw("BalanceMonitor: skipping top-up, key was topped up recently", "lastTopUp", t.CreatedAt)
+				return
+			}
+		}
+		if now.Sub(t.CreatedAt) < 24*time.Hour {
+			lastDay++
+		}
+	}
+	if maxPerDay := topUp.MaxPerDay(); maxPerDay > 0 && lastDay >= int(maxPerDay) {
+		lggr.Warnw("BalanceMonitor: skipping top-up, MaxPerDay reached", "maxPerDay", maxPerDay)
+		return
+	}
+	if fundingBal := bm.GetEthBalance(funding); fundingBal != nil && fundingBal.ToInt().Cmp(topUp.Amount().ToInt()) < 0 {
+		lggr.Warnw("BalanceMonitor: skipping top-up, insufficient balance of funding key", "fundingAddress", funding, "fundingBalance", fundingBal)
+		return
+	}
+
+	if err := bm.topUpSender.SendTopUp(ctx, funding, address, topUp.Amount()); err != nil {
+		lggr.Errorw("BalanceMonitor: failed to send top-up", "err", err, "fundingAddress", funding)
+		return
+	}
+	promTopUps.WithLabelValues(bm.chainIDStr).Inc()
+	lggr.Infow("BalanceMonitor: sent top-up", "fundingAddress", funding)
+}